@@ -18,13 +18,13 @@ func NewAuthMysqlRepository(conn *sql.DB) domain.AuthRepository {
 }
 
 func (r *authMysqlRepository) GetByLogin(ctx context.Context, login string) (*domain.Auth, error) {
-	query := `SELECT id, uuid, login, password FROM auth WHERE login = ?;`
+	query := `SELECT id, uuid, login, password, role FROM auth WHERE login = ?;`
 
 	row := r.Conn.QueryRowContext(ctx, query, login)
 
 	var res domain.Auth
 
-	if err := row.Scan(&res.ID, &res.UUID, &res.Login, &res.Password); err != nil {
+	if err := row.Scan(&res.ID, &res.UUID, &res.Login, &res.Password, &res.Role); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
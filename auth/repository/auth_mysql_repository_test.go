@@ -18,9 +18,9 @@ func TestGetByLoginNotFound(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "uuid", "login", "password"})
+	rows := sqlmock.NewRows([]string{"id", "uuid", "login", "password", "role"})
 
-	query := regexp.QuoteMeta("SELECT id, uuid, login, password FROM auth WHERE login = ?;")
+	query := regexp.QuoteMeta("SELECT id, uuid, login, password, role FROM auth WHERE login = ?;")
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
@@ -43,7 +43,7 @@ func TestGetByLoginError(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	query := regexp.QuoteMeta("SELECT id, uuid, login, password FROM auth WHERE login = ?;")
+	query := regexp.QuoteMeta("SELECT id, uuid, login, password, role FROM auth WHERE login = ?;")
 
 	mock.ExpectQuery(query).WillReturnError(errors.New("error message"))
 
@@ -65,9 +65,9 @@ func TestGetByLogin(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "uuid", "login", "password"}).AddRow(1, "uuid", "login", "password")
+	rows := sqlmock.NewRows([]string{"id", "uuid", "login", "password", "role"}).AddRow(1, "uuid", "login", "password", "admin")
 
-	query := regexp.QuoteMeta("SELECT id, uuid, login, password FROM auth WHERE login = ?;")
+	query := regexp.QuoteMeta("SELECT id, uuid, login, password, role FROM auth WHERE login = ?;")
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
@@ -80,6 +80,7 @@ func TestGetByLogin(t *testing.T) {
 	assert.Equal(t, "uuid", auth.UUID)
 	assert.Equal(t, "login", auth.Login)
 	assert.Equal(t, "password", auth.Password)
+	assert.Equal(t, "admin", auth.Role)
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Error(err)
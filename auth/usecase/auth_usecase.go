@@ -45,6 +45,7 @@ func (au *authUseCase) Login(ctx context.Context, a *domain.Auth) (domain.Token,
 	var tokenInfo domain.TokenInfo
 
 	tokenInfo.Info = a.Login
+	tokenInfo.Role = auth.Role
 
 	var thirtyDaysInMinutes int64 = 43200
 
@@ -127,6 +128,8 @@ func (au *authUseCase) ForgotPassCode(ctx context.Context, login string) error {
 	messageConf.Medium = "phone"
 	messageConf.To = user.PhoneNumber
 	messageConf.Message = message
+	messageConf.Category = domain.NotificationCategorySecurity
+	messageConf.User = user
 
 	if errMessage := au.messageService.SendMessage(ctx, &messageConf); errMessage != nil {
 		return errMessage
@@ -161,6 +164,7 @@ func (au *authUseCase) ForgotPassReset(ctx context.Context, code *domain.Code, n
 	var tokenInfo domain.TokenInfo
 
 	tokenInfo.Info = code.Identifier
+	tokenInfo.Role = auth.Role
 
 	var thirtyDaysInMinutes int64 = 43200
 
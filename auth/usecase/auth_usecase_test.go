@@ -327,6 +327,8 @@ func TestForgotPassCodeSendMessageError(t *testing.T) {
 	messageConf.Medium = "phone"
 	messageConf.To = "user phone number"
 	messageConf.Message = "O código para recuperar sua senha é generated code"
+	messageConf.Category = domain.NotificationCategorySecurity
+	messageConf.User = &domain.User{ID: 1, UUID: "uuid", Email: "user email", FirstName: "user first name", LastName: "user last name", PhoneNumber: "user phone number", Address: domain.UserAddress{City: "user address city", State: "user address state", Neighborhood: "user address neighborhood", Street: "user address street", Number: "user address number", ZipCode: "user address zipcode"}}
 
 	mockMessageService.On("SendMessage", mock.Anything, &messageConf).Return(errors.New("error message"))
 
@@ -353,6 +355,8 @@ func TestForgotPassCodeSuccess(t *testing.T) {
 	messageConf.Medium = "phone"
 	messageConf.To = "user phone number"
 	messageConf.Message = "O código para recuperar sua senha é generated code"
+	messageConf.Category = domain.NotificationCategorySecurity
+	messageConf.User = &domain.User{ID: 1, UUID: "uuid", Email: "user email", FirstName: "user first name", LastName: "user last name", PhoneNumber: "user phone number", Address: domain.UserAddress{City: "user address city", State: "user address state", Neighborhood: "user address neighborhood", Street: "user address street", Number: "user address number", ZipCode: "user address zipcode"}}
 
 	mockMessageService.On("SendMessage", mock.Anything, &messageConf).Return(nil)
 
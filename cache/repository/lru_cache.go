@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// lruCache keeps up to size values in memory, dropping the least recently
+// used when it is full. It is not shared between instances, so each one may
+// serve what another already changed until the ttl ends.
+type lruCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+	now     func() time.Time
+}
+
+func NewLRUCache(size int) domain.Cache {
+	return &lruCache{size: size, entries: map[string]*list.Element{}, order: list.New(), now: time.Now}
+}
+
+func (lc *lruCache) Get(ctx context.Context, key string) ([]byte, error) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	element, ok := lc.entries[key]
+
+	if !ok {
+		return nil, nil
+	}
+
+	entry := element.Value.(*lruEntry)
+
+	if !lc.now().Before(entry.expiresAt) {
+		lc.remove(element)
+		return nil, nil
+	}
+
+	lc.order.MoveToFront(element)
+
+	return entry.value, nil
+}
+
+func (lc *lruCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	// the caller may change its slice later
+	stored := make([]byte, len(value))
+	copy(stored, value)
+
+	entry := &lruEntry{key: key, value: stored, expiresAt: lc.now().Add(ttl)}
+
+	if element, ok := lc.entries[key]; ok {
+		element.Value = entry
+		lc.order.MoveToFront(element)
+		return nil
+	}
+
+	lc.entries[key] = lc.order.PushFront(entry)
+
+	for lc.order.Len() > lc.size {
+		lc.remove(lc.order.Back())
+	}
+
+	return nil
+}
+
+func (lc *lruCache) Delete(ctx context.Context, key string) error {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if element, ok := lc.entries[key]; ok {
+		lc.remove(element)
+	}
+
+	return nil
+}
+
+func (lc *lruCache) remove(element *list.Element) {
+	lc.order.Remove(element)
+	delete(lc.entries, element.Value.(*lruEntry).key)
+}
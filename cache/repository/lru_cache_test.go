@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUCacheGetMissing(t *testing.T) {
+	value, err := NewLRUCache(2).Get(context.Background(), "key")
+
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestLRUCacheEvictsTheLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUCache(2)
+
+	assert.NoError(t, cache.Set(ctx, "a", []byte("1"), time.Minute))
+	assert.NoError(t, cache.Set(ctx, "b", []byte("2"), time.Minute))
+
+	// a is used, so b is the one to go
+	value, _ := cache.Get(ctx, "a")
+	assert.Equal(t, []byte("1"), value)
+
+	assert.NoError(t, cache.Set(ctx, "c", []byte("3"), time.Minute))
+
+	value, _ = cache.Get(ctx, "b")
+	assert.Nil(t, value)
+
+	value, _ = cache.Get(ctx, "a")
+	assert.Equal(t, []byte("1"), value)
+
+	value, _ = cache.Get(ctx, "c")
+	assert.Equal(t, []byte("3"), value)
+}
+
+func TestLRUCacheExpires(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUCache(2).(*lruCache)
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	assert.NoError(t, cache.Set(ctx, "a", []byte("1"), time.Minute))
+
+	now = now.Add(time.Minute)
+
+	value, err := cache.Get(ctx, "a")
+
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+	assert.Empty(t, cache.entries)
+}
+
+func TestLRUCacheSetReplaces(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUCache(2)
+
+	data := []byte("1")
+
+	assert.NoError(t, cache.Set(ctx, "a", data, time.Minute))
+	assert.NoError(t, cache.Set(ctx, "a", []byte("2"), time.Minute))
+
+	data[0] = 'x'
+
+	value, _ := cache.Get(ctx, "a")
+	assert.Equal(t, []byte("2"), value)
+}
+
+func TestLRUCacheDelete(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUCache(2)
+
+	assert.NoError(t, cache.Set(ctx, "a", []byte("1"), time.Minute))
+	assert.NoError(t, cache.Delete(ctx, "a"))
+	assert.NoError(t, cache.Delete(ctx, "missing"))
+
+	value, _ := cache.Get(ctx, "a")
+	assert.Nil(t, value)
+}
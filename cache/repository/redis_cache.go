@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+// redisTimeout bounds each command when the context has no deadline, a slow
+// cache must not be slower than the database it saves.
+const redisTimeout = time.Second
+
+type redisError string
+
+func (re redisError) Error() string {
+	return "redis: " + string(re)
+}
+
+// redisCache speaks the RESP protocol of Redis, and of the servers
+// compatible with it, over a single connection, the commands wait for each
+// other. The connection is opened again after any error.
+type redisCache struct {
+	address  string
+	password string
+	db       int
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func NewRedisCache(address string, password string, db int) domain.Cache {
+	return &redisCache{address: address, password: password, db: db}
+}
+
+func (rc *redisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	reply, err := rc.do(ctx, "GET", key)
+
+	if err != nil || reply == nil {
+		return nil, err
+	}
+
+	value, ok := reply.([]byte)
+
+	if !ok {
+		return nil, fmt.Errorf("redis: unexpected reply to GET: %v", reply)
+	}
+
+	return value, nil
+}
+
+func (rc *redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := rc.do(ctx, "SET", key, string(value), "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+
+	return err
+}
+
+func (rc *redisCache) Delete(ctx context.Context, key string) error {
+	_, err := rc.do(ctx, "DEL", key)
+
+	return err
+}
+
+func (rc *redisCache) do(ctx context.Context, args ...string) (interface{}, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	deadline, ok := ctx.Deadline()
+
+	if !ok {
+		deadline = time.Now().Add(redisTimeout)
+	}
+
+	if rc.conn == nil {
+		if err := rc.connect(ctx, deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	reply, err := rc.command(deadline, args...)
+
+	var replyErr redisError
+
+	// an error reply leaves the connection in a good state
+	if err != nil && !errors.As(err, &replyErr) {
+		rc.conn.Close()
+		rc.conn = nil
+	}
+
+	return reply, err
+}
+
+func (rc *redisCache) connect(ctx context.Context, deadline time.Time) error {
+	dialer := net.Dialer{Deadline: deadline}
+
+	conn, err := dialer.DialContext(ctx, "tcp", rc.address)
+
+	if err != nil {
+		return err
+	}
+
+	rc.conn = conn
+	rc.reader = bufio.NewReader(conn)
+
+	if rc.password != "" {
+		if _, err := rc.command(deadline, "AUTH", rc.password); err != nil {
+			rc.conn.Close()
+			rc.conn = nil
+			return err
+		}
+	}
+
+	if rc.db != 0 {
+		if _, err := rc.command(deadline, "SELECT", strconv.Itoa(rc.db)); err != nil {
+			rc.conn.Close()
+			rc.conn = nil
+			return err
+		}
+	}
+
+	return nil
+}
+
+// command sends the args as an array of bulk strings and reads the reply.
+func (rc *redisCache) command(deadline time.Time, args ...string) (interface{}, error) {
+	if err := rc.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	buf := []byte("*" + strconv.Itoa(len(args)) + "\r\n")
+
+	for _, arg := range args {
+		buf = append(buf, "$"+strconv.Itoa(len(arg))+"\r\n"...)
+		buf = append(buf, arg...)
+		buf = append(buf, "\r\n"...)
+	}
+
+	if _, err := rc.conn.Write(buf); err != nil {
+		return nil, err
+	}
+
+	return readReply(rc.reader)
+}
+
+// readReply gives a string for a simple string, an int64 for an integer,
+// a []byte for a bulk string, an []interface{} for an array and nil for the
+// null bulk string and array.
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply %q", line)
+	}
+
+	kind, content := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return content, nil
+	case '-':
+		return nil, redisError(content)
+	case ':':
+		return strconv.ParseInt(content, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(content)
+
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed bulk length %q", content)
+		}
+
+		if n < 0 {
+			return nil, nil
+		}
+
+		data := make([]byte, n+2)
+
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+
+		return data[:n], nil
+	case '*':
+		n, err := strconv.Atoi(content)
+
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed array length %q", content)
+		}
+
+		if n < 0 {
+			return nil, nil
+		}
+
+		items := make([]interface{}, n)
+
+		for i := range items {
+			if items[i], err = readReply(r); err != nil {
+				// the rest of the array is left unread, so it is not a
+				// redisError, which would keep the connection
+				return nil, fmt.Errorf("redis: item %d of array: %s", i, err.Error())
+			}
+		}
+
+		return items, nil
+	}
+
+	return nil, fmt.Errorf("redis: unknown reply %q", line)
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRedis answers each command it receives with the next reply, and
+// gives the commands received on the returned channel.
+func fakeRedis(t *testing.T, replies ...string) (string, chan []string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	commands := make(chan []string, len(replies))
+
+	go func() {
+		defer listener.Close()
+
+		conn, err := listener.Accept()
+
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+
+		for _, reply := range replies {
+			command, err := readReply(reader)
+
+			if err != nil {
+				return
+			}
+
+			args := []string{}
+			for _, arg := range command.([]interface{}) {
+				args = append(args, string(arg.([]byte)))
+			}
+
+			commands <- args
+
+			conn.Write([]byte(reply))
+		}
+	}()
+
+	return listener.Addr().String(), commands
+}
+
+func TestRedisCacheGet(t *testing.T) {
+	address, commands := fakeRedis(t, "+OK\r\n", "+OK\r\n", "$5\r\nvalue\r\n", "$-1\r\n")
+
+	cache := NewRedisCache(address, "secret", 2)
+
+	value, err := cache.Get(context.Background(), "product:uuid")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	assert.Equal(t, []string{"AUTH", "secret"}, <-commands)
+	assert.Equal(t, []string{"SELECT", "2"}, <-commands)
+	assert.Equal(t, []string{"GET", "product:uuid"}, <-commands)
+
+	value, err = cache.Get(context.Background(), "product:missing")
+
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestRedisCacheSetAndDelete(t *testing.T) {
+	address, commands := fakeRedis(t, "+OK\r\n", ":1\r\n")
+
+	cache := NewRedisCache(address, "", 0)
+
+	err := cache.Set(context.Background(), "product:uuid", []byte("line\r\nbreak"), 90*time.Second)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"SET", "product:uuid", "line\r\nbreak", "PX", "90000"}, <-commands)
+
+	err = cache.Delete(context.Background(), "product:uuid")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"DEL", "product:uuid"}, <-commands)
+}
+
+func TestRedisCacheErrorReply(t *testing.T) {
+	address, _ := fakeRedis(t, "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n", "$1\r\nv\r\n")
+
+	cache := NewRedisCache(address, "", 0).(*redisCache)
+
+	_, err := cache.Get(context.Background(), "key")
+
+	assert.EqualError(t, err, "redis: WRONGTYPE Operation against a key holding the wrong kind of value")
+	assert.NotNil(t, cache.conn)
+
+	value, err := cache.Get(context.Background(), "key")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v"), value)
+}
+
+func TestRedisCacheReconnects(t *testing.T) {
+	address, _ := fakeRedis(t)
+
+	cache := NewRedisCache(address, "", 0).(*redisCache)
+
+	// the server closes the connection without answering
+	_, err := cache.Get(context.Background(), "key")
+
+	assert.Error(t, err)
+	assert.Nil(t, cache.conn)
+}
+
+func TestRedisCacheUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	_, err = NewRedisCache(address, "", 0).Get(context.Background(), "key")
+
+	assert.Error(t, err)
+}
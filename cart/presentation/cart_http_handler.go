@@ -0,0 +1,190 @@
+package presentation
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+// cartCookie keeps the token of the guest cart, for as long as the cart is
+// kept without changes.
+const (
+	cartCookie       = "cart"
+	cartCookieMaxAge = 7 * 24 * time.Hour
+)
+
+type cartHandler struct {
+	CartUseCase       domain.CartUseCase
+	CartItemValidator domain.CartItemValidator
+}
+
+type cartItemRequest struct {
+	ProductUUID string `json:"productUuid"`
+	SKU         string `json:"sku"`
+	Quantity    int64  `json:"quantity"`
+}
+
+type cartQuantityRequest struct {
+	Quantity int64 `json:"quantity"`
+}
+
+func NewCartHandler(e *echo.Echo, cuc domain.CartUseCase, civ domain.CartItemValidator, ts domain.TokenService) *cartHandler {
+	handler := &cartHandler{
+		CartUseCase:       cuc,
+		CartItemValidator: civ,
+	}
+
+	optionalAuth := _tokenPresentation.NewOptionalAuthMiddleware(ts)
+
+	e.GET("/cart", handler.Get, optionalAuth)
+	e.POST("/cart/items", handler.AddItem, optionalAuth)
+	e.PUT("/cart/items/:sku", handler.SetQuantity, optionalAuth)
+	e.DELETE("/cart/items/:sku", handler.RemoveItem, optionalAuth)
+
+	return handler
+}
+
+func (ch *cartHandler) Get(c echo.Context) error {
+	login, token := cartOwner(c)
+
+	cart, err := ch.CartUseCase.Get(c.Request().Context(), login, token)
+
+	return cartResponse(c, login, token, cart, err)
+}
+
+func (ch *cartHandler) AddItem(c echo.Context) error {
+	var req cartItemRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	if req.ProductUUID == "" {
+		return c.JSON(http.StatusBadRequest, "item's productUuid can not be empty")
+	}
+
+	item := domain.CartItem{ProductUUID: req.ProductUUID, SKU: req.SKU, Quantity: req.Quantity}
+
+	ctx := c.Request().Context()
+
+	isValid, message := ch.CartItemValidator.Validate(ctx, &item)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	login, token := cartOwner(c)
+
+	cart, err := ch.CartUseCase.AddItem(ctx, login, token, &item)
+
+	return cartResponse(c, login, token, cart, err)
+}
+
+func (ch *cartHandler) SetQuantity(c echo.Context) error {
+	var req cartQuantityRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	item := domain.CartItem{SKU: c.Param("sku"), Quantity: req.Quantity}
+
+	ctx := c.Request().Context()
+
+	isValid, message := ch.CartItemValidator.Validate(ctx, &item)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	login, token := cartOwner(c)
+
+	cart, err := ch.CartUseCase.SetQuantity(ctx, login, token, item.SKU, item.Quantity)
+
+	return cartResponse(c, login, token, cart, err)
+}
+
+func (ch *cartHandler) RemoveItem(c echo.Context) error {
+	sku := c.Param("sku")
+
+	if sku == "" {
+		return c.JSON(http.StatusBadRequest, "sku param is not valid")
+	}
+
+	login, token := cartOwner(c)
+
+	cart, err := ch.CartUseCase.RemoveItem(c.Request().Context(), login, token, sku)
+
+	return cartResponse(c, login, token, cart, err)
+}
+
+func cartOwner(c echo.Context) (string, string) {
+	var login, token string
+
+	if tokenInfo := _tokenPresentation.TokenInfoFromContext(c); tokenInfo != nil {
+		login = tokenInfo.Info
+	}
+
+	if cookie, err := c.Cookie(cartCookie); err == nil {
+		token = cookie.Value
+	}
+
+	return login, token
+}
+
+// cartResponse renews the cookie of the guest cart on every answer, and drops
+// it once the guest cart was merged into the cart of the user.
+func cartResponse(c echo.Context, login string, token string, cart *domain.Cart, err error) error {
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, "user not found")
+	}
+
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	if errors.Is(err, domain.ErrVariantNotFound) {
+		return c.JSON(http.StatusNotFound, "variant not found")
+	}
+
+	if errors.Is(err, domain.ErrCartItemNotFound) {
+		return c.JSON(http.StatusNotFound, "item not found in the cart")
+	}
+
+	if errors.Is(err, domain.ErrCartFull) {
+		return c.JSON(http.StatusConflict, fmt.Sprintf("the cart can not have more than %d items", domain.MaxCartItems))
+	}
+
+	if errors.Is(err, domain.ErrQuantityLimit) {
+		return c.JSON(http.StatusConflict, fmt.Sprintf("the cart can not have more than %d units of an item", domain.MaxCartItemQuantity))
+	}
+
+	if errors.Is(err, domain.ErrInsufficientStock) {
+		return c.JSON(http.StatusConflict, "insufficient stock")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to handle the cart: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to handle the cart")
+	}
+
+	cookie := &http.Cookie{Name: cartCookie, Path: "/", HttpOnly: true, Secure: c.Scheme() == "https", SameSite: http.SameSiteLaxMode}
+
+	switch {
+	case login != "" && token != "":
+		cookie.MaxAge = -1
+		c.SetCookie(cookie)
+	case login == "" && cart.Token != "":
+		cookie.Value = cart.Token
+		cookie.MaxAge = int(cartCookieMaxAge.Seconds())
+		c.SetCookie(cookie)
+	}
+
+	return c.JSON(http.StatusOK, cart)
+}
@@ -0,0 +1,188 @@
+package presentation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetGuestCart(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/cart", nil)
+	assert.NoError(t, err)
+	req.AddCookie(&http.Cookie{Name: "cart", Value: "tk"})
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockCartUseCase := new(mocks.MockCartUseCase)
+
+	mockCartUseCase.On("Get", mock.Anything, "", "tk").Return(&domain.Cart{ID: 4, Token: "tk", Items: []domain.CartItem{}, Subtotal: domain.Money{Currency: domain.DefaultCurrency}}, nil)
+
+	handler := NewCartHandler(echo.New(), mockCartUseCase, nil, nil)
+
+	handler.Get(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"items\":[],\"subtotal\":{\"amount\":0,\"currency\":\"BRL\"}}\n", rec.Body.String())
+	assert.Contains(t, rec.Header().Get("Set-Cookie"), "cart=tk")
+	assert.Contains(t, rec.Header().Get("Set-Cookie"), "HttpOnly")
+}
+
+func TestGetUserCartDropsGuestCookie(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/cart", nil)
+	assert.NoError(t, err)
+	req.AddCookie(&http.Cookie{Name: "cart", Value: "tk"})
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "ana@test.com"})
+
+	mockCartUseCase := new(mocks.MockCartUseCase)
+
+	mockCartUseCase.On("Get", mock.Anything, "ana@test.com", "tk").Return(&domain.Cart{ID: 3, UserID: 7, Items: []domain.CartItem{}}, nil)
+
+	handler := NewCartHandler(echo.New(), mockCartUseCase, nil, nil)
+
+	handler.Get(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Set-Cookie"), "Max-Age=0")
+}
+
+func TestAddItemWrongBody(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/cart/items", strings.NewReader("invalidbody"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewCartHandler(echo.New(), nil, nil, nil)
+
+	handler.AddItem(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestAddItemInvalid(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/cart/items", strings.NewReader("{\"productUuid\":\"p1\",\"sku\":\"P1-M\",\"quantity\":0}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockValidator := new(mocks.MockCartItemValidator)
+
+	mockValidator.On("Validate", mock.Anything, &domain.CartItem{ProductUUID: "p1", SKU: "P1-M"}).Return(false, "item's quantity must be between 1 and 10")
+
+	handler := NewCartHandler(echo.New(), nil, mockValidator, nil)
+
+	handler.AddItem(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "\"item's quantity must be between 1 and 10\"\n", rec.Body.String())
+}
+
+func TestAddItemInsufficientStock(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/cart/items", strings.NewReader("{\"productUuid\":\"p1\",\"sku\":\"P1-M\",\"quantity\":3}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockCartUseCase := new(mocks.MockCartUseCase)
+	mockValidator := new(mocks.MockCartItemValidator)
+
+	mockValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockCartUseCase.On("AddItem", mock.Anything, "", "", &domain.CartItem{ProductUUID: "p1", SKU: "P1-M", Quantity: 3}).Return(nil, domain.ErrInsufficientStock)
+
+	handler := NewCartHandler(echo.New(), mockCartUseCase, mockValidator, nil)
+
+	handler.AddItem(c)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestAddItemNewGuestCart(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/cart/items", strings.NewReader("{\"productUuid\":\"p1\",\"sku\":\"P1-M\",\"quantity\":1}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockCartUseCase := new(mocks.MockCartUseCase)
+	mockValidator := new(mocks.MockCartItemValidator)
+
+	mockValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockCartUseCase.On("AddItem", mock.Anything, "", "", mock.Anything).Return(&domain.Cart{ID: 4, Token: "newtk", Items: []domain.CartItem{{ProductUUID: "p1", SKU: "P1-M", Quantity: 1}}}, nil)
+
+	handler := NewCartHandler(echo.New(), mockCartUseCase, mockValidator, nil)
+
+	handler.AddItem(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Set-Cookie"), "cart=newtk")
+}
+
+func TestSetQuantity(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/cart/items/P1-M", strings.NewReader("{\"quantity\":2}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("sku")
+	c.SetParamValues("P1-M")
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "ana@test.com"})
+
+	mockCartUseCase := new(mocks.MockCartUseCase)
+	mockValidator := new(mocks.MockCartItemValidator)
+
+	mockValidator.On("Validate", mock.Anything, &domain.CartItem{SKU: "P1-M", Quantity: 2}).Return(true, "")
+	mockCartUseCase.On("SetQuantity", mock.Anything, "ana@test.com", "", "P1-M", int64(2)).Return(&domain.Cart{ID: 3, UserID: 7, Items: []domain.CartItem{}}, nil)
+
+	handler := NewCartHandler(echo.New(), mockCartUseCase, mockValidator, nil)
+
+	handler.SetQuantity(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Set-Cookie"))
+}
+
+func TestRemoveItemNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/cart/items/P1-M", nil)
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("sku")
+	c.SetParamValues("P1-M")
+
+	mockCartUseCase := new(mocks.MockCartUseCase)
+
+	mockCartUseCase.On("RemoveItem", mock.Anything, "", "", "P1-M").Return(nil, domain.ErrCartItemNotFound)
+
+	handler := NewCartHandler(echo.New(), mockCartUseCase, nil, nil)
+
+	handler.RemoveItem(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
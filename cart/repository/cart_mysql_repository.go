@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type cartMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewCartMysqlRepository(conn *sql.DB) domain.CartRepository {
+	return &cartMysqlRepository{Conn: conn}
+}
+
+func (cmr *cartMysqlRepository) GetByUserID(ctx context.Context, userID int64) (*domain.Cart, error) {
+	return cmr.get(ctx, `SELECT id, user_id, token FROM cart WHERE user_id = ?;`, userID)
+}
+
+func (cmr *cartMysqlRepository) GetByToken(ctx context.Context, token string) (*domain.Cart, error) {
+	return cmr.get(ctx, `SELECT id, user_id, token FROM cart WHERE token = ?;`, token)
+}
+
+func (cmr *cartMysqlRepository) get(ctx context.Context, query string, arg interface{}) (*domain.Cart, error) {
+	var c domain.Cart
+	var userID sql.NullInt64
+	var token sql.NullString
+
+	err := cmr.Conn.QueryRowContext(ctx, query, arg).Scan(&c.ID, &userID, &token)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.UserID, c.Token = userID.Int64, token.String
+
+	rows, err := cmr.Conn.QueryContext(ctx, `SELECT product_uuid, sku, quantity FROM cart_item WHERE cart_id = ? ORDER BY id;`, c.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	c.Items = []domain.CartItem{}
+
+	for rows.Next() {
+		var item domain.CartItem
+
+		if err := rows.Scan(&item.ProductUUID, &item.SKU, &item.Quantity); err != nil {
+			return nil, err
+		}
+
+		c.Items = append(c.Items, item)
+	}
+
+	return &c, rows.Err()
+}
+
+// Store gives back the cart the user already has when two requests create
+// it at the same time.
+func (cmr *cartMysqlRepository) Store(ctx context.Context, c *domain.Cart) error {
+	var userID, token interface{}
+
+	if c.UserID != 0 {
+		userID = c.UserID
+	}
+
+	if c.Token != "" {
+		token = c.Token
+	}
+
+	query := `INSERT INTO cart (user_id, token, updated_at) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id);`
+
+	exec, err := cmr.Conn.ExecContext(ctx, query, userID, token, time.Now().UTC())
+
+	if err != nil {
+		return err
+	}
+
+	c.ID, err = exec.LastInsertId()
+
+	return err
+}
+
+// SetItem adds the item or changes its quantity, every change keeps the
+// cart from expiring.
+func (cmr *cartMysqlRepository) SetItem(ctx context.Context, cartID int64, item *domain.CartItem) error {
+	query := `INSERT INTO cart_item (cart_id, product_uuid, sku, quantity) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE quantity = VALUES(quantity);`
+
+	return cmr.change(ctx, cartID, query, cartID, item.ProductUUID, item.SKU, item.Quantity)
+}
+
+func (cmr *cartMysqlRepository) RemoveItem(ctx context.Context, cartID int64, sku string) error {
+	return cmr.change(ctx, cartID, `DELETE FROM cart_item WHERE cart_id = ? AND sku = ?;`, cartID, sku)
+}
+
+func (cmr *cartMysqlRepository) change(ctx context.Context, cartID int64, query string, args ...interface{}) error {
+	tx, err := cmr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE cart SET updated_at = ? WHERE id = ?;`, time.Now().UTC(), cartID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (cmr *cartMysqlRepository) Delete(ctx context.Context, cartID int64) error {
+	_, err := cmr.Conn.ExecContext(ctx, `DELETE FROM cart WHERE id = ?;`, cartID)
+
+	return err
+}
+
+// DeleteExpired deletes up to limit carts not changed since the given times,
+// with their items.
+func (cmr *cartMysqlRepository) DeleteExpired(ctx context.Context, guestsBefore time.Time, usersBefore time.Time, limit int) (int, error) {
+	query := `DELETE FROM cart WHERE (user_id IS NULL AND updated_at < ?) OR (user_id IS NOT NULL AND updated_at < ?) LIMIT ?;`
+
+	exec, err := cmr.Conn.ExecContext(ctx, query, guestsBefore.UTC(), usersBefore.UTC(), limit)
+
+	if err != nil {
+		return 0, err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	return int(affect), err
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetByUserID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	cartRows := sqlmock.NewRows([]string{"id", "user_id", "token"}).AddRow(3, 7, nil)
+	itemRows := sqlmock.NewRows([]string{"product_uuid", "sku", "quantity"}).AddRow("p1", "P1-M", 2).AddRow("p2", "P2", 1)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, token FROM cart WHERE user_id = ?;")).WithArgs(7).WillReturnRows(cartRows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT product_uuid, sku, quantity FROM cart_item WHERE cart_id = ? ORDER BY id;")).WithArgs(3).WillReturnRows(itemRows)
+
+	cart, err := NewCartMysqlRepository(db).GetByUserID(context.Background(), 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Cart{ID: 3, UserID: 7, Items: []domain.CartItem{{ProductUUID: "p1", SKU: "P1-M", Quantity: 2}, {ProductUUID: "p2", SKU: "P2", Quantity: 1}}}, cart)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetByTokenNotExists(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, token FROM cart WHERE token = ?;")).WithArgs("tk").WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "token"}))
+
+	cart, err := NewCartMysqlRepository(db).GetByToken(context.Background(), "tk")
+
+	assert.NoError(t, err)
+	assert.Nil(t, cart)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreGuestCart(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO cart (user_id, token, updated_at) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id);")).
+		WithArgs(nil, "tk", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(4, 1))
+
+	cart := &domain.Cart{Token: "tk"}
+
+	err = NewCartMysqlRepository(db).Store(context.Background(), cart)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), cart.ID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetItem(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO cart_item (cart_id, product_uuid, sku, quantity) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE quantity = VALUES(quantity);")).
+		WithArgs(3, "p1", "P1-M", 2).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE cart SET updated_at = ? WHERE id = ?;")).WithArgs(sqlmock.AnyArg(), 3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = NewCartMysqlRepository(db).SetItem(context.Background(), 3, &domain.CartItem{ProductUUID: "p1", SKU: "P1-M", Quantity: 2})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRemoveItemError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM cart_item WHERE cart_id = ? AND sku = ?;")).WithArgs(3, "P1-M").WillReturnError(errors.New("connection lost"))
+	mock.ExpectRollback()
+
+	err = NewCartMysqlRepository(db).RemoveItem(context.Background(), 3, "P1-M")
+
+	assert.EqualError(t, err, "connection lost")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDeleteExpired(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	guests := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	users := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM cart WHERE (user_id IS NULL AND updated_at < ?) OR (user_id IS NOT NULL AND updated_at < ?) LIMIT ?;")).
+		WithArgs(guests, users, 100).WillReturnResult(sqlmock.NewResult(0, 12))
+
+	deleted, err := NewCartMysqlRepository(db).DeleteExpired(context.Background(), guests, users, 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 12, deleted)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,457 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+// the guest carts are kept for a week after their last change, the carts of
+// the users for two months
+const (
+	guestCartTTL = 7 * 24 * time.Hour
+	userCartTTL  = 60 * 24 * time.Hour
+	batchSize    = 100
+)
+
+type cartUseCase struct {
+	cartRepo       domain.CartRepository
+	productRepo    domain.ProductRepository
+	variantRepo    domain.VariantRepository
+	userRepo       domain.UserRepository
+	pricingService domain.PricingService
+}
+
+func NewCartUseCase(cr domain.CartRepository, pr domain.ProductRepository, vr domain.VariantRepository, ur domain.UserRepository, ps domain.PricingService) domain.CartUseCase {
+	return &cartUseCase{cartRepo: cr, productRepo: pr, variantRepo: vr, userRepo: ur, pricingService: ps}
+}
+
+func (cu *cartUseCase) Get(ctx context.Context, login string, token string) (*domain.Cart, error) {
+	userID, err := cu.userID(ctx, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := cu.find(ctx, userID, token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if cart == nil {
+		cart = &domain.Cart{UserID: userID, Items: []domain.CartItem{}}
+	}
+
+	return cart, cu.price(ctx, cart)
+}
+
+// AddItem adds the quantity to the one of the sku already in the cart, the
+// cart is created with the first item.
+func (cu *cartUseCase) AddItem(ctx context.Context, login string, token string, item *domain.CartItem) (*domain.Cart, error) {
+	userID, err := cu.userID(ctx, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := cu.find(ctx, userID, token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := cu.productRepo.GetByUUID(ctx, item.ProductUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil || product.Status != domain.ProductStatusPublished {
+		return nil, domain.ErrProductNotFound
+	}
+
+	variant, err := cu.variantRepo.GetBySKU(ctx, item.SKU)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if variant == nil || variant.ProductID != product.ID {
+		return nil, domain.ErrVariantNotFound
+	}
+
+	quantity := item.Quantity
+
+	if cart == nil {
+		cart = &domain.Cart{UserID: userID, Items: []domain.CartItem{}}
+	}
+
+	i := itemIndex(cart, item.SKU)
+
+	if i >= 0 {
+		quantity += cart.Items[i].Quantity
+	} else if len(cart.Items) >= domain.MaxCartItems {
+		return nil, domain.ErrCartFull
+	}
+
+	if quantity > domain.MaxCartItemQuantity {
+		return nil, domain.ErrQuantityLimit
+	}
+
+	if variant.Stock < quantity {
+		return nil, domain.ErrInsufficientStock
+	}
+
+	if cart.ID == 0 {
+		if userID == 0 {
+			if cart.Token, err = newCartToken(); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := cu.cartRepo.Store(ctx, cart); err != nil {
+			return nil, err
+		}
+	}
+
+	added := domain.CartItem{ProductUUID: product.UUID, SKU: variant.SKU, Quantity: quantity}
+
+	if err := cu.cartRepo.SetItem(ctx, cart.ID, &added); err != nil {
+		return nil, err
+	}
+
+	if i >= 0 {
+		cart.Items[i] = added
+	} else {
+		cart.Items = append(cart.Items, added)
+	}
+
+	return cart, cu.price(ctx, cart)
+}
+
+// SetQuantity only checks the stock when the quantity grows, so a customer
+// can always take units out of an item that lacks stock.
+func (cu *cartUseCase) SetQuantity(ctx context.Context, login string, token string, sku string, quantity int64) (*domain.Cart, error) {
+	cart, i, err := cu.findItem(ctx, login, token, sku)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if quantity > domain.MaxCartItemQuantity {
+		return nil, domain.ErrQuantityLimit
+	}
+
+	if quantity > cart.Items[i].Quantity {
+		variant, err := cu.variantRepo.GetBySKU(ctx, sku)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if variant == nil {
+			return nil, domain.ErrVariantNotFound
+		}
+
+		if variant.Stock < quantity {
+			return nil, domain.ErrInsufficientStock
+		}
+	}
+
+	cart.Items[i].Quantity = quantity
+
+	if err := cu.cartRepo.SetItem(ctx, cart.ID, &cart.Items[i]); err != nil {
+		return nil, err
+	}
+
+	return cart, cu.price(ctx, cart)
+}
+
+func (cu *cartUseCase) RemoveItem(ctx context.Context, login string, token string, sku string) (*domain.Cart, error) {
+	cart, i, err := cu.findItem(ctx, login, token, sku)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cu.cartRepo.RemoveItem(ctx, cart.ID, sku); err != nil {
+		return nil, err
+	}
+
+	cart.Items = append(cart.Items[:i], cart.Items[i+1:]...)
+
+	return cart, cu.price(ctx, cart)
+}
+
+func (cu *cartUseCase) DeleteExpired(ctx context.Context) (int, error) {
+	deleted := 0
+
+	for {
+		now := time.Now()
+
+		n, err := cu.cartRepo.DeleteExpired(ctx, now.Add(-guestCartTTL), now.Add(-userCartTTL), batchSize)
+
+		if err != nil {
+			return deleted, err
+		}
+
+		deleted += n
+
+		if n < batchSize {
+			return deleted, nil
+		}
+	}
+}
+
+func (cu *cartUseCase) userID(ctx context.Context, login string) (int64, error) {
+	if login == "" {
+		return 0, nil
+	}
+
+	user, err := cu.userRepo.GetByEmail(ctx, login)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if user == nil {
+		return 0, domain.ErrUserNotFound
+	}
+
+	return user.ID, nil
+}
+
+func (cu *cartUseCase) findItem(ctx context.Context, login string, token string, sku string) (*domain.Cart, int, error) {
+	userID, err := cu.userID(ctx, login)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cart, err := cu.find(ctx, userID, token)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if cart == nil {
+		return nil, 0, domain.ErrCartItemNotFound
+	}
+
+	i := itemIndex(cart, sku)
+
+	if i < 0 {
+		return nil, 0, domain.ErrCartItemNotFound
+	}
+
+	return cart, i, nil
+}
+
+// find gives the cart of the user, or of the guest when there is no user.
+// The guest cart of a user who just logged in is merged into the cart of the
+// user, within the quantity limits.
+func (cu *cartUseCase) find(ctx context.Context, userID int64, token string) (*domain.Cart, error) {
+	if userID == 0 {
+		if token == "" {
+			return nil, nil
+		}
+
+		return cu.cartRepo.GetByToken(ctx, token)
+	}
+
+	cart, err := cu.cartRepo.GetByUserID(ctx, userID)
+
+	if err != nil || token == "" {
+		return cart, err
+	}
+
+	guest, err := cu.cartRepo.GetByToken(ctx, token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if guest == nil || guest.UserID != 0 {
+		return cart, nil
+	}
+
+	if len(guest.Items) > 0 && cart == nil {
+		cart = &domain.Cart{UserID: userID, Items: []domain.CartItem{}}
+
+		if err := cu.cartRepo.Store(ctx, cart); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, item := range guest.Items {
+		i := itemIndex(cart, item.SKU)
+
+		if i < 0 && len(cart.Items) >= domain.MaxCartItems {
+			continue
+		}
+
+		if i >= 0 {
+			item.Quantity += cart.Items[i].Quantity
+		}
+
+		if item.Quantity > domain.MaxCartItemQuantity {
+			item.Quantity = domain.MaxCartItemQuantity
+		}
+
+		if err := cu.cartRepo.SetItem(ctx, cart.ID, &item); err != nil {
+			return nil, err
+		}
+
+		if i >= 0 {
+			cart.Items[i] = item
+		} else {
+			cart.Items = append(cart.Items, item)
+		}
+	}
+
+	if err := cu.cartRepo.Delete(ctx, guest.ID); err != nil {
+		return nil, err
+	}
+
+	return cart, nil
+}
+
+// price reads the items again from the catalogue, with the prices of the
+// user and the stock of now. The items that can not be bought stay in the
+// cart out of the subtotal, so the customer sees what happened to them.
+func (cu *cartUseCase) price(ctx context.Context, cart *domain.Cart) error {
+	cart.Subtotal = domain.Money{Currency: domain.DefaultCurrency}
+
+	if len(cart.Items) == 0 {
+		return nil
+	}
+
+	uuids := []string{}
+	skus := make([]string, len(cart.Items))
+	seen := map[string]bool{}
+
+	for i, item := range cart.Items {
+		if !seen[item.ProductUUID] {
+			seen[item.ProductUUID] = true
+			uuids = append(uuids, item.ProductUUID)
+		}
+
+		skus[i] = item.SKU
+	}
+
+	products, err := cu.productRepo.GetByUUIDs(ctx, uuids)
+
+	if err != nil {
+		return err
+	}
+
+	byUUID := map[string]domain.Product{}
+
+	for _, p := range products {
+		if p.Status == domain.ProductStatusPublished {
+			byUUID[p.UUID] = p
+		}
+	}
+
+	found, err := cu.variantRepo.GetBySKUs(ctx, skus)
+
+	if err != nil {
+		return err
+	}
+
+	variants := map[string]domain.Variant{}
+
+	for _, v := range found {
+		variants[v.SKU] = v
+	}
+
+	now := time.Now()
+
+	for i := range cart.Items {
+		item := &cart.Items[i]
+		item.Status, item.Price, item.Stock = domain.CartItemStatusUnavailable, nil, nil
+
+		product, ok := byUUID[item.ProductUUID]
+
+		if !ok {
+			continue
+		}
+
+		item.Name = product.Name
+
+		if len(product.Pictures) > 0 {
+			item.Picture = product.Pictures[0]
+		}
+
+		variant, ok := variants[item.SKU]
+
+		if !ok || variant.ProductID != product.ID {
+			continue
+		}
+
+		item.Options = variant.Options
+
+		if len(variant.Pictures) > 0 {
+			item.Picture = variant.Pictures[0]
+		}
+
+		price, err := cu.pricingService.Resolve(ctx, domain.PriceRequest{Product: &product, Variant: &variant, Quantity: item.Quantity, UserID: cart.UserID, Currency: domain.DefaultCurrency, At: now})
+
+		if errors.Is(err, domain.ErrNoPrice) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		item.Price = price
+
+		stock := variant.Stock
+
+		if stock < 0 {
+			stock = 0
+		}
+
+		switch {
+		case stock == 0:
+			item.Status, item.Stock = domain.CartItemStatusOutOfStock, &stock
+		case stock < item.Quantity:
+			item.Status, item.Stock = domain.CartItemStatusInsufficientStock, &stock
+		default:
+			item.Status = domain.CartItemStatusAvailable
+			cart.Subtotal.Amount += price.Total.Amount
+		}
+	}
+
+	return nil
+}
+
+func itemIndex(cart *domain.Cart, sku string) int {
+	if cart == nil {
+		return -1
+	}
+
+	for i, item := range cart.Items {
+		if item.SKU == sku {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func newCartToken() (string, error) {
+	b := make([]byte, 24)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var shirt = domain.Product{ID: 1, UUID: "p1", Name: "Camiseta", Pictures: []string{"camiseta.png"}, Status: domain.ProductStatusPublished}
+
+func userRepoWithAna() *mocks.MockUserRepository {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockUserRepo.On("GetByEmail", mock.Anything, "ana@test.com").Return(7, "u7", "ana@test.com", "Ana", "Lima", "", "", "", "", "", "", "", nil)
+	return mockUserRepo
+}
+
+func unitPrice(amount int64, quantity int64) *domain.EffectivePrice {
+	unit := domain.Money{Amount: amount, Currency: domain.DefaultCurrency}
+	return &domain.EffectivePrice{Unit: unit, List: unit, Total: domain.Money{Amount: amount * quantity, Currency: domain.DefaultCurrency}, Quantity: quantity}
+}
+
+func TestGetNoCart(t *testing.T) {
+	cart, err := NewCartUseCase(new(mocks.MockCartRepository), nil, nil, nil, nil).Get(context.Background(), "", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Cart{Items: []domain.CartItem{}, Subtotal: domain.Money{Currency: domain.DefaultCurrency}}, cart)
+}
+
+func TestGetRecalculates(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+	mockPricingService := new(mocks.MockPricingService)
+
+	mockCartRepo.On("GetByToken", mock.Anything, "tk").Return(&domain.Cart{ID: 3, Token: "tk", Items: []domain.CartItem{
+		{ProductUUID: "p1", SKU: "P1-M", Quantity: 2},
+		{ProductUUID: "p1", SKU: "P1-G", Quantity: 3},
+		{ProductUUID: "p1", SKU: "P1-P", Quantity: 1},
+		{ProductUUID: "p2", SKU: "P2", Quantity: 1},
+	}}, nil)
+	mockProductRepo.On("GetByUUIDs", mock.Anything, []string{"p1", "p2"}).Return([]domain.Product{shirt, {ID: 2, UUID: "p2", Status: domain.ProductStatusArchived}}, nil)
+	mockVariantRepo.On("GetBySKUs", mock.Anything, []string{"P1-M", "P1-G", "P1-P", "P2"}).Return([]domain.Variant{
+		{ProductID: 1, SKU: "P1-M", Stock: 5, Options: []domain.VariantOption{{Label: "size", Value: "M"}}},
+		{ProductID: 1, SKU: "P1-G", Stock: 1},
+		{ProductID: 1, SKU: "P1-P", Stock: 0},
+		{ProductID: 2, SKU: "P2", Stock: 4},
+	}, nil)
+	mockPricingService.On("Resolve", mock.Anything, mock.MatchedBy(func(r domain.PriceRequest) bool { return r.Variant.SKU == "P1-M" })).Return(unitPrice(4990, 2), nil)
+	mockPricingService.On("Resolve", mock.Anything, mock.MatchedBy(func(r domain.PriceRequest) bool { return r.Variant.SKU == "P1-G" })).Return(unitPrice(4990, 3), nil)
+	mockPricingService.On("Resolve", mock.Anything, mock.MatchedBy(func(r domain.PriceRequest) bool { return r.Variant.SKU == "P1-P" })).Return(unitPrice(4990, 1), nil)
+
+	cart, err := NewCartUseCase(mockCartRepo, mockProductRepo, mockVariantRepo, nil, mockPricingService).Get(context.Background(), "", "tk")
+
+	one, none := int64(1), int64(0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Money{Amount: 9980, Currency: domain.DefaultCurrency}, cart.Subtotal)
+	assert.Equal(t, domain.CartItem{ProductUUID: "p1", SKU: "P1-M", Quantity: 2, Name: "Camiseta", Options: []domain.VariantOption{{Label: "size", Value: "M"}}, Picture: "camiseta.png", Price: unitPrice(4990, 2), Status: domain.CartItemStatusAvailable}, cart.Items[0])
+	assert.Equal(t, domain.CartItemStatusInsufficientStock, cart.Items[1].Status)
+	assert.Equal(t, &one, cart.Items[1].Stock)
+	assert.Equal(t, domain.CartItemStatusOutOfStock, cart.Items[2].Status)
+	assert.Equal(t, &none, cart.Items[2].Stock)
+	assert.Equal(t, domain.CartItemStatusUnavailable, cart.Items[3].Status)
+	assert.Nil(t, cart.Items[3].Price)
+	mockVariantRepo.AssertNumberOfCalls(t, "GetBySKUs", 1)
+	mockVariantRepo.AssertNotCalled(t, "GetByProductID", mock.Anything, mock.Anything)
+}
+
+func TestGetNoPrice(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+	mockPricingService := new(mocks.MockPricingService)
+
+	mockCartRepo.On("GetByUserID", mock.Anything, int64(7)).Return(&domain.Cart{ID: 3, UserID: 7, Items: []domain.CartItem{{ProductUUID: "p1", SKU: "P1-M", Quantity: 1}}}, nil)
+	mockProductRepo.On("GetByUUIDs", mock.Anything, []string{"p1"}).Return([]domain.Product{shirt}, nil)
+	mockVariantRepo.On("GetBySKUs", mock.Anything, []string{"P1-M"}).Return([]domain.Variant{{ProductID: 1, SKU: "P1-M", Stock: 5}}, nil)
+	mockPricingService.On("Resolve", mock.Anything, mock.MatchedBy(func(r domain.PriceRequest) bool { return r.UserID == 7 })).Return(nil, domain.ErrNoPrice)
+
+	cart, err := NewCartUseCase(mockCartRepo, mockProductRepo, mockVariantRepo, userRepoWithAna(), mockPricingService).Get(context.Background(), "ana@test.com", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.CartItemStatusUnavailable, cart.Items[0].Status)
+	assert.Equal(t, int64(0), cart.Subtotal.Amount)
+}
+
+func TestGetMergesGuestCart(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+	mockPricingService := new(mocks.MockPricingService)
+
+	mockCartRepo.On("GetByUserID", mock.Anything, int64(7)).Return(&domain.Cart{ID: 3, UserID: 7, Items: []domain.CartItem{{ProductUUID: "p1", SKU: "P1-M", Quantity: 8}}}, nil)
+	mockCartRepo.On("GetByToken", mock.Anything, "tk").Return(&domain.Cart{ID: 4, Token: "tk", Items: []domain.CartItem{{ProductUUID: "p1", SKU: "P1-M", Quantity: 5}, {ProductUUID: "p1", SKU: "P1-G", Quantity: 1}}}, nil)
+	mockCartRepo.On("SetItem", mock.Anything, int64(3), &domain.CartItem{ProductUUID: "p1", SKU: "P1-M", Quantity: domain.MaxCartItemQuantity}).Return(nil)
+	mockCartRepo.On("SetItem", mock.Anything, int64(3), &domain.CartItem{ProductUUID: "p1", SKU: "P1-G", Quantity: 1}).Return(nil)
+	mockCartRepo.On("Delete", mock.Anything, int64(4)).Return(nil)
+	mockProductRepo.On("GetByUUIDs", mock.Anything, []string{"p1"}).Return([]domain.Product{shirt}, nil)
+	mockVariantRepo.On("GetBySKUs", mock.Anything, []string{"P1-M", "P1-G"}).Return([]domain.Variant{{ProductID: 1, SKU: "P1-M", Stock: 20}, {ProductID: 1, SKU: "P1-G", Stock: 20}}, nil)
+	mockPricingService.On("Resolve", mock.Anything, mock.Anything).Return(unitPrice(4990, 1), nil)
+
+	cart, err := NewCartUseCase(mockCartRepo, mockProductRepo, mockVariantRepo, userRepoWithAna(), mockPricingService).Get(context.Background(), "ana@test.com", "tk")
+
+	assert.NoError(t, err)
+	assert.Len(t, cart.Items, 2)
+	assert.Equal(t, int64(domain.MaxCartItemQuantity), cart.Items[0].Quantity)
+	mockCartRepo.AssertExpectations(t)
+}
+
+func TestGetMergesIntoNewUserCart(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+	mockPricingService := new(mocks.MockPricingService)
+
+	mockCartRepo.On("GetByUserID", mock.Anything, int64(7)).Return(nil, nil)
+	mockCartRepo.On("GetByToken", mock.Anything, "tk").Return(&domain.Cart{ID: 4, Token: "tk", Items: []domain.CartItem{{ProductUUID: "p1", SKU: "P1-M", Quantity: 1}}}, nil)
+	mockCartRepo.On("Store", mock.Anything, mock.MatchedBy(func(c *domain.Cart) bool { return c.UserID == 7 && c.Token == "" })).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Cart).ID = 5
+	}).Return(nil)
+	mockCartRepo.On("SetItem", mock.Anything, int64(5), &domain.CartItem{ProductUUID: "p1", SKU: "P1-M", Quantity: 1}).Return(nil)
+	mockCartRepo.On("Delete", mock.Anything, int64(4)).Return(nil)
+	mockProductRepo.On("GetByUUIDs", mock.Anything, []string{"p1"}).Return([]domain.Product{shirt}, nil)
+	mockVariantRepo.On("GetBySKUs", mock.Anything, []string{"P1-M"}).Return([]domain.Variant{{ProductID: 1, SKU: "P1-M", Stock: 20}}, nil)
+	mockPricingService.On("Resolve", mock.Anything, mock.Anything).Return(unitPrice(4990, 1), nil)
+
+	cart, err := NewCartUseCase(mockCartRepo, mockProductRepo, mockVariantRepo, userRepoWithAna(), mockPricingService).Get(context.Background(), "ana@test.com", "tk")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), cart.ID)
+	mockCartRepo.AssertExpectations(t)
+}
+
+func TestAddItemCreatesGuestCart(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+	mockPricingService := new(mocks.MockPricingService)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "p1").Return(&shirt, nil)
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P1-M").Return(&domain.Variant{ProductID: 1, SKU: "P1-M", Stock: 5}, nil)
+	mockCartRepo.On("Store", mock.Anything, mock.MatchedBy(func(c *domain.Cart) bool { return c.UserID == 0 && c.Token != "" })).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Cart).ID = 4
+	}).Return(nil)
+	mockCartRepo.On("SetItem", mock.Anything, int64(4), &domain.CartItem{ProductUUID: "p1", SKU: "P1-M", Quantity: 2}).Return(nil)
+	mockProductRepo.On("GetByUUIDs", mock.Anything, []string{"p1"}).Return([]domain.Product{shirt}, nil)
+	mockVariantRepo.On("GetBySKUs", mock.Anything, []string{"P1-M"}).Return([]domain.Variant{{ProductID: 1, SKU: "P1-M", Stock: 5}}, nil)
+	mockPricingService.On("Resolve", mock.Anything, mock.Anything).Return(unitPrice(4990, 2), nil)
+
+	cart, err := NewCartUseCase(mockCartRepo, mockProductRepo, mockVariantRepo, nil, mockPricingService).AddItem(context.Background(), "", "", &domain.CartItem{ProductUUID: "p1", SKU: "P1-M", Quantity: 2})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, cart.Token)
+	assert.Equal(t, int64(9980), cart.Subtotal.Amount)
+	mockCartRepo.AssertExpectations(t)
+}
+
+func TestAddItemQuantityLimit(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	mockCartRepo.On("GetByToken", mock.Anything, "tk").Return(&domain.Cart{ID: 4, Token: "tk", Items: []domain.CartItem{{ProductUUID: "p1", SKU: "P1-M", Quantity: 9}}}, nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "p1").Return(&shirt, nil)
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P1-M").Return(&domain.Variant{ProductID: 1, SKU: "P1-M", Stock: 50}, nil)
+
+	_, err := NewCartUseCase(mockCartRepo, mockProductRepo, mockVariantRepo, nil, nil).AddItem(context.Background(), "", "tk", &domain.CartItem{ProductUUID: "p1", SKU: "P1-M", Quantity: 2})
+
+	assert.ErrorIs(t, err, domain.ErrQuantityLimit)
+	mockCartRepo.AssertNotCalled(t, "SetItem", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAddItemInsufficientStock(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "p1").Return(&shirt, nil)
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P1-M").Return(&domain.Variant{ProductID: 1, SKU: "P1-M", Stock: 1}, nil)
+
+	_, err := NewCartUseCase(mockCartRepo, mockProductRepo, mockVariantRepo, nil, nil).AddItem(context.Background(), "", "", &domain.CartItem{ProductUUID: "p1", SKU: "P1-M", Quantity: 2})
+
+	assert.ErrorIs(t, err, domain.ErrInsufficientStock)
+	mockCartRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestAddItemVariantOfAnotherProduct(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "p1").Return(&shirt, nil)
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P2").Return(&domain.Variant{ProductID: 2, SKU: "P2", Stock: 5}, nil)
+
+	_, err := NewCartUseCase(new(mocks.MockCartRepository), mockProductRepo, mockVariantRepo, nil, nil).AddItem(context.Background(), "", "", &domain.CartItem{ProductUUID: "p1", SKU: "P2", Quantity: 1})
+
+	assert.ErrorIs(t, err, domain.ErrVariantNotFound)
+}
+
+func TestAddItemCartFull(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	items := make([]domain.CartItem, domain.MaxCartItems)
+	for i := range items {
+		items[i] = domain.CartItem{ProductUUID: "p2", SKU: string(rune('a' + i)), Quantity: 1}
+	}
+
+	mockCartRepo.On("GetByToken", mock.Anything, "tk").Return(&domain.Cart{ID: 4, Token: "tk", Items: items}, nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "p1").Return(&shirt, nil)
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P1-M").Return(&domain.Variant{ProductID: 1, SKU: "P1-M", Stock: 5}, nil)
+
+	_, err := NewCartUseCase(mockCartRepo, mockProductRepo, mockVariantRepo, nil, nil).AddItem(context.Background(), "", "tk", &domain.CartItem{ProductUUID: "p1", SKU: "P1-M", Quantity: 1})
+
+	assert.ErrorIs(t, err, domain.ErrCartFull)
+}
+
+func TestSetQuantityLowerSkipsStock(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+	mockPricingService := new(mocks.MockPricingService)
+
+	mockCartRepo.On("GetByToken", mock.Anything, "tk").Return(&domain.Cart{ID: 4, Token: "tk", Items: []domain.CartItem{{ProductUUID: "p1", SKU: "P1-M", Quantity: 3}}}, nil)
+	mockCartRepo.On("SetItem", mock.Anything, int64(4), &domain.CartItem{ProductUUID: "p1", SKU: "P1-M", Quantity: 1}).Return(nil)
+	mockProductRepo.On("GetByUUIDs", mock.Anything, []string{"p1"}).Return([]domain.Product{shirt}, nil)
+	mockVariantRepo.On("GetBySKUs", mock.Anything, []string{"P1-M"}).Return([]domain.Variant{{ProductID: 1, SKU: "P1-M", Stock: 1}}, nil)
+	mockPricingService.On("Resolve", mock.Anything, mock.Anything).Return(unitPrice(4990, 1), nil)
+
+	cart, err := NewCartUseCase(mockCartRepo, mockProductRepo, mockVariantRepo, nil, mockPricingService).SetQuantity(context.Background(), "", "tk", "P1-M", 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.CartItemStatusAvailable, cart.Items[0].Status)
+	mockVariantRepo.AssertNotCalled(t, "GetBySKU", mock.Anything, mock.Anything)
+}
+
+func TestSetQuantityItemNotFound(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+
+	mockCartRepo.On("GetByToken", mock.Anything, "tk").Return(&domain.Cart{ID: 4, Token: "tk", Items: []domain.CartItem{}}, nil)
+
+	_, err := NewCartUseCase(mockCartRepo, nil, nil, nil, nil).SetQuantity(context.Background(), "", "tk", "P1-M", 1)
+
+	assert.ErrorIs(t, err, domain.ErrCartItemNotFound)
+}
+
+func TestRemoveItem(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+
+	mockCartRepo.On("GetByToken", mock.Anything, "tk").Return(&domain.Cart{ID: 4, Token: "tk", Items: []domain.CartItem{{ProductUUID: "p1", SKU: "P1-M", Quantity: 3}}}, nil)
+	mockCartRepo.On("RemoveItem", mock.Anything, int64(4), "P1-M").Return(nil)
+
+	cart, err := NewCartUseCase(mockCartRepo, nil, nil, nil, nil).RemoveItem(context.Background(), "", "tk", "P1-M")
+
+	assert.NoError(t, err)
+	assert.Empty(t, cart.Items)
+}
+
+func TestDeleteExpired(t *testing.T) {
+	mockCartRepo := new(mocks.MockCartRepository)
+
+	mockCartRepo.On("DeleteExpired", mock.Anything, mock.Anything, mock.Anything, batchSize).Return(batchSize, nil).Once()
+	mockCartRepo.On("DeleteExpired", mock.Anything, mock.Anything, mock.Anything, batchSize).Return(3, nil).Once()
+
+	deleted, err := NewCartUseCase(mockCartRepo, nil, nil, nil, nil).DeleteExpired(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, batchSize+3, deleted)
+}
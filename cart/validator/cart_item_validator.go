@@ -0,0 +1,27 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type cartItemValidator struct{}
+
+func NewCartItemValidator() *cartItemValidator {
+	return &cartItemValidator{}
+}
+
+func (civ *cartItemValidator) Validate(ctx context.Context, item *domain.CartItem) (domain.IsValid, domain.Message) {
+	if strings.TrimSpace(item.SKU) == "" || len(item.SKU) > 100 {
+		return false, "item's sku must have between 1 and 100 characters"
+	}
+
+	if item.Quantity < 1 || item.Quantity > domain.MaxCartItemQuantity {
+		return false, domain.Message(fmt.Sprintf("item's quantity must be between 1 and %d", domain.MaxCartItemQuantity))
+	}
+
+	return true, ""
+}
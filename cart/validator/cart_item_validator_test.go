@@ -0,0 +1,31 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCartItemInvalid(t *testing.T) {
+	for _, item := range []domain.CartItem{
+		{SKU: "", Quantity: 1},
+		{SKU: strings.Repeat("a", 101), Quantity: 1},
+		{SKU: "P1-M", Quantity: 0},
+		{SKU: "P1-M", Quantity: domain.MaxCartItemQuantity + 1},
+	} {
+		isValid, message := NewCartItemValidator().Validate(context.Background(), &item)
+
+		assert.False(t, bool(isValid))
+		assert.NotEmpty(t, message)
+	}
+}
+
+func TestValidateCartItem(t *testing.T) {
+	isValid, message := NewCartItemValidator().Validate(context.Background(), &domain.CartItem{SKU: "P1-M", Quantity: 2})
+
+	assert.True(t, bool(isValid))
+	assert.Empty(t, message)
+}
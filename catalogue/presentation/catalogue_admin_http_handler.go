@@ -0,0 +1,168 @@
+package presentation
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+var formatContentTypes = map[domain.CatalogueFormat]string{
+	domain.CatalogueFormatCSV:   "text/csv",
+	domain.CatalogueFormatJSONL: "application/x-ndjson",
+}
+
+type catalogueAdminHandler struct {
+	CatalogueUseCase domain.CatalogueUseCase
+}
+
+func NewCatalogueAdminHandler(e *echo.Echo, cuc domain.CatalogueUseCase, ts domain.TokenService) *catalogueAdminHandler {
+	handler := &catalogueAdminHandler{
+		CatalogueUseCase: cuc,
+	}
+
+	admin := _tokenPresentation.NewAdminMiddleware(ts)
+
+	e.POST("/admin/catalogue/imports", handler.Import, admin)
+	e.GET("/admin/catalogue/imports/:uuid", handler.GetImport, admin)
+	e.POST("/admin/catalogue/imports/:uuid/resume", handler.Resume, admin)
+	e.GET("/admin/catalogue/export", handler.Export, admin)
+
+	return handler
+}
+
+// Import answers as soon as the job is created, the job is followed through
+// GetImport.
+func (cah *catalogueAdminHandler) Import(c echo.Context) error {
+	file, err := c.FormFile("file")
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, "the catalogue must be sent in the file field of a multipart form")
+	}
+
+	format := domain.CatalogueFormat(c.QueryParam("format"))
+
+	if format == "" {
+		format = domain.CatalogueFormat(strings.ToLower(strings.TrimPrefix(filepath.Ext(file.Filename), ".")))
+	}
+
+	if _, ok := formatContentTypes[format]; !ok {
+		return c.JSON(http.StatusUnsupportedMediaType, "the catalogue must be a csv or jsonl file")
+	}
+
+	f, err := file.Open()
+
+	if err != nil {
+		log.Printf("Error trying to open an uploaded catalogue: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to read the catalogue")
+	}
+
+	defer f.Close()
+
+	job, err := cah.CatalogueUseCase.CreateImport(c.Request().Context(), format, f, c.QueryParam("dryRun") == "true")
+
+	if errors.Is(err, domain.ErrImportTooLarge) {
+		return c.JSON(http.StatusRequestEntityTooLarge, "catalogue can not be larger than 50MB")
+	}
+
+	if errors.Is(err, domain.ErrUnsupportedFormat) {
+		return c.JSON(http.StatusUnsupportedMediaType, "the catalogue must be a csv or jsonl file")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to create an import: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to import the catalogue")
+	}
+
+	go cah.run(job.UUID)
+
+	return c.JSON(http.StatusAccepted, job)
+}
+
+func (cah *catalogueAdminHandler) GetImport(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	job, err := cah.CatalogueUseCase.GetImport(c.Request().Context(), uuid)
+
+	if errors.Is(err, domain.ErrImportJobNotFound) {
+		return c.JSON(http.StatusNotFound, "import not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to get an import: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the import")
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
+
+// Resume runs again a failed job from where it stopped, the jobs of the
+// runners that died are resumed on their own.
+func (cah *catalogueAdminHandler) Resume(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	job, err := cah.CatalogueUseCase.GetImport(c.Request().Context(), uuid)
+
+	if errors.Is(err, domain.ErrImportJobNotFound) {
+		return c.JSON(http.StatusNotFound, "import not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to get an import: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to resume the import")
+	}
+
+	if job.Status == domain.ImportStatusCompleted || job.Status == domain.ImportStatusRunning {
+		return c.JSON(http.StatusConflict, "the import is already "+string(job.Status))
+	}
+
+	go cah.run(job.UUID)
+
+	return c.JSON(http.StatusAccepted, job)
+}
+
+func (cah *catalogueAdminHandler) Export(c echo.Context) error {
+	format := domain.CatalogueFormat(c.QueryParam("format"))
+
+	if format == "" {
+		format = domain.CatalogueFormatCSV
+	}
+
+	contentType, ok := formatContentTypes[format]
+
+	if !ok {
+		return c.JSON(http.StatusBadRequest, "format must be csv or jsonl")
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, contentType)
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="catalogue.`+string(format)+`"`)
+	c.Response().WriteHeader(http.StatusOK)
+
+	// once the streaming started the status can not change anymore
+	if err := cah.CatalogueUseCase.Export(c.Request().Context(), format, c.Response()); err != nil {
+		log.Printf("Error trying to export the catalogue: %s", err.Error())
+	}
+
+	return nil
+}
+
+// run outlives the request, so it does not use its context.
+func (cah *catalogueAdminHandler) run(uuid string) {
+	if _, err := cah.CatalogueUseCase.RunImport(context.Background(), uuid); err != nil && !errors.Is(err, domain.ErrImportJobRunning) {
+		log.Printf("Error trying to run the import %s: %s", uuid, err.Error())
+	}
+}
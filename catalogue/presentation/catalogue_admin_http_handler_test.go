@@ -0,0 +1,260 @@
+package presentation
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func multipartCatalogue(t *testing.T, filename string, data string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	part, err := w.CreateFormFile("file", filename)
+	assert.NoError(t, err)
+
+	_, err = part.Write([]byte(data))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+
+	return body, w.FormDataContentType()
+}
+
+func TestImportMissingFile(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/catalogue/imports", strings.NewReader("{}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewCatalogueAdminHandler(echo.New(), nil, nil)
+
+	handler.Import(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestImportUnsupportedFormat(t *testing.T) {
+	body, contentType := multipartCatalogue(t, "catalogue.xlsx", "data")
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/catalogue/imports", body)
+	assert.NoError(t, err)
+	req.Header.Add("content-type", contentType)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewCatalogueAdminHandler(echo.New(), nil, nil)
+
+	handler.Import(c)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+}
+
+func TestImportTooLarge(t *testing.T) {
+	mockCatalogueUsecase := new(mocks.MockCatalogueUseCase)
+
+	mockCatalogueUsecase.On("CreateImport", mock.Anything, domain.CatalogueFormatCSV, mock.Anything, false).Return(nil, domain.ErrImportTooLarge)
+
+	body, contentType := multipartCatalogue(t, "catalogue.CSV", "data")
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/catalogue/imports", body)
+	assert.NoError(t, err)
+	req.Header.Add("content-type", contentType)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewCatalogueAdminHandler(echo.New(), mockCatalogueUsecase, nil)
+
+	handler.Import(c)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestImport(t *testing.T) {
+	mockCatalogueUsecase := new(mocks.MockCatalogueUseCase)
+
+	job := &domain.ImportJob{UUID: "j3", Format: domain.CatalogueFormatJSONL, DryRun: true, Status: domain.ImportStatusPending}
+
+	mockCatalogueUsecase.On("CreateImport", mock.Anything, domain.CatalogueFormatJSONL, mock.Anything, true).Return(job, nil)
+	mockCatalogueUsecase.On("RunImport", mock.Anything, "j3").Return(job, nil).Maybe()
+
+	body, contentType := multipartCatalogue(t, "catalogue.txt", `{"name":"Shirt"}`)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/catalogue/imports?format=jsonl&dryRun=true", body)
+	assert.NoError(t, err)
+	req.Header.Add("content-type", contentType)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewCatalogueAdminHandler(echo.New(), mockCatalogueUsecase, nil)
+
+	handler.Import(c)
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"uuid":"j3"`)
+}
+
+func TestGetImportNotFound(t *testing.T) {
+	mockCatalogueUsecase := new(mocks.MockCatalogueUseCase)
+
+	mockCatalogueUsecase.On("GetImport", mock.Anything, "j3").Return(nil, domain.ErrImportJobNotFound)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin/catalogue/imports/:uuid", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("j3")
+
+	handler := NewCatalogueAdminHandler(echo.New(), mockCatalogueUsecase, nil)
+
+	handler.GetImport(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestGetImport(t *testing.T) {
+	mockCatalogueUsecase := new(mocks.MockCatalogueUseCase)
+
+	mockCatalogueUsecase.On("GetImport", mock.Anything, "j3").Return(&domain.ImportJob{UUID: "j3", Status: domain.ImportStatusCompleted, Errors: []domain.ImportRowError{{Line: 7, Message: "invalid row: product not found"}}}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin/catalogue/imports/:uuid", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("j3")
+
+	handler := NewCatalogueAdminHandler(echo.New(), mockCatalogueUsecase, nil)
+
+	handler.GetImport(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"line":7`)
+}
+
+func TestResumeCompleted(t *testing.T) {
+	mockCatalogueUsecase := new(mocks.MockCatalogueUseCase)
+
+	mockCatalogueUsecase.On("GetImport", mock.Anything, "j3").Return(&domain.ImportJob{UUID: "j3", Status: domain.ImportStatusCompleted}, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/catalogue/imports/:uuid/resume", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("j3")
+
+	handler := NewCatalogueAdminHandler(echo.New(), mockCatalogueUsecase, nil)
+
+	handler.Resume(c)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	mockCatalogueUsecase.AssertNotCalled(t, "RunImport", mock.Anything, mock.Anything)
+}
+
+func TestResume(t *testing.T) {
+	mockCatalogueUsecase := new(mocks.MockCatalogueUseCase)
+
+	job := &domain.ImportJob{UUID: "j3", Status: domain.ImportStatusFailed, Error: "connection refused"}
+
+	mockCatalogueUsecase.On("GetImport", mock.Anything, "j3").Return(job, nil)
+	mockCatalogueUsecase.On("RunImport", mock.Anything, "j3").Return(job, nil).Maybe()
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/catalogue/imports/:uuid/resume", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("j3")
+
+	handler := NewCatalogueAdminHandler(echo.New(), mockCatalogueUsecase, nil)
+
+	handler.Resume(c)
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+}
+
+func TestExportInvalidFormat(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin/catalogue/export?format=xml", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewCatalogueAdminHandler(echo.New(), nil, nil)
+
+	handler.Export(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestExport(t *testing.T) {
+	mockCatalogueUsecase := new(mocks.MockCatalogueUseCase)
+
+	mockCatalogueUsecase.On("Export", mock.Anything, domain.CatalogueFormatJSONL, mock.Anything).Run(func(args mock.Arguments) {
+		io.WriteString(args.Get(2).(io.Writer), "{\"uuid\":\"p1\"}\n")
+	}).Return(nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin/catalogue/export?format=jsonl", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewCatalogueAdminHandler(echo.New(), mockCatalogueUsecase, nil)
+
+	handler.Export(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="catalogue.jsonl"`, rec.Header().Get("Content-Disposition"))
+	assert.Equal(t, "{\"uuid\":\"p1\"}\n", rec.Body.String())
+}
+
+func TestExportError(t *testing.T) {
+	mockCatalogueUsecase := new(mocks.MockCatalogueUseCase)
+
+	mockCatalogueUsecase.On("Export", mock.Anything, domain.CatalogueFormatCSV, mock.Anything).Return(errors.New("error message"))
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin/catalogue/export", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewCatalogueAdminHandler(echo.New(), mockCatalogueUsecase, nil)
+
+	assert.NoError(t, handler.Export(c))
+	assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+}
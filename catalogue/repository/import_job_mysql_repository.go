@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/google/uuid"
+)
+
+const importJobColumns = `id, uuid, format, dry_run, status, processed, created_count, updated_count, failed_count, error, created_at, updated_at FROM import_job`
+
+type importJobMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewImportJobMysqlRepository(conn *sql.DB) domain.ImportJobRepository {
+	return &importJobMysqlRepository{Conn: conn}
+}
+
+func (ijmr *importJobMysqlRepository) GetByUUID(ctx context.Context, uuid string) (*domain.ImportJob, error) {
+	j, err := scanImportJob(ijmr.Conn.QueryRowContext(ctx, `SELECT `+importJobColumns+` WHERE uuid = ?;`, uuid))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return j, err
+}
+
+func (ijmr *importJobMysqlRepository) GetInterrupted(ctx context.Context, staleBefore time.Time) ([]domain.ImportJob, error) {
+	rows, err := ijmr.Conn.QueryContext(ctx, `SELECT `+importJobColumns+` WHERE status IN (?, ?) AND updated_at < ? ORDER BY id;`, domain.ImportStatusPending, domain.ImportStatusRunning, staleBefore.UTC())
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []domain.ImportJob{}
+
+	for rows.Next() {
+		j, err := scanImportJob(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *j)
+	}
+
+	return res, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanImportJob(row scanner) (*domain.ImportJob, error) {
+	var j domain.ImportJob
+	var createdAt, updatedAt string
+
+	if err := row.Scan(&j.ID, &j.UUID, &j.Format, &j.DryRun, &j.Status, &j.Processed, &j.Created, &j.Updated, &j.Failed, &j.Error, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	var err error
+
+	if j.CreatedAt, err = time.Parse(datetimeLayout, createdAt); err != nil {
+		return nil, err
+	}
+
+	if j.UpdatedAt, err = time.Parse(datetimeLayout, updatedAt); err != nil {
+		return nil, err
+	}
+
+	return &j, nil
+}
+
+func (ijmr *importJobMysqlRepository) Store(ctx context.Context, j *domain.ImportJob) error {
+	j.UUID = uuid.NewString()
+	j.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	j.UpdatedAt = j.CreatedAt
+
+	query := `INSERT INTO import_job (uuid, format, dry_run, status, processed, created_count, updated_count, failed_count, error, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+	exec, err := ijmr.Conn.ExecContext(ctx, query, j.UUID, j.Format, j.DryRun, j.Status, j.Processed, j.Created, j.Updated, j.Failed, j.Error, j.CreatedAt, j.UpdatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	j.ID, err = exec.LastInsertId()
+
+	return err
+}
+
+func (ijmr *importJobMysqlRepository) Claim(ctx context.Context, j *domain.ImportJob, staleBefore time.Time) (bool, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	query := `UPDATE import_job SET status = ?, error = '', updated_at = ? WHERE id = ? AND (status IN (?, ?) OR (status = ? AND updated_at < ?));`
+
+	exec, err := ijmr.Conn.ExecContext(ctx, query, domain.ImportStatusRunning, now, j.ID, domain.ImportStatusPending, domain.ImportStatusFailed, domain.ImportStatusRunning, staleBefore.UTC())
+
+	if err != nil {
+		return false, err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return false, err
+	}
+
+	if affect != 1 {
+		return false, nil
+	}
+
+	j.Status = domain.ImportStatusRunning
+	j.Error = ""
+	j.UpdatedAt = now
+
+	return true, nil
+}
+
+func (ijmr *importJobMysqlRepository) Update(ctx context.Context, j *domain.ImportJob) error {
+	j.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+
+	query := `UPDATE import_job SET status = ?, processed = ?, created_count = ?, updated_count = ?, failed_count = ?, error = ?, updated_at = ? WHERE id = ?;`
+
+	_, err := ijmr.Conn.ExecContext(ctx, query, j.Status, j.Processed, j.Created, j.Updated, j.Failed, j.Error, j.UpdatedAt, j.ID)
+
+	return err
+}
+
+func (ijmr *importJobMysqlRepository) AddError(ctx context.Context, jobID int64, e domain.ImportRowError) error {
+	_, err := ijmr.Conn.ExecContext(ctx, `INSERT INTO import_job_error (job_id, line, message) VALUES (?, ?, ?);`, jobID, e.Line, e.Message)
+
+	return err
+}
+
+func (ijmr *importJobMysqlRepository) GetErrors(ctx context.Context, jobID int64, limit int) ([]domain.ImportRowError, error) {
+	rows, err := ijmr.Conn.QueryContext(ctx, `SELECT line, message FROM import_job_error WHERE job_id = ? ORDER BY id LIMIT ?;`, jobID, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []domain.ImportRowError{}
+
+	for rows.Next() {
+		var e domain.ImportRowError
+
+		if err := rows.Scan(&e.Line, &e.Message); err != nil {
+			return nil, err
+		}
+
+		res = append(res, e)
+	}
+
+	return res, rows.Err()
+}
+
+// datetimeLayout is how the connection gives the DATETIME columns, in UTC.
+const datetimeLayout = "2006-01-02 15:04:05"
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var importJobRowColumns = []string{"id", "uuid", "format", "dry_run", "status", "processed", "created_count", "updated_count", "failed_count", "error", "created_at", "updated_at"}
+
+const importJobSelect = "SELECT id, uuid, format, dry_run, status, processed, created_count, updated_count, failed_count, error, created_at, updated_at FROM import_job"
+
+func TestGetImportJobByUUID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows(importJobRowColumns).AddRow(3, "j3", "csv", true, "running", 120, 10, 100, 10, "", "2026-01-02 10:00:00", "2026-01-02 10:05:00")
+
+	mock.ExpectQuery(regexp.QuoteMeta(importJobSelect + " WHERE uuid = ?;")).WithArgs("j3").WillReturnRows(rows)
+
+	j, err := NewImportJobMysqlRepository(db).GetByUUID(context.Background(), "j3")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.ImportJob{ID: 3, UUID: "j3", Format: domain.CatalogueFormatCSV, DryRun: true, Status: domain.ImportStatusRunning, Processed: 120, Created: 10, Updated: 100, Failed: 10,
+		CreatedAt: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2026, 1, 2, 10, 5, 0, 0, time.UTC)}, j)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetImportJobByUUIDNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(importJobSelect + " WHERE uuid = ?;")).WithArgs("j3").WillReturnRows(sqlmock.NewRows(importJobRowColumns))
+
+	j, err := NewImportJobMysqlRepository(db).GetByUUID(context.Background(), "j3")
+
+	assert.NoError(t, err)
+	assert.Nil(t, j)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetInterruptedImportJobs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	staleBefore := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows(importJobRowColumns).AddRow(3, "j3", "jsonl", false, "pending", 0, 0, 0, 0, "", "2026-01-02 09:00:00", "2026-01-02 09:00:00")
+
+	mock.ExpectQuery(regexp.QuoteMeta(importJobSelect+" WHERE status IN (?, ?) AND updated_at < ? ORDER BY id;")).WithArgs(domain.ImportStatusPending, domain.ImportStatusRunning, staleBefore).WillReturnRows(rows)
+
+	jobs, err := NewImportJobMysqlRepository(db).GetInterrupted(context.Background(), staleBefore)
+
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, "j3", jobs[0].UUID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreImportJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	j := &domain.ImportJob{Format: domain.CatalogueFormatCSV, Status: domain.ImportStatusPending}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO import_job (uuid, format, dry_run, status, processed, created_count, updated_count, failed_count, error, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+		WithArgs(sqlmock.AnyArg(), domain.CatalogueFormatCSV, false, domain.ImportStatusPending, 0, 0, 0, 0, "", sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(3, 1))
+
+	err = NewImportJobMysqlRepository(db).Store(context.Background(), j)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), j.ID)
+	assert.NotEmpty(t, j.UUID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClaimImportJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	staleBefore := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	j := &domain.ImportJob{ID: 3, Status: domain.ImportStatusFailed, Error: "connection refused"}
+
+	query := regexp.QuoteMeta("UPDATE import_job SET status = ?, error = '', updated_at = ? WHERE id = ? AND (status IN (?, ?) OR (status = ? AND updated_at < ?));")
+
+	mock.ExpectExec(query).WithArgs(domain.ImportStatusRunning, sqlmock.AnyArg(), 3, domain.ImportStatusPending, domain.ImportStatusFailed, domain.ImportStatusRunning, staleBefore).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	claimed, err := NewImportJobMysqlRepository(db).Claim(context.Background(), j, staleBefore)
+
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+	assert.Equal(t, domain.ImportStatusRunning, j.Status)
+	assert.Empty(t, j.Error)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClaimRunningImportJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	j := &domain.ImportJob{ID: 3, Status: domain.ImportStatusRunning}
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE import_job SET status = ?")).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	claimed, err := NewImportJobMysqlRepository(db).Claim(context.Background(), j, time.Now())
+
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdateImportJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	j := &domain.ImportJob{ID: 3, Status: domain.ImportStatusCompleted, Processed: 120, Created: 10, Updated: 100, Failed: 10}
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE import_job SET status = ?, processed = ?, created_count = ?, updated_count = ?, failed_count = ?, error = ?, updated_at = ? WHERE id = ?;")).
+		WithArgs(domain.ImportStatusCompleted, 120, 10, 100, 10, "", sqlmock.AnyArg(), 3).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewImportJobMysqlRepository(db).Update(context.Background(), j)
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestImportJobErrors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO import_job_error (job_id, line, message) VALUES (?, ?, ?);")).WithArgs(3, 7, "product not found").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT line, message FROM import_job_error WHERE job_id = ? ORDER BY id LIMIT ?;")).WithArgs(3, 1000).
+		WillReturnRows(sqlmock.NewRows([]string{"line", "message"}).AddRow(7, "product not found"))
+
+	repo := NewImportJobMysqlRepository(db)
+
+	assert.NoError(t, repo.AddError(context.Background(), 3, domain.ImportRowError{Line: 7, Message: "product not found"}))
+
+	errs, err := repo.GetErrors(context.Background(), 3, 1000)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.ImportRowError{{Line: 7, Message: "product not found"}}, errs)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
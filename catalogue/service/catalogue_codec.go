@@ -0,0 +1,338 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+// csvColumns is the order of the columns of the exported files, the imported
+// ones may have any of them in any order.
+var csvColumns = []string{"uuid", "sku", "name", "detail", "status", "price", "barcode", "attributes", "pictures"}
+
+// utf8BOM is written by some spreadsheets at the beginning of the files.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+type catalogueCodec struct{}
+
+func NewCatalogueCodec() *catalogueCodec {
+	return &catalogueCodec{}
+}
+
+func (cc *catalogueCodec) NewReader(format domain.CatalogueFormat, r io.Reader) (domain.CatalogueReader, error) {
+	br := bufio.NewReader(r)
+
+	if prefix, err := br.Peek(len(utf8BOM)); err == nil && bytes.Equal(prefix, utf8BOM) {
+		br.Discard(len(utf8BOM))
+	}
+
+	switch format {
+	case domain.CatalogueFormatCSV:
+		return newCSVReader(br)
+	case domain.CatalogueFormatJSONL:
+		return &jsonlReader{r: br}, nil
+	}
+
+	return nil, domain.ErrUnsupportedFormat
+}
+
+func (cc *catalogueCodec) NewWriter(format domain.CatalogueFormat, w io.Writer) (domain.CatalogueWriter, error) {
+	bw := bufio.NewWriter(w)
+
+	switch format {
+	case domain.CatalogueFormatCSV:
+		cw := csv.NewWriter(bw)
+
+		if err := cw.Write(csvColumns); err != nil {
+			return nil, err
+		}
+
+		return &csvWriter{w: cw, bw: bw}, nil
+	case domain.CatalogueFormatJSONL:
+		enc := json.NewEncoder(bw)
+		enc.SetEscapeHTML(false)
+
+		return &jsonlWriter{enc: enc, bw: bw}, nil
+	}
+
+	return nil, domain.ErrUnsupportedFormat
+}
+
+type csvReader struct {
+	r       *csv.Reader
+	columns map[string]int
+	line    int64
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+
+	if err == io.EOF {
+		return nil, errors.New("the file has no header")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	known := map[string]bool{}
+	for _, c := range csvColumns {
+		known[c] = true
+	}
+
+	columns := map[string]int{}
+
+	for i, h := range header {
+		name := strings.ToLower(strings.TrimSpace(h))
+
+		if !known[name] {
+			return nil, fmt.Errorf("unknown column %q, the columns are %s", h, strings.Join(csvColumns, ", "))
+		}
+
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("column %q is repeated", h)
+		}
+
+		columns[name] = i
+	}
+
+	return &csvReader{r: cr, columns: columns, line: 1}, nil
+}
+
+// Read skips the blank rows, the spreadsheets often leave some at the end.
+func (cr *csvReader) Read() (*domain.CatalogueRow, int64, error) {
+	for {
+		record, err := cr.r.Read()
+		cr.line++
+
+		if err == io.EOF {
+			return nil, cr.line, io.EOF
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, cr.line, fmt.Errorf("%w: %s", domain.ErrInvalidRow, parseErr.Err.Error())
+		}
+
+		if err != nil {
+			return nil, cr.line, err
+		}
+
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		row, err := cr.parse(record)
+
+		return row, cr.line, err
+	}
+}
+
+func (cr *csvReader) parse(record []string) (*domain.CatalogueRow, error) {
+	get := func(column string) string {
+		if i, ok := cr.columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	row := &domain.CatalogueRow{
+		UUID:    get("uuid"),
+		SKU:     get("sku"),
+		Name:    get("name"),
+		Detail:  get("detail"),
+		Status:  domain.ProductStatus(get("status")),
+		Barcode: get("barcode"),
+	}
+
+	if price := get("price"); price != "" {
+		p, err := strconv.ParseInt(price, 10, 64)
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: price must be a whole number of cents", domain.ErrInvalidRow)
+		}
+
+		row.Price = &p
+	}
+
+	if attributes := get("attributes"); attributes != "" {
+		for _, attribute := range splitEscaped(attributes, ';') {
+			if strings.TrimSpace(attribute) == "" {
+				continue
+			}
+
+			parts := splitEscaped(attribute, ':')
+
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("%w: attributes must obey the format label:value|value;label:value", domain.ErrInvalidRow)
+			}
+
+			a := domain.Attribute{Label: unescape(strings.TrimSpace(parts[0])), Values: []string{}}
+
+			for _, value := range splitEscaped(parts[1], '|') {
+				if value = strings.TrimSpace(value); value != "" {
+					a.Values = append(a.Values, unescape(value))
+				}
+			}
+
+			row.Attributes = append(row.Attributes, a)
+		}
+	}
+
+	if pictures := get("pictures"); pictures != "" {
+		for _, picture := range splitEscaped(pictures, '|') {
+			if picture = strings.TrimSpace(picture); picture != "" {
+				row.Pictures = append(row.Pictures, unescape(picture))
+			}
+		}
+	}
+
+	return row, nil
+}
+
+type jsonlReader struct {
+	r    *bufio.Reader
+	line int64
+}
+
+// Read skips the blank lines.
+func (jr *jsonlReader) Read() (*domain.CatalogueRow, int64, error) {
+	for {
+		b, err := jr.r.ReadBytes('\n')
+
+		if err != nil && err != io.EOF {
+			return nil, jr.line, err
+		}
+
+		if len(b) == 0 && err == io.EOF {
+			return nil, jr.line, io.EOF
+		}
+
+		jr.line++
+
+		if len(bytes.TrimSpace(b)) == 0 {
+			continue
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.DisallowUnknownFields()
+
+		var row domain.CatalogueRow
+
+		if err := dec.Decode(&row); err != nil {
+			return nil, jr.line, fmt.Errorf("%w: %s", domain.ErrInvalidRow, err.Error())
+		}
+
+		return &row, jr.line, nil
+	}
+}
+
+type csvWriter struct {
+	w  *csv.Writer
+	bw *bufio.Writer
+}
+
+func (cw *csvWriter) Write(row *domain.CatalogueRow) error {
+	var price string
+	if row.Price != nil {
+		price = strconv.FormatInt(*row.Price, 10)
+	}
+
+	attributes := make([]string, len(row.Attributes))
+	for i, a := range row.Attributes {
+		values := make([]string, len(a.Values))
+		for j, v := range a.Values {
+			values[j] = escape(v)
+		}
+
+		attributes[i] = escape(a.Label) + ":" + strings.Join(values, "|")
+	}
+
+	pictures := make([]string, len(row.Pictures))
+	for i, p := range row.Pictures {
+		pictures[i] = escape(p)
+	}
+
+	return cw.w.Write([]string{row.UUID, row.SKU, row.Name, row.Detail, string(row.Status), price, row.Barcode, strings.Join(attributes, ";"), strings.Join(pictures, "|")})
+}
+
+func (cw *csvWriter) Flush() error {
+	cw.w.Flush()
+
+	if err := cw.w.Error(); err != nil {
+		return err
+	}
+
+	return cw.bw.Flush()
+}
+
+type jsonlWriter struct {
+	enc *json.Encoder
+	bw  *bufio.Writer
+}
+
+func (jw *jsonlWriter) Write(row *domain.CatalogueRow) error {
+	return jw.enc.Encode(row)
+}
+
+func (jw *jsonlWriter) Flush() error {
+	return jw.bw.Flush()
+}
+
+// escape protects the separators of the attributes and pictures cells with
+// a backslash.
+func escape(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		if r == '\\' || r == ';' || r == ':' || r == '|' {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+func unescape(s string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
+
+// splitEscaped splits s at the separators not escaped, keeping the escapes
+// in the parts.
+func splitEscaped(s string, sep byte) []string {
+	parts := []string{}
+	start := 0
+
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
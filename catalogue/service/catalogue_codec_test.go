@@ -0,0 +1,131 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func readAll(t *testing.T, r domain.CatalogueReader) ([]*domain.CatalogueRow, []int64, []error) {
+	rows, lines, errs := []*domain.CatalogueRow{}, []int64{}, []error{}
+
+	for {
+		row, line, err := r.Read()
+
+		if err == io.EOF {
+			return rows, lines, errs
+		}
+
+		if err != nil && !errors.Is(err, domain.ErrInvalidRow) {
+			t.Fatalf("unexpected error %s", err)
+		}
+
+		rows, lines, errs = append(rows, row), append(lines, line), append(errs, err)
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	file := "\xEF\xBB\xBFName,Price,attributes,pictures,uuid,sku\n" +
+		"Camiseta,1990,Cor:Preta|Branca;Tamanho:P|M,/pictures/a.jpg|/pictures/b.jpg,,\n" +
+		",,,,,\n" +
+		",abc,,,p1,\n" +
+		",2490,,,,P7-M\n"
+
+	r, err := NewCatalogueCodec().NewReader(domain.CatalogueFormatCSV, strings.NewReader(file))
+	assert.NoError(t, err)
+
+	rows, lines, errs := readAll(t, r)
+
+	price := int64(1990)
+	assert.Equal(t, &domain.CatalogueRow{
+		Name:       "Camiseta",
+		Price:      &price,
+		Attributes: []domain.Attribute{{Label: "Cor", Values: []string{"Preta", "Branca"}}, {Label: "Tamanho", Values: []string{"P", "M"}}},
+		Pictures:   []string{"/pictures/a.jpg", "/pictures/b.jpg"},
+	}, rows[0])
+	assert.NoError(t, errs[0])
+
+	assert.Equal(t, []int64{2, 4, 5}, lines)
+	assert.ErrorIs(t, errs[1], domain.ErrInvalidRow)
+	assert.Equal(t, "P7-M", rows[2].SKU)
+	assert.Equal(t, int64(2490), *rows[2].Price)
+}
+
+func TestReadCSVGoesOnAfterAMalformedRow(t *testing.T) {
+	file := "name,detail\n" +
+		"Camiseta,\"bad \"quote\"\n" +
+		"Calça,Jeans\n"
+
+	r, err := NewCatalogueCodec().NewReader(domain.CatalogueFormatCSV, strings.NewReader(file))
+	assert.NoError(t, err)
+
+	rows, _, errs := readAll(t, r)
+
+	assert.ErrorIs(t, errs[0], domain.ErrInvalidRow)
+	assert.Equal(t, "Calça", rows[len(rows)-1].Name)
+}
+
+func TestReadCSVUnknownColumn(t *testing.T) {
+	_, err := NewCatalogueCodec().NewReader(domain.CatalogueFormatCSV, strings.NewReader("name,stock\n"))
+
+	assert.Error(t, err)
+}
+
+func TestReadJSONL(t *testing.T) {
+	file := "{\"uuid\":\"p1\",\"name\":\"Camiseta\",\"pictures\":[]}\n" +
+		"\n" +
+		"{\"uuid\":\"p2\",\"stock\":4}\n" +
+		"{\"sku\":\"P7-M\",\"price\":2490}"
+
+	r, err := NewCatalogueCodec().NewReader(domain.CatalogueFormatJSONL, strings.NewReader(file))
+	assert.NoError(t, err)
+
+	rows, lines, errs := readAll(t, r)
+
+	assert.Equal(t, []int64{1, 3, 4}, lines)
+	assert.Equal(t, &domain.CatalogueRow{UUID: "p1", Name: "Camiseta", Pictures: []string{}}, rows[0])
+	assert.ErrorIs(t, errs[1], domain.ErrInvalidRow)
+	assert.Equal(t, int64(2490), *rows[2].Price)
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	_, err := NewCatalogueCodec().NewReader("xlsx", strings.NewReader(""))
+
+	assert.ErrorIs(t, err, domain.ErrUnsupportedFormat)
+}
+
+func TestWriteAndReadBack(t *testing.T) {
+	price := int64(1990)
+	rows := []*domain.CatalogueRow{
+		{UUID: "p1", Name: "Camiseta, básica", Detail: "Algodão \"pima\"", Status: domain.ProductStatusPublished, Price: &price,
+			Attributes: []domain.Attribute{{Label: "Cor: tom", Values: []string{"Preta|Fosca", "Azul;Claro", "C:\\"}}},
+			Pictures:   []string{"/pictures/a.jpg"}},
+		{UUID: "p1", SKU: "P1-PRETA", Price: &price, Barcode: "7891234567895"},
+	}
+
+	for _, format := range []domain.CatalogueFormat{domain.CatalogueFormatCSV, domain.CatalogueFormatJSONL} {
+		var buf bytes.Buffer
+
+		w, err := NewCatalogueCodec().NewWriter(format, &buf)
+		assert.NoError(t, err)
+
+		for _, row := range rows {
+			assert.NoError(t, w.Write(row))
+		}
+
+		assert.NoError(t, w.Flush())
+
+		r, err := NewCatalogueCodec().NewReader(format, &buf)
+		assert.NoError(t, err)
+
+		read, _, errs := readAll(t, r)
+
+		assert.Equal(t, rows, read, string(format))
+		assert.Equal(t, []error{nil, nil}, errs)
+	}
+}
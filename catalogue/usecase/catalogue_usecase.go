@@ -0,0 +1,463 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+const maxImportSize = 50 << 20
+
+// staleAfter is how long a running job goes without progress before it is
+// taken as interrupted, the runner saves the progress after every row.
+const staleAfter = 5 * time.Minute
+
+const maxReportedErrors = 1000
+
+const exportPageSize = 100
+
+type catalogueUseCase struct {
+	importJobRepo    domain.ImportJobRepository
+	blobStore        domain.BlobStore
+	catalogueCodec   domain.CatalogueCodec
+	productUseCase   domain.ProductUseCase
+	productRepo      domain.ProductRepository
+	variantRepo      domain.VariantRepository
+	productValidator domain.ProductValidator
+	variantValidator domain.VariantValidator
+}
+
+func NewCatalogueUseCase(ijr domain.ImportJobRepository, bs domain.BlobStore, cc domain.CatalogueCodec, puc domain.ProductUseCase, pr domain.ProductRepository, vr domain.VariantRepository, pv domain.ProductValidator, vv domain.VariantValidator) domain.CatalogueUseCase {
+	return &catalogueUseCase{
+		importJobRepo:    ijr,
+		blobStore:        bs,
+		catalogueCodec:   cc,
+		productUseCase:   puc,
+		productRepo:      pr,
+		variantRepo:      vr,
+		productValidator: pv,
+		variantValidator: vv,
+	}
+}
+
+// CreateImport keeps the file in the blob store, so the job can be run, and
+// resumed, by any instance.
+func (cu *catalogueUseCase) CreateImport(ctx context.Context, format domain.CatalogueFormat, r io.Reader, dryRun bool) (*domain.ImportJob, error) {
+	if format != domain.CatalogueFormatCSV && format != domain.CatalogueFormatJSONL {
+		return nil, domain.ErrUnsupportedFormat
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxImportSize+1))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxImportSize {
+		return nil, domain.ErrImportTooLarge
+	}
+
+	job := &domain.ImportJob{Format: format, DryRun: dryRun, Status: domain.ImportStatusPending}
+
+	if err := cu.importJobRepo.Store(ctx, job); err != nil {
+		return nil, err
+	}
+
+	if err := cu.blobStore.Put(ctx, importKey(job), &domain.Blob{Data: data, ContentType: contentType(format)}); err != nil {
+		cu.fail(ctx, job, "the file could not be stored")
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// RunImport goes on from the rows already processed by a previous run. A
+// row is applied before the progress is saved, so when a runner dies in
+// between that single row is applied again.
+func (cu *catalogueUseCase) RunImport(ctx context.Context, uuid string) (*domain.ImportJob, error) {
+	job, err := cu.importJobRepo.GetByUUID(ctx, uuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if job == nil {
+		return nil, domain.ErrImportJobNotFound
+	}
+
+	if job.Status == domain.ImportStatusCompleted {
+		return job, nil
+	}
+
+	claimed, err := cu.importJobRepo.Claim(ctx, job, time.Now().Add(-staleAfter))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !claimed {
+		return nil, domain.ErrImportJobRunning
+	}
+
+	blob, err := cu.blobStore.Get(ctx, importKey(job))
+
+	if err != nil {
+		return nil, cu.interrupt(ctx, job, err)
+	}
+
+	if blob == nil {
+		return job, cu.fail(ctx, job, "the file of the import was not found")
+	}
+
+	reader, err := cu.catalogueCodec.NewReader(job.Format, bytes.NewReader(blob.Data))
+
+	if err != nil {
+		return job, cu.fail(ctx, job, err.Error())
+	}
+
+	var read int64
+
+	for {
+		row, line, err := reader.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil && !errors.Is(err, domain.ErrInvalidRow) {
+			return job, cu.fail(ctx, job, err.Error())
+		}
+
+		read++
+
+		if read <= job.Processed {
+			continue
+		}
+
+		var created bool
+
+		if err == nil {
+			created, err = cu.apply(ctx, row, job.DryRun)
+		}
+
+		switch {
+		case errors.Is(err, domain.ErrInvalidRow):
+			if err := cu.importJobRepo.AddError(ctx, job.ID, domain.ImportRowError{Line: line, Message: truncate(err.Error())}); err != nil {
+				return nil, cu.interrupt(ctx, job, err)
+			}
+
+			job.Failed++
+		case err != nil:
+			return nil, cu.interrupt(ctx, job, err)
+		case created:
+			job.Created++
+		default:
+			job.Updated++
+		}
+
+		job.Processed = read
+
+		if err := cu.importJobRepo.Update(ctx, job); err != nil {
+			return nil, err
+		}
+	}
+
+	job.Status = domain.ImportStatusCompleted
+
+	if err := cu.importJobRepo.Update(ctx, job); err != nil {
+		return nil, err
+	}
+
+	if err := cu.blobStore.Delete(ctx, importKey(job)); err != nil {
+		log.Printf("Error trying to remove the file of the import %s: %s", job.UUID, err.Error())
+	}
+
+	return job, nil
+}
+
+// apply tells whether the row created a product, the rows that can not be
+// applied give an error wrapping ErrInvalidRow.
+func (cu *catalogueUseCase) apply(ctx context.Context, row *domain.CatalogueRow, dryRun bool) (bool, error) {
+	if row.SKU != "" {
+		return false, cu.applyVariant(ctx, row, dryRun)
+	}
+
+	if row.Barcode != "" {
+		return false, invalidRow("barcode can only be given with a sku")
+	}
+
+	product := &domain.Product{Pictures: []string{}, Attributes: []domain.Attribute{}, Status: domain.ProductStatusDraft}
+
+	if row.UUID != "" {
+		existing, err := cu.productRepo.GetByUUID(ctx, row.UUID)
+
+		if err != nil {
+			return false, err
+		}
+
+		if existing == nil {
+			return false, invalidRow("product not found")
+		}
+
+		product = existing
+	}
+
+	if row.Name != "" {
+		product.Name = row.Name
+	}
+
+	if row.Detail != "" {
+		product.Detail = row.Detail
+	}
+
+	if row.Status != "" {
+		product.Status = row.Status
+	}
+
+	if row.Price != nil {
+		product.Price = *row.Price
+	}
+
+	if len(row.Attributes) > 0 {
+		product.Attributes = row.Attributes
+	}
+
+	if len(row.Pictures) > 0 {
+		product.Pictures = row.Pictures
+	}
+
+	if isValid, message := cu.productValidator.Validate(ctx, product); !isValid {
+		return false, invalidRow(string(message))
+	}
+
+	if dryRun {
+		return row.UUID == "", nil
+	}
+
+	if row.UUID == "" {
+		return true, cu.productUseCase.Create(ctx, product)
+	}
+
+	updated, err := cu.productUseCase.Update(ctx, product)
+
+	if errors.Is(err, domain.ErrVersionConflict) || (err == nil && updated == nil) {
+		return false, invalidRow("product was changed or removed during the import")
+	}
+
+	return false, err
+}
+
+func (cu *catalogueUseCase) applyVariant(ctx context.Context, row *domain.CatalogueRow, dryRun bool) error {
+	if row.Name != "" || row.Detail != "" || row.Status != "" || len(row.Attributes) > 0 || len(row.Pictures) > 0 {
+		return invalidRow("a row with sku can only change the price and the barcode")
+	}
+
+	variant, err := cu.variantRepo.GetBySKU(ctx, row.SKU)
+
+	if err != nil {
+		return err
+	}
+
+	if variant == nil {
+		return invalidRow("variant not found")
+	}
+
+	if row.UUID != "" {
+		product, err := cu.productRepo.GetByUUID(ctx, row.UUID)
+
+		if err != nil {
+			return err
+		}
+
+		if product == nil || product.ID != variant.ProductID {
+			return invalidRow("variant not found in the product")
+		}
+	}
+
+	barcode := variant.Barcode
+
+	if row.Price != nil {
+		variant.Price = *row.Price
+	}
+
+	if row.Barcode != "" {
+		variant.Barcode = row.Barcode
+	}
+
+	if isValid, message := cu.variantValidator.Validate(ctx, variant); !isValid {
+		return invalidRow(string(message))
+	}
+
+	if variant.Barcode != barcode {
+		other, err := cu.variantRepo.GetByBarcode(ctx, variant.Barcode)
+
+		if err != nil {
+			return err
+		}
+
+		if other != nil {
+			return invalidRow(domain.ErrBarcodeTaken.Error())
+		}
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	if err := cu.variantRepo.Update(ctx, variant); err != nil {
+		return err
+	}
+
+	// the variant is written, the row is not failed for the cached copies
+	if err := cu.productRepo.Touch(ctx, []int64{variant.ProductID}); err != nil {
+		log.Printf("Error trying to touch the product of the variant %s: %s", variant.SKU, err.Error())
+	}
+
+	return nil
+}
+
+// ResumeInterrupted runs, one after the other, the jobs left behind by the
+// runners that stopped.
+func (cu *catalogueUseCase) ResumeInterrupted(ctx context.Context) (int, error) {
+	jobs, err := cu.importJobRepo.GetInterrupted(ctx, time.Now().Add(-staleAfter))
+
+	if err != nil {
+		return 0, err
+	}
+
+	resumed := 0
+
+	for _, job := range jobs {
+		if _, err := cu.RunImport(ctx, job.UUID); err != nil {
+			if !errors.Is(err, domain.ErrImportJobRunning) {
+				log.Printf("Error trying to resume the import %s: %s", job.UUID, err.Error())
+			}
+
+			continue
+		}
+
+		resumed++
+	}
+
+	return resumed, nil
+}
+
+func (cu *catalogueUseCase) GetImport(ctx context.Context, uuid string) (*domain.ImportJob, error) {
+	job, err := cu.importJobRepo.GetByUUID(ctx, uuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if job == nil {
+		return nil, domain.ErrImportJobNotFound
+	}
+
+	if job.Errors, err = cu.importJobRepo.GetErrors(ctx, job.ID, maxReportedErrors); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// Export writes each product of every status followed by its variants, in
+// the same format the import reads.
+func (cu *catalogueUseCase) Export(ctx context.Context, format domain.CatalogueFormat, w io.Writer) error {
+	writer, err := cu.catalogueCodec.NewWriter(format, w)
+
+	if err != nil {
+		return err
+	}
+
+	q := domain.ProductQuery{Limit: exportPageSize, Sort: domain.ProductSortName, Attributes: map[string][]string{}}
+
+	for {
+		page, err := cu.productRepo.List(ctx, q)
+
+		if err != nil {
+			return err
+		}
+
+		for _, product := range page.Products {
+			price := product.Price
+
+			if err := writer.Write(&domain.CatalogueRow{UUID: product.UUID, Name: product.Name, Detail: product.Detail, Status: product.Status, Price: &price, Attributes: product.Attributes, Pictures: product.Pictures}); err != nil {
+				return err
+			}
+
+			variants, err := cu.variantRepo.GetByProductID(ctx, product.ID)
+
+			if err != nil {
+				return err
+			}
+
+			for _, variant := range variants {
+				price := variant.Price
+
+				if err := writer.Write(&domain.CatalogueRow{UUID: product.UUID, SKU: variant.SKU, Price: &price, Barcode: variant.Barcode}); err != nil {
+					return err
+				}
+			}
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+
+		q.Cursor = page.NextCursor
+	}
+
+	return writer.Flush()
+}
+
+// fail ends a job that can not go on without a new file, the error is only
+// about saving it.
+func (cu *catalogueUseCase) fail(ctx context.Context, job *domain.ImportJob, message string) error {
+	job.Status = domain.ImportStatusFailed
+	job.Error = truncate(message)
+
+	return cu.importJobRepo.Update(ctx, job)
+}
+
+// interrupt marks a job that stopped for a reason outside the file, so it
+// can be resumed later.
+func (cu *catalogueUseCase) interrupt(ctx context.Context, job *domain.ImportJob, err error) error {
+	if updateErr := cu.fail(ctx, job, err.Error()); updateErr != nil {
+		log.Printf("Error trying to mark the import %s as failed: %s", job.UUID, updateErr.Error())
+	}
+
+	return err
+}
+
+func invalidRow(message string) error {
+	return fmt.Errorf("%w: %s", domain.ErrInvalidRow, message)
+}
+
+func truncate(message string) string {
+	runes := []rune(message)
+
+	if len(runes) > 500 {
+		return string(runes[:500])
+	}
+
+	return message
+}
+
+func importKey(job *domain.ImportJob) string {
+	return fmt.Sprintf("imports/%s.%s", job.UUID, job.Format)
+}
+
+func contentType(format domain.CatalogueFormat) string {
+	if format == domain.CatalogueFormatJSONL {
+		return "application/x-ndjson"
+	}
+
+	return "text/csv"
+}
@@ -0,0 +1,307 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func TestCreateImportUnsupportedFormat(t *testing.T) {
+	_, err := NewCatalogueUseCase(nil, nil, nil, nil, nil, nil, nil, nil).CreateImport(context.Background(), "xlsx", strings.NewReader(""), false)
+
+	assert.ErrorIs(t, err, domain.ErrUnsupportedFormat)
+}
+
+func TestCreateImport(t *testing.T) {
+	mockImportJobRepo := new(mocks.MockImportJobRepository)
+	mockBlobStore := new(mocks.MockBlobStore)
+
+	mockImportJobRepo.On("Store", mock.Anything, &domain.ImportJob{Format: domain.CatalogueFormatCSV, DryRun: true, Status: domain.ImportStatusPending}).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.ImportJob).UUID = "j3"
+	}).Return(nil)
+	mockBlobStore.On("Put", mock.Anything, "imports/j3.csv", &domain.Blob{Data: []byte("name\nShirt\n"), ContentType: "text/csv"}).Return(nil)
+
+	job, err := NewCatalogueUseCase(mockImportJobRepo, mockBlobStore, nil, nil, nil, nil, nil, nil).CreateImport(context.Background(), domain.CatalogueFormatCSV, strings.NewReader("name\nShirt\n"), true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "j3", job.UUID)
+	mockBlobStore.AssertExpectations(t)
+}
+
+func TestCreateImportFailsToStoreTheFile(t *testing.T) {
+	mockImportJobRepo := new(mocks.MockImportJobRepository)
+	mockBlobStore := new(mocks.MockBlobStore)
+
+	mockImportJobRepo.On("Store", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.ImportJob).UUID = "j3"
+	}).Return(nil)
+	mockBlobStore.On("Put", mock.Anything, "imports/j3.jsonl", mock.Anything).Return(errors.New("error message"))
+	mockImportJobRepo.On("Update", mock.Anything, mock.MatchedBy(func(j *domain.ImportJob) bool { return j.Status == domain.ImportStatusFailed })).Return(nil)
+
+	_, err := NewCatalogueUseCase(mockImportJobRepo, mockBlobStore, nil, nil, nil, nil, nil, nil).CreateImport(context.Background(), domain.CatalogueFormatJSONL, strings.NewReader("{}"), false)
+
+	assert.Error(t, err)
+	mockImportJobRepo.AssertExpectations(t)
+}
+
+func TestRunImportNotFound(t *testing.T) {
+	mockImportJobRepo := new(mocks.MockImportJobRepository)
+
+	mockImportJobRepo.On("GetByUUID", mock.Anything, "j3").Return(nil, nil)
+
+	_, err := NewCatalogueUseCase(mockImportJobRepo, nil, nil, nil, nil, nil, nil, nil).RunImport(context.Background(), "j3")
+
+	assert.ErrorIs(t, err, domain.ErrImportJobNotFound)
+}
+
+func TestRunImportCompleted(t *testing.T) {
+	mockImportJobRepo := new(mocks.MockImportJobRepository)
+
+	job := &domain.ImportJob{ID: 3, UUID: "j3", Status: domain.ImportStatusCompleted}
+
+	mockImportJobRepo.On("GetByUUID", mock.Anything, "j3").Return(job, nil)
+
+	res, err := NewCatalogueUseCase(mockImportJobRepo, nil, nil, nil, nil, nil, nil, nil).RunImport(context.Background(), "j3")
+
+	assert.NoError(t, err)
+	assert.Equal(t, job, res)
+	mockImportJobRepo.AssertNotCalled(t, "Claim", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRunImportAlreadyRunning(t *testing.T) {
+	mockImportJobRepo := new(mocks.MockImportJobRepository)
+
+	job := &domain.ImportJob{ID: 3, UUID: "j3", Status: domain.ImportStatusRunning}
+
+	mockImportJobRepo.On("GetByUUID", mock.Anything, "j3").Return(job, nil)
+	mockImportJobRepo.On("Claim", mock.Anything, job, mock.Anything).Return(false, nil)
+
+	_, err := NewCatalogueUseCase(mockImportJobRepo, nil, nil, nil, nil, nil, nil, nil).RunImport(context.Background(), "j3")
+
+	assert.ErrorIs(t, err, domain.ErrImportJobRunning)
+}
+
+func TestRunImportInvalidFile(t *testing.T) {
+	mockImportJobRepo := new(mocks.MockImportJobRepository)
+	mockBlobStore := new(mocks.MockBlobStore)
+	mockCatalogueCodec := new(mocks.MockCatalogueCodec)
+
+	job := &domain.ImportJob{ID: 3, UUID: "j3", Format: domain.CatalogueFormatCSV, Status: domain.ImportStatusPending}
+
+	mockImportJobRepo.On("GetByUUID", mock.Anything, "j3").Return(job, nil)
+	mockImportJobRepo.On("Claim", mock.Anything, job, mock.Anything).Return(true, nil)
+	mockBlobStore.On("Get", mock.Anything, "imports/j3.csv").Return(&domain.Blob{Data: []byte("")}, nil)
+	mockCatalogueCodec.On("NewReader", domain.CatalogueFormatCSV, mock.Anything).Return(nil, errors.New("the file has no header"))
+	mockImportJobRepo.On("Update", mock.Anything, job).Return(nil)
+
+	res, err := NewCatalogueUseCase(mockImportJobRepo, mockBlobStore, mockCatalogueCodec, nil, nil, nil, nil, nil).RunImport(context.Background(), "j3")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ImportStatusFailed, res.Status)
+	assert.Equal(t, "the file has no header", res.Error)
+}
+
+func TestRunImport(t *testing.T) {
+	mockImportJobRepo := new(mocks.MockImportJobRepository)
+	mockBlobStore := new(mocks.MockBlobStore)
+	mockCatalogueCodec := new(mocks.MockCatalogueCodec)
+	mockReader := new(mocks.MockCatalogueReader)
+	mockProductUsecase := new(mocks.MockProductUsecase)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+	mockProductValidator := new(mocks.MockProductValidator)
+	mockVariantValidator := new(mocks.MockVariantValidator)
+
+	// the first row was applied by a previous run
+	job := &domain.ImportJob{ID: 3, UUID: "j3", Format: domain.CatalogueFormatCSV, Status: domain.ImportStatusRunning, Processed: 1, Created: 1}
+
+	mockImportJobRepo.On("GetByUUID", mock.Anything, "j3").Return(job, nil)
+	mockImportJobRepo.On("Claim", mock.Anything, job, mock.Anything).Return(true, nil)
+	mockBlobStore.On("Get", mock.Anything, "imports/j3.csv").Return(&domain.Blob{Data: []byte("data")}, nil)
+	mockCatalogueCodec.On("NewReader", domain.CatalogueFormatCSV, bytes.NewReader([]byte("data"))).Return(mockReader, nil)
+
+	mockReader.On("Read").Return(&domain.CatalogueRow{Name: "Applied"}, int64(2), nil).Once()
+	mockReader.On("Read").Return(nil, int64(3), fmt.Errorf("%w: price must be a whole number of cents", domain.ErrInvalidRow)).Once()
+	mockReader.On("Read").Return(&domain.CatalogueRow{Name: "Shirt", Detail: "Cotton", Price: int64Ptr(4990)}, int64(4), nil).Once()
+	mockReader.On("Read").Return(&domain.CatalogueRow{UUID: "p1", Status: domain.ProductStatusArchived}, int64(5), nil).Once()
+	mockReader.On("Read").Return(&domain.CatalogueRow{UUID: "p2", Name: "Pants"}, int64(6), nil).Once()
+	mockReader.On("Read").Return(&domain.CatalogueRow{SKU: "P1-M", Price: int64Ptr(3990)}, int64(7), nil).Once()
+	mockReader.On("Read").Return(nil, int64(8), io.EOF).Once()
+
+	created := &domain.Product{Name: "Shirt", Detail: "Cotton", Price: 4990, Status: domain.ProductStatusDraft, Pictures: []string{}, Attributes: []domain.Attribute{}}
+	mockProductValidator.On("Validate", mock.Anything, created).Return(true, "")
+	mockProductUsecase.On("Create", mock.Anything, created).Return(nil)
+
+	existing := &domain.Product{ID: 1, UUID: "p1", Name: "Shoe", Detail: "Leather", Status: domain.ProductStatusPublished, Version: 4}
+	updated := &domain.Product{ID: 1, UUID: "p1", Name: "Shoe", Detail: "Leather", Status: domain.ProductStatusArchived, Version: 4}
+	mockProductRepo.On("GetByUUID", mock.Anything, "p1").Return(existing, nil)
+	mockProductValidator.On("Validate", mock.Anything, updated).Return(true, "")
+	mockProductUsecase.On("Update", mock.Anything, updated).Return(updated, nil)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "p2").Return(nil, nil)
+
+	variant := &domain.Variant{ID: 9, ProductID: 1, SKU: "P1-M", Price: 3990, Barcode: "7891234567895"}
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P1-M").Return(&domain.Variant{ID: 9, ProductID: 1, SKU: "P1-M", Price: 2990, Barcode: "7891234567895"}, nil)
+	mockVariantValidator.On("Validate", mock.Anything, variant).Return(true, "")
+	mockVariantRepo.On("Update", mock.Anything, variant).Return(nil)
+	mockProductRepo.On("Touch", mock.Anything, []int64{1}).Return(nil)
+
+	mockImportJobRepo.On("AddError", mock.Anything, int64(3), domain.ImportRowError{Line: 3, Message: "invalid row: price must be a whole number of cents"}).Return(nil)
+	mockImportJobRepo.On("AddError", mock.Anything, int64(3), domain.ImportRowError{Line: 6, Message: "invalid row: product not found"}).Return(nil)
+	mockImportJobRepo.On("Update", mock.Anything, job).Return(nil)
+	mockBlobStore.On("Delete", mock.Anything, "imports/j3.csv").Return(nil)
+
+	res, err := NewCatalogueUseCase(mockImportJobRepo, mockBlobStore, mockCatalogueCodec, mockProductUsecase, mockProductRepo, mockVariantRepo, mockProductValidator, mockVariantValidator).RunImport(context.Background(), "j3")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ImportStatusCompleted, res.Status)
+	assert.Equal(t, int64(6), res.Processed)
+	assert.Equal(t, int64(2), res.Created)
+	assert.Equal(t, int64(2), res.Updated)
+	assert.Equal(t, int64(2), res.Failed)
+	mockProductUsecase.AssertNumberOfCalls(t, "Create", 1)
+	mockImportJobRepo.AssertNumberOfCalls(t, "Update", 6)
+	mockVariantRepo.AssertExpectations(t)
+	mockProductRepo.AssertCalled(t, "Touch", mock.Anything, []int64{1})
+}
+
+func TestRunImportDryRun(t *testing.T) {
+	mockImportJobRepo := new(mocks.MockImportJobRepository)
+	mockBlobStore := new(mocks.MockBlobStore)
+	mockCatalogueCodec := new(mocks.MockCatalogueCodec)
+	mockReader := new(mocks.MockCatalogueReader)
+	mockProductUsecase := new(mocks.MockProductUsecase)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+	mockProductValidator := new(mocks.MockProductValidator)
+	mockVariantValidator := new(mocks.MockVariantValidator)
+
+	job := &domain.ImportJob{ID: 3, UUID: "j3", Format: domain.CatalogueFormatJSONL, DryRun: true, Status: domain.ImportStatusPending}
+
+	mockImportJobRepo.On("GetByUUID", mock.Anything, "j3").Return(job, nil)
+	mockImportJobRepo.On("Claim", mock.Anything, job, mock.Anything).Return(true, nil)
+	mockBlobStore.On("Get", mock.Anything, "imports/j3.jsonl").Return(&domain.Blob{Data: []byte("data")}, nil)
+	mockCatalogueCodec.On("NewReader", domain.CatalogueFormatJSONL, mock.Anything).Return(mockReader, nil)
+
+	mockReader.On("Read").Return(&domain.CatalogueRow{Name: "Shirt"}, int64(1), nil).Once()
+	mockReader.On("Read").Return(&domain.CatalogueRow{SKU: "P1-M", Barcode: "7891234567888"}, int64(2), nil).Once()
+	mockReader.On("Read").Return(nil, int64(3), io.EOF).Once()
+
+	mockProductValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P1-M").Return(&domain.Variant{ID: 9, ProductID: 1, SKU: "P1-M", Barcode: "7891234567895"}, nil)
+	mockVariantValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockVariantRepo.On("GetByBarcode", mock.Anything, "7891234567888").Return(&domain.Variant{ID: 10, SKU: "P2-M"}, nil)
+
+	mockImportJobRepo.On("AddError", mock.Anything, int64(3), domain.ImportRowError{Line: 2, Message: "invalid row: barcode already in use"}).Return(nil)
+	mockImportJobRepo.On("Update", mock.Anything, job).Return(nil)
+	mockBlobStore.On("Delete", mock.Anything, "imports/j3.jsonl").Return(nil)
+
+	res, err := NewCatalogueUseCase(mockImportJobRepo, mockBlobStore, mockCatalogueCodec, mockProductUsecase, nil, mockVariantRepo, mockProductValidator, mockVariantValidator).RunImport(context.Background(), "j3")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), res.Created)
+	assert.Equal(t, int64(1), res.Failed)
+	mockProductUsecase.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockVariantRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestRunImportInterrupted(t *testing.T) {
+	mockImportJobRepo := new(mocks.MockImportJobRepository)
+	mockBlobStore := new(mocks.MockBlobStore)
+	mockCatalogueCodec := new(mocks.MockCatalogueCodec)
+	mockReader := new(mocks.MockCatalogueReader)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	job := &domain.ImportJob{ID: 3, UUID: "j3", Format: domain.CatalogueFormatCSV, Status: domain.ImportStatusPending}
+
+	mockImportJobRepo.On("GetByUUID", mock.Anything, "j3").Return(job, nil)
+	mockImportJobRepo.On("Claim", mock.Anything, job, mock.Anything).Return(true, nil)
+	mockBlobStore.On("Get", mock.Anything, "imports/j3.csv").Return(&domain.Blob{Data: []byte("data")}, nil)
+	mockCatalogueCodec.On("NewReader", domain.CatalogueFormatCSV, mock.Anything).Return(mockReader, nil)
+	mockReader.On("Read").Return(&domain.CatalogueRow{UUID: "p1"}, int64(2), nil).Once()
+	mockProductRepo.On("GetByUUID", mock.Anything, "p1").Return(nil, errors.New("connection refused"))
+	mockImportJobRepo.On("Update", mock.Anything, job).Return(nil)
+
+	_, err := NewCatalogueUseCase(mockImportJobRepo, mockBlobStore, mockCatalogueCodec, nil, mockProductRepo, nil, nil, nil).RunImport(context.Background(), "j3")
+
+	assert.Error(t, err)
+	assert.Equal(t, domain.ImportStatusFailed, job.Status)
+	assert.Equal(t, int64(0), job.Processed)
+	mockBlobStore.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestResumeInterrupted(t *testing.T) {
+	mockImportJobRepo := new(mocks.MockImportJobRepository)
+
+	mockImportJobRepo.On("GetInterrupted", mock.Anything, mock.Anything).Return([]domain.ImportJob{{UUID: "j3"}, {UUID: "j4"}}, nil)
+	mockImportJobRepo.On("GetByUUID", mock.Anything, "j3").Return(&domain.ImportJob{ID: 3, UUID: "j3", Status: domain.ImportStatusCompleted}, nil)
+	mockImportJobRepo.On("GetByUUID", mock.Anything, "j4").Return(nil, errors.New("error message"))
+
+	resumed, err := NewCatalogueUseCase(mockImportJobRepo, nil, nil, nil, nil, nil, nil, nil).ResumeInterrupted(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resumed)
+}
+
+func TestGetImport(t *testing.T) {
+	mockImportJobRepo := new(mocks.MockImportJobRepository)
+
+	mockImportJobRepo.On("GetByUUID", mock.Anything, "j3").Return(&domain.ImportJob{ID: 3, UUID: "j3"}, nil)
+	mockImportJobRepo.On("GetErrors", mock.Anything, int64(3), 1000).Return([]domain.ImportRowError{{Line: 7, Message: "invalid row: product not found"}}, nil)
+
+	job, err := NewCatalogueUseCase(mockImportJobRepo, nil, nil, nil, nil, nil, nil, nil).GetImport(context.Background(), "j3")
+
+	assert.NoError(t, err)
+	assert.Len(t, job.Errors, 1)
+}
+
+func TestGetImportNotFound(t *testing.T) {
+	mockImportJobRepo := new(mocks.MockImportJobRepository)
+
+	mockImportJobRepo.On("GetByUUID", mock.Anything, "j3").Return(nil, nil)
+
+	_, err := NewCatalogueUseCase(mockImportJobRepo, nil, nil, nil, nil, nil, nil, nil).GetImport(context.Background(), "j3")
+
+	assert.ErrorIs(t, err, domain.ErrImportJobNotFound)
+}
+
+func TestExport(t *testing.T) {
+	mockCatalogueCodec := new(mocks.MockCatalogueCodec)
+	mockWriter := new(mocks.MockCatalogueWriter)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	var buf bytes.Buffer
+
+	mockCatalogueCodec.On("NewWriter", domain.CatalogueFormatCSV, &buf).Return(mockWriter, nil)
+
+	q := domain.ProductQuery{Limit: 100, Sort: domain.ProductSortName, Attributes: map[string][]string{}}
+	mockProductRepo.On("List", mock.Anything, q).Return(&domain.ProductPage{Products: []domain.Product{{ID: 1, UUID: "p1", Name: "Shirt", Price: 4990, Status: domain.ProductStatusDraft}}, NextCursor: "next"}, nil)
+	q.Cursor = "next"
+	mockProductRepo.On("List", mock.Anything, q).Return(&domain.ProductPage{Products: []domain.Product{{ID: 2, UUID: "p2", Name: "Shoe", Price: 9990, Status: domain.ProductStatusPublished}}}, nil)
+
+	mockVariantRepo.On("GetByProductID", mock.Anything, int64(1)).Return([]domain.Variant{}, nil)
+	mockVariantRepo.On("GetByProductID", mock.Anything, int64(2)).Return([]domain.Variant{{SKU: "P2-40", Price: 8990, Barcode: "7891234567895"}}, nil)
+
+	mockWriter.On("Write", &domain.CatalogueRow{UUID: "p1", Name: "Shirt", Status: domain.ProductStatusDraft, Price: int64Ptr(4990)}).Return(nil).Once()
+	mockWriter.On("Write", &domain.CatalogueRow{UUID: "p2", Name: "Shoe", Status: domain.ProductStatusPublished, Price: int64Ptr(9990)}).Return(nil).Once()
+	mockWriter.On("Write", &domain.CatalogueRow{UUID: "p2", SKU: "P2-40", Price: int64Ptr(8990), Barcode: "7891234567895"}).Return(nil).Once()
+	mockWriter.On("Flush").Return(nil)
+
+	err := NewCatalogueUseCase(nil, nil, mockCatalogueCodec, nil, mockProductRepo, mockVariantRepo, nil, nil).Export(context.Background(), domain.CatalogueFormatCSV, &buf)
+
+	assert.NoError(t, err)
+	mockWriter.AssertExpectations(t)
+}
@@ -0,0 +1,256 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type categoryAdminHandler struct {
+	CategoryUseCase     domain.CategoryUseCase
+	CategoryValidator   domain.CategoryValidator
+	CollectionUseCase   domain.CollectionUseCase
+	CollectionValidator domain.CollectionValidator
+}
+
+type categoryRequest struct {
+	Slug   string `json:"slug"`
+	Name   string `json:"name"`
+	Parent string `json:"parent"`
+}
+
+func NewCategoryAdminHandler(e *echo.Echo, cuc domain.CategoryUseCase, cv domain.CategoryValidator, coluc domain.CollectionUseCase, colv domain.CollectionValidator, ts domain.TokenService) *categoryAdminHandler {
+	handler := &categoryAdminHandler{
+		CategoryUseCase:     cuc,
+		CategoryValidator:   cv,
+		CollectionUseCase:   coluc,
+		CollectionValidator: colv,
+	}
+
+	admin := _tokenPresentation.NewAdminMiddleware(ts)
+
+	e.POST("/admin/categories", handler.Create, admin)
+	e.PUT("/admin/categories/:slug", handler.Update, admin)
+	e.PUT("/admin/categories/:slug/parent", handler.Move, admin)
+	e.PUT("/admin/products/:uuid/categories", handler.SetProductCategories, admin)
+	e.POST("/admin/collections", handler.CreateCollection, admin)
+	e.PUT("/admin/collections/:slug", handler.UpdateCollection, admin)
+
+	return handler
+}
+
+func (cah *categoryAdminHandler) Create(c echo.Context) error {
+	var req categoryRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	category := domain.Category{Slug: req.Slug, Name: req.Name}
+
+	ctx := c.Request().Context()
+
+	isValid, message := cah.CategoryValidator.Validate(ctx, &category)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	err := cah.CategoryUseCase.Create(ctx, &category, req.Parent)
+
+	if errors.Is(err, domain.ErrSlugTaken) {
+		return c.JSON(http.StatusConflict, "slug already in use")
+	}
+
+	if errors.Is(err, domain.ErrCategoryNotFound) {
+		return c.JSON(http.StatusBadRequest, "parent category not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to create a category: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to create the category")
+	}
+
+	return c.JSON(http.StatusCreated, category)
+}
+
+func (cah *categoryAdminHandler) Update(c echo.Context) error {
+	slug := c.Param("slug")
+
+	if slug == "" {
+		return c.JSON(http.StatusBadRequest, "slug param is not valid")
+	}
+
+	var req categoryRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	category := domain.Category{Slug: req.Slug, Name: req.Name}
+
+	ctx := c.Request().Context()
+
+	isValid, message := cah.CategoryValidator.Validate(ctx, &category)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	updated, err := cah.CategoryUseCase.Update(ctx, slug, &category)
+
+	if errors.Is(err, domain.ErrSlugTaken) {
+		return c.JSON(http.StatusConflict, "slug already in use")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to update a category: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to update the category")
+	}
+
+	if updated == nil {
+		return c.JSON(http.StatusNotFound, "category not found")
+	}
+
+	return c.JSON(http.StatusOK, updated)
+}
+
+func (cah *categoryAdminHandler) Move(c echo.Context) error {
+	slug := c.Param("slug")
+
+	if slug == "" {
+		return c.JSON(http.StatusBadRequest, "slug param is not valid")
+	}
+
+	var req categoryRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	moved, err := cah.CategoryUseCase.Move(c.Request().Context(), slug, req.Parent)
+
+	if errors.Is(err, domain.ErrCategoryCycle) {
+		return c.JSON(http.StatusBadRequest, "category can not be moved under itself")
+	}
+
+	if errors.Is(err, domain.ErrCategoryNotFound) {
+		return c.JSON(http.StatusBadRequest, "parent category not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to move a category: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to move the category")
+	}
+
+	if moved == nil {
+		return c.JSON(http.StatusNotFound, "category not found")
+	}
+
+	return c.JSON(http.StatusOK, moved)
+}
+
+func (cah *categoryAdminHandler) SetProductCategories(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	var req struct {
+		Categories []string `json:"categories"`
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	err := cah.CategoryUseCase.SetProductCategories(c.Request().Context(), uuid, req.Categories)
+
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	if errors.Is(err, domain.ErrCategoryNotFound) {
+		return c.JSON(http.StatusBadRequest, "category not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to set the product categories: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to set the product categories")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (cah *categoryAdminHandler) CreateCollection(c echo.Context) error {
+	var collection domain.Collection
+
+	if err := c.Bind(&collection); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	ctx := c.Request().Context()
+
+	isValid, message := cah.CollectionValidator.Validate(ctx, &collection)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	err := cah.CollectionUseCase.Create(ctx, &collection)
+
+	if errors.Is(err, domain.ErrSlugTaken) {
+		return c.JSON(http.StatusConflict, "slug already in use")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to create a collection: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to create the collection")
+	}
+
+	return c.JSON(http.StatusCreated, collection)
+}
+
+func (cah *categoryAdminHandler) UpdateCollection(c echo.Context) error {
+	slug := c.Param("slug")
+
+	if slug == "" {
+		return c.JSON(http.StatusBadRequest, "slug param is not valid")
+	}
+
+	var collection domain.Collection
+
+	if err := c.Bind(&collection); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	ctx := c.Request().Context()
+
+	isValid, message := cah.CollectionValidator.Validate(ctx, &collection)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	updated, err := cah.CollectionUseCase.Update(ctx, slug, &collection)
+
+	if errors.Is(err, domain.ErrSlugTaken) {
+		return c.JSON(http.StatusConflict, "slug already in use")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to update a collection: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to update the collection")
+	}
+
+	if updated == nil {
+		return c.JSON(http.StatusNotFound, "collection not found")
+	}
+
+	return c.JSON(http.StatusOK, updated)
+}
@@ -0,0 +1,275 @@
+package presentation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateWrongBody(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/categories", strings.NewReader("invalidbody"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewCategoryAdminHandler(echo.New(), nil, nil, nil, nil, nil)
+
+	handler.Create(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestCreateInvalid(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/categories", strings.NewReader("{\"slug\":\"Shirts\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockCategoryValidator := new(mocks.MockCategoryValidator)
+
+	mockCategoryValidator.On("Validate", mock.Anything, &domain.Category{Slug: "Shirts"}).Return(false, "category's name can not be empty")
+
+	handler := NewCategoryAdminHandler(echo.New(), nil, mockCategoryValidator, nil, nil, nil)
+
+	handler.Create(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "\"category's name can not be empty\"\n", rec.Body.String())
+}
+
+func TestCreateSlugTaken(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/categories", strings.NewReader("{\"slug\":\"shirts\",\"name\":\"Shirts\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockCategoryUsecase := new(mocks.MockCategoryUseCase)
+	mockCategoryValidator := new(mocks.MockCategoryValidator)
+
+	mockCategoryValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockCategoryUsecase.On("Create", mock.Anything, mock.Anything, "").Return(domain.ErrSlugTaken)
+
+	handler := NewCategoryAdminHandler(echo.New(), mockCategoryUsecase, mockCategoryValidator, nil, nil, nil)
+
+	handler.Create(c)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestCreate(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/categories", strings.NewReader("{\"slug\":\"shirts\",\"name\":\"Shirts\",\"parent\":\"clothes\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockCategoryUsecase := new(mocks.MockCategoryUseCase)
+	mockCategoryValidator := new(mocks.MockCategoryValidator)
+
+	mockCategoryValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockCategoryUsecase.On("Create", mock.Anything, &domain.Category{Slug: "shirts", Name: "Shirts"}, "clothes").Return(nil)
+
+	handler := NewCategoryAdminHandler(echo.New(), mockCategoryUsecase, mockCategoryValidator, nil, nil, nil)
+
+	handler.Create(c)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestUpdateNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/categories/:slug", strings.NewReader("{\"slug\":\"shirts\",\"name\":\"Shirts\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("slug")
+	c.SetParamValues("shirts")
+
+	mockCategoryUsecase := new(mocks.MockCategoryUseCase)
+	mockCategoryValidator := new(mocks.MockCategoryValidator)
+
+	mockCategoryValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockCategoryUsecase.On("Update", mock.Anything, "shirts", mock.Anything).Return(nil, nil)
+
+	handler := NewCategoryAdminHandler(echo.New(), mockCategoryUsecase, mockCategoryValidator, nil, nil, nil)
+
+	handler.Update(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestMoveCycle(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/categories/:slug/parent", strings.NewReader("{\"parent\":\"polo\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("slug")
+	c.SetParamValues("shirts")
+
+	mockCategoryUsecase := new(mocks.MockCategoryUseCase)
+
+	mockCategoryUsecase.On("Move", mock.Anything, "shirts", "polo").Return(nil, domain.ErrCategoryCycle)
+
+	handler := NewCategoryAdminHandler(echo.New(), mockCategoryUsecase, nil, nil, nil, nil)
+
+	handler.Move(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMove(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/categories/:slug/parent", strings.NewReader("{\"parent\":\"men\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("slug")
+	c.SetParamValues("shirts")
+
+	mockCategoryUsecase := new(mocks.MockCategoryUseCase)
+
+	mockCategoryUsecase.On("Move", mock.Anything, "shirts", "men").Return(&domain.Category{Slug: "shirts", Name: "Shirts", Depth: 1}, nil)
+
+	handler := NewCategoryAdminHandler(echo.New(), mockCategoryUsecase, nil, nil, nil, nil)
+
+	handler.Move(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestSetProductCategoriesProductNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/products/:uuid/categories", strings.NewReader("{\"categories\":[\"shirts\"]}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("uuid")
+
+	mockCategoryUsecase := new(mocks.MockCategoryUseCase)
+
+	mockCategoryUsecase.On("SetProductCategories", mock.Anything, "uuid", []string{"shirts"}).Return(domain.ErrProductNotFound)
+
+	handler := NewCategoryAdminHandler(echo.New(), mockCategoryUsecase, nil, nil, nil, nil)
+
+	handler.SetProductCategories(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestSetProductCategories(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/products/:uuid/categories", strings.NewReader("{\"categories\":[\"shirts\"]}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("uuid")
+
+	mockCategoryUsecase := new(mocks.MockCategoryUseCase)
+
+	mockCategoryUsecase.On("SetProductCategories", mock.Anything, "uuid", []string{"shirts"}).Return(nil)
+
+	handler := NewCategoryAdminHandler(echo.New(), mockCategoryUsecase, nil, nil, nil, nil)
+
+	handler.SetProductCategories(c)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestCreateCollectionInvalid(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/collections", strings.NewReader("{\"slug\":\"summer\",\"name\":\"Summer\",\"kind\":\"rule\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockCollectionValidator := new(mocks.MockCollectionValidator)
+
+	mockCollectionValidator.On("Validate", mock.Anything, mock.Anything).Return(false, "rule collection must have a rule")
+
+	handler := NewCategoryAdminHandler(echo.New(), nil, nil, nil, mockCollectionValidator, nil)
+
+	handler.CreateCollection(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestCreateCollection(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/collections", strings.NewReader("{\"slug\":\"cheap\",\"name\":\"Cheap\",\"kind\":\"rule\",\"rule\":{\"maxPrice\":5000}}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	maxPrice := int64(5000)
+
+	mockCollectionUsecase := new(mocks.MockCollectionUseCase)
+	mockCollectionValidator := new(mocks.MockCollectionValidator)
+
+	mockCollectionValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockCollectionUsecase.On("Create", mock.Anything, &domain.Collection{Slug: "cheap", Name: "Cheap", Kind: domain.CollectionKindRule, Rule: &domain.CollectionRule{MaxPrice: &maxPrice}}).Return(nil)
+
+	handler := NewCategoryAdminHandler(echo.New(), nil, nil, mockCollectionUsecase, mockCollectionValidator, nil)
+
+	handler.CreateCollection(c)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestUpdateCollectionSlugTaken(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/collections/:slug", strings.NewReader("{\"slug\":\"winter\",\"name\":\"Winter\",\"kind\":\"manual\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("slug")
+	c.SetParamValues("summer")
+
+	mockCollectionUsecase := new(mocks.MockCollectionUseCase)
+	mockCollectionValidator := new(mocks.MockCollectionValidator)
+
+	mockCollectionValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockCollectionUsecase.On("Update", mock.Anything, "summer", mock.Anything).Return(nil, domain.ErrSlugTaken)
+
+	handler := NewCategoryAdminHandler(echo.New(), nil, nil, mockCollectionUsecase, mockCollectionValidator, nil)
+
+	handler.UpdateCollection(c)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
@@ -0,0 +1,148 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_productPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/product/presentation"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type categoryHandler struct {
+	CategoryUseCase   domain.CategoryUseCase
+	CollectionUseCase domain.CollectionUseCase
+}
+
+func NewCategoryHandler(e *echo.Echo, cuc domain.CategoryUseCase, coluc domain.CollectionUseCase, ts domain.TokenService) *categoryHandler {
+	handler := &categoryHandler{
+		CategoryUseCase:   cuc,
+		CollectionUseCase: coluc,
+	}
+
+	optionalAuth := _tokenPresentation.NewOptionalAuthMiddleware(ts)
+
+	e.GET("/categories", handler.GetTree)
+	e.GET("/categories/:slug/breadcrumb", handler.GetBreadcrumb)
+	e.GET("/categories/:slug/products", handler.ListProducts, optionalAuth)
+	e.GET("/collections/:slug", handler.GetCollection)
+	e.GET("/collections/:slug/products", handler.ListCollectionProducts, optionalAuth)
+
+	return handler
+}
+
+func (ch *categoryHandler) GetTree(c echo.Context) error {
+	tree, err := ch.CategoryUseCase.GetTree(c.Request().Context())
+
+	if err != nil {
+		log.Printf("Error trying to get the categories: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the categories")
+	}
+
+	return c.JSON(http.StatusOK, map[string][]*domain.Category{"categories": tree})
+}
+
+func (ch *categoryHandler) GetBreadcrumb(c echo.Context) error {
+	slug := c.Param("slug")
+
+	if slug == "" {
+		return c.JSON(http.StatusBadRequest, "slug param is not valid")
+	}
+
+	breadcrumb, err := ch.CategoryUseCase.GetBreadcrumb(c.Request().Context(), slug)
+
+	if err != nil {
+		log.Printf("Error trying to get a category breadcrumb: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the breadcrumb")
+	}
+
+	if breadcrumb == nil {
+		return c.JSON(http.StatusNotFound, "category not found")
+	}
+
+	return c.JSON(http.StatusOK, map[string][]domain.Category{"breadcrumb": breadcrumb})
+}
+
+func (ch *categoryHandler) ListProducts(c echo.Context) error {
+	slug := c.Param("slug")
+
+	if slug == "" {
+		return c.JSON(http.StatusBadRequest, "slug param is not valid")
+	}
+
+	q, message := _productPresentation.ParseProductQuery(c)
+
+	if message != "" {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	page, err := ch.CategoryUseCase.ListProducts(c.Request().Context(), slug, *q, loginFromContext(c))
+
+	return listResponse(c, page, err, "category not found")
+}
+
+func (ch *categoryHandler) GetCollection(c echo.Context) error {
+	slug := c.Param("slug")
+
+	if slug == "" {
+		return c.JSON(http.StatusBadRequest, "slug param is not valid")
+	}
+
+	collection, err := ch.CollectionUseCase.Get(c.Request().Context(), slug)
+
+	if err != nil {
+		log.Printf("Error trying to get a collection: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the collection")
+	}
+
+	if collection == nil {
+		return c.JSON(http.StatusNotFound, "collection not found")
+	}
+
+	return c.JSON(http.StatusOK, collection)
+}
+
+func (ch *categoryHandler) ListCollectionProducts(c echo.Context) error {
+	slug := c.Param("slug")
+
+	if slug == "" {
+		return c.JSON(http.StatusBadRequest, "slug param is not valid")
+	}
+
+	q, message := _productPresentation.ParseProductQuery(c)
+
+	if message != "" {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	page, err := ch.CollectionUseCase.ListProducts(c.Request().Context(), slug, *q, loginFromContext(c))
+
+	return listResponse(c, page, err, "collection not found")
+}
+
+func listResponse(c echo.Context, page *domain.ProductPage, err error, notFound string) error {
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return c.JSON(http.StatusBadRequest, "cursor param is not valid")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to list products: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to list the products")
+	}
+
+	if page == nil {
+		return c.JSON(http.StatusNotFound, notFound)
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func loginFromContext(c echo.Context) string {
+	if tokenInfo := _tokenPresentation.TokenInfoFromContext(c); tokenInfo != nil {
+		return tokenInfo.Info
+	}
+
+	return ""
+}
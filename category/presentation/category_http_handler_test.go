@@ -0,0 +1,205 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetTreeError(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/categories", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockCategoryUsecase := new(mocks.MockCategoryUseCase)
+
+	mockCategoryUsecase.On("GetTree", mock.Anything).Return(nil, errors.New("error message"))
+
+	handler := NewCategoryHandler(echo.New(), mockCategoryUsecase, nil, nil)
+
+	handler.GetTree(c)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestGetTree(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/categories", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockCategoryUsecase := new(mocks.MockCategoryUseCase)
+
+	tree := []*domain.Category{{ID: 1, Slug: "clothes", Name: "Clothes", Path: "/1/", Children: []*domain.Category{{ID: 4, Slug: "shirts", Name: "Shirts", Path: "/1/4/", Depth: 1}}}}
+
+	mockCategoryUsecase.On("GetTree", mock.Anything).Return(tree, nil)
+
+	handler := NewCategoryHandler(echo.New(), mockCategoryUsecase, nil, nil)
+
+	handler.GetTree(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"categories\":[{\"slug\":\"clothes\",\"name\":\"Clothes\",\"depth\":0,\"children\":[{\"slug\":\"shirts\",\"name\":\"Shirts\",\"depth\":1}]}]}\n", rec.Body.String())
+}
+
+func TestGetBreadcrumbNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/categories/:slug/breadcrumb", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("slug")
+	c.SetParamValues("polo")
+
+	mockCategoryUsecase := new(mocks.MockCategoryUseCase)
+
+	mockCategoryUsecase.On("GetBreadcrumb", mock.Anything, "polo").Return(nil, nil)
+
+	handler := NewCategoryHandler(echo.New(), mockCategoryUsecase, nil, nil)
+
+	handler.GetBreadcrumb(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestGetBreadcrumb(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/categories/:slug/breadcrumb", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("slug")
+	c.SetParamValues("shirts")
+
+	mockCategoryUsecase := new(mocks.MockCategoryUseCase)
+
+	mockCategoryUsecase.On("GetBreadcrumb", mock.Anything, "shirts").Return([]domain.Category{{Slug: "clothes", Name: "Clothes"}, {Slug: "shirts", Name: "Shirts", Depth: 1}}, nil)
+
+	handler := NewCategoryHandler(echo.New(), mockCategoryUsecase, nil, nil)
+
+	handler.GetBreadcrumb(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"breadcrumb\":[{\"slug\":\"clothes\",\"name\":\"Clothes\",\"depth\":0},{\"slug\":\"shirts\",\"name\":\"Shirts\",\"depth\":1}]}\n", rec.Body.String())
+}
+
+func TestListProductsNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/categories/:slug/products", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("slug")
+	c.SetParamValues("shirts")
+
+	mockCategoryUsecase := new(mocks.MockCategoryUseCase)
+
+	mockCategoryUsecase.On("ListProducts", mock.Anything, "shirts", mock.Anything, "").Return(nil, nil)
+
+	handler := NewCategoryHandler(echo.New(), mockCategoryUsecase, nil, nil)
+
+	handler.ListProducts(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestListProductsInvalidQuery(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/categories/shirts/products?limit=0", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("slug")
+	c.SetParamValues("shirts")
+
+	handler := NewCategoryHandler(echo.New(), nil, nil, nil)
+
+	handler.ListProducts(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestListProducts(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/categories/shirts/products?sort=-price", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("slug")
+	c.SetParamValues("shirts")
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	q := domain.ProductQuery{Limit: 20, Sort: domain.ProductSortPrice, Desc: true, Attributes: map[string][]string{}}
+
+	mockCategoryUsecase := new(mocks.MockCategoryUseCase)
+
+	mockCategoryUsecase.On("ListProducts", mock.Anything, "shirts", q, "user@test.com").Return(&domain.ProductPage{Products: []domain.Product{}}, nil)
+
+	handler := NewCategoryHandler(echo.New(), mockCategoryUsecase, nil, nil)
+
+	handler.ListProducts(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"products\":[]}\n", rec.Body.String())
+}
+
+func TestGetCollection(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/collections/:slug", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("slug")
+	c.SetParamValues("summer")
+
+	mockCollectionUsecase := new(mocks.MockCollectionUseCase)
+
+	mockCollectionUsecase.On("Get", mock.Anything, "summer").Return(&domain.Collection{ID: 2, Slug: "summer", Name: "Summer", Kind: domain.CollectionKindManual, ProductUUIDs: []string{"uuid"}}, nil)
+
+	handler := NewCategoryHandler(echo.New(), nil, mockCollectionUsecase, nil)
+
+	handler.GetCollection(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"slug\":\"summer\",\"name\":\"Summer\",\"kind\":\"manual\",\"productUuids\":[\"uuid\"]}\n", rec.Body.String())
+}
+
+func TestListCollectionProductsInvalidCursor(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/collections/summer/products?cursor=abc", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("slug")
+	c.SetParamValues("summer")
+
+	mockCollectionUsecase := new(mocks.MockCollectionUseCase)
+
+	mockCollectionUsecase.On("ListProducts", mock.Anything, "summer", mock.Anything, "").Return(nil, domain.ErrInvalidCursor)
+
+	handler := NewCategoryHandler(echo.New(), nil, mockCollectionUsecase, nil)
+
+	handler.ListCollectionProducts(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type categoryMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewCategoryMysqlRepository(conn *sql.DB) domain.CategoryRepository {
+	return &categoryMysqlRepository{Conn: conn}
+}
+
+func (cmr *categoryMysqlRepository) GetAll(ctx context.Context) ([]domain.Category, error) {
+	query := `SELECT id, parent_id, slug, name, path, depth FROM category ORDER BY depth, name;`
+
+	return cmr.fetch(ctx, query)
+}
+
+func (cmr *categoryMysqlRepository) GetBySlug(ctx context.Context, slug string) (*domain.Category, error) {
+	query := `SELECT id, parent_id, slug, name, path, depth FROM category WHERE slug = ?;`
+
+	categories, err := cmr.fetch(ctx, query, slug)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(categories) == 0 {
+		return nil, nil
+	}
+
+	return &categories[0], nil
+}
+
+func (cmr *categoryMysqlRepository) GetBySlugs(ctx context.Context, slugs []string) ([]domain.Category, error) {
+	if len(slugs) == 0 {
+		return []domain.Category{}, nil
+	}
+
+	query := `SELECT id, parent_id, slug, name, path, depth FROM category WHERE slug IN (` + placeholders(len(slugs)) + `);`
+
+	args := make([]interface{}, len(slugs))
+	for i, slug := range slugs {
+		args[i] = slug
+	}
+
+	return cmr.fetch(ctx, query, args...)
+}
+
+func (cmr *categoryMysqlRepository) GetByIDs(ctx context.Context, ids []int64) ([]domain.Category, error) {
+	if len(ids) == 0 {
+		return []domain.Category{}, nil
+	}
+
+	query := `SELECT id, parent_id, slug, name, path, depth FROM category WHERE id IN (` + placeholders(len(ids)) + `) ORDER BY depth;`
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	return cmr.fetch(ctx, query, args...)
+}
+
+// Store inserts the category and then sets its path, which needs the id
+// generated for it.
+func (cmr *categoryMysqlRepository) Store(ctx context.Context, c *domain.Category, parent *domain.Category) error {
+	c.Path, c.Depth, c.ParentID = "/", 0, nil
+
+	if parent != nil {
+		parentID := parent.ID
+		c.Path, c.Depth, c.ParentID = parent.Path, parent.Depth+1, &parentID
+	}
+
+	tx, err := cmr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, `INSERT INTO category (parent_id, slug, name, path, depth) VALUES (?, ?, ?, ?, ?);`, c.ParentID, c.Slug, c.Name, c.Path, c.Depth)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	id, err := res.LastInsertId()
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	path := fmt.Sprintf("%s%d/", c.Path, id)
+
+	if _, err := tx.ExecContext(ctx, `UPDATE category SET path = ? WHERE id = ?;`, path, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	c.ID, c.Path = id, path
+
+	return nil
+}
+
+func (cmr *categoryMysqlRepository) Update(ctx context.Context, c *domain.Category) error {
+	query := `UPDATE category SET slug = ?, name = ? WHERE id = ?;`
+
+	res, err := cmr.Conn.ExecContext(ctx, query, c.Slug, c.Name, c.ID)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := res.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect > 1 {
+		return fmt.Errorf("weird behavior, total affected: %d", affect)
+	}
+
+	return nil
+}
+
+// Move puts the category under the parent, or in the root when parent is
+// nil, rewriting the path and depth of the whole subtree in one statement.
+func (cmr *categoryMysqlRepository) Move(ctx context.Context, c *domain.Category, parent *domain.Category) error {
+	parentPath, depth := "/", 0
+	var parentID *int64
+
+	if parent != nil {
+		id := parent.ID
+		parentPath, depth, parentID = parent.Path, parent.Depth+1, &id
+	}
+
+	path := fmt.Sprintf("%s%d/", parentPath, c.ID)
+
+	tx, err := cmr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE category SET parent_id = ? WHERE id = ?;`, parentID, c.ID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	subtreeQuery := `UPDATE category SET path = CONCAT(?, SUBSTRING(path, ?)), depth = depth + ? WHERE path LIKE ?;`
+
+	if _, err := tx.ExecContext(ctx, subtreeQuery, path, len(c.Path)+1, depth-c.Depth, c.Path+"%"); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	c.ParentID, c.Path, c.Depth = parentID, path, depth
+
+	return nil
+}
+
+func (cmr *categoryMysqlRepository) SetProductCategories(ctx context.Context, productID int64, categoryIDs []int64) error {
+	tx, err := cmr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM product_category WHERE product_id = ?;`, productID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, categoryID := range categoryIDs {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO product_category (product_id, category_id) VALUES (?, ?);`, productID, categoryID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (cmr *categoryMysqlRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]domain.Category, error) {
+	rows, err := cmr.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []domain.Category{}
+
+	for rows.Next() {
+		var c domain.Category
+		var parentID sql.NullInt64
+
+		if err := rows.Scan(&c.ID, &parentID, &c.Slug, &c.Name, &c.Path, &c.Depth); err != nil {
+			return nil, err
+		}
+
+		if parentID.Valid {
+			c.ParentID = &parentID.Int64
+		}
+
+		res = append(res, c)
+	}
+
+	return res, rows.Err()
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var categoryColumns = []string{"id", "parent_id", "slug", "name", "path", "depth"}
+
+func TestGetBySlugNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT id, parent_id, slug, name, path, depth FROM category WHERE slug = ?;")
+
+	mock.ExpectQuery(query).WithArgs("shirts").WillReturnRows(sqlmock.NewRows(categoryColumns))
+
+	categoryMysqlRepository := NewCategoryMysqlRepository(db)
+
+	category, err := categoryMysqlRepository.GetBySlug(context.Background(), "shirts")
+
+	assert.NoError(t, err)
+	assert.Nil(t, category)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetBySlug(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows(categoryColumns).AddRow(4, 1, "shirts", "Shirts", "/1/4/", 1)
+
+	query := regexp.QuoteMeta("SELECT id, parent_id, slug, name, path, depth FROM category WHERE slug = ?;")
+
+	mock.ExpectQuery(query).WithArgs("shirts").WillReturnRows(rows)
+
+	categoryMysqlRepository := NewCategoryMysqlRepository(db)
+
+	category, err := categoryMysqlRepository.GetBySlug(context.Background(), "shirts")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), category.ID)
+	assert.Equal(t, int64(1), *category.ParentID)
+	assert.Equal(t, "/1/4/", category.Path)
+	assert.Equal(t, 1, category.Depth)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetAll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows(categoryColumns).AddRow(1, nil, "clothes", "Clothes", "/1/", 0).AddRow(4, 1, "shirts", "Shirts", "/1/4/", 1)
+
+	query := regexp.QuoteMeta("SELECT id, parent_id, slug, name, path, depth FROM category ORDER BY depth, name;")
+
+	mock.ExpectQuery(query).WillReturnRows(rows)
+
+	categoryMysqlRepository := NewCategoryMysqlRepository(db)
+
+	categories, err := categoryMysqlRepository.GetAll(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, categories, 2)
+	assert.Nil(t, categories[0].ParentID)
+	assert.Equal(t, int64(1), *categories[1].ParentID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetByIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows(categoryColumns).AddRow(1, nil, "clothes", "Clothes", "/1/", 0)
+
+	query := regexp.QuoteMeta("SELECT id, parent_id, slug, name, path, depth FROM category WHERE id IN (?, ?) ORDER BY depth;")
+
+	mock.ExpectQuery(query).WithArgs(1, 4).WillReturnRows(rows)
+
+	categoryMysqlRepository := NewCategoryMysqlRepository(db)
+
+	categories, err := categoryMysqlRepository.GetByIDs(context.Background(), []int64{1, 4})
+
+	assert.NoError(t, err)
+	assert.Len(t, categories, 1)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreWithParent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO category (parent_id, slug, name, path, depth) VALUES (?, ?, ?, ?, ?);")).WithArgs(1, "shirts", "Shirts", "/1/", 1).WillReturnResult(sqlmock.NewResult(4, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE category SET path = ? WHERE id = ?;")).WithArgs("/1/4/", 4).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	categoryMysqlRepository := NewCategoryMysqlRepository(db)
+
+	c := &domain.Category{Slug: "shirts", Name: "Shirts"}
+
+	err = categoryMysqlRepository.Store(context.Background(), c, &domain.Category{ID: 1, Path: "/1/"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), c.ID)
+	assert.Equal(t, "/1/4/", c.Path)
+	assert.Equal(t, 1, c.Depth)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO category (parent_id, slug, name, path, depth) VALUES (?, ?, ?, ?, ?);")).WillReturnError(errors.New("error message"))
+	mock.ExpectRollback()
+
+	categoryMysqlRepository := NewCategoryMysqlRepository(db)
+
+	err = categoryMysqlRepository.Store(context.Background(), &domain.Category{Slug: "clothes", Name: "Clothes"}, nil)
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMove(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE category SET parent_id = ? WHERE id = ?;")).WithArgs(7, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE category SET path = CONCAT(?, SUBSTRING(path, ?)), depth = depth + ? WHERE path LIKE ?;")).WithArgs("/2/7/4/", 6, 1, "/1/4/%").WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	categoryMysqlRepository := NewCategoryMysqlRepository(db)
+
+	c := &domain.Category{ID: 4, Path: "/1/4/", Depth: 1}
+
+	err = categoryMysqlRepository.Move(context.Background(), c, &domain.Category{ID: 7, Path: "/2/7/", Depth: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/2/7/4/", c.Path)
+	assert.Equal(t, 2, c.Depth)
+	assert.Equal(t, int64(7), *c.ParentID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMoveToRoot(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE category SET parent_id = ? WHERE id = ?;")).WithArgs(nil, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE category SET path = CONCAT(?, SUBSTRING(path, ?)), depth = depth + ? WHERE path LIKE ?;")).WithArgs("/4/", 6, -1, "/1/4/%").WillReturnError(errors.New("error message"))
+	mock.ExpectRollback()
+
+	categoryMysqlRepository := NewCategoryMysqlRepository(db)
+
+	err = categoryMysqlRepository.Move(context.Background(), &domain.Category{ID: 4, Path: "/1/4/", Depth: 1}, nil)
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetProductCategories(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product_category WHERE product_id = ?;")).WithArgs(9).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_category (product_id, category_id) VALUES (?, ?);")).WithArgs(9, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_category (product_id, category_id) VALUES (?, ?);")).WithArgs(9, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	categoryMysqlRepository := NewCategoryMysqlRepository(db)
+
+	err = categoryMysqlRepository.SetProductCategories(context.Background(), 9, []int64{1, 4})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type collectionMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewCollectionMysqlRepository(conn *sql.DB) domain.CollectionRepository {
+	return &collectionMysqlRepository{Conn: conn}
+}
+
+func (cmr *collectionMysqlRepository) GetBySlug(ctx context.Context, slug string) (*domain.Collection, error) {
+	query := `SELECT id, slug, name, kind, rule FROM collection WHERE slug = ?;`
+
+	var res domain.Collection
+	var rule sql.NullString
+
+	if err := cmr.Conn.QueryRowContext(ctx, query, slug).Scan(&res.ID, &res.Slug, &res.Name, &res.Kind, &rule); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if rule.Valid {
+		if err := json.Unmarshal([]byte(rule.String), &res.Rule); err != nil {
+			return nil, err
+		}
+	}
+
+	if res.Kind != domain.CollectionKindManual {
+		return &res, nil
+	}
+
+	rows, err := cmr.Conn.QueryContext(ctx, `SELECT p.uuid FROM collection_product cp JOIN product p ON p.id = cp.product_id WHERE cp.collection_id = ? ORDER BY cp.position;`, res.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res.ProductUUIDs = []string{}
+
+	for rows.Next() {
+		var productUUID string
+
+		if err := rows.Scan(&productUUID); err != nil {
+			return nil, err
+		}
+
+		res.ProductUUIDs = append(res.ProductUUIDs, productUUID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (cmr *collectionMysqlRepository) Store(ctx context.Context, c *domain.Collection) error {
+	rule, err := encodeRule(c.Rule)
+
+	if err != nil {
+		return err
+	}
+
+	tx, err := cmr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, `INSERT INTO collection (slug, name, kind, rule) VALUES (?, ?, ?, ?);`, c.Slug, c.Name, c.Kind, rule)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	id, err := res.LastInsertId()
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := storeCollectionProducts(ctx, tx, id, c.ProductUUIDs); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	c.ID = id
+
+	return nil
+}
+
+func (cmr *collectionMysqlRepository) Update(ctx context.Context, c *domain.Collection) error {
+	rule, err := encodeRule(c.Rule)
+
+	if err != nil {
+		return err
+	}
+
+	tx, err := cmr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE collection SET slug = ?, name = ?, kind = ?, rule = ? WHERE id = ?;`, c.Slug, c.Name, c.Kind, rule, c.ID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM collection_product WHERE collection_id = ?;`, c.ID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := storeCollectionProducts(ctx, tx, c.ID, c.ProductUUIDs); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// storeCollectionProducts ignores the uuids of products that do not exist.
+func storeCollectionProducts(ctx context.Context, tx *sql.Tx, collectionID int64, productUUIDs []string) error {
+	for i, productUUID := range productUUIDs {
+		query := `INSERT INTO collection_product (collection_id, product_id, position) SELECT ?, id, ? FROM product WHERE uuid = ?;`
+
+		if _, err := tx.ExecContext(ctx, query, collectionID, i, productUUID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func encodeRule(rule *domain.CollectionRule) (interface{}, error) {
+	if rule == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(rule)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
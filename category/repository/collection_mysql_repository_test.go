@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCollectionBySlugNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, slug, name, kind, rule FROM collection WHERE slug = ?;")).WithArgs("summer").WillReturnRows(sqlmock.NewRows([]string{"id", "slug", "name", "kind", "rule"}))
+
+	collectionMysqlRepository := NewCollectionMysqlRepository(db)
+
+	collection, err := collectionMysqlRepository.GetBySlug(context.Background(), "summer")
+
+	assert.NoError(t, err)
+	assert.Nil(t, collection)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetCollectionBySlugRule(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "slug", "name", "kind", "rule"}).AddRow(1, "cheap-shirts", "Cheap shirts", "rule", `{"category":"shirts","maxPrice":5000}`)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, slug, name, kind, rule FROM collection WHERE slug = ?;")).WithArgs("cheap-shirts").WillReturnRows(rows)
+
+	collectionMysqlRepository := NewCollectionMysqlRepository(db)
+
+	collection, err := collectionMysqlRepository.GetBySlug(context.Background(), "cheap-shirts")
+
+	maxPrice := int64(5000)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.CollectionKindRule, collection.Kind)
+	assert.Equal(t, &domain.CollectionRule{Category: "shirts", MaxPrice: &maxPrice}, collection.Rule)
+	assert.Nil(t, collection.ProductUUIDs)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetCollectionBySlugManual(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "slug", "name", "kind", "rule"}).AddRow(2, "summer", "Summer", "manual", nil)
+	productRows := sqlmock.NewRows([]string{"uuid"}).AddRow("uuid2").AddRow("uuid1")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, slug, name, kind, rule FROM collection WHERE slug = ?;")).WithArgs("summer").WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT p.uuid FROM collection_product cp JOIN product p ON p.id = cp.product_id WHERE cp.collection_id = ? ORDER BY cp.position;")).WithArgs(2).WillReturnRows(productRows)
+
+	collectionMysqlRepository := NewCollectionMysqlRepository(db)
+
+	collection, err := collectionMysqlRepository.GetBySlug(context.Background(), "summer")
+
+	assert.NoError(t, err)
+	assert.Nil(t, collection.Rule)
+	assert.Equal(t, []string{"uuid2", "uuid1"}, collection.ProductUUIDs)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreCollection(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	productQuery := regexp.QuoteMeta("INSERT INTO collection_product (collection_id, product_id, position) SELECT ?, id, ? FROM product WHERE uuid = ?;")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO collection (slug, name, kind, rule) VALUES (?, ?, ?, ?);")).WithArgs("summer", "Summer", "manual", nil).WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectExec(productQuery).WithArgs(2, 0, "uuid2").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(productQuery).WithArgs(2, 1, "uuid1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	collectionMysqlRepository := NewCollectionMysqlRepository(db)
+
+	c := &domain.Collection{Slug: "summer", Name: "Summer", Kind: domain.CollectionKindManual, ProductUUIDs: []string{"uuid2", "uuid1"}}
+
+	err = collectionMysqlRepository.Store(context.Background(), c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), c.ID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdateCollection(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE collection SET slug = ?, name = ?, kind = ?, rule = ? WHERE id = ?;")).WithArgs("cheap", "Cheap", "rule", `{"maxPrice":5000}`, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM collection_product WHERE collection_id = ?;")).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	collectionMysqlRepository := NewCollectionMysqlRepository(db)
+
+	maxPrice := int64(5000)
+
+	err = collectionMysqlRepository.Update(context.Background(), &domain.Collection{ID: 1, Slug: "cheap", Name: "Cheap", Kind: domain.CollectionKindRule, Rule: &domain.CollectionRule{MaxPrice: &maxPrice}})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
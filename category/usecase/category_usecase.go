@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type categoryUseCase struct {
+	categoryRepo   domain.CategoryRepository
+	productRepo    domain.ProductRepository
+	productUseCase domain.ProductUseCase
+}
+
+func NewCategoryUseCase(cr domain.CategoryRepository, pr domain.ProductRepository, puc domain.ProductUseCase) domain.CategoryUseCase {
+	return &categoryUseCase{categoryRepo: cr, productRepo: pr, productUseCase: puc}
+}
+
+func (cu *categoryUseCase) GetTree(ctx context.Context) ([]*domain.Category, error) {
+	categories, err := cu.categoryRepo.GetAll(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	byID := map[int64]*domain.Category{}
+	roots := []*domain.Category{}
+
+	// the categories come ordered by depth, so a parent is always seen
+	// before its children
+	for i := range categories {
+		c := &categories[i]
+		byID[c.ID] = c
+
+		if c.ParentID == nil {
+			roots = append(roots, c)
+			continue
+		}
+
+		if parent, ok := byID[*c.ParentID]; ok {
+			parent.Children = append(parent.Children, c)
+		}
+	}
+
+	return roots, nil
+}
+
+func (cu *categoryUseCase) GetBreadcrumb(ctx context.Context, slug string) ([]domain.Category, error) {
+	category, err := cu.categoryRepo.GetBySlug(ctx, slug)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if category == nil {
+		return nil, nil
+	}
+
+	ids := []int64{}
+
+	for _, part := range strings.Split(strings.Trim(category.Path, "/"), "/") {
+		id, err := strconv.ParseInt(part, 10, 64)
+
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return cu.categoryRepo.GetByIDs(ctx, ids)
+}
+
+func (cu *categoryUseCase) ListProducts(ctx context.Context, slug string, q domain.ProductQuery, login string) (*domain.ProductPage, error) {
+	category, err := cu.categoryRepo.GetBySlug(ctx, slug)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if category == nil {
+		return nil, nil
+	}
+
+	q.Category = slug
+
+	return cu.productUseCase.List(ctx, q, login)
+}
+
+func (cu *categoryUseCase) Create(ctx context.Context, c *domain.Category, parentSlug string) error {
+	existing, err := cu.categoryRepo.GetBySlug(ctx, c.Slug)
+
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		return domain.ErrSlugTaken
+	}
+
+	var parent *domain.Category
+
+	if parentSlug != "" {
+		parent, err = cu.categoryRepo.GetBySlug(ctx, parentSlug)
+
+		if err != nil {
+			return err
+		}
+
+		if parent == nil {
+			return domain.ErrCategoryNotFound
+		}
+	}
+
+	return cu.categoryRepo.Store(ctx, c, parent)
+}
+
+func (cu *categoryUseCase) Update(ctx context.Context, slug string, c *domain.Category) (*domain.Category, error) {
+	existing, err := cu.categoryRepo.GetBySlug(ctx, slug)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if existing == nil {
+		return nil, nil
+	}
+
+	if c.Slug != slug {
+		taken, err := cu.categoryRepo.GetBySlug(ctx, c.Slug)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if taken != nil {
+			return nil, domain.ErrSlugTaken
+		}
+	}
+
+	existing.Slug, existing.Name = c.Slug, c.Name
+
+	if err := cu.categoryRepo.Update(ctx, existing); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// Move puts the category and its whole subtree under the parent, or in the
+// root when parentSlug is empty.
+func (cu *categoryUseCase) Move(ctx context.Context, slug string, parentSlug string) (*domain.Category, error) {
+	category, err := cu.categoryRepo.GetBySlug(ctx, slug)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if category == nil {
+		return nil, nil
+	}
+
+	var parent *domain.Category
+
+	if parentSlug != "" {
+		parent, err = cu.categoryRepo.GetBySlug(ctx, parentSlug)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if parent == nil {
+			return nil, domain.ErrCategoryNotFound
+		}
+
+		if strings.HasPrefix(parent.Path, category.Path) {
+			return nil, domain.ErrCategoryCycle
+		}
+	}
+
+	if err := cu.categoryRepo.Move(ctx, category, parent); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (cu *categoryUseCase) SetProductCategories(ctx context.Context, productUUID string, slugs []string) error {
+	product, err := cu.productRepo.GetByUUID(ctx, productUUID)
+
+	if err != nil {
+		return err
+	}
+
+	if product == nil {
+		return domain.ErrProductNotFound
+	}
+
+	unique := map[string]bool{}
+	for _, slug := range slugs {
+		unique[slug] = true
+	}
+
+	categories, err := cu.categoryRepo.GetBySlugs(ctx, slugs)
+
+	if err != nil {
+		return err
+	}
+
+	if len(categories) != len(unique) {
+		return domain.ErrCategoryNotFound
+	}
+
+	categoryIDs := make([]int64, len(categories))
+	for i, c := range categories {
+		categoryIDs[i] = c.ID
+	}
+
+	return cu.categoryRepo.SetProductCategories(ctx, product.ID, categoryIDs)
+}
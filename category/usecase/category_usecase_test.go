@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func TestGetTree(t *testing.T) {
+	mockCategoryRepo := new(mocks.MockCategoryRepository)
+
+	mockCategoryRepo.On("GetAll", mock.Anything).Return([]domain.Category{
+		{ID: 1, Slug: "clothes", Path: "/1/"},
+		{ID: 2, Slug: "shoes", Path: "/2/"},
+		{ID: 4, ParentID: int64Ptr(1), Slug: "shirts", Path: "/1/4/", Depth: 1},
+		{ID: 5, ParentID: int64Ptr(4), Slug: "polo", Path: "/1/4/5/", Depth: 2},
+	}, nil)
+
+	tree, err := NewCategoryUseCase(mockCategoryRepo, nil, nil).GetTree(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, tree, 2)
+	assert.Equal(t, "shirts", tree[0].Children[0].Slug)
+	assert.Equal(t, "polo", tree[0].Children[0].Children[0].Slug)
+	assert.Empty(t, tree[1].Children)
+}
+
+func TestGetBreadcrumbNotFound(t *testing.T) {
+	mockCategoryRepo := new(mocks.MockCategoryRepository)
+
+	mockCategoryRepo.On("GetBySlug", mock.Anything, "polo").Return(nil, nil)
+
+	breadcrumb, err := NewCategoryUseCase(mockCategoryRepo, nil, nil).GetBreadcrumb(context.Background(), "polo")
+
+	assert.NoError(t, err)
+	assert.Nil(t, breadcrumb)
+}
+
+func TestGetBreadcrumb(t *testing.T) {
+	mockCategoryRepo := new(mocks.MockCategoryRepository)
+
+	expected := []domain.Category{{ID: 1, Slug: "clothes"}, {ID: 4, Slug: "shirts"}, {ID: 5, Slug: "polo"}}
+
+	mockCategoryRepo.On("GetBySlug", mock.Anything, "polo").Return(&domain.Category{ID: 5, Slug: "polo", Path: "/1/4/5/"}, nil)
+	mockCategoryRepo.On("GetByIDs", mock.Anything, []int64{1, 4, 5}).Return(expected, nil)
+
+	breadcrumb, err := NewCategoryUseCase(mockCategoryRepo, nil, nil).GetBreadcrumb(context.Background(), "polo")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, breadcrumb)
+}
+
+func TestListProductsCategoryNotFound(t *testing.T) {
+	mockCategoryRepo := new(mocks.MockCategoryRepository)
+	mockProductUseCase := new(mocks.MockProductUsecase)
+
+	mockCategoryRepo.On("GetBySlug", mock.Anything, "shirts").Return(nil, nil)
+
+	page, err := NewCategoryUseCase(mockCategoryRepo, nil, mockProductUseCase).ListProducts(context.Background(), "shirts", domain.ProductQuery{}, "")
+
+	assert.NoError(t, err)
+	assert.Nil(t, page)
+	mockProductUseCase.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestListProducts(t *testing.T) {
+	mockCategoryRepo := new(mocks.MockCategoryRepository)
+	mockProductUseCase := new(mocks.MockProductUsecase)
+
+	page := &domain.ProductPage{Products: []domain.Product{}}
+
+	mockCategoryRepo.On("GetBySlug", mock.Anything, "shirts").Return(&domain.Category{ID: 4, Slug: "shirts"}, nil)
+	mockProductUseCase.On("List", mock.Anything, domain.ProductQuery{Limit: 20, Category: "shirts"}, "user@test.com").Return(page, nil)
+
+	res, err := NewCategoryUseCase(mockCategoryRepo, nil, mockProductUseCase).ListProducts(context.Background(), "shirts", domain.ProductQuery{Limit: 20}, "user@test.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, page, res)
+}
+
+func TestCreateSlugTaken(t *testing.T) {
+	mockCategoryRepo := new(mocks.MockCategoryRepository)
+
+	mockCategoryRepo.On("GetBySlug", mock.Anything, "shirts").Return(&domain.Category{ID: 4}, nil)
+
+	err := NewCategoryUseCase(mockCategoryRepo, nil, nil).Create(context.Background(), &domain.Category{Slug: "shirts"}, "")
+
+	assert.ErrorIs(t, err, domain.ErrSlugTaken)
+}
+
+func TestCreateParentNotFound(t *testing.T) {
+	mockCategoryRepo := new(mocks.MockCategoryRepository)
+
+	mockCategoryRepo.On("GetBySlug", mock.Anything, "shirts").Return(nil, nil)
+	mockCategoryRepo.On("GetBySlug", mock.Anything, "clothes").Return(nil, nil)
+
+	err := NewCategoryUseCase(mockCategoryRepo, nil, nil).Create(context.Background(), &domain.Category{Slug: "shirts"}, "clothes")
+
+	assert.ErrorIs(t, err, domain.ErrCategoryNotFound)
+}
+
+func TestCreate(t *testing.T) {
+	mockCategoryRepo := new(mocks.MockCategoryRepository)
+
+	c := &domain.Category{Slug: "shirts"}
+	parent := &domain.Category{ID: 1, Slug: "clothes", Path: "/1/"}
+
+	mockCategoryRepo.On("GetBySlug", mock.Anything, "shirts").Return(nil, nil)
+	mockCategoryRepo.On("GetBySlug", mock.Anything, "clothes").Return(parent, nil)
+	mockCategoryRepo.On("Store", mock.Anything, c, parent).Return(nil)
+
+	err := NewCategoryUseCase(mockCategoryRepo, nil, nil).Create(context.Background(), c, "clothes")
+
+	assert.NoError(t, err)
+	mockCategoryRepo.AssertExpectations(t)
+}
+
+func TestUpdate(t *testing.T) {
+	mockCategoryRepo := new(mocks.MockCategoryRepository)
+
+	existing := &domain.Category{ID: 4, Slug: "shirts", Name: "Shirts", Path: "/1/4/"}
+
+	mockCategoryRepo.On("GetBySlug", mock.Anything, "shirts").Return(existing, nil)
+	mockCategoryRepo.On("GetBySlug", mock.Anything, "t-shirts").Return(nil, nil)
+	mockCategoryRepo.On("Update", mock.Anything, existing).Return(nil)
+
+	updated, err := NewCategoryUseCase(mockCategoryRepo, nil, nil).Update(context.Background(), "shirts", &domain.Category{Slug: "t-shirts", Name: "T-Shirts"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "t-shirts", updated.Slug)
+	assert.Equal(t, "/1/4/", updated.Path)
+}
+
+func TestMoveUnderItsOwnSubtree(t *testing.T) {
+	mockCategoryRepo := new(mocks.MockCategoryRepository)
+
+	mockCategoryRepo.On("GetBySlug", mock.Anything, "shirts").Return(&domain.Category{ID: 4, Path: "/1/4/"}, nil)
+	mockCategoryRepo.On("GetBySlug", mock.Anything, "polo").Return(&domain.Category{ID: 5, Path: "/1/4/5/"}, nil)
+
+	_, err := NewCategoryUseCase(mockCategoryRepo, nil, nil).Move(context.Background(), "shirts", "polo")
+
+	assert.ErrorIs(t, err, domain.ErrCategoryCycle)
+	mockCategoryRepo.AssertNotCalled(t, "Move", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMoveUnderItself(t *testing.T) {
+	mockCategoryRepo := new(mocks.MockCategoryRepository)
+
+	mockCategoryRepo.On("GetBySlug", mock.Anything, "shirts").Return(&domain.Category{ID: 4, Path: "/1/4/"}, nil)
+
+	_, err := NewCategoryUseCase(mockCategoryRepo, nil, nil).Move(context.Background(), "shirts", "shirts")
+
+	assert.ErrorIs(t, err, domain.ErrCategoryCycle)
+}
+
+func TestMove(t *testing.T) {
+	mockCategoryRepo := new(mocks.MockCategoryRepository)
+
+	c := &domain.Category{ID: 4, Path: "/1/4/"}
+	parent := &domain.Category{ID: 14, Path: "/14/"}
+
+	mockCategoryRepo.On("GetBySlug", mock.Anything, "shirts").Return(c, nil)
+	mockCategoryRepo.On("GetBySlug", mock.Anything, "men").Return(parent, nil)
+	mockCategoryRepo.On("Move", mock.Anything, c, parent).Return(nil)
+
+	moved, err := NewCategoryUseCase(mockCategoryRepo, nil, nil).Move(context.Background(), "shirts", "men")
+
+	assert.NoError(t, err)
+	assert.Equal(t, c, moved)
+}
+
+func TestMoveToRoot(t *testing.T) {
+	mockCategoryRepo := new(mocks.MockCategoryRepository)
+
+	c := &domain.Category{ID: 4, Path: "/1/4/"}
+
+	mockCategoryRepo.On("GetBySlug", mock.Anything, "shirts").Return(c, nil)
+	mockCategoryRepo.On("Move", mock.Anything, c, (*domain.Category)(nil)).Return(errors.New("error message"))
+
+	_, err := NewCategoryUseCase(mockCategoryRepo, nil, nil).Move(context.Background(), "shirts", "")
+
+	assert.Error(t, err)
+}
+
+func TestSetProductCategoriesProductNotFound(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(nil, nil)
+
+	err := NewCategoryUseCase(nil, mockProductRepo, nil).SetProductCategories(context.Background(), "uuid", []string{"shirts"})
+
+	assert.ErrorIs(t, err, domain.ErrProductNotFound)
+}
+
+func TestSetProductCategoriesCategoryNotFound(t *testing.T) {
+	mockCategoryRepo := new(mocks.MockCategoryRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 9}, nil)
+	mockCategoryRepo.On("GetBySlugs", mock.Anything, []string{"shirts", "ghost"}).Return([]domain.Category{{ID: 4}}, nil)
+
+	err := NewCategoryUseCase(mockCategoryRepo, mockProductRepo, nil).SetProductCategories(context.Background(), "uuid", []string{"shirts", "ghost"})
+
+	assert.ErrorIs(t, err, domain.ErrCategoryNotFound)
+}
+
+func TestSetProductCategories(t *testing.T) {
+	mockCategoryRepo := new(mocks.MockCategoryRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 9}, nil)
+	mockCategoryRepo.On("GetBySlugs", mock.Anything, []string{"shirts", "polo", "shirts"}).Return([]domain.Category{{ID: 4}, {ID: 5}}, nil)
+	mockCategoryRepo.On("SetProductCategories", mock.Anything, int64(9), []int64{4, 5}).Return(nil)
+
+	err := NewCategoryUseCase(mockCategoryRepo, mockProductRepo, nil).SetProductCategories(context.Background(), "uuid", []string{"shirts", "polo", "shirts"})
+
+	assert.NoError(t, err)
+	mockCategoryRepo.AssertExpectations(t)
+}
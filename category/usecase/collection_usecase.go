@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type collectionUseCase struct {
+	collectionRepo domain.CollectionRepository
+	productUseCase domain.ProductUseCase
+}
+
+func NewCollectionUseCase(cr domain.CollectionRepository, puc domain.ProductUseCase) domain.CollectionUseCase {
+	return &collectionUseCase{collectionRepo: cr, productUseCase: puc}
+}
+
+func (cu *collectionUseCase) Get(ctx context.Context, slug string) (*domain.Collection, error) {
+	return cu.collectionRepo.GetBySlug(ctx, slug)
+}
+
+// ListProducts lists the products added to a manual collection, or the ones
+// matching the rule of a rule collection. The filters of the request only
+// narrow the collection down, the rule always wins.
+func (cu *collectionUseCase) ListProducts(ctx context.Context, slug string, q domain.ProductQuery, login string) (*domain.ProductPage, error) {
+	collection, err := cu.collectionRepo.GetBySlug(ctx, slug)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if collection == nil {
+		return nil, nil
+	}
+
+	if collection.Kind == domain.CollectionKindManual {
+		q.Collection = slug
+	}
+
+	if rule := collection.Rule; collection.Kind == domain.CollectionKindRule && rule != nil {
+		if rule.Category != "" {
+			q.Category = rule.Category
+		}
+
+		attributes := map[string][]string{}
+
+		for label, values := range q.Attributes {
+			attributes[label] = values
+		}
+
+		for label, values := range rule.Attributes {
+			attributes[label] = values
+		}
+
+		q.Attributes = attributes
+
+		if rule.MinPrice != nil && (q.MinPrice == nil || *q.MinPrice < *rule.MinPrice) {
+			q.MinPrice = rule.MinPrice
+		}
+
+		if rule.MaxPrice != nil && (q.MaxPrice == nil || *q.MaxPrice > *rule.MaxPrice) {
+			q.MaxPrice = rule.MaxPrice
+		}
+	}
+
+	return cu.productUseCase.List(ctx, q, login)
+}
+
+func (cu *collectionUseCase) Create(ctx context.Context, c *domain.Collection) error {
+	existing, err := cu.collectionRepo.GetBySlug(ctx, c.Slug)
+
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		return domain.ErrSlugTaken
+	}
+
+	return cu.collectionRepo.Store(ctx, c)
+}
+
+func (cu *collectionUseCase) Update(ctx context.Context, slug string, c *domain.Collection) (*domain.Collection, error) {
+	existing, err := cu.collectionRepo.GetBySlug(ctx, slug)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if existing == nil {
+		return nil, nil
+	}
+
+	if c.Slug != slug {
+		taken, err := cu.collectionRepo.GetBySlug(ctx, c.Slug)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if taken != nil {
+			return nil, domain.ErrSlugTaken
+		}
+	}
+
+	c.ID = existing.ID
+
+	if err := cu.collectionRepo.Update(ctx, c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
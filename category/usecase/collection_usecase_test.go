@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCollectionListProductsNotFound(t *testing.T) {
+	mockCollectionRepo := new(mocks.MockCollectionRepository)
+
+	mockCollectionRepo.On("GetBySlug", mock.Anything, "summer").Return(nil, nil)
+
+	page, err := NewCollectionUseCase(mockCollectionRepo, nil).ListProducts(context.Background(), "summer", domain.ProductQuery{}, "")
+
+	assert.NoError(t, err)
+	assert.Nil(t, page)
+}
+
+func TestCollectionListProductsManual(t *testing.T) {
+	mockCollectionRepo := new(mocks.MockCollectionRepository)
+	mockProductUseCase := new(mocks.MockProductUsecase)
+
+	page := &domain.ProductPage{Products: []domain.Product{}}
+
+	mockCollectionRepo.On("GetBySlug", mock.Anything, "summer").Return(&domain.Collection{ID: 2, Slug: "summer", Kind: domain.CollectionKindManual}, nil)
+	mockProductUseCase.On("List", mock.Anything, domain.ProductQuery{Limit: 20, Collection: "summer"}, "").Return(page, nil)
+
+	res, err := NewCollectionUseCase(mockCollectionRepo, mockProductUseCase).ListProducts(context.Background(), "summer", domain.ProductQuery{Limit: 20}, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, page, res)
+}
+
+func TestCollectionListProductsRule(t *testing.T) {
+	mockCollectionRepo := new(mocks.MockCollectionRepository)
+	mockProductUseCase := new(mocks.MockProductUsecase)
+
+	ruleMin, ruleMax, reqMin, reqMax := int64(1000), int64(5000), int64(2000), int64(9000)
+
+	rule := &domain.CollectionRule{Category: "shirts", Attributes: map[string][]string{"color": {"black"}}, MinPrice: &ruleMin, MaxPrice: &ruleMax}
+
+	reqAttributes := map[string][]string{"size": {"M"}, "color": {"white"}}
+
+	expected := domain.ProductQuery{
+		Limit:      20,
+		Category:   "shirts",
+		Attributes: map[string][]string{"size": {"M"}, "color": {"black"}},
+		MinPrice:   &reqMin,
+		MaxPrice:   &ruleMax,
+	}
+
+	mockCollectionRepo.On("GetBySlug", mock.Anything, "cheap-shirts").Return(&domain.Collection{ID: 1, Kind: domain.CollectionKindRule, Rule: rule}, nil)
+	mockProductUseCase.On("List", mock.Anything, expected, "").Return(&domain.ProductPage{}, nil)
+
+	_, err := NewCollectionUseCase(mockCollectionRepo, mockProductUseCase).ListProducts(context.Background(), "cheap-shirts", domain.ProductQuery{Limit: 20, Category: "shoes", Attributes: reqAttributes, MinPrice: &reqMin, MaxPrice: &reqMax}, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"white"}, reqAttributes["color"])
+}
+
+func TestCollectionCreateSlugTaken(t *testing.T) {
+	mockCollectionRepo := new(mocks.MockCollectionRepository)
+
+	mockCollectionRepo.On("GetBySlug", mock.Anything, "summer").Return(&domain.Collection{ID: 2}, nil)
+
+	err := NewCollectionUseCase(mockCollectionRepo, nil).Create(context.Background(), &domain.Collection{Slug: "summer"})
+
+	assert.ErrorIs(t, err, domain.ErrSlugTaken)
+}
+
+func TestCollectionCreate(t *testing.T) {
+	mockCollectionRepo := new(mocks.MockCollectionRepository)
+
+	c := &domain.Collection{Slug: "summer", Kind: domain.CollectionKindManual}
+
+	mockCollectionRepo.On("GetBySlug", mock.Anything, "summer").Return(nil, nil)
+	mockCollectionRepo.On("Store", mock.Anything, c).Return(nil)
+
+	err := NewCollectionUseCase(mockCollectionRepo, nil).Create(context.Background(), c)
+
+	assert.NoError(t, err)
+}
+
+func TestCollectionUpdate(t *testing.T) {
+	mockCollectionRepo := new(mocks.MockCollectionRepository)
+
+	c := &domain.Collection{Slug: "summer", Name: "Summer sale", Kind: domain.CollectionKindManual}
+
+	mockCollectionRepo.On("GetBySlug", mock.Anything, "summer").Return(&domain.Collection{ID: 2, Slug: "summer"}, nil)
+	mockCollectionRepo.On("Update", mock.Anything, c).Return(nil)
+
+	updated, err := NewCollectionUseCase(mockCollectionRepo, nil).Update(context.Background(), "summer", c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), updated.ID)
+}
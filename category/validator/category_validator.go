@@ -0,0 +1,33 @@
+package validator
+
+import (
+	"context"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+var slugRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+type categoryValidator struct{}
+
+func NewCategoryValidator() *categoryValidator {
+	return &categoryValidator{}
+}
+
+func (cv *categoryValidator) Validate(ctx context.Context, c *domain.Category) (domain.IsValid, domain.Message) {
+	if c.Name == "" {
+		return false, "category's name can not be empty"
+	}
+
+	if utf8.RuneCountInString(c.Name) > 150 {
+		return false, "category's name can not have more than 150 characters"
+	}
+
+	if len(c.Slug) > 150 || !slugRegexp.MatchString(c.Slug) {
+		return false, "category's slug must have up to 150 lowercase letters, numbers and hyphens"
+	}
+
+	return true, ""
+}
@@ -0,0 +1,31 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCategoryEmptyName(t *testing.T) {
+	isValid, message := NewCategoryValidator().Validate(context.Background(), &domain.Category{Slug: "shirts"})
+
+	assert.False(t, bool(isValid))
+	assert.Equal(t, domain.Message("category's name can not be empty"), message)
+}
+
+func TestValidateCategoryInvalidSlug(t *testing.T) {
+	for _, slug := range []string{"", "Shirts", "t shirts", "-shirts", "camisetas-", "camisetas--polo", "café"} {
+		isValid, _ := NewCategoryValidator().Validate(context.Background(), &domain.Category{Slug: slug, Name: "Shirts"})
+
+		assert.False(t, bool(isValid), slug)
+	}
+}
+
+func TestValidateCategory(t *testing.T) {
+	isValid, message := NewCategoryValidator().Validate(context.Background(), &domain.Category{Slug: "t-shirts-2", Name: "T-Shirts"})
+
+	assert.True(t, bool(isValid))
+	assert.Equal(t, domain.Message(""), message)
+}
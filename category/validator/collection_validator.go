@@ -0,0 +1,57 @@
+package validator
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type collectionValidator struct{}
+
+func NewCollectionValidator() *collectionValidator {
+	return &collectionValidator{}
+}
+
+func (cv *collectionValidator) Validate(ctx context.Context, c *domain.Collection) (domain.IsValid, domain.Message) {
+	if c.Name == "" {
+		return false, "collection's name can not be empty"
+	}
+
+	if utf8.RuneCountInString(c.Name) > 150 {
+		return false, "collection's name can not have more than 150 characters"
+	}
+
+	if len(c.Slug) > 150 || !slugRegexp.MatchString(c.Slug) {
+		return false, "collection's slug must have up to 150 lowercase letters, numbers and hyphens"
+	}
+
+	switch c.Kind {
+	case domain.CollectionKindManual:
+		if c.Rule != nil {
+			return false, "manual collection can not have a rule"
+		}
+
+		for _, productUUID := range c.ProductUUIDs {
+			if productUUID == "" {
+				return false, "collection's product uuid can not be empty"
+			}
+		}
+	case domain.CollectionKindRule:
+		if c.Rule == nil {
+			return false, "rule collection must have a rule"
+		}
+
+		if len(c.ProductUUIDs) > 0 {
+			return false, "rule collection can not have products added manually"
+		}
+
+		if c.Rule.MinPrice != nil && c.Rule.MaxPrice != nil && *c.Rule.MinPrice > *c.Rule.MaxPrice {
+			return false, "collection's rule minPrice can not be greater than maxPrice"
+		}
+	default:
+		return false, "collection's kind must be manual or rule"
+	}
+
+	return true, ""
+}
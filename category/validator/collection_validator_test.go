@@ -0,0 +1,43 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCollectionInvalidKind(t *testing.T) {
+	isValid, message := NewCollectionValidator().Validate(context.Background(), &domain.Collection{Slug: "summer", Name: "Summer", Kind: "auto"})
+
+	assert.False(t, bool(isValid))
+	assert.Equal(t, domain.Message("collection's kind must be manual or rule"), message)
+}
+
+func TestValidateCollectionRuleWithoutRule(t *testing.T) {
+	isValid, message := NewCollectionValidator().Validate(context.Background(), &domain.Collection{Slug: "summer", Name: "Summer", Kind: domain.CollectionKindRule})
+
+	assert.False(t, bool(isValid))
+	assert.Equal(t, domain.Message("rule collection must have a rule"), message)
+}
+
+func TestValidateCollectionManualWithRule(t *testing.T) {
+	isValid, _ := NewCollectionValidator().Validate(context.Background(), &domain.Collection{Slug: "summer", Name: "Summer", Kind: domain.CollectionKindManual, Rule: &domain.CollectionRule{}})
+
+	assert.False(t, bool(isValid))
+}
+
+func TestValidateCollectionRulePriceRange(t *testing.T) {
+	min, max := int64(500), int64(100)
+
+	isValid, _ := NewCollectionValidator().Validate(context.Background(), &domain.Collection{Slug: "summer", Name: "Summer", Kind: domain.CollectionKindRule, Rule: &domain.CollectionRule{MinPrice: &min, MaxPrice: &max}})
+
+	assert.False(t, bool(isValid))
+}
+
+func TestValidateCollection(t *testing.T) {
+	isValid, _ := NewCollectionValidator().Validate(context.Background(), &domain.Collection{Slug: "summer", Name: "Summer", Kind: domain.CollectionKindManual, ProductUUIDs: []string{"uuid"}})
+
+	assert.True(t, bool(isValid))
+}
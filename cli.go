@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+const usage = `usage:
+  e-commerce-go-clean-arch import [-dry-run] [-format csv|jsonl] <file>
+  e-commerce-go-clean-arch import -resume <uuid>
+  e-commerce-go-clean-arch export [-format csv|jsonl] [file]`
+
+// runCommand runs the command line tools instead of the server, the imports
+// go through the same jobs as the admin routes, so a killed import can be
+// resumed from the command line or by the server.
+func runCommand(args []string, catalogueUsecase domain.CatalogueUseCase) error {
+	switch args[0] {
+	case "import":
+		return runImport(args[1:], catalogueUsecase)
+	case "export":
+		return runExport(args[1:], catalogueUsecase)
+	}
+
+	return errors.New(usage)
+}
+
+func runImport(args []string, catalogueUsecase domain.CatalogueUseCase) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "check the rows without changing the catalogue")
+	format := flags.String("format", "", "csv or jsonl, taken from the extension of the file when empty")
+	resume := flags.String("resume", "", "uuid of an import to resume")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	uuid := *resume
+
+	if uuid == "" {
+		if flags.NArg() != 1 {
+			return errors.New(usage)
+		}
+
+		f, err := os.Open(flags.Arg(0))
+
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+
+		if *format == "" {
+			*format = strings.ToLower(strings.TrimPrefix(filepath.Ext(f.Name()), "."))
+		}
+
+		job, err := catalogueUsecase.CreateImport(ctx, domain.CatalogueFormat(*format), f, *dryRun)
+
+		if err != nil {
+			return err
+		}
+
+		uuid = job.UUID
+		fmt.Printf("import %s created\n", uuid)
+	}
+
+	if _, err := catalogueUsecase.RunImport(ctx, uuid); err != nil {
+		return fmt.Errorf("import %s stopped, resume it with -resume: %w", uuid, err)
+	}
+
+	job, err := catalogueUsecase.GetImport(ctx, uuid)
+
+	if err != nil {
+		return err
+	}
+
+	if job.DryRun {
+		fmt.Println("dry run, nothing was changed")
+	}
+
+	fmt.Printf("%s: %d rows, %d created, %d updated, %d failed\n", job.Status, job.Processed, job.Created, job.Updated, job.Failed)
+
+	if job.Error != "" {
+		fmt.Println(job.Error)
+	}
+
+	for _, rowError := range job.Errors {
+		fmt.Printf("line %d: %s\n", rowError.Line, rowError.Message)
+	}
+
+	return nil
+}
+
+func runExport(args []string, catalogueUsecase domain.CatalogueUseCase) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := flags.String("format", "csv", "csv or jsonl")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+
+	if flags.NArg() > 0 {
+		f, err := os.Create(flags.Arg(0))
+
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+
+		w = f
+	}
+
+	return catalogueUsecase.Export(context.Background(), domain.CatalogueFormat(*format), w)
+}
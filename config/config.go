@@ -11,6 +11,13 @@ type conf struct {
 	Server struct {
 		Address string
 	}
+	Site struct {
+		URL string
+	}
+	Locale struct {
+		Default   string
+		Fallbacks map[string]string
+	}
 	Context struct {
 		Timeout int8
 	}
@@ -21,6 +28,51 @@ type conf struct {
 		Pass string
 		Name string
 	}
+	Notification struct {
+		Secret         string
+		UnsubscribeURL string `yaml:"unsubscribeUrl"`
+	}
+	Storage struct {
+		Driver    string
+		Path      string
+		Endpoint  string
+		Bucket    string
+		Region    string
+		AccessKey string `yaml:"accessKey"`
+		SecretKey string `yaml:"secretKey"`
+	}
+	Checkout struct {
+		Shipping         int64
+		ShippingPerUnit  int64 `yaml:"shippingPerUnit"`
+		FreeShippingFrom int64 `yaml:"freeShippingFrom"`
+		TaxRate          int64 `yaml:"taxRate"`
+	}
+	Payment struct {
+		Driver         string
+		MerchantName   string `yaml:"merchantName"`
+		MerchantCity   string `yaml:"merchantCity"`
+		PixKey         string `yaml:"pixKey"`
+		WebhookSecret  string `yaml:"webhookSecret"`
+		RestockRefunds bool   `yaml:"restockRefunds"`
+		Card           struct {
+			Endpoint      string
+			APIKey        string `yaml:"apiKey"`
+			WebhookSecret string `yaml:"webhookSecret"`
+		}
+		Pix struct {
+			Endpoint      string
+			Token         string
+			WebhookSecret string `yaml:"webhookSecret"`
+		}
+	}
+	Cache struct {
+		Driver   string
+		Size     int
+		TTL      int
+		Address  string
+		Password string
+		DB       int
+	}
 }
 
 func GetConf(filename string) (*conf, error) {
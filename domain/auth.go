@@ -7,8 +7,11 @@ type Auth struct {
 	UUID     string `json:"uuid"`
 	Login    string `json:"login"`
 	Password string `json:"password"`
+	Role     string `json:"-"`
 }
 
+const AdminRole = "admin"
+
 type AuthUseCase interface {
 	Login(ctx context.Context, a *Auth) (Token, error)
 	SignUp(ctx context.Context, a *Auth, u *User) (Token, error)
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrCartItemNotFound = errors.New("cart item not found")
+var ErrCartFull = errors.New("cart full")
+var ErrQuantityLimit = errors.New("quantity over the limit")
+
+// MaxCartItems is how many skus a cart takes, MaxCartItemQuantity how many
+// units of each.
+const (
+	MaxCartItems        = 50
+	MaxCartItemQuantity = 10
+)
+
+type CartItemStatus string
+
+const (
+	CartItemStatusAvailable         CartItemStatus = "available"
+	CartItemStatusInsufficientStock CartItemStatus = "insufficient_stock"
+	CartItemStatusOutOfStock        CartItemStatus = "out_of_stock"
+	CartItemStatusUnavailable       CartItemStatus = "unavailable"
+)
+
+// CartItem keeps only the sku and the quantity, the rest is read again from
+// the catalogue, the pricing and the inventory every time the cart is read.
+// Stock is the quantity available when it is not enough for the item.
+type CartItem struct {
+	ProductUUID string          `json:"productUuid"`
+	SKU         string          `json:"sku"`
+	Quantity    int64           `json:"quantity"`
+	Name        string          `json:"name"`
+	Options     []VariantOption `json:"options"`
+	Picture     string          `json:"picture,omitempty"`
+	Price       *EffectivePrice `json:"price,omitempty"`
+	Status      CartItemStatus  `json:"status"`
+	Stock       *int64          `json:"stock,omitempty"`
+}
+
+// Cart belongs to a user, or to a guest who has its Token in a cookie. The
+// Subtotal only counts the available items.
+type Cart struct {
+	ID       int64      `json:"-"`
+	UserID   int64      `json:"-"`
+	Token    string     `json:"-"`
+	Items    []CartItem `json:"items"`
+	Subtotal Money      `json:"subtotal"`
+}
+
+// CartUseCase takes the login of the user, empty for guests, and the token
+// of the guest cart, empty when there is none. A guest cart given with a
+// login is merged into the cart of the user and deleted.
+type CartUseCase interface {
+	Get(ctx context.Context, login string, token string) (*Cart, error)
+	AddItem(ctx context.Context, login string, token string, item *CartItem) (*Cart, error)
+	SetQuantity(ctx context.Context, login string, token string, sku string, quantity int64) (*Cart, error)
+	RemoveItem(ctx context.Context, login string, token string, sku string) (*Cart, error)
+	DeleteExpired(ctx context.Context) (int, error)
+}
+
+type CartRepository interface {
+	GetByUserID(ctx context.Context, userID int64) (*Cart, error)
+	GetByToken(ctx context.Context, token string) (*Cart, error)
+	Store(ctx context.Context, c *Cart) error
+	SetItem(ctx context.Context, cartID int64, item *CartItem) error
+	RemoveItem(ctx context.Context, cartID int64, sku string) error
+	Delete(ctx context.Context, cartID int64) error
+	DeleteExpired(ctx context.Context, guestsBefore time.Time, usersBefore time.Time, limit int) (int, error)
+}
+
+type CartItemValidator interface {
+	Validate(ctx context.Context, item *CartItem) (IsValid, Message)
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var ErrInvalidRow = errors.New("invalid row")
+var ErrUnsupportedFormat = errors.New("unsupported format")
+var ErrImportJobNotFound = errors.New("import job not found")
+var ErrImportJobRunning = errors.New("import job already running")
+var ErrImportTooLarge = errors.New("import file too large")
+
+type CatalogueFormat string
+
+const (
+	CatalogueFormatCSV   CatalogueFormat = "csv"
+	CatalogueFormatJSONL CatalogueFormat = "jsonl"
+)
+
+// CatalogueRow is one line of a catalogue file. A row with SKU changes the
+// price and the barcode of that variant, otherwise the row creates a product,
+// or updates the one with UUID. The empty fields are kept as they are.
+type CatalogueRow struct {
+	UUID       string        `json:"uuid,omitempty"`
+	SKU        string        `json:"sku,omitempty"`
+	Name       string        `json:"name,omitempty"`
+	Detail     string        `json:"detail,omitempty"`
+	Status     ProductStatus `json:"status,omitempty"`
+	Price      *int64        `json:"price,omitempty"`
+	Barcode    string        `json:"barcode,omitempty"`
+	Attributes []Attribute   `json:"attributes,omitempty"`
+	Pictures   []string      `json:"pictures,omitempty"`
+}
+
+// CatalogueReader gives the rows of a file one at a time with their line,
+// the errors of a single row wrap ErrInvalidRow and the reading goes on after
+// them, io.EOF ends it.
+type CatalogueReader interface {
+	Read() (*CatalogueRow, int64, error)
+}
+
+type CatalogueWriter interface {
+	Write(row *CatalogueRow) error
+	Flush() error
+}
+
+type CatalogueCodec interface {
+	NewReader(format CatalogueFormat, r io.Reader) (CatalogueReader, error)
+	NewWriter(format CatalogueFormat, w io.Writer) (CatalogueWriter, error)
+}
+
+type ImportStatus string
+
+const (
+	ImportStatusPending   ImportStatus = "pending"
+	ImportStatusRunning   ImportStatus = "running"
+	ImportStatusCompleted ImportStatus = "completed"
+	ImportStatusFailed    ImportStatus = "failed"
+)
+
+type ImportRowError struct {
+	Line    int64  `json:"line"`
+	Message string `json:"message"`
+}
+
+// ImportJob is resumed after its Processed rows, a dry run checks every row
+// without changing the catalogue and counts what would be created and updated.
+type ImportJob struct {
+	ID        int64            `json:"-"`
+	UUID      string           `json:"uuid"`
+	Format    CatalogueFormat  `json:"format"`
+	DryRun    bool             `json:"dryRun"`
+	Status    ImportStatus     `json:"status"`
+	Processed int64            `json:"processed"`
+	Created   int64            `json:"created"`
+	Updated   int64            `json:"updated"`
+	Failed    int64            `json:"failed"`
+	Error     string           `json:"error,omitempty"`
+	Errors    []ImportRowError `json:"errors,omitempty"`
+	CreatedAt time.Time        `json:"createdAt"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+}
+
+type CatalogueUseCase interface {
+	CreateImport(ctx context.Context, format CatalogueFormat, r io.Reader, dryRun bool) (*ImportJob, error)
+	RunImport(ctx context.Context, uuid string) (*ImportJob, error)
+	ResumeInterrupted(ctx context.Context) (int, error)
+	GetImport(ctx context.Context, uuid string) (*ImportJob, error)
+	Export(ctx context.Context, format CatalogueFormat, w io.Writer) error
+}
+
+// ImportJobRepository Claim takes a job to run it, unless it is completed or
+// running and updated after staleBefore, which means its runner is alive.
+type ImportJobRepository interface {
+	GetByUUID(ctx context.Context, uuid string) (*ImportJob, error)
+	GetInterrupted(ctx context.Context, staleBefore time.Time) ([]ImportJob, error)
+	Store(ctx context.Context, j *ImportJob) error
+	Claim(ctx context.Context, j *ImportJob, staleBefore time.Time) (bool, error)
+	Update(ctx context.Context, j *ImportJob) error
+	AddError(ctx context.Context, jobID int64, e ImportRowError) error
+	GetErrors(ctx context.Context, jobID int64, limit int) ([]ImportRowError, error)
+}
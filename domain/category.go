@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrCategoryNotFound = errors.New("category not found")
+var ErrCategoryCycle = errors.New("category can not be moved under itself")
+var ErrSlugTaken = errors.New("slug already in use")
+
+// Category Path is the materialised path of ids from the root, like "/1/4/9/",
+// so a subtree is every category whose path starts with the root's path.
+type Category struct {
+	ID       int64       `json:"-"`
+	ParentID *int64      `json:"-"`
+	Slug     string      `json:"slug"`
+	Name     string      `json:"name"`
+	Path     string      `json:"-"`
+	Depth    int         `json:"depth"`
+	Children []*Category `json:"children,omitempty"`
+}
+
+type CollectionKind string
+
+const (
+	CollectionKindManual CollectionKind = "manual"
+	CollectionKindRule   CollectionKind = "rule"
+)
+
+// CollectionRule holds the listing filters a rule collection is made of.
+type CollectionRule struct {
+	Category   string              `json:"category,omitempty"`
+	Attributes map[string][]string `json:"attributes,omitempty"`
+	MinPrice   *int64              `json:"minPrice,omitempty"`
+	MaxPrice   *int64              `json:"maxPrice,omitempty"`
+}
+
+type Collection struct {
+	ID           int64           `json:"-"`
+	Slug         string          `json:"slug"`
+	Name         string          `json:"name"`
+	Kind         CollectionKind  `json:"kind"`
+	Rule         *CollectionRule `json:"rule,omitempty"`
+	ProductUUIDs []string        `json:"productUuids,omitempty"`
+}
+
+type CategoryUseCase interface {
+	GetTree(ctx context.Context) ([]*Category, error)
+	GetBreadcrumb(ctx context.Context, slug string) ([]Category, error)
+	ListProducts(ctx context.Context, slug string, q ProductQuery, login string) (*ProductPage, error)
+	Create(ctx context.Context, c *Category, parentSlug string) error
+	Update(ctx context.Context, slug string, c *Category) (*Category, error)
+	Move(ctx context.Context, slug string, parentSlug string) (*Category, error)
+	SetProductCategories(ctx context.Context, productUUID string, slugs []string) error
+}
+
+type CategoryRepository interface {
+	GetAll(ctx context.Context) ([]Category, error)
+	GetBySlug(ctx context.Context, slug string) (*Category, error)
+	GetBySlugs(ctx context.Context, slugs []string) ([]Category, error)
+	GetByIDs(ctx context.Context, ids []int64) ([]Category, error)
+	Store(ctx context.Context, c *Category, parent *Category) error
+	Update(ctx context.Context, c *Category) error
+	Move(ctx context.Context, c *Category, parent *Category) error
+	SetProductCategories(ctx context.Context, productID int64, categoryIDs []int64) error
+}
+
+type CategoryValidator interface {
+	Validate(ctx context.Context, c *Category) (IsValid, Message)
+}
+
+type CollectionUseCase interface {
+	Get(ctx context.Context, slug string) (*Collection, error)
+	ListProducts(ctx context.Context, slug string, q ProductQuery, login string) (*ProductPage, error)
+	Create(ctx context.Context, c *Collection) error
+	Update(ctx context.Context, slug string, c *Collection) (*Collection, error)
+}
+
+type CollectionRepository interface {
+	GetBySlug(ctx context.Context, slug string) (*Collection, error)
+	Store(ctx context.Context, c *Collection) error
+	Update(ctx context.Context, c *Collection) error
+}
+
+type CollectionValidator interface {
+	Validate(ctx context.Context, c *Collection) (IsValid, Message)
+}
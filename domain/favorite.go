@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrWishlistNotFound = errors.New("wishlist not found")
+var ErrWishlistNameTaken = errors.New("wishlist name already in use")
+
+// Wishlist is a named list of products of a customer, anyone with its
+// ShareToken can see it while it is shared.
+type Wishlist struct {
+	ID         int64     `json:"-"`
+	UUID       string    `json:"uuid"`
+	UserID     int64     `json:"-"`
+	Name       string    `json:"name"`
+	ShareToken string    `json:"shareToken,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type FavoriteUseCase interface {
+	Add(ctx context.Context, login string, productUUID string) error
+	Remove(ctx context.Context, login string, productUUID string) error
+	List(ctx context.Context, login string, q ProductQuery) (*ProductPage, error)
+}
+
+type FavoriteRepository interface {
+	Add(ctx context.Context, userID int64, productID int64) error
+	Remove(ctx context.Context, userID int64, productID int64) error
+}
+
+type WishlistUseCase interface {
+	List(ctx context.Context, login string) ([]Wishlist, error)
+	Create(ctx context.Context, login string, w *Wishlist) error
+	Delete(ctx context.Context, login string, uuid string) error
+	AddProduct(ctx context.Context, login string, uuid string, productUUID string) error
+	RemoveProduct(ctx context.Context, login string, uuid string, productUUID string) error
+	ListProducts(ctx context.Context, login string, uuid string, q ProductQuery) (*ProductPage, error)
+	Share(ctx context.Context, login string, uuid string) (*Wishlist, error)
+	Unshare(ctx context.Context, login string, uuid string) error
+	GetShared(ctx context.Context, token string) (*Wishlist, error)
+	ListSharedProducts(ctx context.Context, token string, q ProductQuery, login string) (*ProductPage, error)
+}
+
+type WishlistRepository interface {
+	GetByUUID(ctx context.Context, uuid string) (*Wishlist, error)
+	GetByShareToken(ctx context.Context, token string) (*Wishlist, error)
+	GetByUserID(ctx context.Context, userID int64) ([]Wishlist, error)
+	Store(ctx context.Context, w *Wishlist) error
+	Delete(ctx context.Context, id int64) error
+	AddProduct(ctx context.Context, wishlistID int64, productID int64) error
+	RemoveProduct(ctx context.Context, wishlistID int64, productID int64) error
+	SetShareToken(ctx context.Context, id int64, token string) error
+}
+
+type WishlistValidator interface {
+	Validate(ctx context.Context, w *Wishlist) (IsValid, Message)
+}
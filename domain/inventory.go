@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrInsufficientStock = errors.New("insufficient stock")
+var ErrReservationNotFound = errors.New("reservation not found")
+var ErrReservationExpired = errors.New("reservation expired")
+var ErrWarehouseNotFound = errors.New("warehouse not found")
+var ErrWarehouseCodeTaken = errors.New("warehouse code already in use")
+
+type Warehouse struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// Priority orders the warehouses the reservations are taken from, the
+	// highest first.
+	Priority int `json:"priority"`
+}
+
+type StockLevel struct {
+	SKU       string `json:"sku"`
+	Warehouse string `json:"warehouse"`
+	OnHand    int64  `json:"onHand"`
+	Reserved  int64  `json:"reserved"`
+	Available int64  `json:"available"`
+}
+
+type Stock struct {
+	SKU       string       `json:"sku"`
+	Levels    []StockLevel `json:"levels"`
+	Available int64        `json:"available"`
+	// BackInStockThreshold is the available quantity from which the
+	// customers waiting for the sku are told it is back in stock.
+	BackInStockThreshold int64 `json:"backInStockThreshold"`
+}
+
+type ReservationStatus string
+
+const (
+	ReservationStatusActive    ReservationStatus = "active"
+	ReservationStatusCommitted ReservationStatus = "committed"
+	ReservationStatusReleased  ReservationStatus = "released"
+)
+
+// Reservation holds a quantity of a sku in one warehouse for a cart or an
+// order, given by Reference, until it is committed, released or expires.
+type Reservation struct {
+	UUID      string            `json:"uuid"`
+	SKU       string            `json:"sku"`
+	Warehouse string            `json:"warehouse"`
+	Quantity  int64             `json:"quantity"`
+	Reference string            `json:"reference"`
+	Status    ReservationStatus `json:"status"`
+	ExpiresAt time.Time         `json:"expiresAt"`
+}
+
+type MovementReason string
+
+const (
+	MovementReasonReceipt     MovementReason = "receipt"
+	MovementReasonAdjustment  MovementReason = "adjustment"
+	MovementReasonReservation MovementReason = "reservation"
+	MovementReasonRelease     MovementReason = "release"
+	MovementReasonExpiry      MovementReason = "expiry"
+	MovementReasonSale        MovementReason = "sale"
+	MovementReasonRefund      MovementReason = "refund"
+)
+
+// StockMovement is an entry of the append-only ledger of the changes of the
+// stock levels.
+type StockMovement struct {
+	ID            int64          `json:"id"`
+	SKU           string         `json:"sku"`
+	Warehouse     string         `json:"warehouse"`
+	OnHandDelta   int64          `json:"onHandDelta"`
+	ReservedDelta int64          `json:"reservedDelta"`
+	Reason        MovementReason `json:"reason"`
+	Reference     string         `json:"reference,omitempty"`
+	CreatedAt     time.Time      `json:"createdAt"`
+}
+
+type StockAlert struct {
+	ID     int64
+	SKU    string
+	UserID int64
+}
+
+type InventoryUseCase interface {
+	GetStock(ctx context.Context, sku string) (*Stock, error)
+	AddMovement(ctx context.Context, m *StockMovement) error
+	GetMovements(ctx context.Context, sku string, limit int) ([]StockMovement, error)
+	CreateWarehouse(ctx context.Context, w *Warehouse) error
+	SetThreshold(ctx context.Context, sku string, threshold int64) error
+	Reserve(ctx context.Context, sku string, quantity int64, reference string, ttl time.Duration) (*Reservation, error)
+	Release(ctx context.Context, uuid string) error
+	Commit(ctx context.Context, uuid string) error
+	ReleaseExpired(ctx context.Context) (int, error)
+	SubscribeAlert(ctx context.Context, sku string, login string) error
+	NotifyBackInStock(ctx context.Context) (int, error)
+}
+
+// InventoryRepository changes the stock levels only with conditional updates,
+// so concurrent reservations never take more than what is available. The Tx
+// methods write within the transaction of another repository, as the one
+// that places an order, which rolls it back on errors and calls
+// TouchProducts once it is committed.
+type InventoryRepository interface {
+	GetWarehouse(ctx context.Context, code string) (*Warehouse, error)
+	StoreWarehouse(ctx context.Context, w *Warehouse) error
+	GetLevels(ctx context.Context, sku string) ([]StockLevel, error)
+	Adjust(ctx context.Context, m *StockMovement) error
+	Reserve(ctx context.Context, r *Reservation) error
+	GetReservation(ctx context.Context, uuid string) (*Reservation, error)
+	Release(ctx context.Context, uuid string, reason MovementReason) error
+	Commit(ctx context.Context, uuid string) error
+	ReserveTx(ctx context.Context, tx Tx, r *Reservation) error
+	ReleaseTx(ctx context.Context, tx Tx, uuid string, reason MovementReason) error
+	CommitTx(ctx context.Context, tx Tx, uuid string) error
+	TouchProducts(ctx context.Context, skus []string) error
+	GetExpiredReservations(ctx context.Context, now time.Time, limit int) ([]Reservation, error)
+	GetMovements(ctx context.Context, sku string, limit int) ([]StockMovement, error)
+	GetThreshold(ctx context.Context, sku string) (int64, error)
+	SetThreshold(ctx context.Context, sku string, threshold int64) error
+	StoreAlert(ctx context.Context, sku string, userID int64) error
+	GetDueAlerts(ctx context.Context, limit int) ([]StockAlert, error)
+	DeleteAlert(ctx context.Context, id int64) error
+}
+
+type WarehouseValidator interface {
+	Validate(ctx context.Context, w *Warehouse) (IsValid, Message)
+}
+
+type StockMovementValidator interface {
+	Validate(ctx context.Context, m *StockMovement) (IsValid, Message)
+}
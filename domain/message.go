@@ -11,6 +11,9 @@ type MessageConfig struct {
 	HasTemplate       bool
 	TemplateID        string
 	TemplateVariables map[string]string
+	Headers           map[string]string
+	Category          NotificationCategory
+	User              *User
 }
 
 type MessageService interface {
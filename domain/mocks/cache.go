@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCache struct {
+	mock.Mock
+}
+
+func (mc *MockCache) Get(ctx context.Context, key string) ([]byte, error) {
+	args := mc.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (mc *MockCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := mc.Called(ctx, key, value, ttl)
+	return args.Error(0)
+}
+
+func (mc *MockCache) Delete(ctx context.Context, key string) error {
+	args := mc.Called(ctx, key)
+	return args.Error(0)
+}
@@ -0,0 +1,104 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCartUseCase struct {
+	mock.Mock
+}
+
+func (mcu *MockCartUseCase) Get(ctx context.Context, login string, token string) (*domain.Cart, error) {
+	args := mcu.Called(ctx, login, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Cart), args.Error(1)
+}
+
+func (mcu *MockCartUseCase) AddItem(ctx context.Context, login string, token string, item *domain.CartItem) (*domain.Cart, error) {
+	args := mcu.Called(ctx, login, token, item)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Cart), args.Error(1)
+}
+
+func (mcu *MockCartUseCase) SetQuantity(ctx context.Context, login string, token string, sku string, quantity int64) (*domain.Cart, error) {
+	args := mcu.Called(ctx, login, token, sku, quantity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Cart), args.Error(1)
+}
+
+func (mcu *MockCartUseCase) RemoveItem(ctx context.Context, login string, token string, sku string) (*domain.Cart, error) {
+	args := mcu.Called(ctx, login, token, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Cart), args.Error(1)
+}
+
+func (mcu *MockCartUseCase) DeleteExpired(ctx context.Context) (int, error) {
+	args := mcu.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+type MockCartRepository struct {
+	mock.Mock
+}
+
+func (mcr *MockCartRepository) GetByUserID(ctx context.Context, userID int64) (*domain.Cart, error) {
+	args := mcr.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Cart), args.Error(1)
+}
+
+func (mcr *MockCartRepository) GetByToken(ctx context.Context, token string) (*domain.Cart, error) {
+	args := mcr.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Cart), args.Error(1)
+}
+
+func (mcr *MockCartRepository) Store(ctx context.Context, c *domain.Cart) error {
+	args := mcr.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (mcr *MockCartRepository) SetItem(ctx context.Context, cartID int64, item *domain.CartItem) error {
+	args := mcr.Called(ctx, cartID, item)
+	return args.Error(0)
+}
+
+func (mcr *MockCartRepository) RemoveItem(ctx context.Context, cartID int64, sku string) error {
+	args := mcr.Called(ctx, cartID, sku)
+	return args.Error(0)
+}
+
+func (mcr *MockCartRepository) Delete(ctx context.Context, cartID int64) error {
+	args := mcr.Called(ctx, cartID)
+	return args.Error(0)
+}
+
+func (mcr *MockCartRepository) DeleteExpired(ctx context.Context, guestsBefore time.Time, usersBefore time.Time, limit int) (int, error) {
+	args := mcr.Called(ctx, guestsBefore, usersBefore, limit)
+	return args.Int(0), args.Error(1)
+}
+
+type MockCartItemValidator struct {
+	mock.Mock
+}
+
+func (mciv *MockCartItemValidator) Validate(ctx context.Context, item *domain.CartItem) (domain.IsValid, domain.Message) {
+	args := mciv.Called(ctx, item)
+	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
+}
@@ -0,0 +1,142 @@
+package mocks
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCatalogueReader struct {
+	mock.Mock
+}
+
+func (mcr *MockCatalogueReader) Read() (*domain.CatalogueRow, int64, error) {
+	args := mcr.Called()
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).(*domain.CatalogueRow), args.Get(1).(int64), args.Error(2)
+}
+
+type MockCatalogueWriter struct {
+	mock.Mock
+}
+
+func (mcw *MockCatalogueWriter) Write(row *domain.CatalogueRow) error {
+	args := mcw.Called(row)
+	return args.Error(0)
+}
+
+func (mcw *MockCatalogueWriter) Flush() error {
+	args := mcw.Called()
+	return args.Error(0)
+}
+
+type MockCatalogueCodec struct {
+	mock.Mock
+}
+
+func (mcc *MockCatalogueCodec) NewReader(format domain.CatalogueFormat, r io.Reader) (domain.CatalogueReader, error) {
+	args := mcc.Called(format, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.CatalogueReader), args.Error(1)
+}
+
+func (mcc *MockCatalogueCodec) NewWriter(format domain.CatalogueFormat, w io.Writer) (domain.CatalogueWriter, error) {
+	args := mcc.Called(format, w)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.CatalogueWriter), args.Error(1)
+}
+
+type MockCatalogueUseCase struct {
+	mock.Mock
+}
+
+func (mcu *MockCatalogueUseCase) CreateImport(ctx context.Context, format domain.CatalogueFormat, r io.Reader, dryRun bool) (*domain.ImportJob, error) {
+	args := mcu.Called(ctx, format, r, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportJob), args.Error(1)
+}
+
+func (mcu *MockCatalogueUseCase) RunImport(ctx context.Context, uuid string) (*domain.ImportJob, error) {
+	args := mcu.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportJob), args.Error(1)
+}
+
+func (mcu *MockCatalogueUseCase) ResumeInterrupted(ctx context.Context) (int, error) {
+	args := mcu.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (mcu *MockCatalogueUseCase) GetImport(ctx context.Context, uuid string) (*domain.ImportJob, error) {
+	args := mcu.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportJob), args.Error(1)
+}
+
+func (mcu *MockCatalogueUseCase) Export(ctx context.Context, format domain.CatalogueFormat, w io.Writer) error {
+	args := mcu.Called(ctx, format, w)
+	return args.Error(0)
+}
+
+type MockImportJobRepository struct {
+	mock.Mock
+}
+
+func (mijr *MockImportJobRepository) GetByUUID(ctx context.Context, uuid string) (*domain.ImportJob, error) {
+	args := mijr.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportJob), args.Error(1)
+}
+
+func (mijr *MockImportJobRepository) GetInterrupted(ctx context.Context, staleBefore time.Time) ([]domain.ImportJob, error) {
+	args := mijr.Called(ctx, staleBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ImportJob), args.Error(1)
+}
+
+func (mijr *MockImportJobRepository) Store(ctx context.Context, j *domain.ImportJob) error {
+	args := mijr.Called(ctx, j)
+	return args.Error(0)
+}
+
+func (mijr *MockImportJobRepository) Claim(ctx context.Context, j *domain.ImportJob, staleBefore time.Time) (bool, error) {
+	args := mijr.Called(ctx, j, staleBefore)
+	return args.Bool(0), args.Error(1)
+}
+
+func (mijr *MockImportJobRepository) Update(ctx context.Context, j *domain.ImportJob) error {
+	args := mijr.Called(ctx, j)
+	return args.Error(0)
+}
+
+func (mijr *MockImportJobRepository) AddError(ctx context.Context, jobID int64, e domain.ImportRowError) error {
+	args := mijr.Called(ctx, jobID, e)
+	return args.Error(0)
+}
+
+func (mijr *MockImportJobRepository) GetErrors(ctx context.Context, jobID int64, limit int) ([]domain.ImportRowError, error) {
+	args := mijr.Called(ctx, jobID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ImportRowError), args.Error(1)
+}
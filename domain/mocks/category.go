@@ -0,0 +1,191 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCategoryUseCase struct {
+	mock.Mock
+}
+
+func (mcu *MockCategoryUseCase) GetTree(ctx context.Context) ([]*domain.Category, error) {
+	args := mcu.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Category), args.Error(1)
+}
+
+func (mcu *MockCategoryUseCase) GetBreadcrumb(ctx context.Context, slug string) ([]domain.Category, error) {
+	args := mcu.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Category), args.Error(1)
+}
+
+func (mcu *MockCategoryUseCase) ListProducts(ctx context.Context, slug string, q domain.ProductQuery, login string) (*domain.ProductPage, error) {
+	args := mcu.Called(ctx, slug, q, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProductPage), args.Error(1)
+}
+
+func (mcu *MockCategoryUseCase) Create(ctx context.Context, c *domain.Category, parentSlug string) error {
+	args := mcu.Called(ctx, c, parentSlug)
+	return args.Error(0)
+}
+
+func (mcu *MockCategoryUseCase) Update(ctx context.Context, slug string, c *domain.Category) (*domain.Category, error) {
+	args := mcu.Called(ctx, slug, c)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Category), args.Error(1)
+}
+
+func (mcu *MockCategoryUseCase) Move(ctx context.Context, slug string, parentSlug string) (*domain.Category, error) {
+	args := mcu.Called(ctx, slug, parentSlug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Category), args.Error(1)
+}
+
+func (mcu *MockCategoryUseCase) SetProductCategories(ctx context.Context, productUUID string, slugs []string) error {
+	args := mcu.Called(ctx, productUUID, slugs)
+	return args.Error(0)
+}
+
+type MockCategoryRepository struct {
+	mock.Mock
+}
+
+func (mcr *MockCategoryRepository) GetAll(ctx context.Context) ([]domain.Category, error) {
+	args := mcr.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Category), args.Error(1)
+}
+
+func (mcr *MockCategoryRepository) GetBySlug(ctx context.Context, slug string) (*domain.Category, error) {
+	args := mcr.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Category), args.Error(1)
+}
+
+func (mcr *MockCategoryRepository) GetBySlugs(ctx context.Context, slugs []string) ([]domain.Category, error) {
+	args := mcr.Called(ctx, slugs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Category), args.Error(1)
+}
+
+func (mcr *MockCategoryRepository) GetByIDs(ctx context.Context, ids []int64) ([]domain.Category, error) {
+	args := mcr.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Category), args.Error(1)
+}
+
+func (mcr *MockCategoryRepository) Store(ctx context.Context, c *domain.Category, parent *domain.Category) error {
+	args := mcr.Called(ctx, c, parent)
+	return args.Error(0)
+}
+
+func (mcr *MockCategoryRepository) Update(ctx context.Context, c *domain.Category) error {
+	args := mcr.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (mcr *MockCategoryRepository) Move(ctx context.Context, c *domain.Category, parent *domain.Category) error {
+	args := mcr.Called(ctx, c, parent)
+	return args.Error(0)
+}
+
+func (mcr *MockCategoryRepository) SetProductCategories(ctx context.Context, productID int64, categoryIDs []int64) error {
+	args := mcr.Called(ctx, productID, categoryIDs)
+	return args.Error(0)
+}
+
+type MockCategoryValidator struct {
+	mock.Mock
+}
+
+func (mcv *MockCategoryValidator) Validate(ctx context.Context, c *domain.Category) (domain.IsValid, domain.Message) {
+	args := mcv.Called(ctx, c)
+	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
+}
+
+type MockCollectionUseCase struct {
+	mock.Mock
+}
+
+func (mcu *MockCollectionUseCase) Get(ctx context.Context, slug string) (*domain.Collection, error) {
+	args := mcu.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Collection), args.Error(1)
+}
+
+func (mcu *MockCollectionUseCase) ListProducts(ctx context.Context, slug string, q domain.ProductQuery, login string) (*domain.ProductPage, error) {
+	args := mcu.Called(ctx, slug, q, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProductPage), args.Error(1)
+}
+
+func (mcu *MockCollectionUseCase) Create(ctx context.Context, c *domain.Collection) error {
+	args := mcu.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (mcu *MockCollectionUseCase) Update(ctx context.Context, slug string, c *domain.Collection) (*domain.Collection, error) {
+	args := mcu.Called(ctx, slug, c)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Collection), args.Error(1)
+}
+
+type MockCollectionRepository struct {
+	mock.Mock
+}
+
+func (mcr *MockCollectionRepository) GetBySlug(ctx context.Context, slug string) (*domain.Collection, error) {
+	args := mcr.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Collection), args.Error(1)
+}
+
+func (mcr *MockCollectionRepository) Store(ctx context.Context, c *domain.Collection) error {
+	args := mcr.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (mcr *MockCollectionRepository) Update(ctx context.Context, c *domain.Collection) error {
+	args := mcr.Called(ctx, c)
+	return args.Error(0)
+}
+
+type MockCollectionValidator struct {
+	mock.Mock
+}
+
+func (mcv *MockCollectionValidator) Validate(ctx context.Context, c *domain.Collection) (domain.IsValid, domain.Message) {
+	args := mcv.Called(ctx, c)
+	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
+}
@@ -0,0 +1,175 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFavoriteUseCase struct {
+	mock.Mock
+}
+
+func (mfu *MockFavoriteUseCase) Add(ctx context.Context, login string, productUUID string) error {
+	args := mfu.Called(ctx, login, productUUID)
+	return args.Error(0)
+}
+
+func (mfu *MockFavoriteUseCase) Remove(ctx context.Context, login string, productUUID string) error {
+	args := mfu.Called(ctx, login, productUUID)
+	return args.Error(0)
+}
+
+func (mfu *MockFavoriteUseCase) List(ctx context.Context, login string, q domain.ProductQuery) (*domain.ProductPage, error) {
+	args := mfu.Called(ctx, login, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProductPage), args.Error(1)
+}
+
+type MockFavoriteRepository struct {
+	mock.Mock
+}
+
+func (mfr *MockFavoriteRepository) Add(ctx context.Context, userID int64, productID int64) error {
+	args := mfr.Called(ctx, userID, productID)
+	return args.Error(0)
+}
+
+func (mfr *MockFavoriteRepository) Remove(ctx context.Context, userID int64, productID int64) error {
+	args := mfr.Called(ctx, userID, productID)
+	return args.Error(0)
+}
+
+type MockWishlistUseCase struct {
+	mock.Mock
+}
+
+func (mwu *MockWishlistUseCase) List(ctx context.Context, login string) ([]domain.Wishlist, error) {
+	args := mwu.Called(ctx, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Wishlist), args.Error(1)
+}
+
+func (mwu *MockWishlistUseCase) Create(ctx context.Context, login string, w *domain.Wishlist) error {
+	args := mwu.Called(ctx, login, w)
+	return args.Error(0)
+}
+
+func (mwu *MockWishlistUseCase) Delete(ctx context.Context, login string, uuid string) error {
+	args := mwu.Called(ctx, login, uuid)
+	return args.Error(0)
+}
+
+func (mwu *MockWishlistUseCase) AddProduct(ctx context.Context, login string, uuid string, productUUID string) error {
+	args := mwu.Called(ctx, login, uuid, productUUID)
+	return args.Error(0)
+}
+
+func (mwu *MockWishlistUseCase) RemoveProduct(ctx context.Context, login string, uuid string, productUUID string) error {
+	args := mwu.Called(ctx, login, uuid, productUUID)
+	return args.Error(0)
+}
+
+func (mwu *MockWishlistUseCase) ListProducts(ctx context.Context, login string, uuid string, q domain.ProductQuery) (*domain.ProductPage, error) {
+	args := mwu.Called(ctx, login, uuid, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProductPage), args.Error(1)
+}
+
+func (mwu *MockWishlistUseCase) Share(ctx context.Context, login string, uuid string) (*domain.Wishlist, error) {
+	args := mwu.Called(ctx, login, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Wishlist), args.Error(1)
+}
+
+func (mwu *MockWishlistUseCase) Unshare(ctx context.Context, login string, uuid string) error {
+	args := mwu.Called(ctx, login, uuid)
+	return args.Error(0)
+}
+
+func (mwu *MockWishlistUseCase) GetShared(ctx context.Context, token string) (*domain.Wishlist, error) {
+	args := mwu.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Wishlist), args.Error(1)
+}
+
+func (mwu *MockWishlistUseCase) ListSharedProducts(ctx context.Context, token string, q domain.ProductQuery, login string) (*domain.ProductPage, error) {
+	args := mwu.Called(ctx, token, q, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProductPage), args.Error(1)
+}
+
+type MockWishlistRepository struct {
+	mock.Mock
+}
+
+func (mwr *MockWishlistRepository) GetByUUID(ctx context.Context, uuid string) (*domain.Wishlist, error) {
+	args := mwr.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Wishlist), args.Error(1)
+}
+
+func (mwr *MockWishlistRepository) GetByShareToken(ctx context.Context, token string) (*domain.Wishlist, error) {
+	args := mwr.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Wishlist), args.Error(1)
+}
+
+func (mwr *MockWishlistRepository) GetByUserID(ctx context.Context, userID int64) ([]domain.Wishlist, error) {
+	args := mwr.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Wishlist), args.Error(1)
+}
+
+func (mwr *MockWishlistRepository) Store(ctx context.Context, w *domain.Wishlist) error {
+	args := mwr.Called(ctx, w)
+	return args.Error(0)
+}
+
+func (mwr *MockWishlistRepository) Delete(ctx context.Context, id int64) error {
+	args := mwr.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (mwr *MockWishlistRepository) AddProduct(ctx context.Context, wishlistID int64, productID int64) error {
+	args := mwr.Called(ctx, wishlistID, productID)
+	return args.Error(0)
+}
+
+func (mwr *MockWishlistRepository) RemoveProduct(ctx context.Context, wishlistID int64, productID int64) error {
+	args := mwr.Called(ctx, wishlistID, productID)
+	return args.Error(0)
+}
+
+func (mwr *MockWishlistRepository) SetShareToken(ctx context.Context, id int64, token string) error {
+	args := mwr.Called(ctx, id, token)
+	return args.Error(0)
+}
+
+type MockWishlistValidator struct {
+	mock.Mock
+}
+
+func (mwv *MockWishlistValidator) Validate(ctx context.Context, w *domain.Wishlist) (domain.IsValid, domain.Message) {
+	args := mwv.Called(ctx, w)
+	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
+}
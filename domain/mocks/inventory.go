@@ -0,0 +1,212 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInventoryUseCase struct {
+	mock.Mock
+}
+
+func (miu *MockInventoryUseCase) GetStock(ctx context.Context, sku string) (*domain.Stock, error) {
+	args := miu.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Stock), args.Error(1)
+}
+
+func (miu *MockInventoryUseCase) AddMovement(ctx context.Context, m *domain.StockMovement) error {
+	args := miu.Called(ctx, m)
+	return args.Error(0)
+}
+
+func (miu *MockInventoryUseCase) GetMovements(ctx context.Context, sku string, limit int) ([]domain.StockMovement, error) {
+	args := miu.Called(ctx, sku, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.StockMovement), args.Error(1)
+}
+
+func (miu *MockInventoryUseCase) CreateWarehouse(ctx context.Context, w *domain.Warehouse) error {
+	args := miu.Called(ctx, w)
+	return args.Error(0)
+}
+
+func (miu *MockInventoryUseCase) SetThreshold(ctx context.Context, sku string, threshold int64) error {
+	args := miu.Called(ctx, sku, threshold)
+	return args.Error(0)
+}
+
+func (miu *MockInventoryUseCase) Reserve(ctx context.Context, sku string, quantity int64, reference string, ttl time.Duration) (*domain.Reservation, error) {
+	args := miu.Called(ctx, sku, quantity, reference, ttl)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Reservation), args.Error(1)
+}
+
+func (miu *MockInventoryUseCase) Release(ctx context.Context, uuid string) error {
+	args := miu.Called(ctx, uuid)
+	return args.Error(0)
+}
+
+func (miu *MockInventoryUseCase) Commit(ctx context.Context, uuid string) error {
+	args := miu.Called(ctx, uuid)
+	return args.Error(0)
+}
+
+func (miu *MockInventoryUseCase) ReleaseExpired(ctx context.Context) (int, error) {
+	args := miu.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (miu *MockInventoryUseCase) SubscribeAlert(ctx context.Context, sku string, login string) error {
+	args := miu.Called(ctx, sku, login)
+	return args.Error(0)
+}
+
+func (miu *MockInventoryUseCase) NotifyBackInStock(ctx context.Context) (int, error) {
+	args := miu.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+type MockInventoryRepository struct {
+	mock.Mock
+}
+
+func (mir *MockInventoryRepository) GetWarehouse(ctx context.Context, code string) (*domain.Warehouse, error) {
+	args := mir.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Warehouse), args.Error(1)
+}
+
+func (mir *MockInventoryRepository) StoreWarehouse(ctx context.Context, w *domain.Warehouse) error {
+	args := mir.Called(ctx, w)
+	return args.Error(0)
+}
+
+func (mir *MockInventoryRepository) GetLevels(ctx context.Context, sku string) ([]domain.StockLevel, error) {
+	args := mir.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.StockLevel), args.Error(1)
+}
+
+func (mir *MockInventoryRepository) Adjust(ctx context.Context, m *domain.StockMovement) error {
+	args := mir.Called(ctx, m)
+	return args.Error(0)
+}
+
+func (mir *MockInventoryRepository) Reserve(ctx context.Context, r *domain.Reservation) error {
+	args := mir.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (mir *MockInventoryRepository) GetReservation(ctx context.Context, uuid string) (*domain.Reservation, error) {
+	args := mir.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Reservation), args.Error(1)
+}
+
+func (mir *MockInventoryRepository) Release(ctx context.Context, uuid string, reason domain.MovementReason) error {
+	args := mir.Called(ctx, uuid, reason)
+	return args.Error(0)
+}
+
+func (mir *MockInventoryRepository) Commit(ctx context.Context, uuid string) error {
+	args := mir.Called(ctx, uuid)
+	return args.Error(0)
+}
+
+func (mir *MockInventoryRepository) ReserveTx(ctx context.Context, tx domain.Tx, r *domain.Reservation) error {
+	args := mir.Called(ctx, tx, r)
+	return args.Error(0)
+}
+
+func (mir *MockInventoryRepository) ReleaseTx(ctx context.Context, tx domain.Tx, uuid string, reason domain.MovementReason) error {
+	args := mir.Called(ctx, tx, uuid, reason)
+	return args.Error(0)
+}
+
+func (mir *MockInventoryRepository) CommitTx(ctx context.Context, tx domain.Tx, uuid string) error {
+	args := mir.Called(ctx, tx, uuid)
+	return args.Error(0)
+}
+
+func (mir *MockInventoryRepository) TouchProducts(ctx context.Context, skus []string) error {
+	args := mir.Called(ctx, skus)
+	return args.Error(0)
+}
+
+func (mir *MockInventoryRepository) GetExpiredReservations(ctx context.Context, now time.Time, limit int) ([]domain.Reservation, error) {
+	args := mir.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Reservation), args.Error(1)
+}
+
+func (mir *MockInventoryRepository) GetMovements(ctx context.Context, sku string, limit int) ([]domain.StockMovement, error) {
+	args := mir.Called(ctx, sku, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.StockMovement), args.Error(1)
+}
+
+func (mir *MockInventoryRepository) GetThreshold(ctx context.Context, sku string) (int64, error) {
+	args := mir.Called(ctx, sku)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (mir *MockInventoryRepository) SetThreshold(ctx context.Context, sku string, threshold int64) error {
+	args := mir.Called(ctx, sku, threshold)
+	return args.Error(0)
+}
+
+func (mir *MockInventoryRepository) StoreAlert(ctx context.Context, sku string, userID int64) error {
+	args := mir.Called(ctx, sku, userID)
+	return args.Error(0)
+}
+
+func (mir *MockInventoryRepository) GetDueAlerts(ctx context.Context, limit int) ([]domain.StockAlert, error) {
+	args := mir.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.StockAlert), args.Error(1)
+}
+
+func (mir *MockInventoryRepository) DeleteAlert(ctx context.Context, id int64) error {
+	args := mir.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockWarehouseValidator struct {
+	mock.Mock
+}
+
+func (mwv *MockWarehouseValidator) Validate(ctx context.Context, w *domain.Warehouse) (domain.IsValid, domain.Message) {
+	args := mwv.Called(ctx, w)
+	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
+}
+
+type MockStockMovementValidator struct {
+	mock.Mock
+}
+
+func (msmv *MockStockMovementValidator) Validate(ctx context.Context, m *domain.StockMovement) (domain.IsValid, domain.Message) {
+	args := msmv.Called(ctx, m)
+	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
+}
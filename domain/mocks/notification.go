@@ -0,0 +1,89 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotificationUseCase struct {
+	mock.Mock
+}
+
+func (mnu *MockNotificationUseCase) GetPreferences(ctx context.Context, login string) ([]domain.NotificationPreference, error) {
+	args := mnu.Called(ctx, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.NotificationPreference), args.Error(1)
+}
+
+func (mnu *MockNotificationUseCase) UpdatePreferences(ctx context.Context, login string, prefs []domain.NotificationPreference) ([]domain.NotificationPreference, error) {
+	args := mnu.Called(ctx, login, prefs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.NotificationPreference), args.Error(1)
+}
+
+func (mnu *MockNotificationUseCase) Unsubscribe(ctx context.Context, token string) error {
+	args := mnu.Called(ctx, token)
+	return args.Error(0)
+}
+
+type MockNotificationService struct {
+	mock.Mock
+}
+
+func (mns *MockNotificationService) Preferences(ctx context.Context, userID int64) ([]domain.NotificationPreference, error) {
+	args := mns.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.NotificationPreference), args.Error(1)
+}
+
+func (mns *MockNotificationService) IsOptedIn(ctx context.Context, userID int64, category domain.NotificationCategory, medium string) (bool, error) {
+	args := mns.Called(ctx, userID, category, medium)
+	return args.Bool(0), args.Error(1)
+}
+
+func (mns *MockNotificationService) UnsubscribeLink(ctx context.Context, u *domain.Unsubscribe) string {
+	args := mns.Called(ctx, u)
+	return args.String(0)
+}
+
+func (mns *MockNotificationService) ParseUnsubscribeToken(ctx context.Context, token string) (*domain.Unsubscribe, error) {
+	args := mns.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.Unsubscribe{UserUUID: args.String(0), Category: domain.NotificationCategory(args.String(1)), Medium: args.String(2)}, args.Error(3)
+}
+
+type MockNotificationPreferenceRepository struct {
+	mock.Mock
+}
+
+func (mnpr *MockNotificationPreferenceRepository) GetByUserID(ctx context.Context, userID int64) ([]domain.NotificationPreference, error) {
+	args := mnpr.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.NotificationPreference), args.Error(1)
+}
+
+func (mnpr *MockNotificationPreferenceRepository) Store(ctx context.Context, userID int64, prefs []domain.NotificationPreference) error {
+	args := mnpr.Called(ctx, userID, prefs)
+	return args.Error(0)
+}
+
+type MockNotificationValidator struct {
+	mock.Mock
+}
+
+func (mnv *MockNotificationValidator) Validate(ctx context.Context, prefs []domain.NotificationPreference) (domain.IsValid, domain.Message) {
+	args := mnv.Called(ctx, prefs)
+	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
+}
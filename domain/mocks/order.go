@@ -0,0 +1,147 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOrderUseCase struct {
+	mock.Mock
+}
+
+func (mou *MockOrderUseCase) Place(ctx context.Context, login string, idempotencyKey string, address *domain.UserAddress) (*domain.Order, bool, error) {
+	args := mou.Called(ctx, login, idempotencyKey, address)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(*domain.Order), args.Bool(1), args.Error(2)
+}
+
+func (mou *MockOrderUseCase) ListMine(ctx context.Context, login string, q domain.OrderQuery) (*domain.OrderPage, error) {
+	args := mou.Called(ctx, login, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OrderPage), args.Error(1)
+}
+
+func (mou *MockOrderUseCase) GetMine(ctx context.Context, login string, uuid string) (*domain.Order, error) {
+	args := mou.Called(ctx, login, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Order), args.Error(1)
+}
+
+func (mou *MockOrderUseCase) List(ctx context.Context, q domain.OrderQuery) (*domain.OrderPage, error) {
+	args := mou.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OrderPage), args.Error(1)
+}
+
+func (mou *MockOrderUseCase) Get(ctx context.Context, uuid string) (*domain.Order, error) {
+	args := mou.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Order), args.Error(1)
+}
+
+func (mou *MockOrderUseCase) Transition(ctx context.Context, uuid string, actor string, t *domain.OrderTransitionRequest) (*domain.Order, error) {
+	args := mou.Called(ctx, uuid, actor, t)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Order), args.Error(1)
+}
+
+type MockOrderRepository struct {
+	mock.Mock
+}
+
+func (mor *MockOrderRepository) GetByIdempotencyKey(ctx context.Context, userID int64, key string) (*domain.Order, error) {
+	args := mor.Called(ctx, userID, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Order), args.Error(1)
+}
+
+func (mor *MockOrderRepository) GetByUUID(ctx context.Context, uuid string) (*domain.Order, error) {
+	args := mor.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Order), args.Error(1)
+}
+
+func (mor *MockOrderRepository) List(ctx context.Context, q domain.OrderQuery) (*domain.OrderPage, error) {
+	args := mor.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OrderPage), args.Error(1)
+}
+
+func (mor *MockOrderRepository) GetHistory(ctx context.Context, orderID int64) ([]domain.OrderHistoryEntry, error) {
+	args := mor.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OrderHistoryEntry), args.Error(1)
+}
+
+func (mor *MockOrderRepository) Place(ctx context.Context, o *domain.Order, cartID int64, reservedUntil time.Time) error {
+	args := mor.Called(ctx, o, cartID, reservedUntil)
+	return args.Error(0)
+}
+
+func (mor *MockOrderRepository) Transition(ctx context.Context, o *domain.Order, entry *domain.OrderHistoryEntry, stock domain.StockEffect) error {
+	args := mor.Called(ctx, o, entry, stock)
+	return args.Error(0)
+}
+
+func (mor *MockOrderRepository) Extend(ctx context.Context, o *domain.Order, until time.Time) error {
+	args := mor.Called(ctx, o, until)
+	return args.Error(0)
+}
+
+func (mor *MockOrderRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]domain.Order, error) {
+	args := mor.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Order), args.Error(1)
+}
+
+type MockCheckoutService struct {
+	mock.Mock
+}
+
+func (mcs *MockCheckoutService) Totals(ctx context.Context, o *domain.Order) error {
+	args := mcs.Called(ctx, o)
+	return args.Error(0)
+}
+
+type MockAddressValidator struct {
+	mock.Mock
+}
+
+func (mav *MockAddressValidator) Validate(ctx context.Context, a *domain.UserAddress) (domain.IsValid, domain.Message) {
+	args := mav.Called(ctx, a)
+	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
+}
+
+type MockOrderTransitionValidator struct {
+	mock.Mock
+}
+
+func (motv *MockOrderTransitionValidator) Validate(ctx context.Context, t *domain.OrderTransitionRequest) (domain.IsValid, domain.Message) {
+	args := motv.Called(ctx, t)
+	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
+}
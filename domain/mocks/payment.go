@@ -0,0 +1,171 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPaymentGateway struct {
+	mock.Mock
+}
+
+func (mpg *MockPaymentGateway) Name() string {
+	args := mpg.Called()
+	return args.String(0)
+}
+
+func (mpg *MockPaymentGateway) Authorize(ctx context.Context, a *domain.PaymentAuthorization) (*domain.PaymentResult, error) {
+	args := mpg.Called(ctx, a)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PaymentResult), args.Error(1)
+}
+
+func (mpg *MockPaymentGateway) Capture(ctx context.Context, providerReference string, amount domain.Money) (*domain.PaymentResult, error) {
+	args := mpg.Called(ctx, providerReference, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PaymentResult), args.Error(1)
+}
+
+func (mpg *MockPaymentGateway) Void(ctx context.Context, providerReference string) (*domain.PaymentResult, error) {
+	args := mpg.Called(ctx, providerReference)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PaymentResult), args.Error(1)
+}
+
+func (mpg *MockPaymentGateway) Refund(ctx context.Context, providerReference string, reference string, amount domain.Money) (*domain.PaymentResult, error) {
+	args := mpg.Called(ctx, providerReference, reference, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PaymentResult), args.Error(1)
+}
+
+func (mpg *MockPaymentGateway) VerifyWebhook(ctx context.Context, payload []byte, signature string) ([]domain.PaymentEvent, error) {
+	args := mpg.Called(ctx, payload, signature)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PaymentEvent), args.Error(1)
+}
+
+type MockPaymentUseCase struct {
+	mock.Mock
+}
+
+func (mpu *MockPaymentUseCase) Pay(ctx context.Context, login string, orderUUID string, r *domain.PaymentRequest) (*domain.PaymentIntent, error) {
+	args := mpu.Called(ctx, login, orderUUID, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PaymentIntent), args.Error(1)
+}
+
+func (mpu *MockPaymentUseCase) ListMine(ctx context.Context, login string, orderUUID string) ([]domain.PaymentIntent, error) {
+	args := mpu.Called(ctx, login, orderUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PaymentIntent), args.Error(1)
+}
+
+func (mpu *MockPaymentUseCase) List(ctx context.Context, orderUUID string) ([]domain.PaymentIntent, error) {
+	args := mpu.Called(ctx, orderUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PaymentIntent), args.Error(1)
+}
+
+func (mpu *MockPaymentUseCase) Capture(ctx context.Context, uuid string) (*domain.PaymentIntent, error) {
+	args := mpu.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PaymentIntent), args.Error(1)
+}
+
+func (mpu *MockPaymentUseCase) Void(ctx context.Context, uuid string) (*domain.PaymentIntent, error) {
+	args := mpu.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PaymentIntent), args.Error(1)
+}
+
+func (mpu *MockPaymentUseCase) Webhook(ctx context.Context, provider string, payload []byte, signature string) error {
+	args := mpu.Called(ctx, provider, payload, signature)
+	return args.Error(0)
+}
+
+func (mpu *MockPaymentUseCase) Reconcile(ctx context.Context) (int, error) {
+	args := mpu.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (mpu *MockPaymentUseCase) Expire(ctx context.Context) (int, error) {
+	args := mpu.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+type MockPaymentRepository struct {
+	mock.Mock
+}
+
+func (mpr *MockPaymentRepository) Create(ctx context.Context, p *domain.PaymentIntent) error {
+	args := mpr.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (mpr *MockPaymentRepository) GetByUUID(ctx context.Context, uuid string) (*domain.PaymentIntent, error) {
+	args := mpr.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PaymentIntent), args.Error(1)
+}
+
+func (mpr *MockPaymentRepository) GetByProviderReference(ctx context.Context, provider string, reference string) (*domain.PaymentIntent, error) {
+	args := mpr.Called(ctx, provider, reference)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PaymentIntent), args.Error(1)
+}
+
+func (mpr *MockPaymentRepository) ListByOrder(ctx context.Context, orderID int64) ([]domain.PaymentIntent, error) {
+	args := mpr.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PaymentIntent), args.Error(1)
+}
+
+func (mpr *MockPaymentRepository) ListToReconcile(ctx context.Context) ([]domain.PaymentIntent, error) {
+	args := mpr.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PaymentIntent), args.Error(1)
+}
+
+func (mpr *MockPaymentRepository) Update(ctx context.Context, p *domain.PaymentIntent, from domain.PaymentStatus) error {
+	args := mpr.Called(ctx, p, from)
+	return args.Error(0)
+}
+
+type MockPaymentRequestValidator struct {
+	mock.Mock
+}
+
+func (mprv *MockPaymentRequestValidator) Validate(ctx context.Context, r *domain.PaymentRequest) (domain.IsValid, domain.Message) {
+	args := mprv.Called(ctx, r)
+	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
+}
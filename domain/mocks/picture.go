@@ -0,0 +1,62 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBlobStore struct {
+	mock.Mock
+}
+
+func (mbs *MockBlobStore) Put(ctx context.Context, key string, b *domain.Blob) error {
+	args := mbs.Called(ctx, key, b)
+	return args.Error(0)
+}
+
+func (mbs *MockBlobStore) Get(ctx context.Context, key string) (*domain.Blob, error) {
+	args := mbs.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Blob), args.Error(1)
+}
+
+func (mbs *MockBlobStore) Delete(ctx context.Context, key string) error {
+	args := mbs.Called(ctx, key)
+	return args.Error(0)
+}
+
+type MockImageService struct {
+	mock.Mock
+}
+
+func (mis *MockImageService) Process(ctx context.Context, data []byte) (*domain.ProcessedImage, error) {
+	args := mis.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProcessedImage), args.Error(1)
+}
+
+type MockPictureUseCase struct {
+	mock.Mock
+}
+
+func (mpu *MockPictureUseCase) Upload(ctx context.Context, productUUID string, data []byte) (*domain.Picture, error) {
+	args := mpu.Called(ctx, productUUID, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Picture), args.Error(1)
+}
+
+func (mpu *MockPictureUseCase) Get(ctx context.Context, key string) (*domain.Blob, error) {
+	args := mpu.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Blob), args.Error(1)
+}
@@ -0,0 +1,126 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPricingService struct {
+	mock.Mock
+}
+
+func (mps *MockPricingService) Resolve(ctx context.Context, r domain.PriceRequest) (*domain.EffectivePrice, error) {
+	args := mps.Called(ctx, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EffectivePrice), args.Error(1)
+}
+
+type MockPriceUseCase struct {
+	mock.Mock
+}
+
+func (mpu *MockPriceUseCase) Quote(ctx context.Context, productUUID string, sku string, quantity int64, currency domain.Currency, login string) (*domain.EffectivePrice, error) {
+	args := mpu.Called(ctx, productUUID, sku, quantity, currency, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EffectivePrice), args.Error(1)
+}
+
+func (mpu *MockPriceUseCase) GetPrices(ctx context.Context, productUUID string) ([]domain.ProductPrice, error) {
+	args := mpu.Called(ctx, productUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ProductPrice), args.Error(1)
+}
+
+func (mpu *MockPriceUseCase) AddPrice(ctx context.Context, productUUID string, p *domain.ProductPrice) error {
+	args := mpu.Called(ctx, productUUID, p)
+	return args.Error(0)
+}
+
+func (mpu *MockPriceUseCase) DeletePrice(ctx context.Context, productUUID string, id int64) error {
+	args := mpu.Called(ctx, productUUID, id)
+	return args.Error(0)
+}
+
+func (mpu *MockPriceUseCase) CreatePriceList(ctx context.Context, pl *domain.PriceList) error {
+	args := mpu.Called(ctx, pl)
+	return args.Error(0)
+}
+
+func (mpu *MockPriceUseCase) SetCustomerGroups(ctx context.Context, userUUID string, groups []string) error {
+	args := mpu.Called(ctx, userUUID, groups)
+	return args.Error(0)
+}
+
+type MockPriceRepository struct {
+	mock.Mock
+}
+
+func (mpr *MockPriceRepository) GetByProductID(ctx context.Context, productID int64) ([]domain.ProductPrice, error) {
+	args := mpr.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ProductPrice), args.Error(1)
+}
+
+func (mpr *MockPriceRepository) Store(ctx context.Context, p *domain.ProductPrice) error {
+	args := mpr.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (mpr *MockPriceRepository) Delete(ctx context.Context, productID int64, id int64) error {
+	args := mpr.Called(ctx, productID, id)
+	return args.Error(0)
+}
+
+func (mpr *MockPriceRepository) GetPriceListByCode(ctx context.Context, code string) (*domain.PriceList, error) {
+	args := mpr.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PriceList), args.Error(1)
+}
+
+func (mpr *MockPriceRepository) StorePriceList(ctx context.Context, pl *domain.PriceList) error {
+	args := mpr.Called(ctx, pl)
+	return args.Error(0)
+}
+
+func (mpr *MockPriceRepository) GetPriceListCodesByUserID(ctx context.Context, userID int64) ([]string, error) {
+	args := mpr.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (mpr *MockPriceRepository) SetCustomerGroups(ctx context.Context, userID int64, groups []string) error {
+	args := mpr.Called(ctx, userID, groups)
+	return args.Error(0)
+}
+
+type MockPriceValidator struct {
+	mock.Mock
+}
+
+func (mpv *MockPriceValidator) Validate(ctx context.Context, p *domain.ProductPrice) (domain.IsValid, domain.Message) {
+	args := mpv.Called(ctx, p)
+	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
+}
+
+type MockPriceListValidator struct {
+	mock.Mock
+}
+
+func (mplv *MockPriceListValidator) Validate(ctx context.Context, pl *domain.PriceList) (domain.IsValid, domain.Message) {
+	args := mplv.Called(ctx, pl)
+	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
+}
@@ -11,12 +11,75 @@ type MockProductUsecase struct {
 	mock.Mock
 }
 
-func (mpu *MockProductUsecase) Get(ctx context.Context, uuid string) (*domain.Product, error) {
+func (mpu *MockProductUsecase) Head(ctx context.Context, uuid string, login string) (*domain.Product, error) {
+	args := mpu.Called(ctx, uuid, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Product), args.Error(1)
+}
+
+func (mpu *MockProductUsecase) HeadBySlug(ctx context.Context, slug string, login string) (*domain.Product, error) {
+	args := mpu.Called(ctx, slug, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Product), args.Error(1)
+}
+
+func (mpu *MockProductUsecase) Complete(ctx context.Context, p *domain.Product) error {
+	args := mpu.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (mpu *MockProductUsecase) List(ctx context.Context, q domain.ProductQuery, login string) (*domain.ProductPage, error) {
+	args := mpu.Called(ctx, q, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProductPage), args.Error(1)
+}
+
+func (mpu *MockProductUsecase) Search(ctx context.Context, q string, limit int, login string) (*domain.ProductPage, error) {
+	args := mpu.Called(ctx, q, limit, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProductPage), args.Error(1)
+}
+
+func (mpu *MockProductUsecase) IndexAll(ctx context.Context) error {
+	args := mpu.Called(ctx)
+	return args.Error(0)
+}
+
+func (mpu *MockProductUsecase) AdminGet(ctx context.Context, uuid string) (*domain.Product, error) {
 	args := mpu.Called(ctx, uuid)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return &domain.Product{ID: int64(args.Int(0)), UUID: args.String(1), Rate: float32(args.Int(2)), Pictures: []string{args.String(3)}, Name: args.String(4), Detail: args.String(5), Favorite: args.Bool(6), Attributes: []domain.Attribute{domain.Attribute{Label: args.String(7), Values: []string{args.String(8)}}}}, args.Error(9)
+	return args.Get(0).(*domain.Product), args.Error(1)
+}
+
+func (mpu *MockProductUsecase) Create(ctx context.Context, p *domain.Product) error {
+	args := mpu.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (mpu *MockProductUsecase) Update(ctx context.Context, p *domain.Product) (*domain.Product, error) {
+	args := mpu.Called(ctx, p)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Product), args.Error(1)
+}
+
+func (mpu *MockProductUsecase) Delete(ctx context.Context, uuid string, version int64) (*domain.Product, error) {
+	args := mpu.Called(ctx, uuid, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Product), args.Error(1)
 }
 
 type MockProductRepository struct {
@@ -28,5 +91,106 @@ func (mpr *MockProductRepository) GetByUUID(ctx context.Context, uuid string) (*
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
+	if p, ok := args.Get(0).(*domain.Product); ok {
+		return p, args.Error(1)
+	}
 	return &domain.Product{ID: int64(args.Int(0)), UUID: args.String(1), Rate: float32(args.Int(2)), Pictures: []string{args.String(3)}, Name: args.String(4), Detail: args.String(5), Favorite: args.Bool(6), Attributes: []domain.Attribute{domain.Attribute{Label: args.String(7), Values: []string{args.String(8)}}}}, args.Error(9)
 }
+
+func (mpr *MockProductRepository) GetUUIDs(ctx context.Context, productIDs []int64) ([]string, error) {
+	args := mpr.Called(ctx, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (mpr *MockProductRepository) GetIDsByAttribute(ctx context.Context, label string) ([]int64, error) {
+	args := mpr.Called(ctx, label)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+func (mpr *MockProductRepository) GetBySlug(ctx context.Context, slug string) (*domain.Product, error) {
+	args := mpr.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Product), args.Error(1)
+}
+
+func (mpr *MockProductRepository) GetByOldSlug(ctx context.Context, slug string) (*domain.Product, error) {
+	args := mpr.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Product), args.Error(1)
+}
+
+func (mpr *MockProductRepository) SlugTaken(ctx context.Context, slug string, productID int64) (bool, error) {
+	args := mpr.Called(ctx, slug, productID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (mpr *MockProductRepository) GetByUUIDs(ctx context.Context, uuids []string) ([]domain.Product, error) {
+	args := mpr.Called(ctx, uuids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Product), args.Error(1)
+}
+
+func (mpr *MockProductRepository) GetFavoriteProductIDs(ctx context.Context, userID int64, productIDs []int64) (map[int64]bool, error) {
+	args := mpr.Called(ctx, userID, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]bool), args.Error(1)
+}
+
+func (mpr *MockProductRepository) List(ctx context.Context, q domain.ProductQuery) (*domain.ProductPage, error) {
+	args := mpr.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProductPage), args.Error(1)
+}
+
+func (mpr *MockProductRepository) Facets(ctx context.Context, q domain.ProductQuery) (*domain.ProductFacets, error) {
+	args := mpr.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProductFacets), args.Error(1)
+}
+
+func (mpr *MockProductRepository) Store(ctx context.Context, p *domain.Product) error {
+	args := mpr.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (mpr *MockProductRepository) Update(ctx context.Context, p *domain.Product) error {
+	args := mpr.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (mpr *MockProductRepository) Delete(ctx context.Context, uuid string, version int64) error {
+	args := mpr.Called(ctx, uuid, version)
+	return args.Error(0)
+}
+
+func (mpr *MockProductRepository) Touch(ctx context.Context, productIDs []int64) error {
+	args := mpr.Called(ctx, productIDs)
+	return args.Error(0)
+}
+
+type MockProductValidator struct {
+	mock.Mock
+}
+
+func (mpv *MockProductValidator) Validate(ctx context.Context, p *domain.Product) (domain.IsValid, domain.Message) {
+	args := mpv.Called(ctx, p)
+	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
+}
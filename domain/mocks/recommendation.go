@@ -0,0 +1,85 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRecommendationUseCase struct {
+	mock.Mock
+}
+
+func (mru *MockRecommendationUseCase) Related(ctx context.Context, uuid string, limit int, login string) (*domain.RelatedProducts, error) {
+	args := mru.Called(ctx, uuid, limit, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RelatedProducts), args.Error(1)
+}
+
+func (mru *MockRecommendationUseCase) GetPinned(ctx context.Context, uuid string) ([]domain.Product, error) {
+	args := mru.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Product), args.Error(1)
+}
+
+func (mru *MockRecommendationUseCase) SetPinned(ctx context.Context, uuid string, related []string) ([]domain.Product, error) {
+	args := mru.Called(ctx, uuid, related)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Product), args.Error(1)
+}
+
+func (mru *MockRecommendationUseCase) ComputeAffinities(ctx context.Context) error {
+	args := mru.Called(ctx)
+	return args.Error(0)
+}
+
+type MockRecommendationRepository struct {
+	mock.Mock
+}
+
+func (mrr *MockRecommendationRepository) AddView(ctx context.Context, userID int64, productID int64) error {
+	args := mrr.Called(ctx, userID, productID)
+	return args.Error(0)
+}
+
+func (mrr *MockRecommendationRepository) GetAffinities(ctx context.Context, productID int64, kind domain.AffinityKind, limit int) ([]string, error) {
+	args := mrr.Called(ctx, productID, kind, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (mrr *MockRecommendationRepository) GetSimilar(ctx context.Context, productID int64, limit int) ([]string, error) {
+	args := mrr.Called(ctx, productID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (mrr *MockRecommendationRepository) GetPinned(ctx context.Context, productID int64) ([]string, error) {
+	args := mrr.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (mrr *MockRecommendationRepository) SetPinned(ctx context.Context, productID int64, relatedIDs []int64) error {
+	args := mrr.Called(ctx, productID, relatedIDs)
+	return args.Error(0)
+}
+
+func (mrr *MockRecommendationRepository) ComputeAffinities(ctx context.Context, salesSince time.Time, viewsSince time.Time) error {
+	args := mrr.Called(ctx, salesSince, viewsSince)
+	return args.Error(0)
+}
@@ -0,0 +1,75 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRefundUseCase struct {
+	mock.Mock
+}
+
+func (mru *MockRefundUseCase) Refund(ctx context.Context, orderUUID string, actor string, r *domain.RefundRequest) ([]domain.Refund, error) {
+	args := mru.Called(ctx, orderUUID, actor, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Refund), args.Error(1)
+}
+
+func (mru *MockRefundUseCase) List(ctx context.Context, orderUUID string) ([]domain.Refund, error) {
+	args := mru.Called(ctx, orderUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Refund), args.Error(1)
+}
+
+func (mru *MockRefundUseCase) Ledger(ctx context.Context, orderUUID string) (*domain.Ledger, error) {
+	args := mru.Called(ctx, orderUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Ledger), args.Error(1)
+}
+
+func (mru *MockRefundUseCase) LedgerMine(ctx context.Context, login string, orderUUID string) (*domain.Ledger, error) {
+	args := mru.Called(ctx, login, orderUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Ledger), args.Error(1)
+}
+
+type MockRefundRepository struct {
+	mock.Mock
+}
+
+func (mrr *MockRefundRepository) Create(ctx context.Context, r *domain.Refund) error {
+	args := mrr.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (mrr *MockRefundRepository) Finish(ctx context.Context, r *domain.Refund) error {
+	args := mrr.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (mrr *MockRefundRepository) ListByOrder(ctx context.Context, orderID int64) ([]domain.Refund, error) {
+	args := mrr.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Refund), args.Error(1)
+}
+
+type MockRefundRequestValidator struct {
+	mock.Mock
+}
+
+func (mrrv *MockRefundRequestValidator) Validate(ctx context.Context, r *domain.RefundRequest) (domain.IsValid, domain.Message) {
+	args := mrrv.Called(ctx, r)
+	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
+}
@@ -0,0 +1,106 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReviewUseCase struct {
+	mock.Mock
+}
+
+func (mru *MockReviewUseCase) List(ctx context.Context, productUUID string, q domain.ReviewQuery) (*domain.ReviewPage, error) {
+	args := mru.Called(ctx, productUUID, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReviewPage), args.Error(1)
+}
+
+func (mru *MockReviewUseCase) Create(ctx context.Context, productUUID string, login string, r *domain.Review) error {
+	args := mru.Called(ctx, productUUID, login, r)
+	return args.Error(0)
+}
+
+func (mru *MockReviewUseCase) Vote(ctx context.Context, reviewUUID string, login string) error {
+	args := mru.Called(ctx, reviewUUID, login)
+	return args.Error(0)
+}
+
+func (mru *MockReviewUseCase) Queue(ctx context.Context, q domain.ReviewQuery) (*domain.ReviewPage, error) {
+	args := mru.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReviewPage), args.Error(1)
+}
+
+func (mru *MockReviewUseCase) Moderate(ctx context.Context, reviewUUID string, status domain.ReviewStatus) (*domain.Review, error) {
+	args := mru.Called(ctx, reviewUUID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Review), args.Error(1)
+}
+
+type MockReviewRepository struct {
+	mock.Mock
+}
+
+func (mrr *MockReviewRepository) List(ctx context.Context, q domain.ReviewQuery) (*domain.ReviewPage, error) {
+	args := mrr.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReviewPage), args.Error(1)
+}
+
+func (mrr *MockReviewRepository) GetByUUID(ctx context.Context, uuid string) (*domain.Review, error) {
+	args := mrr.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Review), args.Error(1)
+}
+
+func (mrr *MockReviewRepository) GetByProductAndUser(ctx context.Context, productID int64, userID int64) (*domain.Review, error) {
+	args := mrr.Called(ctx, productID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Review), args.Error(1)
+}
+
+func (mrr *MockReviewRepository) Store(ctx context.Context, r *domain.Review) error {
+	args := mrr.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (mrr *MockReviewRepository) SetStatus(ctx context.Context, r *domain.Review, status domain.ReviewStatus) error {
+	args := mrr.Called(ctx, r, status)
+	return args.Error(0)
+}
+
+func (mrr *MockReviewRepository) GetRating(ctx context.Context, productID int64) (*domain.RatingSummary, error) {
+	args := mrr.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RatingSummary), args.Error(1)
+}
+
+func (mrr *MockReviewRepository) Vote(ctx context.Context, reviewID int64, userID int64) error {
+	args := mrr.Called(ctx, reviewID, userID)
+	return args.Error(0)
+}
+
+type MockReviewValidator struct {
+	mock.Mock
+}
+
+func (mrv *MockReviewValidator) Validate(ctx context.Context, r *domain.Review) (domain.IsValid, domain.Message) {
+	args := mrv.Called(ctx, r)
+	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
+}
@@ -0,0 +1,62 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSearchIndex struct {
+	mock.Mock
+}
+
+func (msi *MockSearchIndex) Index(ctx context.Context, p *domain.Product) {
+	msi.Called(ctx, p)
+}
+
+func (msi *MockSearchIndex) Remove(ctx context.Context, uuid string) {
+	msi.Called(ctx, uuid)
+}
+
+func (msi *MockSearchIndex) Search(ctx context.Context, q string, limit int) []domain.SearchHit {
+	args := msi.Called(ctx, q, limit)
+	return args.Get(0).([]domain.SearchHit)
+}
+
+type MockSearchUseCase struct {
+	mock.Mock
+}
+
+func (msu *MockSearchUseCase) Suggest(ctx context.Context, q string, limit int) ([]domain.Suggestion, error) {
+	args := msu.Called(ctx, q, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Suggestion), args.Error(1)
+}
+
+type MockSearchRepository struct {
+	mock.Mock
+}
+
+func (msr *MockSearchRepository) StoreQuery(ctx context.Context, q string) error {
+	args := msr.Called(ctx, q)
+	return args.Error(0)
+}
+
+func (msr *MockSearchRepository) GetQuerySuggestions(ctx context.Context, prefix string, limit int) ([]domain.Suggestion, error) {
+	args := msr.Called(ctx, prefix, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Suggestion), args.Error(1)
+}
+
+func (msr *MockSearchRepository) GetCategorySuggestions(ctx context.Context, prefix string, limit int) ([]domain.Suggestion, error) {
+	args := msr.Called(ctx, prefix, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Suggestion), args.Error(1)
+}
@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSitemapUseCase struct {
+	mock.Mock
+}
+
+func (msu *MockSitemapUseCase) Count(ctx context.Context) (int, error) {
+	args := msu.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (msu *MockSitemapUseCase) Products(ctx context.Context, n int) ([]domain.Product, error) {
+	args := msu.Called(ctx, n)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Product), args.Error(1)
+}
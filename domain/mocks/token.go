@@ -20,3 +20,11 @@ func (mts *MockTokenService) IsValid(ctx context.Context, token domain.Token) (d
 	args := mts.Called(ctx, token)
 	return domain.IsValid(args.Bool(0)), args.Error(1)
 }
+
+func (mts *MockTokenService) GetInfo(ctx context.Context, token domain.Token) (*domain.TokenInfo, error) {
+	args := mts.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.TokenInfo{Info: args.String(0), Role: args.String(1)}, args.Error(2)
+}
@@ -0,0 +1,106 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTranslationUseCase struct {
+	mock.Mock
+}
+
+func (mtu *MockTranslationUseCase) GetProductTranslations(ctx context.Context, productUUID string) ([]domain.ProductTranslation, error) {
+	args := mtu.Called(ctx, productUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ProductTranslation), args.Error(1)
+}
+
+func (mtu *MockTranslationUseCase) SetProductTranslation(ctx context.Context, productUUID string, t *domain.ProductTranslation) error {
+	args := mtu.Called(ctx, productUUID, t)
+	return args.Error(0)
+}
+
+func (mtu *MockTranslationUseCase) DeleteProductTranslation(ctx context.Context, productUUID string, locale string) error {
+	args := mtu.Called(ctx, productUUID, locale)
+	return args.Error(0)
+}
+
+func (mtu *MockTranslationUseCase) GetAttributeTranslations(ctx context.Context, locale string) ([]domain.AttributeTranslation, error) {
+	args := mtu.Called(ctx, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AttributeTranslation), args.Error(1)
+}
+
+func (mtu *MockTranslationUseCase) SetAttributeTranslation(ctx context.Context, t *domain.AttributeTranslation) error {
+	args := mtu.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (mtu *MockTranslationUseCase) DeleteAttributeTranslation(ctx context.Context, locale string, label string) error {
+	args := mtu.Called(ctx, locale, label)
+	return args.Error(0)
+}
+
+type MockTranslationRepository struct {
+	mock.Mock
+}
+
+func (mtr *MockTranslationRepository) GetProductTranslations(ctx context.Context, productIDs []int64, locales []string) ([]domain.ProductTranslation, error) {
+	args := mtr.Called(ctx, productIDs, locales)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ProductTranslation), args.Error(1)
+}
+
+func (mtr *MockTranslationRepository) StoreProductTranslation(ctx context.Context, t *domain.ProductTranslation) error {
+	args := mtr.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (mtr *MockTranslationRepository) DeleteProductTranslation(ctx context.Context, productID int64, locale string) error {
+	args := mtr.Called(ctx, productID, locale)
+	return args.Error(0)
+}
+
+func (mtr *MockTranslationRepository) GetAttributeTranslations(ctx context.Context, locales []string, labels []string) ([]domain.AttributeTranslation, error) {
+	args := mtr.Called(ctx, locales, labels)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AttributeTranslation), args.Error(1)
+}
+
+func (mtr *MockTranslationRepository) StoreAttributeTranslation(ctx context.Context, t *domain.AttributeTranslation) error {
+	args := mtr.Called(ctx, t)
+	return args.Error(0)
+}
+
+func (mtr *MockTranslationRepository) DeleteAttributeTranslation(ctx context.Context, locale string, label string) error {
+	args := mtr.Called(ctx, locale, label)
+	return args.Error(0)
+}
+
+type MockProductTranslationValidator struct {
+	mock.Mock
+}
+
+func (mptv *MockProductTranslationValidator) Validate(ctx context.Context, t *domain.ProductTranslation) (domain.IsValid, domain.Message) {
+	args := mptv.Called(ctx, t)
+	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
+}
+
+type MockAttributeTranslationValidator struct {
+	mock.Mock
+}
+
+func (matv *MockAttributeTranslationValidator) Validate(ctx context.Context, t *domain.AttributeTranslation) (domain.IsValid, domain.Message) {
+	args := matv.Called(ctx, t)
+	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
+}
@@ -27,3 +27,19 @@ func (mur *MockUserRepository) GetByEmail(ctx context.Context, email string) (*d
 	}
 	return &domain.User{ID: int64(args.Int(0)), UUID: args.String(1), Email: args.String(2), FirstName: args.String(3), LastName: args.String(4), PhoneNumber: args.String(5), Address: domain.UserAddress{City: args.String(6), State: args.String(7), Neighborhood: args.String(8), Street: args.String(9), Number: args.String(10), ZipCode: args.String(11)}}, args.Error(12)
 }
+
+func (mur *MockUserRepository) GetByUUID(ctx context.Context, uuid string) (*domain.User, error) {
+	args := mur.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return &domain.User{ID: int64(args.Int(0)), UUID: args.String(1), Email: args.String(2), FirstName: args.String(3), LastName: args.String(4), PhoneNumber: args.String(5), Address: domain.UserAddress{City: args.String(6), State: args.String(7), Neighborhood: args.String(8), Street: args.String(9), Number: args.String(10), ZipCode: args.String(11)}}, args.Error(12)
+}
+
+func (mur *MockUserRepository) GetByID(ctx context.Context, id int64) (*domain.User, error) {
+	args := mur.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
@@ -0,0 +1,83 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockVariantUseCase struct {
+	mock.Mock
+}
+
+func (mvu *MockVariantUseCase) Generate(ctx context.Context, productUUID string) ([]domain.Variant, error) {
+	args := mvu.Called(ctx, productUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Variant), args.Error(1)
+}
+
+func (mvu *MockVariantUseCase) Update(ctx context.Context, productUUID string, v *domain.Variant) (*domain.Variant, error) {
+	args := mvu.Called(ctx, productUUID, v)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Variant), args.Error(1)
+}
+
+type MockVariantRepository struct {
+	mock.Mock
+}
+
+func (mvr *MockVariantRepository) GetByProductID(ctx context.Context, productID int64) ([]domain.Variant, error) {
+	args := mvr.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Variant), args.Error(1)
+}
+
+func (mvr *MockVariantRepository) GetBySKU(ctx context.Context, sku string) (*domain.Variant, error) {
+	args := mvr.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Variant), args.Error(1)
+}
+
+func (mvr *MockVariantRepository) GetBySKUs(ctx context.Context, skus []string) ([]domain.Variant, error) {
+	args := mvr.Called(ctx, skus)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Variant), args.Error(1)
+}
+
+func (mvr *MockVariantRepository) GetByBarcode(ctx context.Context, barcode string) (*domain.Variant, error) {
+	args := mvr.Called(ctx, barcode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Variant), args.Error(1)
+}
+
+func (mvr *MockVariantRepository) Replace(ctx context.Context, productID int64, variants []domain.Variant) error {
+	args := mvr.Called(ctx, productID, variants)
+	return args.Error(0)
+}
+
+func (mvr *MockVariantRepository) Update(ctx context.Context, v *domain.Variant) error {
+	args := mvr.Called(ctx, v)
+	return args.Error(0)
+}
+
+type MockVariantValidator struct {
+	mock.Mock
+}
+
+func (mvv *MockVariantValidator) Validate(ctx context.Context, v *domain.Variant) (domain.IsValid, domain.Message) {
+	args := mvv.Called(ctx, v)
+	return domain.IsValid(args.Bool(0)), domain.Message(args.String(1))
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "context"
+
+type NotificationCategory string
+
+const (
+	NotificationCategorySecurity  NotificationCategory = "security"
+	NotificationCategoryOrders    NotificationCategory = "orders"
+	NotificationCategoryMarketing NotificationCategory = "marketing"
+)
+
+type NotificationPreference struct {
+	Category NotificationCategory `json:"category"`
+	Medium   string               `json:"medium"`
+	OptedIn  bool                 `json:"optedIn"`
+}
+
+type Unsubscribe struct {
+	UserUUID string
+	Category NotificationCategory
+	Medium   string
+}
+
+type NotificationUseCase interface {
+	GetPreferences(ctx context.Context, login string) ([]NotificationPreference, error)
+	UpdatePreferences(ctx context.Context, login string, prefs []NotificationPreference) ([]NotificationPreference, error)
+	Unsubscribe(ctx context.Context, token string) error
+}
+
+type NotificationService interface {
+	Preferences(ctx context.Context, userID int64) ([]NotificationPreference, error)
+	IsOptedIn(ctx context.Context, userID int64, category NotificationCategory, medium string) (bool, error)
+	UnsubscribeLink(ctx context.Context, u *Unsubscribe) string
+	ParseUnsubscribeToken(ctx context.Context, token string) (*Unsubscribe, error)
+}
+
+type NotificationPreferenceRepository interface {
+	GetByUserID(ctx context.Context, userID int64) ([]NotificationPreference, error)
+	Store(ctx context.Context, userID int64, prefs []NotificationPreference) error
+}
+
+type NotificationValidator interface {
+	Validate(ctx context.Context, prefs []NotificationPreference) (IsValid, Message)
+}
@@ -0,0 +1,187 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrOrderNotFound = errors.New("order not found")
+var ErrOrderPlaced = errors.New("order already placed with the idempotency key")
+var ErrCartEmpty = errors.New("cart empty")
+var ErrCartUnavailable = errors.New("cart has unavailable items")
+var ErrCartChanged = errors.New("cart changed while placing the order")
+var ErrIdempotencyKeyReused = errors.New("idempotency key reused with another request")
+var ErrOrderChanged = errors.New("order changed while being transitioned")
+var ErrInvalidTransition = errors.New("invalid order transition")
+
+type OrderStatus string
+
+const (
+	OrderStatusPendingPayment OrderStatus = "pending_payment"
+	OrderStatusPaid           OrderStatus = "paid"
+	OrderStatusPicking        OrderStatus = "picking"
+	OrderStatusShipped        OrderStatus = "shipped"
+	OrderStatusDelivered      OrderStatus = "delivered"
+	OrderStatusCancelled      OrderStatus = "cancelled"
+	OrderStatusRefunded       OrderStatus = "refunded"
+	OrderStatusReturned       OrderStatus = "returned"
+)
+
+// StockEffect is what a transition does to the stock reserved for the order.
+type StockEffect string
+
+const (
+	StockEffectNone    StockEffect = ""
+	StockEffectCommit  StockEffect = "commit"
+	StockEffectRelease StockEffect = "release"
+)
+
+// OrderTransitions are the only changes of status an order goes through,
+// with their effect on the stock. The payment takes the reserved stock out
+// of the stock on hand, the cancellation of an unpaid order gives it back.
+// Cancelled and refunded orders change no more.
+var OrderTransitions = map[OrderStatus]map[OrderStatus]StockEffect{
+	OrderStatusPendingPayment: {OrderStatusPaid: StockEffectCommit, OrderStatusCancelled: StockEffectRelease},
+	OrderStatusPaid:           {OrderStatusPicking: StockEffectNone, OrderStatusRefunded: StockEffectNone},
+	OrderStatusPicking:        {OrderStatusShipped: StockEffectNone, OrderStatusRefunded: StockEffectNone},
+	OrderStatusShipped:        {OrderStatusDelivered: StockEffectNone, OrderStatusReturned: StockEffectNone},
+	OrderStatusDelivered:      {OrderStatusReturned: StockEffectNone, OrderStatusRefunded: StockEffectNone},
+	OrderStatusReturned:       {OrderStatusRefunded: StockEffectNone},
+}
+
+// TransitionError is the refusal of a change of status, because the state
+// machine does not allow it or because the order is not ready for it.
+type TransitionError struct {
+	From   OrderStatus
+	To     OrderStatus
+	Reason string
+}
+
+func (e *TransitionError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("order can not go from %s to %s", e.From, e.To)
+	}
+
+	return fmt.Sprintf("order can not go from %s to %s: %s", e.From, e.To, e.Reason)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// OrderHistoryEntry records a change of status, Actor is the login of the
+// staff member who made it or "system".
+type OrderHistoryEntry struct {
+	From      OrderStatus `json:"from"`
+	To        OrderStatus `json:"to"`
+	Actor     string      `json:"actor,omitempty"`
+	Note      string      `json:"note,omitempty"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+// OrderTransitionRequest asks an order to go to Status, TrackingCode is
+// required to ship it.
+type OrderTransitionRequest struct {
+	Status       OrderStatus `json:"status"`
+	TrackingCode string      `json:"trackingCode"`
+	Note         string      `json:"note"`
+}
+
+// OrderItem is a snapshot of a cart item when the order was placed, later
+// changes of the catalogue and of the prices do not change it. UnitPrice is
+// what the customer paid for each unit, ListPrice the regular price.
+type OrderItem struct {
+	ProductUUID     string          `json:"productUuid"`
+	SKU             string          `json:"sku"`
+	Name            string          `json:"name"`
+	Options         []VariantOption `json:"options"`
+	Quantity        int64           `json:"quantity"`
+	UnitPrice       Money           `json:"unitPrice"`
+	ListPrice       Money           `json:"listPrice"`
+	Total           Money           `json:"total"`
+	ReservationUUID string          `json:"-"`
+}
+
+// Order is placed from the cart of a user. Subtotal is the items at their
+// list prices, Discount what the sales and the price lists took from it, and
+// Total what is charged: Subtotal - Discount + Shipping + Tax. An order not
+// paid by ExpiresAt is cancelled.
+type Order struct {
+	ID             int64       `json:"-"`
+	UUID           string      `json:"uuid"`
+	UserID         int64       `json:"-"`
+	Status         OrderStatus `json:"status"`
+	Items          []OrderItem `json:"items"`
+	Address        UserAddress `json:"address"`
+	Subtotal       Money       `json:"subtotal"`
+	Discount       Money       `json:"discount"`
+	Shipping       Money       `json:"shipping"`
+	Tax            Money       `json:"tax"`
+	Total          Money       `json:"total"`
+	TrackingCode   string      `json:"trackingCode,omitempty"`
+	IdempotencyKey string      `json:"-"`
+	RequestHash    string      `json:"-"`
+	CreatedAt      time.Time   `json:"createdAt"`
+	ExpiresAt      time.Time   `json:"expiresAt"`
+	// History is only read with a single order.
+	History []OrderHistoryEntry `json:"history,omitempty"`
+}
+
+// OrderQuery lists the orders of all the users when UserID is 0, the newest
+// first.
+type OrderQuery struct {
+	UserID int64
+	Status OrderStatus
+	Cursor string
+	Limit  int
+}
+
+type OrderPage struct {
+	Orders     []Order `json:"orders"`
+	NextCursor string  `json:"nextCursor,omitempty"`
+}
+
+// OrderUseCase places the order of the cart of the user once per
+// idempotency key, the same request sent again gives back the order placed
+// the first time and true.
+type OrderUseCase interface {
+	Place(ctx context.Context, login string, idempotencyKey string, address *UserAddress) (*Order, bool, error)
+	ListMine(ctx context.Context, login string, q OrderQuery) (*OrderPage, error)
+	GetMine(ctx context.Context, login string, uuid string) (*Order, error)
+	List(ctx context.Context, q OrderQuery) (*OrderPage, error)
+	Get(ctx context.Context, uuid string) (*Order, error)
+	Transition(ctx context.Context, uuid string, actor string, t *OrderTransitionRequest) (*Order, error)
+}
+
+// OrderRepository places the order in a single transaction: the order, its
+// items, the reservations of their stock and their removal from the cart.
+// Place gives ErrOrderPlaced when the user already has an order with the
+// idempotency key. Transition changes the status only when the order still
+// has the one it was read with, giving ErrOrderChanged otherwise, and makes
+// the stock effect and the history entry with it. Extend gives
+// ErrOrderChanged when the order is no longer waiting for the payment.
+type OrderRepository interface {
+	GetByIdempotencyKey(ctx context.Context, userID int64, key string) (*Order, error)
+	GetByUUID(ctx context.Context, uuid string) (*Order, error)
+	List(ctx context.Context, q OrderQuery) (*OrderPage, error)
+	GetHistory(ctx context.Context, orderID int64) ([]OrderHistoryEntry, error)
+	Place(ctx context.Context, o *Order, cartID int64, reservedUntil time.Time) error
+	Transition(ctx context.Context, o *Order, entry *OrderHistoryEntry, stock StockEffect) error
+	Extend(ctx context.Context, o *Order, until time.Time) error
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]Order, error)
+}
+
+// CheckoutService calculates the totals of the order from its items.
+type CheckoutService interface {
+	Totals(ctx context.Context, o *Order) error
+}
+
+type AddressValidator interface {
+	Validate(ctx context.Context, a *UserAddress) (IsValid, Message)
+}
+
+type OrderTransitionValidator interface {
+	Validate(ctx context.Context, t *OrderTransitionRequest) (IsValid, Message)
+}
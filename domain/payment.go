@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrPaymentNotFound = errors.New("payment not found")
+var ErrPaymentDeclined = errors.New("payment declined")
+var ErrPaymentChanged = errors.New("payment changed while being updated")
+var ErrPaymentInvalidState = errors.New("payment does not allow the operation in its status")
+var ErrPaymentMethodNotSupported = errors.New("payment method not supported")
+var ErrPaymentProviderNotFound = errors.New("payment provider not found")
+var ErrInvalidWebhook = errors.New("invalid webhook")
+var ErrOrderNotPayable = errors.New("order is not waiting for a payment")
+
+type PaymentMethod string
+
+const (
+	PaymentMethodCard   PaymentMethod = "card"
+	PaymentMethodPix    PaymentMethod = "pix"
+	PaymentMethodBoleto PaymentMethod = "boleto"
+)
+
+// PaymentStatus of an intent: pending waits for the customer, like a pix
+// not paid yet, authorized holds the amount on the card until it is
+// captured or voided, and captured is money collected. Expired is a pix or
+// a boleto no longer payable.
+type PaymentStatus string
+
+const (
+	PaymentStatusPending    PaymentStatus = "pending"
+	PaymentStatusAuthorized PaymentStatus = "authorized"
+	PaymentStatusCaptured   PaymentStatus = "captured"
+	PaymentStatusVoided     PaymentStatus = "voided"
+	PaymentStatusRefunded   PaymentStatus = "refunded"
+	PaymentStatusFailed     PaymentStatus = "failed"
+	PaymentStatusExpired    PaymentStatus = "expired"
+)
+
+// PaymentTransitions are the changes of status a payment intent goes
+// through, the gateways may skip the authorization and capture at once. A
+// bank may still confirm an expired boleto paid on its last day, the
+// reconciliation refunds it.
+var PaymentTransitions = map[PaymentStatus][]PaymentStatus{
+	PaymentStatusPending:    {PaymentStatusAuthorized, PaymentStatusCaptured, PaymentStatusVoided, PaymentStatusFailed, PaymentStatusExpired},
+	PaymentStatusAuthorized: {PaymentStatusCaptured, PaymentStatusVoided, PaymentStatusFailed},
+	PaymentStatusCaptured:   {PaymentStatusRefunded},
+	PaymentStatusExpired:    {PaymentStatusCaptured},
+}
+
+// PaymentIntent is an attempt to pay an order through a gateway, an order
+// may have many, failed or voided ones included. ProviderReference is the id
+// of the payment at the gateway and PixCode the "copia e cola" payload of the
+// pix QR code. BoletoLine is the digitable line of the boleto, typed by the
+// customer at the bank, and BoletoBarcode the 44 digits of its bar code. The
+// pix and the boletos can not be paid after ExpiresAt. Refunded is how much
+// of a captured payment was given back, the payment is refunded once all of
+// it is.
+type PaymentIntent struct {
+	ID                int64         `json:"-"`
+	UUID              string        `json:"uuid"`
+	OrderID           int64         `json:"-"`
+	OrderUUID         string        `json:"orderUuid"`
+	Method            PaymentMethod `json:"method"`
+	Provider          string        `json:"provider"`
+	ProviderReference string        `json:"-"`
+	Status            PaymentStatus `json:"status"`
+	Amount            Money         `json:"amount"`
+	Refunded          Money         `json:"refunded"`
+	PixCode           string        `json:"pixCode,omitempty"`
+	BoletoLine        string        `json:"boletoLine,omitempty"`
+	BoletoBarcode     string        `json:"boletoBarcode,omitempty"`
+	FailureReason     string        `json:"failureReason,omitempty"`
+	ExpiresAt         *time.Time    `json:"expiresAt,omitempty"`
+	CreatedAt         time.Time     `json:"createdAt"`
+	UpdatedAt         time.Time     `json:"updatedAt"`
+}
+
+// PaymentRequest is how the customer pays the order, CardToken is the card
+// tokenized by the processor in the browser, the store never sees the card.
+type PaymentRequest struct {
+	Method    PaymentMethod `json:"method"`
+	CardToken string        `json:"cardToken"`
+}
+
+// PaymentAuthorization asks the gateway to hold the amount, Reference is the
+// uuid of the intent and makes the request idempotent at the gateway.
+type PaymentAuthorization struct {
+	Reference   string
+	Method      PaymentMethod
+	Amount      Money
+	CardToken   string
+	Description string
+	ExpiresAt   time.Time
+}
+
+// PaymentResult is the answer of the gateway, a declined payment is a
+// result with the failed status and the FailureReason, not an error.
+type PaymentResult struct {
+	ProviderReference string
+	Status            PaymentStatus
+	PixCode           string
+	BoletoLine        string
+	BoletoBarcode     string
+	FailureReason     string
+}
+
+// PaymentEvent is a change of status of a payment told by the gateway.
+type PaymentEvent struct {
+	ProviderReference string
+	Status            PaymentStatus
+	Amount            Money
+}
+
+// PaymentGateway talks to a payment provider. The errors are failures to
+// talk to it, ErrPaymentInvalidState when the payment does not allow the
+// operation. VerifyWebhook checks the signature of a notification sent by
+// the provider, giving ErrInvalidWebhook when it does not match.
+type PaymentGateway interface {
+	Name() string
+	Authorize(ctx context.Context, a *PaymentAuthorization) (*PaymentResult, error)
+	Capture(ctx context.Context, providerReference string, amount Money) (*PaymentResult, error)
+	Void(ctx context.Context, providerReference string) (*PaymentResult, error)
+	Refund(ctx context.Context, providerReference string, reference string, amount Money) (*PaymentResult, error)
+	VerifyWebhook(ctx context.Context, payload []byte, signature string) ([]PaymentEvent, error)
+}
+
+// PaymentUseCase pays the orders through the gateways and keeps the orders in
+// step with their payments: a captured payment pays the order, an authorized
+// payment of a cancelled order is voided and a payment captured after the
+// order was cancelled is refunded. Expire cancels the orders not paid in
+// time, expiring their pix and boletos.
+type PaymentUseCase interface {
+	Pay(ctx context.Context, login string, orderUUID string, r *PaymentRequest) (*PaymentIntent, error)
+	ListMine(ctx context.Context, login string, orderUUID string) ([]PaymentIntent, error)
+	List(ctx context.Context, orderUUID string) ([]PaymentIntent, error)
+	Capture(ctx context.Context, uuid string) (*PaymentIntent, error)
+	Void(ctx context.Context, uuid string) (*PaymentIntent, error)
+	Webhook(ctx context.Context, provider string, payload []byte, signature string) error
+	Reconcile(ctx context.Context) (int, error)
+	Expire(ctx context.Context) (int, error)
+}
+
+// PaymentRepository updates an intent only when it still has the status it
+// was read with, giving ErrPaymentChanged otherwise. ListToReconcile gives
+// the intents out of step with their orders.
+type PaymentRepository interface {
+	Create(ctx context.Context, p *PaymentIntent) error
+	GetByUUID(ctx context.Context, uuid string) (*PaymentIntent, error)
+	GetByProviderReference(ctx context.Context, provider string, reference string) (*PaymentIntent, error)
+	ListByOrder(ctx context.Context, orderID int64) ([]PaymentIntent, error)
+	ListToReconcile(ctx context.Context) ([]PaymentIntent, error)
+	Update(ctx context.Context, p *PaymentIntent, from PaymentStatus) error
+}
+
+type PaymentRequestValidator interface {
+	Validate(ctx context.Context, r *PaymentRequest) (IsValid, Message)
+}
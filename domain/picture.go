@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrUnsupportedImage = errors.New("unsupported image")
+var ErrImageTooLarge = errors.New("image too large")
+
+type Blob struct {
+	Data        []byte
+	ContentType string
+}
+
+// BlobStore keeps files by key, a key is a slash separated path like
+// products/<uuid>/<name>.jpg. Get gives nil when the key does not exist.
+type BlobStore interface {
+	Put(ctx context.Context, key string, b *Blob) error
+	Get(ctx context.Context, key string) (*Blob, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type Thumbnail struct {
+	Size        string
+	Width       int
+	Height      int
+	ContentType string
+	Data        []byte
+}
+
+type ProcessedImage struct {
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+	Thumbnails  []Thumbnail
+}
+
+type ImageService interface {
+	Process(ctx context.Context, data []byte) (*ProcessedImage, error)
+}
+
+type Picture struct {
+	Path       string            `json:"path"`
+	Width      int               `json:"width"`
+	Height     int               `json:"height"`
+	Thumbnails map[string]string `json:"thumbnails"`
+}
+
+type PictureUseCase interface {
+	Upload(ctx context.Context, productUUID string, data []byte) (*Picture, error)
+	Get(ctx context.Context, key string) (*Blob, error)
+}
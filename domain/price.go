@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrNoPrice = errors.New("no price")
+var ErrPriceNotFound = errors.New("price not found")
+var ErrPriceListNotFound = errors.New("price list not found")
+var ErrPriceListCodeTaken = errors.New("price list code already in use")
+var ErrCurrencyMismatch = errors.New("currency differs from the price list one")
+
+type Currency string
+
+const CurrencyBRL Currency = "BRL"
+
+// DefaultCurrency is the currency of Product.Price and Variant.Price.
+const DefaultCurrency = CurrencyBRL
+
+// Money amounts are in minor units (cents) of the currency.
+type Money struct {
+	Amount   int64    `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+type PriceKind string
+
+const (
+	PriceKindList PriceKind = "list"
+	PriceKindSale PriceKind = "sale"
+)
+
+// ProductPrice is a price of a product, or of only one of its variants when
+// VariantSKU is set. Without PriceList it applies to every customer, with it
+// only to the customers of the groups of the list. It applies from
+// MinQuantity units on, between StartsAt inclusive and EndsAt exclusive when
+// they are set.
+type ProductPrice struct {
+	ID          int64      `json:"id"`
+	ProductID   int64      `json:"-"`
+	VariantSKU  string     `json:"variantSku,omitempty"`
+	PriceList   string     `json:"priceList,omitempty"`
+	Kind        PriceKind  `json:"kind"`
+	Amount      Money      `json:"amount"`
+	MinQuantity int64      `json:"minQuantity"`
+	StartsAt    *time.Time `json:"startsAt,omitempty"`
+	EndsAt      *time.Time `json:"endsAt,omitempty"`
+}
+
+// PriceList groups the prices given to the customers of a customer group.
+type PriceList struct {
+	ID            int64    `json:"-"`
+	Code          string   `json:"code"`
+	Name          string   `json:"name"`
+	Currency      Currency `json:"currency"`
+	CustomerGroup string   `json:"customerGroup"`
+}
+
+// EffectivePrice is what a customer pays for a quantity of a product. List is
+// the regular price, when Unit is lower the product is on sale for them.
+type EffectivePrice struct {
+	Unit      Money      `json:"unit"`
+	List      Money      `json:"list"`
+	Total     Money      `json:"total"`
+	Quantity  int64      `json:"quantity"`
+	OnSale    bool       `json:"onSale"`
+	PriceList string     `json:"priceList,omitempty"`
+	EndsAt    *time.Time `json:"endsAt,omitempty"`
+}
+
+// PriceRequest asks the price of a product, or of one of its variants, for a
+// user at a moment. UserID is 0 for anonymous customers.
+type PriceRequest struct {
+	Product  *Product
+	Variant  *Variant
+	Quantity int64
+	UserID   int64
+	Currency Currency
+	At       time.Time
+}
+
+type PricingService interface {
+	Resolve(ctx context.Context, r PriceRequest) (*EffectivePrice, error)
+}
+
+type PriceUseCase interface {
+	Quote(ctx context.Context, productUUID string, sku string, quantity int64, currency Currency, login string) (*EffectivePrice, error)
+	GetPrices(ctx context.Context, productUUID string) ([]ProductPrice, error)
+	AddPrice(ctx context.Context, productUUID string, p *ProductPrice) error
+	DeletePrice(ctx context.Context, productUUID string, id int64) error
+	CreatePriceList(ctx context.Context, pl *PriceList) error
+	SetCustomerGroups(ctx context.Context, userUUID string, groups []string) error
+}
+
+type PriceRepository interface {
+	GetByProductID(ctx context.Context, productID int64) ([]ProductPrice, error)
+	Store(ctx context.Context, p *ProductPrice) error
+	Delete(ctx context.Context, productID int64, id int64) error
+	GetPriceListByCode(ctx context.Context, code string) (*PriceList, error)
+	StorePriceList(ctx context.Context, pl *PriceList) error
+	GetPriceListCodesByUserID(ctx context.Context, userID int64) ([]string, error)
+	SetCustomerGroups(ctx context.Context, userID int64, groups []string) error
+}
+
+type PriceValidator interface {
+	Validate(ctx context.Context, p *ProductPrice) (IsValid, Message)
+}
+
+type PriceListValidator interface {
+	Validate(ctx context.Context, pl *PriceList) (IsValid, Message)
+}
@@ -1,27 +1,164 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+var ErrVersionConflict = errors.New("version conflict")
+var ErrProductNotFound = errors.New("product not found")
+
+type ProductStatus string
+
+const (
+	ProductStatusDraft     ProductStatus = "draft"
+	ProductStatusPublished ProductStatus = "published"
+	ProductStatusArchived  ProductStatus = "archived"
+)
 
 type Attribute struct {
 	Label  string   `json:"label"`
 	Values []string `json:"values"`
 }
 
+// Product slug is generated from the name, the slugs it had before keep
+// leading to it. The meta fields are for the search engines, empty when the
+// store defaults apply. Version guards the writes of the admins, while
+// ContentVersion changes with anything shown of the product, its variants,
+// stock, rating and translations included. CustomerPrice is the price of a
+// unit for the logged customer, with the price lists of their groups.
 type Product struct {
-	ID         int64
-	UUID       string      `json:"uuid"`
-	Rate       float32     `json:"rate"`
-	Pictures   []string    `json:"pictures"`
-	Name       string      `json:"name"`
-	Detail     string      `json:"detail"`
-	Favorite   bool        `json:"favorite"`
-	Attributes []Attribute `json:"attributes"`
+	ID              int64
+	UUID            string          `json:"uuid"`
+	Slug            string          `json:"slug,omitempty"`
+	Rate            float32         `json:"rate"`
+	Pictures        []string        `json:"pictures"`
+	Name            string          `json:"name"`
+	Detail          string          `json:"detail"`
+	Favorite        bool            `json:"favorite"`
+	Attributes      []Attribute     `json:"attributes"`
+	Price           int64           `json:"price"`
+	Status          ProductStatus   `json:"status"`
+	Version         int64           `json:"version"`
+	ContentVersion  int64           `json:"-"`
+	MetaTitle       string          `json:"metaTitle,omitempty"`
+	MetaDescription string          `json:"metaDescription,omitempty"`
+	CanonicalURL    string          `json:"canonicalUrl,omitempty"`
+	Variants        []Variant       `json:"variants,omitempty"`
+	CustomerPrice   *EffectivePrice `json:"customerPrice,omitempty"`
+}
+
+type ProductSort string
+
+const (
+	ProductSortName   ProductSort = "name"
+	ProductSortRate   ProductSort = "rate"
+	ProductSortPrice  ProductSort = "price"
+	ProductSortNewest ProductSort = "newest"
+)
+
+// ProductQuery prices are in minor units (cents). Newest is always sorted
+// from the most recent product, so Desc is ignored for it. Category matches
+// the products of the category and of all its subcategories, Collection the
+// products manually added to a collection. UUIDs limits the list to those
+// products.
+type ProductQuery struct {
+	Cursor     string
+	Limit      int
+	Status     ProductStatus
+	Sort       ProductSort
+	Desc       bool
+	Category   string
+	Collection string
+	FavoriteOf int64
+	Wishlist   int64
+	UUIDs      []string
+	Attributes map[string][]string
+	MinPrice   *int64
+	MaxPrice   *int64
+	WithTotal  bool
+	WithFacets bool
+}
+
+type FacetValue struct {
+	Value string `json:"value"`
+	Name  string `json:"name,omitempty"`
+	Count int64  `json:"count"`
+}
+
+// AttributeFacet name and the names of its values are the translations of
+// the request locales, the label and the values are the ones to filter by.
+type AttributeFacet struct {
+	Label  string       `json:"label"`
+	Name   string       `json:"name,omitempty"`
+	Values []FacetValue `json:"values"`
+}
+
+// PriceFacet counts the products with min <= price < max, the last bucket
+// has no max.
+type PriceFacet struct {
+	Min   int64  `json:"min"`
+	Max   *int64 `json:"max,omitempty"`
+	Count int64  `json:"count"`
+}
+
+// RatingFacet counts the products rated min stars or more.
+type RatingFacet struct {
+	Min   int   `json:"min"`
+	Count int64 `json:"count"`
+}
+
+type ProductFacets struct {
+	Attributes []AttributeFacet `json:"attributes"`
+	Categories []FacetValue     `json:"categories"`
+	Prices     []PriceFacet     `json:"prices"`
+	Ratings    []RatingFacet    `json:"ratings"`
+}
+
+type ProductPage struct {
+	Products   []Product      `json:"products"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+	Total      *int64         `json:"total,omitempty"`
+	Facets     *ProductFacets `json:"facets,omitempty"`
 }
 
 type ProductUseCase interface {
-	Get(ctx context.Context, uuid string) (*Product, error)
+	Head(ctx context.Context, uuid string, login string) (*Product, error)
+	HeadBySlug(ctx context.Context, slug string, login string) (*Product, error)
+	Complete(ctx context.Context, p *Product) error
+	List(ctx context.Context, q ProductQuery, login string) (*ProductPage, error)
+	Search(ctx context.Context, q string, limit int, login string) (*ProductPage, error)
+	IndexAll(ctx context.Context) error
+	AdminGet(ctx context.Context, uuid string) (*Product, error)
+	Create(ctx context.Context, p *Product) error
+	Update(ctx context.Context, p *Product) (*Product, error)
+	Delete(ctx context.Context, uuid string, version int64) (*Product, error)
+}
+
+// ProductToucher is told of the writes made around the products that change
+// what is shown of them, like their variants, stock, rating and translations.
+type ProductToucher interface {
+	Touch(ctx context.Context, productIDs []int64) error
 }
 
 type ProductRepository interface {
 	GetByUUID(ctx context.Context, uuid string) (*Product, error)
+	GetUUIDs(ctx context.Context, productIDs []int64) ([]string, error)
+	GetIDsByAttribute(ctx context.Context, label string) ([]int64, error)
+	GetBySlug(ctx context.Context, slug string) (*Product, error)
+	GetByOldSlug(ctx context.Context, slug string) (*Product, error)
+	SlugTaken(ctx context.Context, slug string, productID int64) (bool, error)
+	GetByUUIDs(ctx context.Context, uuids []string) ([]Product, error)
+	List(ctx context.Context, q ProductQuery) (*ProductPage, error)
+	Facets(ctx context.Context, q ProductQuery) (*ProductFacets, error)
+	GetFavoriteProductIDs(ctx context.Context, userID int64, productIDs []int64) (map[int64]bool, error)
+	Store(ctx context.Context, p *Product) error
+	Update(ctx context.Context, p *Product) error
+	Delete(ctx context.Context, uuid string, version int64) error
+	Touch(ctx context.Context, productIDs []int64) error
+}
+
+type ProductValidator interface {
+	Validate(ctx context.Context, p *Product) (IsValid, Message)
 }
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrInvalidRelated = errors.New("invalid related products")
+
+type AffinityKind string
+
+const (
+	AffinityBoughtTogether AffinityKind = "bought_together"
+	AffinityViewedTogether AffinityKind = "viewed_together"
+)
+
+// RelatedProducts Related has the products pinned by the admins first, then
+// the ones viewed together and, to fill the list, the ones sharing the most
+// attribute values.
+type RelatedProducts struct {
+	BoughtTogether []Product `json:"boughtTogether"`
+	Related        []Product `json:"related"`
+}
+
+type RecommendationUseCase interface {
+	Related(ctx context.Context, uuid string, limit int, login string) (*RelatedProducts, error)
+	GetPinned(ctx context.Context, uuid string) ([]Product, error)
+	SetPinned(ctx context.Context, uuid string, related []string) ([]Product, error)
+	ComputeAffinities(ctx context.Context) error
+}
+
+// RecommendationRepository gives the uuids of the products, the most related
+// first, GetAffinities and GetSimilar only give the published ones.
+// ComputeAffinities replaces all the affinities with the ones of the sales
+// and the views since the given times.
+type RecommendationRepository interface {
+	AddView(ctx context.Context, userID int64, productID int64) error
+	GetAffinities(ctx context.Context, productID int64, kind AffinityKind, limit int) ([]string, error)
+	GetSimilar(ctx context.Context, productID int64, limit int) ([]string, error)
+	GetPinned(ctx context.Context, productID int64) ([]string, error)
+	SetPinned(ctx context.Context, productID int64, relatedIDs []int64) error
+	ComputeAffinities(ctx context.Context, salesSince time.Time, viewsSince time.Time) error
+}
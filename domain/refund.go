@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrRefundNotAllowed = errors.New("order does not allow refunds in its status")
+var ErrRefundExceeds = errors.New("refund exceeds what is left to refund")
+var ErrRefundLineNotFound = errors.New("refund line not found in the order")
+var ErrRefundFailed = errors.New("refund refused by the gateway")
+var ErrOrderNotRefunded = errors.New("payments refunded but the order not moved to refunded")
+
+// RefundStatus of a refund: pending holds its amount on the payment while the
+// gateway answers, a failed refund gives the amount back to be refunded
+// again.
+type RefundStatus string
+
+const (
+	RefundStatusPending   RefundStatus = "pending"
+	RefundStatusSucceeded RefundStatus = "succeeded"
+	RefundStatusFailed    RefundStatus = "failed"
+)
+
+// RefundLine is a quantity of an item of the order given back.
+type RefundLine struct {
+	SKU      string `json:"sku"`
+	Quantity int64  `json:"quantity"`
+}
+
+// RefundRequest gives back the whole order when Full, otherwise the Lines at
+// the unit prices paid for them plus Amount, in cents of the currency of the
+// order, for what is not an item, like the shipping.
+type RefundRequest struct {
+	Full   bool         `json:"full"`
+	Lines  []RefundLine `json:"lines"`
+	Amount int64        `json:"amount"`
+	Reason string       `json:"reason"`
+}
+
+// Refund is money given back of a captured payment, a refund of an order
+// paid by many payments has one for each of them. Restocked tells the lines
+// went back to the stock, Actor is the login of the staff member who made it
+// or "system".
+type Refund struct {
+	ID                int64        `json:"-"`
+	UUID              string       `json:"uuid"`
+	OrderID           int64        `json:"-"`
+	PaymentID         int64        `json:"-"`
+	PaymentUUID       string       `json:"paymentUuid"`
+	ProviderReference string       `json:"-"`
+	Status            RefundStatus `json:"status"`
+	Amount            Money        `json:"amount"`
+	Lines             []RefundLine `json:"lines"`
+	Reason            string       `json:"reason"`
+	Restocked         bool         `json:"restocked"`
+	FailureReason     string       `json:"failureReason,omitempty"`
+	Actor             string       `json:"actor"`
+	CreatedAt         time.Time    `json:"createdAt"`
+}
+
+type LedgerEntryKind string
+
+const (
+	LedgerEntryPayment LedgerEntryKind = "payment"
+	LedgerEntryRefund  LedgerEntryKind = "refund"
+)
+
+// LedgerEntry is money collected, or given back with a negative Amount.
+type LedgerEntry struct {
+	Kind      LedgerEntryKind `json:"kind"`
+	UUID      string          `json:"uuid"`
+	Amount    Money           `json:"amount"`
+	Reason    string          `json:"reason,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// Ledger is the money of an order: Collected by the captured payments,
+// Refunded by the refunds that succeeded and Net what the store kept.
+type Ledger struct {
+	OrderUUID string        `json:"orderUuid"`
+	Entries   []LedgerEntry `json:"entries"`
+	Collected Money         `json:"collected"`
+	Refunded  Money         `json:"refunded"`
+	Net       Money         `json:"net"`
+}
+
+// RefundUseCase gives back the money of the orders through the gateways of
+// their payments. A refund of all that is left refunds the order.
+type RefundUseCase interface {
+	Refund(ctx context.Context, orderUUID string, actor string, r *RefundRequest) ([]Refund, error)
+	List(ctx context.Context, orderUUID string) ([]Refund, error)
+	Ledger(ctx context.Context, orderUUID string) (*Ledger, error)
+	LedgerMine(ctx context.Context, login string, orderUUID string) (*Ledger, error)
+}
+
+// RefundRepository holds the amount of a refund on its payment when it is
+// created, only while the payment has that much left to refund, giving
+// ErrPaymentChanged otherwise. Finish gives the amount back to the payment
+// when the refund failed and refunds the payment once all of it is given
+// back.
+type RefundRepository interface {
+	Create(ctx context.Context, r *Refund) error
+	Finish(ctx context.Context, r *Refund) error
+	ListByOrder(ctx context.Context, orderID int64) ([]Refund, error)
+}
+
+type RefundRequestValidator interface {
+	Validate(ctx context.Context, r *RefundRequest) (IsValid, Message)
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrReviewNotFound = errors.New("review not found")
+var ErrReviewExists = errors.New("review already exists")
+var ErrOwnReview = errors.New("own review")
+
+type ReviewStatus string
+
+const (
+	ReviewStatusPending  ReviewStatus = "pending"
+	ReviewStatusApproved ReviewStatus = "approved"
+	ReviewStatusRejected ReviewStatus = "rejected"
+)
+
+// Review is shown in the catalogue only after it is approved, Author is the
+// first name of the customer.
+type Review struct {
+	ID               int64        `json:"-"`
+	UUID             string       `json:"uuid"`
+	ProductID        int64        `json:"-"`
+	ProductUUID      string       `json:"productUuid"`
+	UserID           int64        `json:"-"`
+	Author           string       `json:"author"`
+	Stars            int          `json:"stars"`
+	Title            string       `json:"title"`
+	Body             string       `json:"body"`
+	VerifiedPurchase bool         `json:"verifiedPurchase"`
+	Status           ReviewStatus `json:"status"`
+	HelpfulCount     int64        `json:"helpfulCount"`
+	CreatedAt        time.Time    `json:"createdAt"`
+}
+
+// RatingSummary is computed from the approved reviews, Histogram counts the
+// reviews of 1 to 5 stars, in this order.
+type RatingSummary struct {
+	Average   float64  `json:"average"`
+	Count     int64    `json:"count"`
+	Histogram [5]int64 `json:"histogram"`
+}
+
+type ReviewSort string
+
+const (
+	ReviewSortHelpful ReviewSort = "helpful"
+	ReviewSortNewest  ReviewSort = "newest"
+	ReviewSortHighest ReviewSort = "highest"
+	ReviewSortLowest  ReviewSort = "lowest"
+	// ReviewSortOldest is the order of the moderation queue.
+	ReviewSortOldest ReviewSort = "oldest"
+)
+
+// ReviewQuery lists the reviews of all the products when ProductID is 0.
+type ReviewQuery struct {
+	ProductID int64
+	Status    ReviewStatus
+	Sort      ReviewSort
+	Cursor    string
+	Limit     int
+}
+
+type ReviewPage struct {
+	Rating     *RatingSummary `json:"rating,omitempty"`
+	Reviews    []Review       `json:"reviews"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+}
+
+type ReviewUseCase interface {
+	List(ctx context.Context, productUUID string, q ReviewQuery) (*ReviewPage, error)
+	Create(ctx context.Context, productUUID string, login string, r *Review) error
+	Vote(ctx context.Context, reviewUUID string, login string) error
+	Queue(ctx context.Context, q ReviewQuery) (*ReviewPage, error)
+	Moderate(ctx context.Context, reviewUUID string, status ReviewStatus) (*Review, error)
+}
+
+// ReviewRepository keeps the rating of the products up to date as the
+// reviews are approved or leave the approved status, without recounting them.
+type ReviewRepository interface {
+	List(ctx context.Context, q ReviewQuery) (*ReviewPage, error)
+	GetByUUID(ctx context.Context, uuid string) (*Review, error)
+	GetByProductAndUser(ctx context.Context, productID int64, userID int64) (*Review, error)
+	Store(ctx context.Context, r *Review) error
+	SetStatus(ctx context.Context, r *Review, status ReviewStatus) error
+	GetRating(ctx context.Context, productID int64) (*RatingSummary, error)
+	Vote(ctx context.Context, reviewID int64, userID int64) error
+}
+
+type ReviewValidator interface {
+	Validate(ctx context.Context, r *Review) (IsValid, Message)
+}
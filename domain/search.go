@@ -0,0 +1,41 @@
+package domain
+
+import "context"
+
+type SearchHit struct {
+	ProductUUID string
+	Name        string
+	Score       float64
+}
+
+type SuggestionType string
+
+const (
+	SuggestionTypeQuery    SuggestionType = "query"
+	SuggestionTypeCategory SuggestionType = "category"
+	SuggestionTypeProduct  SuggestionType = "product"
+)
+
+// Suggestion Key is the query itself, the category slug or the product uuid,
+// depending on the Type.
+type Suggestion struct {
+	Type SuggestionType `json:"type"`
+	Text string         `json:"text"`
+	Key  string         `json:"key"`
+}
+
+type SearchIndex interface {
+	Index(ctx context.Context, p *Product)
+	Remove(ctx context.Context, uuid string)
+	Search(ctx context.Context, q string, limit int) []SearchHit
+}
+
+type SearchUseCase interface {
+	Suggest(ctx context.Context, q string, limit int) ([]Suggestion, error)
+}
+
+type SearchRepository interface {
+	StoreQuery(ctx context.Context, q string) error
+	GetQuerySuggestions(ctx context.Context, prefix string, limit int) ([]Suggestion, error)
+	GetCategorySuggestions(ctx context.Context, prefix string, limit int) ([]Suggestion, error)
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "context"
+
+// SitemapUseCase splits the published products in sitemaps numbered from 1,
+// each one within the limit of urls of a sitemap file. Products gives no
+// product for a sitemap past the last one.
+type SitemapUseCase interface {
+	Count(ctx context.Context) (int, error)
+	Products(ctx context.Context, n int) ([]Product, error)
+}
@@ -8,9 +8,11 @@ type Token string
 
 type TokenInfo struct {
 	Info string
+	Role string
 }
 
 type TokenService interface {
 	Sign(ctx context.Context, info TokenInfo, expirationInMinutes int64) (Token, error)
 	IsValid(ctx context.Context, token Token) (IsValid, error)
+	GetInfo(ctx context.Context, token Token) (*TokenInfo, error)
 }
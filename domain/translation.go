@@ -0,0 +1,64 @@
+package domain
+
+import "context"
+
+// ProductTranslation replaces the name and the detail of a product in a
+// locale, an empty field falls back to the next locale asked.
+type ProductTranslation struct {
+	ProductID int64  `json:"-"`
+	Locale    string `json:"locale"`
+	Name      string `json:"name"`
+	Detail    string `json:"detail"`
+}
+
+// AttributeTranslation translates an attribute label and its values for
+// every product, Values goes from the original value to the translated one.
+type AttributeTranslation struct {
+	Locale      string            `json:"locale"`
+	Label       string            `json:"label"`
+	Translation string            `json:"translation"`
+	Values      map[string]string `json:"values"`
+}
+
+type TranslationUseCase interface {
+	GetProductTranslations(ctx context.Context, productUUID string) ([]ProductTranslation, error)
+	SetProductTranslation(ctx context.Context, productUUID string, t *ProductTranslation) error
+	DeleteProductTranslation(ctx context.Context, productUUID string, locale string) error
+	GetAttributeTranslations(ctx context.Context, locale string) ([]AttributeTranslation, error)
+	SetAttributeTranslation(ctx context.Context, t *AttributeTranslation) error
+	DeleteAttributeTranslation(ctx context.Context, locale string, label string) error
+}
+
+// TranslationRepository gives the translations of every locale when locales
+// is nil, and of every label when labels is nil.
+type TranslationRepository interface {
+	GetProductTranslations(ctx context.Context, productIDs []int64, locales []string) ([]ProductTranslation, error)
+	StoreProductTranslation(ctx context.Context, t *ProductTranslation) error
+	DeleteProductTranslation(ctx context.Context, productID int64, locale string) error
+	GetAttributeTranslations(ctx context.Context, locales []string, labels []string) ([]AttributeTranslation, error)
+	StoreAttributeTranslation(ctx context.Context, t *AttributeTranslation) error
+	DeleteAttributeTranslation(ctx context.Context, locale string, label string) error
+}
+
+type ProductTranslationValidator interface {
+	Validate(ctx context.Context, t *ProductTranslation) (IsValid, Message)
+}
+
+type AttributeTranslationValidator interface {
+	Validate(ctx context.Context, t *AttributeTranslation) (IsValid, Message)
+}
+
+type localesKey struct{}
+
+// ContextWithLocales keeps the locales asked by the request, the most
+// wanted first, for the use cases that translate what they give.
+func ContextWithLocales(ctx context.Context, locales []string) context.Context {
+	return context.WithValue(ctx, localesKey{}, locales)
+}
+
+// LocalesFromContext is empty when the request wants the original content.
+func LocalesFromContext(ctx context.Context) []string {
+	locales, _ := ctx.Value(localesKey{}).([]string)
+
+	return locales
+}
@@ -1,4 +1,15 @@
 package domain
 
+import "errors"
+
+var ErrUnsupportedTx = errors.New("unsupported transaction")
+
 type IsValid bool
 type Message string
+
+// Tx is a transaction begun by one repository and handed to the others that
+// write within it, only the one that began it commits or rolls it back.
+type Tx interface {
+	Commit() error
+	Rollback() error
+}
@@ -1,6 +1,11 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+var ErrUserNotFound = errors.New("user not found")
 
 type User struct {
 	ID          int64
@@ -23,6 +28,8 @@ type UserAddress struct {
 
 type UserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*User, error)
+	GetByUUID(ctx context.Context, uuid string) (*User, error)
+	GetByID(ctx context.Context, id int64) (*User, error)
 }
 
 type UserValidator interface {
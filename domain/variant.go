@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrBarcodeTaken = errors.New("barcode already in use")
+var ErrTooManyVariants = errors.New("too many variants")
+var ErrVariantNotFound = errors.New("variant not found")
+
+type VariantOption struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// Variant is a purchasable combination of one value of each attribute of a
+// product, prices are in minor units (cents).
+type Variant struct {
+	ID        int64           `json:"-"`
+	ProductID int64           `json:"-"`
+	SKU       string          `json:"sku"`
+	Options   []VariantOption `json:"options"`
+	Price     int64           `json:"price"`
+	// Stock is the quantity available in all the warehouses, it is changed
+	// only through the inventory.
+	Stock    int64    `json:"stock"`
+	Barcode  string   `json:"barcode"`
+	Pictures []string `json:"pictures"`
+}
+
+type VariantUseCase interface {
+	Generate(ctx context.Context, productUUID string) ([]Variant, error)
+	Update(ctx context.Context, productUUID string, v *Variant) (*Variant, error)
+}
+
+type VariantRepository interface {
+	GetByProductID(ctx context.Context, productID int64) ([]Variant, error)
+	GetBySKU(ctx context.Context, sku string) (*Variant, error)
+	GetBySKUs(ctx context.Context, skus []string) ([]Variant, error)
+	GetByBarcode(ctx context.Context, barcode string) (*Variant, error)
+	Replace(ctx context.Context, productID int64, variants []Variant) error
+	Update(ctx context.Context, v *Variant) error
+}
+
+type VariantValidator interface {
+	Validate(ctx context.Context, v *Variant) (IsValid, Message)
+}
@@ -0,0 +1,131 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_productPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/product/presentation"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type favoriteHandler struct {
+	FavoriteUseCase domain.FavoriteUseCase
+}
+
+func NewFavoriteHandler(e *echo.Echo, fuc domain.FavoriteUseCase, ts domain.TokenService) *favoriteHandler {
+	handler := &favoriteHandler{
+		FavoriteUseCase: fuc,
+	}
+
+	auth := _tokenPresentation.NewAuthMiddleware(ts)
+
+	e.GET("/me/favorites", handler.List, auth)
+	e.PUT("/me/favorites/:productUuid", handler.Add, auth)
+	e.DELETE("/me/favorites/:productUuid", handler.Remove, auth)
+
+	return handler
+}
+
+func (fh *favoriteHandler) List(c echo.Context) error {
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	q, message := _productPresentation.ParseProductQuery(c)
+
+	if message != "" {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	page, err := fh.FavoriteUseCase.List(c.Request().Context(), tokenInfo.Info, *q)
+
+	return productsResponse(c, page, err)
+}
+
+func (fh *favoriteHandler) Add(c echo.Context) error {
+	productUUID := c.Param("productUuid")
+
+	if productUUID == "" {
+		return c.JSON(http.StatusBadRequest, "productUuid param is not valid")
+	}
+
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	err := fh.FavoriteUseCase.Add(c.Request().Context(), tokenInfo.Info, productUUID)
+
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, "user not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to favorite a product: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to favorite the product")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (fh *favoriteHandler) Remove(c echo.Context) error {
+	productUUID := c.Param("productUuid")
+
+	if productUUID == "" {
+		return c.JSON(http.StatusBadRequest, "productUuid param is not valid")
+	}
+
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	err := fh.FavoriteUseCase.Remove(c.Request().Context(), tokenInfo.Info, productUUID)
+
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, "user not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to unfavorite a product: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to unfavorite the product")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func productsResponse(c echo.Context, page *domain.ProductPage, err error) error {
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return c.JSON(http.StatusBadRequest, "cursor param is not valid")
+	}
+
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, "user not found")
+	}
+
+	if errors.Is(err, domain.ErrWishlistNotFound) {
+		return c.JSON(http.StatusNotFound, "wishlist not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to list products: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to list the products")
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
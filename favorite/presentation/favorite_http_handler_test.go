@@ -0,0 +1,118 @@
+package presentation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListFavoritesUnauthorized(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/me/favorites", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewFavoriteHandler(echo.New(), nil, nil)
+
+	handler.List(c)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestListFavorites(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/me/favorites?sort=-price&limit=5", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockFavoriteUsecase := new(mocks.MockFavoriteUseCase)
+
+	q := domain.ProductQuery{Limit: 5, Sort: domain.ProductSortPrice, Desc: true, Attributes: map[string][]string{}}
+
+	mockFavoriteUsecase.On("List", mock.Anything, "user@test.com", q).Return(&domain.ProductPage{Products: []domain.Product{{UUID: "p1", Favorite: true}}}, nil)
+
+	handler := NewFavoriteHandler(echo.New(), mockFavoriteUsecase, nil)
+
+	handler.List(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"favorite\":true")
+}
+
+func TestAddFavoriteProductNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/me/favorites/:productUuid", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("productUuid")
+	c.SetParamValues("p1")
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockFavoriteUsecase := new(mocks.MockFavoriteUseCase)
+
+	mockFavoriteUsecase.On("Add", mock.Anything, "user@test.com", "p1").Return(domain.ErrProductNotFound)
+
+	handler := NewFavoriteHandler(echo.New(), mockFavoriteUsecase, nil)
+
+	handler.Add(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAddFavorite(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/me/favorites/:productUuid", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("productUuid")
+	c.SetParamValues("p1")
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockFavoriteUsecase := new(mocks.MockFavoriteUseCase)
+
+	mockFavoriteUsecase.On("Add", mock.Anything, "user@test.com", "p1").Return(nil)
+
+	handler := NewFavoriteHandler(echo.New(), mockFavoriteUsecase, nil)
+
+	handler.Add(c)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestRemoveFavorite(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/me/favorites/:productUuid", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("productUuid")
+	c.SetParamValues("p1")
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockFavoriteUsecase := new(mocks.MockFavoriteUseCase)
+
+	mockFavoriteUsecase.On("Remove", mock.Anything, "user@test.com", "p1").Return(nil)
+
+	handler := NewFavoriteHandler(echo.New(), mockFavoriteUsecase, nil)
+
+	handler.Remove(c)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
@@ -0,0 +1,285 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_productPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/product/presentation"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type wishlistHandler struct {
+	WishlistUseCase   domain.WishlistUseCase
+	WishlistValidator domain.WishlistValidator
+}
+
+type wishlistRequest struct {
+	Name string `json:"name"`
+}
+
+func NewWishlistHandler(e *echo.Echo, wuc domain.WishlistUseCase, wv domain.WishlistValidator, ts domain.TokenService) *wishlistHandler {
+	handler := &wishlistHandler{
+		WishlistUseCase:   wuc,
+		WishlistValidator: wv,
+	}
+
+	auth := _tokenPresentation.NewAuthMiddleware(ts)
+
+	e.GET("/me/wishlists", handler.List, auth)
+	e.POST("/me/wishlists", handler.Create, auth)
+	e.DELETE("/me/wishlists/:uuid", handler.Delete, auth)
+	e.GET("/me/wishlists/:uuid/products", handler.ListProducts, auth)
+	e.PUT("/me/wishlists/:uuid/products/:productUuid", handler.AddProduct, auth)
+	e.DELETE("/me/wishlists/:uuid/products/:productUuid", handler.RemoveProduct, auth)
+	e.POST("/me/wishlists/:uuid/share", handler.Share, auth)
+	e.DELETE("/me/wishlists/:uuid/share", handler.Unshare, auth)
+	e.GET("/wishlists/shared/:token", handler.GetShared)
+	e.GET("/wishlists/shared/:token/products", handler.ListSharedProducts)
+
+	return handler
+}
+
+func (wh *wishlistHandler) List(c echo.Context) error {
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	wishlists, err := wh.WishlistUseCase.List(c.Request().Context(), tokenInfo.Info)
+
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, "user not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to list the wishlists: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to list the wishlists")
+	}
+
+	return c.JSON(http.StatusOK, map[string][]domain.Wishlist{"wishlists": wishlists})
+}
+
+func (wh *wishlistHandler) Create(c echo.Context) error {
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	var req wishlistRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	ctx := c.Request().Context()
+
+	wishlist := domain.Wishlist{Name: req.Name}
+
+	isValid, message := wh.WishlistValidator.Validate(ctx, &wishlist)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	err := wh.WishlistUseCase.Create(ctx, tokenInfo.Info, &wishlist)
+
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, "user not found")
+	}
+
+	if errors.Is(err, domain.ErrWishlistNameTaken) {
+		return c.JSON(http.StatusConflict, "wishlist name already in use")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to create a wishlist: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to create the wishlist")
+	}
+
+	return c.JSON(http.StatusCreated, wishlist)
+}
+
+func (wh *wishlistHandler) Delete(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	err := wh.WishlistUseCase.Delete(c.Request().Context(), tokenInfo.Info, uuid)
+
+	return wishlistResponse(c, err, "Error trying to delete a wishlist: %s", "failed to delete the wishlist")
+}
+
+func (wh *wishlistHandler) ListProducts(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	q, message := _productPresentation.ParseProductQuery(c)
+
+	if message != "" {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	page, err := wh.WishlistUseCase.ListProducts(c.Request().Context(), tokenInfo.Info, uuid, *q)
+
+	return productsResponse(c, page, err)
+}
+
+func (wh *wishlistHandler) AddProduct(c echo.Context) error {
+	uuid, productUUID := c.Param("uuid"), c.Param("productUuid")
+
+	if uuid == "" || productUUID == "" {
+		return c.JSON(http.StatusBadRequest, "uuid and productUuid params are not valid")
+	}
+
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	err := wh.WishlistUseCase.AddProduct(c.Request().Context(), tokenInfo.Info, uuid, productUUID)
+
+	return wishlistResponse(c, err, "Error trying to add a product to a wishlist: %s", "failed to add the product to the wishlist")
+}
+
+func (wh *wishlistHandler) RemoveProduct(c echo.Context) error {
+	uuid, productUUID := c.Param("uuid"), c.Param("productUuid")
+
+	if uuid == "" || productUUID == "" {
+		return c.JSON(http.StatusBadRequest, "uuid and productUuid params are not valid")
+	}
+
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	err := wh.WishlistUseCase.RemoveProduct(c.Request().Context(), tokenInfo.Info, uuid, productUUID)
+
+	return wishlistResponse(c, err, "Error trying to remove a product from a wishlist: %s", "failed to remove the product from the wishlist")
+}
+
+func (wh *wishlistHandler) Share(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	wishlist, err := wh.WishlistUseCase.Share(c.Request().Context(), tokenInfo.Info, uuid)
+
+	if err != nil {
+		return wishlistResponse(c, err, "Error trying to share a wishlist: %s", "failed to share the wishlist")
+	}
+
+	return c.JSON(http.StatusOK, wishlist)
+}
+
+func (wh *wishlistHandler) Unshare(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	err := wh.WishlistUseCase.Unshare(c.Request().Context(), tokenInfo.Info, uuid)
+
+	return wishlistResponse(c, err, "Error trying to stop sharing a wishlist: %s", "failed to stop sharing the wishlist")
+}
+
+func (wh *wishlistHandler) GetShared(c echo.Context) error {
+	token := c.Param("token")
+
+	if token == "" {
+		return c.JSON(http.StatusBadRequest, "token param is not valid")
+	}
+
+	wishlist, err := wh.WishlistUseCase.GetShared(c.Request().Context(), token)
+
+	if err != nil {
+		return wishlistResponse(c, err, "Error trying to get a shared wishlist: %s", "failed to get the wishlist")
+	}
+
+	return c.JSON(http.StatusOK, wishlist)
+}
+
+func (wh *wishlistHandler) ListSharedProducts(c echo.Context) error {
+	token := c.Param("token")
+
+	if token == "" {
+		return c.JSON(http.StatusBadRequest, "token param is not valid")
+	}
+
+	q, message := _productPresentation.ParseProductQuery(c)
+
+	if message != "" {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	var login string
+
+	if tokenInfo := _tokenPresentation.TokenInfoFromContext(c); tokenInfo != nil {
+		login = tokenInfo.Info
+	}
+
+	page, err := wh.WishlistUseCase.ListSharedProducts(c.Request().Context(), token, *q, login)
+
+	return productsResponse(c, page, err)
+}
+
+// wishlistResponse answers 204 when there is no error.
+func wishlistResponse(c echo.Context, err error, logFormat string, failure string) error {
+	if errors.Is(err, domain.ErrWishlistNotFound) {
+		return c.JSON(http.StatusNotFound, "wishlist not found")
+	}
+
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, "user not found")
+	}
+
+	if err != nil {
+		log.Printf(logFormat, err.Error())
+		return c.JSON(http.StatusInternalServerError, failure)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
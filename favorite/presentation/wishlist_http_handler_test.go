@@ -0,0 +1,216 @@
+package presentation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListWishlists(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/me/wishlists", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockWishlistUsecase := new(mocks.MockWishlistUseCase)
+
+	mockWishlistUsecase.On("List", mock.Anything, "user@test.com").Return([]domain.Wishlist{{UUID: "w3", Name: "Birthday"}}, nil)
+
+	handler := NewWishlistHandler(echo.New(), mockWishlistUsecase, nil, nil)
+
+	handler.List(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Birthday")
+}
+
+func TestCreateWishlistInvalid(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/me/wishlists", strings.NewReader("{\"name\":\"\"}"))
+	assert.NoError(t, err)
+
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockWishlistValidator := new(mocks.MockWishlistValidator)
+
+	mockWishlistValidator.On("Validate", mock.Anything, &domain.Wishlist{}).Return(false, "wishlist's name can not be empty")
+
+	handler := NewWishlistHandler(echo.New(), nil, mockWishlistValidator, nil)
+
+	handler.Create(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestCreateWishlistNameTaken(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/me/wishlists", strings.NewReader("{\"name\":\"Birthday\"}"))
+	assert.NoError(t, err)
+
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockWishlistUsecase := new(mocks.MockWishlistUseCase)
+	mockWishlistValidator := new(mocks.MockWishlistValidator)
+
+	mockWishlistValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockWishlistUsecase.On("Create", mock.Anything, "user@test.com", &domain.Wishlist{Name: "Birthday"}).Return(domain.ErrWishlistNameTaken)
+
+	handler := NewWishlistHandler(echo.New(), mockWishlistUsecase, mockWishlistValidator, nil)
+
+	handler.Create(c)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestCreateWishlist(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/me/wishlists", strings.NewReader("{\"name\":\"Birthday\"}"))
+	assert.NoError(t, err)
+
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockWishlistUsecase := new(mocks.MockWishlistUseCase)
+	mockWishlistValidator := new(mocks.MockWishlistValidator)
+
+	mockWishlistValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockWishlistUsecase.On("Create", mock.Anything, "user@test.com", &domain.Wishlist{Name: "Birthday"}).Return(nil)
+
+	handler := NewWishlistHandler(echo.New(), mockWishlistUsecase, mockWishlistValidator, nil)
+
+	handler.Create(c)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestAddWishlistProductWishlistNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/me/wishlists/:uuid/products/:productUuid", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid", "productUuid")
+	c.SetParamValues("w3", "p1")
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockWishlistUsecase := new(mocks.MockWishlistUseCase)
+
+	mockWishlistUsecase.On("AddProduct", mock.Anything, "user@test.com", "w3", "p1").Return(domain.ErrWishlistNotFound)
+
+	handler := NewWishlistHandler(echo.New(), mockWishlistUsecase, nil, nil)
+
+	handler.AddProduct(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestRemoveWishlistProduct(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/me/wishlists/:uuid/products/:productUuid", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid", "productUuid")
+	c.SetParamValues("w3", "p1")
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockWishlistUsecase := new(mocks.MockWishlistUseCase)
+
+	mockWishlistUsecase.On("RemoveProduct", mock.Anything, "user@test.com", "w3", "p1").Return(nil)
+
+	handler := NewWishlistHandler(echo.New(), mockWishlistUsecase, nil, nil)
+
+	handler.RemoveProduct(c)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestShareWishlist(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/me/wishlists/:uuid/share", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("w3")
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockWishlistUsecase := new(mocks.MockWishlistUseCase)
+
+	mockWishlistUsecase.On("Share", mock.Anything, "user@test.com", "w3").Return(&domain.Wishlist{UUID: "w3", Name: "Birthday", ShareToken: "tk"}, nil)
+
+	handler := NewWishlistHandler(echo.New(), mockWishlistUsecase, nil, nil)
+
+	handler.Share(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"shareToken\":\"tk\"")
+}
+
+func TestGetSharedWishlistNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/wishlists/shared/:token", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("token")
+	c.SetParamValues("tk")
+
+	mockWishlistUsecase := new(mocks.MockWishlistUseCase)
+
+	mockWishlistUsecase.On("GetShared", mock.Anything, "tk").Return(nil, domain.ErrWishlistNotFound)
+
+	handler := NewWishlistHandler(echo.New(), mockWishlistUsecase, nil, nil)
+
+	handler.GetShared(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestListSharedProducts(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/wishlists/shared/:token/products", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("token")
+	c.SetParamValues("tk")
+
+	mockWishlistUsecase := new(mocks.MockWishlistUseCase)
+
+	q := domain.ProductQuery{Limit: 20, Sort: domain.ProductSortName, Attributes: map[string][]string{}}
+
+	mockWishlistUsecase.On("ListSharedProducts", mock.Anything, "tk", q, "").Return(&domain.ProductPage{Products: []domain.Product{}}, nil)
+
+	handler := NewWishlistHandler(echo.New(), mockWishlistUsecase, nil, nil)
+
+	handler.ListSharedProducts(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type favoriteMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewFavoriteMysqlRepository(conn *sql.DB) domain.FavoriteRepository {
+	return &favoriteMysqlRepository{Conn: conn}
+}
+
+func (fmr *favoriteMysqlRepository) Add(ctx context.Context, userID int64, productID int64) error {
+	_, err := fmr.Conn.ExecContext(ctx, `INSERT IGNORE INTO favorite (user_id, product_id) VALUES (?, ?);`, userID, productID)
+
+	return err
+}
+
+func (fmr *favoriteMysqlRepository) Remove(ctx context.Context, userID int64, productID int64) error {
+	_, err := fmr.Conn.ExecContext(ctx, `DELETE FROM favorite WHERE user_id = ? AND product_id = ?;`, userID, productID)
+
+	return err
+}
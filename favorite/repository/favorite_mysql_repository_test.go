@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddFavorite(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO favorite (user_id, product_id) VALUES (?, ?);")).WithArgs(7, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewFavoriteMysqlRepository(db).Add(context.Background(), 7, 1)
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRemoveFavorite(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM favorite WHERE user_id = ? AND product_id = ?;")).WithArgs(7, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = NewFavoriteMysqlRepository(db).Remove(context.Background(), 7, 1)
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/google/uuid"
+)
+
+const wishlistColumns = `id, uuid, user_id, name, share_token, created_at FROM wishlist`
+
+type wishlistMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewWishlistMysqlRepository(conn *sql.DB) domain.WishlistRepository {
+	return &wishlistMysqlRepository{Conn: conn}
+}
+
+func (wmr *wishlistMysqlRepository) GetByUUID(ctx context.Context, uuid string) (*domain.Wishlist, error) {
+	return wmr.getOne(ctx, `SELECT `+wishlistColumns+` WHERE uuid = ?;`, uuid)
+}
+
+func (wmr *wishlistMysqlRepository) GetByShareToken(ctx context.Context, token string) (*domain.Wishlist, error) {
+	return wmr.getOne(ctx, `SELECT `+wishlistColumns+` WHERE share_token = ?;`, token)
+}
+
+func (wmr *wishlistMysqlRepository) getOne(ctx context.Context, query string, args ...interface{}) (*domain.Wishlist, error) {
+	w, err := scanWishlist(wmr.Conn.QueryRowContext(ctx, query, args...))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return w, err
+}
+
+func (wmr *wishlistMysqlRepository) GetByUserID(ctx context.Context, userID int64) ([]domain.Wishlist, error) {
+	rows, err := wmr.Conn.QueryContext(ctx, `SELECT `+wishlistColumns+` WHERE user_id = ? ORDER BY name;`, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []domain.Wishlist{}
+
+	for rows.Next() {
+		w, err := scanWishlist(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *w)
+	}
+
+	return res, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanWishlist(row scanner) (*domain.Wishlist, error) {
+	var w domain.Wishlist
+	var shareToken sql.NullString
+	var createdAt string
+
+	if err := row.Scan(&w.ID, &w.UUID, &w.UserID, &w.Name, &shareToken, &createdAt); err != nil {
+		return nil, err
+	}
+
+	t, err := time.Parse(datetimeLayout, createdAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	w.ShareToken = shareToken.String
+	w.CreatedAt = t
+
+	return &w, nil
+}
+
+func (wmr *wishlistMysqlRepository) Store(ctx context.Context, w *domain.Wishlist) error {
+	w.UUID = uuid.NewString()
+	w.CreatedAt = time.Now().UTC().Truncate(time.Second)
+
+	exec, err := wmr.Conn.ExecContext(ctx, `INSERT INTO wishlist (uuid, user_id, name, created_at) VALUES (?, ?, ?, ?);`, w.UUID, w.UserID, w.Name, w.CreatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	w.ID, err = exec.LastInsertId()
+
+	return err
+}
+
+func (wmr *wishlistMysqlRepository) Delete(ctx context.Context, id int64) error {
+	_, err := wmr.Conn.ExecContext(ctx, `DELETE FROM wishlist WHERE id = ?;`, id)
+
+	return err
+}
+
+func (wmr *wishlistMysqlRepository) AddProduct(ctx context.Context, wishlistID int64, productID int64) error {
+	_, err := wmr.Conn.ExecContext(ctx, `INSERT IGNORE INTO wishlist_product (wishlist_id, product_id) VALUES (?, ?);`, wishlistID, productID)
+
+	return err
+}
+
+func (wmr *wishlistMysqlRepository) RemoveProduct(ctx context.Context, wishlistID int64, productID int64) error {
+	_, err := wmr.Conn.ExecContext(ctx, `DELETE FROM wishlist_product WHERE wishlist_id = ? AND product_id = ?;`, wishlistID, productID)
+
+	return err
+}
+
+// SetShareToken stops sharing the wishlist when the token is empty.
+func (wmr *wishlistMysqlRepository) SetShareToken(ctx context.Context, id int64, token string) error {
+	var shareToken interface{}
+
+	if token != "" {
+		shareToken = token
+	}
+
+	_, err := wmr.Conn.ExecContext(ctx, `UPDATE wishlist SET share_token = ? WHERE id = ?;`, shareToken, id)
+
+	return err
+}
+
+// datetimeLayout is how the connection gives the DATETIME columns, in UTC.
+const datetimeLayout = "2006-01-02 15:04:05"
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var wishlistRowColumns = []string{"id", "uuid", "user_id", "name", "share_token", "created_at"}
+
+func TestGetWishlistByUUID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows(wishlistRowColumns).AddRow(3, "w3", 7, "Birthday", nil, "2026-01-02 10:00:00")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, uuid, user_id, name, share_token, created_at FROM wishlist WHERE uuid = ?;")).WithArgs("w3").WillReturnRows(rows)
+
+	w, err := NewWishlistMysqlRepository(db).GetByUUID(context.Background(), "w3")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Wishlist{ID: 3, UUID: "w3", UserID: 7, Name: "Birthday", CreatedAt: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)}, w)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetWishlistByShareTokenNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, uuid, user_id, name, share_token, created_at FROM wishlist WHERE share_token = ?;")).WithArgs("tk").WillReturnRows(sqlmock.NewRows(wishlistRowColumns))
+
+	w, err := NewWishlistMysqlRepository(db).GetByShareToken(context.Background(), "tk")
+
+	assert.NoError(t, err)
+	assert.Nil(t, w)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetWishlistsByUserID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows(wishlistRowColumns).
+		AddRow(3, "w3", 7, "Birthday", "tk", "2026-01-02 10:00:00").
+		AddRow(4, "w4", 7, "Christmas", nil, "2026-01-03 10:00:00")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, uuid, user_id, name, share_token, created_at FROM wishlist WHERE user_id = ? ORDER BY name;")).WithArgs(7).WillReturnRows(rows)
+
+	wishlists, err := NewWishlistMysqlRepository(db).GetByUserID(context.Background(), 7)
+
+	assert.NoError(t, err)
+	assert.Len(t, wishlists, 2)
+	assert.Equal(t, "tk", wishlists[0].ShareToken)
+	assert.Empty(t, wishlists[1].ShareToken)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreWishlist(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	w := &domain.Wishlist{UserID: 7, Name: "Birthday"}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO wishlist (uuid, user_id, name, created_at) VALUES (?, ?, ?, ?);")).WithArgs(sqlmock.AnyArg(), 7, "Birthday", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(3, 1))
+
+	err = NewWishlistMysqlRepository(db).Store(context.Background(), w)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), w.ID)
+	assert.NotEmpty(t, w.UUID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAddWishlistProduct(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO wishlist_product (wishlist_id, product_id) VALUES (?, ?);")).WithArgs(3, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewWishlistMysqlRepository(db).AddProduct(context.Background(), 3, 1)
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRemoveWishlistProduct(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM wishlist_product WHERE wishlist_id = ? AND product_id = ?;")).WithArgs(3, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewWishlistMysqlRepository(db).RemoveProduct(context.Background(), 3, 1)
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDeleteWishlist(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM wishlist WHERE id = ?;")).WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewWishlistMysqlRepository(db).Delete(context.Background(), 3)
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetShareToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE wishlist SET share_token = ? WHERE id = ?;")).WithArgs("tk", 3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE wishlist SET share_token = ? WHERE id = ?;")).WithArgs(nil, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, NewWishlistMysqlRepository(db).SetShareToken(context.Background(), 3, "tk"))
+	assert.NoError(t, NewWishlistMysqlRepository(db).SetShareToken(context.Background(), 3, ""))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type favoriteUseCase struct {
+	favoriteRepo   domain.FavoriteRepository
+	productRepo    domain.ProductRepository
+	userRepo       domain.UserRepository
+	productUseCase domain.ProductUseCase
+}
+
+func NewFavoriteUseCase(fr domain.FavoriteRepository, pr domain.ProductRepository, ur domain.UserRepository, puc domain.ProductUseCase) domain.FavoriteUseCase {
+	return &favoriteUseCase{favoriteRepo: fr, productRepo: pr, userRepo: ur, productUseCase: puc}
+}
+
+// Add favorites a published product, favoriting it again changes nothing.
+func (fu *favoriteUseCase) Add(ctx context.Context, login string, productUUID string) error {
+	user, err := getUser(ctx, fu.userRepo, login)
+
+	if err != nil {
+		return err
+	}
+
+	product, err := fu.productRepo.GetByUUID(ctx, productUUID)
+
+	if err != nil {
+		return err
+	}
+
+	if product == nil || product.Status != domain.ProductStatusPublished {
+		return domain.ErrProductNotFound
+	}
+
+	return fu.favoriteRepo.Add(ctx, user.ID, product.ID)
+}
+
+func (fu *favoriteUseCase) Remove(ctx context.Context, login string, productUUID string) error {
+	user, err := getUser(ctx, fu.userRepo, login)
+
+	if err != nil {
+		return err
+	}
+
+	product, err := fu.productRepo.GetByUUID(ctx, productUUID)
+
+	if err != nil {
+		return err
+	}
+
+	if product == nil {
+		return domain.ErrProductNotFound
+	}
+
+	return fu.favoriteRepo.Remove(ctx, user.ID, product.ID)
+}
+
+func (fu *favoriteUseCase) List(ctx context.Context, login string, q domain.ProductQuery) (*domain.ProductPage, error) {
+	user, err := getUser(ctx, fu.userRepo, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q.FavoriteOf = user.ID
+
+	return fu.productUseCase.List(ctx, q, login)
+}
+
+func getUser(ctx context.Context, ur domain.UserRepository, login string) (*domain.User, error) {
+	user, err := ur.GetByEmail(ctx, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	return user, nil
+}
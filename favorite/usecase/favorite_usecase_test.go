@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func userRepoWithAna() *mocks.MockUserRepository {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockUserRepo.On("GetByEmail", mock.Anything, "ana@test.com").Return(7, "u7", "ana@test.com", "Ana", "Lima", "", "", "", "", "", "", "", nil)
+	return mockUserRepo
+}
+
+func TestAddFavorite(t *testing.T) {
+	mockFavoriteRepo := new(mocks.MockFavoriteRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "p1").Return(&domain.Product{ID: 1, UUID: "p1", Status: domain.ProductStatusPublished}, nil)
+	mockFavoriteRepo.On("Add", mock.Anything, int64(7), int64(1)).Return(nil)
+
+	err := NewFavoriteUseCase(mockFavoriteRepo, mockProductRepo, userRepoWithAna(), nil).Add(context.Background(), "ana@test.com", "p1")
+
+	assert.NoError(t, err)
+	mockFavoriteRepo.AssertExpectations(t)
+}
+
+func TestAddFavoriteDraftProduct(t *testing.T) {
+	mockFavoriteRepo := new(mocks.MockFavoriteRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "p1").Return(&domain.Product{ID: 1, UUID: "p1", Status: domain.ProductStatusDraft}, nil)
+
+	err := NewFavoriteUseCase(mockFavoriteRepo, mockProductRepo, userRepoWithAna(), nil).Add(context.Background(), "ana@test.com", "p1")
+
+	assert.ErrorIs(t, err, domain.ErrProductNotFound)
+	mockFavoriteRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRemoveFavoriteArchivedProduct(t *testing.T) {
+	mockFavoriteRepo := new(mocks.MockFavoriteRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "p1").Return(&domain.Product{ID: 1, UUID: "p1", Status: domain.ProductStatusArchived}, nil)
+	mockFavoriteRepo.On("Remove", mock.Anything, int64(7), int64(1)).Return(nil)
+
+	err := NewFavoriteUseCase(mockFavoriteRepo, mockProductRepo, userRepoWithAna(), nil).Remove(context.Background(), "ana@test.com", "p1")
+
+	assert.NoError(t, err)
+	mockFavoriteRepo.AssertExpectations(t)
+}
+
+func TestListFavorites(t *testing.T) {
+	mockProductUseCase := new(mocks.MockProductUsecase)
+
+	page := &domain.ProductPage{Products: []domain.Product{{UUID: "p1", Favorite: true}}}
+
+	mockProductUseCase.On("List", mock.Anything, domain.ProductQuery{Limit: 20, FavoriteOf: 7}, "ana@test.com").Return(page, nil)
+
+	res, err := NewFavoriteUseCase(nil, nil, userRepoWithAna(), mockProductUseCase).List(context.Background(), "ana@test.com", domain.ProductQuery{Limit: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, page, res)
+}
+
+func TestListFavoritesUserNotFound(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, "ana@test.com").Return(nil, nil)
+
+	_, err := NewFavoriteUseCase(nil, nil, mockUserRepo, nil).List(context.Background(), "ana@test.com", domain.ProductQuery{Limit: 20})
+
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type wishlistUseCase struct {
+	wishlistRepo   domain.WishlistRepository
+	productRepo    domain.ProductRepository
+	userRepo       domain.UserRepository
+	productUseCase domain.ProductUseCase
+}
+
+func NewWishlistUseCase(wr domain.WishlistRepository, pr domain.ProductRepository, ur domain.UserRepository, puc domain.ProductUseCase) domain.WishlistUseCase {
+	return &wishlistUseCase{wishlistRepo: wr, productRepo: pr, userRepo: ur, productUseCase: puc}
+}
+
+func (wu *wishlistUseCase) List(ctx context.Context, login string) ([]domain.Wishlist, error) {
+	user, err := getUser(ctx, wu.userRepo, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return wu.wishlistRepo.GetByUserID(ctx, user.ID)
+}
+
+func (wu *wishlistUseCase) Create(ctx context.Context, login string, w *domain.Wishlist) error {
+	user, err := getUser(ctx, wu.userRepo, login)
+
+	if err != nil {
+		return err
+	}
+
+	existing, err := wu.wishlistRepo.GetByUserID(ctx, user.ID)
+
+	if err != nil {
+		return err
+	}
+
+	for _, e := range existing {
+		if strings.EqualFold(e.Name, w.Name) {
+			return domain.ErrWishlistNameTaken
+		}
+	}
+
+	w.UserID = user.ID
+	w.ShareToken = ""
+
+	return wu.wishlistRepo.Store(ctx, w)
+}
+
+func (wu *wishlistUseCase) Delete(ctx context.Context, login string, uuid string) error {
+	wishlist, err := wu.owned(ctx, login, uuid)
+
+	if err != nil {
+		return err
+	}
+
+	return wu.wishlistRepo.Delete(ctx, wishlist.ID)
+}
+
+func (wu *wishlistUseCase) AddProduct(ctx context.Context, login string, uuid string, productUUID string) error {
+	wishlist, err := wu.owned(ctx, login, uuid)
+
+	if err != nil {
+		return err
+	}
+
+	product, err := wu.productRepo.GetByUUID(ctx, productUUID)
+
+	if err != nil {
+		return err
+	}
+
+	if product == nil || product.Status != domain.ProductStatusPublished {
+		return domain.ErrProductNotFound
+	}
+
+	return wu.wishlistRepo.AddProduct(ctx, wishlist.ID, product.ID)
+}
+
+func (wu *wishlistUseCase) RemoveProduct(ctx context.Context, login string, uuid string, productUUID string) error {
+	wishlist, err := wu.owned(ctx, login, uuid)
+
+	if err != nil {
+		return err
+	}
+
+	product, err := wu.productRepo.GetByUUID(ctx, productUUID)
+
+	if err != nil {
+		return err
+	}
+
+	if product == nil {
+		return domain.ErrProductNotFound
+	}
+
+	return wu.wishlistRepo.RemoveProduct(ctx, wishlist.ID, product.ID)
+}
+
+func (wu *wishlistUseCase) ListProducts(ctx context.Context, login string, uuid string, q domain.ProductQuery) (*domain.ProductPage, error) {
+	wishlist, err := wu.owned(ctx, login, uuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q.Wishlist = wishlist.ID
+
+	return wu.productUseCase.List(ctx, q, login)
+}
+
+// Share gives the wishlist a token to be seen by anyone who has it, sharing
+// a shared wishlist keeps its token.
+func (wu *wishlistUseCase) Share(ctx context.Context, login string, uuid string) (*domain.Wishlist, error) {
+	wishlist, err := wu.owned(ctx, login, uuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if wishlist.ShareToken != "" {
+		return wishlist, nil
+	}
+
+	token, err := newShareToken()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wu.wishlistRepo.SetShareToken(ctx, wishlist.ID, token); err != nil {
+		return nil, err
+	}
+
+	wishlist.ShareToken = token
+
+	return wishlist, nil
+}
+
+// Unshare invalidates the token, sharing the wishlist again gives a new one.
+func (wu *wishlistUseCase) Unshare(ctx context.Context, login string, uuid string) error {
+	wishlist, err := wu.owned(ctx, login, uuid)
+
+	if err != nil {
+		return err
+	}
+
+	if wishlist.ShareToken == "" {
+		return nil
+	}
+
+	return wu.wishlistRepo.SetShareToken(ctx, wishlist.ID, "")
+}
+
+func (wu *wishlistUseCase) GetShared(ctx context.Context, token string) (*domain.Wishlist, error) {
+	if token == "" {
+		return nil, domain.ErrWishlistNotFound
+	}
+
+	wishlist, err := wu.wishlistRepo.GetByShareToken(ctx, token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if wishlist == nil {
+		return nil, domain.ErrWishlistNotFound
+	}
+
+	return wishlist, nil
+}
+
+func (wu *wishlistUseCase) ListSharedProducts(ctx context.Context, token string, q domain.ProductQuery, login string) (*domain.ProductPage, error) {
+	wishlist, err := wu.GetShared(ctx, token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q.Wishlist = wishlist.ID
+
+	return wu.productUseCase.List(ctx, q, login)
+}
+
+// owned hides the wishlists of the other customers as if they did not exist.
+func (wu *wishlistUseCase) owned(ctx context.Context, login string, uuid string) (*domain.Wishlist, error) {
+	user, err := getUser(ctx, wu.userRepo, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	wishlist, err := wu.wishlistRepo.GetByUUID(ctx, uuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if wishlist == nil || wishlist.UserID != user.ID {
+		return nil, domain.ErrWishlistNotFound
+	}
+
+	return wishlist, nil
+}
+
+func newShareToken() (string, error) {
+	b := make([]byte, 18)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
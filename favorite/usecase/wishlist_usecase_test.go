@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateWishlist(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepository)
+
+	mockWishlistRepo.On("GetByUserID", mock.Anything, int64(7)).Return([]domain.Wishlist{{Name: "Christmas"}}, nil)
+	mockWishlistRepo.On("Store", mock.Anything, mock.Anything).Return(nil)
+
+	w := &domain.Wishlist{Name: "Birthday", ShareToken: "forged"}
+
+	err := NewWishlistUseCase(mockWishlistRepo, nil, userRepoWithAna(), nil).Create(context.Background(), "ana@test.com", w)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Wishlist{UserID: 7, Name: "Birthday"}, w)
+}
+
+func TestCreateWishlistNameTaken(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepository)
+
+	mockWishlistRepo.On("GetByUserID", mock.Anything, int64(7)).Return([]domain.Wishlist{{Name: "Birthday"}}, nil)
+
+	err := NewWishlistUseCase(mockWishlistRepo, nil, userRepoWithAna(), nil).Create(context.Background(), "ana@test.com", &domain.Wishlist{Name: "birthday"})
+
+	assert.ErrorIs(t, err, domain.ErrWishlistNameTaken)
+	mockWishlistRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestDeleteWishlistOfAnotherUser(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepository)
+
+	mockWishlistRepo.On("GetByUUID", mock.Anything, "w3").Return(&domain.Wishlist{ID: 3, UUID: "w3", UserID: 8}, nil)
+
+	err := NewWishlistUseCase(mockWishlistRepo, nil, userRepoWithAna(), nil).Delete(context.Background(), "ana@test.com", "w3")
+
+	assert.ErrorIs(t, err, domain.ErrWishlistNotFound)
+	mockWishlistRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestAddWishlistProduct(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockWishlistRepo.On("GetByUUID", mock.Anything, "w3").Return(&domain.Wishlist{ID: 3, UUID: "w3", UserID: 7}, nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "p1").Return(&domain.Product{ID: 1, UUID: "p1", Status: domain.ProductStatusPublished}, nil)
+	mockWishlistRepo.On("AddProduct", mock.Anything, int64(3), int64(1)).Return(nil)
+
+	err := NewWishlistUseCase(mockWishlistRepo, mockProductRepo, userRepoWithAna(), nil).AddProduct(context.Background(), "ana@test.com", "w3", "p1")
+
+	assert.NoError(t, err)
+	mockWishlistRepo.AssertExpectations(t)
+}
+
+func TestListWishlistProducts(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepository)
+	mockProductUseCase := new(mocks.MockProductUsecase)
+
+	page := &domain.ProductPage{Products: []domain.Product{}}
+
+	mockWishlistRepo.On("GetByUUID", mock.Anything, "w3").Return(&domain.Wishlist{ID: 3, UUID: "w3", UserID: 7}, nil)
+	mockProductUseCase.On("List", mock.Anything, domain.ProductQuery{Limit: 20, Wishlist: 3}, "ana@test.com").Return(page, nil)
+
+	res, err := NewWishlistUseCase(mockWishlistRepo, nil, userRepoWithAna(), mockProductUseCase).ListProducts(context.Background(), "ana@test.com", "w3", domain.ProductQuery{Limit: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, page, res)
+}
+
+func TestShareWishlist(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepository)
+
+	mockWishlistRepo.On("GetByUUID", mock.Anything, "w3").Return(&domain.Wishlist{ID: 3, UUID: "w3", UserID: 7}, nil)
+	mockWishlistRepo.On("SetShareToken", mock.Anything, int64(3), mock.AnythingOfType("string")).Return(nil)
+
+	w, err := NewWishlistUseCase(mockWishlistRepo, nil, userRepoWithAna(), nil).Share(context.Background(), "ana@test.com", "w3")
+
+	assert.NoError(t, err)
+	assert.Len(t, w.ShareToken, 24)
+	mockWishlistRepo.AssertExpectations(t)
+}
+
+func TestShareSharedWishlist(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepository)
+
+	mockWishlistRepo.On("GetByUUID", mock.Anything, "w3").Return(&domain.Wishlist{ID: 3, UUID: "w3", UserID: 7, ShareToken: "tk"}, nil)
+
+	w, err := NewWishlistUseCase(mockWishlistRepo, nil, userRepoWithAna(), nil).Share(context.Background(), "ana@test.com", "w3")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "tk", w.ShareToken)
+	mockWishlistRepo.AssertNotCalled(t, "SetShareToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUnshareWishlist(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepository)
+
+	mockWishlistRepo.On("GetByUUID", mock.Anything, "w3").Return(&domain.Wishlist{ID: 3, UUID: "w3", UserID: 7, ShareToken: "tk"}, nil)
+	mockWishlistRepo.On("SetShareToken", mock.Anything, int64(3), "").Return(nil)
+
+	err := NewWishlistUseCase(mockWishlistRepo, nil, userRepoWithAna(), nil).Unshare(context.Background(), "ana@test.com", "w3")
+
+	assert.NoError(t, err)
+	mockWishlistRepo.AssertExpectations(t)
+}
+
+func TestGetSharedWishlistNotFound(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepository)
+
+	mockWishlistRepo.On("GetByShareToken", mock.Anything, "tk").Return(nil, nil)
+
+	_, err := NewWishlistUseCase(mockWishlistRepo, nil, nil, nil).GetShared(context.Background(), "tk")
+
+	assert.ErrorIs(t, err, domain.ErrWishlistNotFound)
+}
+
+func TestListSharedProducts(t *testing.T) {
+	mockWishlistRepo := new(mocks.MockWishlistRepository)
+	mockProductUseCase := new(mocks.MockProductUsecase)
+
+	page := &domain.ProductPage{Products: []domain.Product{}}
+
+	mockWishlistRepo.On("GetByShareToken", mock.Anything, "tk").Return(&domain.Wishlist{ID: 3, UUID: "w3", UserID: 7, ShareToken: "tk"}, nil)
+	mockProductUseCase.On("List", mock.Anything, domain.ProductQuery{Limit: 20, Wishlist: 3}, "").Return(page, nil)
+
+	res, err := NewWishlistUseCase(mockWishlistRepo, nil, nil, mockProductUseCase).ListSharedProducts(context.Background(), "tk", domain.ProductQuery{Limit: 20}, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, page, res)
+}
@@ -0,0 +1,27 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type wishlistValidator struct{}
+
+func NewWishlistValidator() *wishlistValidator {
+	return &wishlistValidator{}
+}
+
+func (wv *wishlistValidator) Validate(ctx context.Context, w *domain.Wishlist) (domain.IsValid, domain.Message) {
+	if strings.TrimSpace(w.Name) == "" {
+		return false, "wishlist's name can not be empty"
+	}
+
+	if utf8.RuneCountInString(w.Name) > 100 {
+		return false, "wishlist's name can not have more than 100 characters"
+	}
+
+	return true, ""
+}
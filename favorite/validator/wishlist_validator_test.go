@@ -0,0 +1,26 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateWishlistName(t *testing.T) {
+	for _, name := range []string{"", " ", strings.Repeat("a", 101)} {
+		isValid, message := NewWishlistValidator().Validate(context.Background(), &domain.Wishlist{Name: name})
+
+		assert.False(t, bool(isValid))
+		assert.NotEmpty(t, message)
+	}
+}
+
+func TestValidateWishlist(t *testing.T) {
+	isValid, message := NewWishlistValidator().Validate(context.Background(), &domain.Wishlist{Name: "Aniversário"})
+
+	assert.True(t, bool(isValid))
+	assert.Empty(t, message)
+}
@@ -3,6 +3,7 @@ CREATE TABLE gocleanarch.auth (
 	uuid varchar(128) NOT NULL,
 	login varchar(150) NOT NULL,
 	password varchar(150) NOT NULL,
+	role varchar(50) DEFAULT 'customer' NOT NULL,
 	CONSTRAINT auth_id_PK PRIMARY KEY (id),
   CONSTRAINT auth_id_UN UNIQUE KEY (id),
   CONSTRAINT auth_uuid_UN UNIQUE KEY (uuid),
@@ -46,13 +47,675 @@ COLLATE=latin1_swedish_ci;
 CREATE TABLE gocleanarch.product (
 	id INT auto_increment NOT NULL,
 	uuid varchar(128) NOT NULL,
+	slug varchar(150) NOT NULL,
 	name varchar(150) NOT NULL,
 	detail varchar(250) NOT NULL,
+	rate DECIMAL(3,2) DEFAULT 0 NOT NULL,
+	price BIGINT DEFAULT 0 NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL,
+	status varchar(20) DEFAULT 'draft' NOT NULL,
+	version INT DEFAULT 1 NOT NULL,
+	content_version INT DEFAULT 1 NOT NULL,
+	meta_title varchar(150) DEFAULT '' NOT NULL,
+	meta_description varchar(300) DEFAULT '' NOT NULL,
+	canonical_url varchar(250) DEFAULT '' NOT NULL,
 	CONSTRAINT `PRIMARY` PRIMARY KEY (id),
 	CONSTRAINT product_id_UN UNIQUE KEY (id),
 	CONSTRAINT product_uuid_UN UNIQUE KEY (uuid),
-	CONSTRAINT product_name_UN UNIQUE KEY (name)
+	CONSTRAINT product_slug_UN UNIQUE KEY (slug),
+	CONSTRAINT product_name_UN UNIQUE KEY (name),
+	INDEX product_status_IDX (status),
+	INDEX product_rate_IDX (rate, id),
+	INDEX product_price_IDX (price, id),
+	INDEX product_created_at_IDX (created_at, id)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=utf8mb4
+COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE gocleanarch.product_slug (
+	slug varchar(150) NOT NULL,
+	product_id INT NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL,
+	CONSTRAINT product_slug_PK PRIMARY KEY (slug),
+	CONSTRAINT product_slug_product_FK FOREIGN KEY (product_id) REFERENCES gocleanarch.product(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=utf8mb4
+COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE gocleanarch.notification_preference (
+	user_id INT NOT NULL,
+	category varchar(50) NOT NULL,
+	medium varchar(50) NOT NULL,
+	opted_in BOOLEAN NOT NULL,
+	CONSTRAINT notification_preference_PK PRIMARY KEY (user_id, category, medium),
+	CONSTRAINT notification_preference_user_FK FOREIGN KEY (user_id) REFERENCES gocleanarch.users(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.product_picture (
+	id INT auto_increment NOT NULL,
+	product_id INT NOT NULL,
+	path varchar(250) NOT NULL,
+	position INT DEFAULT 0 NOT NULL,
+	CONSTRAINT product_picture_PK PRIMARY KEY (id),
+	CONSTRAINT product_picture_product_FK FOREIGN KEY (product_id) REFERENCES gocleanarch.product(id) ON DELETE CASCADE,
+	INDEX product_picture_product_IDX (product_id, position)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.product_attribute (
+	id INT auto_increment NOT NULL,
+	product_id INT NOT NULL,
+	label varchar(100) NOT NULL,
+	position INT DEFAULT 0 NOT NULL,
+	CONSTRAINT product_attribute_PK PRIMARY KEY (id),
+	CONSTRAINT product_attribute_product_label_UN UNIQUE KEY (product_id, label),
+	INDEX product_attribute_label_IDX (label),
+	CONSTRAINT product_attribute_product_FK FOREIGN KEY (product_id) REFERENCES gocleanarch.product(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=utf8mb4
+COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE gocleanarch.product_attribute_value (
+	id INT auto_increment NOT NULL,
+	attribute_id INT NOT NULL,
+	value varchar(100) NOT NULL,
+	position INT DEFAULT 0 NOT NULL,
+	CONSTRAINT product_attribute_value_PK PRIMARY KEY (id),
+	CONSTRAINT product_attribute_value_UN UNIQUE KEY (attribute_id, value),
+	CONSTRAINT product_attribute_value_attribute_FK FOREIGN KEY (attribute_id) REFERENCES gocleanarch.product_attribute(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=utf8mb4
+COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE gocleanarch.favorite (
+	user_id INT NOT NULL,
+	product_id INT NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL,
+	CONSTRAINT favorite_PK PRIMARY KEY (user_id, product_id),
+	CONSTRAINT favorite_user_FK FOREIGN KEY (user_id) REFERENCES gocleanarch.users(id) ON DELETE CASCADE,
+	CONSTRAINT favorite_product_FK FOREIGN KEY (product_id) REFERENCES gocleanarch.product(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.category (
+	id INT auto_increment NOT NULL,
+	parent_id INT NULL,
+	slug varchar(150) NOT NULL,
+	name varchar(150) NOT NULL,
+	path varchar(500) NOT NULL,
+	depth INT DEFAULT 0 NOT NULL,
+	CONSTRAINT category_PK PRIMARY KEY (id),
+	CONSTRAINT category_slug_UN UNIQUE KEY (slug),
+	CONSTRAINT category_parent_FK FOREIGN KEY (parent_id) REFERENCES gocleanarch.category(id),
+	INDEX category_path_IDX (path)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.product_category (
+	product_id INT NOT NULL,
+	category_id INT NOT NULL,
+	CONSTRAINT product_category_PK PRIMARY KEY (product_id, category_id),
+	CONSTRAINT product_category_product_FK FOREIGN KEY (product_id) REFERENCES gocleanarch.product(id) ON DELETE CASCADE,
+	CONSTRAINT product_category_category_FK FOREIGN KEY (category_id) REFERENCES gocleanarch.category(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.search_query (
+	id INT auto_increment NOT NULL,
+	query varchar(100) NOT NULL,
+	hits INT DEFAULT 0 NOT NULL,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NOT NULL,
+	CONSTRAINT search_query_PK PRIMARY KEY (id),
+	CONSTRAINT search_query_query_UN UNIQUE KEY (query),
+	INDEX search_query_hits_IDX (hits)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.collection (
+	id INT auto_increment NOT NULL,
+	slug varchar(150) NOT NULL,
+	name varchar(150) NOT NULL,
+	kind varchar(20) NOT NULL,
+	rule TEXT NULL,
+	CONSTRAINT collection_PK PRIMARY KEY (id),
+	CONSTRAINT collection_slug_UN UNIQUE KEY (slug)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.collection_product (
+	collection_id INT NOT NULL,
+	product_id INT NOT NULL,
+	position INT DEFAULT 0 NOT NULL,
+	CONSTRAINT collection_product_PK PRIMARY KEY (collection_id, product_id),
+	CONSTRAINT collection_product_collection_FK FOREIGN KEY (collection_id) REFERENCES gocleanarch.collection(id) ON DELETE CASCADE,
+	CONSTRAINT collection_product_product_FK FOREIGN KEY (product_id) REFERENCES gocleanarch.product(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.variant (
+	id INT auto_increment NOT NULL,
+	product_id INT NOT NULL,
+	sku varchar(100) NOT NULL,
+	price BIGINT DEFAULT 0 NOT NULL,
+	barcode varchar(13) NOT NULL,
+	position INT DEFAULT 0 NOT NULL,
+	CONSTRAINT variant_PK PRIMARY KEY (id),
+	CONSTRAINT variant_sku_UN UNIQUE KEY (sku),
+	CONSTRAINT variant_barcode_UN UNIQUE KEY (barcode),
+	CONSTRAINT variant_product_FK FOREIGN KEY (product_id) REFERENCES gocleanarch.product(id) ON DELETE CASCADE,
+	INDEX variant_product_IDX (product_id, position)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.variant_option (
+	variant_id INT NOT NULL,
+	label varchar(100) NOT NULL,
+	value varchar(100) NOT NULL,
+	position INT DEFAULT 0 NOT NULL,
+	CONSTRAINT variant_option_PK PRIMARY KEY (variant_id, label),
+	CONSTRAINT variant_option_variant_FK FOREIGN KEY (variant_id) REFERENCES gocleanarch.variant(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=utf8mb4
+COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE gocleanarch.variant_picture (
+	id INT auto_increment NOT NULL,
+	variant_id INT NOT NULL,
+	path varchar(250) NOT NULL,
+	position INT DEFAULT 0 NOT NULL,
+	CONSTRAINT variant_picture_PK PRIMARY KEY (id),
+	CONSTRAINT variant_picture_variant_FK FOREIGN KEY (variant_id) REFERENCES gocleanarch.variant(id) ON DELETE CASCADE,
+	INDEX variant_picture_variant_IDX (variant_id, position)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.price_list (
+	id INT auto_increment NOT NULL,
+	code varchar(50) NOT NULL,
+	name varchar(150) NOT NULL,
+	currency char(3) NOT NULL,
+	customer_group varchar(50) NOT NULL,
+	CONSTRAINT price_list_PK PRIMARY KEY (id),
+	CONSTRAINT price_list_code_UN UNIQUE KEY (code),
+	INDEX price_list_customer_group_IDX (customer_group)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.customer_group_member (
+	user_id INT NOT NULL,
+	customer_group varchar(50) NOT NULL,
+	CONSTRAINT customer_group_member_PK PRIMARY KEY (user_id, customer_group),
+	CONSTRAINT customer_group_member_user_FK FOREIGN KEY (user_id) REFERENCES gocleanarch.users(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.product_price (
+	id INT auto_increment NOT NULL,
+	product_id INT NOT NULL,
+	variant_sku varchar(100) NULL,
+	price_list_id INT NULL,
+	kind varchar(20) NOT NULL,
+	amount BIGINT NOT NULL,
+	currency char(3) NOT NULL,
+	min_quantity INT DEFAULT 1 NOT NULL,
+	starts_at DATETIME NULL,
+	ends_at DATETIME NULL,
+	CONSTRAINT product_price_PK PRIMARY KEY (id),
+	CONSTRAINT product_price_product_FK FOREIGN KEY (product_id) REFERENCES gocleanarch.product(id) ON DELETE CASCADE,
+	CONSTRAINT product_price_variant_FK FOREIGN KEY (variant_sku) REFERENCES gocleanarch.variant(sku) ON DELETE CASCADE,
+	CONSTRAINT product_price_price_list_FK FOREIGN KEY (price_list_id) REFERENCES gocleanarch.price_list(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.warehouse (
+	id INT auto_increment NOT NULL,
+	code varchar(50) NOT NULL,
+	name varchar(150) NOT NULL,
+	priority INT DEFAULT 0 NOT NULL,
+	CONSTRAINT warehouse_PK PRIMARY KEY (id),
+	CONSTRAINT warehouse_code_UN UNIQUE KEY (code)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.stock_level (
+	sku varchar(100) NOT NULL,
+	warehouse_code varchar(50) NOT NULL,
+	on_hand BIGINT DEFAULT 0 NOT NULL,
+	reserved BIGINT DEFAULT 0 NOT NULL,
+	CONSTRAINT stock_level_PK PRIMARY KEY (sku, warehouse_code),
+	CONSTRAINT stock_level_warehouse_FK FOREIGN KEY (warehouse_code) REFERENCES gocleanarch.warehouse(code)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.stock_reservation (
+	id INT auto_increment NOT NULL,
+	uuid varchar(36) NOT NULL,
+	sku varchar(100) NOT NULL,
+	warehouse_code varchar(50) NOT NULL,
+	quantity BIGINT NOT NULL,
+	reference varchar(150) DEFAULT '' NOT NULL,
+	status varchar(20) NOT NULL,
+	expires_at DATETIME NOT NULL,
+	CONSTRAINT stock_reservation_PK PRIMARY KEY (id),
+	CONSTRAINT stock_reservation_uuid_UN UNIQUE KEY (uuid),
+	CONSTRAINT stock_reservation_warehouse_FK FOREIGN KEY (warehouse_code) REFERENCES gocleanarch.warehouse(code),
+	INDEX stock_reservation_status_IDX (status, expires_at)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.stock_movement (
+	id INT auto_increment NOT NULL,
+	sku varchar(100) NOT NULL,
+	warehouse_code varchar(50) NOT NULL,
+	on_hand_delta BIGINT DEFAULT 0 NOT NULL,
+	reserved_delta BIGINT DEFAULT 0 NOT NULL,
+	reason varchar(20) NOT NULL,
+	reference varchar(150) DEFAULT '' NOT NULL,
+	created_at DATETIME NOT NULL,
+	CONSTRAINT stock_movement_PK PRIMARY KEY (id),
+	INDEX stock_movement_sku_IDX (sku, id)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.stock_threshold (
+	sku varchar(100) NOT NULL,
+	back_in_stock BIGINT DEFAULT 1 NOT NULL,
+	CONSTRAINT stock_threshold_PK PRIMARY KEY (sku)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.stock_alert (
+	id INT auto_increment NOT NULL,
+	sku varchar(100) NOT NULL,
+	user_id INT NOT NULL,
+	CONSTRAINT stock_alert_PK PRIMARY KEY (id),
+	CONSTRAINT stock_alert_UN UNIQUE KEY (sku, user_id),
+	CONSTRAINT stock_alert_variant_FK FOREIGN KEY (sku) REFERENCES gocleanarch.variant(sku) ON DELETE CASCADE,
+	CONSTRAINT stock_alert_user_FK FOREIGN KEY (user_id) REFERENCES gocleanarch.users(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.review (
+	id INT auto_increment NOT NULL,
+	uuid varchar(128) NOT NULL,
+	product_id INT NOT NULL,
+	user_id INT NOT NULL,
+	stars TINYINT NOT NULL,
+	title varchar(150) NOT NULL,
+	body TEXT NOT NULL,
+	verified_purchase BOOLEAN DEFAULT FALSE NOT NULL,
+	status varchar(20) DEFAULT 'pending' NOT NULL,
+	helpful_count INT DEFAULT 0 NOT NULL,
+	created_at DATETIME NOT NULL,
+	CONSTRAINT review_PK PRIMARY KEY (id),
+	CONSTRAINT review_uuid_UN UNIQUE KEY (uuid),
+	CONSTRAINT review_product_user_UN UNIQUE KEY (product_id, user_id),
+	CONSTRAINT review_product_FK FOREIGN KEY (product_id) REFERENCES gocleanarch.product(id) ON DELETE CASCADE,
+	CONSTRAINT review_user_FK FOREIGN KEY (user_id) REFERENCES gocleanarch.users(id) ON DELETE CASCADE,
+	INDEX review_helpful_IDX (product_id, status, helpful_count, id),
+	INDEX review_stars_IDX (product_id, status, stars, id),
+	INDEX review_status_IDX (status, id)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=utf8mb4
+COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE gocleanarch.review_vote (
+	review_id INT NOT NULL,
+	user_id INT NOT NULL,
+	CONSTRAINT review_vote_PK PRIMARY KEY (review_id, user_id),
+	CONSTRAINT review_vote_review_FK FOREIGN KEY (review_id) REFERENCES gocleanarch.review(id) ON DELETE CASCADE,
+	CONSTRAINT review_vote_user_FK FOREIGN KEY (user_id) REFERENCES gocleanarch.users(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=utf8mb4
+COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE gocleanarch.product_rating (
+	product_id INT NOT NULL,
+	rating_count INT DEFAULT 0 NOT NULL,
+	rating_sum INT DEFAULT 0 NOT NULL,
+	stars_1 INT DEFAULT 0 NOT NULL,
+	stars_2 INT DEFAULT 0 NOT NULL,
+	stars_3 INT DEFAULT 0 NOT NULL,
+	stars_4 INT DEFAULT 0 NOT NULL,
+	stars_5 INT DEFAULT 0 NOT NULL,
+	CONSTRAINT product_rating_PK PRIMARY KEY (product_id),
+	CONSTRAINT product_rating_product_FK FOREIGN KEY (product_id) REFERENCES gocleanarch.product(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.wishlist (
+	id INT auto_increment NOT NULL,
+	uuid varchar(128) NOT NULL,
+	user_id INT NOT NULL,
+	name varchar(100) NOT NULL,
+	share_token varchar(64) NULL,
+	created_at DATETIME NOT NULL,
+	CONSTRAINT wishlist_PK PRIMARY KEY (id),
+	CONSTRAINT wishlist_uuid_UN UNIQUE KEY (uuid),
+	CONSTRAINT wishlist_user_name_UN UNIQUE KEY (user_id, name),
+	CONSTRAINT wishlist_share_token_UN UNIQUE KEY (share_token),
+	CONSTRAINT wishlist_user_FK FOREIGN KEY (user_id) REFERENCES gocleanarch.users(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.wishlist_product (
+	wishlist_id INT NOT NULL,
+	product_id INT NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL,
+	CONSTRAINT wishlist_product_PK PRIMARY KEY (wishlist_id, product_id),
+	CONSTRAINT wishlist_product_wishlist_FK FOREIGN KEY (wishlist_id) REFERENCES gocleanarch.wishlist(id) ON DELETE CASCADE,
+	CONSTRAINT wishlist_product_product_FK FOREIGN KEY (product_id) REFERENCES gocleanarch.product(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.import_job (
+	id INT auto_increment NOT NULL,
+	uuid varchar(128) NOT NULL,
+	format varchar(10) NOT NULL,
+	dry_run BOOL DEFAULT 0 NOT NULL,
+	status varchar(20) NOT NULL,
+	processed INT DEFAULT 0 NOT NULL,
+	created_count INT DEFAULT 0 NOT NULL,
+	updated_count INT DEFAULT 0 NOT NULL,
+	failed_count INT DEFAULT 0 NOT NULL,
+	error varchar(500) DEFAULT '' NOT NULL,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL,
+	CONSTRAINT import_job_PK PRIMARY KEY (id),
+	CONSTRAINT import_job_uuid_UN UNIQUE KEY (uuid),
+	INDEX import_job_status_IDX (status, updated_at)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.import_job_error (
+	id INT auto_increment NOT NULL,
+	job_id INT NOT NULL,
+	line INT NOT NULL,
+	message varchar(500) NOT NULL,
+	CONSTRAINT import_job_error_PK PRIMARY KEY (id),
+	CONSTRAINT import_job_error_job_FK FOREIGN KEY (job_id) REFERENCES gocleanarch.import_job(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.product_view (
+	user_id INT NOT NULL,
+	viewed_on DATE NOT NULL,
+	product_id INT NOT NULL,
+	CONSTRAINT product_view_PK PRIMARY KEY (user_id, viewed_on, product_id),
+	CONSTRAINT product_view_user_FK FOREIGN KEY (user_id) REFERENCES gocleanarch.users(id) ON DELETE CASCADE,
+	CONSTRAINT product_view_product_FK FOREIGN KEY (product_id) REFERENCES gocleanarch.product(id) ON DELETE CASCADE,
+	INDEX product_view_viewed_on_IDX (viewed_on)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.product_affinity (
+	product_id INT NOT NULL,
+	related_id INT NOT NULL,
+	kind varchar(20) NOT NULL,
+	score INT NOT NULL,
+	CONSTRAINT product_affinity_PK PRIMARY KEY (product_id, kind, related_id),
+	CONSTRAINT product_affinity_product_FK FOREIGN KEY (product_id) REFERENCES gocleanarch.product(id) ON DELETE CASCADE,
+	CONSTRAINT product_affinity_related_FK FOREIGN KEY (related_id) REFERENCES gocleanarch.product(id) ON DELETE CASCADE,
+	INDEX product_affinity_score_IDX (product_id, kind, score)
 )
 ENGINE=InnoDB
 DEFAULT CHARSET=latin1
 COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.product_related (
+	product_id INT NOT NULL,
+	related_id INT NOT NULL,
+	position INT DEFAULT 0 NOT NULL,
+	CONSTRAINT product_related_PK PRIMARY KEY (product_id, related_id),
+	CONSTRAINT product_related_product_FK FOREIGN KEY (product_id) REFERENCES gocleanarch.product(id) ON DELETE CASCADE,
+	CONSTRAINT product_related_related_FK FOREIGN KEY (related_id) REFERENCES gocleanarch.product(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.product_translation (
+	product_id INT NOT NULL,
+	locale varchar(20) NOT NULL,
+	name varchar(150) DEFAULT '' NOT NULL,
+	detail varchar(250) DEFAULT '' NOT NULL,
+	CONSTRAINT product_translation_PK PRIMARY KEY (product_id, locale),
+	CONSTRAINT product_translation_product_FK FOREIGN KEY (product_id) REFERENCES gocleanarch.product(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=utf8mb4
+COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE gocleanarch.attribute_translation (
+	locale varchar(20) NOT NULL,
+	label varchar(100) NOT NULL,
+	translation varchar(100) NOT NULL,
+	CONSTRAINT attribute_translation_PK PRIMARY KEY (locale, label)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=utf8mb4
+COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE gocleanarch.attribute_value_translation (
+	locale varchar(20) NOT NULL,
+	label varchar(100) NOT NULL,
+	value varchar(100) NOT NULL,
+	translation varchar(100) NOT NULL,
+	CONSTRAINT attribute_value_translation_PK PRIMARY KEY (locale, label, value),
+	CONSTRAINT attribute_value_translation_attribute_FK FOREIGN KEY (locale, label) REFERENCES gocleanarch.attribute_translation(locale, label) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=utf8mb4
+COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE gocleanarch.cart (
+	id INT auto_increment NOT NULL,
+	user_id INT NULL,
+	token varchar(64) NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL,
+	updated_at DATETIME NOT NULL,
+	CONSTRAINT cart_PK PRIMARY KEY (id),
+	CONSTRAINT cart_user_UN UNIQUE KEY (user_id),
+	CONSTRAINT cart_token_UN UNIQUE KEY (token),
+	CONSTRAINT cart_user_FK FOREIGN KEY (user_id) REFERENCES gocleanarch.users(id) ON DELETE CASCADE,
+	INDEX cart_updated_at_IDX (updated_at)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.cart_item (
+	id INT auto_increment NOT NULL,
+	cart_id INT NOT NULL,
+	product_uuid varchar(128) NOT NULL,
+	sku varchar(100) NOT NULL,
+	quantity INT NOT NULL,
+	CONSTRAINT cart_item_PK PRIMARY KEY (id),
+	CONSTRAINT cart_item_cart_sku_UN UNIQUE KEY (cart_id, sku),
+	CONSTRAINT cart_item_cart_FK FOREIGN KEY (cart_id) REFERENCES gocleanarch.cart(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=latin1
+COLLATE=latin1_swedish_ci;
+
+CREATE TABLE gocleanarch.orders (
+	id INT auto_increment NOT NULL,
+	uuid varchar(128) NOT NULL,
+	user_id INT NOT NULL,
+	status varchar(20) NOT NULL,
+	idempotency_key varchar(100) NOT NULL,
+	request_hash char(64) NOT NULL,
+	address_city varchar(100) NOT NULL,
+	address_state varchar(100) NOT NULL,
+	address_neighborhood varchar(150) NOT NULL,
+	address_street varchar(150) NOT NULL,
+	address_number varchar(20) NOT NULL,
+	address_zipcode varchar(100) NOT NULL,
+	currency char(3) NOT NULL,
+	subtotal BIGINT NOT NULL,
+	discount BIGINT NOT NULL,
+	shipping BIGINT NOT NULL,
+	tax BIGINT NOT NULL,
+	total BIGINT NOT NULL,
+	tracking_code varchar(50) DEFAULT '' NOT NULL,
+	created_at DATETIME NOT NULL,
+	expires_at DATETIME NOT NULL,
+	CONSTRAINT orders_PK PRIMARY KEY (id),
+	CONSTRAINT orders_uuid_UN UNIQUE KEY (uuid),
+	CONSTRAINT orders_user_idempotency_key_UN UNIQUE KEY (user_id, idempotency_key),
+	CONSTRAINT orders_user_FK FOREIGN KEY (user_id) REFERENCES gocleanarch.users(id),
+	INDEX orders_user_IDX (user_id, id),
+	INDEX orders_status_IDX (status, id),
+	INDEX orders_expires_IDX (status, expires_at)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=utf8mb4
+COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE gocleanarch.order_item (
+	id INT auto_increment NOT NULL,
+	order_id INT NOT NULL,
+	product_uuid varchar(128) NOT NULL,
+	sku varchar(100) NOT NULL,
+	name varchar(150) NOT NULL,
+	options TEXT NOT NULL,
+	quantity BIGINT NOT NULL,
+	unit_price BIGINT NOT NULL,
+	list_price BIGINT NOT NULL,
+	total BIGINT NOT NULL,
+	reservation_uuid varchar(36) NOT NULL,
+	CONSTRAINT order_item_PK PRIMARY KEY (id),
+	CONSTRAINT order_item_order_FK FOREIGN KEY (order_id) REFERENCES gocleanarch.orders(id) ON DELETE CASCADE
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=utf8mb4
+COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE gocleanarch.order_history (
+	id INT auto_increment NOT NULL,
+	order_id INT NOT NULL,
+	from_status varchar(20) NOT NULL,
+	to_status varchar(20) NOT NULL,
+	actor varchar(150) NOT NULL,
+	note varchar(500) DEFAULT '' NOT NULL,
+	created_at DATETIME NOT NULL,
+	CONSTRAINT order_history_PK PRIMARY KEY (id),
+	CONSTRAINT order_history_order_FK FOREIGN KEY (order_id) REFERENCES gocleanarch.orders(id) ON DELETE CASCADE,
+	INDEX order_history_order_IDX (order_id, id)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=utf8mb4
+COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE gocleanarch.payment_intents (
+	id INT auto_increment NOT NULL,
+	uuid varchar(128) NOT NULL,
+	order_id INT NOT NULL,
+	method varchar(20) NOT NULL,
+	provider varchar(20) NOT NULL,
+	provider_reference varchar(100) DEFAULT '' NOT NULL,
+	status varchar(20) NOT NULL,
+	currency char(3) NOT NULL,
+	amount BIGINT NOT NULL,
+	refunded BIGINT DEFAULT 0 NOT NULL,
+	pix_code TEXT NOT NULL,
+	boleto_line varchar(60) DEFAULT '' NOT NULL,
+	boleto_barcode varchar(44) DEFAULT '' NOT NULL,
+	failure_reason varchar(255) DEFAULT '' NOT NULL,
+	expires_at DATETIME NULL,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL,
+	CONSTRAINT payment_intents_PK PRIMARY KEY (id),
+	CONSTRAINT payment_intents_UN UNIQUE KEY (uuid),
+	CONSTRAINT payment_intents_order_FK FOREIGN KEY (order_id) REFERENCES gocleanarch.orders(id),
+	INDEX payment_intents_order_IDX (order_id, id),
+	INDEX payment_intents_provider_IDX (provider, provider_reference),
+	INDEX payment_intents_status_IDX (status)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=utf8mb4
+COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE gocleanarch.refunds (
+	id INT auto_increment NOT NULL,
+	uuid varchar(128) NOT NULL,
+	order_id INT NOT NULL,
+	payment_id INT NOT NULL,
+	provider_reference varchar(100) DEFAULT '' NOT NULL,
+	status varchar(20) NOT NULL,
+	currency char(3) NOT NULL,
+	amount BIGINT NOT NULL,
+	items TEXT NOT NULL,
+	reason varchar(255) NOT NULL,
+	restocked BOOLEAN DEFAULT FALSE NOT NULL,
+	failure_reason varchar(255) DEFAULT '' NOT NULL,
+	actor varchar(150) NOT NULL,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL,
+	CONSTRAINT refunds_PK PRIMARY KEY (id),
+	CONSTRAINT refunds_UN UNIQUE KEY (uuid),
+	CONSTRAINT refunds_order_FK FOREIGN KEY (order_id) REFERENCES gocleanarch.orders(id),
+	CONSTRAINT refunds_payment_FK FOREIGN KEY (payment_id) REFERENCES gocleanarch.payment_intents(id),
+	INDEX refunds_order_IDX (order_id, id)
+)
+ENGINE=InnoDB
+DEFAULT CHARSET=utf8mb4
+COLLATE=utf8mb4_unicode_ci;
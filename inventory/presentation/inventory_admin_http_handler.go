@@ -0,0 +1,202 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type inventoryAdminHandler struct {
+	InventoryUseCase       domain.InventoryUseCase
+	WarehouseValidator     domain.WarehouseValidator
+	StockMovementValidator domain.StockMovementValidator
+}
+
+type movementRequest struct {
+	Warehouse string                `json:"warehouse"`
+	Quantity  int64                 `json:"quantity"`
+	Reason    domain.MovementReason `json:"reason"`
+	Reference string                `json:"reference"`
+}
+
+type thresholdRequest struct {
+	BackInStock int64 `json:"backInStock"`
+}
+
+func NewInventoryAdminHandler(e *echo.Echo, iuc domain.InventoryUseCase, wv domain.WarehouseValidator, smv domain.StockMovementValidator, ts domain.TokenService) *inventoryAdminHandler {
+	handler := &inventoryAdminHandler{
+		InventoryUseCase:       iuc,
+		WarehouseValidator:     wv,
+		StockMovementValidator: smv,
+	}
+
+	admin := _tokenPresentation.NewAdminMiddleware(ts)
+
+	e.GET("/admin/inventory/:sku", handler.GetStock, admin)
+	e.POST("/admin/inventory/:sku/movements", handler.AddMovement, admin)
+	e.GET("/admin/inventory/:sku/movements", handler.GetMovements, admin)
+	e.PUT("/admin/inventory/:sku/threshold", handler.SetThreshold, admin)
+	e.POST("/admin/warehouses", handler.CreateWarehouse, admin)
+
+	return handler
+}
+
+func (iah *inventoryAdminHandler) GetStock(c echo.Context) error {
+	sku := c.Param("sku")
+
+	if sku == "" {
+		return c.JSON(http.StatusBadRequest, "sku param is not valid")
+	}
+
+	stock, err := iah.InventoryUseCase.GetStock(c.Request().Context(), sku)
+
+	if err != nil {
+		log.Printf("Error trying to get the stock of a sku: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the stock")
+	}
+
+	if stock == nil {
+		return c.JSON(http.StatusNotFound, "variant not found")
+	}
+
+	return c.JSON(http.StatusOK, stock)
+}
+
+func (iah *inventoryAdminHandler) AddMovement(c echo.Context) error {
+	sku := c.Param("sku")
+
+	if sku == "" {
+		return c.JSON(http.StatusBadRequest, "sku param is not valid")
+	}
+
+	var req movementRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	ctx := c.Request().Context()
+
+	movement := domain.StockMovement{SKU: sku, Warehouse: req.Warehouse, OnHandDelta: req.Quantity, Reason: req.Reason, Reference: req.Reference}
+
+	isValid, message := iah.StockMovementValidator.Validate(ctx, &movement)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	err := iah.InventoryUseCase.AddMovement(ctx, &movement)
+
+	if errors.Is(err, domain.ErrVariantNotFound) {
+		return c.JSON(http.StatusNotFound, "variant not found")
+	}
+
+	if errors.Is(err, domain.ErrWarehouseNotFound) {
+		return c.JSON(http.StatusBadRequest, "warehouse not found")
+	}
+
+	if errors.Is(err, domain.ErrInsufficientStock) {
+		return c.JSON(http.StatusConflict, "the quantity on hand can not be lower than the reserved one")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to add a stock movement: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to add the stock movement")
+	}
+
+	return c.JSON(http.StatusCreated, movement)
+}
+
+func (iah *inventoryAdminHandler) GetMovements(c echo.Context) error {
+	sku := c.Param("sku")
+
+	if sku == "" {
+		return c.JSON(http.StatusBadRequest, "sku param is not valid")
+	}
+
+	limit := 50
+
+	if l := c.QueryParam("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+
+		if err != nil || parsed < 1 || parsed > 500 {
+			return c.JSON(http.StatusBadRequest, "limit param must be a number between 1 and 500")
+		}
+
+		limit = parsed
+	}
+
+	movements, err := iah.InventoryUseCase.GetMovements(c.Request().Context(), sku, limit)
+
+	if err != nil {
+		log.Printf("Error trying to get the stock movements of a sku: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the stock movements")
+	}
+
+	return c.JSON(http.StatusOK, map[string][]domain.StockMovement{"movements": movements})
+}
+
+func (iah *inventoryAdminHandler) SetThreshold(c echo.Context) error {
+	sku := c.Param("sku")
+
+	if sku == "" {
+		return c.JSON(http.StatusBadRequest, "sku param is not valid")
+	}
+
+	var req thresholdRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	if req.BackInStock < 1 {
+		return c.JSON(http.StatusBadRequest, "back in stock threshold must be at least 1")
+	}
+
+	err := iah.InventoryUseCase.SetThreshold(c.Request().Context(), sku, req.BackInStock)
+
+	if errors.Is(err, domain.ErrVariantNotFound) {
+		return c.JSON(http.StatusNotFound, "variant not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to set the back in stock threshold: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to set the back in stock threshold")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (iah *inventoryAdminHandler) CreateWarehouse(c echo.Context) error {
+	var w domain.Warehouse
+
+	if err := c.Bind(&w); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	ctx := c.Request().Context()
+
+	isValid, message := iah.WarehouseValidator.Validate(ctx, &w)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	err := iah.InventoryUseCase.CreateWarehouse(ctx, &w)
+
+	if errors.Is(err, domain.ErrWarehouseCodeTaken) {
+		return c.JSON(http.StatusConflict, "warehouse code already in use")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to create a warehouse: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to create the warehouse")
+	}
+
+	return c.JSON(http.StatusCreated, w)
+}
@@ -0,0 +1,233 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetStockNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin/inventory/:sku", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("sku")
+	c.SetParamValues("P7-M")
+
+	mockInventoryUsecase := new(mocks.MockInventoryUseCase)
+
+	mockInventoryUsecase.On("GetStock", mock.Anything, "P7-M").Return(nil, nil)
+
+	handler := NewInventoryAdminHandler(echo.New(), mockInventoryUsecase, nil, nil, nil)
+
+	handler.GetStock(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestGetStock(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin/inventory/:sku", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("sku")
+	c.SetParamValues("P7-M")
+
+	mockInventoryUsecase := new(mocks.MockInventoryUseCase)
+
+	mockInventoryUsecase.On("GetStock", mock.Anything, "P7-M").Return(&domain.Stock{SKU: "P7-M", Levels: []domain.StockLevel{}, Available: 0, BackInStockThreshold: 1}, nil)
+
+	handler := NewInventoryAdminHandler(echo.New(), mockInventoryUsecase, nil, nil, nil)
+
+	handler.GetStock(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"sku\":\"P7-M\",\"levels\":[],\"available\":0,\"backInStockThreshold\":1}\n", rec.Body.String())
+}
+
+func TestAddMovementInvalid(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/inventory/:sku/movements", strings.NewReader("{\"warehouse\":\"sp\",\"quantity\":-2,\"reason\":\"sale\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("sku")
+	c.SetParamValues("P7-M")
+
+	mockStockMovementValidator := new(mocks.MockStockMovementValidator)
+
+	mockStockMovementValidator.On("Validate", mock.Anything, &domain.StockMovement{SKU: "P7-M", Warehouse: "sp", OnHandDelta: -2, Reason: domain.MovementReasonSale}).Return(false, "error message")
+
+	handler := NewInventoryAdminHandler(echo.New(), nil, nil, mockStockMovementValidator, nil)
+
+	handler.AddMovement(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "\"error message\"\n", rec.Body.String())
+}
+
+func TestAddMovementBelowReserved(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/inventory/:sku/movements", strings.NewReader("{\"warehouse\":\"sp\",\"quantity\":-2,\"reason\":\"adjustment\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("sku")
+	c.SetParamValues("P7-M")
+
+	mockInventoryUsecase := new(mocks.MockInventoryUseCase)
+	mockStockMovementValidator := new(mocks.MockStockMovementValidator)
+
+	mockStockMovementValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockInventoryUsecase.On("AddMovement", mock.Anything, mock.Anything).Return(domain.ErrInsufficientStock)
+
+	handler := NewInventoryAdminHandler(echo.New(), mockInventoryUsecase, nil, mockStockMovementValidator, nil)
+
+	handler.AddMovement(c)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestAddMovement(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/inventory/:sku/movements", strings.NewReader("{\"warehouse\":\"sp\",\"quantity\":10,\"reason\":\"receipt\",\"reference\":\"NF 123\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("sku")
+	c.SetParamValues("P7-M")
+
+	mockInventoryUsecase := new(mocks.MockInventoryUseCase)
+	mockStockMovementValidator := new(mocks.MockStockMovementValidator)
+
+	movement := &domain.StockMovement{SKU: "P7-M", Warehouse: "sp", OnHandDelta: 10, Reason: domain.MovementReasonReceipt, Reference: "NF 123"}
+
+	mockStockMovementValidator.On("Validate", mock.Anything, movement).Return(true, "")
+	mockInventoryUsecase.On("AddMovement", mock.Anything, movement).Return(nil)
+
+	handler := NewInventoryAdminHandler(echo.New(), mockInventoryUsecase, nil, mockStockMovementValidator, nil)
+
+	handler.AddMovement(c)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestGetMovementsInvalidLimit(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin/inventory/:sku/movements?limit=0", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("sku")
+	c.SetParamValues("P7-M")
+
+	handler := NewInventoryAdminHandler(echo.New(), nil, nil, nil, nil)
+
+	handler.GetMovements(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGetMovementsError(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin/inventory/:sku/movements", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("sku")
+	c.SetParamValues("P7-M")
+
+	mockInventoryUsecase := new(mocks.MockInventoryUseCase)
+
+	mockInventoryUsecase.On("GetMovements", mock.Anything, "P7-M", 50).Return(nil, errors.New("error message"))
+
+	handler := NewInventoryAdminHandler(echo.New(), mockInventoryUsecase, nil, nil, nil)
+
+	handler.GetMovements(c)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestSetThresholdInvalid(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/inventory/:sku/threshold", strings.NewReader("{\"backInStock\":0}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("sku")
+	c.SetParamValues("P7-M")
+
+	handler := NewInventoryAdminHandler(echo.New(), nil, nil, nil, nil)
+
+	handler.SetThreshold(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSetThreshold(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/inventory/:sku/threshold", strings.NewReader("{\"backInStock\":5}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("sku")
+	c.SetParamValues("P7-M")
+
+	mockInventoryUsecase := new(mocks.MockInventoryUseCase)
+
+	mockInventoryUsecase.On("SetThreshold", mock.Anything, "P7-M", int64(5)).Return(nil)
+
+	handler := NewInventoryAdminHandler(echo.New(), mockInventoryUsecase, nil, nil, nil)
+
+	handler.SetThreshold(c)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestCreateWarehouseCodeTaken(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/warehouses", strings.NewReader("{\"code\":\"sp\",\"name\":\"São Paulo\",\"priority\":10}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockInventoryUsecase := new(mocks.MockInventoryUseCase)
+	mockWarehouseValidator := new(mocks.MockWarehouseValidator)
+
+	warehouse := &domain.Warehouse{Code: "sp", Name: "São Paulo", Priority: 10}
+
+	mockWarehouseValidator.On("Validate", mock.Anything, warehouse).Return(true, "")
+	mockInventoryUsecase.On("CreateWarehouse", mock.Anything, warehouse).Return(domain.ErrWarehouseCodeTaken)
+
+	handler := NewInventoryAdminHandler(echo.New(), mockInventoryUsecase, mockWarehouseValidator, nil, nil)
+
+	handler.CreateWarehouse(c)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
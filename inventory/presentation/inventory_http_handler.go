@@ -0,0 +1,58 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type inventoryHandler struct {
+	InventoryUseCase domain.InventoryUseCase
+}
+
+func NewInventoryHandler(e *echo.Echo, iuc domain.InventoryUseCase, ts domain.TokenService) *inventoryHandler {
+	handler := &inventoryHandler{
+		InventoryUseCase: iuc,
+	}
+
+	auth := _tokenPresentation.NewAuthMiddleware(ts)
+
+	e.POST("/inventory/:sku/alerts", handler.SubscribeAlert, auth)
+
+	return handler
+}
+
+func (ih *inventoryHandler) SubscribeAlert(c echo.Context) error {
+	sku := c.Param("sku")
+
+	if sku == "" {
+		return c.JSON(http.StatusBadRequest, "sku param is not valid")
+	}
+
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	err := ih.InventoryUseCase.SubscribeAlert(c.Request().Context(), sku, tokenInfo.Info)
+
+	if errors.Is(err, domain.ErrVariantNotFound) {
+		return c.JSON(http.StatusNotFound, "variant not found")
+	}
+
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, "user not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to subscribe to a back in stock alert: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to subscribe to the alert")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
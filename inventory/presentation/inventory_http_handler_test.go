@@ -0,0 +1,75 @@
+package presentation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSubscribeAlertUnauthorized(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/inventory/:sku/alerts", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("sku")
+	c.SetParamValues("P7-M")
+
+	handler := NewInventoryHandler(echo.New(), nil, nil)
+
+	handler.SubscribeAlert(c)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestSubscribeAlertVariantNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/inventory/:sku/alerts", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("sku")
+	c.SetParamValues("P7-M")
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockInventoryUsecase := new(mocks.MockInventoryUseCase)
+
+	mockInventoryUsecase.On("SubscribeAlert", mock.Anything, "P7-M", "user@test.com").Return(domain.ErrVariantNotFound)
+
+	handler := NewInventoryHandler(echo.New(), mockInventoryUsecase, nil)
+
+	handler.SubscribeAlert(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestSubscribeAlert(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/inventory/:sku/alerts", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("sku")
+	c.SetParamValues("P7-M")
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockInventoryUsecase := new(mocks.MockInventoryUseCase)
+
+	mockInventoryUsecase.On("SubscribeAlert", mock.Anything, "P7-M", "user@test.com").Return(nil)
+
+	handler := NewInventoryHandler(echo.New(), mockInventoryUsecase, nil)
+
+	handler.SubscribeAlert(c)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
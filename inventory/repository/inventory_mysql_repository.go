@@ -0,0 +1,545 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/google/uuid"
+)
+
+// inventoryMysqlRepository touches the products once the quantities
+// available of their skus change, the stock is shown with them.
+type inventoryMysqlRepository struct {
+	Conn           *sql.DB
+	ProductToucher domain.ProductToucher
+}
+
+func NewInventoryMysqlRepository(conn *sql.DB, pt domain.ProductToucher) domain.InventoryRepository {
+	return &inventoryMysqlRepository{Conn: conn, ProductToucher: pt}
+}
+
+func (imr *inventoryMysqlRepository) GetWarehouse(ctx context.Context, code string) (*domain.Warehouse, error) {
+	row := imr.Conn.QueryRowContext(ctx, `SELECT code, name, priority FROM warehouse WHERE code = ?;`, code)
+
+	var res domain.Warehouse
+
+	if err := row.Scan(&res.Code, &res.Name, &res.Priority); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (imr *inventoryMysqlRepository) StoreWarehouse(ctx context.Context, w *domain.Warehouse) error {
+	_, err := imr.Conn.ExecContext(ctx, `INSERT INTO warehouse (code, name, priority) VALUES (?, ?, ?);`, w.Code, w.Name, w.Priority)
+
+	return err
+}
+
+func (imr *inventoryMysqlRepository) GetLevels(ctx context.Context, sku string) ([]domain.StockLevel, error) {
+	query := `SELECT sl.sku, sl.warehouse_code, sl.on_hand, sl.reserved FROM stock_level sl JOIN warehouse w ON w.code = sl.warehouse_code WHERE sl.sku = ? ORDER BY w.priority DESC, sl.warehouse_code;`
+
+	rows, err := imr.Conn.QueryContext(ctx, query, sku)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []domain.StockLevel{}
+
+	for rows.Next() {
+		var l domain.StockLevel
+
+		if err := rows.Scan(&l.SKU, &l.Warehouse, &l.OnHand, &l.Reserved); err != nil {
+			return nil, err
+		}
+
+		l.Available = l.OnHand - l.Reserved
+		res = append(res, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Adjust changes the quantity on hand, it never goes below the reserved one.
+func (imr *inventoryMysqlRepository) Adjust(ctx context.Context, m *domain.StockMovement) error {
+	tx, err := imr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `INSERT IGNORE INTO stock_level (sku, warehouse_code, on_hand, reserved) VALUES (?, ?, 0, 0);`, m.SKU, m.Warehouse); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	exec, err := tx.ExecContext(ctx, `UPDATE stock_level SET on_hand = on_hand + ? WHERE sku = ? AND warehouse_code = ? AND on_hand + ? >= reserved;`, m.OnHandDelta, m.SKU, m.Warehouse, m.OnHandDelta)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if affect != 1 {
+		tx.Rollback()
+		return domain.ErrInsufficientStock
+	}
+
+	if err := storeMovement(ctx, tx, m); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	imr.touch(ctx, m.SKU)
+
+	return nil
+}
+
+// Reserve takes the quantity from the given warehouse or, without one, from
+// the first warehouse by priority that has it all available.
+func (imr *inventoryMysqlRepository) Reserve(ctx context.Context, r *domain.Reservation) error {
+	tx, err := imr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if err := reserveTx(ctx, tx, r); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	imr.touch(ctx, r.SKU)
+
+	return nil
+}
+
+func (imr *inventoryMysqlRepository) ReserveTx(ctx context.Context, tx domain.Tx, r *domain.Reservation) error {
+	sqlTx, ok := tx.(*sql.Tx)
+
+	if !ok {
+		return domain.ErrUnsupportedTx
+	}
+
+	return reserveTx(ctx, sqlTx, r)
+}
+
+func reserveTx(ctx context.Context, tx *sql.Tx, r *domain.Reservation) error {
+	candidates := []string{r.Warehouse}
+
+	if r.Warehouse == "" {
+		var err error
+
+		if candidates, err = availableWarehouses(ctx, tx, r.SKU, r.Quantity); err != nil {
+			return err
+		}
+	}
+
+	r.Warehouse = ""
+
+	for _, code := range candidates {
+		// the condition is checked again under the row lock, a concurrent
+		// reservation may have taken the stock after the select
+		exec, err := tx.ExecContext(ctx, `UPDATE stock_level SET reserved = reserved + ? WHERE sku = ? AND warehouse_code = ? AND on_hand - reserved >= ?;`, r.Quantity, r.SKU, code, r.Quantity)
+
+		if err != nil {
+			return err
+		}
+
+		affect, err := exec.RowsAffected()
+
+		if err != nil {
+			return err
+		}
+
+		if affect == 1 {
+			r.Warehouse = code
+			break
+		}
+	}
+
+	if r.Warehouse == "" {
+		return domain.ErrInsufficientStock
+	}
+
+	r.UUID = uuid.NewString()
+	r.Status = domain.ReservationStatusActive
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO stock_reservation (uuid, sku, warehouse_code, quantity, reference, status, expires_at) VALUES (?, ?, ?, ?, ?, ?, ?);`, r.UUID, r.SKU, r.Warehouse, r.Quantity, r.Reference, r.Status, r.ExpiresAt.UTC()); err != nil {
+		return err
+	}
+
+	m := &domain.StockMovement{SKU: r.SKU, Warehouse: r.Warehouse, ReservedDelta: r.Quantity, Reason: domain.MovementReasonReservation, Reference: r.Reference}
+
+	return storeMovement(ctx, tx, m)
+}
+
+func availableWarehouses(ctx context.Context, tx *sql.Tx, sku string, quantity int64) ([]string, error) {
+	query := `SELECT sl.warehouse_code FROM stock_level sl JOIN warehouse w ON w.code = sl.warehouse_code WHERE sl.sku = ? AND sl.on_hand - sl.reserved >= ? ORDER BY w.priority DESC, sl.warehouse_code;`
+
+	rows, err := tx.QueryContext(ctx, query, sku, quantity)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []string{}
+
+	for rows.Next() {
+		var code string
+
+		if err := rows.Scan(&code); err != nil {
+			return nil, err
+		}
+
+		res = append(res, code)
+	}
+
+	return res, rows.Err()
+}
+
+func (imr *inventoryMysqlRepository) GetReservation(ctx context.Context, reservationUUID string) (*domain.Reservation, error) {
+	row := imr.Conn.QueryRowContext(ctx, `SELECT uuid, sku, warehouse_code, quantity, reference, status, expires_at FROM stock_reservation WHERE uuid = ?;`, reservationUUID)
+
+	var res domain.Reservation
+	var expiresAt string
+
+	if err := row.Scan(&res.UUID, &res.SKU, &res.Warehouse, &res.Quantity, &res.Reference, &res.Status, &expiresAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	t, err := time.Parse(datetimeLayout, expiresAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.ExpiresAt = t
+
+	return &res, nil
+}
+
+func (imr *inventoryMysqlRepository) Release(ctx context.Context, reservationUUID string, reason domain.MovementReason) error {
+	return imr.close(ctx, reservationUUID, domain.ReservationStatusReleased, reason)
+}
+
+func (imr *inventoryMysqlRepository) Commit(ctx context.Context, reservationUUID string) error {
+	return imr.close(ctx, reservationUUID, domain.ReservationStatusCommitted, domain.MovementReasonSale)
+}
+
+func (imr *inventoryMysqlRepository) close(ctx context.Context, reservationUUID string, status domain.ReservationStatus, reason domain.MovementReason) error {
+	tx, err := imr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	sku, err := closeTx(ctx, tx, reservationUUID, status, reason)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	// a commit takes from the quantity on hand what was reserved already,
+	// only a release changes the quantity available
+	if status == domain.ReservationStatusReleased {
+		imr.touch(ctx, sku)
+	}
+
+	return nil
+}
+
+func (imr *inventoryMysqlRepository) ReleaseTx(ctx context.Context, tx domain.Tx, reservationUUID string, reason domain.MovementReason) error {
+	sqlTx, ok := tx.(*sql.Tx)
+
+	if !ok {
+		return domain.ErrUnsupportedTx
+	}
+
+	_, err := closeTx(ctx, sqlTx, reservationUUID, domain.ReservationStatusReleased, reason)
+
+	return err
+}
+
+func (imr *inventoryMysqlRepository) CommitTx(ctx context.Context, tx domain.Tx, reservationUUID string) error {
+	sqlTx, ok := tx.(*sql.Tx)
+
+	if !ok {
+		return domain.ErrUnsupportedTx
+	}
+
+	_, err := closeTx(ctx, sqlTx, reservationUUID, domain.ReservationStatusCommitted, domain.MovementReasonSale)
+
+	return err
+}
+
+// closeTx ends an active reservation, giving the quantity back when it is
+// released or taking it from the quantity on hand when it is committed. It
+// gives the sku of the reservation.
+func closeTx(ctx context.Context, tx *sql.Tx, reservationUUID string, status domain.ReservationStatus, reason domain.MovementReason) (string, error) {
+	row := tx.QueryRowContext(ctx, `SELECT sku, warehouse_code, quantity, reference FROM stock_reservation WHERE uuid = ? AND status = ? FOR UPDATE;`, reservationUUID, domain.ReservationStatusActive)
+
+	m := &domain.StockMovement{Reason: reason}
+
+	if err := row.Scan(&m.SKU, &m.Warehouse, &m.ReservedDelta, &m.Reference); err != nil {
+		if err == sql.ErrNoRows {
+			return "", domain.ErrReservationNotFound
+		}
+
+		return "", err
+	}
+
+	m.ReservedDelta = -m.ReservedDelta
+
+	if status == domain.ReservationStatusCommitted {
+		m.OnHandDelta = m.ReservedDelta
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE stock_level SET on_hand = on_hand + ?, reserved = reserved + ? WHERE sku = ? AND warehouse_code = ?;`, m.OnHandDelta, m.ReservedDelta, m.SKU, m.Warehouse); err != nil {
+		return "", err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE stock_reservation SET status = ? WHERE uuid = ?;`, status, reservationUUID); err != nil {
+		return "", err
+	}
+
+	return m.SKU, storeMovement(ctx, tx, m)
+}
+
+// TouchProducts touches the products of the skus, the writes made within
+// the transaction of another repository are touched by it once committed.
+func (imr *inventoryMysqlRepository) TouchProducts(ctx context.Context, skus []string) error {
+	if len(skus) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, len(skus))
+
+	for i, sku := range skus {
+		args[i] = sku
+	}
+
+	query := `SELECT DISTINCT product_id FROM variant WHERE sku IN (` + placeholders(len(skus)) + `);`
+
+	rows, err := imr.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	productIDs := []int64{}
+
+	for rows.Next() {
+		var id int64
+
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+
+		productIDs = append(productIDs, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return imr.ProductToucher.Touch(ctx, productIDs)
+}
+
+// touch follows a write already committed, so an error is only logged.
+func (imr *inventoryMysqlRepository) touch(ctx context.Context, sku string) {
+	if err := imr.TouchProducts(ctx, []string{sku}); err != nil {
+		log.Printf("Error trying to touch the product of the sku %s: %s", sku, err.Error())
+	}
+}
+
+func (imr *inventoryMysqlRepository) GetExpiredReservations(ctx context.Context, now time.Time, limit int) ([]domain.Reservation, error) {
+	query := `SELECT uuid, sku, warehouse_code, quantity, reference FROM stock_reservation WHERE status = ? AND expires_at <= ? ORDER BY expires_at LIMIT ?;`
+
+	rows, err := imr.Conn.QueryContext(ctx, query, domain.ReservationStatusActive, now.UTC(), limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []domain.Reservation{}
+
+	for rows.Next() {
+		r := domain.Reservation{Status: domain.ReservationStatusActive}
+
+		if err := rows.Scan(&r.UUID, &r.SKU, &r.Warehouse, &r.Quantity, &r.Reference); err != nil {
+			return nil, err
+		}
+
+		res = append(res, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (imr *inventoryMysqlRepository) GetMovements(ctx context.Context, sku string, limit int) ([]domain.StockMovement, error) {
+	query := `SELECT id, sku, warehouse_code, on_hand_delta, reserved_delta, reason, reference, created_at FROM stock_movement WHERE sku = ? ORDER BY id DESC LIMIT ?;`
+
+	rows, err := imr.Conn.QueryContext(ctx, query, sku, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []domain.StockMovement{}
+
+	for rows.Next() {
+		var m domain.StockMovement
+		var createdAt string
+
+		if err := rows.Scan(&m.ID, &m.SKU, &m.Warehouse, &m.OnHandDelta, &m.ReservedDelta, &m.Reason, &m.Reference, &createdAt); err != nil {
+			return nil, err
+		}
+
+		if m.CreatedAt, err = time.Parse(datetimeLayout, createdAt); err != nil {
+			return nil, err
+		}
+
+		res = append(res, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (imr *inventoryMysqlRepository) GetThreshold(ctx context.Context, sku string) (int64, error) {
+	var threshold int64
+
+	err := imr.Conn.QueryRowContext(ctx, `SELECT back_in_stock FROM stock_threshold WHERE sku = ?;`, sku).Scan(&threshold)
+
+	if err == sql.ErrNoRows {
+		return 1, nil
+	}
+
+	return threshold, err
+}
+
+func (imr *inventoryMysqlRepository) SetThreshold(ctx context.Context, sku string, threshold int64) error {
+	_, err := imr.Conn.ExecContext(ctx, `INSERT INTO stock_threshold (sku, back_in_stock) VALUES (?, ?) ON DUPLICATE KEY UPDATE back_in_stock = VALUES(back_in_stock);`, sku, threshold)
+
+	return err
+}
+
+func (imr *inventoryMysqlRepository) StoreAlert(ctx context.Context, sku string, userID int64) error {
+	_, err := imr.Conn.ExecContext(ctx, `INSERT IGNORE INTO stock_alert (sku, user_id) VALUES (?, ?);`, sku, userID)
+
+	return err
+}
+
+// GetDueAlerts gives the alerts of the skus whose available quantity reached
+// their back in stock threshold, 1 when they have none.
+func (imr *inventoryMysqlRepository) GetDueAlerts(ctx context.Context, limit int) ([]domain.StockAlert, error) {
+	query := `SELECT a.id, a.sku, a.user_id FROM stock_alert a LEFT JOIN stock_threshold t ON t.sku = a.sku WHERE (SELECT COALESCE(SUM(sl.on_hand - sl.reserved), 0) FROM stock_level sl WHERE sl.sku = a.sku) >= COALESCE(t.back_in_stock, 1) ORDER BY a.id LIMIT ?;`
+
+	rows, err := imr.Conn.QueryContext(ctx, query, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []domain.StockAlert{}
+
+	for rows.Next() {
+		var a domain.StockAlert
+
+		if err := rows.Scan(&a.ID, &a.SKU, &a.UserID); err != nil {
+			return nil, err
+		}
+
+		res = append(res, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (imr *inventoryMysqlRepository) DeleteAlert(ctx context.Context, id int64) error {
+	_, err := imr.Conn.ExecContext(ctx, `DELETE FROM stock_alert WHERE id = ?;`, id)
+
+	return err
+}
+
+// datetimeLayout is how the connection gives the DATETIME columns, in UTC.
+const datetimeLayout = "2006-01-02 15:04:05"
+
+func storeMovement(ctx context.Context, tx *sql.Tx, m *domain.StockMovement) error {
+	m.CreatedAt = time.Now().UTC().Truncate(time.Second)
+
+	exec, err := tx.ExecContext(ctx, `INSERT INTO stock_movement (sku, warehouse_code, on_hand_delta, reserved_delta, reason, reference, created_at) VALUES (?, ?, ?, ?, ?, ?, ?);`, m.SKU, m.Warehouse, m.OnHandDelta, m.ReservedDelta, m.Reason, m.Reference, m.CreatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	m.ID, err = exec.LastInsertId()
+
+	return err
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
@@ -0,0 +1,738 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// touchedProducts keeps the products touched by the repository.
+type touchedProducts struct {
+	mu  sync.Mutex
+	ids []int64
+}
+
+func (tp *touchedProducts) Touch(ctx context.Context, productIDs []int64) error {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	tp.ids = append(tp.ids, productIDs...)
+	return nil
+}
+
+func TestGetWarehouse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"code", "name", "priority"}).AddRow("sp", "São Paulo", 10)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT code, name, priority FROM warehouse WHERE code = ?;")).WithArgs("sp").WillReturnRows(rows)
+
+	w, err := NewInventoryMysqlRepository(db, &touchedProducts{}).GetWarehouse(context.Background(), "sp")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Warehouse{Code: "sp", Name: "São Paulo", Priority: 10}, w)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetWarehouseNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT code, name, priority FROM warehouse WHERE code = ?;")).WithArgs("sp").WillReturnRows(sqlmock.NewRows([]string{"code", "name", "priority"}))
+
+	w, err := NewInventoryMysqlRepository(db, &touchedProducts{}).GetWarehouse(context.Background(), "sp")
+
+	assert.NoError(t, err)
+	assert.Nil(t, w)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetLevels(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"sku", "warehouse_code", "on_hand", "reserved"}).
+		AddRow("P7-M", "sp", 10, 4).
+		AddRow("P7-M", "rj", 3, 0)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT sl.sku, sl.warehouse_code, sl.on_hand, sl.reserved FROM stock_level sl JOIN warehouse w ON w.code = sl.warehouse_code WHERE sl.sku = ? ORDER BY w.priority DESC, sl.warehouse_code;")).
+		WithArgs("P7-M").
+		WillReturnRows(rows)
+
+	levels, err := NewInventoryMysqlRepository(db, &touchedProducts{}).GetLevels(context.Background(), "P7-M")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.StockLevel{
+		{SKU: "P7-M", Warehouse: "sp", OnHand: 10, Reserved: 4, Available: 6},
+		{SKU: "P7-M", Warehouse: "rj", OnHand: 3, Reserved: 0, Available: 3},
+	}, levels)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAdjust(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	m := &domain.StockMovement{SKU: "P7-M", Warehouse: "sp", OnHandDelta: 10, Reason: domain.MovementReasonReceipt, Reference: "NF 123"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO stock_level (sku, warehouse_code, on_hand, reserved) VALUES (?, ?, 0, 0);")).WithArgs("P7-M", "sp").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE stock_level SET on_hand = on_hand + ? WHERE sku = ? AND warehouse_code = ? AND on_hand + ? >= reserved;")).WithArgs(10, "P7-M", "sp", 10).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO stock_movement (sku, warehouse_code, on_hand_delta, reserved_delta, reason, reference, created_at) VALUES (?, ?, ?, ?, ?, ?, ?);")).
+		WithArgs("P7-M", "sp", 10, 0, "receipt", "NF 123", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT product_id FROM variant WHERE sku IN (?);")).
+		WithArgs("P7-M").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id"}).AddRow(7))
+
+	touched := &touchedProducts{}
+
+	err = NewInventoryMysqlRepository(db, touched).Adjust(context.Background(), m)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{7}, touched.ids)
+	assert.Equal(t, int64(3), m.ID)
+	assert.False(t, m.CreatedAt.IsZero())
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAdjustBelowReserved(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	m := &domain.StockMovement{SKU: "P7-M", Warehouse: "sp", OnHandDelta: -10, Reason: domain.MovementReasonAdjustment}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO stock_level")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE stock_level SET on_hand = on_hand + ?")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err = NewInventoryMysqlRepository(db, &touchedProducts{}).Adjust(context.Background(), m)
+
+	assert.ErrorIs(t, err, domain.ErrInsufficientStock)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReserve(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	expiresAt := time.Date(2026, 10, 19, 12, 15, 0, 0, time.UTC)
+	r := &domain.Reservation{SKU: "P7-M", Quantity: 2, Reference: "cart-1", ExpiresAt: expiresAt}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT sl.warehouse_code FROM stock_level sl JOIN warehouse w ON w.code = sl.warehouse_code WHERE sl.sku = ? AND sl.on_hand - sl.reserved >= ? ORDER BY w.priority DESC, sl.warehouse_code;")).
+		WithArgs("P7-M", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"warehouse_code"}).AddRow("sp").AddRow("rj"))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE stock_level SET reserved = reserved + ? WHERE sku = ? AND warehouse_code = ? AND on_hand - reserved >= ?;")).
+		WithArgs(2, "P7-M", "sp", 2).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE stock_level SET reserved = reserved + ? WHERE sku = ? AND warehouse_code = ? AND on_hand - reserved >= ?;")).
+		WithArgs(2, "P7-M", "rj", 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO stock_reservation (uuid, sku, warehouse_code, quantity, reference, status, expires_at) VALUES (?, ?, ?, ?, ?, ?, ?);")).
+		WithArgs(sqlmock.AnyArg(), "P7-M", "rj", 2, "cart-1", "active", expiresAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO stock_movement")).
+		WithArgs("P7-M", "rj", 0, 2, "reservation", "cart-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT product_id FROM variant WHERE sku IN (?);")).
+		WithArgs("P7-M").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id"}).AddRow(7))
+
+	touched := &touchedProducts{}
+
+	err = NewInventoryMysqlRepository(db, touched).Reserve(context.Background(), r)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{7}, touched.ids)
+	assert.NotEmpty(t, r.UUID)
+	assert.Equal(t, "rj", r.Warehouse)
+	assert.Equal(t, domain.ReservationStatusActive, r.Status)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReserveInsufficientStock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	r := &domain.Reservation{SKU: "P7-M", Warehouse: "sp", Quantity: 2, ExpiresAt: time.Now()}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE stock_level SET reserved = reserved + ?")).
+		WithArgs(2, "P7-M", "sp", 2).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err = NewInventoryMysqlRepository(db, &touchedProducts{}).Reserve(context.Background(), r)
+
+	assert.ErrorIs(t, err, domain.ErrInsufficientStock)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestReserveNeverOversells plays two buyers racing for the last units: both
+// see the stock available in the select, the second one loses the row to
+// the first and its conditional update takes nothing, so it is refused
+// instead of reserving more than is on hand.
+func TestReserveNeverOversells(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	reserve := regexp.QuoteMeta("UPDATE stock_level SET reserved = reserved + ? WHERE sku = ? AND warehouse_code = ? AND on_hand - reserved >= ?;")
+
+	// the first buyer takes the last 3 units of sp
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT sl.warehouse_code FROM stock_level sl")).
+		WithArgs("P7-M", 3).
+		WillReturnRows(sqlmock.NewRows([]string{"warehouse_code"}).AddRow("sp"))
+	mock.ExpectExec(reserve).
+		WithArgs(3, "P7-M", "sp", 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO stock_reservation")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO stock_movement")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT product_id FROM variant WHERE sku IN (?);")).
+		WithArgs("P7-M").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id"}).AddRow(7))
+
+	// the second one read sp as available before the first committed, the
+	// condition checked again under the row lock refuses it
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT sl.warehouse_code FROM stock_level sl")).
+		WithArgs("P7-M", 3).
+		WillReturnRows(sqlmock.NewRows([]string{"warehouse_code"}).AddRow("sp"))
+	mock.ExpectExec(reserve).
+		WithArgs(3, "P7-M", "sp", 3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	ir := NewInventoryMysqlRepository(db, &touchedProducts{})
+
+	first := &domain.Reservation{SKU: "P7-M", Quantity: 3, Reference: "cart-1", ExpiresAt: time.Now()}
+	second := &domain.Reservation{SKU: "P7-M", Quantity: 3, Reference: "cart-2", ExpiresAt: time.Now()}
+
+	assert.NoError(t, ir.Reserve(context.Background(), first))
+	assert.ErrorIs(t, ir.Reserve(context.Background(), second), domain.ErrInsufficientStock)
+	assert.Equal(t, "sp", first.Warehouse)
+	assert.Empty(t, second.UUID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// stockLevels is a database of a single sku answering the statements of a
+// reservation, the conditional update is atomic like under the row lock of
+// mysql and a rollback gives back what the transaction reserved.
+type stockLevels struct {
+	mu       sync.Mutex
+	order    []string
+	onHand   map[string]int64
+	reserved map[string]int64
+}
+
+func (sl *stockLevels) Connect(ctx context.Context) (driver.Conn, error) {
+	return &stockConn{levels: sl}, nil
+}
+
+func (sl *stockLevels) Driver() driver.Driver {
+	return nil
+}
+
+type stockConn struct {
+	levels  *stockLevels
+	pending map[string]int64
+}
+
+func (sc *stockConn) Prepare(query string) (driver.Stmt, error) {
+	return &stockStmt{conn: sc, query: query}, nil
+}
+
+func (sc *stockConn) Close() error {
+	return nil
+}
+
+func (sc *stockConn) Begin() (driver.Tx, error) {
+	sc.pending = map[string]int64{}
+	return sc, nil
+}
+
+func (sc *stockConn) Commit() error {
+	sc.pending = nil
+	return nil
+}
+
+func (sc *stockConn) Rollback() error {
+	sc.levels.mu.Lock()
+	defer sc.levels.mu.Unlock()
+
+	for code, quantity := range sc.pending {
+		sc.levels.reserved[code] -= quantity
+	}
+
+	sc.pending = nil
+	return nil
+}
+
+type stockStmt struct {
+	conn  *stockConn
+	query string
+}
+
+func (ss *stockStmt) Close() error {
+	return nil
+}
+
+func (ss *stockStmt) NumInput() int {
+	return -1
+}
+
+func (ss *stockStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if !strings.HasPrefix(ss.query, "UPDATE stock_level SET reserved = reserved + ?") {
+		return stockResult(1), nil
+	}
+
+	levels := ss.conn.levels
+	quantity, code := args[0].(int64), args[2].(string)
+
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+
+	if levels.onHand[code]-levels.reserved[code] < quantity {
+		return stockResult(0), nil
+	}
+
+	levels.reserved[code] += quantity
+	ss.conn.pending[code] += quantity
+
+	return stockResult(1), nil
+}
+
+func (ss *stockStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if strings.HasPrefix(ss.query, "SELECT DISTINCT product_id") {
+		return &stockRows{column: "product_id", values: []driver.Value{int64(7)}}, nil
+	}
+
+	levels := ss.conn.levels
+	quantity := args[1].(int64)
+
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+
+	rows := &stockRows{column: "warehouse_code"}
+
+	for _, code := range levels.order {
+		if levels.onHand[code]-levels.reserved[code] >= quantity {
+			rows.values = append(rows.values, code)
+		}
+	}
+
+	return rows, nil
+}
+
+// stockResult is the rows affected, also given as the last insert id.
+type stockResult int64
+
+func (sr stockResult) LastInsertId() (int64, error) {
+	return int64(sr), nil
+}
+
+func (sr stockResult) RowsAffected() (int64, error) {
+	return int64(sr), nil
+}
+
+type stockRows struct {
+	column string
+	values []driver.Value
+}
+
+func (sr *stockRows) Columns() []string {
+	return []string{sr.column}
+}
+
+func (sr *stockRows) Close() error {
+	return nil
+}
+
+func (sr *stockRows) Next(dest []driver.Value) error {
+	if len(sr.values) == 0 {
+		return io.EOF
+	}
+
+	dest[0], sr.values = sr.values[0], sr.values[1:]
+	return nil
+}
+
+func TestReserveConcurrently(t *testing.T) {
+	levels := &stockLevels{
+		order:    []string{"sp", "rj"},
+		onHand:   map[string]int64{"sp": 5, "rj": 3},
+		reserved: map[string]int64{},
+	}
+
+	db := sql.OpenDB(levels)
+	defer db.Close()
+
+	ir := NewInventoryMysqlRepository(db, &touchedProducts{})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			errs <- ir.Reserve(context.Background(), &domain.Reservation{SKU: "P7-M", Quantity: 1, Reference: "cart", ExpiresAt: time.Now()})
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	reserved := 0
+
+	for err := range errs {
+		if err == nil {
+			reserved++
+		} else {
+			assert.ErrorIs(t, err, domain.ErrInsufficientStock)
+		}
+	}
+
+	assert.Equal(t, 8, reserved)
+
+	for code, onHand := range levels.onHand {
+		assert.LessOrEqual(t, levels.reserved[code], onHand, code)
+	}
+}
+
+type otherTx struct{}
+
+func (otherTx) Commit() error   { return nil }
+func (otherTx) Rollback() error { return nil }
+
+func TestReserveTxUnsupportedTx(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	err = NewInventoryMysqlRepository(db, &touchedProducts{}).ReserveTx(context.Background(), otherTx{}, &domain.Reservation{SKU: "P7-M", Quantity: 1})
+
+	assert.ErrorIs(t, err, domain.ErrUnsupportedTx)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetReservation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"uuid", "sku", "warehouse_code", "quantity", "reference", "status", "expires_at"}).
+		AddRow("uuid", "P7-M", "sp", 2, "cart-1", "active", "2026-10-19 12:15:00")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT uuid, sku, warehouse_code, quantity, reference, status, expires_at FROM stock_reservation WHERE uuid = ?;")).WithArgs("uuid").WillReturnRows(rows)
+
+	r, err := NewInventoryMysqlRepository(db, &touchedProducts{}).GetReservation(context.Background(), "uuid")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Reservation{UUID: "uuid", SKU: "P7-M", Warehouse: "sp", Quantity: 2, Reference: "cart-1", Status: domain.ReservationStatusActive, ExpiresAt: time.Date(2026, 10, 19, 12, 15, 0, 0, time.UTC)}, r)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCommit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT sku, warehouse_code, quantity, reference FROM stock_reservation WHERE uuid = ? AND status = ? FOR UPDATE;")).
+		WithArgs("uuid", "active").
+		WillReturnRows(sqlmock.NewRows([]string{"sku", "warehouse_code", "quantity", "reference"}).AddRow("P7-M", "sp", 2, "order-1"))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE stock_level SET on_hand = on_hand + ?, reserved = reserved + ? WHERE sku = ? AND warehouse_code = ?;")).
+		WithArgs(-2, -2, "P7-M", "sp").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE stock_reservation SET status = ? WHERE uuid = ?;")).
+		WithArgs("committed", "uuid").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO stock_movement")).
+		WithArgs("P7-M", "sp", -2, -2, "sale", "order-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectCommit()
+
+	touched := &touchedProducts{}
+
+	err = NewInventoryMysqlRepository(db, touched).Commit(context.Background(), "uuid")
+
+	assert.NoError(t, err)
+	assert.Empty(t, touched.ids)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRelease(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT sku, warehouse_code, quantity, reference FROM stock_reservation")).
+		WithArgs("uuid", "active").
+		WillReturnRows(sqlmock.NewRows([]string{"sku", "warehouse_code", "quantity", "reference"}).AddRow("P7-M", "sp", 2, "cart-1"))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE stock_level SET on_hand = on_hand + ?, reserved = reserved + ?")).
+		WithArgs(0, -2, "P7-M", "sp").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE stock_reservation SET status = ? WHERE uuid = ?;")).
+		WithArgs("released", "uuid").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO stock_movement")).
+		WithArgs("P7-M", "sp", 0, -2, "expiry", "cart-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(6, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT product_id FROM variant WHERE sku IN (?);")).
+		WithArgs("P7-M").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id"}).AddRow(7))
+
+	touched := &touchedProducts{}
+
+	err = NewInventoryMysqlRepository(db, touched).Release(context.Background(), "uuid", domain.MovementReasonExpiry)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{7}, touched.ids)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReleaseNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT sku, warehouse_code, quantity, reference FROM stock_reservation")).
+		WithArgs("uuid", "active").
+		WillReturnRows(sqlmock.NewRows([]string{"sku", "warehouse_code", "quantity", "reference"}))
+	mock.ExpectRollback()
+
+	err = NewInventoryMysqlRepository(db, &touchedProducts{}).Release(context.Background(), "uuid", domain.MovementReasonRelease)
+
+	assert.ErrorIs(t, err, domain.ErrReservationNotFound)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetExpiredReservations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"uuid", "sku", "warehouse_code", "quantity", "reference"}).AddRow("uuid", "P7-M", "sp", 2, "cart-1")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT uuid, sku, warehouse_code, quantity, reference FROM stock_reservation WHERE status = ? AND expires_at <= ? ORDER BY expires_at LIMIT ?;")).
+		WithArgs("active", now, 100).
+		WillReturnRows(rows)
+
+	res, err := NewInventoryMysqlRepository(db, &touchedProducts{}).GetExpiredReservations(context.Background(), now, 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Reservation{{UUID: "uuid", SKU: "P7-M", Warehouse: "sp", Quantity: 2, Reference: "cart-1", Status: domain.ReservationStatusActive}}, res)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetMovements(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "sku", "warehouse_code", "on_hand_delta", "reserved_delta", "reason", "reference", "created_at"}).
+		AddRow(2, "P7-M", "sp", 0, 2, "reservation", "cart-1", "2026-10-19 12:00:00").
+		AddRow(1, "P7-M", "sp", 10, 0, "receipt", "", "2026-10-18 09:30:00")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, sku, warehouse_code, on_hand_delta, reserved_delta, reason, reference, created_at FROM stock_movement WHERE sku = ? ORDER BY id DESC LIMIT ?;")).
+		WithArgs("P7-M", 50).
+		WillReturnRows(rows)
+
+	movements, err := NewInventoryMysqlRepository(db, &touchedProducts{}).GetMovements(context.Background(), "P7-M", 50)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.StockMovement{
+		{ID: 2, SKU: "P7-M", Warehouse: "sp", ReservedDelta: 2, Reason: domain.MovementReasonReservation, Reference: "cart-1", CreatedAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)},
+		{ID: 1, SKU: "P7-M", Warehouse: "sp", OnHandDelta: 10, Reason: domain.MovementReasonReceipt, CreatedAt: time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)},
+	}, movements)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetMovementsError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, sku, warehouse_code")).WillReturnError(errors.New("error message"))
+
+	_, err = NewInventoryMysqlRepository(db, &touchedProducts{}).GetMovements(context.Background(), "P7-M", 50)
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetThresholdDefault(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT back_in_stock FROM stock_threshold WHERE sku = ?;")).WithArgs("P7-M").WillReturnRows(sqlmock.NewRows([]string{"back_in_stock"}))
+
+	threshold, err := NewInventoryMysqlRepository(db, &touchedProducts{}).GetThreshold(context.Background(), "P7-M")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), threshold)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetThreshold(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO stock_threshold (sku, back_in_stock) VALUES (?, ?) ON DUPLICATE KEY UPDATE back_in_stock = VALUES(back_in_stock);")).
+		WithArgs("P7-M", 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewInventoryMysqlRepository(db, &touchedProducts{}).SetThreshold(context.Background(), "P7-M", 5)
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetDueAlerts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "sku", "user_id"}).AddRow(1, "P7-M", 3)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT a.id, a.sku, a.user_id FROM stock_alert a LEFT JOIN stock_threshold t ON t.sku = a.sku WHERE (SELECT COALESCE(SUM(sl.on_hand - sl.reserved), 0) FROM stock_level sl WHERE sl.sku = a.sku) >= COALESCE(t.back_in_stock, 1) ORDER BY a.id LIMIT ?;")).
+		WithArgs(100).
+		WillReturnRows(rows)
+
+	alerts, err := NewInventoryMysqlRepository(db, &touchedProducts{}).GetDueAlerts(context.Background(), 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.StockAlert{{ID: 1, SKU: "P7-M", UserID: 3}}, alerts)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
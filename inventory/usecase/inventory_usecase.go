@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+const defaultReservationTTL = 15 * time.Minute
+const maxReservationTTL = 2 * time.Hour
+const batchSize = 100
+
+type inventoryUseCase struct {
+	inventoryRepo  domain.InventoryRepository
+	variantRepo    domain.VariantRepository
+	userRepo       domain.UserRepository
+	messageService domain.MessageService
+}
+
+func NewInventoryUseCase(ir domain.InventoryRepository, vr domain.VariantRepository, ur domain.UserRepository, ms domain.MessageService) domain.InventoryUseCase {
+	return &inventoryUseCase{inventoryRepo: ir, variantRepo: vr, userRepo: ur, messageService: ms}
+}
+
+func (iu *inventoryUseCase) GetStock(ctx context.Context, sku string) (*domain.Stock, error) {
+	variant, err := iu.variantRepo.GetBySKU(ctx, sku)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if variant == nil {
+		return nil, nil
+	}
+
+	levels, err := iu.inventoryRepo.GetLevels(ctx, sku)
+
+	if err != nil {
+		return nil, err
+	}
+
+	threshold, err := iu.inventoryRepo.GetThreshold(ctx, sku)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stock := &domain.Stock{SKU: sku, Levels: levels, BackInStockThreshold: threshold}
+
+	for _, l := range levels {
+		stock.Available += l.Available
+	}
+
+	return stock, nil
+}
+
+func (iu *inventoryUseCase) AddMovement(ctx context.Context, m *domain.StockMovement) error {
+	if err := iu.checkSKU(ctx, m.SKU); err != nil {
+		return err
+	}
+
+	warehouse, err := iu.inventoryRepo.GetWarehouse(ctx, m.Warehouse)
+
+	if err != nil {
+		return err
+	}
+
+	if warehouse == nil {
+		return domain.ErrWarehouseNotFound
+	}
+
+	m.ReservedDelta = 0
+
+	return iu.inventoryRepo.Adjust(ctx, m)
+}
+
+func (iu *inventoryUseCase) GetMovements(ctx context.Context, sku string, limit int) ([]domain.StockMovement, error) {
+	return iu.inventoryRepo.GetMovements(ctx, sku, limit)
+}
+
+func (iu *inventoryUseCase) CreateWarehouse(ctx context.Context, w *domain.Warehouse) error {
+	existing, err := iu.inventoryRepo.GetWarehouse(ctx, w.Code)
+
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		return domain.ErrWarehouseCodeTaken
+	}
+
+	return iu.inventoryRepo.StoreWarehouse(ctx, w)
+}
+
+func (iu *inventoryUseCase) SetThreshold(ctx context.Context, sku string, threshold int64) error {
+	if err := iu.checkSKU(ctx, sku); err != nil {
+		return err
+	}
+
+	return iu.inventoryRepo.SetThreshold(ctx, sku, threshold)
+}
+
+// Reserve holds the quantity for the ttl, 15 minutes when not given and
+// never more than 2 hours, so abandoned carts give the stock back.
+func (iu *inventoryUseCase) Reserve(ctx context.Context, sku string, quantity int64, reference string, ttl time.Duration) (*domain.Reservation, error) {
+	if ttl <= 0 {
+		ttl = defaultReservationTTL
+	}
+
+	if ttl > maxReservationTTL {
+		ttl = maxReservationTTL
+	}
+
+	r := &domain.Reservation{SKU: sku, Quantity: quantity, Reference: reference, ExpiresAt: time.Now().UTC().Add(ttl).Truncate(time.Second)}
+
+	if err := iu.inventoryRepo.Reserve(ctx, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (iu *inventoryUseCase) Release(ctx context.Context, uuid string) error {
+	return iu.inventoryRepo.Release(ctx, uuid, domain.MovementReasonRelease)
+}
+
+// Commit takes the reserved quantity out of the stock when the order is
+// confirmed. An expired reservation is released instead, its stock may have
+// been promised to someone else already.
+func (iu *inventoryUseCase) Commit(ctx context.Context, uuid string) error {
+	r, err := iu.inventoryRepo.GetReservation(ctx, uuid)
+
+	if err != nil {
+		return err
+	}
+
+	if r == nil || r.Status != domain.ReservationStatusActive {
+		return domain.ErrReservationNotFound
+	}
+
+	if !r.ExpiresAt.After(time.Now()) {
+		if err := iu.inventoryRepo.Release(ctx, uuid, domain.MovementReasonExpiry); err != nil {
+			return err
+		}
+
+		return domain.ErrReservationExpired
+	}
+
+	return iu.inventoryRepo.Commit(ctx, uuid)
+}
+
+func (iu *inventoryUseCase) ReleaseExpired(ctx context.Context) (int, error) {
+	released := 0
+
+	for {
+		reservations, err := iu.inventoryRepo.GetExpiredReservations(ctx, time.Now(), batchSize)
+
+		if err != nil {
+			return released, err
+		}
+
+		for _, r := range reservations {
+			err := iu.inventoryRepo.Release(ctx, r.UUID, domain.MovementReasonExpiry)
+
+			// committed or released in the meantime
+			if errors.Is(err, domain.ErrReservationNotFound) {
+				continue
+			}
+
+			if err != nil {
+				return released, err
+			}
+
+			released++
+		}
+
+		if len(reservations) < batchSize {
+			return released, nil
+		}
+	}
+}
+
+func (iu *inventoryUseCase) SubscribeAlert(ctx context.Context, sku string, login string) error {
+	if err := iu.checkSKU(ctx, sku); err != nil {
+		return err
+	}
+
+	user, err := iu.userRepo.GetByEmail(ctx, login)
+
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+
+	return iu.inventoryRepo.StoreAlert(ctx, sku, user.ID)
+}
+
+// NotifyBackInStock tells the customers waiting for a sku that reached its
+// threshold, each alert is sent once and then removed.
+func (iu *inventoryUseCase) NotifyBackInStock(ctx context.Context) (int, error) {
+	alerts, err := iu.inventoryRepo.GetDueAlerts(ctx, batchSize)
+
+	if err != nil {
+		return 0, err
+	}
+
+	notified := 0
+
+	for _, a := range alerts {
+		user, err := iu.userRepo.GetByID(ctx, a.UserID)
+
+		if err != nil {
+			return notified, err
+		}
+
+		if user != nil {
+			var messageConf domain.MessageConfig
+
+			messageConf.Medium = "email"
+			messageConf.To = user.Email
+			messageConf.Subject = "Produto disponível novamente"
+			messageConf.Message = fmt.Sprintf("O produto %s que você aguardava está disponível novamente", a.SKU)
+			messageConf.Category = domain.NotificationCategoryOrders
+			messageConf.User = user
+
+			if err := iu.messageService.SendMessage(ctx, &messageConf); err != nil {
+				return notified, err
+			}
+
+			notified++
+		}
+
+		if err := iu.inventoryRepo.DeleteAlert(ctx, a.ID); err != nil {
+			return notified, err
+		}
+	}
+
+	return notified, nil
+}
+
+func (iu *inventoryUseCase) checkSKU(ctx context.Context, sku string) error {
+	variant, err := iu.variantRepo.GetBySKU(ctx, sku)
+
+	if err != nil {
+		return err
+	}
+
+	if variant == nil {
+		return domain.ErrVariantNotFound
+	}
+
+	return nil
+}
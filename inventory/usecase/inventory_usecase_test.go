@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetStock(t *testing.T) {
+	mockInventoryRepo := new(mocks.MockInventoryRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	levels := []domain.StockLevel{
+		{SKU: "P7-M", Warehouse: "sp", OnHand: 10, Reserved: 4, Available: 6},
+		{SKU: "P7-M", Warehouse: "rj", OnHand: 3, Available: 3},
+	}
+
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P7-M").Return(&domain.Variant{ID: 2, SKU: "P7-M"}, nil)
+	mockInventoryRepo.On("GetLevels", mock.Anything, "P7-M").Return(levels, nil)
+	mockInventoryRepo.On("GetThreshold", mock.Anything, "P7-M").Return(int64(5), nil)
+
+	stock, err := NewInventoryUseCase(mockInventoryRepo, mockVariantRepo, nil, nil).GetStock(context.Background(), "P7-M")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Stock{SKU: "P7-M", Levels: levels, Available: 9, BackInStockThreshold: 5}, stock)
+}
+
+func TestGetStockNotFound(t *testing.T) {
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P7-M").Return(nil, nil)
+
+	stock, err := NewInventoryUseCase(nil, mockVariantRepo, nil, nil).GetStock(context.Background(), "P7-M")
+
+	assert.NoError(t, err)
+	assert.Nil(t, stock)
+}
+
+func TestAddMovementWarehouseNotFound(t *testing.T) {
+	mockInventoryRepo := new(mocks.MockInventoryRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P7-M").Return(&domain.Variant{ID: 2, SKU: "P7-M"}, nil)
+	mockInventoryRepo.On("GetWarehouse", mock.Anything, "sp").Return(nil, nil)
+
+	err := NewInventoryUseCase(mockInventoryRepo, mockVariantRepo, nil, nil).AddMovement(context.Background(), &domain.StockMovement{SKU: "P7-M", Warehouse: "sp", OnHandDelta: 10})
+
+	assert.ErrorIs(t, err, domain.ErrWarehouseNotFound)
+	mockInventoryRepo.AssertNotCalled(t, "Adjust", mock.Anything, mock.Anything)
+}
+
+func TestAddMovement(t *testing.T) {
+	mockInventoryRepo := new(mocks.MockInventoryRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	m := &domain.StockMovement{SKU: "P7-M", Warehouse: "sp", OnHandDelta: 10, ReservedDelta: 3, Reason: domain.MovementReasonReceipt}
+
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P7-M").Return(&domain.Variant{ID: 2, SKU: "P7-M"}, nil)
+	mockInventoryRepo.On("GetWarehouse", mock.Anything, "sp").Return(&domain.Warehouse{Code: "sp"}, nil)
+	mockInventoryRepo.On("Adjust", mock.Anything, m).Return(nil)
+
+	err := NewInventoryUseCase(mockInventoryRepo, mockVariantRepo, nil, nil).AddMovement(context.Background(), m)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), m.ReservedDelta)
+}
+
+func TestCreateWarehouseCodeTaken(t *testing.T) {
+	mockInventoryRepo := new(mocks.MockInventoryRepository)
+
+	mockInventoryRepo.On("GetWarehouse", mock.Anything, "sp").Return(&domain.Warehouse{Code: "sp"}, nil)
+
+	err := NewInventoryUseCase(mockInventoryRepo, nil, nil, nil).CreateWarehouse(context.Background(), &domain.Warehouse{Code: "sp", Name: "São Paulo"})
+
+	assert.ErrorIs(t, err, domain.ErrWarehouseCodeTaken)
+}
+
+func TestReserveDefaultTTL(t *testing.T) {
+	mockInventoryRepo := new(mocks.MockInventoryRepository)
+
+	before := time.Now()
+
+	mockInventoryRepo.On("Reserve", mock.Anything, mock.MatchedBy(func(r *domain.Reservation) bool {
+		return r.SKU == "P7-M" && r.Quantity == 2 && r.Reference == "cart-1" &&
+			!r.ExpiresAt.Before(before.Add(defaultReservationTTL).Truncate(time.Second)) && r.ExpiresAt.Before(before.Add(defaultReservationTTL+time.Minute))
+	})).Return(nil)
+
+	r, err := NewInventoryUseCase(mockInventoryRepo, nil, nil, nil).Reserve(context.Background(), "P7-M", 2, "cart-1", 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "P7-M", r.SKU)
+}
+
+func TestReserveMaxTTL(t *testing.T) {
+	mockInventoryRepo := new(mocks.MockInventoryRepository)
+
+	mockInventoryRepo.On("Reserve", mock.Anything, mock.MatchedBy(func(r *domain.Reservation) bool {
+		return r.ExpiresAt.Before(time.Now().Add(maxReservationTTL + time.Second))
+	})).Return(nil)
+
+	_, err := NewInventoryUseCase(mockInventoryRepo, nil, nil, nil).Reserve(context.Background(), "P7-M", 2, "cart-1", 24*time.Hour)
+
+	assert.NoError(t, err)
+}
+
+func TestReserveInsufficientStock(t *testing.T) {
+	mockInventoryRepo := new(mocks.MockInventoryRepository)
+
+	mockInventoryRepo.On("Reserve", mock.Anything, mock.Anything).Return(domain.ErrInsufficientStock)
+
+	r, err := NewInventoryUseCase(mockInventoryRepo, nil, nil, nil).Reserve(context.Background(), "P7-M", 2, "cart-1", 0)
+
+	assert.ErrorIs(t, err, domain.ErrInsufficientStock)
+	assert.Nil(t, r)
+}
+
+func TestCommit(t *testing.T) {
+	mockInventoryRepo := new(mocks.MockInventoryRepository)
+
+	mockInventoryRepo.On("GetReservation", mock.Anything, "uuid").Return(&domain.Reservation{UUID: "uuid", Status: domain.ReservationStatusActive, ExpiresAt: time.Now().Add(time.Minute)}, nil)
+	mockInventoryRepo.On("Commit", mock.Anything, "uuid").Return(nil)
+
+	err := NewInventoryUseCase(mockInventoryRepo, nil, nil, nil).Commit(context.Background(), "uuid")
+
+	assert.NoError(t, err)
+}
+
+func TestCommitExpired(t *testing.T) {
+	mockInventoryRepo := new(mocks.MockInventoryRepository)
+
+	mockInventoryRepo.On("GetReservation", mock.Anything, "uuid").Return(&domain.Reservation{UUID: "uuid", Status: domain.ReservationStatusActive, ExpiresAt: time.Now().Add(-time.Minute)}, nil)
+	mockInventoryRepo.On("Release", mock.Anything, "uuid", domain.MovementReasonExpiry).Return(nil)
+
+	err := NewInventoryUseCase(mockInventoryRepo, nil, nil, nil).Commit(context.Background(), "uuid")
+
+	assert.ErrorIs(t, err, domain.ErrReservationExpired)
+	mockInventoryRepo.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+}
+
+func TestCommitNotActive(t *testing.T) {
+	mockInventoryRepo := new(mocks.MockInventoryRepository)
+
+	mockInventoryRepo.On("GetReservation", mock.Anything, "uuid").Return(&domain.Reservation{UUID: "uuid", Status: domain.ReservationStatusReleased}, nil)
+
+	err := NewInventoryUseCase(mockInventoryRepo, nil, nil, nil).Commit(context.Background(), "uuid")
+
+	assert.ErrorIs(t, err, domain.ErrReservationNotFound)
+}
+
+func TestReleaseExpired(t *testing.T) {
+	mockInventoryRepo := new(mocks.MockInventoryRepository)
+
+	full := make([]domain.Reservation, batchSize)
+	for i := range full {
+		full[i] = domain.Reservation{UUID: "full"}
+	}
+
+	mockInventoryRepo.On("GetExpiredReservations", mock.Anything, mock.Anything, batchSize).Return(full, nil).Once()
+	mockInventoryRepo.On("GetExpiredReservations", mock.Anything, mock.Anything, batchSize).Return([]domain.Reservation{{UUID: "gone"}, {UUID: "last"}}, nil).Once()
+	mockInventoryRepo.On("Release", mock.Anything, "full", domain.MovementReasonExpiry).Return(nil)
+	mockInventoryRepo.On("Release", mock.Anything, "gone", domain.MovementReasonExpiry).Return(domain.ErrReservationNotFound)
+	mockInventoryRepo.On("Release", mock.Anything, "last", domain.MovementReasonExpiry).Return(nil)
+
+	released, err := NewInventoryUseCase(mockInventoryRepo, nil, nil, nil).ReleaseExpired(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, batchSize+1, released)
+}
+
+func TestReleaseExpiredError(t *testing.T) {
+	mockInventoryRepo := new(mocks.MockInventoryRepository)
+
+	mockInventoryRepo.On("GetExpiredReservations", mock.Anything, mock.Anything, batchSize).Return([]domain.Reservation{{UUID: "uuid"}}, nil)
+	mockInventoryRepo.On("Release", mock.Anything, "uuid", domain.MovementReasonExpiry).Return(errors.New("error message"))
+
+	_, err := NewInventoryUseCase(mockInventoryRepo, nil, nil, nil).ReleaseExpired(context.Background())
+
+	assert.Error(t, err)
+}
+
+func TestSubscribeAlertVariantNotFound(t *testing.T) {
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P7-M").Return(nil, nil)
+
+	err := NewInventoryUseCase(nil, mockVariantRepo, nil, nil).SubscribeAlert(context.Background(), "P7-M", "user@test.com")
+
+	assert.ErrorIs(t, err, domain.ErrVariantNotFound)
+}
+
+func TestSubscribeAlert(t *testing.T) {
+	mockInventoryRepo := new(mocks.MockInventoryRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P7-M").Return(&domain.Variant{ID: 2, SKU: "P7-M"}, nil)
+	mockUserRepo.On("GetByEmail", mock.Anything, "user@test.com").Return(3, "user uuid", "user@test.com", "", "", "", "", "", "", "", "", "", nil)
+	mockInventoryRepo.On("StoreAlert", mock.Anything, "P7-M", int64(3)).Return(nil)
+
+	err := NewInventoryUseCase(mockInventoryRepo, mockVariantRepo, mockUserRepo, nil).SubscribeAlert(context.Background(), "P7-M", "user@test.com")
+
+	assert.NoError(t, err)
+	mockInventoryRepo.AssertExpectations(t)
+}
+
+func TestNotifyBackInStock(t *testing.T) {
+	mockInventoryRepo := new(mocks.MockInventoryRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockMessageService := new(mocks.MockMessageService)
+
+	user := &domain.User{ID: 3, Email: "user@test.com"}
+
+	mockInventoryRepo.On("GetDueAlerts", mock.Anything, batchSize).Return([]domain.StockAlert{{ID: 1, SKU: "P7-M", UserID: 3}, {ID: 2, SKU: "P7-M", UserID: 4}}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, int64(3)).Return(user, nil)
+	mockUserRepo.On("GetByID", mock.Anything, int64(4)).Return(nil, nil)
+	mockMessageService.On("SendMessage", mock.Anything, mock.MatchedBy(func(mc *domain.MessageConfig) bool {
+		return mc.Medium == "email" && mc.To == "user@test.com" && mc.Category == domain.NotificationCategoryOrders && mc.User == user
+	})).Return(nil)
+	mockInventoryRepo.On("DeleteAlert", mock.Anything, int64(1)).Return(nil)
+	mockInventoryRepo.On("DeleteAlert", mock.Anything, int64(2)).Return(nil)
+
+	notified, err := NewInventoryUseCase(mockInventoryRepo, nil, mockUserRepo, mockMessageService).NotifyBackInStock(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, notified)
+	mockInventoryRepo.AssertExpectations(t)
+}
+
+func TestNotifyBackInStockKeepsAlertWhenSendFails(t *testing.T) {
+	mockInventoryRepo := new(mocks.MockInventoryRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockMessageService := new(mocks.MockMessageService)
+
+	mockInventoryRepo.On("GetDueAlerts", mock.Anything, batchSize).Return([]domain.StockAlert{{ID: 1, SKU: "P7-M", UserID: 3}}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, int64(3)).Return(&domain.User{ID: 3}, nil)
+	mockMessageService.On("SendMessage", mock.Anything, mock.Anything).Return(errors.New("error message"))
+
+	_, err := NewInventoryUseCase(mockInventoryRepo, nil, mockUserRepo, mockMessageService).NotifyBackInStock(context.Background())
+
+	assert.Error(t, err)
+	mockInventoryRepo.AssertNotCalled(t, "DeleteAlert", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,40 @@
+package validator
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type stockMovementValidator struct{}
+
+func NewStockMovementValidator() *stockMovementValidator {
+	return &stockMovementValidator{}
+}
+
+// Validate accepts only the movements made by hand, the other reasons are
+// recorded by the reservations.
+func (smv *stockMovementValidator) Validate(ctx context.Context, m *domain.StockMovement) (domain.IsValid, domain.Message) {
+	if m.Warehouse == "" {
+		return false, "movement's warehouse can not be empty"
+	}
+
+	if m.Reason != domain.MovementReasonReceipt && m.Reason != domain.MovementReasonAdjustment {
+		return false, "movement's reason must be receipt or adjustment"
+	}
+
+	if m.OnHandDelta == 0 {
+		return false, "movement's quantity can not be zero"
+	}
+
+	if m.Reason == domain.MovementReasonReceipt && m.OnHandDelta < 0 {
+		return false, "movement's quantity of a receipt must be positive"
+	}
+
+	if utf8.RuneCountInString(m.Reference) > 150 {
+		return false, "movement's reference can not have more than 150 characters"
+	}
+
+	return true, ""
+}
@@ -0,0 +1,35 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateStockMovementReason(t *testing.T) {
+	isValid, message := NewStockMovementValidator().Validate(context.Background(), &domain.StockMovement{Warehouse: "sp", OnHandDelta: -2, Reason: domain.MovementReasonSale})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidateStockMovementQuantity(t *testing.T) {
+	isValid, message := NewStockMovementValidator().Validate(context.Background(), &domain.StockMovement{Warehouse: "sp", Reason: domain.MovementReasonAdjustment})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+
+	isValid, message = NewStockMovementValidator().Validate(context.Background(), &domain.StockMovement{Warehouse: "sp", OnHandDelta: -2, Reason: domain.MovementReasonReceipt})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidateStockMovement(t *testing.T) {
+	isValid, message := NewStockMovementValidator().Validate(context.Background(), &domain.StockMovement{Warehouse: "sp", OnHandDelta: -2, Reason: domain.MovementReasonAdjustment, Reference: "avaria"})
+
+	assert.True(t, bool(isValid))
+	assert.Empty(t, message)
+}
@@ -0,0 +1,33 @@
+package validator
+
+import (
+	"context"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+var codeRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+type warehouseValidator struct{}
+
+func NewWarehouseValidator() *warehouseValidator {
+	return &warehouseValidator{}
+}
+
+func (wv *warehouseValidator) Validate(ctx context.Context, w *domain.Warehouse) (domain.IsValid, domain.Message) {
+	if len(w.Code) > 50 || !codeRegexp.MatchString(w.Code) {
+		return false, "warehouse's code must have up to 50 lowercase letters, numbers and hyphens"
+	}
+
+	if w.Name == "" {
+		return false, "warehouse's name can not be empty"
+	}
+
+	if utf8.RuneCountInString(w.Name) > 150 {
+		return false, "warehouse's name can not have more than 150 characters"
+	}
+
+	return true, ""
+}
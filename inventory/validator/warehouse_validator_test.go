@@ -0,0 +1,30 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateWarehouseCode(t *testing.T) {
+	isValid, message := NewWarehouseValidator().Validate(context.Background(), &domain.Warehouse{Code: "São Paulo", Name: "São Paulo"})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidateWarehouseName(t *testing.T) {
+	isValid, message := NewWarehouseValidator().Validate(context.Background(), &domain.Warehouse{Code: "sp"})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidateWarehouse(t *testing.T) {
+	isValid, message := NewWarehouseValidator().Validate(context.Background(), &domain.Warehouse{Code: "sp", Name: "São Paulo", Priority: 10})
+
+	assert.True(t, bool(isValid))
+	assert.Empty(t, message)
+}
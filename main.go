@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
+	"os"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 
@@ -12,14 +15,78 @@ import (
 	_authService "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/auth/service"
 	_authUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/auth/usecase"
 	_authValidator "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/auth/validator"
+	_cacheRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/cache/repository"
+	_cartPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/cart/presentation"
+	_cartRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/cart/repository"
+	_cartUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/cart/usecase"
+	_cartValidator "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/cart/validator"
+	_cataloguePresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/catalogue/presentation"
+	_catalogueRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/catalogue/repository"
+	_catalogueService "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/catalogue/service"
+	_catalogueUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/catalogue/usecase"
+	_categoryPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/category/presentation"
+	_categoryRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/category/repository"
+	_categoryUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/category/usecase"
+	_categoryValidator "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/category/validator"
 	_codeRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/code/repository"
 	_codeService "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/code/service"
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/config"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_favoritePresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/favorite/presentation"
+	_favoriteRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/favorite/repository"
+	_favoriteUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/favorite/usecase"
+	_favoriteValidator "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/favorite/validator"
+	_inventoryPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/inventory/presentation"
+	_inventoryRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/inventory/repository"
+	_inventoryUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/inventory/usecase"
+	_inventoryValidator "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/inventory/validator"
 	_messageService "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/message/service"
+	_notificationPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/notification/presentation"
+	_notificationRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/notification/repository"
+	_notificationService "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/notification/service"
+	_notificationUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/notification/usecase"
+	_notificationValidator "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/notification/validator"
+	_orderPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/order/presentation"
+	_orderRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/order/repository"
+	_orderService "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/order/service"
+	_orderUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/order/usecase"
+	_orderValidator "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/order/validator"
+	_paymentPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/payment/presentation"
+	_paymentRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/payment/repository"
+	_paymentService "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/payment/service"
+	_paymentUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/payment/usecase"
+	_paymentValidator "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/payment/validator"
+	_picturePresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/picture/presentation"
+	_pictureRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/picture/repository"
+	_pictureService "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/picture/service"
+	_pictureUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/picture/usecase"
+	_pricingPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/pricing/presentation"
+	_pricingRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/pricing/repository"
+	_pricingService "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/pricing/service"
+	_pricingUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/pricing/usecase"
+	_pricingValidator "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/pricing/validator"
 	_productPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/product/presentation"
 	_productRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/product/repository"
 	_productUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/product/usecase"
+	_productValidator "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/product/validator"
+	_recommendationPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/recommendation/presentation"
+	_recommendationRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/recommendation/repository"
+	_recommendationUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/recommendation/usecase"
+	_reviewPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/review/presentation"
+	_reviewRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/review/repository"
+	_reviewUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/review/usecase"
+	_reviewValidator "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/review/validator"
+	_searchPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/search/presentation"
+	_searchRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/search/repository"
+	_searchService "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/search/service"
+	_searchUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/search/usecase"
+	_sitemapPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/sitemap/presentation"
+	_sitemapUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/sitemap/usecase"
 	_tokenService "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/service"
+	_translationPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/translation/presentation"
+	_translationRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/translation/repository"
+	_translationUsecase "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/translation/usecase"
+	_translationValidator "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/translation/validator"
 	_userRepo "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/user/repository"
 	_userValidator "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/user/validator"
 	"github.com/labstack/echo/v4"
@@ -32,7 +99,7 @@ func main() {
 		log.Fatal(err)
 	}
 
-	dbConn, err := sql.Open(`mysql`, fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", conf.Database.User, conf.Database.Pass, conf.Database.Host, conf.Database.Port, conf.Database.Name))
+	dbConn, err := sql.Open(`mysql`, fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4", conf.Database.User, conf.Database.Pass, conf.Database.Host, conf.Database.Port, conf.Database.Name))
 
 	if err != nil {
 		log.Fatal(err)
@@ -54,25 +121,223 @@ func main() {
 	e := echo.New()
 
 	e.Use(middleware.CORS())
+	e.Use(_translationPresentation.NewLocaleMiddleware(conf.Locale.Default, conf.Locale.Fallbacks))
+
+	var cache domain.Cache
+
+	if conf.Cache.Driver == "redis" {
+		cache = _cacheRepo.NewRedisCache(conf.Cache.Address, conf.Cache.Password, conf.Cache.DB)
+	} else {
+		cache = _cacheRepo.NewLRUCache(conf.Cache.Size)
+	}
 
 	authRepo := _authRepo.NewAuthMysqlRepository(dbConn)
 	codeRepo := _codeRepo.NewCodeMysqlRepository(dbConn)
 	userRepo := _userRepo.NewUserMysqlRepository(dbConn)
-	productRepo := _productRepo.NewProductMysqlRepository(dbConn)
+	productRepo := _productRepo.NewProductCacheRepository(_productRepo.NewProductMysqlRepository(dbConn), cache, time.Duration(conf.Cache.TTL)*time.Second)
+	notificationPreferenceRepo := _notificationRepo.NewNotificationPreferenceMysqlRepository(dbConn)
+	searchRepo := _searchRepo.NewSearchMysqlRepository(dbConn)
+	categoryRepo := _categoryRepo.NewCategoryMysqlRepository(dbConn)
+	collectionRepo := _categoryRepo.NewCollectionMysqlRepository(dbConn)
+	variantRepo := _productRepo.NewVariantMysqlRepository(dbConn)
+	priceRepo := _pricingRepo.NewPriceMysqlRepository(dbConn)
+	inventoryRepo := _inventoryRepo.NewInventoryMysqlRepository(dbConn, productRepo)
+	reviewRepo := _reviewRepo.NewReviewMysqlRepository(dbConn)
+	favoriteRepo := _favoriteRepo.NewFavoriteMysqlRepository(dbConn)
+	wishlistRepo := _favoriteRepo.NewWishlistMysqlRepository(dbConn)
+	recommendationRepo := _recommendationRepo.NewRecommendationMysqlRepository(dbConn)
+	importJobRepo := _catalogueRepo.NewImportJobMysqlRepository(dbConn)
+	translationRepo := _translationRepo.NewTranslationMysqlRepository(dbConn)
+	cartRepo := _cartRepo.NewCartMysqlRepository(dbConn)
+	orderRepo := _orderRepo.NewOrderMysqlRepository(dbConn, inventoryRepo)
+	paymentRepo := _paymentRepo.NewPaymentMysqlRepository(dbConn)
+	refundRepo := _paymentRepo.NewRefundMysqlRepository(dbConn)
+
+	var blobStore domain.BlobStore
+
+	if conf.Storage.Driver == "s3" {
+		blobStore = _pictureRepo.NewS3BlobStore(conf.Storage.Endpoint, conf.Storage.Bucket, conf.Storage.Region, conf.Storage.AccessKey, conf.Storage.SecretKey)
+	} else {
+		blobStore = _pictureRepo.NewLocalBlobStore(conf.Storage.Path)
+	}
+
+	gateways := map[domain.PaymentMethod]domain.PaymentGateway{}
+	// the live driver has no boleto gateway yet, the boletos are not asked for
+	paymentMethods := []domain.PaymentMethod{domain.PaymentMethodCard, domain.PaymentMethodPix}
+
+	if conf.Payment.Driver == "live" {
+		gateways[domain.PaymentMethodCard] = _paymentService.NewCardPaymentGateway(conf.Payment.Card.Endpoint, conf.Payment.Card.APIKey, conf.Payment.Card.WebhookSecret)
+		gateways[domain.PaymentMethodPix] = _paymentService.NewPixPaymentGateway(conf.Payment.Pix.Endpoint, conf.Payment.Pix.Token, conf.Payment.PixKey, conf.Payment.MerchantName, conf.Payment.MerchantCity, conf.Payment.Pix.WebhookSecret)
+	} else {
+		fakePaymentGateway := _paymentService.NewFakePaymentGateway(conf.Payment.WebhookSecret, conf.Payment.PixKey, conf.Payment.MerchantName, conf.Payment.MerchantCity)
+		gateways[domain.PaymentMethodCard] = fakePaymentGateway
+		gateways[domain.PaymentMethodPix] = fakePaymentGateway
+		gateways[domain.PaymentMethodBoleto] = fakePaymentGateway
+		paymentMethods = append(paymentMethods, domain.PaymentMethodBoleto)
+	}
 
 	authService := _authService.NewAuthService()
 	codeService := _codeService.NewCodeService(codeRepo)
-	messageService := _messageService.NewMessageService()
+	notificationService := _notificationService.NewNotificationService(notificationPreferenceRepo, conf.Notification.Secret, conf.Notification.UnsubscribeURL)
+	messageService := _messageService.NewMessageService(notificationService)
 	tokenService := _tokenService.NewTokenService()
+	searchIndexService := _searchService.NewSearchIndexService()
+	pricingService := _pricingService.NewPricingService(priceRepo)
+	imageService := _pictureService.NewImageService()
+	catalogueCodec := _catalogueService.NewCatalogueCodec()
+	checkoutService := _orderService.NewCheckoutService(conf.Checkout.Shipping, conf.Checkout.ShippingPerUnit, conf.Checkout.FreeShippingFrom, conf.Checkout.TaxRate)
 
 	authValidator := _authValidator.NewAuthValidator()
 	userValidator := _userValidator.NewUserValidator()
+	notificationValidator := _notificationValidator.NewNotificationValidator()
+	productValidator := _productValidator.NewProductValidator()
+	variantValidator := _productValidator.NewVariantValidator()
+	categoryValidator := _categoryValidator.NewCategoryValidator()
+	collectionValidator := _categoryValidator.NewCollectionValidator()
+	priceValidator := _pricingValidator.NewPriceValidator()
+	priceListValidator := _pricingValidator.NewPriceListValidator()
+	warehouseValidator := _inventoryValidator.NewWarehouseValidator()
+	stockMovementValidator := _inventoryValidator.NewStockMovementValidator()
+	reviewValidator := _reviewValidator.NewReviewValidator()
+	wishlistValidator := _favoriteValidator.NewWishlistValidator()
+	productTranslationValidator := _translationValidator.NewProductTranslationValidator()
+	attributeTranslationValidator := _translationValidator.NewAttributeTranslationValidator()
+	cartItemValidator := _cartValidator.NewCartItemValidator()
+	addressValidator := _orderValidator.NewAddressValidator()
+	orderTransitionValidator := _orderValidator.NewOrderTransitionValidator()
+	paymentRequestValidator := _paymentValidator.NewPaymentRequestValidator(paymentMethods)
+	refundRequestValidator := _paymentValidator.NewRefundRequestValidator()
 
 	authUsecase := _authUsecase.NewAuthUseCase(authService, tokenService, codeService, messageService, authRepo, userRepo)
-	productUsecase := _productUsecase.NewProductUseCase(productRepo)
+	productUsecase := _productUsecase.NewProductUseCase(productRepo, userRepo, searchIndexService, searchRepo, variantRepo, translationRepo, pricingService)
+	variantUsecase := _productUsecase.NewVariantUseCase(productRepo, variantRepo)
+	priceUsecase := _pricingUsecase.NewPriceUseCase(pricingService, priceRepo, productRepo, variantRepo, userRepo)
+	pictureUsecase := _pictureUsecase.NewPictureUseCase(blobStore, imageService, productRepo)
+	inventoryUsecase := _inventoryUsecase.NewInventoryUseCase(inventoryRepo, variantRepo, userRepo, messageService)
+	reviewUsecase := _reviewUsecase.NewReviewUseCase(reviewRepo, productRepo, userRepo)
+	favoriteUsecase := _favoriteUsecase.NewFavoriteUseCase(favoriteRepo, productRepo, userRepo, productUsecase)
+	wishlistUsecase := _favoriteUsecase.NewWishlistUseCase(wishlistRepo, productRepo, userRepo, productUsecase)
+	recommendationUsecase := _recommendationUsecase.NewRecommendationUseCase(recommendationRepo, productRepo, userRepo, productUsecase)
+	searchUsecase := _searchUsecase.NewSearchUseCase(searchIndexService, searchRepo)
+	categoryUsecase := _categoryUsecase.NewCategoryUseCase(categoryRepo, productRepo, productUsecase)
+	collectionUsecase := _categoryUsecase.NewCollectionUseCase(collectionRepo, productUsecase)
+	notificationUsecase := _notificationUsecase.NewNotificationUseCase(notificationService, notificationPreferenceRepo, userRepo)
+	catalogueUsecase := _catalogueUsecase.NewCatalogueUseCase(importJobRepo, blobStore, catalogueCodec, productUsecase, productRepo, variantRepo, productValidator, variantValidator)
+	sitemapUsecase := _sitemapUsecase.NewSitemapUseCase(productRepo)
+	translationUsecase := _translationUsecase.NewTranslationUseCase(productRepo, translationRepo)
+	cartUsecase := _cartUsecase.NewCartUseCase(cartRepo, productRepo, variantRepo, userRepo, pricingService)
+	orderUsecase := _orderUsecase.NewOrderUseCase(orderRepo, userRepo, cartUsecase, checkoutService, messageService, paymentRepo)
+	paymentUsecase := _paymentUsecase.NewPaymentUseCase(paymentRepo, refundRepo, orderRepo, userRepo, orderUsecase, gateways)
+	refundUsecase := _paymentUsecase.NewRefundUseCase(refundRepo, paymentRepo, orderRepo, userRepo, inventoryRepo, orderUsecase, messageService, gateways, conf.Payment.RestockRefunds)
+
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1:], catalogueUsecase); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
+	if err := productUsecase.IndexAll(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			ctx := context.Background()
+
+			if _, err := inventoryUsecase.ReleaseExpired(ctx); err != nil {
+				log.Printf("Error trying to release the expired reservations: %s", err.Error())
+			}
+
+			if _, err := cartUsecase.DeleteExpired(ctx); err != nil {
+				log.Printf("Error trying to delete the expired carts: %s", err.Error())
+			}
+
+			if _, err := paymentUsecase.Expire(ctx); err != nil {
+				log.Printf("Error trying to cancel the expired orders: %s", err.Error())
+			}
+
+			if _, err := paymentUsecase.Reconcile(ctx); err != nil {
+				log.Printf("Error trying to reconcile the payments: %s", err.Error())
+			}
+
+			// an import can take longer than the ticker, the jobs being run
+			// are not taken again
+			go func() {
+				if _, err := catalogueUsecase.ResumeInterrupted(ctx); err != nil {
+					log.Printf("Error trying to resume the interrupted imports: %s", err.Error())
+				}
+			}()
+		}
+	}()
+
+	// the alerts are mailed one by one and can take longer than the minute,
+	// they have their own ticker so the reservations and the payments above
+	// are not held back
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if _, err := inventoryUsecase.NotifyBackInStock(context.Background()); err != nil {
+				log.Printf("Error trying to notify the back in stock alerts: %s", err.Error())
+			}
+		}
+	}()
+
+	go func() {
+		for {
+			// the affinities are computed at 3 AM UTC, when there are fewer
+			// customers around
+			now := time.Now().UTC()
+			next := time.Date(now.Year(), now.Month(), now.Day(), 3, 0, 0, 0, time.UTC)
+
+			if !next.After(now) {
+				next = next.AddDate(0, 0, 1)
+			}
+
+			time.Sleep(next.Sub(now))
+
+			if err := recommendationUsecase.ComputeAffinities(context.Background()); err != nil {
+				log.Printf("Error trying to compute the product affinities: %s", err.Error())
+			}
+		}
+	}()
 
 	_authPresentation.NewAuthHandler(e, authUsecase, authValidator, userValidator)
 	_productPresentation.NewProductHandler(e, productUsecase, tokenService)
+	_productPresentation.NewProductAdminHandler(e, productUsecase, productValidator, tokenService)
+	_productPresentation.NewVariantAdminHandler(e, variantUsecase, variantValidator, tokenService)
+	_picturePresentation.NewPictureHandler(e, pictureUsecase)
+	_picturePresentation.NewPictureAdminHandler(e, pictureUsecase, tokenService)
+	_cataloguePresentation.NewCatalogueAdminHandler(e, catalogueUsecase, tokenService)
+	_pricingPresentation.NewPriceHandler(e, priceUsecase, tokenService)
+	_pricingPresentation.NewPriceAdminHandler(e, priceUsecase, priceValidator, priceListValidator, tokenService)
+	_inventoryPresentation.NewInventoryHandler(e, inventoryUsecase, tokenService)
+	_inventoryPresentation.NewInventoryAdminHandler(e, inventoryUsecase, warehouseValidator, stockMovementValidator, tokenService)
+	_recommendationPresentation.NewRecommendationHandler(e, recommendationUsecase, tokenService)
+	_recommendationPresentation.NewRecommendationAdminHandler(e, recommendationUsecase, tokenService)
+	_reviewPresentation.NewReviewHandler(e, reviewUsecase, reviewValidator, tokenService)
+	_reviewPresentation.NewReviewAdminHandler(e, reviewUsecase, tokenService)
+	_favoritePresentation.NewFavoriteHandler(e, favoriteUsecase, tokenService)
+	_favoritePresentation.NewWishlistHandler(e, wishlistUsecase, wishlistValidator, tokenService)
+	_searchPresentation.NewSearchHandler(e, searchUsecase)
+	_categoryPresentation.NewCategoryHandler(e, categoryUsecase, collectionUsecase, tokenService)
+	_categoryPresentation.NewCategoryAdminHandler(e, categoryUsecase, categoryValidator, collectionUsecase, collectionValidator, tokenService)
+	_notificationPresentation.NewNotificationHandler(e, notificationUsecase, notificationValidator, tokenService)
+	_sitemapPresentation.NewSitemapHandler(e, sitemapUsecase, conf.Site.URL)
+	_translationPresentation.NewTranslationAdminHandler(e, translationUsecase, productTranslationValidator, attributeTranslationValidator, tokenService)
+	_cartPresentation.NewCartHandler(e, cartUsecase, cartItemValidator, tokenService)
+	_orderPresentation.NewOrderHandler(e, orderUsecase, addressValidator, tokenService)
+	_orderPresentation.NewOrderAdminHandler(e, orderUsecase, orderTransitionValidator, tokenService)
+	_paymentPresentation.NewPaymentHandler(e, paymentUsecase, paymentRequestValidator, tokenService)
+	_paymentPresentation.NewPaymentAdminHandler(e, paymentUsecase, tokenService)
+	_paymentPresentation.NewRefundHandler(e, refundUsecase, tokenService)
+	_paymentPresentation.NewRefundAdminHandler(e, refundUsecase, refundRequestValidator, tokenService)
 
 	log.Fatal(e.Start(conf.Server.Address))
 }
@@ -2,19 +2,54 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"time"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
 )
 
-type messageService struct{}
+type messageService struct {
+	notificationService domain.NotificationService
+}
 
-func NewMessageService() *messageService {
-	return &messageService{}
+func NewMessageService(ns domain.NotificationService) *messageService {
+	return &messageService{notificationService: ns}
 }
 
 func (m messageService) SendMessage(ctx context.Context, mc *domain.MessageConfig) error {
+	if mc.User != nil && mc.Category != domain.NotificationCategorySecurity {
+		if mc.Category == "" {
+			return fmt.Errorf("message to user %s has no category", mc.User.UUID)
+		}
+
+		optedIn, err := m.notificationService.IsOptedIn(ctx, mc.User.ID, mc.Category, mc.Medium)
+
+		if err != nil {
+			return err
+		}
+
+		if !optedIn {
+			return nil
+		}
+
+		if mc.Medium == "email" {
+			link := m.notificationService.UnsubscribeLink(ctx, &domain.Unsubscribe{UserUUID: mc.User.UUID, Category: mc.Category, Medium: mc.Medium})
+
+			if mc.TemplateVariables == nil {
+				mc.TemplateVariables = map[string]string{}
+			}
+
+			if mc.Headers == nil {
+				mc.Headers = map[string]string{}
+			}
+
+			mc.TemplateVariables["unsubscribeLink"] = link
+			mc.Headers["List-Unsubscribe"] = fmt.Sprintf("<%s>", link)
+			mc.Headers["List-Unsubscribe-Post"] = "List-Unsubscribe=One-Click"
+		}
+	}
+
 	rand.Seed(time.Now().UnixNano())
 	time.Sleep(time.Duration((8 + rand.Intn(5))) * time.Second)
 
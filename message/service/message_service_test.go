@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSendMessageWithoutCategory(t *testing.T) {
+	mc := &domain.MessageConfig{Medium: "email", User: &domain.User{ID: 1, UUID: "uuid"}}
+
+	err := NewMessageService(nil).SendMessage(context.Background(), mc)
+
+	assert.Error(t, err)
+}
+
+func TestSendMessageIsOptedInError(t *testing.T) {
+	mockNotificationService := new(mocks.MockNotificationService)
+
+	mockNotificationService.On("IsOptedIn", mock.Anything, int64(1), domain.NotificationCategoryMarketing, "email").Return(false, errors.New("error message"))
+
+	mc := &domain.MessageConfig{Medium: "email", Category: domain.NotificationCategoryMarketing, User: &domain.User{ID: 1, UUID: "uuid"}}
+
+	err := NewMessageService(mockNotificationService).SendMessage(context.Background(), mc)
+
+	assert.Error(t, err)
+}
+
+func TestSendMessageOptedOut(t *testing.T) {
+	mockNotificationService := new(mocks.MockNotificationService)
+
+	mockNotificationService.On("IsOptedIn", mock.Anything, int64(1), domain.NotificationCategoryMarketing, "email").Return(false, nil)
+
+	mc := &domain.MessageConfig{Medium: "email", Category: domain.NotificationCategoryMarketing, User: &domain.User{ID: 1, UUID: "uuid"}}
+
+	err := NewMessageService(mockNotificationService).SendMessage(context.Background(), mc)
+
+	assert.NoError(t, err)
+	assert.Empty(t, mc.Headers)
+	mockNotificationService.AssertNotCalled(t, "UnsubscribeLink", mock.Anything, mock.Anything)
+}
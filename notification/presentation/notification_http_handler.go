@@ -0,0 +1,133 @@
+package presentation
+
+import (
+	"bytes"
+	"html/template"
+	"log"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+// unsubscribePage asks the user to confirm the unsubscription, the link of
+// the email only opens it so the scanners and the prefetchers of the mail
+// clients do not unsubscribe anyone. The form makes the same one-click POST
+// of RFC 8058 the mail clients make.
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head><meta charset="utf-8"><title>Cancelar inscrição</title></head>
+<body>
+<form method="post" action="?token={{.}}">
+<input type="hidden" name="List-Unsubscribe" value="One-Click">
+<p>Deseja deixar de receber estas mensagens?</p>
+<button type="submit">Cancelar inscrição</button>
+</form>
+</body>
+</html>
+`))
+
+type notificationHandler struct {
+	NotificationUseCase   domain.NotificationUseCase
+	NotificationValidator domain.NotificationValidator
+}
+
+func NewNotificationHandler(e *echo.Echo, nuc domain.NotificationUseCase, nv domain.NotificationValidator, ts domain.TokenService) *notificationHandler {
+	handler := &notificationHandler{
+		NotificationUseCase:   nuc,
+		NotificationValidator: nv,
+	}
+
+	auth := _tokenPresentation.NewAuthMiddleware(ts)
+
+	e.GET("/me/notifications", handler.GetPreferences, auth)
+	e.PUT("/me/notifications", handler.UpdatePreferences, auth)
+	e.GET("/notifications/unsubscribe", handler.ConfirmUnsubscribe)
+	e.POST("/notifications/unsubscribe", handler.Unsubscribe)
+
+	return handler
+}
+
+func (nh *notificationHandler) GetPreferences(c echo.Context) error {
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	prefs, err := nh.NotificationUseCase.GetPreferences(c.Request().Context(), tokenInfo.Info)
+
+	if err != nil {
+		log.Printf("Error trying to get notification preferences: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the notification preferences")
+	}
+
+	return c.JSON(http.StatusOK, map[string][]domain.NotificationPreference{"preferences": prefs})
+}
+
+func (nh *notificationHandler) UpdatePreferences(c echo.Context) error {
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	var prefsReq struct {
+		Preferences []domain.NotificationPreference `json:"preferences"`
+	}
+
+	if err := c.Bind(&prefsReq); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	ctx := c.Request().Context()
+
+	isValid, message := nh.NotificationValidator.Validate(ctx, prefsReq.Preferences)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	prefs, err := nh.NotificationUseCase.UpdatePreferences(ctx, tokenInfo.Info, prefsReq.Preferences)
+
+	if err != nil {
+		log.Printf("Error trying to update notification preferences: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to update the notification preferences")
+	}
+
+	return c.JSON(http.StatusOK, map[string][]domain.NotificationPreference{"preferences": prefs})
+}
+
+// ConfirmUnsubscribe only shows the confirmation page, it changes nothing.
+func (nh *notificationHandler) ConfirmUnsubscribe(c echo.Context) error {
+	token := c.QueryParam("token")
+
+	if token == "" {
+		return c.JSON(http.StatusBadRequest, "token param is not valid")
+	}
+
+	var page bytes.Buffer
+
+	if err := unsubscribePage.Execute(&page, token); err != nil {
+		log.Printf("Error trying to show the unsubscribe page: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to show the unsubscribe page")
+	}
+
+	return c.HTML(http.StatusOK, page.String())
+}
+
+func (nh *notificationHandler) Unsubscribe(c echo.Context) error {
+	token := c.QueryParam("token")
+
+	if token == "" {
+		return c.JSON(http.StatusBadRequest, "token param is not valid")
+	}
+
+	if err := nh.NotificationUseCase.Unsubscribe(c.Request().Context(), token); err != nil {
+		log.Printf("Error trying to unsubscribe: %s", err.Error())
+		return c.JSON(http.StatusBadRequest, "failed to unsubscribe")
+	}
+
+	return c.String(http.StatusOK, "")
+}
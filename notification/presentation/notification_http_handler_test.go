@@ -0,0 +1,186 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetPreferencesNotAuthorized(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/me/notifications", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewNotificationHandler(echo.New(), nil, nil, nil)
+
+	handler.GetPreferences(c)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestGetPreferencesError(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/me/notifications", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockNotificationUsecase := new(mocks.MockNotificationUseCase)
+
+	mockNotificationUsecase.On("GetPreferences", mock.Anything, "user@test.com").Return(nil, errors.New("error message"))
+
+	handler := NewNotificationHandler(echo.New(), mockNotificationUsecase, nil, nil)
+
+	handler.GetPreferences(c)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestGetPreferences(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/me/notifications", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockNotificationUsecase := new(mocks.MockNotificationUseCase)
+
+	mockNotificationUsecase.On("GetPreferences", mock.Anything, "user@test.com").Return([]domain.NotificationPreference{{Category: domain.NotificationCategoryOrders, Medium: "email", OptedIn: true}}, nil)
+
+	handler := NewNotificationHandler(echo.New(), mockNotificationUsecase, nil, nil)
+
+	handler.GetPreferences(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"preferences\":[{\"category\":\"orders\",\"medium\":\"email\",\"optedIn\":true}]}\n", rec.Body.String())
+}
+
+func TestUpdatePreferencesInvalid(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/me/notifications", strings.NewReader("{\"preferences\":[{\"category\":\"security\",\"medium\":\"email\",\"optedIn\":false}]}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockNotificationValidator := new(mocks.MockNotificationValidator)
+
+	mockNotificationValidator.On("Validate", mock.Anything, []domain.NotificationPreference{{Category: domain.NotificationCategorySecurity, Medium: "email", OptedIn: false}}).Return(false, "error message")
+
+	handler := NewNotificationHandler(echo.New(), nil, mockNotificationValidator, nil)
+
+	handler.UpdatePreferences(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "\"error message\"\n", rec.Body.String())
+}
+
+func TestUpdatePreferences(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/me/notifications", strings.NewReader("{\"preferences\":[{\"category\":\"marketing\",\"medium\":\"email\",\"optedIn\":true}]}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	prefs := []domain.NotificationPreference{{Category: domain.NotificationCategoryMarketing, Medium: "email", OptedIn: true}}
+
+	mockNotificationUsecase := new(mocks.MockNotificationUseCase)
+	mockNotificationValidator := new(mocks.MockNotificationValidator)
+
+	mockNotificationValidator.On("Validate", mock.Anything, prefs).Return(true, "")
+	mockNotificationUsecase.On("UpdatePreferences", mock.Anything, "user@test.com", prefs).Return(prefs, nil)
+
+	handler := NewNotificationHandler(echo.New(), mockNotificationUsecase, mockNotificationValidator, nil)
+
+	handler.UpdatePreferences(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestConfirmUnsubscribe(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/notifications/unsubscribe?token=a.b%2Bc", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockNotificationUsecase := new(mocks.MockNotificationUseCase)
+
+	handler := NewNotificationHandler(echo.New(), mockNotificationUsecase, nil, nil)
+
+	handler.ConfirmUnsubscribe(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `<form method="post" action="?token=a.b%2bc">`)
+	mockNotificationUsecase.AssertNotCalled(t, "Unsubscribe", mock.Anything, mock.Anything)
+}
+
+func TestConfirmUnsubscribeEmptyToken(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/notifications/unsubscribe", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewNotificationHandler(echo.New(), nil, nil, nil)
+
+	handler.ConfirmUnsubscribe(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestUnsubscribeEmptyToken(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/notifications/unsubscribe", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewNotificationHandler(echo.New(), nil, nil, nil)
+
+	handler.Unsubscribe(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestUnsubscribe(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/notifications/unsubscribe?token=token", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockNotificationUsecase := new(mocks.MockNotificationUseCase)
+
+	mockNotificationUsecase.On("Unsubscribe", mock.Anything, "token").Return(nil)
+
+	handler := NewNotificationHandler(echo.New(), mockNotificationUsecase, nil, nil)
+
+	handler.Unsubscribe(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type notificationPreferenceMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewNotificationPreferenceMysqlRepository(conn *sql.DB) domain.NotificationPreferenceRepository {
+	return &notificationPreferenceMysqlRepository{Conn: conn}
+}
+
+func (r *notificationPreferenceMysqlRepository) GetByUserID(ctx context.Context, userID int64) ([]domain.NotificationPreference, error) {
+	query := `SELECT category, medium, opted_in FROM notification_preference WHERE user_id = ?;`
+
+	rows, err := r.Conn.QueryContext(ctx, query, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []domain.NotificationPreference{}
+
+	for rows.Next() {
+		var pref domain.NotificationPreference
+
+		if err := rows.Scan(&pref.Category, &pref.Medium, &pref.OptedIn); err != nil {
+			return nil, err
+		}
+
+		res = append(res, pref)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *notificationPreferenceMysqlRepository) Store(ctx context.Context, userID int64, prefs []domain.NotificationPreference) error {
+	query := `INSERT INTO notification_preference (user_id, category, medium, opted_in) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE opted_in = VALUES(opted_in);`
+
+	tx, err := r.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, query)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, pref := range prefs {
+		if _, err = stmt.ExecContext(ctx, userID, pref.Category, pref.Medium, pref.OptedIn); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetByUserIDError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT category, medium, opted_in FROM notification_preference WHERE user_id = ?;")
+
+	mock.ExpectQuery(query).WillReturnError(errors.New("error message"))
+
+	_, err = NewNotificationPreferenceMysqlRepository(db).GetByUserID(context.Background(), 1)
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetByUserID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"category", "medium", "opted_in"}).AddRow("marketing", "email", true).AddRow("orders", "phone", false)
+
+	query := regexp.QuoteMeta("SELECT category, medium, opted_in FROM notification_preference WHERE user_id = ?;")
+
+	mock.ExpectQuery(query).WithArgs(1).WillReturnRows(rows)
+
+	prefs, err := NewNotificationPreferenceMysqlRepository(db).GetByUserID(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, prefs, 2)
+	assert.Equal(t, domain.NotificationCategoryMarketing, prefs[0].Category)
+	assert.Equal(t, "email", prefs[0].Medium)
+	assert.True(t, prefs[0].OptedIn)
+	assert.False(t, prefs[1].OptedIn)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("INSERT INTO notification_preference (user_id, category, medium, opted_in) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE opted_in = VALUES(opted_in);")
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(query).ExpectExec().WillReturnError(errors.New("error message"))
+	mock.ExpectRollback()
+
+	err = NewNotificationPreferenceMysqlRepository(db).Store(context.Background(), 1, []domain.NotificationPreference{{Category: domain.NotificationCategoryMarketing, Medium: "email", OptedIn: true}})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("INSERT INTO notification_preference (user_id, category, medium, opted_in) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE opted_in = VALUES(opted_in);")
+
+	mock.ExpectBegin()
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().WithArgs(1, "marketing", "email", true).WillReturnResult(sqlmock.NewResult(1, 1))
+	prep.ExpectExec().WithArgs(1, "orders", "phone", false).WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit()
+
+	err = NewNotificationPreferenceMysqlRepository(db).Store(context.Background(), 1, []domain.NotificationPreference{
+		{Category: domain.NotificationCategoryMarketing, Medium: "email", OptedIn: true},
+		{Category: domain.NotificationCategoryOrders, Medium: "phone", OptedIn: false},
+	})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
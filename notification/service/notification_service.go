@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+var defaultPreferences = []domain.NotificationPreference{
+	{Category: domain.NotificationCategorySecurity, Medium: "email", OptedIn: true},
+	{Category: domain.NotificationCategorySecurity, Medium: "phone", OptedIn: true},
+	{Category: domain.NotificationCategoryOrders, Medium: "email", OptedIn: true},
+	{Category: domain.NotificationCategoryOrders, Medium: "phone", OptedIn: true},
+	{Category: domain.NotificationCategoryMarketing, Medium: "email", OptedIn: false},
+	{Category: domain.NotificationCategoryMarketing, Medium: "phone", OptedIn: false},
+}
+
+// unsubscribeTokenTTL is how long the unsubscribe link of an email works
+// after it was sent.
+const unsubscribeTokenTTL = 90 * 24 * time.Hour
+
+type notificationService struct {
+	prefRepo       domain.NotificationPreferenceRepository
+	secret         []byte
+	unsubscribeURL string
+	now            func() time.Time
+}
+
+func NewNotificationService(npr domain.NotificationPreferenceRepository, secret string, unsubscribeURL string) *notificationService {
+	return &notificationService{prefRepo: npr, secret: []byte(secret), unsubscribeURL: unsubscribeURL, now: time.Now}
+}
+
+func (ns *notificationService) Preferences(ctx context.Context, userID int64) ([]domain.NotificationPreference, error) {
+	stored, err := ns.prefRepo.GetByUserID(ctx, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	prefs := make([]domain.NotificationPreference, len(defaultPreferences))
+	copy(prefs, defaultPreferences)
+
+	for i := range prefs {
+		if prefs[i].Category == domain.NotificationCategorySecurity {
+			continue
+		}
+
+		for _, s := range stored {
+			if s.Category == prefs[i].Category && s.Medium == prefs[i].Medium {
+				prefs[i].OptedIn = s.OptedIn
+			}
+		}
+	}
+
+	return prefs, nil
+}
+
+func (ns *notificationService) IsOptedIn(ctx context.Context, userID int64, category domain.NotificationCategory, medium string) (bool, error) {
+	if category == domain.NotificationCategorySecurity {
+		return true, nil
+	}
+
+	prefs, err := ns.Preferences(ctx, userID)
+
+	if err != nil {
+		return false, err
+	}
+
+	for _, p := range prefs {
+		if p.Category == category && p.Medium == medium {
+			return p.OptedIn, nil
+		}
+	}
+
+	return false, nil
+}
+
+// UnsubscribeLink signs the unsubscription with the time it was issued, the
+// link expires after unsubscribeTokenTTL.
+func (ns *notificationService) UnsubscribeLink(ctx context.Context, u *domain.Unsubscribe) string {
+	payload := fmt.Sprintf("%s:%s:%s:%d", u.UserUUID, u.Category, u.Medium, ns.now().Unix())
+
+	token := base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + base64.RawURLEncoding.EncodeToString(ns.sign(payload))
+
+	return ns.unsubscribeURL + "?token=" + url.QueryEscape(token)
+}
+
+func (ns *notificationService) ParseUnsubscribeToken(ctx context.Context, token string) (*domain.Unsubscribe, error) {
+	parts := strings.Split(token, ".")
+
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("unsubscribe token is malformed")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !hmac.Equal(signature, ns.sign(string(payload))) {
+		return nil, fmt.Errorf("unsubscribe token signature is not valid")
+	}
+
+	fields := strings.Split(string(payload), ":")
+
+	if len(fields) != 4 {
+		return nil, fmt.Errorf("unsubscribe token is malformed")
+	}
+
+	issuedAt, err := strconv.ParseInt(fields[3], 10, 64)
+
+	if err != nil {
+		return nil, fmt.Errorf("unsubscribe token is malformed")
+	}
+
+	if ns.now().Sub(time.Unix(issuedAt, 0)) > unsubscribeTokenTTL {
+		return nil, fmt.Errorf("unsubscribe token expired")
+	}
+
+	return &domain.Unsubscribe{UserUUID: fields[0], Category: domain.NotificationCategory(fields[1]), Medium: fields[2]}, nil
+}
+
+func (ns *notificationService) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, ns.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
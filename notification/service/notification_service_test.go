@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPreferencesError(t *testing.T) {
+	mockPrefRepo := new(mocks.MockNotificationPreferenceRepository)
+
+	mockPrefRepo.On("GetByUserID", mock.Anything, int64(1)).Return(nil, errors.New("error message"))
+
+	_, err := NewNotificationService(mockPrefRepo, "secret", "http://localhost/unsubscribe").Preferences(context.Background(), 1)
+
+	assert.Error(t, err)
+}
+
+func TestPreferencesMergeDefaults(t *testing.T) {
+	mockPrefRepo := new(mocks.MockNotificationPreferenceRepository)
+
+	mockPrefRepo.On("GetByUserID", mock.Anything, int64(1)).Return([]domain.NotificationPreference{
+		{Category: domain.NotificationCategoryMarketing, Medium: "email", OptedIn: true},
+		{Category: domain.NotificationCategorySecurity, Medium: "phone", OptedIn: false},
+	}, nil)
+
+	prefs, err := NewNotificationService(mockPrefRepo, "secret", "http://localhost/unsubscribe").Preferences(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, prefs, 6)
+
+	for _, p := range prefs {
+		if p.Category == domain.NotificationCategoryMarketing && p.Medium == "email" {
+			assert.True(t, p.OptedIn)
+		}
+		if p.Category == domain.NotificationCategoryMarketing && p.Medium == "phone" {
+			assert.False(t, p.OptedIn)
+		}
+		if p.Category == domain.NotificationCategorySecurity {
+			assert.True(t, p.OptedIn)
+		}
+	}
+}
+
+func TestIsOptedInSecurityAlways(t *testing.T) {
+	isOptedIn, err := NewNotificationService(nil, "secret", "http://localhost/unsubscribe").IsOptedIn(context.Background(), 1, domain.NotificationCategorySecurity, "phone")
+
+	assert.NoError(t, err)
+	assert.True(t, isOptedIn)
+}
+
+func TestIsOptedIn(t *testing.T) {
+	mockPrefRepo := new(mocks.MockNotificationPreferenceRepository)
+
+	mockPrefRepo.On("GetByUserID", mock.Anything, int64(1)).Return([]domain.NotificationPreference{
+		{Category: domain.NotificationCategoryOrders, Medium: "email", OptedIn: false},
+	}, nil)
+
+	ns := NewNotificationService(mockPrefRepo, "secret", "http://localhost/unsubscribe")
+
+	isOptedIn, err := ns.IsOptedIn(context.Background(), 1, domain.NotificationCategoryOrders, "email")
+
+	assert.NoError(t, err)
+	assert.False(t, isOptedIn)
+
+	isOptedIn, err = ns.IsOptedIn(context.Background(), 1, domain.NotificationCategoryOrders, "phone")
+
+	assert.NoError(t, err)
+	assert.True(t, isOptedIn)
+}
+
+func TestUnsubscribeLinkRoundTrip(t *testing.T) {
+	ns := NewNotificationService(nil, "secret", "http://localhost/unsubscribe")
+
+	link := ns.UnsubscribeLink(context.Background(), &domain.Unsubscribe{UserUUID: "uuid", Category: domain.NotificationCategoryMarketing, Medium: "email"})
+
+	assert.True(t, strings.HasPrefix(link, "http://localhost/unsubscribe?token="))
+
+	parsed, err := url.Parse(link)
+	assert.NoError(t, err)
+
+	unsubscribe, err := ns.ParseUnsubscribeToken(context.Background(), parsed.Query().Get("token"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "uuid", unsubscribe.UserUUID)
+	assert.Equal(t, domain.NotificationCategoryMarketing, unsubscribe.Category)
+	assert.Equal(t, "email", unsubscribe.Medium)
+}
+
+func TestParseUnsubscribeTokenWrongSignature(t *testing.T) {
+	link := NewNotificationService(nil, "secret", "http://localhost/unsubscribe").UnsubscribeLink(context.Background(), &domain.Unsubscribe{UserUUID: "uuid", Category: domain.NotificationCategoryMarketing, Medium: "email"})
+
+	parsed, err := url.Parse(link)
+	assert.NoError(t, err)
+
+	_, err = NewNotificationService(nil, "other secret", "http://localhost/unsubscribe").ParseUnsubscribeToken(context.Background(), parsed.Query().Get("token"))
+
+	assert.Error(t, err)
+}
+
+func TestParseUnsubscribeTokenExpired(t *testing.T) {
+	ns := NewNotificationService(nil, "secret", "http://localhost/unsubscribe")
+
+	sentAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	ns.now = func() time.Time { return sentAt }
+
+	link := ns.UnsubscribeLink(context.Background(), &domain.Unsubscribe{UserUUID: "uuid", Category: domain.NotificationCategoryMarketing, Medium: "email"})
+
+	parsed, err := url.Parse(link)
+	assert.NoError(t, err)
+
+	ns.now = func() time.Time { return sentAt.Add(unsubscribeTokenTTL - time.Hour) }
+
+	_, err = ns.ParseUnsubscribeToken(context.Background(), parsed.Query().Get("token"))
+
+	assert.NoError(t, err)
+
+	ns.now = func() time.Time { return sentAt.Add(unsubscribeTokenTTL + time.Hour) }
+
+	_, err = ns.ParseUnsubscribeToken(context.Background(), parsed.Query().Get("token"))
+
+	assert.EqualError(t, err, "unsubscribe token expired")
+}
+
+func TestParseUnsubscribeTokenMalformed(t *testing.T) {
+	_, err := NewNotificationService(nil, "secret", "http://localhost/unsubscribe").ParseUnsubscribeToken(context.Background(), "malformed")
+
+	assert.Error(t, err)
+}
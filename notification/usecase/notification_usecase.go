@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type notificationUseCase struct {
+	notificationService domain.NotificationService
+	prefRepo            domain.NotificationPreferenceRepository
+	userRepo            domain.UserRepository
+}
+
+func NewNotificationUseCase(ns domain.NotificationService, npr domain.NotificationPreferenceRepository, ur domain.UserRepository) domain.NotificationUseCase {
+	return &notificationUseCase{
+		notificationService: ns,
+		prefRepo:            npr,
+		userRepo:            ur,
+	}
+}
+
+func (nu *notificationUseCase) GetPreferences(ctx context.Context, login string) ([]domain.NotificationPreference, error) {
+	user, err := nu.userRepo.GetByEmail(ctx, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, fmt.Errorf("user with login %s not found", login)
+	}
+
+	return nu.notificationService.Preferences(ctx, user.ID)
+}
+
+func (nu *notificationUseCase) UpdatePreferences(ctx context.Context, login string, prefs []domain.NotificationPreference) ([]domain.NotificationPreference, error) {
+	user, err := nu.userRepo.GetByEmail(ctx, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, fmt.Errorf("user with login %s not found", login)
+	}
+
+	if err := nu.prefRepo.Store(ctx, user.ID, prefs); err != nil {
+		return nil, err
+	}
+
+	return nu.notificationService.Preferences(ctx, user.ID)
+}
+
+func (nu *notificationUseCase) Unsubscribe(ctx context.Context, token string) error {
+	unsubscribe, err := nu.notificationService.ParseUnsubscribeToken(ctx, token)
+
+	if err != nil {
+		return err
+	}
+
+	if unsubscribe.Category == domain.NotificationCategorySecurity {
+		return fmt.Errorf("security notifications can not be unsubscribed")
+	}
+
+	user, err := nu.userRepo.GetByUUID(ctx, unsubscribe.UserUUID)
+
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return fmt.Errorf("user with uuid %s not found", unsubscribe.UserUUID)
+	}
+
+	pref := domain.NotificationPreference{Category: unsubscribe.Category, Medium: unsubscribe.Medium, OptedIn: false}
+
+	return nu.prefRepo.Store(ctx, user.ID, []domain.NotificationPreference{pref})
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetPreferencesUserNotFound(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, "user@test.com").Return(nil, nil)
+
+	_, err := NewNotificationUseCase(nil, nil, mockUserRepo).GetPreferences(context.Background(), "user@test.com")
+
+	assert.Error(t, err)
+}
+
+func TestGetPreferences(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockNotificationService := new(mocks.MockNotificationService)
+
+	prefs := []domain.NotificationPreference{{Category: domain.NotificationCategoryOrders, Medium: "email", OptedIn: true}}
+
+	mockUserRepo.On("GetByEmail", mock.Anything, "user@test.com").Return(1, "uuid", "user@test.com", "", "", "", "", "", "", "", "", "", nil)
+	mockNotificationService.On("Preferences", mock.Anything, int64(1)).Return(prefs, nil)
+
+	res, err := NewNotificationUseCase(mockNotificationService, nil, mockUserRepo).GetPreferences(context.Background(), "user@test.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, prefs, res)
+}
+
+func TestUpdatePreferencesStoreError(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockPrefRepo := new(mocks.MockNotificationPreferenceRepository)
+
+	prefs := []domain.NotificationPreference{{Category: domain.NotificationCategoryMarketing, Medium: "email", OptedIn: true}}
+
+	mockUserRepo.On("GetByEmail", mock.Anything, "user@test.com").Return(1, "uuid", "user@test.com", "", "", "", "", "", "", "", "", "", nil)
+	mockPrefRepo.On("Store", mock.Anything, int64(1), prefs).Return(errors.New("error message"))
+
+	_, err := NewNotificationUseCase(nil, mockPrefRepo, mockUserRepo).UpdatePreferences(context.Background(), "user@test.com", prefs)
+
+	assert.Error(t, err)
+}
+
+func TestUpdatePreferences(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockPrefRepo := new(mocks.MockNotificationPreferenceRepository)
+	mockNotificationService := new(mocks.MockNotificationService)
+
+	prefs := []domain.NotificationPreference{{Category: domain.NotificationCategoryMarketing, Medium: "email", OptedIn: true}}
+
+	mockUserRepo.On("GetByEmail", mock.Anything, "user@test.com").Return(1, "uuid", "user@test.com", "", "", "", "", "", "", "", "", "", nil)
+	mockPrefRepo.On("Store", mock.Anything, int64(1), prefs).Return(nil)
+	mockNotificationService.On("Preferences", mock.Anything, int64(1)).Return(prefs, nil)
+
+	res, err := NewNotificationUseCase(mockNotificationService, mockPrefRepo, mockUserRepo).UpdatePreferences(context.Background(), "user@test.com", prefs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, prefs, res)
+}
+
+func TestUnsubscribeInvalidToken(t *testing.T) {
+	mockNotificationService := new(mocks.MockNotificationService)
+
+	mockNotificationService.On("ParseUnsubscribeToken", mock.Anything, "token").Return(nil, errors.New("error message"))
+
+	err := NewNotificationUseCase(mockNotificationService, nil, nil).Unsubscribe(context.Background(), "token")
+
+	assert.Error(t, err)
+}
+
+func TestUnsubscribeSecurity(t *testing.T) {
+	mockNotificationService := new(mocks.MockNotificationService)
+
+	mockNotificationService.On("ParseUnsubscribeToken", mock.Anything, "token").Return("uuid", "security", "email", nil)
+
+	err := NewNotificationUseCase(mockNotificationService, nil, nil).Unsubscribe(context.Background(), "token")
+
+	assert.Error(t, err)
+}
+
+func TestUnsubscribe(t *testing.T) {
+	mockNotificationService := new(mocks.MockNotificationService)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockPrefRepo := new(mocks.MockNotificationPreferenceRepository)
+
+	mockNotificationService.On("ParseUnsubscribeToken", mock.Anything, "token").Return("uuid", "marketing", "email", nil)
+	mockUserRepo.On("GetByUUID", mock.Anything, "uuid").Return(1, "uuid", "user@test.com", "", "", "", "", "", "", "", "", "", nil)
+	mockPrefRepo.On("Store", mock.Anything, int64(1), []domain.NotificationPreference{{Category: domain.NotificationCategoryMarketing, Medium: "email", OptedIn: false}}).Return(nil)
+
+	err := NewNotificationUseCase(mockNotificationService, mockPrefRepo, mockUserRepo).Unsubscribe(context.Background(), "token")
+
+	assert.NoError(t, err)
+	mockPrefRepo.AssertExpectations(t)
+}
@@ -0,0 +1,35 @@
+package validator
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type notificationValidator struct{}
+
+func NewNotificationValidator() *notificationValidator {
+	return &notificationValidator{}
+}
+
+func (nv *notificationValidator) Validate(ctx context.Context, prefs []domain.NotificationPreference) (domain.IsValid, domain.Message) {
+	if len(prefs) == 0 {
+		return false, "preferences can not be empty"
+	}
+
+	for _, p := range prefs {
+		if p.Category != domain.NotificationCategorySecurity && p.Category != domain.NotificationCategoryOrders && p.Category != domain.NotificationCategoryMarketing {
+			return false, "preference category must be security, orders or marketing"
+		}
+
+		if p.Medium != "email" && p.Medium != "phone" {
+			return false, "preference medium must be email or phone"
+		}
+
+		if p.Category == domain.NotificationCategorySecurity && !p.OptedIn {
+			return false, "security notifications can not be disabled"
+		}
+	}
+
+	return true, ""
+}
@@ -0,0 +1,47 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePreferencesCanNotBeEmpty(t *testing.T) {
+	isValid, message := NewNotificationValidator().Validate(context.Background(), []domain.NotificationPreference{})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidatePreferencesInvalidCategory(t *testing.T) {
+	isValid, message := NewNotificationValidator().Validate(context.Background(), []domain.NotificationPreference{{Category: "news", Medium: "email"}})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidatePreferencesInvalidMedium(t *testing.T) {
+	isValid, message := NewNotificationValidator().Validate(context.Background(), []domain.NotificationPreference{{Category: domain.NotificationCategoryOrders, Medium: "pigeon"}})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidatePreferencesSecurityOptOut(t *testing.T) {
+	isValid, message := NewNotificationValidator().Validate(context.Background(), []domain.NotificationPreference{{Category: domain.NotificationCategorySecurity, Medium: "email", OptedIn: false}})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidatePreferences(t *testing.T) {
+	isValid, message := NewNotificationValidator().Validate(context.Background(), []domain.NotificationPreference{
+		{Category: domain.NotificationCategorySecurity, Medium: "email", OptedIn: true},
+		{Category: domain.NotificationCategoryMarketing, Medium: "phone", OptedIn: false},
+	})
+
+	assert.True(t, bool(isValid))
+	assert.Empty(t, message)
+}
@@ -0,0 +1,150 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type orderAdminHandler struct {
+	OrderUseCase             domain.OrderUseCase
+	OrderTransitionValidator domain.OrderTransitionValidator
+}
+
+func NewOrderAdminHandler(e *echo.Echo, ouc domain.OrderUseCase, otv domain.OrderTransitionValidator, ts domain.TokenService) *orderAdminHandler {
+	handler := &orderAdminHandler{
+		OrderUseCase:             ouc,
+		OrderTransitionValidator: otv,
+	}
+
+	admin := _tokenPresentation.NewAdminMiddleware(ts)
+
+	e.GET("/admin/orders", handler.List, admin)
+	e.GET("/admin/orders/:uuid", handler.Get, admin)
+	e.POST("/admin/orders/:uuid/transitions", handler.Transition, admin)
+
+	return handler
+}
+
+func (oah *orderAdminHandler) List(c echo.Context) error {
+	q, message := parseOrderQuery(c)
+
+	if message != "" {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	if status := c.QueryParam("status"); status != "" {
+		q.Status = domain.OrderStatus(status)
+
+		if !validOrderStatus(q.Status) {
+			return c.JSON(http.StatusBadRequest, "status param is not a status of the orders")
+		}
+	}
+
+	page, err := oah.OrderUseCase.List(c.Request().Context(), *q)
+
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return c.JSON(http.StatusBadRequest, "cursor param is not valid")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to list the orders: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to list the orders")
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func (oah *orderAdminHandler) Get(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	order, err := oah.OrderUseCase.Get(c.Request().Context(), uuid)
+
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		return c.JSON(http.StatusNotFound, "order not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to get the order: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the order")
+	}
+
+	return c.JSON(http.StatusOK, order)
+}
+
+func (oah *orderAdminHandler) Transition(c echo.Context) error {
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	var req domain.OrderTransitionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	ctx := c.Request().Context()
+
+	isValid, message := oah.OrderTransitionValidator.Validate(ctx, &req)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	order, err := oah.OrderUseCase.Transition(ctx, uuid, tokenInfo.Info, &req)
+
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		return c.JSON(http.StatusNotFound, "order not found")
+	}
+
+	var transitionErr *domain.TransitionError
+
+	if errors.As(err, &transitionErr) {
+		return c.JSON(http.StatusConflict, transitionErr.Error())
+	}
+
+	if errors.Is(err, domain.ErrOrderChanged) {
+		return c.JSON(http.StatusConflict, "the order changed while being transitioned, review it and try again")
+	}
+
+	if errors.Is(err, domain.ErrInsufficientStock) {
+		return c.JSON(http.StatusConflict, "insufficient stock")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to transition the order: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to transition the order")
+	}
+
+	return c.JSON(http.StatusOK, order)
+}
+
+func validOrderStatus(s domain.OrderStatus) bool {
+	for from, to := range domain.OrderTransitions {
+		if s == from {
+			return true
+		}
+
+		if _, ok := to[s]; ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,163 @@
+package presentation
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func transitionContext(body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req, _ := http.NewRequest(echo.POST, "/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "admin@test.com"})
+	c.SetPath("/admin/orders/:uuid/transitions")
+	c.SetParamNames("uuid")
+	c.SetParamValues("order-1")
+
+	return c, rec
+}
+
+func TestAdminListOrders(t *testing.T) {
+	e := echo.New()
+	req, _ := http.NewRequest(echo.GET, "/admin/orders?status=paid", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+
+	mockOrderUseCase.On("List", mock.Anything, domain.OrderQuery{Status: domain.OrderStatusPaid, Limit: 20}).Return(&domain.OrderPage{Orders: []domain.Order{{UUID: "order-1"}}}, nil)
+
+	handler := NewOrderAdminHandler(echo.New(), mockOrderUseCase, nil, nil)
+
+	handler.List(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"uuid\":\"order-1\"")
+}
+
+func TestAdminListOrdersInvalidStatus(t *testing.T) {
+	e := echo.New()
+	req, _ := http.NewRequest(echo.GET, "/admin/orders?status=lost", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewOrderAdminHandler(echo.New(), new(mocks.MockOrderUseCase), nil, nil)
+
+	handler.List(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestAdminGetOrder(t *testing.T) {
+	e := echo.New()
+	req, _ := http.NewRequest(echo.GET, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/admin/orders/:uuid")
+	c.SetParamNames("uuid")
+	c.SetParamValues("order-1")
+
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+
+	mockOrderUseCase.On("Get", mock.Anything, "order-1").Return(&domain.Order{UUID: "order-1", History: []domain.OrderHistoryEntry{{From: domain.OrderStatusPendingPayment, To: domain.OrderStatusPaid, Actor: "admin@test.com"}}}, nil)
+
+	handler := NewOrderAdminHandler(echo.New(), mockOrderUseCase, nil, nil)
+
+	handler.Get(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"actor\":\"admin@test.com\"")
+}
+
+func TestAdminTransitionOrder(t *testing.T) {
+	c, rec := transitionContext(`{"status":"shipped","trackingCode":"BR123"}`)
+
+	tr := &domain.OrderTransitionRequest{Status: domain.OrderStatusShipped, TrackingCode: "BR123"}
+
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+	mockOrderTransitionValidator := new(mocks.MockOrderTransitionValidator)
+
+	mockOrderTransitionValidator.On("Validate", mock.Anything, tr).Return(true, "")
+	mockOrderUseCase.On("Transition", mock.Anything, "order-1", "admin@test.com", tr).Return(&domain.Order{UUID: "order-1", Status: domain.OrderStatusShipped, TrackingCode: "BR123"}, nil)
+
+	handler := NewOrderAdminHandler(echo.New(), mockOrderUseCase, mockOrderTransitionValidator, nil)
+
+	handler.Transition(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"status\":\"shipped\"")
+}
+
+func TestAdminTransitionOrderInvalid(t *testing.T) {
+	c, rec := transitionContext(`{"status":"lost"}`)
+
+	mockOrderTransitionValidator := new(mocks.MockOrderTransitionValidator)
+
+	mockOrderTransitionValidator.On("Validate", mock.Anything, mock.Anything).Return(false, "transition's status is not valid")
+
+	handler := NewOrderAdminHandler(echo.New(), new(mocks.MockOrderUseCase), mockOrderTransitionValidator, nil)
+
+	handler.Transition(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestAdminTransitionOrderNotAllowed(t *testing.T) {
+	c, rec := transitionContext(`{"status":"paid"}`)
+
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+	mockOrderTransitionValidator := new(mocks.MockOrderTransitionValidator)
+
+	mockOrderTransitionValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockOrderUseCase.On("Transition", mock.Anything, "order-1", "admin@test.com", mock.Anything).Return(nil, fmt.Errorf("transitioning: %w", &domain.TransitionError{From: domain.OrderStatusCancelled, To: domain.OrderStatusPaid}))
+
+	handler := NewOrderAdminHandler(echo.New(), mockOrderUseCase, mockOrderTransitionValidator, nil)
+
+	handler.Transition(c)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "order can not go from cancelled to paid")
+}
+
+func TestAdminTransitionOrderChanged(t *testing.T) {
+	c, rec := transitionContext(`{"status":"picking"}`)
+
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+	mockOrderTransitionValidator := new(mocks.MockOrderTransitionValidator)
+
+	mockOrderTransitionValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockOrderUseCase.On("Transition", mock.Anything, "order-1", "admin@test.com", mock.Anything).Return(nil, domain.ErrOrderChanged)
+
+	handler := NewOrderAdminHandler(echo.New(), mockOrderUseCase, mockOrderTransitionValidator, nil)
+
+	handler.Transition(c)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestAdminTransitionOrderNotFound(t *testing.T) {
+	c, rec := transitionContext(`{"status":"picking"}`)
+
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+	mockOrderTransitionValidator := new(mocks.MockOrderTransitionValidator)
+
+	mockOrderTransitionValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockOrderUseCase.On("Transition", mock.Anything, "order-1", "admin@test.com", mock.Anything).Return(nil, domain.ErrOrderNotFound)
+
+	handler := NewOrderAdminHandler(echo.New(), mockOrderUseCase, mockOrderTransitionValidator, nil)
+
+	handler.Transition(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
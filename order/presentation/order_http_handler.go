@@ -0,0 +1,196 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type orderHandler struct {
+	OrderUseCase     domain.OrderUseCase
+	AddressValidator domain.AddressValidator
+}
+
+// placeOrderRequest ships the order to the address of the user when Address
+// is not given.
+type placeOrderRequest struct {
+	Address *domain.UserAddress `json:"address"`
+}
+
+func NewOrderHandler(e *echo.Echo, ouc domain.OrderUseCase, av domain.AddressValidator, ts domain.TokenService) *orderHandler {
+	handler := &orderHandler{
+		OrderUseCase:     ouc,
+		AddressValidator: av,
+	}
+
+	auth := _tokenPresentation.NewAuthMiddleware(ts)
+
+	e.POST("/orders", handler.Place, auth)
+	e.GET("/me/orders", handler.ListMine, auth)
+	e.GET("/me/orders/:uuid", handler.GetMine, auth)
+
+	return handler
+}
+
+// Place answers the same request sent again with the same Idempotency-Key
+// with the order placed the first time, marked by the Idempotent-Replayed
+// header.
+func (oh *orderHandler) Place(c echo.Context) error {
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	key := c.Request().Header.Get("Idempotency-Key")
+
+	if key == "" {
+		return c.JSON(http.StatusBadRequest, "Idempotency-Key header can not be empty")
+	}
+
+	if utf8.RuneCountInString(key) > 100 {
+		return c.JSON(http.StatusBadRequest, "Idempotency-Key header can not be longer than 100 characters")
+	}
+
+	var req placeOrderRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	ctx := c.Request().Context()
+
+	if req.Address != nil {
+		isValid, message := oh.AddressValidator.Validate(ctx, req.Address)
+
+		if !isValid {
+			return c.JSON(http.StatusBadRequest, message)
+		}
+	}
+
+	order, replayed, err := oh.OrderUseCase.Place(ctx, tokenInfo.Info, key, req.Address)
+
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, "user not found")
+	}
+
+	if errors.Is(err, domain.ErrIdempotencyKeyReused) {
+		return c.JSON(http.StatusUnprocessableEntity, "Idempotency-Key already used with another request")
+	}
+
+	if errors.Is(err, domain.ErrOrderPlaced) {
+		return c.JSON(http.StatusConflict, "an order is already being placed with the Idempotency-Key")
+	}
+
+	if errors.Is(err, domain.ErrCartEmpty) {
+		return c.JSON(http.StatusConflict, "the cart is empty")
+	}
+
+	if errors.Is(err, domain.ErrCartUnavailable) {
+		return c.JSON(http.StatusConflict, "the cart has items that can not be bought, review it and try again")
+	}
+
+	if errors.Is(err, domain.ErrCartChanged) {
+		return c.JSON(http.StatusConflict, "the cart changed while placing the order, review it and try again")
+	}
+
+	if errors.Is(err, domain.ErrInsufficientStock) {
+		return c.JSON(http.StatusConflict, "insufficient stock")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to place the order: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to place the order")
+	}
+
+	if replayed {
+		c.Response().Header().Set("Idempotent-Replayed", "true")
+		return c.JSON(http.StatusOK, order)
+	}
+
+	return c.JSON(http.StatusCreated, order)
+}
+
+func (oh *orderHandler) ListMine(c echo.Context) error {
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	q, message := parseOrderQuery(c)
+
+	if message != "" {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	page, err := oh.OrderUseCase.ListMine(c.Request().Context(), tokenInfo.Info, *q)
+
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, "user not found")
+	}
+
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return c.JSON(http.StatusBadRequest, "cursor param is not valid")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to list the orders of the user: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to list the orders")
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func (oh *orderHandler) GetMine(c echo.Context) error {
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	order, err := oh.OrderUseCase.GetMine(c.Request().Context(), tokenInfo.Info, uuid)
+
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, "user not found")
+	}
+
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		return c.JSON(http.StatusNotFound, "order not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to get the order of the user: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the order")
+	}
+
+	return c.JSON(http.StatusOK, order)
+}
+
+func parseOrderQuery(c echo.Context) (*domain.OrderQuery, domain.Message) {
+	q := domain.OrderQuery{Cursor: c.QueryParam("cursor"), Limit: 20}
+
+	if limit := c.QueryParam("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+
+		if err != nil || l < 1 || l > 100 {
+			return nil, "limit param must be a number between 1 and 100"
+		}
+
+		q.Limit = l
+	}
+
+	return &q, ""
+}
@@ -0,0 +1,230 @@
+package presentation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func placeContext(body string, key string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req, _ := http.NewRequest(echo.POST, "/orders", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "ana@test.com"})
+
+	return c, rec
+}
+
+func TestPlace(t *testing.T) {
+	c, rec := placeContext(`{}`, "key-1")
+
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+
+	mockOrderUseCase.On("Place", mock.Anything, "ana@test.com", "key-1", (*domain.UserAddress)(nil)).Return(&domain.Order{UUID: "order-1", Status: domain.OrderStatusPendingPayment}, false, nil)
+
+	handler := NewOrderHandler(echo.New(), mockOrderUseCase, nil, nil)
+
+	handler.Place(c)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"uuid\":\"order-1\"")
+	assert.Contains(t, rec.Body.String(), "\"status\":\"pending_payment\"")
+	assert.Empty(t, rec.Header().Get("Idempotent-Replayed"))
+}
+
+func TestPlaceToAddress(t *testing.T) {
+	c, rec := placeContext(`{"address":{"city":"Olinda","state":"PE","neighborhood":"Carmo","street":"Rua B","number":"2","zipcode":"53020000"}}`, "key-1")
+
+	address := &domain.UserAddress{City: "Olinda", State: "PE", Neighborhood: "Carmo", Street: "Rua B", Number: "2", ZipCode: "53020000"}
+
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+	mockAddressValidator := new(mocks.MockAddressValidator)
+
+	mockAddressValidator.On("Validate", mock.Anything, address).Return(true, "")
+	mockOrderUseCase.On("Place", mock.Anything, "ana@test.com", "key-1", address).Return(&domain.Order{UUID: "order-1"}, false, nil)
+
+	handler := NewOrderHandler(echo.New(), mockOrderUseCase, mockAddressValidator, nil)
+
+	handler.Place(c)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	mockOrderUseCase.AssertExpectations(t)
+}
+
+func TestPlaceReplayed(t *testing.T) {
+	c, rec := placeContext(``, "key-1")
+
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+
+	mockOrderUseCase.On("Place", mock.Anything, "ana@test.com", "key-1", (*domain.UserAddress)(nil)).Return(&domain.Order{UUID: "order-1"}, true, nil)
+
+	handler := NewOrderHandler(echo.New(), mockOrderUseCase, nil, nil)
+
+	handler.Place(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "true", rec.Header().Get("Idempotent-Replayed"))
+}
+
+func TestPlaceWithoutIdempotencyKey(t *testing.T) {
+	c, rec := placeContext(`{}`, "")
+
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+
+	handler := NewOrderHandler(echo.New(), mockOrderUseCase, nil, nil)
+
+	handler.Place(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockOrderUseCase.AssertNotCalled(t, "Place", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPlaceInvalidAddress(t *testing.T) {
+	c, rec := placeContext(`{"address":{"city":""}}`, "key-1")
+
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+	mockAddressValidator := new(mocks.MockAddressValidator)
+
+	mockAddressValidator.On("Validate", mock.Anything, mock.Anything).Return(false, "address city can not be empty")
+
+	handler := NewOrderHandler(echo.New(), mockOrderUseCase, mockAddressValidator, nil)
+
+	handler.Place(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "\"address city can not be empty\"\n", rec.Body.String())
+}
+
+func TestPlaceErrors(t *testing.T) {
+	for err, code := range map[error]int{
+		domain.ErrUserNotFound:         http.StatusNotFound,
+		domain.ErrIdempotencyKeyReused: http.StatusUnprocessableEntity,
+		domain.ErrOrderPlaced:          http.StatusConflict,
+		domain.ErrCartEmpty:            http.StatusConflict,
+		domain.ErrCartUnavailable:      http.StatusConflict,
+		domain.ErrCartChanged:          http.StatusConflict,
+		domain.ErrInsufficientStock:    http.StatusConflict,
+		domain.ErrCurrencyMismatch:     http.StatusInternalServerError,
+	} {
+		c, rec := placeContext(`{}`, "key-1")
+
+		mockOrderUseCase := new(mocks.MockOrderUseCase)
+
+		mockOrderUseCase.On("Place", mock.Anything, "ana@test.com", "key-1", mock.Anything).Return(nil, false, err)
+
+		handler := NewOrderHandler(echo.New(), mockOrderUseCase, nil, nil)
+
+		handler.Place(c)
+
+		assert.Equal(t, code, rec.Code, err.Error())
+	}
+}
+
+func TestListMine(t *testing.T) {
+	e := echo.New()
+	req, _ := http.NewRequest(echo.GET, "/me/orders?limit=10&cursor=abc", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "ana@test.com"})
+
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+
+	mockOrderUseCase.On("ListMine", mock.Anything, "ana@test.com", domain.OrderQuery{Cursor: "abc", Limit: 10}).Return(&domain.OrderPage{Orders: []domain.Order{{UUID: "order-1"}}, NextCursor: "def"}, nil)
+
+	handler := NewOrderHandler(echo.New(), mockOrderUseCase, nil, nil)
+
+	handler.ListMine(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"uuid\":\"order-1\"")
+	assert.Contains(t, rec.Body.String(), "\"nextCursor\":\"def\"")
+}
+
+func TestListMineInvalidLimit(t *testing.T) {
+	e := echo.New()
+	req, _ := http.NewRequest(echo.GET, "/me/orders?limit=101", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "ana@test.com"})
+
+	handler := NewOrderHandler(echo.New(), new(mocks.MockOrderUseCase), nil, nil)
+
+	handler.ListMine(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestListMineInvalidCursor(t *testing.T) {
+	e := echo.New()
+	req, _ := http.NewRequest(echo.GET, "/me/orders?cursor=abc", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "ana@test.com"})
+
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+
+	mockOrderUseCase.On("ListMine", mock.Anything, "ana@test.com", domain.OrderQuery{Cursor: "abc", Limit: 20}).Return(nil, domain.ErrInvalidCursor)
+
+	handler := NewOrderHandler(echo.New(), mockOrderUseCase, nil, nil)
+
+	handler.ListMine(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGetMine(t *testing.T) {
+	e := echo.New()
+	req, _ := http.NewRequest(echo.GET, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "ana@test.com"})
+	c.SetPath("/me/orders/:uuid")
+	c.SetParamNames("uuid")
+	c.SetParamValues("order-1")
+
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+
+	mockOrderUseCase.On("GetMine", mock.Anything, "ana@test.com", "order-1").Return(&domain.Order{UUID: "order-1", History: []domain.OrderHistoryEntry{{From: domain.OrderStatusPendingPayment, To: domain.OrderStatusPaid}}}, nil)
+
+	handler := NewOrderHandler(echo.New(), mockOrderUseCase, nil, nil)
+
+	handler.GetMine(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"to\":\"paid\"")
+}
+
+func TestGetMineNotFound(t *testing.T) {
+	e := echo.New()
+	req, _ := http.NewRequest(echo.GET, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "ana@test.com"})
+	c.SetPath("/me/orders/:uuid")
+	c.SetParamNames("uuid")
+	c.SetParamValues("order-2")
+
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+
+	mockOrderUseCase.On("GetMine", mock.Anything, "ana@test.com", "order-2").Return(nil, domain.ErrOrderNotFound)
+
+	handler := NewOrderHandler(echo.New(), mockOrderUseCase, nil, nil)
+
+	handler.GetMine(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
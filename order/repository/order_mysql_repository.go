@@ -0,0 +1,569 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/go-sql-driver/mysql"
+)
+
+// datetimeLayout is how the connection gives the DATETIME columns, in UTC.
+const datetimeLayout = "2006-01-02 15:04:05"
+
+// erDupEntry is the error of MySQL for a write breaking a unique key.
+const erDupEntry = 1062
+
+// isDuplicateKey tells whether the error is a write breaking the given
+// unique key, any other error, even on another unique key, is not.
+func isDuplicateKey(err error, key string) bool {
+	var mysqlErr *mysql.MySQLError
+
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == erDupEntry && strings.Contains(mysqlErr.Message, key)
+}
+
+// orderMysqlRepository reserves, commits and releases the stock of the
+// orders through the inventory repository, within its own transactions.
+type orderMysqlRepository struct {
+	Conn                *sql.DB
+	InventoryRepository domain.InventoryRepository
+}
+
+func NewOrderMysqlRepository(conn *sql.DB, ir domain.InventoryRepository) domain.OrderRepository {
+	return &orderMysqlRepository{Conn: conn, InventoryRepository: ir}
+}
+
+// orderColumns are read by scanOrder, in this order.
+const orderColumns = `id, uuid, user_id, status, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode, currency, subtotal, discount, shipping, tax, total, tracking_code, idempotency_key, request_hash, created_at, expires_at`
+
+func (omr *orderMysqlRepository) GetByIdempotencyKey(ctx context.Context, userID int64, key string) (*domain.Order, error) {
+	return omr.get(ctx, `SELECT `+orderColumns+` FROM orders WHERE user_id = ? AND idempotency_key = ?;`, userID, key)
+}
+
+func (omr *orderMysqlRepository) GetByUUID(ctx context.Context, uuid string) (*domain.Order, error) {
+	return omr.get(ctx, `SELECT `+orderColumns+` FROM orders WHERE uuid = ?;`, uuid)
+}
+
+func (omr *orderMysqlRepository) get(ctx context.Context, query string, args ...interface{}) (*domain.Order, error) {
+	o, err := scanOrder(omr.Conn.QueryRowContext(ctx, query, args...))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := omr.items(ctx, []*domain.Order{o}); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+func (omr *orderMysqlRepository) List(ctx context.Context, q domain.OrderQuery) (*domain.OrderPage, error) {
+	if q.Limit <= 0 {
+		return nil, fmt.Errorf("order limit must be positive, got %d", q.Limit)
+	}
+
+	where := []string{}
+	args := []interface{}{}
+
+	if q.UserID != 0 {
+		where = append(where, "user_id = ?")
+		args = append(args, q.UserID)
+	}
+
+	if q.Status != "" {
+		where = append(where, "status = ?")
+		args = append(args, q.Status)
+	}
+
+	if q.Cursor != "" {
+		id, err := decodeOrderCursor(q.Cursor)
+
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+
+		where = append(where, "id < ?")
+		args = append(args, id)
+	}
+
+	whereClause := ""
+	if len(where) > 0 {
+		whereClause = " WHERE " + strings.Join(where, " AND ")
+	}
+
+	rows, err := omr.Conn.QueryContext(ctx, `SELECT `+orderColumns+` FROM orders`+whereClause+` ORDER BY id DESC LIMIT ?;`, append(args, q.Limit+1)...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orders := []*domain.Order{}
+
+	for rows.Next() {
+		o, err := scanOrder(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, o)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	res := &domain.OrderPage{Orders: []domain.Order{}}
+
+	if len(orders) > q.Limit {
+		orders = orders[:q.Limit]
+		res.NextCursor = encodeOrderCursor(orders[q.Limit-1].ID)
+	}
+
+	if err := omr.items(ctx, orders); err != nil {
+		return nil, err
+	}
+
+	for _, o := range orders {
+		res.Orders = append(res.Orders, *o)
+	}
+
+	return res, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanOrder(row scanner) (*domain.Order, error) {
+	var o domain.Order
+	var currency domain.Currency
+	var createdAt, expiresAt string
+
+	err := row.Scan(
+		&o.ID, &o.UUID, &o.UserID, &o.Status,
+		&o.Address.City, &o.Address.State, &o.Address.Neighborhood, &o.Address.Street, &o.Address.Number, &o.Address.ZipCode,
+		&currency, &o.Subtotal.Amount, &o.Discount.Amount, &o.Shipping.Amount, &o.Tax.Amount, &o.Total.Amount,
+		&o.TrackingCode, &o.IdempotencyKey, &o.RequestHash, &createdAt, &expiresAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	o.Subtotal.Currency, o.Discount.Currency, o.Shipping.Currency, o.Tax.Currency, o.Total.Currency = currency, currency, currency, currency, currency
+
+	if o.CreatedAt, err = time.Parse(datetimeLayout, createdAt); err != nil {
+		return nil, err
+	}
+
+	if o.ExpiresAt, err = time.Parse(datetimeLayout, expiresAt); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// items reads the items of all the orders at once.
+func (omr *orderMysqlRepository) items(ctx context.Context, orders []*domain.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	byID := map[int64]*domain.Order{}
+	args := []interface{}{}
+
+	for _, o := range orders {
+		o.Items = []domain.OrderItem{}
+		byID[o.ID] = o
+		args = append(args, o.ID)
+	}
+
+	query := fmt.Sprintf(`SELECT order_id, product_uuid, sku, name, options, quantity, unit_price, list_price, total, reservation_uuid FROM order_item WHERE order_id IN (%s) ORDER BY id;`, placeholders(len(args)))
+
+	rows, err := omr.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderID int64
+		var item domain.OrderItem
+		var options string
+
+		if err := rows.Scan(&orderID, &item.ProductUUID, &item.SKU, &item.Name, &options, &item.Quantity, &item.UnitPrice.Amount, &item.ListPrice.Amount, &item.Total.Amount, &item.ReservationUUID); err != nil {
+			return err
+		}
+
+		if err := json.Unmarshal([]byte(options), &item.Options); err != nil {
+			return err
+		}
+
+		o := byID[orderID]
+		currency := o.Total.Currency
+		item.UnitPrice.Currency, item.ListPrice.Currency, item.Total.Currency = currency, currency, currency
+
+		o.Items = append(o.Items, item)
+	}
+
+	return rows.Err()
+}
+
+func (omr *orderMysqlRepository) GetHistory(ctx context.Context, orderID int64) ([]domain.OrderHistoryEntry, error) {
+	rows, err := omr.Conn.QueryContext(ctx, `SELECT from_status, to_status, actor, note, created_at FROM order_history WHERE order_id = ? ORDER BY id;`, orderID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []domain.OrderHistoryEntry{}
+
+	for rows.Next() {
+		var e domain.OrderHistoryEntry
+		var createdAt string
+
+		if err := rows.Scan(&e.From, &e.To, &e.Actor, &e.Note, &createdAt); err != nil {
+			return nil, err
+		}
+
+		if e.CreatedAt, err = time.Parse(datetimeLayout, createdAt); err != nil {
+			return nil, err
+		}
+
+		res = append(res, e)
+	}
+
+	return res, rows.Err()
+}
+
+// Place takes the items out of the cart only when they are still there with
+// the quantities ordered, so an order never takes what the customer changed
+// meanwhile, nor two orders the same cart.
+func (omr *orderMysqlRepository) Place(ctx context.Context, o *domain.Order, cartID int64, reservedUntil time.Time) error {
+	tx, err := omr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	o.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	o.ExpiresAt = reservedUntil.UTC().Truncate(time.Second)
+
+	// the unique key of the user and the idempotency key holds a concurrent
+	// placement with the same key until this one ends
+	query := `INSERT INTO orders (uuid, user_id, status, idempotency_key, request_hash, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode, currency, subtotal, discount, shipping, tax, total, created_at, expires_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+	exec, err := tx.ExecContext(ctx, query,
+		o.UUID, o.UserID, o.Status, o.IdempotencyKey, o.RequestHash,
+		o.Address.City, o.Address.State, o.Address.Neighborhood, o.Address.Street, o.Address.Number, o.Address.ZipCode,
+		o.Total.Currency, o.Subtotal.Amount, o.Discount.Amount, o.Shipping.Amount, o.Tax.Amount, o.Total.Amount, o.CreatedAt, o.ExpiresAt,
+	)
+
+	if err != nil {
+		tx.Rollback()
+
+		if isDuplicateKey(err, "orders_user_idempotency_key_UN") {
+			return domain.ErrOrderPlaced
+		}
+
+		return err
+	}
+
+	if o.ID, err = exec.LastInsertId(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for i := range o.Items {
+		item := &o.Items[i]
+
+		r := &domain.Reservation{SKU: item.SKU, Quantity: item.Quantity, Reference: o.UUID, ExpiresAt: reservedUntil}
+
+		if err := omr.InventoryRepository.ReserveTx(ctx, tx, r); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		item.ReservationUUID = r.UUID
+
+		options, err := json.Marshal(item.Options)
+
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		query := `INSERT INTO order_item (order_id, product_uuid, sku, name, options, quantity, unit_price, list_price, total, reservation_uuid) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+		if _, err := tx.ExecContext(ctx, query, o.ID, item.ProductUUID, item.SKU, item.Name, string(options), item.Quantity, item.UnitPrice.Amount, item.ListPrice.Amount, item.Total.Amount, item.ReservationUUID); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		exec, err := tx.ExecContext(ctx, `DELETE FROM cart_item WHERE cart_id = ? AND sku = ? AND quantity = ?;`, cartID, item.SKU, item.Quantity)
+
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		affect, err := exec.RowsAffected()
+
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if affect != 1 {
+			tx.Rollback()
+			return domain.ErrCartChanged
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	skus := make([]string, len(o.Items))
+
+	for i, item := range o.Items {
+		skus[i] = item.SKU
+	}
+
+	omr.touch(ctx, o, skus)
+
+	return nil
+}
+
+// Extend moves the deadline of the payment of the order, and the expiration
+// of the reservations of its stock with it. The reservations released
+// meanwhile stay released, the payment reserves their stock again.
+func (omr *orderMysqlRepository) Extend(ctx context.Context, o *domain.Order, until time.Time) error {
+	until = until.UTC().Truncate(time.Second)
+
+	tx, err := omr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := tx.ExecContext(ctx, `UPDATE orders SET expires_at = ? WHERE id = ? AND status = ?;`, until, o.ID, domain.OrderStatusPendingPayment)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if affect != 1 {
+		tx.Rollback()
+		return domain.ErrOrderChanged
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE stock_reservation SET expires_at = ? WHERE reference = ? AND status = ?;`, until, o.UUID, domain.ReservationStatusActive); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	o.ExpiresAt = until
+
+	return nil
+}
+
+// ListExpired gives the orders still waiting for the payment after their
+// deadline, the oldest first.
+func (omr *orderMysqlRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]domain.Order, error) {
+	rows, err := omr.Conn.QueryContext(ctx, `SELECT `+orderColumns+` FROM orders WHERE status = ? AND expires_at <= ? ORDER BY id LIMIT ?;`, domain.OrderStatusPendingPayment, now.UTC(), limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orders := []*domain.Order{}
+
+	for rows.Next() {
+		o, err := scanOrder(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, o)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := omr.items(ctx, orders); err != nil {
+		return nil, err
+	}
+
+	res := []domain.Order{}
+
+	for _, o := range orders {
+		res = append(res, *o)
+	}
+
+	return res, nil
+}
+
+// Transition moves the order out of entry.From, failing with ErrOrderChanged
+// when it is not in it anymore, applies the stock effect to the reservations
+// of the items and records the history, all in one transaction.
+func (omr *orderMysqlRepository) Transition(ctx context.Context, o *domain.Order, entry *domain.OrderHistoryEntry, stock domain.StockEffect) error {
+	tx, err := omr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := tx.ExecContext(ctx, `UPDATE orders SET status = ?, tracking_code = ? WHERE id = ? AND status = ?;`, entry.To, o.TrackingCode, o.ID, entry.From)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if affect != 1 {
+		tx.Rollback()
+		return domain.ErrOrderChanged
+	}
+
+	// the skus whose quantity available changed, a commit takes from the
+	// quantity on hand what was reserved already
+	skus := []string{}
+
+	for i := range o.Items {
+		item := &o.Items[i]
+
+		switch stock {
+		case domain.StockEffectCommit:
+			err = omr.InventoryRepository.CommitTx(ctx, tx, item.ReservationUUID)
+
+			if errors.Is(err, domain.ErrReservationNotFound) {
+				err = omr.reserveAgain(ctx, tx, o, item)
+				skus = append(skus, item.SKU)
+			}
+		case domain.StockEffectRelease:
+			err = omr.InventoryRepository.ReleaseTx(ctx, tx, item.ReservationUUID, domain.MovementReasonRelease)
+
+			// released when it expired
+			if errors.Is(err, domain.ErrReservationNotFound) {
+				err = nil
+			} else {
+				skus = append(skus, item.SKU)
+			}
+		}
+
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	entry.CreatedAt = time.Now().UTC().Truncate(time.Second)
+
+	query := `INSERT INTO order_history (order_id, from_status, to_status, actor, note, created_at) VALUES (?, ?, ?, ?, ?, ?);`
+
+	if _, err := tx.ExecContext(ctx, query, o.ID, entry.From, entry.To, entry.Actor, entry.Note, entry.CreatedAt); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	omr.touch(ctx, o, skus)
+
+	return nil
+}
+
+// touch touches the products of the skus whose stock the order changed, the
+// order is already written so an error is only logged.
+func (omr *orderMysqlRepository) touch(ctx context.Context, o *domain.Order, skus []string) {
+	if err := omr.InventoryRepository.TouchProducts(ctx, skus); err != nil {
+		log.Printf("Error trying to touch the products of the order %s: %s", o.UUID, err.Error())
+	}
+}
+
+// reserveAgain makes again, from the stock available, the reservation of an
+// item that expired before the payment and commits it, failing with
+// ErrInsufficientStock when there is not enough anymore.
+func (omr *orderMysqlRepository) reserveAgain(ctx context.Context, tx *sql.Tx, o *domain.Order, item *domain.OrderItem) error {
+	r := &domain.Reservation{SKU: item.SKU, Quantity: item.Quantity, Reference: o.UUID, ExpiresAt: time.Now().UTC()}
+
+	if err := omr.InventoryRepository.ReserveTx(ctx, tx, r); err != nil {
+		return err
+	}
+
+	if err := omr.InventoryRepository.CommitTx(ctx, tx, r.UUID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE order_item SET reservation_uuid = ? WHERE order_id = ? AND reservation_uuid = ?;`, r.UUID, o.ID, item.ReservationUUID); err != nil {
+		return err
+	}
+
+	item.ReservationUUID = r.UUID
+
+	return nil
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func encodeOrderCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+func decodeOrderCursor(s string) (int64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(string(b), 10, 64)
+}
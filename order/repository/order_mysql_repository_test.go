@@ -0,0 +1,533 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	_mock "github.com/stretchr/testify/mock"
+)
+
+func orderRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "uuid", "user_id", "status", "address_city", "address_state", "address_neighborhood", "address_street", "address_number", "address_zipcode", "currency", "subtotal", "discount", "shipping", "tax", "total", "tracking_code", "idempotency_key", "request_hash", "created_at", "expires_at"})
+}
+
+func itemRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"order_id", "product_uuid", "sku", "name", "options", "quantity", "unit_price", "list_price", "total", "reservation_uuid"})
+}
+
+func placedOrder() *domain.Order {
+	brl := func(amount int64) domain.Money { return domain.Money{Amount: amount, Currency: domain.CurrencyBRL} }
+
+	return &domain.Order{
+		UUID:   "order-1",
+		UserID: 7,
+		Status: domain.OrderStatusPendingPayment,
+		Items: []domain.OrderItem{
+			{ProductUUID: "p7", SKU: "P7-M", Name: "Shirt", Options: []domain.VariantOption{{Label: "size", Value: "M"}}, Quantity: 2, UnitPrice: brl(4000), ListPrice: brl(5000), Total: brl(8000)},
+		},
+		Address:        domain.UserAddress{City: "Recife", State: "PE", Neighborhood: "Boa Viagem", Street: "Rua A", Number: "10", ZipCode: "51020000"},
+		Subtotal:       brl(10000),
+		Discount:       brl(2000),
+		Shipping:       brl(1500),
+		Tax:            brl(0),
+		Total:          brl(9500),
+		IdempotencyKey: "key-1",
+		RequestHash:    "hash",
+	}
+}
+
+func TestGetByIdempotencyKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, uuid, user_id, status, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode, currency, subtotal, discount, shipping, tax, total, tracking_code, idempotency_key, request_hash, created_at, expires_at FROM orders WHERE user_id = ? AND idempotency_key = ?;")).
+		WithArgs(7, "key-1").
+		WillReturnRows(orderRows().AddRow(3, "order-1", 7, "pending_payment", "Recife", "PE", "Boa Viagem", "Rua A", "10", "51020000", "BRL", 10000, 2000, 1500, 0, 9500, "", "key-1", "hash", "2026-10-19 12:00:00", "2026-10-19 14:00:00"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT order_id, product_uuid, sku, name, options, quantity, unit_price, list_price, total, reservation_uuid FROM order_item WHERE order_id IN (?) ORDER BY id;")).
+		WithArgs(3).
+		WillReturnRows(itemRows().AddRow(3, "p7", "P7-M", "Shirt", `[{"label":"size","value":"M"}]`, 2, 4000, 5000, 8000, "res-1"))
+
+	order, err := NewOrderMysqlRepository(db, new(mocks.MockInventoryRepository)).GetByIdempotencyKey(context.Background(), 7, "key-1")
+
+	expected := placedOrder()
+	expected.ID = 3
+	expected.Items[0].ReservationUUID = "res-1"
+	expected.CreatedAt = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	expected.ExpiresAt = time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, order)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetByIdempotencyKeyNotExists(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM orders WHERE user_id = ? AND idempotency_key = ?;")).WillReturnRows(orderRows())
+
+	order, err := NewOrderMysqlRepository(db, new(mocks.MockInventoryRepository)).GetByIdempotencyKey(context.Background(), 7, "key-1")
+
+	assert.NoError(t, err)
+	assert.Nil(t, order)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPlace(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	reservedUntil := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	order := placedOrder()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO orders (uuid, user_id, status, idempotency_key, request_hash, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode, currency, subtotal, discount, shipping, tax, total, created_at, expires_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+		WithArgs("order-1", 7, "pending_payment", "key-1", "hash", "Recife", "PE", "Boa Viagem", "Rua A", "10", "51020000", "BRL", 10000, 2000, 1500, 0, 9500, sqlmock.AnyArg(), reservedUntil).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO order_item (order_id, product_uuid, sku, name, options, quantity, unit_price, list_price, total, reservation_uuid) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+		WithArgs(3, "p7", "P7-M", "Shirt", `[{"label":"size","value":"M"}]`, 2, 4000, 5000, 8000, "res-1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM cart_item WHERE cart_id = ? AND sku = ? AND quantity = ?;")).
+		WithArgs(5, "P7-M", 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	inventory := new(mocks.MockInventoryRepository)
+
+	inventory.On("ReserveTx", _mock.Anything, _mock.AnythingOfType("*sql.Tx"), _mock.MatchedBy(func(r *domain.Reservation) bool {
+		return r.SKU == "P7-M" && r.Quantity == 2 && r.Reference == "order-1" && r.ExpiresAt.Equal(reservedUntil)
+	})).Run(func(args _mock.Arguments) {
+		args.Get(2).(*domain.Reservation).UUID = "res-1"
+	}).Return(nil)
+	inventory.On("TouchProducts", _mock.Anything, []string{"P7-M"}).Return(nil)
+
+	err = NewOrderMysqlRepository(db, inventory).Place(context.Background(), order, 5, reservedUntil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), order.ID)
+	assert.Equal(t, "res-1", order.Items[0].ReservationUUID)
+	assert.False(t, order.CreatedAt.IsZero())
+	assert.Equal(t, reservedUntil, order.ExpiresAt)
+	// the arguments were matched when the calls were made, the transaction
+	// they got is finished now and printing it races with database/sql
+	inventory.AssertNumberOfCalls(t, "ReserveTx", 1)
+	inventory.AssertNumberOfCalls(t, "TouchProducts", 1)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPlaceAlreadyPlaced(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO orders")).
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '7-key-1' for key 'orders.orders_user_idempotency_key_UN'"})
+	mock.ExpectRollback()
+
+	err = NewOrderMysqlRepository(db, new(mocks.MockInventoryRepository)).Place(context.Background(), placedOrder(), 5, time.Now())
+
+	assert.ErrorIs(t, err, domain.ErrOrderPlaced)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPlaceOtherInsertErrors(t *testing.T) {
+	for _, insertErr := range []error{
+		&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'uuid' for key 'orders.orders_uuid_UN'"},
+		&mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails"},
+		errors.New("connection lost"),
+	} {
+		db, mock, err := sqlmock.New()
+
+		if err != nil {
+			t.Fatalf("error when opening a stub database conn %s", err)
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO orders")).WillReturnError(insertErr)
+		mock.ExpectRollback()
+
+		err = NewOrderMysqlRepository(db, new(mocks.MockInventoryRepository)).Place(context.Background(), placedOrder(), 5, time.Now())
+
+		assert.Equal(t, insertErr, err)
+		assert.NotErrorIs(t, err, domain.ErrOrderPlaced)
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestPlaceInsufficientStock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO orders")).WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectRollback()
+
+	inventory := new(mocks.MockInventoryRepository)
+
+	inventory.On("ReserveTx", _mock.Anything, _mock.AnythingOfType("*sql.Tx"), _mock.AnythingOfType("*domain.Reservation")).Return(domain.ErrInsufficientStock)
+
+	err = NewOrderMysqlRepository(db, inventory).Place(context.Background(), placedOrder(), 5, time.Now())
+
+	assert.ErrorIs(t, err, domain.ErrInsufficientStock)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPlaceCartChanged(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO orders")).WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO order_item")).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM cart_item")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	inventory := new(mocks.MockInventoryRepository)
+
+	inventory.On("ReserveTx", _mock.Anything, _mock.AnythingOfType("*sql.Tx"), _mock.AnythingOfType("*domain.Reservation")).Return(nil)
+
+	err = NewOrderMysqlRepository(db, inventory).Place(context.Background(), placedOrder(), 5, time.Now())
+
+	assert.ErrorIs(t, err, domain.ErrCartChanged)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestListByUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM orders WHERE user_id = ? AND id < ? ORDER BY id DESC LIMIT ?;")).
+		WithArgs(7, 10, 3).
+		WillReturnRows(orderRows().
+			AddRow(9, "order-9", 7, "shipped", "Recife", "PE", "Boa Viagem", "Rua A", "10", "51020000", "BRL", 5000, 0, 1500, 0, 6500, "BR123", "key-9", "hash", "2026-10-19 12:00:00", "2026-10-19 14:00:00").
+			AddRow(8, "order-8", 7, "paid", "Recife", "PE", "Boa Viagem", "Rua A", "10", "51020000", "BRL", 5000, 0, 1500, 0, 6500, "", "key-8", "hash", "2026-10-18 12:00:00", "2026-10-18 14:00:00").
+			AddRow(4, "order-4", 7, "delivered", "Recife", "PE", "Boa Viagem", "Rua A", "10", "51020000", "BRL", 5000, 0, 1500, 0, 6500, "", "key-4", "hash", "2026-10-17 12:00:00", "2026-10-17 14:00:00"))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM order_item WHERE order_id IN (?, ?) ORDER BY id;")).
+		WithArgs(9, 8).
+		WillReturnRows(itemRows().
+			AddRow(8, "p7", "P7-M", "Shirt", `[]`, 1, 5000, 5000, 5000, "res-8").
+			AddRow(9, "p7", "P7-G", "Shirt", `[]`, 1, 5000, 5000, 5000, "res-9"))
+
+	page, err := NewOrderMysqlRepository(db, new(mocks.MockInventoryRepository)).List(context.Background(), domain.OrderQuery{UserID: 7, Cursor: encodeOrderCursor(10), Limit: 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Orders, 2)
+	assert.Equal(t, "order-9", page.Orders[0].UUID)
+	assert.Equal(t, "BR123", page.Orders[0].TrackingCode)
+	assert.Equal(t, "P7-G", page.Orders[0].Items[0].SKU)
+	assert.Equal(t, "P7-M", page.Orders[1].Items[0].SKU)
+	assert.Equal(t, encodeOrderCursor(8), page.NextCursor)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestListInvalidCursor(t *testing.T) {
+	db, _, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	_, err = NewOrderMysqlRepository(db, new(mocks.MockInventoryRepository)).List(context.Background(), domain.OrderQuery{Cursor: "!", Limit: 20})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
+
+func TestGetHistory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT from_status, to_status, actor, note, created_at FROM order_history WHERE order_id = ? ORDER BY id;")).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"from_status", "to_status", "actor", "note", "created_at"}).
+			AddRow("pending_payment", "paid", "admin@test.com", "", "2026-10-19 12:10:00"))
+
+	history, err := NewOrderMysqlRepository(db, new(mocks.MockInventoryRepository)).GetHistory(context.Background(), 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.OrderHistoryEntry{
+		{From: domain.OrderStatusPendingPayment, To: domain.OrderStatusPaid, Actor: "admin@test.com", CreatedAt: time.Date(2026, 10, 19, 12, 10, 0, 0, time.UTC)},
+	}, history)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTransitionCommitsStock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	order := placedOrder()
+	order.ID = 3
+	order.Items[0].ReservationUUID = "res-1"
+
+	entry := &domain.OrderHistoryEntry{From: domain.OrderStatusPendingPayment, To: domain.OrderStatusPaid, Actor: "admin@test.com"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE orders SET status = ?, tracking_code = ? WHERE id = ? AND status = ?;")).
+		WithArgs("paid", "", 3, "pending_payment").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO order_history (order_id, from_status, to_status, actor, note, created_at) VALUES (?, ?, ?, ?, ?, ?);")).
+		WithArgs(3, "pending_payment", "paid", "admin@test.com", "", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	inventory := new(mocks.MockInventoryRepository)
+
+	inventory.On("CommitTx", _mock.Anything, _mock.AnythingOfType("*sql.Tx"), "res-1").Return(nil)
+	inventory.On("TouchProducts", _mock.Anything, []string{}).Return(nil)
+
+	err = NewOrderMysqlRepository(db, inventory).Transition(context.Background(), order, entry, domain.StockEffectCommit)
+
+	assert.NoError(t, err)
+	assert.False(t, entry.CreatedAt.IsZero())
+	inventory.AssertNumberOfCalls(t, "CommitTx", 1)
+	inventory.AssertNumberOfCalls(t, "TouchProducts", 1)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTransitionReservesExpiredStockAgain(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	order := placedOrder()
+	order.ID = 3
+	order.Items[0].ReservationUUID = "res-1"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE orders SET status = ?")).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE order_item SET reservation_uuid = ? WHERE order_id = ? AND reservation_uuid = ?;")).
+		WithArgs("res-2", 3, "res-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO order_history")).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	inventory := new(mocks.MockInventoryRepository)
+
+	inventory.On("CommitTx", _mock.Anything, _mock.AnythingOfType("*sql.Tx"), "res-1").Return(domain.ErrReservationNotFound)
+	inventory.On("ReserveTx", _mock.Anything, _mock.AnythingOfType("*sql.Tx"), _mock.MatchedBy(func(r *domain.Reservation) bool {
+		return r.SKU == "P7-M" && r.Quantity == 2 && r.Reference == "order-1"
+	})).Run(func(args _mock.Arguments) {
+		args.Get(2).(*domain.Reservation).UUID = "res-2"
+	}).Return(nil)
+	inventory.On("CommitTx", _mock.Anything, _mock.AnythingOfType("*sql.Tx"), "res-2").Return(nil)
+	inventory.On("TouchProducts", _mock.Anything, []string{"P7-M"}).Return(nil)
+
+	err = NewOrderMysqlRepository(db, inventory).Transition(context.Background(), order, &domain.OrderHistoryEntry{From: domain.OrderStatusPendingPayment, To: domain.OrderStatusPaid}, domain.StockEffectCommit)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "res-2", order.Items[0].ReservationUUID)
+	inventory.AssertNumberOfCalls(t, "CommitTx", 2)
+	inventory.AssertNumberOfCalls(t, "ReserveTx", 1)
+	inventory.AssertNumberOfCalls(t, "TouchProducts", 1)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTransitionReleasesStock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	order := placedOrder()
+	order.ID = 3
+	order.Items[0].ReservationUUID = "res-1"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE orders SET status = ?")).
+		WithArgs("cancelled", "", 3, "pending_payment").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO order_history")).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	inventory := new(mocks.MockInventoryRepository)
+
+	inventory.On("ReleaseTx", _mock.Anything, _mock.AnythingOfType("*sql.Tx"), "res-1", domain.MovementReasonRelease).Return(nil)
+	inventory.On("TouchProducts", _mock.Anything, []string{"P7-M"}).Return(nil)
+
+	err = NewOrderMysqlRepository(db, inventory).Transition(context.Background(), order, &domain.OrderHistoryEntry{From: domain.OrderStatusPendingPayment, To: domain.OrderStatusCancelled}, domain.StockEffectRelease)
+
+	assert.NoError(t, err)
+	inventory.AssertNumberOfCalls(t, "ReleaseTx", 1)
+	inventory.AssertNumberOfCalls(t, "TouchProducts", 1)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTransitionOrderChanged(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	order := placedOrder()
+	order.ID = 3
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE orders SET status = ?")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err = NewOrderMysqlRepository(db, new(mocks.MockInventoryRepository)).Transition(context.Background(), order, &domain.OrderHistoryEntry{From: domain.OrderStatusPendingPayment, To: domain.OrderStatusPaid}, domain.StockEffectCommit)
+
+	assert.ErrorIs(t, err, domain.ErrOrderChanged)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExtend(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	until := time.Date(2026, 10, 25, 12, 0, 0, 0, time.UTC)
+	order := placedOrder()
+	order.ID = 3
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE orders SET expires_at = ? WHERE id = ? AND status = ?;")).
+		WithArgs(until, 3, "pending_payment").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE stock_reservation SET expires_at = ? WHERE reference = ? AND status = ?;")).
+		WithArgs(until, "order-1", "active").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = NewOrderMysqlRepository(db, new(mocks.MockInventoryRepository)).Extend(context.Background(), order, until)
+
+	assert.NoError(t, err)
+	assert.Equal(t, until, order.ExpiresAt)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExtendOrderChanged(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	order := placedOrder()
+	order.ID = 3
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE orders SET expires_at = ? WHERE id = ? AND status = ?;")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err = NewOrderMysqlRepository(db, new(mocks.MockInventoryRepository)).Extend(context.Background(), order, time.Now())
+
+	assert.ErrorIs(t, err, domain.ErrOrderChanged)
+	assert.True(t, order.ExpiresAt.IsZero())
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestListExpired(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	now := time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM orders WHERE status = ? AND expires_at <= ? ORDER BY id LIMIT ?;")).
+		WithArgs("pending_payment", now, 100).
+		WillReturnRows(orderRows().AddRow(3, "order-1", 7, "pending_payment", "Recife", "PE", "Boa Viagem", "Rua A", "10", "51020000", "BRL", 10000, 2000, 1500, 0, 9500, "", "key-1", "hash", "2026-10-19 12:00:00", "2026-10-19 14:00:00"))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM order_item WHERE order_id IN (?) ORDER BY id;")).
+		WithArgs(3).
+		WillReturnRows(itemRows().AddRow(3, "p7", "P7-M", "Shirt", `[]`, 2, 4000, 5000, 8000, "res-1"))
+
+	orders, err := NewOrderMysqlRepository(db, new(mocks.MockInventoryRepository)).ListExpired(context.Background(), now, 100)
+
+	assert.NoError(t, err)
+	assert.Len(t, orders, 1)
+	assert.Equal(t, "order-1", orders[0].UUID)
+	assert.Equal(t, "res-1", orders[0].Items[0].ReservationUUID)
+	assert.Equal(t, time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC), orders[0].ExpiresAt)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+// checkoutService charges a flat shipping plus an amount per unit, free from
+// a total of the items on when freeShippingFrom is set, and the tax in basis
+// points of the total of the items.
+type checkoutService struct {
+	shipping         int64
+	shippingPerUnit  int64
+	freeShippingFrom int64
+	taxRate          int64
+}
+
+func NewCheckoutService(shipping int64, shippingPerUnit int64, freeShippingFrom int64, taxRate int64) *checkoutService {
+	return &checkoutService{shipping: shipping, shippingPerUnit: shippingPerUnit, freeShippingFrom: freeShippingFrom, taxRate: taxRate}
+}
+
+func (cs *checkoutService) Totals(ctx context.Context, o *domain.Order) error {
+	currency := domain.DefaultCurrency
+
+	if len(o.Items) > 0 {
+		currency = o.Items[0].Total.Currency
+	}
+
+	var subtotal, discount, units int64
+
+	for _, item := range o.Items {
+		if item.Total.Currency != currency || item.ListPrice.Currency != currency {
+			return domain.ErrCurrencyMismatch
+		}
+
+		list := item.ListPrice.Amount * item.Quantity
+
+		// a price list above the list price is no discount
+		if list < item.Total.Amount {
+			list = item.Total.Amount
+		}
+
+		subtotal += list
+		discount += list - item.Total.Amount
+		units += item.Quantity
+	}
+
+	items := subtotal - discount
+
+	var shipping int64
+
+	if cs.freeShippingFrom == 0 || items < cs.freeShippingFrom {
+		shipping = cs.shipping + cs.shippingPerUnit*units
+	}
+
+	// rounded half up to the cent
+	tax := (items*cs.taxRate + 5000) / 10000
+
+	o.Subtotal = domain.Money{Amount: subtotal, Currency: currency}
+	o.Discount = domain.Money{Amount: discount, Currency: currency}
+	o.Shipping = domain.Money{Amount: shipping, Currency: currency}
+	o.Tax = domain.Money{Amount: tax, Currency: currency}
+	o.Total = domain.Money{Amount: items + shipping + tax, Currency: currency}
+
+	return nil
+}
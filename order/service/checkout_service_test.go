@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func brl(amount int64) domain.Money {
+	return domain.Money{Amount: amount, Currency: domain.CurrencyBRL}
+}
+
+func TestTotals(t *testing.T) {
+	order := &domain.Order{Items: []domain.OrderItem{
+		{SKU: "P7-M", Quantity: 2, UnitPrice: brl(4000), ListPrice: brl(5000), Total: brl(8000)},
+		{SKU: "P8-U", Quantity: 1, UnitPrice: brl(1999), ListPrice: brl(1999), Total: brl(1999)},
+	}}
+
+	err := NewCheckoutService(1500, 200, 0, 1800).Totals(context.Background(), order)
+
+	assert.NoError(t, err)
+	assert.Equal(t, brl(11999), order.Subtotal)
+	assert.Equal(t, brl(2000), order.Discount)
+	assert.Equal(t, brl(2100), order.Shipping)
+	assert.Equal(t, brl(1800), order.Tax)
+	assert.Equal(t, brl(13899), order.Total)
+}
+
+func TestTotalsFreeShipping(t *testing.T) {
+	order := &domain.Order{Items: []domain.OrderItem{
+		{SKU: "P7-M", Quantity: 2, UnitPrice: brl(4000), ListPrice: brl(5000), Total: brl(8000)},
+	}}
+
+	err := NewCheckoutService(1500, 200, 8000, 0).Totals(context.Background(), order)
+
+	assert.NoError(t, err)
+	assert.Equal(t, brl(0), order.Shipping)
+	assert.Equal(t, brl(8000), order.Total)
+}
+
+func TestTotalsPriceListAboveListPrice(t *testing.T) {
+	order := &domain.Order{Items: []domain.OrderItem{
+		{SKU: "P7-M", Quantity: 2, UnitPrice: brl(5500), ListPrice: brl(5000), Total: brl(11000)},
+	}}
+
+	err := NewCheckoutService(0, 0, 0, 0).Totals(context.Background(), order)
+
+	assert.NoError(t, err)
+	assert.Equal(t, brl(11000), order.Subtotal)
+	assert.Equal(t, brl(0), order.Discount)
+	assert.Equal(t, brl(11000), order.Total)
+}
+
+func TestTotalsCurrencyMismatch(t *testing.T) {
+	order := &domain.Order{Items: []domain.OrderItem{
+		{SKU: "P7-M", Quantity: 1, ListPrice: brl(5000), Total: brl(5000)},
+		{SKU: "P8-U", Quantity: 1, ListPrice: domain.Money{Amount: 10, Currency: "USD"}, Total: domain.Money{Amount: 10, Currency: "USD"}},
+	}}
+
+	err := NewCheckoutService(0, 0, 0, 0).Totals(context.Background(), order)
+
+	assert.ErrorIs(t, err, domain.ErrCurrencyMismatch)
+}
@@ -0,0 +1,358 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/google/uuid"
+)
+
+// reservationTTL is how long the stock of a placed order is held waiting for
+// its payment.
+const reservationTTL = 2 * time.Hour
+
+// orderMessages tell the customers about the changes of status of their
+// orders.
+var orderMessages = map[domain.OrderStatus]struct{ subject, message string }{
+	domain.OrderStatusPaid:      {"Pagamento confirmado", "O pagamento do pedido %s foi confirmado"},
+	domain.OrderStatusShipped:   {"Pedido enviado", "O pedido %s foi enviado"},
+	domain.OrderStatusDelivered: {"Pedido entregue", "O pedido %s foi entregue"},
+	domain.OrderStatusCancelled: {"Pedido cancelado", "O pedido %s foi cancelado"},
+	domain.OrderStatusReturned:  {"Devolução recebida", "Recebemos a devolução do pedido %s"},
+	domain.OrderStatusRefunded:  {"Pedido reembolsado", "O pedido %s foi reembolsado"},
+}
+
+type orderUseCase struct {
+	orderRepo       domain.OrderRepository
+	userRepo        domain.UserRepository
+	cartUseCase     domain.CartUseCase
+	checkoutService domain.CheckoutService
+	messageService  domain.MessageService
+	paymentRepo     domain.PaymentRepository
+}
+
+func NewOrderUseCase(or domain.OrderRepository, ur domain.UserRepository, cuc domain.CartUseCase, cs domain.CheckoutService, ms domain.MessageService, pr domain.PaymentRepository) domain.OrderUseCase {
+	return &orderUseCase{orderRepo: or, userRepo: ur, cartUseCase: cuc, checkoutService: cs, messageService: ms, paymentRepo: pr}
+}
+
+// Place snapshots the cart as it is priced now, shipped to the given address
+// or, without one, to the address of the user. The idempotency key is tied
+// to the request it came with, another request with the same key is refused.
+func (ou *orderUseCase) Place(ctx context.Context, login string, idempotencyKey string, address *domain.UserAddress) (*domain.Order, bool, error) {
+	user, err := ou.user(ctx, login)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	hash, err := requestHash(address)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	placed, err := ou.placed(ctx, user.ID, idempotencyKey, hash)
+
+	if err != nil || placed != nil {
+		return placed, placed != nil, err
+	}
+
+	cart, err := ou.cartUseCase.Get(ctx, login, "")
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(cart.Items) == 0 {
+		return nil, false, domain.ErrCartEmpty
+	}
+
+	order := &domain.Order{
+		UUID:           uuid.NewString(),
+		UserID:         user.ID,
+		Status:         domain.OrderStatusPendingPayment,
+		Items:          []domain.OrderItem{},
+		Address:        user.Address,
+		IdempotencyKey: idempotencyKey,
+		RequestHash:    hash,
+	}
+
+	if address != nil {
+		order.Address = *address
+	}
+
+	for _, item := range cart.Items {
+		if item.Status != domain.CartItemStatusAvailable {
+			return nil, false, domain.ErrCartUnavailable
+		}
+
+		order.Items = append(order.Items, domain.OrderItem{
+			ProductUUID: item.ProductUUID,
+			SKU:         item.SKU,
+			Name:        item.Name,
+			Options:     item.Options,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.Price.Unit,
+			ListPrice:   item.Price.List,
+			Total:       item.Price.Total,
+		})
+	}
+
+	if err := ou.checkoutService.Totals(ctx, order); err != nil {
+		return nil, false, err
+	}
+
+	err = ou.orderRepo.Place(ctx, order, cart.ID, time.Now().Add(reservationTTL))
+
+	// a concurrent request with the same key placed the order first
+	if errors.Is(err, domain.ErrOrderPlaced) {
+		placed, err := ou.placed(ctx, user.ID, idempotencyKey, hash)
+
+		if err == nil && placed == nil {
+			err = domain.ErrOrderPlaced
+		}
+
+		return placed, placed != nil, err
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return order, false, nil
+}
+
+func (ou *orderUseCase) ListMine(ctx context.Context, login string, q domain.OrderQuery) (*domain.OrderPage, error) {
+	user, err := ou.user(ctx, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q.UserID = user.ID
+
+	return ou.orderRepo.List(ctx, q)
+}
+
+// GetMine gives the order with its history, without the staff members who
+// made the changes.
+func (ou *orderUseCase) GetMine(ctx context.Context, login string, uuid string) (*domain.Order, error) {
+	user, err := ou.user(ctx, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := ou.orderRepo.GetByUUID(ctx, uuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil || order.UserID != user.ID {
+		return nil, domain.ErrOrderNotFound
+	}
+
+	if order.History, err = ou.orderRepo.GetHistory(ctx, order.ID); err != nil {
+		return nil, err
+	}
+
+	for i := range order.History {
+		order.History[i].Actor = ""
+	}
+
+	return order, nil
+}
+
+func (ou *orderUseCase) List(ctx context.Context, q domain.OrderQuery) (*domain.OrderPage, error) {
+	return ou.orderRepo.List(ctx, q)
+}
+
+func (ou *orderUseCase) Get(ctx context.Context, uuid string) (*domain.Order, error) {
+	order, err := ou.orderRepo.GetByUUID(ctx, uuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil {
+		return nil, domain.ErrOrderNotFound
+	}
+
+	if order.History, err = ou.orderRepo.GetHistory(ctx, order.ID); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// Transition moves the order along domain.OrderTransitions once the guards
+// let it, and tells the customer. A failure to tell them does not undo it.
+func (ou *orderUseCase) Transition(ctx context.Context, uuid string, actor string, t *domain.OrderTransitionRequest) (*domain.Order, error) {
+	order, err := ou.orderRepo.GetByUUID(ctx, uuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil {
+		return nil, domain.ErrOrderNotFound
+	}
+
+	stock, ok := domain.OrderTransitions[order.Status][t.Status]
+
+	if !ok {
+		return nil, &domain.TransitionError{From: order.Status, To: t.Status}
+	}
+
+	if err := ou.guard(ctx, order, t); err != nil {
+		return nil, err
+	}
+
+	if t.Status == domain.OrderStatusShipped {
+		order.TrackingCode = t.TrackingCode
+	}
+
+	entry := &domain.OrderHistoryEntry{From: order.Status, To: t.Status, Actor: actor, Note: t.Note}
+
+	if err := ou.orderRepo.Transition(ctx, order, entry, stock); err != nil {
+		return nil, err
+	}
+
+	order.Status = t.Status
+
+	if order.History, err = ou.orderRepo.GetHistory(ctx, order.ID); err != nil {
+		return nil, err
+	}
+
+	// the message service takes seconds to answer, the transition is already
+	// committed and does not wait for the customer to be told
+	notified := *order
+
+	go func() {
+		if err := ou.notify(context.Background(), &notified); err != nil {
+			log.Printf("Error trying to notify the order %s: %s", notified.UUID, err.Error())
+		}
+	}()
+
+	return order, nil
+}
+
+// guard refuses the transitions allowed that the order is not ready for, an
+// order is only paid by the payments captured for it and only refunded once
+// they are refunded.
+func (ou *orderUseCase) guard(ctx context.Context, o *domain.Order, t *domain.OrderTransitionRequest) error {
+	if t.Status == domain.OrderStatusShipped && t.TrackingCode == "" {
+		return &domain.TransitionError{From: o.Status, To: t.Status, Reason: "a tracking code is required"}
+	}
+
+	if t.Status != domain.OrderStatusPaid && t.Status != domain.OrderStatusRefunded {
+		return nil
+	}
+
+	payments, err := ou.paymentRepo.ListByOrder(ctx, o.ID)
+
+	if err != nil {
+		return err
+	}
+
+	if t.Status == domain.OrderStatusRefunded {
+		for _, p := range payments {
+			if p.Status == domain.PaymentStatusCaptured {
+				return &domain.TransitionError{From: o.Status, To: t.Status, Reason: "the payments captured were not refunded"}
+			}
+		}
+
+		return nil
+	}
+
+	var captured int64
+
+	for _, p := range payments {
+		if p.Status == domain.PaymentStatusCaptured && p.Amount.Currency == o.Total.Currency {
+			captured += p.Amount.Amount
+		}
+	}
+
+	if captured < o.Total.Amount {
+		return &domain.TransitionError{From: o.Status, To: t.Status, Reason: "the payments captured do not cover the total"}
+	}
+
+	return nil
+}
+
+func (ou *orderUseCase) notify(ctx context.Context, o *domain.Order) error {
+	text, ok := orderMessages[o.Status]
+
+	if !ok {
+		return nil
+	}
+
+	user, err := ou.userRepo.GetByID(ctx, o.UserID)
+
+	if err != nil || user == nil {
+		return err
+	}
+
+	var messageConf domain.MessageConfig
+
+	messageConf.Medium = "email"
+	messageConf.To = user.Email
+	messageConf.Subject = text.subject
+	messageConf.Message = fmt.Sprintf(text.message, o.UUID)
+	messageConf.Category = domain.NotificationCategoryOrders
+	messageConf.User = user
+
+	if o.Status == domain.OrderStatusShipped {
+		messageConf.Message += fmt.Sprintf(", código de rastreio %s", o.TrackingCode)
+	}
+
+	return ou.messageService.SendMessage(ctx, &messageConf)
+}
+
+func (ou *orderUseCase) user(ctx context.Context, login string) (*domain.User, error) {
+	user, err := ou.userRepo.GetByEmail(ctx, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+func (ou *orderUseCase) placed(ctx context.Context, userID int64, idempotencyKey string, hash string) (*domain.Order, error) {
+	order, err := ou.orderRepo.GetByIdempotencyKey(ctx, userID, idempotencyKey)
+
+	if err != nil || order == nil {
+		return nil, err
+	}
+
+	if order.RequestHash != hash {
+		return nil, domain.ErrIdempotencyKeyReused
+	}
+
+	return order, nil
+}
+
+func requestHash(address *domain.UserAddress) (string, error) {
+	b, err := json.Marshal(address)
+
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:]), nil
+}
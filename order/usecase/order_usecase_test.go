@@ -0,0 +1,342 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func brl(amount int64) domain.Money {
+	return domain.Money{Amount: amount, Currency: domain.CurrencyBRL}
+}
+
+func userRepoWithAna() *mocks.MockUserRepository {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockUserRepo.On("GetByEmail", mock.Anything, "ana@test.com").Return(7, "u7", "ana@test.com", "Ana", "Lima", "", "Recife", "PE", "Boa Viagem", "Rua A", "10", "51020000", nil)
+	return mockUserRepo
+}
+
+func cartWith(items ...domain.CartItem) *mocks.MockCartUseCase {
+	mockCartUseCase := new(mocks.MockCartUseCase)
+	mockCartUseCase.On("Get", mock.Anything, "ana@test.com", "").Return(&domain.Cart{ID: 5, UserID: 7, Items: items}, nil)
+	return mockCartUseCase
+}
+
+var shirt = domain.CartItem{
+	ProductUUID: "p7",
+	SKU:         "P7-M",
+	Quantity:    2,
+	Name:        "Camiseta",
+	Options:     []domain.VariantOption{{Label: "size", Value: "M"}},
+	Price:       &domain.EffectivePrice{Unit: brl(4000), List: brl(5000), Total: brl(8000), Quantity: 2, OnSale: true},
+	Status:      domain.CartItemStatusAvailable,
+}
+
+func TestPlace(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockCheckoutService := new(mocks.MockCheckoutService)
+
+	mockOrderRepo.On("GetByIdempotencyKey", mock.Anything, int64(7), "key-1").Return(nil, nil)
+	mockCheckoutService.On("Totals", mock.Anything, mock.AnythingOfType("*domain.Order")).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Order).Total = brl(9500)
+	})
+	mockOrderRepo.On("Place", mock.Anything, mock.AnythingOfType("*domain.Order"), int64(5), mock.AnythingOfType("time.Time")).Return(nil)
+
+	order, replayed, err := NewOrderUseCase(mockOrderRepo, userRepoWithAna(), cartWith(shirt), mockCheckoutService, nil, nil).Place(context.Background(), "ana@test.com", "key-1", nil)
+
+	hash, _ := requestHash(nil)
+
+	assert.NoError(t, err)
+	assert.False(t, replayed)
+	assert.NotEmpty(t, order.UUID)
+	assert.Equal(t, int64(7), order.UserID)
+	assert.Equal(t, domain.OrderStatusPendingPayment, order.Status)
+	assert.Equal(t, domain.UserAddress{City: "Recife", State: "PE", Neighborhood: "Boa Viagem", Street: "Rua A", Number: "10", ZipCode: "51020000"}, order.Address)
+	assert.Equal(t, []domain.OrderItem{
+		{ProductUUID: "p7", SKU: "P7-M", Name: "Camiseta", Options: []domain.VariantOption{{Label: "size", Value: "M"}}, Quantity: 2, UnitPrice: brl(4000), ListPrice: brl(5000), Total: brl(8000)},
+	}, order.Items)
+	assert.Equal(t, brl(9500), order.Total)
+	assert.Equal(t, "key-1", order.IdempotencyKey)
+	assert.Equal(t, hash, order.RequestHash)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func TestPlaceToAddress(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockCheckoutService := new(mocks.MockCheckoutService)
+
+	mockOrderRepo.On("GetByIdempotencyKey", mock.Anything, int64(7), "key-1").Return(nil, nil)
+	mockCheckoutService.On("Totals", mock.Anything, mock.Anything).Return(nil)
+	mockOrderRepo.On("Place", mock.Anything, mock.Anything, int64(5), mock.Anything).Return(nil)
+
+	address := &domain.UserAddress{City: "Olinda", State: "PE", Neighborhood: "Carmo", Street: "Rua B", Number: "2", ZipCode: "53020000"}
+
+	order, _, err := NewOrderUseCase(mockOrderRepo, userRepoWithAna(), cartWith(shirt), mockCheckoutService, nil, nil).Place(context.Background(), "ana@test.com", "key-1", address)
+
+	assert.NoError(t, err)
+	assert.Equal(t, *address, order.Address)
+}
+
+func TestPlaceReplay(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockCartUseCase := new(mocks.MockCartUseCase)
+
+	hash, _ := requestHash(nil)
+	placed := &domain.Order{ID: 3, UUID: "order-1", UserID: 7, RequestHash: hash}
+
+	mockOrderRepo.On("GetByIdempotencyKey", mock.Anything, int64(7), "key-1").Return(placed, nil)
+
+	order, replayed, err := NewOrderUseCase(mockOrderRepo, userRepoWithAna(), mockCartUseCase, nil, nil, nil).Place(context.Background(), "ana@test.com", "key-1", nil)
+
+	assert.NoError(t, err)
+	assert.True(t, replayed)
+	assert.Equal(t, placed, order)
+	mockCartUseCase.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPlaceIdempotencyKeyReused(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+
+	mockOrderRepo.On("GetByIdempotencyKey", mock.Anything, int64(7), "key-1").Return(&domain.Order{UUID: "order-1", RequestHash: "other"}, nil)
+
+	order, replayed, err := NewOrderUseCase(mockOrderRepo, userRepoWithAna(), nil, nil, nil, nil).Place(context.Background(), "ana@test.com", "key-1", nil)
+
+	assert.ErrorIs(t, err, domain.ErrIdempotencyKeyReused)
+	assert.False(t, replayed)
+	assert.Nil(t, order)
+}
+
+func TestPlaceConcurrentRequest(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockCheckoutService := new(mocks.MockCheckoutService)
+
+	hash, _ := requestHash(nil)
+	placed := &domain.Order{ID: 3, UUID: "order-1", UserID: 7, RequestHash: hash}
+
+	mockOrderRepo.On("GetByIdempotencyKey", mock.Anything, int64(7), "key-1").Return(nil, nil).Once()
+	mockOrderRepo.On("GetByIdempotencyKey", mock.Anything, int64(7), "key-1").Return(placed, nil).Once()
+	mockCheckoutService.On("Totals", mock.Anything, mock.Anything).Return(nil)
+	mockOrderRepo.On("Place", mock.Anything, mock.Anything, int64(5), mock.Anything).Return(domain.ErrOrderPlaced)
+
+	order, replayed, err := NewOrderUseCase(mockOrderRepo, userRepoWithAna(), cartWith(shirt), mockCheckoutService, nil, nil).Place(context.Background(), "ana@test.com", "key-1", nil)
+
+	assert.NoError(t, err)
+	assert.True(t, replayed)
+	assert.Equal(t, placed, order)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func TestPlaceEmptyCart(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+
+	mockOrderRepo.On("GetByIdempotencyKey", mock.Anything, int64(7), "key-1").Return(nil, nil)
+
+	_, _, err := NewOrderUseCase(mockOrderRepo, userRepoWithAna(), cartWith(), nil, nil, nil).Place(context.Background(), "ana@test.com", "key-1", nil)
+
+	assert.ErrorIs(t, err, domain.ErrCartEmpty)
+	mockOrderRepo.AssertNotCalled(t, "Place", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPlaceUnavailableItem(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+
+	mockOrderRepo.On("GetByIdempotencyKey", mock.Anything, int64(7), "key-1").Return(nil, nil)
+
+	outOfStock := shirt
+	outOfStock.Status = domain.CartItemStatusOutOfStock
+
+	_, _, err := NewOrderUseCase(mockOrderRepo, userRepoWithAna(), cartWith(shirt, outOfStock), nil, nil, nil).Place(context.Background(), "ana@test.com", "key-1", nil)
+
+	assert.ErrorIs(t, err, domain.ErrCartUnavailable)
+	mockOrderRepo.AssertNotCalled(t, "Place", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPlaceUserNotFound(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	mockUserRepo.On("GetByEmail", mock.Anything, "ana@test.com").Return(nil, nil)
+
+	_, _, err := NewOrderUseCase(nil, mockUserRepo, nil, nil, nil, nil).Place(context.Background(), "ana@test.com", "key-1", nil)
+
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestListMine(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+
+	page := &domain.OrderPage{Orders: []domain.Order{{UUID: "order-1"}}}
+
+	mockOrderRepo.On("List", mock.Anything, domain.OrderQuery{UserID: 7, Limit: 20}).Return(page, nil)
+
+	res, err := NewOrderUseCase(mockOrderRepo, userRepoWithAna(), nil, nil, nil, nil).ListMine(context.Background(), "ana@test.com", domain.OrderQuery{UserID: 99, Limit: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, page, res)
+}
+
+func TestGetMineHidesActors(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order-1").Return(&domain.Order{ID: 3, UUID: "order-1", UserID: 7}, nil)
+	mockOrderRepo.On("GetHistory", mock.Anything, int64(3)).Return([]domain.OrderHistoryEntry{{From: domain.OrderStatusPendingPayment, To: domain.OrderStatusPaid, Actor: "admin@test.com"}}, nil)
+
+	order, err := NewOrderUseCase(mockOrderRepo, userRepoWithAna(), nil, nil, nil, nil).GetMine(context.Background(), "ana@test.com", "order-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.OrderHistoryEntry{{From: domain.OrderStatusPendingPayment, To: domain.OrderStatusPaid}}, order.History)
+}
+
+func TestGetMineOfAnotherUser(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order-1").Return(&domain.Order{ID: 3, UUID: "order-1", UserID: 8}, nil)
+
+	_, err := NewOrderUseCase(mockOrderRepo, userRepoWithAna(), nil, nil, nil, nil).GetMine(context.Background(), "ana@test.com", "order-1")
+
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound)
+}
+
+func TestTransition(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockMessageService := new(mocks.MockMessageService)
+
+	ana := &domain.User{ID: 7, Email: "ana@test.com"}
+
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order-1").Return(&domain.Order{ID: 3, UUID: "order-1", UserID: 7, Status: domain.OrderStatusPicking}, nil)
+	mockOrderRepo.On("Transition", mock.Anything, mock.Anything, &domain.OrderHistoryEntry{From: domain.OrderStatusPicking, To: domain.OrderStatusShipped, Actor: "admin@test.com", Note: "correios"}, domain.StockEffectNone).Return(nil)
+	mockOrderRepo.On("GetHistory", mock.Anything, int64(3)).Return([]domain.OrderHistoryEntry{{From: domain.OrderStatusPicking, To: domain.OrderStatusShipped}}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, int64(7)).Return(ana, nil)
+	// the message is held until the transition returned, a transition
+	// waiting for it would never end
+	release := make(chan bool)
+	sent := make(chan bool)
+
+	mockMessageService.On("SendMessage", mock.Anything, mock.MatchedBy(func(mc *domain.MessageConfig) bool {
+		return mc.To == "ana@test.com" && mc.Message == "O pedido order-1 foi enviado, código de rastreio BR123" && mc.Category == domain.NotificationCategoryOrders
+	})).Run(func(args mock.Arguments) {
+		<-release
+		sent <- true
+	}).Return(nil)
+
+	order, err := NewOrderUseCase(mockOrderRepo, mockUserRepo, nil, nil, mockMessageService, nil).Transition(context.Background(), "order-1", "admin@test.com", &domain.OrderTransitionRequest{Status: domain.OrderStatusShipped, TrackingCode: "BR123", Note: "correios"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.OrderStatusShipped, order.Status)
+	assert.Equal(t, "BR123", order.TrackingCode)
+	assert.Len(t, order.History, 1)
+
+	close(release)
+
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("the order was not notified")
+	}
+
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestTransitionCommitsStockOnPayment(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockMessageService := new(mocks.MockMessageService)
+
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order-1").Return(&domain.Order{ID: 3, UUID: "order-1", UserID: 7, Status: domain.OrderStatusPendingPayment, Total: domain.Money{Amount: 9500, Currency: domain.CurrencyBRL}}, nil)
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{
+		{Status: domain.PaymentStatusFailed, Amount: domain.Money{Amount: 9500, Currency: domain.CurrencyBRL}},
+		{Status: domain.PaymentStatusCaptured, Amount: domain.Money{Amount: 9500, Currency: domain.CurrencyBRL}},
+	}, nil)
+	mockOrderRepo.On("Transition", mock.Anything, mock.Anything, mock.Anything, domain.StockEffectCommit).Return(nil)
+	mockOrderRepo.On("GetHistory", mock.Anything, int64(3)).Return([]domain.OrderHistoryEntry{}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, int64(7)).Return(&domain.User{ID: 7, Email: "ana@test.com"}, nil)
+	sent := make(chan bool, 1)
+
+	mockMessageService.On("SendMessage", mock.Anything, mock.Anything).Run(func(args mock.Arguments) { sent <- true }).Return(errors.New("smtp down"))
+
+	order, err := NewOrderUseCase(mockOrderRepo, mockUserRepo, nil, nil, mockMessageService, mockPaymentRepo).Transition(context.Background(), "order-1", "system", &domain.OrderTransitionRequest{Status: domain.OrderStatusPaid})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.OrderStatusPaid, order.Status)
+	mockOrderRepo.AssertExpectations(t)
+
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("the order was not notified")
+	}
+}
+
+func TestTransitionPaidWithoutPayment(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order-1").Return(&domain.Order{ID: 3, UUID: "order-1", Status: domain.OrderStatusPendingPayment, Total: domain.Money{Amount: 9500, Currency: domain.CurrencyBRL}}, nil)
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{{Status: domain.PaymentStatusPending, Amount: domain.Money{Amount: 9500, Currency: domain.CurrencyBRL}}}, nil)
+
+	_, err := NewOrderUseCase(mockOrderRepo, nil, nil, nil, nil, mockPaymentRepo).Transition(context.Background(), "order-1", "admin@test.com", &domain.OrderTransitionRequest{Status: domain.OrderStatusPaid})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	assert.Contains(t, err.Error(), "payments captured")
+	mockOrderRepo.AssertNotCalled(t, "Transition", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransitionRefundedWithPaymentCaptured(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order-1").Return(&domain.Order{ID: 3, UUID: "order-1", Status: domain.OrderStatusDelivered, Total: domain.Money{Amount: 9500, Currency: domain.CurrencyBRL}}, nil)
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{{Status: domain.PaymentStatusCaptured, Amount: domain.Money{Amount: 9500, Currency: domain.CurrencyBRL}}}, nil)
+
+	_, err := NewOrderUseCase(mockOrderRepo, nil, nil, nil, nil, mockPaymentRepo).Transition(context.Background(), "order-1", "admin@test.com", &domain.OrderTransitionRequest{Status: domain.OrderStatusRefunded})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	assert.Contains(t, err.Error(), "were not refunded")
+	mockOrderRepo.AssertNotCalled(t, "Transition", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransitionNotAllowed(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order-1").Return(&domain.Order{ID: 3, UUID: "order-1", Status: domain.OrderStatusCancelled}, nil)
+
+	_, err := NewOrderUseCase(mockOrderRepo, nil, nil, nil, nil, nil).Transition(context.Background(), "order-1", "admin@test.com", &domain.OrderTransitionRequest{Status: domain.OrderStatusPaid})
+
+	var transitionErr *domain.TransitionError
+
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	assert.True(t, errors.As(err, &transitionErr))
+	assert.Equal(t, domain.OrderStatusCancelled, transitionErr.From)
+	assert.Equal(t, domain.OrderStatusPaid, transitionErr.To)
+	mockOrderRepo.AssertNotCalled(t, "Transition", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransitionShipWithoutTrackingCode(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order-1").Return(&domain.Order{ID: 3, UUID: "order-1", Status: domain.OrderStatusPicking}, nil)
+
+	_, err := NewOrderUseCase(mockOrderRepo, nil, nil, nil, nil, nil).Transition(context.Background(), "order-1", "admin@test.com", &domain.OrderTransitionRequest{Status: domain.OrderStatusShipped})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	assert.Contains(t, err.Error(), "tracking code")
+	mockOrderRepo.AssertNotCalled(t, "Transition", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransitionOrderNotFound(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order-1").Return(nil, nil)
+
+	_, err := NewOrderUseCase(mockOrderRepo, nil, nil, nil, nil, nil).Transition(context.Background(), "order-1", "admin@test.com", &domain.OrderTransitionRequest{Status: domain.OrderStatusPaid})
+
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound)
+}
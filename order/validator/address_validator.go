@@ -0,0 +1,42 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type addressValidator struct{}
+
+func NewAddressValidator() *addressValidator {
+	return &addressValidator{}
+}
+
+func (av *addressValidator) Validate(ctx context.Context, a *domain.UserAddress) (domain.IsValid, domain.Message) {
+	fields := []struct {
+		name  string
+		value string
+		max   int
+	}{
+		{"city", a.City, 100},
+		{"state", a.State, 100},
+		{"neighborhood", a.Neighborhood, 150},
+		{"street", a.Street, 150},
+		{"number", a.Number, 20},
+		{"zipcode", a.ZipCode, 100},
+	}
+
+	for _, f := range fields {
+		if f.value == "" {
+			return false, domain.Message(fmt.Sprintf("address %s can not be empty", f.name))
+		}
+
+		if utf8.RuneCountInString(f.value) > f.max {
+			return false, domain.Message(fmt.Sprintf("address %s can not be longer than %d characters", f.name, f.max))
+		}
+	}
+
+	return true, ""
+}
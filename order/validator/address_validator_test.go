@@ -0,0 +1,44 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func validAddress() domain.UserAddress {
+	return domain.UserAddress{City: "Recife", State: "PE", Neighborhood: "Boa Viagem", Street: "Rua A", Number: "10", ZipCode: "51020000"}
+}
+
+func TestValidateAddressInvalid(t *testing.T) {
+	noCity := validAddress()
+	noCity.City = ""
+
+	noZipCode := validAddress()
+	noZipCode.ZipCode = ""
+
+	longStreet := validAddress()
+	longStreet.Street = strings.Repeat("a", 151)
+
+	longNumber := validAddress()
+	longNumber.Number = strings.Repeat("1", 21)
+
+	for _, a := range []domain.UserAddress{noCity, noZipCode, longStreet, longNumber} {
+		isValid, message := NewAddressValidator().Validate(context.Background(), &a)
+
+		assert.False(t, bool(isValid))
+		assert.NotEmpty(t, message)
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	a := validAddress()
+
+	isValid, message := NewAddressValidator().Validate(context.Background(), &a)
+
+	assert.True(t, bool(isValid))
+	assert.Empty(t, message)
+}
@@ -0,0 +1,41 @@
+package validator
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+var orderStatuses = map[domain.OrderStatus]bool{
+	domain.OrderStatusPendingPayment: true,
+	domain.OrderStatusPaid:           true,
+	domain.OrderStatusPicking:        true,
+	domain.OrderStatusShipped:        true,
+	domain.OrderStatusDelivered:      true,
+	domain.OrderStatusCancelled:      true,
+	domain.OrderStatusRefunded:       true,
+	domain.OrderStatusReturned:       true,
+}
+
+type orderTransitionValidator struct{}
+
+func NewOrderTransitionValidator() *orderTransitionValidator {
+	return &orderTransitionValidator{}
+}
+
+func (otv *orderTransitionValidator) Validate(ctx context.Context, t *domain.OrderTransitionRequest) (domain.IsValid, domain.Message) {
+	if !orderStatuses[t.Status] {
+		return false, "transition's status must be one of pending_payment, paid, picking, shipped, delivered, cancelled, refunded or returned"
+	}
+
+	if utf8.RuneCountInString(t.TrackingCode) > 50 {
+		return false, "transition's tracking code can not be longer than 50 characters"
+	}
+
+	if utf8.RuneCountInString(t.Note) > 500 {
+		return false, "transition's note can not be longer than 500 characters"
+	}
+
+	return true, ""
+}
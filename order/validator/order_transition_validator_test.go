@@ -0,0 +1,31 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateOrderTransitionInvalid(t *testing.T) {
+	for _, tr := range []domain.OrderTransitionRequest{
+		{Status: ""},
+		{Status: "lost"},
+		{Status: domain.OrderStatusShipped, TrackingCode: strings.Repeat("a", 51)},
+		{Status: domain.OrderStatusCancelled, Note: strings.Repeat("a", 501)},
+	} {
+		isValid, message := NewOrderTransitionValidator().Validate(context.Background(), &tr)
+
+		assert.False(t, bool(isValid))
+		assert.NotEmpty(t, message)
+	}
+}
+
+func TestValidateOrderTransition(t *testing.T) {
+	isValid, message := NewOrderTransitionValidator().Validate(context.Background(), &domain.OrderTransitionRequest{Status: domain.OrderStatusShipped, TrackingCode: "BR123", Note: "correios"})
+
+	assert.True(t, bool(isValid))
+	assert.Empty(t, message)
+}
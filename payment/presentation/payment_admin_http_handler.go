@@ -0,0 +1,95 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type paymentAdminHandler struct {
+	PaymentUseCase domain.PaymentUseCase
+}
+
+func NewPaymentAdminHandler(e *echo.Echo, puc domain.PaymentUseCase, ts domain.TokenService) *paymentAdminHandler {
+	handler := &paymentAdminHandler{
+		PaymentUseCase: puc,
+	}
+
+	admin := _tokenPresentation.NewAdminMiddleware(ts)
+
+	e.GET("/admin/orders/:uuid/payments", handler.List, admin)
+	e.POST("/admin/payments/:uuid/capture", handler.Capture, admin)
+	e.POST("/admin/payments/:uuid/void", handler.Void, admin)
+
+	return handler
+}
+
+func (pah *paymentAdminHandler) List(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	payments, err := pah.PaymentUseCase.List(c.Request().Context(), uuid)
+
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		return c.JSON(http.StatusNotFound, "order not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to list the payments of the order: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to list the payments")
+	}
+
+	return c.JSON(http.StatusOK, map[string][]domain.PaymentIntent{"payments": payments})
+}
+
+func (pah *paymentAdminHandler) Capture(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	payment, err := pah.PaymentUseCase.Capture(c.Request().Context(), uuid)
+
+	return pah.answer(c, payment, err, "capture")
+}
+
+func (pah *paymentAdminHandler) Void(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	payment, err := pah.PaymentUseCase.Void(c.Request().Context(), uuid)
+
+	return pah.answer(c, payment, err, "void")
+}
+
+func (pah *paymentAdminHandler) answer(c echo.Context, payment *domain.PaymentIntent, err error, operation string) error {
+	if errors.Is(err, domain.ErrPaymentNotFound) {
+		return c.JSON(http.StatusNotFound, "payment not found")
+	}
+
+	if errors.Is(err, domain.ErrPaymentInvalidState) {
+		return c.JSON(http.StatusConflict, "the payment does not allow it in its status")
+	}
+
+	if errors.Is(err, domain.ErrPaymentChanged) {
+		return c.JSON(http.StatusConflict, "the payment changed while being updated, review it and try again")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to %s the payment: %s", operation, err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to "+operation+" the payment")
+	}
+
+	return c.JSON(http.StatusOK, payment)
+}
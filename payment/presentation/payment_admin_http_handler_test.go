@@ -0,0 +1,97 @@
+package presentation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func adminPaymentContext(path string, uuid string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req, _ := http.NewRequest(echo.POST, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath(path)
+	c.SetParamNames("uuid")
+	c.SetParamValues(uuid)
+
+	return c, rec
+}
+
+func TestAdminListPayments(t *testing.T) {
+	c, rec := adminPaymentContext("/admin/orders/:uuid/payments", "order-1")
+
+	mockPaymentUseCase := new(mocks.MockPaymentUseCase)
+
+	mockPaymentUseCase.On("List", mock.Anything, "order-1").Return([]domain.PaymentIntent{{UUID: "pay-1", Provider: "fake"}}, nil)
+
+	handler := NewPaymentAdminHandler(echo.New(), mockPaymentUseCase, nil)
+
+	handler.List(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"provider\":\"fake\"")
+}
+
+func TestAdminListPaymentsOrderNotFound(t *testing.T) {
+	c, rec := adminPaymentContext("/admin/orders/:uuid/payments", "order-1")
+
+	mockPaymentUseCase := new(mocks.MockPaymentUseCase)
+
+	mockPaymentUseCase.On("List", mock.Anything, "order-1").Return(nil, domain.ErrOrderNotFound)
+
+	handler := NewPaymentAdminHandler(echo.New(), mockPaymentUseCase, nil)
+
+	handler.List(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAdminCapturePayment(t *testing.T) {
+	c, rec := adminPaymentContext("/admin/payments/:uuid/capture", "pay-1")
+
+	mockPaymentUseCase := new(mocks.MockPaymentUseCase)
+
+	mockPaymentUseCase.On("Capture", mock.Anything, "pay-1").Return(&domain.PaymentIntent{UUID: "pay-1", Status: domain.PaymentStatusCaptured}, nil)
+
+	handler := NewPaymentAdminHandler(echo.New(), mockPaymentUseCase, nil)
+
+	handler.Capture(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"status\":\"captured\"")
+}
+
+func TestAdminVoidPaymentInvalidState(t *testing.T) {
+	c, rec := adminPaymentContext("/admin/payments/:uuid/void", "pay-1")
+
+	mockPaymentUseCase := new(mocks.MockPaymentUseCase)
+
+	mockPaymentUseCase.On("Void", mock.Anything, "pay-1").Return(nil, domain.ErrPaymentInvalidState)
+
+	handler := NewPaymentAdminHandler(echo.New(), mockPaymentUseCase, nil)
+
+	handler.Void(c)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestAdminVoidPaymentNotFound(t *testing.T) {
+	c, rec := adminPaymentContext("/admin/payments/:uuid/void", "pay-1")
+
+	mockPaymentUseCase := new(mocks.MockPaymentUseCase)
+
+	mockPaymentUseCase.On("Void", mock.Anything, "pay-1").Return(nil, domain.ErrPaymentNotFound)
+
+	handler := NewPaymentAdminHandler(echo.New(), mockPaymentUseCase, nil)
+
+	handler.Void(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,161 @@
+package presentation
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+// maxWebhookSize is the largest notification taken from the providers.
+const maxWebhookSize = 1 << 20
+
+type paymentHandler struct {
+	PaymentUseCase          domain.PaymentUseCase
+	PaymentRequestValidator domain.PaymentRequestValidator
+}
+
+func NewPaymentHandler(e *echo.Echo, puc domain.PaymentUseCase, prv domain.PaymentRequestValidator, ts domain.TokenService) *paymentHandler {
+	handler := &paymentHandler{
+		PaymentUseCase:          puc,
+		PaymentRequestValidator: prv,
+	}
+
+	auth := _tokenPresentation.NewAuthMiddleware(ts)
+
+	e.POST("/orders/:uuid/payments", handler.Pay, auth)
+	e.GET("/me/orders/:uuid/payments", handler.ListMine, auth)
+	e.POST("/payments/webhooks/:provider", handler.Webhook)
+
+	return handler
+}
+
+func (ph *paymentHandler) Pay(c echo.Context) error {
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	var req domain.PaymentRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	ctx := c.Request().Context()
+
+	isValid, message := ph.PaymentRequestValidator.Validate(ctx, &req)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	payment, err := ph.PaymentUseCase.Pay(ctx, tokenInfo.Info, uuid, &req)
+
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, "user not found")
+	}
+
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		return c.JSON(http.StatusNotFound, "order not found")
+	}
+
+	if errors.Is(err, domain.ErrPaymentMethodNotSupported) {
+		return c.JSON(http.StatusBadRequest, "payment method not supported")
+	}
+
+	if errors.Is(err, domain.ErrOrderNotPayable) {
+		return c.JSON(http.StatusConflict, "the order is not waiting for a payment")
+	}
+
+	if errors.Is(err, domain.ErrPaymentChanged) {
+		return c.JSON(http.StatusConflict, "the payment changed while being made, review it and try again")
+	}
+
+	if errors.Is(err, domain.ErrPaymentDeclined) {
+		return c.JSON(http.StatusPaymentRequired, err.Error())
+	}
+
+	if err != nil {
+		log.Printf("Error trying to pay the order: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to pay the order")
+	}
+
+	return c.JSON(http.StatusCreated, payment)
+}
+
+func (ph *paymentHandler) ListMine(c echo.Context) error {
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	payments, err := ph.PaymentUseCase.ListMine(c.Request().Context(), tokenInfo.Info, uuid)
+
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, "user not found")
+	}
+
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		return c.JSON(http.StatusNotFound, "order not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to list the payments of the order of the user: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to list the payments")
+	}
+
+	return c.JSON(http.StatusOK, map[string][]domain.PaymentIntent{"payments": payments})
+}
+
+// Webhook takes the notifications of the payment providers, signed by them
+// in the X-Signature header.
+func (ph *paymentHandler) Webhook(c echo.Context) error {
+	provider := c.Param("provider")
+
+	if provider == "" {
+		return c.JSON(http.StatusBadRequest, "provider param is not valid")
+	}
+
+	payload, err := ioutil.ReadAll(io.LimitReader(c.Request().Body, maxWebhookSize))
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	err = ph.PaymentUseCase.Webhook(c.Request().Context(), provider, payload, c.Request().Header.Get("X-Signature"))
+
+	if errors.Is(err, domain.ErrPaymentProviderNotFound) {
+		return c.JSON(http.StatusNotFound, "provider not found")
+	}
+
+	if errors.Is(err, domain.ErrInvalidWebhook) {
+		return c.JSON(http.StatusUnauthorized, "invalid signature")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to follow the webhook of %s: %s", provider, err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to follow the webhook")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,159 @@
+package presentation
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func payContext(body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req, _ := http.NewRequest(echo.POST, "/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "ana@test.com"})
+	c.SetPath("/orders/:uuid/payments")
+	c.SetParamNames("uuid")
+	c.SetParamValues("order-1")
+
+	return c, rec
+}
+
+func TestPay(t *testing.T) {
+	c, rec := payContext(`{"method":"pix"}`)
+
+	r := &domain.PaymentRequest{Method: domain.PaymentMethodPix}
+
+	mockPaymentUseCase := new(mocks.MockPaymentUseCase)
+	mockPaymentRequestValidator := new(mocks.MockPaymentRequestValidator)
+
+	mockPaymentRequestValidator.On("Validate", mock.Anything, r).Return(true, "")
+	mockPaymentUseCase.On("Pay", mock.Anything, "ana@test.com", "order-1", r).Return(&domain.PaymentIntent{UUID: "pay-1", Method: domain.PaymentMethodPix, Status: domain.PaymentStatusPending, PixCode: "000201"}, nil)
+
+	handler := NewPaymentHandler(echo.New(), mockPaymentUseCase, mockPaymentRequestValidator, nil)
+
+	handler.Pay(c)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"pixCode\":\"000201\"")
+	assert.NotContains(t, rec.Body.String(), "providerReference")
+}
+
+func TestPayInvalid(t *testing.T) {
+	c, rec := payContext(`{"method":"card"}`)
+
+	mockPaymentRequestValidator := new(mocks.MockPaymentRequestValidator)
+
+	mockPaymentRequestValidator.On("Validate", mock.Anything, mock.Anything).Return(false, "payment's card token can not be empty")
+
+	handler := NewPaymentHandler(echo.New(), new(mocks.MockPaymentUseCase), mockPaymentRequestValidator, nil)
+
+	handler.Pay(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestPayDeclined(t *testing.T) {
+	c, rec := payContext(`{"method":"card","cardToken":"tok_declined"}`)
+
+	mockPaymentUseCase := new(mocks.MockPaymentUseCase)
+	mockPaymentRequestValidator := new(mocks.MockPaymentRequestValidator)
+
+	mockPaymentRequestValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockPaymentUseCase.On("Pay", mock.Anything, "ana@test.com", "order-1", mock.Anything).Return(nil, fmt.Errorf("%w: %s", domain.ErrPaymentDeclined, "card declined"))
+
+	handler := NewPaymentHandler(echo.New(), mockPaymentUseCase, mockPaymentRequestValidator, nil)
+
+	handler.Pay(c)
+
+	assert.Equal(t, http.StatusPaymentRequired, rec.Code)
+	assert.Contains(t, rec.Body.String(), "card declined")
+}
+
+func TestPayOrderNotPayable(t *testing.T) {
+	c, rec := payContext(`{"method":"pix"}`)
+
+	mockPaymentUseCase := new(mocks.MockPaymentUseCase)
+	mockPaymentRequestValidator := new(mocks.MockPaymentRequestValidator)
+
+	mockPaymentRequestValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockPaymentUseCase.On("Pay", mock.Anything, "ana@test.com", "order-1", mock.Anything).Return(nil, domain.ErrOrderNotPayable)
+
+	handler := NewPaymentHandler(echo.New(), mockPaymentUseCase, mockPaymentRequestValidator, nil)
+
+	handler.Pay(c)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestListMyPayments(t *testing.T) {
+	e := echo.New()
+	req, _ := http.NewRequest(echo.GET, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "ana@test.com"})
+	c.SetPath("/me/orders/:uuid/payments")
+	c.SetParamNames("uuid")
+	c.SetParamValues("order-1")
+
+	mockPaymentUseCase := new(mocks.MockPaymentUseCase)
+
+	mockPaymentUseCase.On("ListMine", mock.Anything, "ana@test.com", "order-1").Return([]domain.PaymentIntent{{UUID: "pay-1"}}, nil)
+
+	handler := NewPaymentHandler(echo.New(), mockPaymentUseCase, nil, nil)
+
+	handler.ListMine(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"payments\":[{\"uuid\":\"pay-1\"")
+}
+
+func webhookContext(body string, signature string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req, _ := http.NewRequest(echo.POST, "/", strings.NewReader(body))
+	req.Header.Set("X-Signature", signature)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/payments/webhooks/:provider")
+	c.SetParamNames("provider")
+	c.SetParamValues("fake")
+
+	return c, rec
+}
+
+func TestWebhook(t *testing.T) {
+	c, rec := webhookContext(`{"reference":"fake_pay-1"}`, "sig")
+
+	mockPaymentUseCase := new(mocks.MockPaymentUseCase)
+
+	mockPaymentUseCase.On("Webhook", mock.Anything, "fake", []byte(`{"reference":"fake_pay-1"}`), "sig").Return(nil)
+
+	handler := NewPaymentHandler(echo.New(), mockPaymentUseCase, nil, nil)
+
+	handler.Webhook(c)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestWebhookInvalidSignature(t *testing.T) {
+	c, rec := webhookContext(`{}`, "bad")
+
+	mockPaymentUseCase := new(mocks.MockPaymentUseCase)
+
+	mockPaymentUseCase.On("Webhook", mock.Anything, "fake", mock.Anything, "bad").Return(domain.ErrInvalidWebhook)
+
+	handler := NewPaymentHandler(echo.New(), mockPaymentUseCase, nil, nil)
+
+	handler.Webhook(c)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -0,0 +1,145 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type refundAdminHandler struct {
+	RefundUseCase          domain.RefundUseCase
+	RefundRequestValidator domain.RefundRequestValidator
+}
+
+func NewRefundAdminHandler(e *echo.Echo, ruc domain.RefundUseCase, rrv domain.RefundRequestValidator, ts domain.TokenService) *refundAdminHandler {
+	handler := &refundAdminHandler{
+		RefundUseCase:          ruc,
+		RefundRequestValidator: rrv,
+	}
+
+	admin := _tokenPresentation.NewAdminMiddleware(ts)
+
+	e.POST("/admin/orders/:uuid/refunds", handler.Refund, admin)
+	e.GET("/admin/orders/:uuid/refunds", handler.List, admin)
+	e.GET("/admin/orders/:uuid/ledger", handler.Ledger, admin)
+
+	return handler
+}
+
+func (rah *refundAdminHandler) Refund(c echo.Context) error {
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	var req domain.RefundRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	ctx := c.Request().Context()
+
+	isValid, message := rah.RefundRequestValidator.Validate(ctx, &req)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	refunds, err := rah.RefundUseCase.Refund(ctx, uuid, tokenInfo.Info, &req)
+
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		return c.JSON(http.StatusNotFound, "order not found")
+	}
+
+	if errors.Is(err, domain.ErrRefundLineNotFound) {
+		return c.JSON(http.StatusBadRequest, "the line is not an item of the order")
+	}
+
+	if errors.Is(err, domain.ErrRefundNotAllowed) {
+		return c.JSON(http.StatusConflict, "the order does not allow refunds in its status")
+	}
+
+	if errors.Is(err, domain.ErrRefundExceeds) {
+		return c.JSON(http.StatusConflict, "the refund is more than what is left to refund")
+	}
+
+	if errors.Is(err, domain.ErrOrderNotRefunded) {
+		log.Printf("Error trying to refund the order: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "the payments were refunded but the order was not, move it to refunded")
+	}
+
+	var transitionErr *domain.TransitionError
+
+	if errors.As(err, &transitionErr) {
+		return c.JSON(http.StatusConflict, transitionErr.Error())
+	}
+
+	if errors.Is(err, domain.ErrPaymentChanged) {
+		return c.JSON(http.StatusConflict, "the payment changed while being refunded, review it and try again")
+	}
+
+	if errors.Is(err, domain.ErrRefundFailed) {
+		return c.JSON(http.StatusConflict, err.Error())
+	}
+
+	if err != nil {
+		log.Printf("Error trying to refund the order: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to refund the order")
+	}
+
+	return c.JSON(http.StatusCreated, map[string][]domain.Refund{"refunds": refunds})
+}
+
+func (rah *refundAdminHandler) List(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	refunds, err := rah.RefundUseCase.List(c.Request().Context(), uuid)
+
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		return c.JSON(http.StatusNotFound, "order not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to list the refunds of the order: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to list the refunds")
+	}
+
+	return c.JSON(http.StatusOK, map[string][]domain.Refund{"refunds": refunds})
+}
+
+func (rah *refundAdminHandler) Ledger(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	ledger, err := rah.RefundUseCase.Ledger(c.Request().Context(), uuid)
+
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		return c.JSON(http.StatusNotFound, "order not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to get the ledger of the order: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the ledger")
+	}
+
+	return c.JSON(http.StatusOK, ledger)
+}
@@ -0,0 +1,150 @@
+package presentation
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func refundContext(body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req, _ := http.NewRequest(echo.POST, "/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "admin@test.com"})
+	c.SetPath("/admin/orders/:uuid/refunds")
+	c.SetParamNames("uuid")
+	c.SetParamValues("order-1")
+
+	return c, rec
+}
+
+func TestRefund(t *testing.T) {
+	c, rec := refundContext(`{"lines":[{"sku":"P7-M","quantity":1}],"reason":"produto com defeito"}`)
+
+	r := &domain.RefundRequest{Lines: []domain.RefundLine{{SKU: "P7-M", Quantity: 1}}, Reason: "produto com defeito"}
+
+	mockRefundUseCase := new(mocks.MockRefundUseCase)
+	mockRefundRequestValidator := new(mocks.MockRefundRequestValidator)
+
+	mockRefundRequestValidator.On("Validate", mock.Anything, r).Return(true, "")
+	mockRefundUseCase.On("Refund", mock.Anything, "order-1", "admin@test.com", r).Return([]domain.Refund{{UUID: "refund-1", ProviderReference: "ref-1_refund_1", Status: domain.RefundStatusSucceeded}}, nil)
+
+	handler := NewRefundAdminHandler(echo.New(), mockRefundUseCase, mockRefundRequestValidator, nil)
+
+	handler.Refund(c)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"refunds\":[{\"uuid\":\"refund-1\"")
+	assert.NotContains(t, rec.Body.String(), "ref-1_refund_1")
+}
+
+func TestRefundInvalid(t *testing.T) {
+	c, rec := refundContext(`{"full":true}`)
+
+	mockRefundRequestValidator := new(mocks.MockRefundRequestValidator)
+
+	mockRefundRequestValidator.On("Validate", mock.Anything, mock.Anything).Return(false, "refund's reason can not be empty")
+
+	handler := NewRefundAdminHandler(echo.New(), nil, mockRefundRequestValidator, nil)
+
+	handler.Refund(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRefundErrors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{domain.ErrOrderNotFound, http.StatusNotFound},
+		{domain.ErrRefundLineNotFound, http.StatusBadRequest},
+		{domain.ErrRefundNotAllowed, http.StatusConflict},
+		{domain.ErrRefundExceeds, http.StatusConflict},
+		{&domain.TransitionError{From: domain.OrderStatusShipped, To: domain.OrderStatusRefunded}, http.StatusConflict},
+		{fmt.Errorf("%w: saldo insuficiente", domain.ErrRefundFailed), http.StatusConflict},
+		{fmt.Errorf("%w: db down", domain.ErrOrderNotRefunded), http.StatusInternalServerError},
+		{fmt.Errorf("gateway down"), http.StatusInternalServerError},
+	} {
+		c, rec := refundContext(`{"full":true,"reason":"desistência"}`)
+
+		mockRefundUseCase := new(mocks.MockRefundUseCase)
+		mockRefundRequestValidator := new(mocks.MockRefundRequestValidator)
+
+		mockRefundRequestValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+		mockRefundUseCase.On("Refund", mock.Anything, "order-1", "admin@test.com", mock.Anything).Return(nil, tc.err)
+
+		handler := NewRefundAdminHandler(echo.New(), mockRefundUseCase, mockRefundRequestValidator, nil)
+
+		handler.Refund(c)
+
+		assert.Equal(t, tc.code, rec.Code, tc.err.Error())
+	}
+}
+
+func TestRefundNotAuthorized(t *testing.T) {
+	e := echo.New()
+	req, _ := http.NewRequest(echo.POST, "/", strings.NewReader(`{}`))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewRefundAdminHandler(echo.New(), nil, nil, nil)
+
+	handler.Refund(c)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAdminListRefunds(t *testing.T) {
+	c, rec := adminPaymentContext("/admin/orders/:uuid/refunds", "order-1")
+
+	mockRefundUseCase := new(mocks.MockRefundUseCase)
+
+	mockRefundUseCase.On("List", mock.Anything, "order-1").Return([]domain.Refund{{UUID: "refund-1", Reason: "frete"}}, nil)
+
+	handler := NewRefundAdminHandler(echo.New(), mockRefundUseCase, nil, nil)
+
+	handler.List(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"reason\":\"frete\"")
+}
+
+func TestAdminLedger(t *testing.T) {
+	c, rec := adminPaymentContext("/admin/orders/:uuid/ledger", "order-1")
+
+	mockRefundUseCase := new(mocks.MockRefundUseCase)
+
+	mockRefundUseCase.On("Ledger", mock.Anything, "order-1").Return(&domain.Ledger{OrderUUID: "order-1", Net: domain.Money{Amount: 5500, Currency: domain.CurrencyBRL}}, nil)
+
+	handler := NewRefundAdminHandler(echo.New(), mockRefundUseCase, nil, nil)
+
+	handler.Ledger(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"net\":{\"amount\":5500")
+}
+
+func TestAdminLedgerOrderNotFound(t *testing.T) {
+	c, rec := adminPaymentContext("/admin/orders/:uuid/ledger", "order-1")
+
+	mockRefundUseCase := new(mocks.MockRefundUseCase)
+
+	mockRefundUseCase.On("Ledger", mock.Anything, "order-1").Return(nil, domain.ErrOrderNotFound)
+
+	handler := NewRefundAdminHandler(echo.New(), mockRefundUseCase, nil, nil)
+
+	handler.Ledger(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
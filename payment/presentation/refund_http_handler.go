@@ -0,0 +1,58 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type refundHandler struct {
+	RefundUseCase domain.RefundUseCase
+}
+
+func NewRefundHandler(e *echo.Echo, ruc domain.RefundUseCase, ts domain.TokenService) *refundHandler {
+	handler := &refundHandler{
+		RefundUseCase: ruc,
+	}
+
+	auth := _tokenPresentation.NewAuthMiddleware(ts)
+
+	e.GET("/me/orders/:uuid/ledger", handler.LedgerMine, auth)
+
+	return handler
+}
+
+func (rh *refundHandler) LedgerMine(c echo.Context) error {
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	ledger, err := rh.RefundUseCase.LedgerMine(c.Request().Context(), tokenInfo.Info, uuid)
+
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, "user not found")
+	}
+
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		return c.JSON(http.StatusNotFound, "order not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to get the ledger of the order of the user: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the ledger")
+	}
+
+	return c.JSON(http.StatusOK, ledger)
+}
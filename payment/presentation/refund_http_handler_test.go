@@ -0,0 +1,58 @@
+package presentation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func ledgerContext() (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req, _ := http.NewRequest(echo.GET, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "ana@test.com"})
+	c.SetPath("/me/orders/:uuid/ledger")
+	c.SetParamNames("uuid")
+	c.SetParamValues("order-1")
+
+	return c, rec
+}
+
+func TestMyLedger(t *testing.T) {
+	c, rec := ledgerContext()
+
+	mockRefundUseCase := new(mocks.MockRefundUseCase)
+
+	mockRefundUseCase.On("LedgerMine", mock.Anything, "ana@test.com", "order-1").Return(&domain.Ledger{
+		OrderUUID: "order-1",
+		Entries:   []domain.LedgerEntry{{Kind: domain.LedgerEntryRefund, UUID: "refund-1", Amount: domain.Money{Amount: -4000, Currency: domain.CurrencyBRL}}},
+	}, nil)
+
+	handler := NewRefundHandler(echo.New(), mockRefundUseCase, nil)
+
+	handler.LedgerMine(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"amount\":-4000")
+}
+
+func TestMyLedgerOrderNotFound(t *testing.T) {
+	c, rec := ledgerContext()
+
+	mockRefundUseCase := new(mocks.MockRefundUseCase)
+
+	mockRefundUseCase.On("LedgerMine", mock.Anything, "ana@test.com", "order-1").Return(nil, domain.ErrOrderNotFound)
+
+	handler := NewRefundHandler(echo.New(), mockRefundUseCase, nil)
+
+	handler.LedgerMine(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+// datetimeLayout is how the connection gives the DATETIME columns, in UTC.
+const datetimeLayout = "2006-01-02 15:04:05"
+
+// reconcileBatch is how many intents a reconciliation takes at a time.
+const reconcileBatch = 100
+
+type paymentMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewPaymentMysqlRepository(conn *sql.DB) domain.PaymentRepository {
+	return &paymentMysqlRepository{Conn: conn}
+}
+
+// paymentSelect is read by scanPayment, the uuid of the order comes with the
+// intent.
+const paymentSelect = `SELECT p.id, p.uuid, p.order_id, o.uuid, p.method, p.provider, p.provider_reference, p.status, p.currency, p.amount, p.refunded, p.pix_code, p.boleto_line, p.boleto_barcode, p.failure_reason, p.expires_at, p.created_at, p.updated_at FROM payment_intents p JOIN orders o ON o.id = p.order_id`
+
+func (pmr *paymentMysqlRepository) Create(ctx context.Context, p *domain.PaymentIntent) error {
+	p.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	p.UpdatedAt = p.CreatedAt
+
+	query := `INSERT INTO payment_intents (uuid, order_id, method, provider, provider_reference, status, currency, amount, pix_code, boleto_line, boleto_barcode, failure_reason, expires_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+	exec, err := pmr.Conn.ExecContext(ctx, query, p.UUID, p.OrderID, p.Method, p.Provider, p.ProviderReference, p.Status, p.Amount.Currency, p.Amount.Amount, p.PixCode, p.BoletoLine, p.BoletoBarcode, p.FailureReason, nullTime(p.ExpiresAt), p.CreatedAt, p.UpdatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	p.ID, err = exec.LastInsertId()
+
+	return err
+}
+
+func (pmr *paymentMysqlRepository) GetByUUID(ctx context.Context, uuid string) (*domain.PaymentIntent, error) {
+	return pmr.get(ctx, paymentSelect+` WHERE p.uuid = ?;`, uuid)
+}
+
+func (pmr *paymentMysqlRepository) GetByProviderReference(ctx context.Context, provider string, reference string) (*domain.PaymentIntent, error) {
+	return pmr.get(ctx, paymentSelect+` WHERE p.provider = ? AND p.provider_reference = ?;`, provider, reference)
+}
+
+func (pmr *paymentMysqlRepository) get(ctx context.Context, query string, args ...interface{}) (*domain.PaymentIntent, error) {
+	p, err := scanPayment(pmr.Conn.QueryRowContext(ctx, query, args...))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (pmr *paymentMysqlRepository) ListByOrder(ctx context.Context, orderID int64) ([]domain.PaymentIntent, error) {
+	return pmr.list(ctx, paymentSelect+` WHERE p.order_id = ? ORDER BY p.id;`, orderID)
+}
+
+// ListToReconcile gives the captured intents of orders still waiting for the
+// payment or cancelled, the intents still open of orders no longer waiting
+// for it, and the intents captured after another one of the same order.
+func (pmr *paymentMysqlRepository) ListToReconcile(ctx context.Context) ([]domain.PaymentIntent, error) {
+	query := paymentSelect + ` WHERE (p.status = ? AND o.status IN (?, ?))` +
+		` OR (p.status IN (?, ?) AND o.status <> ? AND p.provider_reference <> '')` +
+		` OR (p.status = ? AND EXISTS (SELECT 1 FROM payment_intents d WHERE d.order_id = p.order_id AND d.status = ? AND d.id < p.id))` +
+		` ORDER BY p.id LIMIT ?;`
+
+	return pmr.list(ctx, query,
+		domain.PaymentStatusCaptured, domain.OrderStatusPendingPayment, domain.OrderStatusCancelled,
+		domain.PaymentStatusPending, domain.PaymentStatusAuthorized, domain.OrderStatusPendingPayment,
+		domain.PaymentStatusCaptured, domain.PaymentStatusCaptured,
+		reconcileBatch,
+	)
+}
+
+func (pmr *paymentMysqlRepository) list(ctx context.Context, query string, args ...interface{}) ([]domain.PaymentIntent, error) {
+	rows, err := pmr.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []domain.PaymentIntent{}
+
+	for rows.Next() {
+		p, err := scanPayment(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, *p)
+	}
+
+	return res, rows.Err()
+}
+
+func (pmr *paymentMysqlRepository) Update(ctx context.Context, p *domain.PaymentIntent, from domain.PaymentStatus) error {
+	updatedAt := time.Now().UTC().Truncate(time.Second)
+
+	query := `UPDATE payment_intents SET provider_reference = ?, status = ?, pix_code = ?, boleto_line = ?, boleto_barcode = ?, failure_reason = ?, updated_at = ? WHERE id = ? AND status = ?;`
+
+	exec, err := pmr.Conn.ExecContext(ctx, query, p.ProviderReference, p.Status, p.PixCode, p.BoletoLine, p.BoletoBarcode, p.FailureReason, updatedAt, p.ID, from)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return domain.ErrPaymentChanged
+	}
+
+	p.UpdatedAt = updatedAt
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPayment(row scanner) (*domain.PaymentIntent, error) {
+	var p domain.PaymentIntent
+	var expiresAt sql.NullString
+	var createdAt, updatedAt string
+
+	err := row.Scan(
+		&p.ID, &p.UUID, &p.OrderID, &p.OrderUUID, &p.Method, &p.Provider, &p.ProviderReference, &p.Status,
+		&p.Amount.Currency, &p.Amount.Amount, &p.Refunded.Amount, &p.PixCode, &p.BoletoLine, &p.BoletoBarcode, &p.FailureReason, &expiresAt, &createdAt, &updatedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	p.Refunded.Currency = p.Amount.Currency
+
+	if expiresAt.Valid {
+		t, err := time.Parse(datetimeLayout, expiresAt.String)
+
+		if err != nil {
+			return nil, err
+		}
+
+		p.ExpiresAt = &t
+	}
+
+	if p.CreatedAt, err = time.Parse(datetimeLayout, createdAt); err != nil {
+		return nil, err
+	}
+
+	if p.UpdatedAt, err = time.Parse(datetimeLayout, updatedAt); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: t.UTC().Truncate(time.Second), Valid: true}
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+const paymentColumns = "SELECT p.id, p.uuid, p.order_id, o.uuid, p.method, p.provider, p.provider_reference, p.status, p.currency, p.amount, p.refunded, p.pix_code, p.boleto_line, p.boleto_barcode, p.failure_reason, p.expires_at, p.created_at, p.updated_at FROM payment_intents p JOIN orders o ON o.id = p.order_id"
+
+func paymentRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "uuid", "order_id", "order_uuid", "method", "provider", "provider_reference", "status", "currency", "amount", "refunded", "pix_code", "boleto_line", "boleto_barcode", "failure_reason", "expires_at", "created_at", "updated_at"})
+}
+
+func TestCreatePayment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	expiresAt := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	p := &domain.PaymentIntent{UUID: "pay-1", OrderID: 3, Method: domain.PaymentMethodPix, Provider: "fake", Status: domain.PaymentStatusPending, Amount: domain.Money{Amount: 9500, Currency: domain.CurrencyBRL}, ExpiresAt: &expiresAt}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO payment_intents (uuid, order_id, method, provider, provider_reference, status, currency, amount, pix_code, boleto_line, boleto_barcode, failure_reason, expires_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+		WithArgs("pay-1", 3, domain.PaymentMethodPix, "fake", "", domain.PaymentStatusPending, domain.CurrencyBRL, 9500, "", "", "", "", expiresAt, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(12, 1))
+
+	err = NewPaymentMysqlRepository(db).Create(context.Background(), p)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), p.ID)
+	assert.False(t, p.CreatedAt.IsZero())
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetPaymentByProviderReference(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	expiresAt := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(paymentColumns+" WHERE p.provider = ? AND p.provider_reference = ?;")).
+		WithArgs("fake", "fake_pay-1").
+		WillReturnRows(paymentRows().AddRow(12, "pay-1", 3, "order-1", "pix", "fake", "fake_pay-1", "pending", "BRL", 9500, 0, "000201", "", "", "", "2026-10-19 14:00:00", "2026-10-19 12:00:00", "2026-10-19 12:01:00"))
+
+	p, err := NewPaymentMysqlRepository(db).GetByProviderReference(context.Background(), "fake", "fake_pay-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.PaymentIntent{
+		ID:                12,
+		UUID:              "pay-1",
+		OrderID:           3,
+		OrderUUID:         "order-1",
+		Method:            domain.PaymentMethodPix,
+		Provider:          "fake",
+		ProviderReference: "fake_pay-1",
+		Status:            domain.PaymentStatusPending,
+		Amount:            domain.Money{Amount: 9500, Currency: domain.CurrencyBRL},
+		Refunded:          domain.Money{Currency: domain.CurrencyBRL},
+		PixCode:           "000201",
+		ExpiresAt:         &expiresAt,
+		CreatedAt:         time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		UpdatedAt:         time.Date(2026, 10, 19, 12, 1, 0, 0, time.UTC),
+	}, p)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetPaymentByUUIDNotExists(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(paymentColumns + " WHERE p.uuid = ?;")).WithArgs("pay-9").WillReturnRows(paymentRows())
+
+	p, err := NewPaymentMysqlRepository(db).GetByUUID(context.Background(), "pay-9")
+
+	assert.NoError(t, err)
+	assert.Nil(t, p)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestListPaymentsByOrder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(paymentColumns + " WHERE p.order_id = ? ORDER BY p.id;")).
+		WithArgs(3).
+		WillReturnRows(paymentRows().
+			AddRow(11, "pay-0", 3, "order-1", "card", "fake", "fake_pay-0", "failed", "BRL", 9500, 0, "", "", "", "card declined", nil, "2026-10-19 11:00:00", "2026-10-19 11:00:00").
+			AddRow(12, "pay-1", 3, "order-1", "card", "fake", "fake_pay-1", "captured", "BRL", 9500, 2000, "", "", "", "", nil, "2026-10-19 12:00:00", "2026-10-19 12:00:00"))
+
+	payments, err := NewPaymentMysqlRepository(db).ListByOrder(context.Background(), 3)
+
+	assert.NoError(t, err)
+	assert.Len(t, payments, 2)
+	assert.Equal(t, "card declined", payments[0].FailureReason)
+	assert.Equal(t, domain.PaymentStatusCaptured, payments[1].Status)
+	assert.Equal(t, domain.Money{Amount: 2000, Currency: domain.CurrencyBRL}, payments[1].Refunded)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestListPaymentsToReconcile(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(paymentColumns+" WHERE (p.status = ? AND o.status IN (?, ?))")+".*"+regexp.QuoteMeta("ORDER BY p.id LIMIT ?;")).
+		WithArgs("captured", "pending_payment", "cancelled", "pending", "authorized", "pending_payment", "captured", "captured", 100).
+		WillReturnRows(paymentRows().AddRow(12, "pay-1", 3, "order-1", "pix", "fake", "fake_pay-1", "captured", "BRL", 9500, 0, "000201", "", "", "", nil, "2026-10-19 12:00:00", "2026-10-19 12:00:00"))
+
+	payments, err := NewPaymentMysqlRepository(db).ListToReconcile(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, payments, 1)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdatePayment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	p := &domain.PaymentIntent{ID: 12, ProviderReference: "fake_pay-1", Status: domain.PaymentStatusCaptured}
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE payment_intents SET provider_reference = ?, status = ?, pix_code = ?, boleto_line = ?, boleto_barcode = ?, failure_reason = ?, updated_at = ? WHERE id = ? AND status = ?;")).
+		WithArgs("fake_pay-1", domain.PaymentStatusCaptured, "", "", "", "", sqlmock.AnyArg(), 12, domain.PaymentStatusAuthorized).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewPaymentMysqlRepository(db).Update(context.Background(), p, domain.PaymentStatusAuthorized)
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdatePaymentChanged(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE payment_intents SET")).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = NewPaymentMysqlRepository(db).Update(context.Background(), &domain.PaymentIntent{ID: 12, Status: domain.PaymentStatusVoided}, domain.PaymentStatusAuthorized)
+
+	assert.ErrorIs(t, err, domain.ErrPaymentChanged)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
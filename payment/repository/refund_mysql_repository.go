@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type refundMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewRefundMysqlRepository(conn *sql.DB) domain.RefundRepository {
+	return &refundMysqlRepository{Conn: conn}
+}
+
+// Create holds the amount on the payment with a conditional update, so two
+// refunds made at once never give back more than was captured.
+func (rmr *refundMysqlRepository) Create(ctx context.Context, r *domain.Refund) error {
+	r.CreatedAt = time.Now().UTC().Truncate(time.Second)
+
+	lines, err := json.Marshal(r.Lines)
+
+	if err != nil {
+		return err
+	}
+
+	tx, err := rmr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := tx.ExecContext(ctx, `UPDATE payment_intents SET refunded = refunded + ?, updated_at = ? WHERE id = ? AND status = ? AND refunded + ? <= amount;`, r.Amount.Amount, r.CreatedAt, r.PaymentID, domain.PaymentStatusCaptured, r.Amount.Amount)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if affect != 1 {
+		tx.Rollback()
+		return domain.ErrPaymentChanged
+	}
+
+	query := `INSERT INTO refunds (uuid, order_id, payment_id, provider_reference, status, currency, amount, items, reason, restocked, failure_reason, actor, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+	exec, err = tx.ExecContext(ctx, query, r.UUID, r.OrderID, r.PaymentID, r.ProviderReference, r.Status, r.Amount.Currency, r.Amount.Amount, string(lines), r.Reason, r.Restocked, r.FailureReason, r.Actor, r.CreatedAt, r.CreatedAt)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if r.ID, err = exec.LastInsertId(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Finish saves the answer of the gateway to a pending refund. The payment
+// may already be refunded by a webhook of the gateway, it is left as it is.
+func (rmr *refundMysqlRepository) Finish(ctx context.Context, r *domain.Refund) error {
+	updatedAt := time.Now().UTC().Truncate(time.Second)
+
+	tx, err := rmr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := tx.ExecContext(ctx, `UPDATE refunds SET provider_reference = ?, status = ?, restocked = ?, failure_reason = ?, updated_at = ? WHERE id = ? AND status = ?;`, r.ProviderReference, r.Status, r.Restocked, r.FailureReason, updatedAt, r.ID, domain.RefundStatusPending)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if affect != 1 {
+		tx.Rollback()
+		return domain.ErrPaymentChanged
+	}
+
+	switch r.Status {
+	case domain.RefundStatusFailed:
+		_, err = tx.ExecContext(ctx, `UPDATE payment_intents SET refunded = refunded - ?, updated_at = ? WHERE id = ?;`, r.Amount.Amount, updatedAt, r.PaymentID)
+	case domain.RefundStatusSucceeded:
+		_, err = tx.ExecContext(ctx, `UPDATE payment_intents SET status = ?, updated_at = ? WHERE id = ? AND status = ? AND refunded = amount;`, domain.PaymentStatusRefunded, updatedAt, r.PaymentID, domain.PaymentStatusCaptured)
+	}
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (rmr *refundMysqlRepository) ListByOrder(ctx context.Context, orderID int64) ([]domain.Refund, error) {
+	query := `SELECT r.id, r.uuid, r.order_id, r.payment_id, p.uuid, r.provider_reference, r.status, r.currency, r.amount, r.items, r.reason, r.restocked, r.failure_reason, r.actor, r.created_at FROM refunds r JOIN payment_intents p ON p.id = r.payment_id WHERE r.order_id = ? ORDER BY r.id;`
+
+	rows, err := rmr.Conn.QueryContext(ctx, query, orderID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []domain.Refund{}
+
+	for rows.Next() {
+		var r domain.Refund
+		var lines, createdAt string
+
+		err := rows.Scan(
+			&r.ID, &r.UUID, &r.OrderID, &r.PaymentID, &r.PaymentUUID, &r.ProviderReference, &r.Status,
+			&r.Amount.Currency, &r.Amount.Amount, &lines, &r.Reason, &r.Restocked, &r.FailureReason, &r.Actor, &createdAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal([]byte(lines), &r.Lines); err != nil {
+			return nil, err
+		}
+
+		if r.CreatedAt, err = time.Parse(datetimeLayout, createdAt); err != nil {
+			return nil, err
+		}
+
+		res = append(res, r)
+	}
+
+	return res, rows.Err()
+}
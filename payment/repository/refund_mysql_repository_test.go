@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func pendingRefund() *domain.Refund {
+	return &domain.Refund{
+		UUID:      "refund-1",
+		OrderID:   3,
+		PaymentID: 12,
+		Status:    domain.RefundStatusPending,
+		Amount:    domain.Money{Amount: 4000, Currency: domain.CurrencyBRL},
+		Lines:     []domain.RefundLine{{SKU: "P7-M", Quantity: 1}},
+		Reason:    "produto com defeito",
+		Actor:     "admin@test.com",
+	}
+}
+
+func TestCreateRefund(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE payment_intents SET refunded = refunded + ?, updated_at = ? WHERE id = ? AND status = ? AND refunded + ? <= amount;")).
+		WithArgs(4000, sqlmock.AnyArg(), 12, "captured", 4000).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO refunds (uuid, order_id, payment_id, provider_reference, status, currency, amount, items, reason, restocked, failure_reason, actor, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+		WithArgs("refund-1", 3, 12, "", "pending", "BRL", 4000, `[{"sku":"P7-M","quantity":1}]`, "produto com defeito", false, "", "admin@test.com", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(21, 1))
+	mock.ExpectCommit()
+
+	r := pendingRefund()
+
+	err = NewRefundMysqlRepository(db).Create(context.Background(), r)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(21), r.ID)
+	assert.False(t, r.CreatedAt.IsZero())
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCreateRefundExceedsPayment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE payment_intents SET refunded = refunded + ?")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err = NewRefundMysqlRepository(db).Create(context.Background(), pendingRefund())
+
+	assert.ErrorIs(t, err, domain.ErrPaymentChanged)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFinishRefundSucceeded(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	r := pendingRefund()
+	r.ID = 21
+	r.Status = domain.RefundStatusSucceeded
+	r.ProviderReference = "fake_pay-1_refund_1"
+	r.Restocked = true
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE refunds SET provider_reference = ?, status = ?, restocked = ?, failure_reason = ?, updated_at = ? WHERE id = ? AND status = ?;")).
+		WithArgs("fake_pay-1_refund_1", "succeeded", true, "", sqlmock.AnyArg(), 21, "pending").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE payment_intents SET status = ?, updated_at = ? WHERE id = ? AND status = ? AND refunded = amount;")).
+		WithArgs("refunded", sqlmock.AnyArg(), 12, "captured").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err = NewRefundMysqlRepository(db).Finish(context.Background(), r)
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFinishRefundFailed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	r := pendingRefund()
+	r.ID = 21
+	r.Status = domain.RefundStatusFailed
+	r.FailureReason = "saldo insuficiente"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE refunds SET provider_reference = ?")).
+		WithArgs("", "failed", false, "saldo insuficiente", sqlmock.AnyArg(), 21, "pending").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE payment_intents SET refunded = refunded - ?, updated_at = ? WHERE id = ?;")).
+		WithArgs(4000, sqlmock.AnyArg(), 12).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = NewRefundMysqlRepository(db).Finish(context.Background(), r)
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFinishRefundNotPending(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	r := pendingRefund()
+	r.ID = 21
+	r.Status = domain.RefundStatusFailed
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE refunds SET provider_reference = ?")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err = NewRefundMysqlRepository(db).Finish(context.Background(), r)
+
+	assert.ErrorIs(t, err, domain.ErrPaymentChanged)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestListRefundsByOrder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM refunds r JOIN payment_intents p ON p.id = r.payment_id WHERE r.order_id = ? ORDER BY r.id;")).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "uuid", "order_id", "payment_id", "payment_uuid", "provider_reference", "status", "currency", "amount", "items", "reason", "restocked", "failure_reason", "actor", "created_at"}).
+			AddRow(21, "refund-1", 3, 12, "pay-1", "fake_pay-1_refund_1", "succeeded", "BRL", 4000, `[{"sku":"P7-M","quantity":1}]`, "produto com defeito", true, "", "admin@test.com", "2026-10-19 15:00:00").
+			AddRow(22, "refund-2", 3, 12, "pay-1", "", "failed", "BRL", 1500, `[]`, "frete", false, "saldo insuficiente", "admin@test.com", "2026-10-19 16:00:00"))
+
+	refunds, err := NewRefundMysqlRepository(db).ListByOrder(context.Background(), 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Refund{
+		{
+			ID:                21,
+			UUID:              "refund-1",
+			OrderID:           3,
+			PaymentID:         12,
+			PaymentUUID:       "pay-1",
+			ProviderReference: "fake_pay-1_refund_1",
+			Status:            domain.RefundStatusSucceeded,
+			Amount:            domain.Money{Amount: 4000, Currency: domain.CurrencyBRL},
+			Lines:             []domain.RefundLine{{SKU: "P7-M", Quantity: 1}},
+			Reason:            "produto com defeito",
+			Restocked:         true,
+			Actor:             "admin@test.com",
+			CreatedAt:         time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:            22,
+			UUID:          "refund-2",
+			OrderID:       3,
+			PaymentID:     12,
+			PaymentUUID:   "pay-1",
+			Status:        domain.RefundStatusFailed,
+			Amount:        domain.Money{Amount: 1500, Currency: domain.CurrencyBRL},
+			Lines:         []domain.RefundLine{},
+			Reason:        "frete",
+			FailureReason: "saldo insuficiente",
+			Actor:         "admin@test.com",
+			CreatedAt:     time.Date(2026, 10, 19, 16, 0, 0, 0, time.UTC),
+		},
+	}, refunds)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// boletoCurrency is the code of the real in the bar codes.
+const boletoCurrency = "9"
+
+// boletoBase is the day the due date factor counts from, the factor went back
+// to 1000 on 2025-02-22 after reaching 9999.
+var boletoBase = time.Date(1997, 10, 7, 0, 0, 0, 0, time.UTC)
+
+// brazilTime is the time of Brasília, the due date of a boleto is a day there.
+var brazilTime = time.FixedZone("BRT", -3*60*60)
+
+// boletoBarcode builds the 44 digits of the bar code of a boleto in the
+// FEBRABAN layout: the bank, the currency, the check digit, the due date
+// factor, the amount in cents and the 25 digits of the free field, laid out
+// by each bank its own way.
+func boletoBarcode(bank string, due time.Time, amount int64, free string) (string, error) {
+	if len(bank) != 3 || !onlyDigits(bank) {
+		return "", fmt.Errorf("boleto bank must have 3 digits, got %q", bank)
+	}
+
+	if len(free) != 25 || !onlyDigits(free) {
+		return "", fmt.Errorf("boleto free field must have 25 digits, got %q", free)
+	}
+
+	if amount <= 0 || amount > 9999999999 {
+		return "", fmt.Errorf("boleto amount out of range, got %d", amount)
+	}
+
+	factor, err := boletoDueFactor(due)
+
+	if err != nil {
+		return "", err
+	}
+
+	code := bank + boletoCurrency + factor + fmt.Sprintf("%010d", amount) + free
+
+	return code[:4] + strconv.Itoa(mod11(code)) + code[4:], nil
+}
+
+// boletoDueFactor is the number of days from the base to the due date,
+// starting again from 1000 after 9999.
+func boletoDueFactor(due time.Time) (string, error) {
+	d := due.In(brazilTime)
+	days := int(time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC).Sub(boletoBase).Hours() / 24)
+
+	if days < 1000 {
+		return "", errors.New("boleto due date before the first factor")
+	}
+
+	return fmt.Sprintf("%04d", (days-1000)%9000+1000), nil
+}
+
+// boletoLine gives the digitable line of the bar code, the five fields the
+// customer types at the bank. The first three have their own check digits,
+// the fourth is the one of the bar code.
+func boletoLine(barcode string) string {
+	f1 := barcode[0:4] + barcode[19:24]
+	f2 := barcode[24:34]
+	f3 := barcode[34:44]
+
+	f1 += strconv.Itoa(mod10(f1))
+	f2 += strconv.Itoa(mod10(f2))
+	f3 += strconv.Itoa(mod10(f3))
+
+	return fmt.Sprintf("%s.%s %s.%s %s.%s %s %s", f1[:5], f1[5:], f2[:5], f2[5:], f3[:5], f3[5:], barcode[4:5], barcode[5:19])
+}
+
+// mod10 is the check digit of the fields of the digitable line, the digits
+// are weighted 2 and 1 from the right and the products summed digit by
+// digit.
+func mod10(s string) int {
+	sum, weight := 0, 2
+
+	for i := len(s) - 1; i >= 0; i-- {
+		n := int(s[i]-'0') * weight
+		sum += n/10 + n%10
+		weight = 3 - weight
+	}
+
+	return (10 - sum%10) % 10
+}
+
+// mod11 is the check digit of the bar code, the digits are weighted 2 to 9
+// from the right and the digits 0, 10 and 11 become 1.
+func mod11(s string) int {
+	sum, weight := 0, 2
+
+	for i := len(s) - 1; i >= 0; i-- {
+		sum += int(s[i]-'0') * weight
+
+		if weight++; weight > 9 {
+			weight = 2
+		}
+	}
+
+	dv := 11 - sum%11
+
+	if dv >= 10 {
+		return 1
+	}
+
+	return dv
+}
+
+func onlyDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
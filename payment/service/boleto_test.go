@@ -0,0 +1,74 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoletoBarcode(t *testing.T) {
+	due := time.Date(2007, 12, 31, 12, 0, 0, 0, brazilTime)
+
+	barcode, err := boletoBarcode("001", due, 100, "0500940144816060680935031")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "00193373700000001000500940144816060680935031", barcode)
+	assert.Equal(t, "00190.50095 40144.816069 06809.350314 3 37370000000100", boletoLine(barcode))
+}
+
+func TestBoletoDueFactor(t *testing.T) {
+	cases := map[time.Time]string{
+		time.Date(2000, 7, 3, 0, 0, 0, 0, brazilTime):   "1000",
+		time.Date(2025, 2, 21, 0, 0, 0, 0, brazilTime):  "9999",
+		time.Date(2025, 2, 22, 0, 0, 0, 0, brazilTime):  "1000",
+		time.Date(2026, 10, 19, 0, 0, 0, 0, brazilTime): "1604",
+		// still the 19th in Brasília
+		time.Date(2026, 10, 20, 2, 0, 0, 0, time.UTC): "1604",
+	}
+
+	for due, expected := range cases {
+		factor, err := boletoDueFactor(due)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, factor, due.String())
+	}
+
+	_, err := boletoDueFactor(time.Date(1998, 1, 1, 0, 0, 0, 0, brazilTime))
+
+	assert.Error(t, err)
+}
+
+func TestBoletoBarcodeInvalid(t *testing.T) {
+	due := time.Date(2026, 10, 22, 0, 0, 0, 0, brazilTime)
+
+	_, err := boletoBarcode("01", due, 100, strings.Repeat("0", 25))
+	assert.Error(t, err)
+
+	_, err = boletoBarcode("001", due, 100, strings.Repeat("0", 24))
+	assert.Error(t, err)
+
+	_, err = boletoBarcode("001", due, 0, strings.Repeat("0", 25))
+	assert.Error(t, err)
+
+	_, err = boletoBarcode("001", due, 10000000000, strings.Repeat("0", 25))
+	assert.Error(t, err)
+}
+
+func TestBoletoLineCheckDigits(t *testing.T) {
+	barcode, err := boletoBarcode("999", time.Date(2026, 10, 22, 0, 0, 0, 0, brazilTime), 9880, "1234567890123456789012345")
+
+	assert.NoError(t, err)
+	assert.Len(t, barcode, 44)
+	assert.Equal(t, mod11(barcode[:4]+barcode[5:]), int(barcode[4]-'0'))
+
+	line := strings.NewReplacer(".", "", " ", "").Replace(boletoLine(barcode))
+
+	assert.Len(t, line, 47)
+	assert.Equal(t, mod10(line[0:9]), int(line[9]-'0'))
+	assert.Equal(t, mod10(line[10:20]), int(line[20]-'0'))
+	assert.Equal(t, mod10(line[21:31]), int(line[31]-'0'))
+	assert.Equal(t, barcode[4:5], line[32:33])
+	assert.Equal(t, barcode[5:19], line[33:])
+}
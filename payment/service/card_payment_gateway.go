@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+// cardStatuses are the statuses of the card processor.
+var cardStatuses = map[string]domain.PaymentStatus{
+	"authorized": domain.PaymentStatusAuthorized,
+	"captured":   domain.PaymentStatusCaptured,
+	"voided":     domain.PaymentStatusVoided,
+	"declined":   domain.PaymentStatusFailed,
+	"failed":     domain.PaymentStatusFailed,
+	"refunded":   domain.PaymentStatusRefunded,
+	"succeeded":  domain.PaymentStatusRefunded,
+}
+
+type cardPayment struct {
+	ID            string `json:"id"`
+	Status        string `json:"status"`
+	DeclineReason string `json:"declineReason"`
+}
+
+type cardWebhook struct {
+	Type string `json:"type"`
+	Data struct {
+		ID       string          `json:"id"`
+		Amount   int64           `json:"amount"`
+		Currency domain.Currency `json:"currency"`
+	} `json:"data"`
+}
+
+type cardPaymentGateway struct {
+	Client        *http.Client
+	Endpoint      string
+	APIKey        string
+	WebhookSecret string
+}
+
+// NewCardPaymentGateway talks to the REST API of the card processor. The
+// cards are tokenized by the processor in the browser, only their tokens
+// reach the store.
+func NewCardPaymentGateway(endpoint string, apiKey string, webhookSecret string) *cardPaymentGateway {
+	return &cardPaymentGateway{
+		Client:        &http.Client{Timeout: 30 * time.Second},
+		Endpoint:      strings.TrimRight(endpoint, "/"),
+		APIKey:        apiKey,
+		WebhookSecret: webhookSecret,
+	}
+}
+
+func (cpg *cardPaymentGateway) Name() string {
+	return "card"
+}
+
+// Authorize only holds the amount, the reference as the Idempotency-Key
+// makes a retry give back the same payment.
+func (cpg *cardPaymentGateway) Authorize(ctx context.Context, a *domain.PaymentAuthorization) (*domain.PaymentResult, error) {
+	if a.Method != domain.PaymentMethodCard {
+		return nil, domain.ErrPaymentMethodNotSupported
+	}
+
+	body := map[string]interface{}{
+		"reference":   a.Reference,
+		"amount":      a.Amount.Amount,
+		"currency":    a.Amount.Currency,
+		"source":      a.CardToken,
+		"description": a.Description,
+		"capture":     false,
+	}
+
+	header := cpg.header()
+	header.Set("Idempotency-Key", a.Reference)
+
+	return cpg.send(ctx, http.MethodPost, "/v1/payments", header, body)
+}
+
+func (cpg *cardPaymentGateway) Capture(ctx context.Context, providerReference string, amount domain.Money) (*domain.PaymentResult, error) {
+	return cpg.send(ctx, http.MethodPost, "/v1/payments/"+url.PathEscape(providerReference)+"/capture", cpg.header(), map[string]interface{}{"amount": amount.Amount})
+}
+
+func (cpg *cardPaymentGateway) Void(ctx context.Context, providerReference string) (*domain.PaymentResult, error) {
+	return cpg.send(ctx, http.MethodPost, "/v1/payments/"+url.PathEscape(providerReference)+"/void", cpg.header(), nil)
+}
+
+// Refund gives the reference of the refund, not of the payment. Like in
+// Authorize, the reference as the Idempotency-Key makes a retry give back
+// the same refund.
+func (cpg *cardPaymentGateway) Refund(ctx context.Context, providerReference string, reference string, amount domain.Money) (*domain.PaymentResult, error) {
+	header := cpg.header()
+	header.Set("Idempotency-Key", reference)
+
+	return cpg.send(ctx, http.MethodPost, "/v1/payments/"+url.PathEscape(providerReference)+"/refunds", header, map[string]interface{}{"amount": amount.Amount})
+}
+
+func (cpg *cardPaymentGateway) VerifyWebhook(ctx context.Context, payload []byte, signature string) ([]domain.PaymentEvent, error) {
+	if !validSignature(cpg.WebhookSecret, payload, signature) {
+		return nil, domain.ErrInvalidWebhook
+	}
+
+	var w cardWebhook
+
+	if err := json.Unmarshal(payload, &w); err != nil {
+		return nil, domain.ErrInvalidWebhook
+	}
+
+	status, ok := cardStatuses[strings.TrimPrefix(w.Type, "payment.")]
+
+	// the processor sends other events the store does not follow
+	if !ok {
+		return []domain.PaymentEvent{}, nil
+	}
+
+	return []domain.PaymentEvent{{ProviderReference: w.Data.ID, Status: status, Amount: domain.Money{Amount: w.Data.Amount, Currency: w.Data.Currency}}}, nil
+}
+
+func (cpg *cardPaymentGateway) send(ctx context.Context, method string, path string, header http.Header, body interface{}) (*domain.PaymentResult, error) {
+	var p cardPayment
+
+	if err := sendJSON(ctx, cpg.Client, cpg.Name(), method, cpg.Endpoint+path, header, body, &p); err != nil {
+		return nil, err
+	}
+
+	status, ok := cardStatuses[p.Status]
+
+	if !ok {
+		return nil, &gatewayError{cpg.Name(), method, cpg.Endpoint + path, http.StatusOK, "unknown status " + p.Status}
+	}
+
+	return &domain.PaymentResult{ProviderReference: p.ID, Status: status, FailureReason: p.DeclineReason}, nil
+}
+
+func (cpg *cardPaymentGateway) header() http.Header {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+cpg.APIKey)
+
+	return header
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCardAuthorize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/payments", r.URL.Path)
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+		assert.Equal(t, "pay-1", r.Header.Get("Idempotency-Key"))
+		assert.JSONEq(t, `{"reference":"pay-1","amount":9500,"currency":"BRL","source":"tok_visa","description":"Pedido order-1","capture":false}`, string(body))
+
+		w.Write([]byte(`{"id":"ch_1","status":"authorized"}`))
+	}))
+	defer server.Close()
+
+	res, err := NewCardPaymentGateway(server.URL, "key", "secret").Authorize(context.Background(), &domain.PaymentAuthorization{Reference: "pay-1", Method: domain.PaymentMethodCard, Amount: brl(9500), CardToken: "tok_visa", Description: "Pedido order-1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.PaymentResult{ProviderReference: "ch_1", Status: domain.PaymentStatusAuthorized}, res)
+}
+
+func TestCardAuthorizeDeclined(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"ch_1","status":"declined","declineReason":"insufficient funds"}`))
+	}))
+	defer server.Close()
+
+	res, err := NewCardPaymentGateway(server.URL, "key", "secret").Authorize(context.Background(), &domain.PaymentAuthorization{Reference: "pay-1", Method: domain.PaymentMethodCard, Amount: brl(9500), CardToken: "tok_visa"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.PaymentResult{ProviderReference: "ch_1", Status: domain.PaymentStatusFailed, FailureReason: "insufficient funds"}, res)
+}
+
+func TestCardCapture(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		assert.Equal(t, "/v1/payments/ch_1/capture", r.URL.Path)
+		assert.JSONEq(t, `{"amount":9500}`, string(body))
+
+		w.Write([]byte(`{"id":"ch_1","status":"captured"}`))
+	}))
+	defer server.Close()
+
+	res, err := NewCardPaymentGateway(server.URL, "key", "secret").Capture(context.Background(), "ch_1", brl(9500))
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusCaptured, res.Status)
+}
+
+func TestCardVoidRefused(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/payments/ch_1/void", r.URL.Path)
+
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error":"payment already captured"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewCardPaymentGateway(server.URL, "key", "secret").Void(context.Background(), "ch_1")
+
+	assert.ErrorIs(t, err, domain.ErrPaymentInvalidState)
+}
+
+func TestCardRefundError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "refund-1", r.Header.Get("Idempotency-Key"))
+
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, err := NewCardPaymentGateway(server.URL, "key", "secret").Refund(context.Background(), "ch_1", "refund-1", brl(100))
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, domain.ErrPaymentInvalidState)
+}
+
+func TestCardVerifyWebhook(t *testing.T) {
+	payload := []byte(`{"type":"payment.captured","data":{"id":"ch_1","amount":9500,"currency":"BRL"}}`)
+
+	events, err := NewCardPaymentGateway("", "key", "secret").VerifyWebhook(context.Background(), payload, sign("secret", payload))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.PaymentEvent{{ProviderReference: "ch_1", Status: domain.PaymentStatusCaptured, Amount: brl(9500)}}, events)
+
+	_, err = NewCardPaymentGateway("", "key", "secret").VerifyWebhook(context.Background(), payload, "bad")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidWebhook)
+}
@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+// Test card tokens of the fake gateway, any other token is approved.
+const (
+	FakeCardDeclined          = "tok_declined"
+	FakeCardInsufficientFunds = "tok_insufficient_funds"
+)
+
+// fakeBoletoBank is the bank of the boletos of the fake gateway, no bank has
+// this code.
+const fakeBoletoBank = "999"
+
+// fakeBoletoTerm is when the boletos are due when the authorization does not
+// say.
+const fakeBoletoTerm = 3 * 24 * time.Hour
+
+type fakePayment struct {
+	method   domain.PaymentMethod
+	status   domain.PaymentStatus
+	reason   string
+	amount   domain.Money
+	refunded int64
+	refunds  int
+	given    map[string]string
+	barcode  string
+	expires  time.Time
+}
+
+// fakeWebhook is the body of the webhooks of the fake gateway.
+type fakeWebhook struct {
+	Reference string               `json:"reference"`
+	Status    domain.PaymentStatus `json:"status"`
+	Amount    domain.Money         `json:"amount"`
+}
+
+type fakePaymentGateway struct {
+	mu            sync.Mutex
+	payments      map[string]*fakePayment
+	WebhookSecret string
+	PixKey        string
+	MerchantName  string
+	MerchantCity  string
+	now           func() time.Time
+}
+
+// NewFakePaymentGateway keeps the payments in memory and answers the same
+// way for the same requests, for the tests and to run the store offline.
+// Cards are authorized unless their token is one of the FakeCard ones, the
+// pix and the boletos wait to be paid by Settle or by a webhook.
+func NewFakePaymentGateway(webhookSecret string, pixKey string, merchantName string, merchantCity string) *fakePaymentGateway {
+	return &fakePaymentGateway{
+		payments:      map[string]*fakePayment{},
+		WebhookSecret: webhookSecret,
+		PixKey:        pixKey,
+		MerchantName:  merchantName,
+		MerchantCity:  merchantCity,
+		now:           time.Now,
+	}
+}
+
+func (fpg *fakePaymentGateway) Name() string {
+	return "fake"
+}
+
+func (fpg *fakePaymentGateway) Authorize(ctx context.Context, a *domain.PaymentAuthorization) (*domain.PaymentResult, error) {
+	fpg.mu.Lock()
+	defer fpg.mu.Unlock()
+
+	ref := "fake_" + a.Reference
+
+	if p, ok := fpg.payments[ref]; ok {
+		return fpg.result(ref, p), nil
+	}
+
+	p := &fakePayment{method: a.Method, status: domain.PaymentStatusAuthorized, amount: a.Amount, expires: a.ExpiresAt}
+
+	switch {
+	case (a.Method == domain.PaymentMethodPix || a.Method == domain.PaymentMethodBoleto) && a.Amount.Currency != domain.CurrencyBRL:
+		p.status, p.reason = domain.PaymentStatusFailed, string(a.Method)+" only takes payments in BRL"
+	case a.Method == domain.PaymentMethodPix:
+		p.status = domain.PaymentStatusPending
+	case a.Method == domain.PaymentMethodBoleto:
+		if p.expires.IsZero() {
+			p.expires = fpg.now().Add(fakeBoletoTerm)
+		}
+
+		barcode, err := boletoBarcode(fakeBoletoBank, p.expires, a.Amount.Amount, fakeBoletoFree(ref))
+
+		if err != nil {
+			return nil, err
+		}
+
+		p.status, p.barcode = domain.PaymentStatusPending, barcode
+	case a.Method != domain.PaymentMethodCard:
+		return nil, domain.ErrPaymentMethodNotSupported
+	case a.CardToken == FakeCardDeclined:
+		p.status, p.reason = domain.PaymentStatusFailed, "card declined"
+	case a.CardToken == FakeCardInsufficientFunds:
+		p.status, p.reason = domain.PaymentStatusFailed, "insufficient funds"
+	}
+
+	fpg.payments[ref] = p
+
+	return fpg.result(ref, p), nil
+}
+
+func (fpg *fakePaymentGateway) Capture(ctx context.Context, providerReference string, amount domain.Money) (*domain.PaymentResult, error) {
+	fpg.mu.Lock()
+	defer fpg.mu.Unlock()
+
+	p, err := fpg.payment(providerReference)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if p.status != domain.PaymentStatusAuthorized || amount.Currency != p.amount.Currency || amount.Amount > p.amount.Amount {
+		return nil, domain.ErrPaymentInvalidState
+	}
+
+	p.status = domain.PaymentStatusCaptured
+	p.amount = amount
+
+	return fpg.result(providerReference, p), nil
+}
+
+func (fpg *fakePaymentGateway) Void(ctx context.Context, providerReference string) (*domain.PaymentResult, error) {
+	fpg.mu.Lock()
+	defer fpg.mu.Unlock()
+
+	p, err := fpg.payment(providerReference)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if p.status != domain.PaymentStatusPending && p.status != domain.PaymentStatusAuthorized {
+		return nil, domain.ErrPaymentInvalidState
+	}
+
+	p.status = domain.PaymentStatusVoided
+
+	return fpg.result(providerReference, p), nil
+}
+
+// Refund gives back part or all of what was captured, the payment is
+// refunded once all of it is given back. A refund asked again with the same
+// reference is given back only once.
+func (fpg *fakePaymentGateway) Refund(ctx context.Context, providerReference string, reference string, amount domain.Money) (*domain.PaymentResult, error) {
+	fpg.mu.Lock()
+	defer fpg.mu.Unlock()
+
+	p, err := fpg.payment(providerReference)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if given, ok := p.given[reference]; ok {
+		return &domain.PaymentResult{ProviderReference: given, Status: domain.PaymentStatusRefunded}, nil
+	}
+
+	if p.status != domain.PaymentStatusCaptured || amount.Currency != p.amount.Currency || amount.Amount <= 0 || p.refunded+amount.Amount > p.amount.Amount {
+		return nil, domain.ErrPaymentInvalidState
+	}
+
+	p.refunded += amount.Amount
+	p.refunds++
+
+	if p.refunded == p.amount.Amount {
+		p.status = domain.PaymentStatusRefunded
+	}
+
+	given := fmt.Sprintf("%s_refund_%d", providerReference, p.refunds)
+
+	if p.given == nil {
+		p.given = map[string]string{}
+	}
+
+	p.given[reference] = given
+
+	return &domain.PaymentResult{ProviderReference: given, Status: domain.PaymentStatusRefunded}, nil
+}
+
+// VerifyWebhook takes the webhooks of Settle and the ones signed by hand,
+// the payments they tell about are changed in memory too, so a payment
+// made before a restart can still be refunded.
+func (fpg *fakePaymentGateway) VerifyWebhook(ctx context.Context, payload []byte, signature string) ([]domain.PaymentEvent, error) {
+	if !validSignature(fpg.WebhookSecret, payload, signature) {
+		return nil, domain.ErrInvalidWebhook
+	}
+
+	var w fakeWebhook
+
+	if err := json.Unmarshal(payload, &w); err != nil || w.Reference == "" {
+		return nil, domain.ErrInvalidWebhook
+	}
+
+	fpg.mu.Lock()
+
+	if p, ok := fpg.payments[w.Reference]; ok {
+		p.status = w.Status
+	} else {
+		fpg.payments[w.Reference] = &fakePayment{status: w.Status, amount: w.Amount}
+	}
+
+	fpg.mu.Unlock()
+
+	return []domain.PaymentEvent{{ProviderReference: w.Reference, Status: w.Status, Amount: w.Amount}}, nil
+}
+
+// Settle pays a pending pix or boleto as the customer would, giving the
+// webhook the gateway sends about it and its signature. The expired ones can
+// not be paid.
+func (fpg *fakePaymentGateway) Settle(providerReference string) ([]byte, string, error) {
+	fpg.mu.Lock()
+	defer fpg.mu.Unlock()
+
+	p, err := fpg.payment(providerReference)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if p.status != domain.PaymentStatusPending || (!p.expires.IsZero() && fpg.now().After(p.expires)) {
+		return nil, "", domain.ErrPaymentInvalidState
+	}
+
+	p.status = domain.PaymentStatusCaptured
+
+	payload, err := json.Marshal(fakeWebhook{Reference: providerReference, Status: p.status, Amount: p.amount})
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return payload, sign(fpg.WebhookSecret, payload), nil
+}
+
+func (fpg *fakePaymentGateway) payment(providerReference string) (*fakePayment, error) {
+	p, ok := fpg.payments[providerReference]
+
+	if !ok {
+		return nil, fmt.Errorf("fake payment %s not found", providerReference)
+	}
+
+	return p, nil
+}
+
+func (fpg *fakePaymentGateway) result(ref string, p *fakePayment) *domain.PaymentResult {
+	res := &domain.PaymentResult{ProviderReference: ref, Status: p.status, FailureReason: p.reason}
+
+	if p.method == domain.PaymentMethodPix && p.status == domain.PaymentStatusPending {
+		res.PixCode = pixPayload(staticPixAccount(fpg.PixKey), false, fpg.MerchantName, fpg.MerchantCity, p.amount.Amount, pixTxid(ref, 25))
+	}
+
+	if p.method == domain.PaymentMethodBoleto && p.status == domain.PaymentStatusPending {
+		res.BoletoBarcode, res.BoletoLine = p.barcode, boletoLine(p.barcode)
+	}
+
+	return res
+}
+
+// fakeBoletoFree is the free field of the boletos of the fake gateway, taken
+// from the reference so the same payment has the same boleto.
+func fakeBoletoFree(reference string) string {
+	var b strings.Builder
+
+	for _, c := range sha256.Sum256([]byte(reference)) {
+		if b.Len() == 25 {
+			break
+		}
+
+		b.WriteByte('0' + c%10)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func brl(amount int64) domain.Money {
+	return domain.Money{Amount: amount, Currency: domain.CurrencyBRL}
+}
+
+func TestFakeAuthorizeCard(t *testing.T) {
+	gateway := NewFakePaymentGateway("secret", "loja@example.com", "Loja", "Recife")
+
+	res, err := gateway.Authorize(context.Background(), &domain.PaymentAuthorization{Reference: "pay-1", Method: domain.PaymentMethodCard, Amount: brl(9500), CardToken: "tok_visa"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.PaymentResult{ProviderReference: "fake_pay-1", Status: domain.PaymentStatusAuthorized}, res)
+
+	res, err = gateway.Capture(context.Background(), "fake_pay-1", brl(9500))
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusCaptured, res.Status)
+
+	_, err = gateway.Void(context.Background(), "fake_pay-1")
+
+	assert.ErrorIs(t, err, domain.ErrPaymentInvalidState)
+}
+
+func TestFakeAuthorizeCardDeclined(t *testing.T) {
+	gateway := NewFakePaymentGateway("secret", "loja@example.com", "Loja", "Recife")
+
+	// the same reference answers the same
+	for i := 0; i < 2; i++ {
+		res, err := gateway.Authorize(context.Background(), &domain.PaymentAuthorization{Reference: "pay-1", Method: domain.PaymentMethodCard, Amount: brl(9500), CardToken: FakeCardDeclined})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.PaymentResult{ProviderReference: "fake_pay-1", Status: domain.PaymentStatusFailed, FailureReason: "card declined"}, res)
+	}
+}
+
+func TestFakeAuthorizePixAndSettle(t *testing.T) {
+	gateway := NewFakePaymentGateway("secret", "loja@example.com", "Loja", "Recife")
+
+	res, err := gateway.Authorize(context.Background(), &domain.PaymentAuthorization{Reference: "pay-1", Method: domain.PaymentMethodPix, Amount: brl(9500)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusPending, res.Status)
+	assert.True(t, strings.HasPrefix(res.PixCode, "000201"))
+	assert.Contains(t, res.PixCode, "0116loja@example.com")
+	assert.Contains(t, res.PixCode, "540595.00")
+
+	_, err = gateway.Capture(context.Background(), "fake_pay-1", brl(9500))
+
+	assert.ErrorIs(t, err, domain.ErrPaymentInvalidState)
+
+	payload, signature, err := gateway.Settle("fake_pay-1")
+
+	assert.NoError(t, err)
+
+	events, err := gateway.VerifyWebhook(context.Background(), payload, signature)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.PaymentEvent{{ProviderReference: "fake_pay-1", Status: domain.PaymentStatusCaptured, Amount: brl(9500)}}, events)
+}
+
+func TestFakeAuthorizeBoleto(t *testing.T) {
+	gateway := NewFakePaymentGateway("secret", "loja@example.com", "Loja", "Recife")
+	gateway.now = func() time.Time { return time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC) }
+
+	res, err := gateway.Authorize(context.Background(), &domain.PaymentAuthorization{Reference: "pay-1", Method: domain.PaymentMethodBoleto, Amount: brl(9880)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusPending, res.Status)
+	assert.Len(t, res.BoletoBarcode, 44)
+	// due in 3 days, the factor of 2026-10-22
+	assert.Equal(t, "99991607", res.BoletoBarcode[:3]+res.BoletoBarcode[3:4]+res.BoletoBarcode[5:9])
+	assert.Equal(t, "0000009880", res.BoletoBarcode[9:19])
+	assert.Equal(t, boletoLine(res.BoletoBarcode), res.BoletoLine)
+
+	again, err := gateway.Authorize(context.Background(), &domain.PaymentAuthorization{Reference: "pay-1", Method: domain.PaymentMethodBoleto, Amount: brl(9880)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, res, again)
+
+	_, _, err = gateway.Settle("fake_pay-1")
+
+	assert.NoError(t, err)
+}
+
+func TestFakeSettleExpired(t *testing.T) {
+	gateway := NewFakePaymentGateway("secret", "loja@example.com", "Loja", "Recife")
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	gateway.now = func() time.Time { return now }
+
+	_, err := gateway.Authorize(context.Background(), &domain.PaymentAuthorization{Reference: "pay-1", Method: domain.PaymentMethodPix, Amount: brl(9500), ExpiresAt: now.Add(time.Hour)})
+
+	assert.NoError(t, err)
+
+	now = now.Add(2 * time.Hour)
+
+	_, _, err = gateway.Settle("fake_pay-1")
+
+	assert.ErrorIs(t, err, domain.ErrPaymentInvalidState)
+
+	res, err := gateway.Void(context.Background(), "fake_pay-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusVoided, res.Status)
+}
+
+func TestFakeAuthorizeBoletoNotBRL(t *testing.T) {
+	gateway := NewFakePaymentGateway("secret", "loja@example.com", "Loja", "Recife")
+
+	res, err := gateway.Authorize(context.Background(), &domain.PaymentAuthorization{Reference: "pay-1", Method: domain.PaymentMethodBoleto, Amount: domain.Money{Amount: 9500, Currency: "USD"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.PaymentResult{ProviderReference: "fake_pay-1", Status: domain.PaymentStatusFailed, FailureReason: "boleto only takes payments in BRL"}, res)
+}
+
+func TestFakeVerifyWebhookSignedByHand(t *testing.T) {
+	gateway := NewFakePaymentGateway("secret", "loja@example.com", "Loja", "Recife")
+
+	payload := []byte(`{"reference":"fake_pay-1","status":"captured","amount":{"amount":9500,"currency":"BRL"}}`)
+
+	events, err := gateway.VerifyWebhook(context.Background(), payload, sign("secret", payload))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.PaymentEvent{{ProviderReference: "fake_pay-1", Status: domain.PaymentStatusCaptured, Amount: brl(9500)}}, events)
+
+	// unknown before the webhook, it can be refunded now
+	_, err = gateway.Refund(context.Background(), "fake_pay-1", "refund-1", brl(9500))
+
+	assert.NoError(t, err)
+}
+
+func TestFakeVerifyWebhookInvalid(t *testing.T) {
+	gateway := NewFakePaymentGateway("secret", "loja@example.com", "Loja", "Recife")
+
+	_, err := gateway.VerifyWebhook(context.Background(), []byte(`{"reference":"fake_pay-1","status":"captured"}`), sign("other", []byte(`{"reference":"fake_pay-1","status":"captured"}`)))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidWebhook)
+}
+
+func TestFakeRefund(t *testing.T) {
+	gateway := NewFakePaymentGateway("secret", "loja@example.com", "Loja", "Recife")
+
+	gateway.Authorize(context.Background(), &domain.PaymentAuthorization{Reference: "pay-1", Method: domain.PaymentMethodCard, Amount: brl(9500), CardToken: "tok_visa"})
+	gateway.Capture(context.Background(), "fake_pay-1", brl(9500))
+
+	res, err := gateway.Refund(context.Background(), "fake_pay-1", "refund-1", brl(4000))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.PaymentResult{ProviderReference: "fake_pay-1_refund_1", Status: domain.PaymentStatusRefunded}, res)
+
+	// a retry of the same refund does not give it back again
+	res, err = gateway.Refund(context.Background(), "fake_pay-1", "refund-1", brl(4000))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.PaymentResult{ProviderReference: "fake_pay-1_refund_1", Status: domain.PaymentStatusRefunded}, res)
+
+	_, err = gateway.Refund(context.Background(), "fake_pay-1", "refund-2", brl(6000))
+
+	assert.ErrorIs(t, err, domain.ErrPaymentInvalidState)
+
+	res, err = gateway.Refund(context.Background(), "fake_pay-1", "refund-3", brl(5500))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "fake_pay-1_refund_2", res.ProviderReference)
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+// gatewayError is an answer of a provider other than a success.
+type gatewayError struct {
+	Provider   string
+	Method     string
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *gatewayError) Error() string {
+	return fmt.Sprintf("%s answered %s %s with %d: %s", e.Provider, e.Method, e.URL, e.StatusCode, e.Body)
+}
+
+// sendJSON sends in as the json body, when not nil, and reads the answer in
+// out. The 409 and 422 answers are refusals for the status of the payment.
+func sendJSON(ctx context.Context, client *http.Client, provider string, method string, url string, header http.Header, in interface{}, out interface{}) error {
+	var body io.Reader
+
+	if in != nil {
+		b, err := json.Marshal(in)
+
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+
+	if err != nil {
+		return err
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	b, err := ioutil.ReadAll(io.LimitReader(res.Body, 1<<20))
+
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode == http.StatusConflict || res.StatusCode == http.StatusUnprocessableEntity {
+		return fmt.Errorf("%w: %s", domain.ErrPaymentInvalidState, (&gatewayError{provider, method, url, res.StatusCode, string(b)}).Error())
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return &gatewayError{provider, method, url, res.StatusCode, string(b)}
+	}
+
+	if out == nil || len(b) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(b, out)
+}
+
+// sign is the hex HMAC-SHA256 of the payload the providers send as the
+// signature of their webhooks.
+func sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func validSignature(secret string, payload []byte, signature string) bool {
+	return secret != "" && hmac.Equal([]byte(sign(secret, payload)), []byte(signature))
+}
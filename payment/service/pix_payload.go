@@ -0,0 +1,160 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const pixGUI = "br.gov.bcb.pix"
+
+var transliterations = map[rune]string{
+	'á': "a", 'à': "a", 'â': "a", 'ã': "a", 'ä': "a",
+	'é': "e", 'è': "e", 'ê': "e", 'ë': "e",
+	'í': "i", 'ì': "i", 'î': "i", 'ï': "i",
+	'ó': "o", 'ò': "o", 'ô': "o", 'õ': "o", 'ö': "o",
+	'ú': "u", 'ù': "u", 'û': "u", 'ü': "u",
+	'ç': "c", 'ñ': "n",
+	'Á': "A", 'À': "A", 'Â': "A", 'Ã': "A", 'Ä': "A",
+	'É': "E", 'È': "E", 'Ê': "E", 'Ë': "E",
+	'Í': "I", 'Ì': "I", 'Î': "I", 'Ï': "I",
+	'Ó': "O", 'Ò': "O", 'Ô': "O", 'Õ': "O", 'Ö': "O",
+	'Ú': "U", 'Ù': "U", 'Û': "U", 'Ü': "U",
+	'Ç': "C", 'Ñ': "N",
+}
+
+// staticPixAccount identifies the receiver by its pix key, the payer's bank
+// reads the amount and the txid from the payload.
+func staticPixAccount(key string) string {
+	return emv("00", pixGUI) + emv("01", key)
+}
+
+// dynamicPixAccount points to the charge created at the PSP, the payer's
+// bank reads it from the location.
+func dynamicPixAccount(location string) string {
+	return emv("00", pixGUI) + emv("25", location)
+}
+
+// pixPayload builds the BR Code of a pix, the EMV payload shown as the QR
+// code and copied as the "copia e cola". The dynamic ones are for a single
+// payment and take "***" as the txid, the charge at the location has it.
+func pixPayload(account string, dynamic bool, name string, city string, amount int64, txid string) string {
+	var b strings.Builder
+
+	b.WriteString(emv("00", "01"))
+
+	if dynamic {
+		b.WriteString(emv("01", "12"))
+	}
+
+	b.WriteString(emv("26", account))
+	b.WriteString(emv("52", "0000"))
+	b.WriteString(emv("53", "986"))
+
+	if amount > 0 {
+		b.WriteString(emv("54", formatCents(amount)))
+	}
+
+	b.WriteString(emv("58", "BR"))
+	b.WriteString(emv("59", merchantText(name, 25)))
+	b.WriteString(emv("60", merchantText(city, 15)))
+	b.WriteString(emv("62", emv("05", txid)))
+	b.WriteString("6304")
+
+	return b.String() + fmt.Sprintf("%04X", crc16(b.String()))
+}
+
+func emv(id string, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+// merchantText keeps the name in the ascii the bank apps show, cut to size.
+func merchantText(s string, size int) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		if t, ok := transliterations[r]; ok {
+			b.WriteString(t)
+		} else if r >= ' ' && r < utf8.RuneSelf {
+			b.WriteRune(r)
+		}
+	}
+
+	text := strings.TrimSpace(b.String())
+
+	if len(text) > size {
+		text = strings.TrimSpace(text[:size])
+	}
+
+	return text
+}
+
+// pixTxid is the txid of the charge of a payment, up to size letters and
+// digits of the reference.
+func pixTxid(reference string, size int) string {
+	var b strings.Builder
+
+	for _, r := range reference {
+		if (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			b.WriteRune(r)
+		}
+
+		if b.Len() == size {
+			break
+		}
+	}
+
+	return b.String()
+}
+
+// crc16 is the CRC-16/CCITT-FALSE the BR Code ends with.
+func crc16(s string) uint16 {
+	crc := uint16(0xFFFF)
+
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
+
+// formatCents writes the cents as the decimal amount the pix uses, 9500 is
+// "95.00".
+func formatCents(amount int64) string {
+	return fmt.Sprintf("%d.%02d", amount/100, amount%100)
+}
+
+func parseCents(s string) (int64, error) {
+	units, cents := s, "00"
+
+	if i := strings.Index(s, "."); i >= 0 {
+		units, cents = s[:i], s[i+1:]
+	}
+
+	if len(cents) != 2 {
+		return 0, fmt.Errorf("invalid pix amount %q", s)
+	}
+
+	u, err := strconv.ParseUint(units, 10, 63)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid pix amount %q", s)
+	}
+
+	c, err := strconv.ParseUint(cents, 10, 8)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid pix amount %q", s)
+	}
+
+	return int64(u)*100 + int64(c), nil
+}
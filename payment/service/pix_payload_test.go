@@ -0,0 +1,47 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPixPayload(t *testing.T) {
+	// the example of the BR Code manual of the Banco Central do Brasil
+	payload := pixPayload(staticPixAccount("123e4567-e12b-12d1-a456-426655440000"), false, "Fulano de Tal", "BRASILIA", 0, "***")
+
+	assert.Equal(t, "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D", payload)
+}
+
+func TestPixPayloadDynamic(t *testing.T) {
+	payload := pixPayload(dynamicPixAccount("pix.example.com/qr/v2/9d36b84f"), true, "Loja São João", "São José dos Campos", 9500, "***")
+
+	assert.Contains(t, payload, "010212")
+	assert.Contains(t, payload, "2530pix.example.com/qr/v2/9d36b84f")
+	assert.Contains(t, payload, "540595.00")
+	assert.Contains(t, payload, "5913Loja Sao Joao")
+	assert.Contains(t, payload, "6015Sao Jose dos Ca")
+	assert.Equal(t, fmt.Sprintf("%04X", crc16(payload[:len(payload)-4])), payload[len(payload)-4:])
+}
+
+func TestPixTxid(t *testing.T) {
+	assert.Equal(t, "8b1c2d3e4f5a4b6c8d7e9f0a1", pixTxid("8b1c2d3e-4f5a-4b6c-8d7e-9f0a1b2c3d4e", 25))
+	assert.Equal(t, "8b1c2d3e4f5a4b6c8d7e9f0a1b2c3d4e", pixTxid("8b1c2d3e-4f5a-4b6c-8d7e-9f0a1b2c3d4e", 35))
+}
+
+func TestParseCents(t *testing.T) {
+	amount, err := parseCents("95.00")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9500), amount)
+
+	amount, err = parseCents("12")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1200), amount)
+
+	_, err = parseCents("1.5")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+// pixExpiration is how long a charge waits to be paid when the
+// authorization does not say.
+const pixExpiration = time.Hour
+
+// pixStatuses are the statuses of the charges of the API Pix.
+var pixStatuses = map[string]domain.PaymentStatus{
+	"ATIVA":                           domain.PaymentStatusPending,
+	"CONCLUIDA":                       domain.PaymentStatusCaptured,
+	"REMOVIDA_PELO_USUARIO_RECEBEDOR": domain.PaymentStatusVoided,
+	"REMOVIDA_PELO_PSP":               domain.PaymentStatusVoided,
+}
+
+type pixCharge struct {
+	Txid     string `json:"txid"`
+	Status   string `json:"status"`
+	Location string `json:"location"`
+	Pix      []struct {
+		EndToEndID string `json:"endToEndId"`
+	} `json:"pix"`
+}
+
+type pixRefund struct {
+	ID     string `json:"id"`
+	RtrID  string `json:"rtrId"`
+	Status string `json:"status"`
+	Motivo string `json:"motivo"`
+}
+
+type pixWebhook struct {
+	Pix []struct {
+		EndToEndID string `json:"endToEndId"`
+		Txid       string `json:"txid"`
+		Valor      string `json:"valor"`
+	} `json:"pix"`
+}
+
+type pixPaymentGateway struct {
+	Client        *http.Client
+	Endpoint      string
+	Token         string
+	Key           string
+	MerchantName  string
+	MerchantCity  string
+	WebhookSecret string
+	now           func() time.Time
+}
+
+// NewPixPaymentGateway creates the charges at the PSP through the API Pix of
+// the Banco Central do Brasil, the customer pays them with the BR Code of
+// the charge. The token is the access token given by the PSP to the store.
+func NewPixPaymentGateway(endpoint string, token string, key string, merchantName string, merchantCity string, webhookSecret string) *pixPaymentGateway {
+	return &pixPaymentGateway{
+		Client:        &http.Client{Timeout: 30 * time.Second},
+		Endpoint:      strings.TrimRight(endpoint, "/"),
+		Token:         token,
+		Key:           key,
+		MerchantName:  merchantName,
+		MerchantCity:  merchantCity,
+		WebhookSecret: webhookSecret,
+		now:           time.Now,
+	}
+}
+
+func (ppg *pixPaymentGateway) Name() string {
+	return "pix"
+}
+
+// Authorize creates the charge, the pix is pending until the customer pays
+// it. The txid of the charge comes from the reference, so a retry finds the
+// same charge.
+func (ppg *pixPaymentGateway) Authorize(ctx context.Context, a *domain.PaymentAuthorization) (*domain.PaymentResult, error) {
+	if a.Method != domain.PaymentMethodPix {
+		return nil, domain.ErrPaymentMethodNotSupported
+	}
+
+	txid := pixTxid(a.Reference, 35)
+
+	if a.Amount.Currency != domain.CurrencyBRL {
+		return &domain.PaymentResult{ProviderReference: txid, Status: domain.PaymentStatusFailed, FailureReason: "pix only takes payments in BRL"}, nil
+	}
+
+	expiration := pixExpiration
+
+	if !a.ExpiresAt.IsZero() {
+		expiration = a.ExpiresAt.Sub(ppg.now())
+	}
+
+	body := map[string]interface{}{
+		"calendario":         map[string]interface{}{"expiracao": int64(expiration.Seconds())},
+		"valor":              map[string]interface{}{"original": formatCents(a.Amount.Amount)},
+		"chave":              ppg.Key,
+		"solicitacaoPagador": a.Description,
+	}
+
+	var c pixCharge
+
+	if err := sendJSON(ctx, ppg.Client, ppg.Name(), http.MethodPut, ppg.Endpoint+"/v2/cob/"+txid, ppg.header(), body, &c); err != nil {
+		return nil, err
+	}
+
+	return &domain.PaymentResult{
+		ProviderReference: txid,
+		Status:            domain.PaymentStatusPending,
+		PixCode:           pixPayload(dynamicPixAccount(c.Location), true, ppg.MerchantName, ppg.MerchantCity, a.Amount.Amount, "***"),
+	}, nil
+}
+
+// Capture checks the charge was paid, a pix is captured by the payment.
+func (ppg *pixPaymentGateway) Capture(ctx context.Context, providerReference string, amount domain.Money) (*domain.PaymentResult, error) {
+	c, err := ppg.charge(ctx, providerReference)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if pixStatuses[c.Status] != domain.PaymentStatusCaptured {
+		return nil, domain.ErrPaymentInvalidState
+	}
+
+	return &domain.PaymentResult{ProviderReference: providerReference, Status: domain.PaymentStatusCaptured}, nil
+}
+
+// Void removes the charge, it can not be paid anymore.
+func (ppg *pixPaymentGateway) Void(ctx context.Context, providerReference string) (*domain.PaymentResult, error) {
+	var c pixCharge
+
+	body := map[string]interface{}{"status": "REMOVIDA_PELO_USUARIO_RECEBEDOR"}
+
+	if err := sendJSON(ctx, ppg.Client, ppg.Name(), http.MethodPatch, ppg.Endpoint+"/v2/cob/"+url.PathEscape(providerReference), ppg.header(), body, &c); err != nil {
+		return nil, err
+	}
+
+	return &domain.PaymentResult{ProviderReference: providerReference, Status: domain.PaymentStatusVoided}, nil
+}
+
+// Refund asks the devolution of the pix that paid the charge, the reference
+// given is the one of the devolution. Its id comes from the reference of the
+// refund, the PSP takes a retry with the same id as the same devolution.
+func (ppg *pixPaymentGateway) Refund(ctx context.Context, providerReference string, reference string, amount domain.Money) (*domain.PaymentResult, error) {
+	c, err := ppg.charge(ctx, providerReference)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(c.Pix) == 0 {
+		return nil, domain.ErrPaymentInvalidState
+	}
+
+	// the ids are up to 35 letters and digits
+	id := "D" + strings.ReplaceAll(reference, "-", "")
+
+	var r pixRefund
+
+	path := "/v2/pix/" + url.PathEscape(c.Pix[0].EndToEndID) + "/devolucao/" + id
+
+	if err := sendJSON(ctx, ppg.Client, ppg.Name(), http.MethodPut, ppg.Endpoint+path, ppg.header(), map[string]interface{}{"valor": formatCents(amount.Amount)}, &r); err != nil {
+		return nil, err
+	}
+
+	if r.Status == "NAO_REALIZADO" {
+		return &domain.PaymentResult{ProviderReference: id, Status: domain.PaymentStatusFailed, FailureReason: r.Motivo}, nil
+	}
+
+	return &domain.PaymentResult{ProviderReference: id, Status: domain.PaymentStatusRefunded}, nil
+}
+
+// VerifyWebhook reads the pix received, the PSP may send many at once. The
+// pix without a txid were not paid through a charge of the store.
+func (ppg *pixPaymentGateway) VerifyWebhook(ctx context.Context, payload []byte, signature string) ([]domain.PaymentEvent, error) {
+	if !validSignature(ppg.WebhookSecret, payload, signature) {
+		return nil, domain.ErrInvalidWebhook
+	}
+
+	var w pixWebhook
+
+	if err := json.Unmarshal(payload, &w); err != nil {
+		return nil, domain.ErrInvalidWebhook
+	}
+
+	events := []domain.PaymentEvent{}
+
+	for _, pix := range w.Pix {
+		if pix.Txid == "" {
+			continue
+		}
+
+		amount, err := parseCents(pix.Valor)
+
+		if err != nil {
+			return nil, domain.ErrInvalidWebhook
+		}
+
+		events = append(events, domain.PaymentEvent{ProviderReference: pix.Txid, Status: domain.PaymentStatusCaptured, Amount: domain.Money{Amount: amount, Currency: domain.CurrencyBRL}})
+	}
+
+	return events, nil
+}
+
+func (ppg *pixPaymentGateway) charge(ctx context.Context, txid string) (*pixCharge, error) {
+	var c pixCharge
+
+	if err := sendJSON(ctx, ppg.Client, ppg.Name(), http.MethodGet, ppg.Endpoint+"/v2/cob/"+url.PathEscape(txid), ppg.header(), nil, &c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+func (ppg *pixPaymentGateway) header() http.Header {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+ppg.Token)
+
+	return header
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPixPaymentGateway(url string) *pixPaymentGateway {
+	gateway := NewPixPaymentGateway(url, "token", "loja@example.com", "Loja", "Recife", "secret")
+	gateway.now = func() time.Time { return time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC) }
+	return gateway
+}
+
+func TestPixAuthorize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/v2/cob/8b1c2d3e4f5a4b6c8d7e9f0a1b2c3d4e", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.JSONEq(t, `{"calendario":{"expiracao":1800},"valor":{"original":"95.00"},"chave":"loja@example.com","solicitacaoPagador":"Pedido order-1"}`, string(body))
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"txid":"8b1c2d3e4f5a4b6c8d7e9f0a1b2c3d4e","status":"ATIVA","location":"pix.example.com/qr/v2/9d36b84f"}`))
+	}))
+	defer server.Close()
+
+	res, err := newTestPixPaymentGateway(server.URL).Authorize(context.Background(), &domain.PaymentAuthorization{
+		Reference:   "8b1c2d3e-4f5a-4b6c-8d7e-9f0a1b2c3d4e",
+		Method:      domain.PaymentMethodPix,
+		Amount:      brl(9500),
+		Description: "Pedido order-1",
+		ExpiresAt:   time.Date(2026, 10, 19, 12, 30, 0, 0, time.UTC),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "8b1c2d3e4f5a4b6c8d7e9f0a1b2c3d4e", res.ProviderReference)
+	assert.Equal(t, domain.PaymentStatusPending, res.Status)
+	assert.True(t, strings.HasPrefix(res.PixCode, "000201010212"))
+	assert.Contains(t, res.PixCode, "2530pix.example.com/qr/v2/9d36b84f")
+	assert.Contains(t, res.PixCode, "0503***")
+}
+
+func TestPixAuthorizeOtherCurrency(t *testing.T) {
+	res, err := newTestPixPaymentGateway("").Authorize(context.Background(), &domain.PaymentAuthorization{Reference: "pay-1", Method: domain.PaymentMethodPix, Amount: domain.Money{Amount: 100, Currency: "USD"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusFailed, res.Status)
+}
+
+func TestPixCaptureNotPaid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v2/cob/txid1", r.URL.Path)
+
+		w.Write([]byte(`{"txid":"txid1","status":"ATIVA"}`))
+	}))
+	defer server.Close()
+
+	_, err := newTestPixPaymentGateway(server.URL).Capture(context.Background(), "txid1", brl(9500))
+
+	assert.ErrorIs(t, err, domain.ErrPaymentInvalidState)
+}
+
+func TestPixVoid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/v2/cob/txid1", r.URL.Path)
+		assert.JSONEq(t, `{"status":"REMOVIDA_PELO_USUARIO_RECEBEDOR"}`, string(body))
+
+		w.Write([]byte(`{"txid":"txid1","status":"REMOVIDA_PELO_USUARIO_RECEBEDOR"}`))
+	}))
+	defer server.Close()
+
+	res, err := newTestPixPaymentGateway(server.URL).Void(context.Background(), "txid1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusVoided, res.Status)
+}
+
+func TestPixRefund(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"txid":"txid1","status":"CONCLUIDA","pix":[{"endToEndId":"E123"}]}`))
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/v2/pix/E123/devolucao/D6f1c2a7e9b3d4c5e8f7a6b5c4d3e2f10", r.URL.Path)
+		assert.JSONEq(t, `{"valor":"40.00"}`, string(body))
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"D6f1c2a7e9b3d4c5e8f7a6b5c4d3e2f10","rtrId":"D123","status":"EM_PROCESSAMENTO"}`))
+	}))
+	defer server.Close()
+
+	res, err := newTestPixPaymentGateway(server.URL).Refund(context.Background(), "txid1", "6f1c2a7e-9b3d-4c5e-8f7a-6b5c4d3e2f10", brl(4000))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.PaymentResult{ProviderReference: "D6f1c2a7e9b3d4c5e8f7a6b5c4d3e2f10", Status: domain.PaymentStatusRefunded}, res)
+}
+
+func TestPixVerifyWebhook(t *testing.T) {
+	payload := []byte(`{"pix":[{"endToEndId":"E123","txid":"txid1","valor":"95.00","horario":"2026-10-19T12:10:00Z"},{"endToEndId":"E124","valor":"10.00"}]}`)
+
+	events, err := newTestPixPaymentGateway("").VerifyWebhook(context.Background(), payload, sign("secret", payload))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.PaymentEvent{{ProviderReference: "txid1", Status: domain.PaymentStatusCaptured, Amount: brl(9500)}}, events)
+
+	_, err = newTestPixPaymentGateway("").VerifyWebhook(context.Background(), payload, "")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidWebhook)
+}
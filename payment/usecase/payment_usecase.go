@@ -0,0 +1,555 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/google/uuid"
+)
+
+// systemActor is who the history of the orders shows for the changes made
+// by the payments.
+const systemActor = "system"
+
+// boletoTerm is how long a boleto takes to be due, the order waits for it
+// boletoClearing longer, the time the banks take to confirm a boleto paid on
+// its last day.
+const (
+	boletoTerm     = 3 * 24 * time.Hour
+	boletoClearing = 3 * 24 * time.Hour
+)
+
+// expireBatch is how many orders an expiration takes at a time.
+const expireBatch = 100
+
+type paymentUseCase struct {
+	paymentRepo  domain.PaymentRepository
+	refundRepo   domain.RefundRepository
+	orderRepo    domain.OrderRepository
+	userRepo     domain.UserRepository
+	orderUseCase domain.OrderUseCase
+	gateways     map[domain.PaymentMethod]domain.PaymentGateway
+}
+
+// NewPaymentUseCase pays each method through its gateway, a gateway may
+// take many methods.
+func NewPaymentUseCase(pr domain.PaymentRepository, rr domain.RefundRepository, or domain.OrderRepository, ur domain.UserRepository, ouc domain.OrderUseCase, gateways map[domain.PaymentMethod]domain.PaymentGateway) domain.PaymentUseCase {
+	return &paymentUseCase{paymentRepo: pr, refundRepo: rr, orderRepo: or, userRepo: ur, orderUseCase: ouc, gateways: gateways}
+}
+
+// Pay charges the cards at once, the stock of the order is already reserved.
+// A pix waits to be paid until the order expires. A boleto waits until it is
+// due, the order and the reservations of its stock wait with it. Asking to
+// pay again with the same method gives back the same pix or boleto while it
+// waits.
+func (pu *paymentUseCase) Pay(ctx context.Context, login string, orderUUID string, r *domain.PaymentRequest) (*domain.PaymentIntent, error) {
+	order, err := pu.mine(ctx, login, orderUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if order.Status != domain.OrderStatusPendingPayment || !now.Before(order.ExpiresAt) {
+		return nil, domain.ErrOrderNotPayable
+	}
+
+	gateway, ok := pu.gateways[r.Method]
+
+	if !ok {
+		return nil, domain.ErrPaymentMethodNotSupported
+	}
+
+	payments, err := pu.paymentRepo.ListByOrder(ctx, order.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range payments {
+		p := &payments[i]
+
+		if p.Status == domain.PaymentStatusCaptured {
+			return nil, domain.ErrOrderNotPayable
+		}
+
+		if p.Method == r.Method && p.Status == domain.PaymentStatusPending && (p.PixCode != "" || p.BoletoLine != "") && p.ExpiresAt != nil && now.Before(*p.ExpiresAt) {
+			return p, nil
+		}
+	}
+
+	var expiresAt time.Time
+
+	switch r.Method {
+	case domain.PaymentMethodPix:
+		expiresAt = order.ExpiresAt
+	case domain.PaymentMethodBoleto:
+		expiresAt = now.Add(boletoTerm)
+
+		if until := expiresAt.Add(boletoClearing); until.After(order.ExpiresAt) {
+			err := pu.orderRepo.Extend(ctx, order, until)
+
+			if errors.Is(err, domain.ErrOrderChanged) {
+				return nil, domain.ErrOrderNotPayable
+			}
+
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	p := &domain.PaymentIntent{
+		UUID:      uuid.NewString(),
+		OrderID:   order.ID,
+		OrderUUID: order.UUID,
+		Method:    r.Method,
+		Provider:  gateway.Name(),
+		Status:    domain.PaymentStatusPending,
+		Amount:    order.Total,
+	}
+
+	if !expiresAt.IsZero() {
+		p.ExpiresAt = &expiresAt
+	}
+
+	if err := pu.paymentRepo.Create(ctx, p); err != nil {
+		return nil, err
+	}
+
+	res, err := gateway.Authorize(ctx, &domain.PaymentAuthorization{
+		Reference:   p.UUID,
+		Method:      p.Method,
+		Amount:      p.Amount,
+		CardToken:   r.CardToken,
+		Description: "Pedido " + order.UUID,
+		ExpiresAt:   expiresAt,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pu.apply(ctx, p, res); err != nil {
+		return nil, err
+	}
+
+	if p.Status == domain.PaymentStatusAuthorized {
+		if err := pu.capture(ctx, gateway, p); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Status == domain.PaymentStatusFailed {
+		return nil, fmt.Errorf("%w: %s", domain.ErrPaymentDeclined, p.FailureReason)
+	}
+
+	if p.Status == domain.PaymentStatusCaptured {
+		pu.settle(ctx, p)
+	}
+
+	return p, nil
+}
+
+func (pu *paymentUseCase) ListMine(ctx context.Context, login string, orderUUID string) ([]domain.PaymentIntent, error) {
+	order, err := pu.mine(ctx, login, orderUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pu.paymentRepo.ListByOrder(ctx, order.ID)
+}
+
+func (pu *paymentUseCase) List(ctx context.Context, orderUUID string) ([]domain.PaymentIntent, error) {
+	order, err := pu.orderRepo.GetByUUID(ctx, orderUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil {
+		return nil, domain.ErrOrderNotFound
+	}
+
+	return pu.paymentRepo.ListByOrder(ctx, order.ID)
+}
+
+// Capture collects a payment left authorized, when the capture failed after
+// the authorization.
+func (pu *paymentUseCase) Capture(ctx context.Context, uuid string) (*domain.PaymentIntent, error) {
+	p, gateway, err := pu.payment(ctx, uuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Status != domain.PaymentStatusAuthorized {
+		return nil, domain.ErrPaymentInvalidState
+	}
+
+	if err := pu.capture(ctx, gateway, p); err != nil {
+		return nil, err
+	}
+
+	pu.settle(ctx, p)
+
+	return p, nil
+}
+
+func (pu *paymentUseCase) Void(ctx context.Context, uuid string) (*domain.PaymentIntent, error) {
+	p, gateway, err := pu.payment(ctx, uuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Status != domain.PaymentStatusPending && p.Status != domain.PaymentStatusAuthorized {
+		return nil, domain.ErrPaymentInvalidState
+	}
+
+	res, err := gateway.Void(ctx, p.ProviderReference)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pu.apply(ctx, p, res); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Webhook follows the changes of status told by the provider. The gateways
+// send the same notification again until it is taken, so the ones already
+// followed, the ones of unknown payments and the captures of another amount
+// are taken without changes.
+func (pu *paymentUseCase) Webhook(ctx context.Context, provider string, payload []byte, signature string) error {
+	gateway := pu.gateway(provider)
+
+	if gateway == nil {
+		return domain.ErrPaymentProviderNotFound
+	}
+
+	events, err := gateway.VerifyWebhook(ctx, payload, signature)
+
+	if err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		p, err := pu.paymentRepo.GetByProviderReference(ctx, provider, e.ProviderReference)
+
+		if err != nil {
+			return err
+		}
+
+		if p == nil {
+			log.Printf("Error trying to follow the webhook of %s: payment %s not found", provider, e.ProviderReference)
+			continue
+		}
+
+		if p.Status == e.Status {
+			continue
+		}
+
+		if !allowed(p.Status, e.Status) {
+			log.Printf("Error trying to follow the webhook of %s: payment %s can not go from %s to %s", provider, p.UUID, p.Status, e.Status)
+			continue
+		}
+
+		// a capture of another amount than the one asked for does not pay
+		// the order, it is left to be looked into
+		if e.Status == domain.PaymentStatusCaptured && e.Amount != p.Amount {
+			log.Printf("Error trying to follow the webhook of %s: payment %s of %d %s captured with %d %s", provider, p.UUID, p.Amount.Amount, p.Amount.Currency, e.Amount.Amount, e.Amount.Currency)
+			continue
+		}
+
+		from := p.Status
+		p.Status = e.Status
+
+		if err := pu.paymentRepo.Update(ctx, p, from); err != nil {
+			return err
+		}
+
+		if p.Status == domain.PaymentStatusCaptured {
+			pu.settle(ctx, p)
+		}
+	}
+
+	return nil
+}
+
+// Reconcile brings the payments and the orders in step: the order of a
+// captured payment is paid, the open payments of orders no longer waiting
+// for them are voided, and the payments captured for cancelled orders or
+// orders already paid are refunded. A payment that fails is tried again on
+// the next call.
+func (pu *paymentUseCase) Reconcile(ctx context.Context) (int, error) {
+	payments, err := pu.paymentRepo.ListToReconcile(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	reconciled := 0
+
+	for i := range payments {
+		if err := pu.reconcile(ctx, &payments[i]); err != nil {
+			log.Printf("Error trying to reconcile the payment %s: %s", payments[i].UUID, err.Error())
+			continue
+		}
+
+		reconciled++
+	}
+
+	return reconciled, nil
+}
+
+func (pu *paymentUseCase) reconcile(ctx context.Context, p *domain.PaymentIntent) error {
+	order, err := pu.orderRepo.GetByUUID(ctx, p.OrderUUID)
+
+	if err != nil {
+		return err
+	}
+
+	if order == nil {
+		return domain.ErrOrderNotFound
+	}
+
+	gateway := pu.gateway(p.Provider)
+
+	if gateway == nil {
+		return domain.ErrPaymentProviderNotFound
+	}
+
+	switch {
+	case p.Status == domain.PaymentStatusPending || p.Status == domain.PaymentStatusAuthorized:
+		res, err := gateway.Void(ctx, p.ProviderReference)
+
+		if err != nil {
+			return err
+		}
+
+		return pu.apply(ctx, p, res)
+	case order.Status == domain.OrderStatusPendingPayment:
+		_, err = pu.orderUseCase.Transition(ctx, order.UUID, systemActor, &domain.OrderTransitionRequest{Status: domain.OrderStatusPaid, Note: "pagamento " + p.UUID})
+		return err
+	}
+
+	return pu.refund(ctx, gateway, order, p)
+}
+
+// refund gives back what is left of a payment captured for a cancelled order
+// or for an order already paid by another one.
+func (pu *paymentUseCase) refund(ctx context.Context, gateway domain.PaymentGateway, order *domain.Order, p *domain.PaymentIntent) error {
+	left := p.Amount.Amount - p.Refunded.Amount
+
+	// the rest is held by refunds still pending
+	if left <= 0 {
+		return domain.ErrRefundExceeds
+	}
+
+	reason := "pagamento em duplicidade"
+
+	if order.Status == domain.OrderStatusCancelled {
+		reason = "pagamento de pedido cancelado"
+	}
+
+	r := &domain.Refund{
+		UUID:    uuid.NewString(),
+		OrderID: p.OrderID,
+		Amount:  domain.Money{Amount: left, Currency: p.Amount.Currency},
+		Lines:   []domain.RefundLine{},
+		Reason:  reason,
+		Actor:   systemActor,
+	}
+
+	if err := refundPayment(ctx, pu.refundRepo, gateway, p, r); err != nil {
+		return err
+	}
+
+	if err := pu.refundRepo.Finish(ctx, r); err != nil {
+		return err
+	}
+
+	if r.Status == domain.RefundStatusFailed {
+		return fmt.Errorf("%w: %s", domain.ErrRefundFailed, r.FailureReason)
+	}
+
+	return nil
+}
+
+// Expire cancels the orders not paid by their deadline, which gives their
+// stock back. Their pix and boletos expire first, an order whose pix was paid
+// meanwhile is left for the webhook. An order that fails is tried again on
+// the next call.
+func (pu *paymentUseCase) Expire(ctx context.Context) (int, error) {
+	orders, err := pu.orderRepo.ListExpired(ctx, time.Now(), expireBatch)
+
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+
+	for i := range orders {
+		if err := pu.expire(ctx, &orders[i]); err != nil {
+			log.Printf("Error trying to expire the order %s: %s", orders[i].UUID, err.Error())
+			continue
+		}
+
+		expired++
+	}
+
+	return expired, nil
+}
+
+func (pu *paymentUseCase) expire(ctx context.Context, order *domain.Order) error {
+	payments, err := pu.paymentRepo.ListByOrder(ctx, order.ID)
+
+	if err != nil {
+		return err
+	}
+
+	for i := range payments {
+		p := &payments[i]
+
+		if p.Status != domain.PaymentStatusPending && p.Status != domain.PaymentStatusAuthorized {
+			continue
+		}
+
+		gateway := pu.gateway(p.Provider)
+
+		if gateway == nil {
+			return domain.ErrPaymentProviderNotFound
+		}
+
+		res, err := gateway.Void(ctx, p.ProviderReference)
+
+		if err != nil {
+			return err
+		}
+
+		if res.Status == domain.PaymentStatusVoided && p.Status == domain.PaymentStatusPending {
+			res.Status = domain.PaymentStatusExpired
+		}
+
+		if err := pu.apply(ctx, p, res); err != nil {
+			return err
+		}
+	}
+
+	_, err = pu.orderUseCase.Transition(ctx, order.UUID, systemActor, &domain.OrderTransitionRequest{Status: domain.OrderStatusCancelled, Note: "prazo de pagamento expirado"})
+
+	return err
+}
+
+// apply saves the result of the gateway in the intent.
+func (pu *paymentUseCase) apply(ctx context.Context, p *domain.PaymentIntent, res *domain.PaymentResult) error {
+	from := p.Status
+
+	if res.ProviderReference != "" {
+		p.ProviderReference = res.ProviderReference
+	}
+
+	if res.PixCode != "" {
+		p.PixCode = res.PixCode
+	}
+
+	if res.BoletoLine != "" {
+		p.BoletoLine, p.BoletoBarcode = res.BoletoLine, res.BoletoBarcode
+	}
+
+	p.Status = res.Status
+	p.FailureReason = res.FailureReason
+
+	return pu.paymentRepo.Update(ctx, p, from)
+}
+
+func (pu *paymentUseCase) capture(ctx context.Context, gateway domain.PaymentGateway, p *domain.PaymentIntent) error {
+	res, err := gateway.Capture(ctx, p.ProviderReference, p.Amount)
+
+	if err != nil {
+		return err
+	}
+
+	return pu.apply(ctx, p, res)
+}
+
+// settle pays the order of a captured payment. When it fails the
+// reconciliation tries again, or refunds the payment when the order was
+// cancelled or paid meanwhile.
+func (pu *paymentUseCase) settle(ctx context.Context, p *domain.PaymentIntent) {
+	_, err := pu.orderUseCase.Transition(ctx, p.OrderUUID, systemActor, &domain.OrderTransitionRequest{Status: domain.OrderStatusPaid, Note: "pagamento " + p.UUID})
+
+	if err != nil {
+		log.Printf("Error trying to pay the order %s with the payment %s: %s", p.OrderUUID, p.UUID, err.Error())
+	}
+}
+
+func (pu *paymentUseCase) payment(ctx context.Context, uuid string) (*domain.PaymentIntent, domain.PaymentGateway, error) {
+	p, err := pu.paymentRepo.GetByUUID(ctx, uuid)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if p == nil {
+		return nil, nil, domain.ErrPaymentNotFound
+	}
+
+	gateway := pu.gateway(p.Provider)
+
+	if gateway == nil {
+		return nil, nil, domain.ErrPaymentProviderNotFound
+	}
+
+	return p, gateway, nil
+}
+
+func (pu *paymentUseCase) gateway(provider string) domain.PaymentGateway {
+	return paymentGateway(pu.gateways, provider)
+}
+
+// mine gives the order of the user, the orders of other users are not
+// found.
+func (pu *paymentUseCase) mine(ctx context.Context, login string, orderUUID string) (*domain.Order, error) {
+	user, err := pu.userRepo.GetByEmail(ctx, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	order, err := pu.orderRepo.GetByUUID(ctx, orderUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil || order.UserID != user.ID {
+		return nil, domain.ErrOrderNotFound
+	}
+
+	return order, nil
+}
+
+func allowed(from domain.PaymentStatus, to domain.PaymentStatus) bool {
+	for _, s := range domain.PaymentTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+
+	return false
+}
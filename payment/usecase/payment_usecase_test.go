@@ -0,0 +1,447 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func brl(amount int64) domain.Money {
+	return domain.Money{Amount: amount, Currency: domain.CurrencyBRL}
+}
+
+func userRepoWithAna() *mocks.MockUserRepository {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockUserRepo.On("GetByEmail", mock.Anything, "ana@test.com").Return(7, "u7", "ana@test.com", "Ana", "Lima", "", "Recife", "PE", "Boa Viagem", "Rua A", "10", "51020000", nil)
+	return mockUserRepo
+}
+
+func orderRepoWith(o *domain.Order) *mocks.MockOrderRepository {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockOrderRepo.On("GetByUUID", mock.Anything, o.UUID).Return(o, nil)
+	return mockOrderRepo
+}
+
+func pendingOrder() *domain.Order {
+	return &domain.Order{ID: 3, UUID: "order-1", UserID: 7, Status: domain.OrderStatusPendingPayment, Total: brl(9500), ExpiresAt: time.Now().Add(time.Hour)}
+}
+
+func fakeGateway() *mocks.MockPaymentGateway {
+	mockGateway := new(mocks.MockPaymentGateway)
+	mockGateway.On("Name").Return("fake")
+	return mockGateway
+}
+
+func paid(orderUUID string) *mocks.MockOrderUseCase {
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+	mockOrderUseCase.On("Transition", mock.Anything, orderUUID, "system", mock.MatchedBy(func(t *domain.OrderTransitionRequest) bool {
+		return t.Status == domain.OrderStatusPaid
+	})).Return(&domain.Order{UUID: orderUUID, Status: domain.OrderStatusPaid}, nil)
+	return mockOrderUseCase
+}
+
+func TestPayCard(t *testing.T) {
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+	mockGateway := fakeGateway()
+	mockOrderUseCase := paid("order-1")
+
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{{Status: domain.PaymentStatusFailed}}, nil)
+	mockPaymentRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *domain.PaymentIntent) bool {
+		return p.OrderID == 3 && p.Provider == "fake" && p.Status == domain.PaymentStatusPending && p.Amount == brl(9500)
+	})).Return(nil)
+	mockGateway.On("Authorize", mock.Anything, mock.MatchedBy(func(a *domain.PaymentAuthorization) bool {
+		return a.Reference != "" && a.CardToken == "tok_visa" && a.Amount == brl(9500) && a.Description == "Pedido order-1"
+	})).Return(&domain.PaymentResult{ProviderReference: "ch_1", Status: domain.PaymentStatusAuthorized}, nil)
+	mockPaymentRepo.On("Update", mock.Anything, mock.Anything, domain.PaymentStatusPending).Return(nil)
+	mockGateway.On("Capture", mock.Anything, "ch_1", brl(9500)).Return(&domain.PaymentResult{ProviderReference: "ch_1", Status: domain.PaymentStatusCaptured}, nil)
+	mockPaymentRepo.On("Update", mock.Anything, mock.Anything, domain.PaymentStatusAuthorized).Return(nil)
+
+	gateways := map[domain.PaymentMethod]domain.PaymentGateway{domain.PaymentMethodCard: mockGateway}
+
+	p, err := NewPaymentUseCase(mockPaymentRepo, nil, orderRepoWith(pendingOrder()), userRepoWithAna(), mockOrderUseCase, gateways).Pay(context.Background(), "ana@test.com", "order-1", &domain.PaymentRequest{Method: domain.PaymentMethodCard, CardToken: "tok_visa"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusCaptured, p.Status)
+	assert.Equal(t, "ch_1", p.ProviderReference)
+	assert.Equal(t, "order-1", p.OrderUUID)
+	mockOrderUseCase.AssertExpectations(t)
+}
+
+func TestPayCardDeclined(t *testing.T) {
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+	mockGateway := fakeGateway()
+
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{}, nil)
+	mockPaymentRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockGateway.On("Authorize", mock.Anything, mock.Anything).Return(&domain.PaymentResult{ProviderReference: "ch_1", Status: domain.PaymentStatusFailed, FailureReason: "card declined"}, nil)
+	mockPaymentRepo.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.PaymentIntent) bool {
+		return p.Status == domain.PaymentStatusFailed && p.FailureReason == "card declined"
+	}), domain.PaymentStatusPending).Return(nil)
+
+	gateways := map[domain.PaymentMethod]domain.PaymentGateway{domain.PaymentMethodCard: mockGateway}
+
+	_, err := NewPaymentUseCase(mockPaymentRepo, nil, orderRepoWith(pendingOrder()), userRepoWithAna(), nil, gateways).Pay(context.Background(), "ana@test.com", "order-1", &domain.PaymentRequest{Method: domain.PaymentMethodCard, CardToken: "tok_declined"})
+
+	assert.ErrorIs(t, err, domain.ErrPaymentDeclined)
+	assert.Contains(t, err.Error(), "card declined")
+	mockPaymentRepo.AssertExpectations(t)
+}
+
+func TestPayPix(t *testing.T) {
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+	mockGateway := fakeGateway()
+
+	order := pendingOrder()
+
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{}, nil)
+	mockPaymentRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockGateway.On("Authorize", mock.Anything, mock.MatchedBy(func(a *domain.PaymentAuthorization) bool {
+		return a.ExpiresAt.Equal(order.ExpiresAt)
+	})).Return(&domain.PaymentResult{ProviderReference: "txid1", Status: domain.PaymentStatusPending, PixCode: "000201"}, nil)
+	mockPaymentRepo.On("Update", mock.Anything, mock.Anything, domain.PaymentStatusPending).Return(nil)
+
+	gateways := map[domain.PaymentMethod]domain.PaymentGateway{domain.PaymentMethodPix: mockGateway}
+
+	p, err := NewPaymentUseCase(mockPaymentRepo, nil, orderRepoWith(order), userRepoWithAna(), nil, gateways).Pay(context.Background(), "ana@test.com", "order-1", &domain.PaymentRequest{Method: domain.PaymentMethodPix})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusPending, p.Status)
+	assert.Equal(t, "000201", p.PixCode)
+	assert.Equal(t, order.ExpiresAt, *p.ExpiresAt)
+	mockGateway.AssertNotCalled(t, "Capture", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPayBoleto(t *testing.T) {
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+	mockGateway := fakeGateway()
+
+	order := pendingOrder()
+	mockOrderRepo := orderRepoWith(order)
+
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{}, nil)
+	mockOrderRepo.On("Extend", mock.Anything, order, mock.MatchedBy(func(until time.Time) bool {
+		return until.After(time.Now().Add(boletoTerm + boletoClearing - time.Minute))
+	})).Return(nil)
+	mockPaymentRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockGateway.On("Authorize", mock.Anything, mock.MatchedBy(func(a *domain.PaymentAuthorization) bool {
+		return a.Method == domain.PaymentMethodBoleto && a.ExpiresAt.After(time.Now().Add(boletoTerm-time.Minute))
+	})).Return(&domain.PaymentResult{ProviderReference: "fake_1", Status: domain.PaymentStatusPending, BoletoLine: "99990.00000 00000.000000 00000.000000 1 16070000009500", BoletoBarcode: "99991160700000095000000000000000000000000000"}, nil)
+	mockPaymentRepo.On("Update", mock.Anything, mock.Anything, domain.PaymentStatusPending).Return(nil)
+
+	gateways := map[domain.PaymentMethod]domain.PaymentGateway{domain.PaymentMethodBoleto: mockGateway}
+
+	p, err := NewPaymentUseCase(mockPaymentRepo, nil, mockOrderRepo, userRepoWithAna(), nil, gateways).Pay(context.Background(), "ana@test.com", "order-1", &domain.PaymentRequest{Method: domain.PaymentMethodBoleto})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusPending, p.Status)
+	assert.Equal(t, "99991160700000095000000000000000000000000000", p.BoletoBarcode)
+	assert.NotEmpty(t, p.BoletoLine)
+	assert.NotNil(t, p.ExpiresAt)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func TestPayBoletoOrderChanged(t *testing.T) {
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+
+	order := pendingOrder()
+	mockOrderRepo := orderRepoWith(order)
+
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{}, nil)
+	mockOrderRepo.On("Extend", mock.Anything, order, mock.Anything).Return(domain.ErrOrderChanged)
+
+	gateways := map[domain.PaymentMethod]domain.PaymentGateway{domain.PaymentMethodBoleto: fakeGateway()}
+
+	_, err := NewPaymentUseCase(mockPaymentRepo, nil, mockOrderRepo, userRepoWithAna(), nil, gateways).Pay(context.Background(), "ana@test.com", "order-1", &domain.PaymentRequest{Method: domain.PaymentMethodBoleto})
+
+	assert.ErrorIs(t, err, domain.ErrOrderNotPayable)
+	mockPaymentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestPayOrderExpired(t *testing.T) {
+	order := pendingOrder()
+	order.ExpiresAt = time.Now().Add(-time.Minute)
+
+	_, err := NewPaymentUseCase(nil, nil, orderRepoWith(order), userRepoWithAna(), nil, nil).Pay(context.Background(), "ana@test.com", "order-1", &domain.PaymentRequest{Method: domain.PaymentMethodPix})
+
+	assert.ErrorIs(t, err, domain.ErrOrderNotPayable)
+}
+
+func TestPayPixWaiting(t *testing.T) {
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+	mockGateway := fakeGateway()
+
+	expiresAt := time.Now().Add(time.Hour)
+	waiting := domain.PaymentIntent{UUID: "pay-1", Method: domain.PaymentMethodPix, Status: domain.PaymentStatusPending, PixCode: "000201", ExpiresAt: &expiresAt}
+
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{waiting}, nil)
+
+	gateways := map[domain.PaymentMethod]domain.PaymentGateway{domain.PaymentMethodPix: mockGateway}
+
+	p, err := NewPaymentUseCase(mockPaymentRepo, nil, orderRepoWith(pendingOrder()), userRepoWithAna(), nil, gateways).Pay(context.Background(), "ana@test.com", "order-1", &domain.PaymentRequest{Method: domain.PaymentMethodPix})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "pay-1", p.UUID)
+	mockPaymentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestPayOrderNotPayable(t *testing.T) {
+	order := pendingOrder()
+	order.Status = domain.OrderStatusPaid
+
+	_, err := NewPaymentUseCase(nil, nil, orderRepoWith(order), userRepoWithAna(), nil, nil).Pay(context.Background(), "ana@test.com", "order-1", &domain.PaymentRequest{Method: domain.PaymentMethodPix})
+
+	assert.ErrorIs(t, err, domain.ErrOrderNotPayable)
+}
+
+func TestPayOrderCaptured(t *testing.T) {
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{{Status: domain.PaymentStatusCaptured}}, nil)
+
+	gateways := map[domain.PaymentMethod]domain.PaymentGateway{domain.PaymentMethodCard: fakeGateway()}
+
+	_, err := NewPaymentUseCase(mockPaymentRepo, nil, orderRepoWith(pendingOrder()), userRepoWithAna(), nil, gateways).Pay(context.Background(), "ana@test.com", "order-1", &domain.PaymentRequest{Method: domain.PaymentMethodCard, CardToken: "tok_visa"})
+
+	assert.ErrorIs(t, err, domain.ErrOrderNotPayable)
+}
+
+func TestPayOrderOfAnotherUser(t *testing.T) {
+	order := pendingOrder()
+	order.UserID = 8
+
+	_, err := NewPaymentUseCase(nil, nil, orderRepoWith(order), userRepoWithAna(), nil, nil).Pay(context.Background(), "ana@test.com", "order-1", &domain.PaymentRequest{Method: domain.PaymentMethodPix})
+
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound)
+}
+
+func TestPayMethodNotSupported(t *testing.T) {
+	gateways := map[domain.PaymentMethod]domain.PaymentGateway{domain.PaymentMethodCard: fakeGateway()}
+
+	_, err := NewPaymentUseCase(nil, nil, orderRepoWith(pendingOrder()), userRepoWithAna(), nil, gateways).Pay(context.Background(), "ana@test.com", "order-1", &domain.PaymentRequest{Method: domain.PaymentMethodPix})
+
+	assert.ErrorIs(t, err, domain.ErrPaymentMethodNotSupported)
+}
+
+func TestCapturePayment(t *testing.T) {
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+	mockGateway := fakeGateway()
+	mockOrderUseCase := paid("order-1")
+
+	mockPaymentRepo.On("GetByUUID", mock.Anything, "pay-1").Return(&domain.PaymentIntent{UUID: "pay-1", OrderUUID: "order-1", Provider: "fake", ProviderReference: "ch_1", Status: domain.PaymentStatusAuthorized, Amount: brl(9500)}, nil)
+	mockGateway.On("Capture", mock.Anything, "ch_1", brl(9500)).Return(&domain.PaymentResult{Status: domain.PaymentStatusCaptured}, nil)
+	mockPaymentRepo.On("Update", mock.Anything, mock.Anything, domain.PaymentStatusAuthorized).Return(nil)
+
+	gateways := map[domain.PaymentMethod]domain.PaymentGateway{domain.PaymentMethodCard: mockGateway}
+
+	p, err := NewPaymentUseCase(mockPaymentRepo, nil, nil, nil, mockOrderUseCase, gateways).Capture(context.Background(), "pay-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PaymentStatusCaptured, p.Status)
+	mockOrderUseCase.AssertExpectations(t)
+}
+
+func TestVoidPaymentCaptured(t *testing.T) {
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+
+	mockPaymentRepo.On("GetByUUID", mock.Anything, "pay-1").Return(&domain.PaymentIntent{UUID: "pay-1", Provider: "fake", Status: domain.PaymentStatusCaptured}, nil)
+
+	gateways := map[domain.PaymentMethod]domain.PaymentGateway{domain.PaymentMethodCard: fakeGateway()}
+
+	_, err := NewPaymentUseCase(mockPaymentRepo, nil, nil, nil, nil, gateways).Void(context.Background(), "pay-1")
+
+	assert.ErrorIs(t, err, domain.ErrPaymentInvalidState)
+}
+
+func TestVoidPaymentNotFound(t *testing.T) {
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+
+	mockPaymentRepo.On("GetByUUID", mock.Anything, "pay-1").Return(nil, nil)
+
+	_, err := NewPaymentUseCase(mockPaymentRepo, nil, nil, nil, nil, nil).Void(context.Background(), "pay-1")
+
+	assert.ErrorIs(t, err, domain.ErrPaymentNotFound)
+}
+
+func TestWebhookCapturesPix(t *testing.T) {
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+	mockGateway := fakeGateway()
+	mockOrderUseCase := paid("order-1")
+
+	payload := []byte(`{}`)
+
+	mockGateway.On("VerifyWebhook", mock.Anything, payload, "sig").Return([]domain.PaymentEvent{{ProviderReference: "txid1", Status: domain.PaymentStatusCaptured, Amount: brl(9500)}}, nil)
+	mockPaymentRepo.On("GetByProviderReference", mock.Anything, "fake", "txid1").Return(&domain.PaymentIntent{UUID: "pay-1", OrderUUID: "order-1", Provider: "fake", Amount: brl(9500), Status: domain.PaymentStatusPending}, nil)
+	mockPaymentRepo.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.PaymentIntent) bool {
+		return p.Status == domain.PaymentStatusCaptured
+	}), domain.PaymentStatusPending).Return(nil)
+
+	gateways := map[domain.PaymentMethod]domain.PaymentGateway{domain.PaymentMethodPix: mockGateway}
+
+	err := NewPaymentUseCase(mockPaymentRepo, nil, nil, nil, mockOrderUseCase, gateways).Webhook(context.Background(), "fake", payload, "sig")
+
+	assert.NoError(t, err)
+	mockPaymentRepo.AssertExpectations(t)
+	mockOrderUseCase.AssertExpectations(t)
+}
+
+func TestWebhookAlreadyFollowed(t *testing.T) {
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+	mockGateway := fakeGateway()
+
+	mockGateway.On("VerifyWebhook", mock.Anything, mock.Anything, "sig").Return([]domain.PaymentEvent{
+		{ProviderReference: "txid1", Status: domain.PaymentStatusCaptured},
+		{ProviderReference: "txid2", Status: domain.PaymentStatusCaptured},
+	}, nil)
+	mockPaymentRepo.On("GetByProviderReference", mock.Anything, "fake", "txid1").Return(&domain.PaymentIntent{UUID: "pay-1", Status: domain.PaymentStatusCaptured}, nil)
+	mockPaymentRepo.On("GetByProviderReference", mock.Anything, "fake", "txid2").Return(nil, nil)
+
+	gateways := map[domain.PaymentMethod]domain.PaymentGateway{domain.PaymentMethodPix: mockGateway}
+
+	err := NewPaymentUseCase(mockPaymentRepo, nil, nil, nil, nil, gateways).Webhook(context.Background(), "fake", []byte(`{}`), "sig")
+
+	assert.NoError(t, err)
+	mockPaymentRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWebhookCapturedAnotherAmount(t *testing.T) {
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+	mockGateway := fakeGateway()
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+
+	mockGateway.On("VerifyWebhook", mock.Anything, mock.Anything, "sig").Return([]domain.PaymentEvent{
+		{ProviderReference: "txid1", Status: domain.PaymentStatusCaptured, Amount: brl(100)},
+		{ProviderReference: "txid2", Status: domain.PaymentStatusCaptured, Amount: domain.Money{Amount: 9500, Currency: "USD"}},
+	}, nil)
+	mockPaymentRepo.On("GetByProviderReference", mock.Anything, "fake", "txid1").Return(&domain.PaymentIntent{UUID: "pay-1", OrderUUID: "order-1", Amount: brl(9500), Status: domain.PaymentStatusPending}, nil)
+	mockPaymentRepo.On("GetByProviderReference", mock.Anything, "fake", "txid2").Return(&domain.PaymentIntent{UUID: "pay-2", OrderUUID: "order-2", Amount: brl(9500), Status: domain.PaymentStatusPending}, nil)
+
+	gateways := map[domain.PaymentMethod]domain.PaymentGateway{domain.PaymentMethodPix: mockGateway}
+
+	err := NewPaymentUseCase(mockPaymentRepo, nil, nil, nil, mockOrderUseCase, gateways).Webhook(context.Background(), "fake", []byte(`{}`), "sig")
+
+	assert.NoError(t, err)
+	mockPaymentRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	mockOrderUseCase.AssertNotCalled(t, "Transition", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWebhookInvalid(t *testing.T) {
+	mockGateway := fakeGateway()
+
+	mockGateway.On("VerifyWebhook", mock.Anything, mock.Anything, "bad").Return(nil, domain.ErrInvalidWebhook)
+
+	gateways := map[domain.PaymentMethod]domain.PaymentGateway{domain.PaymentMethodPix: mockGateway}
+
+	err := NewPaymentUseCase(nil, nil, nil, nil, nil, gateways).Webhook(context.Background(), "fake", []byte(`{}`), "bad")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidWebhook)
+}
+
+func TestWebhookUnknownProvider(t *testing.T) {
+	gateways := map[domain.PaymentMethod]domain.PaymentGateway{domain.PaymentMethodPix: fakeGateway()}
+
+	err := NewPaymentUseCase(nil, nil, nil, nil, nil, gateways).Webhook(context.Background(), "other", []byte(`{}`), "sig")
+
+	assert.ErrorIs(t, err, domain.ErrPaymentProviderNotFound)
+}
+
+func TestReconcile(t *testing.T) {
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+	mockRefundRepo := new(mocks.MockRefundRepository)
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockGateway := fakeGateway()
+	mockOrderUseCase := paid("order-1")
+
+	mockPaymentRepo.On("ListToReconcile", mock.Anything).Return([]domain.PaymentIntent{
+		{UUID: "pay-1", OrderUUID: "order-1", Provider: "fake", ProviderReference: "ref-1", Status: domain.PaymentStatusCaptured, Amount: brl(9500)},
+		{UUID: "pay-2", OrderUUID: "order-2", Provider: "fake", ProviderReference: "ref-2", Status: domain.PaymentStatusAuthorized, Amount: brl(5000)},
+		{UUID: "pay-3", OrderUUID: "order-3", Provider: "fake", ProviderReference: "ref-3", Status: domain.PaymentStatusCaptured, Amount: brl(7000)},
+		{UUID: "pay-4", OrderUUID: "order-4", Provider: "gone", ProviderReference: "ref-4", Status: domain.PaymentStatusCaptured, Amount: brl(7000)},
+	}, nil)
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order-1").Return(&domain.Order{UUID: "order-1", Status: domain.OrderStatusPendingPayment}, nil)
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order-2").Return(&domain.Order{UUID: "order-2", Status: domain.OrderStatusCancelled}, nil)
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order-3").Return(&domain.Order{UUID: "order-3", Status: domain.OrderStatusCancelled}, nil)
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order-4").Return(&domain.Order{UUID: "order-4", Status: domain.OrderStatusCancelled}, nil)
+	mockGateway.On("Void", mock.Anything, "ref-2").Return(&domain.PaymentResult{ProviderReference: "ref-2", Status: domain.PaymentStatusVoided}, nil)
+	mockPaymentRepo.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.PaymentIntent) bool {
+		return p.UUID == "pay-2" && p.Status == domain.PaymentStatusVoided
+	}), domain.PaymentStatusAuthorized).Return(nil)
+	mockRefundRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *domain.Refund) bool {
+		return r.PaymentUUID == "pay-3" && r.Amount == brl(7000) && r.Reason == "pagamento de pedido cancelado" && r.Actor == "system" && r.Status == domain.RefundStatusPending
+	})).Return(nil)
+	mockGateway.On("Refund", mock.Anything, "ref-3", mock.AnythingOfType("string"), brl(7000)).Return(&domain.PaymentResult{ProviderReference: "refund-1", Status: domain.PaymentStatusRefunded}, nil)
+	mockRefundRepo.On("Finish", mock.Anything, mock.MatchedBy(func(r *domain.Refund) bool {
+		return r.PaymentUUID == "pay-3" && r.Status == domain.RefundStatusSucceeded && r.ProviderReference == "refund-1"
+	})).Return(nil)
+
+	gateways := map[domain.PaymentMethod]domain.PaymentGateway{domain.PaymentMethodCard: mockGateway}
+
+	reconciled, err := NewPaymentUseCase(mockPaymentRepo, mockRefundRepo, mockOrderRepo, nil, mockOrderUseCase, gateways).Reconcile(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, reconciled)
+	mockPaymentRepo.AssertExpectations(t)
+	mockRefundRepo.AssertExpectations(t)
+	mockGateway.AssertExpectations(t)
+	mockOrderUseCase.AssertExpectations(t)
+}
+
+func TestExpire(t *testing.T) {
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockGateway := fakeGateway()
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+
+	mockOrderRepo.On("ListExpired", mock.Anything, mock.Anything, expireBatch).Return([]domain.Order{{ID: 3, UUID: "order-1", Status: domain.OrderStatusPendingPayment}, {ID: 4, UUID: "order-2", Status: domain.OrderStatusPendingPayment}}, nil)
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{
+		{UUID: "pay-0", Provider: "fake", ProviderReference: "ref-0", Status: domain.PaymentStatusFailed},
+		{UUID: "pay-1", Provider: "fake", ProviderReference: "ref-1", Status: domain.PaymentStatusPending, Method: domain.PaymentMethodBoleto},
+	}, nil)
+	mockGateway.On("Void", mock.Anything, "ref-1").Return(&domain.PaymentResult{ProviderReference: "ref-1", Status: domain.PaymentStatusVoided}, nil)
+	mockPaymentRepo.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.PaymentIntent) bool {
+		return p.UUID == "pay-1" && p.Status == domain.PaymentStatusExpired
+	}), domain.PaymentStatusPending).Return(nil)
+	mockOrderUseCase.On("Transition", mock.Anything, "order-1", "system", mock.MatchedBy(func(t *domain.OrderTransitionRequest) bool {
+		return t.Status == domain.OrderStatusCancelled
+	})).Return(&domain.Order{UUID: "order-1", Status: domain.OrderStatusCancelled}, nil)
+	// the pix of the second order was paid meanwhile, the webhook pays it
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(4)).Return([]domain.PaymentIntent{
+		{UUID: "pay-2", Provider: "fake", ProviderReference: "ref-2", Status: domain.PaymentStatusPending, Method: domain.PaymentMethodPix},
+	}, nil)
+	mockGateway.On("Void", mock.Anything, "ref-2").Return(nil, domain.ErrPaymentInvalidState)
+
+	gateways := map[domain.PaymentMethod]domain.PaymentGateway{domain.PaymentMethodPix: mockGateway}
+
+	expired, err := NewPaymentUseCase(mockPaymentRepo, nil, mockOrderRepo, nil, mockOrderUseCase, gateways).Expire(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	mockPaymentRepo.AssertExpectations(t)
+	mockOrderUseCase.AssertExpectations(t)
+	mockOrderUseCase.AssertNotCalled(t, "Transition", mock.Anything, "order-2", mock.Anything, mock.Anything)
+}
+
+func TestWebhookCapturesExpired(t *testing.T) {
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+	mockGateway := fakeGateway()
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+
+	mockGateway.On("VerifyWebhook", mock.Anything, []byte("payload"), "sig").Return([]domain.PaymentEvent{{ProviderReference: "ref-1", Status: domain.PaymentStatusCaptured, Amount: brl(9500)}}, nil)
+	mockPaymentRepo.On("GetByProviderReference", mock.Anything, "fake", "ref-1").Return(&domain.PaymentIntent{UUID: "pay-1", OrderUUID: "order-1", Amount: brl(9500), Status: domain.PaymentStatusExpired}, nil)
+	mockPaymentRepo.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.PaymentIntent) bool {
+		return p.Status == domain.PaymentStatusCaptured
+	}), domain.PaymentStatusExpired).Return(nil)
+	// the order was cancelled, the reconciliation refunds the payment
+	mockOrderUseCase.On("Transition", mock.Anything, "order-1", "system", mock.Anything).Return(nil, &domain.TransitionError{From: domain.OrderStatusCancelled, To: domain.OrderStatusPaid})
+
+	gateways := map[domain.PaymentMethod]domain.PaymentGateway{domain.PaymentMethodBoleto: mockGateway}
+
+	err := NewPaymentUseCase(mockPaymentRepo, nil, nil, nil, mockOrderUseCase, gateways).Webhook(context.Background(), "fake", []byte("payload"), "sig")
+
+	assert.NoError(t, err)
+	mockPaymentRepo.AssertExpectations(t)
+}
@@ -0,0 +1,476 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/google/uuid"
+)
+
+// refundableStatuses are the orders with money collected that may be given
+// back, a cancelled order with a payment captured is refunded by the
+// reconciliation.
+var refundableStatuses = map[domain.OrderStatus]bool{
+	domain.OrderStatusPaid:      true,
+	domain.OrderStatusPicking:   true,
+	domain.OrderStatusShipped:   true,
+	domain.OrderStatusDelivered: true,
+	domain.OrderStatusReturned:  true,
+}
+
+// restockStatuses are the orders whose items are in the warehouses, not yet
+// shipped or returned by the customer.
+var restockStatuses = map[domain.OrderStatus]bool{
+	domain.OrderStatusPaid:     true,
+	domain.OrderStatusPicking:  true,
+	domain.OrderStatusReturned: true,
+}
+
+type refundUseCase struct {
+	refundRepo     domain.RefundRepository
+	paymentRepo    domain.PaymentRepository
+	orderRepo      domain.OrderRepository
+	userRepo       domain.UserRepository
+	inventoryRepo  domain.InventoryRepository
+	orderUseCase   domain.OrderUseCase
+	messageService domain.MessageService
+	gateways       map[domain.PaymentMethod]domain.PaymentGateway
+	restock        bool
+}
+
+// NewRefundUseCase puts the refunded lines back in the stock when restock is
+// set.
+func NewRefundUseCase(rr domain.RefundRepository, pr domain.PaymentRepository, or domain.OrderRepository, ur domain.UserRepository, ir domain.InventoryRepository, ouc domain.OrderUseCase, ms domain.MessageService, gateways map[domain.PaymentMethod]domain.PaymentGateway, restock bool) domain.RefundUseCase {
+	return &refundUseCase{refundRepo: rr, paymentRepo: pr, orderRepo: or, userRepo: ur, inventoryRepo: ir, orderUseCase: ouc, messageService: ms, gateways: gateways, restock: restock}
+}
+
+// Refund gives back the lines at the unit prices paid for them, plus the
+// amount asked, from the captured payments in the order they were made. A
+// line can not be refunded more times than it was ordered. Giving back all
+// that is left refunds the order, which tells the customer, giving
+// ErrOrderNotRefunded with the refunds made when the order can not be moved,
+// otherwise the customer is told about the partial refund. A refund the gateway can not be
+// asked for is left pending and holds its amount until it is reviewed.
+func (ru *refundUseCase) Refund(ctx context.Context, orderUUID string, actor string, r *domain.RefundRequest) ([]domain.Refund, error) {
+	order, err := ru.order(ctx, orderUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !refundableStatuses[order.Status] {
+		return nil, domain.ErrRefundNotAllowed
+	}
+
+	payments, err := ru.paymentRepo.ListByOrder(ctx, order.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var left int64
+
+	for _, p := range payments {
+		if p.Status == domain.PaymentStatusCaptured && p.Amount.Currency == order.Total.Currency {
+			left += p.Amount.Amount - p.Refunded.Amount
+		}
+	}
+
+	refunds, err := ru.refundRepo.ListByOrder(ctx, order.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lines, amount, err := refundLines(order, refunds, r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Full {
+		amount = left
+	}
+
+	if amount <= 0 || amount > left {
+		return nil, domain.ErrRefundExceeds
+	}
+
+	full := amount == left
+
+	if _, ok := domain.OrderTransitions[order.Status][domain.OrderStatusRefunded]; full && !ok {
+		return nil, &domain.TransitionError{From: order.Status, To: domain.OrderStatusRefunded}
+	}
+
+	restock := ru.restock && len(lines) > 0 && restockStatuses[order.Status]
+
+	res := []domain.Refund{}
+
+	for i := range payments {
+		p := &payments[i]
+
+		if amount == 0 {
+			break
+		}
+
+		if p.Status != domain.PaymentStatusCaptured || p.Amount.Currency != order.Total.Currency || p.Refunded.Amount == p.Amount.Amount {
+			continue
+		}
+
+		gateway := paymentGateway(ru.gateways, p.Provider)
+
+		if gateway == nil {
+			return res, domain.ErrPaymentProviderNotFound
+		}
+
+		take := p.Amount.Amount - p.Refunded.Amount
+
+		if take > amount {
+			take = amount
+		}
+
+		refund := &domain.Refund{
+			UUID:    uuid.NewString(),
+			OrderID: order.ID,
+			Amount:  domain.Money{Amount: take, Currency: p.Amount.Currency},
+			Lines:   []domain.RefundLine{},
+			Reason:  r.Reason,
+			Actor:   actor,
+		}
+
+		// the lines go with the first refund, the others are money only
+		if len(res) == 0 {
+			refund.Lines = lines
+		}
+
+		if err := refundPayment(ctx, ru.refundRepo, gateway, p, refund); err != nil {
+			return res, err
+		}
+
+		if refund.Status == domain.RefundStatusSucceeded && restock && len(refund.Lines) > 0 {
+			refund.Restocked = ru.putBack(ctx, order, refund.Lines)
+		}
+
+		if err := ru.refundRepo.Finish(ctx, refund); err != nil {
+			return res, err
+		}
+
+		if refund.Status == domain.RefundStatusFailed {
+			return res, fmt.Errorf("%w: %s", domain.ErrRefundFailed, refund.FailureReason)
+		}
+
+		res = append(res, *refund)
+		amount -= take
+	}
+
+	if full {
+		// the money is already given back, a refund asked again finds nothing
+		// left, the order is moved to refunded by hand
+		_, err := ru.orderUseCase.Transition(ctx, order.UUID, actor, &domain.OrderTransitionRequest{Status: domain.OrderStatusRefunded, Note: r.Reason})
+
+		if err != nil {
+			return res, fmt.Errorf("%w: %s", domain.ErrOrderNotRefunded, err.Error())
+		}
+
+		return res, nil
+	}
+
+	// the message service takes seconds to answer, the money is already given
+	// back and does not wait for the customer to be told
+	notified := *order
+	refunded := append([]domain.Refund{}, res...)
+
+	go func() {
+		if err := ru.notify(context.Background(), &notified, refunded, r.Reason); err != nil {
+			log.Printf("Error trying to notify the refund of the order %s: %s", notified.UUID, err.Error())
+		}
+	}()
+
+	return res, nil
+}
+
+func (ru *refundUseCase) List(ctx context.Context, orderUUID string) ([]domain.Refund, error) {
+	order, err := ru.order(ctx, orderUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ru.refundRepo.ListByOrder(ctx, order.ID)
+}
+
+// Ledger has the payments captured, the ones refunded since included, and
+// the refunds that succeeded, in the order they were made.
+func (ru *refundUseCase) Ledger(ctx context.Context, orderUUID string) (*domain.Ledger, error) {
+	order, err := ru.order(ctx, orderUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ru.ledger(ctx, order)
+}
+
+func (ru *refundUseCase) LedgerMine(ctx context.Context, login string, orderUUID string) (*domain.Ledger, error) {
+	user, err := ru.userRepo.GetByEmail(ctx, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	order, err := ru.order(ctx, orderUUID)
+
+	if errors.Is(err, domain.ErrOrderNotFound) || (err == nil && order.UserID != user.ID) {
+		return nil, domain.ErrOrderNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ru.ledger(ctx, order)
+}
+
+func (ru *refundUseCase) ledger(ctx context.Context, order *domain.Order) (*domain.Ledger, error) {
+	payments, err := ru.paymentRepo.ListByOrder(ctx, order.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	refunds, err := ru.refundRepo.ListByOrder(ctx, order.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	currency := order.Total.Currency
+
+	ledger := &domain.Ledger{
+		OrderUUID: order.UUID,
+		Entries:   []domain.LedgerEntry{},
+		Collected: domain.Money{Currency: currency},
+		Refunded:  domain.Money{Currency: currency},
+		Net:       domain.Money{Currency: currency},
+	}
+
+	for _, p := range payments {
+		if (p.Status != domain.PaymentStatusCaptured && p.Status != domain.PaymentStatusRefunded) || p.Amount.Currency != currency {
+			continue
+		}
+
+		ledger.Entries = append(ledger.Entries, domain.LedgerEntry{Kind: domain.LedgerEntryPayment, UUID: p.UUID, Amount: p.Amount, CreatedAt: p.CreatedAt})
+		ledger.Collected.Amount += p.Amount.Amount
+	}
+
+	for _, r := range refunds {
+		if r.Status != domain.RefundStatusSucceeded || r.Amount.Currency != currency {
+			continue
+		}
+
+		ledger.Entries = append(ledger.Entries, domain.LedgerEntry{Kind: domain.LedgerEntryRefund, UUID: r.UUID, Amount: domain.Money{Amount: -r.Amount.Amount, Currency: currency}, Reason: r.Reason, CreatedAt: r.CreatedAt})
+		ledger.Refunded.Amount += r.Amount.Amount
+	}
+
+	sort.SliceStable(ledger.Entries, func(i, j int) bool {
+		return ledger.Entries[i].CreatedAt.Before(ledger.Entries[j].CreatedAt)
+	})
+
+	ledger.Net.Amount = ledger.Collected.Amount - ledger.Refunded.Amount
+
+	return ledger, nil
+}
+
+// putBack gives the lines back to the warehouses their stock was reserved
+// from. The money is already given back, a line that fails is only logged.
+func (ru *refundUseCase) putBack(ctx context.Context, order *domain.Order, lines []domain.RefundLine) bool {
+	restocked := true
+
+	for _, line := range lines {
+		if err := ru.putBackLine(ctx, order, line); err != nil {
+			log.Printf("Error trying to restock %s of the order %s: %s", line.SKU, order.UUID, err.Error())
+			restocked = false
+		}
+	}
+
+	return restocked
+}
+
+func (ru *refundUseCase) putBackLine(ctx context.Context, order *domain.Order, line domain.RefundLine) error {
+	for _, item := range order.Items {
+		if item.SKU != line.SKU {
+			continue
+		}
+
+		reservation, err := ru.inventoryRepo.GetReservation(ctx, item.ReservationUUID)
+
+		if err != nil {
+			return err
+		}
+
+		if reservation == nil {
+			return domain.ErrReservationNotFound
+		}
+
+		return ru.inventoryRepo.Adjust(ctx, &domain.StockMovement{
+			SKU:         line.SKU,
+			Warehouse:   reservation.Warehouse,
+			OnHandDelta: line.Quantity,
+			Reason:      domain.MovementReasonRefund,
+			Reference:   order.UUID,
+		})
+	}
+
+	return domain.ErrRefundLineNotFound
+}
+
+func (ru *refundUseCase) notify(ctx context.Context, o *domain.Order, refunds []domain.Refund, reason string) error {
+	user, err := ru.userRepo.GetByID(ctx, o.UserID)
+
+	if err != nil || user == nil {
+		return err
+	}
+
+	var amount int64
+
+	for _, r := range refunds {
+		amount += r.Amount.Amount
+	}
+
+	var messageConf domain.MessageConfig
+
+	messageConf.Medium = "email"
+	messageConf.To = user.Email
+	messageConf.Subject = "Reembolso parcial"
+	messageConf.Message = fmt.Sprintf("O pedido %s teve %s %d,%02d reembolsados: %s", o.UUID, o.Total.Currency, amount/100, amount%100, reason)
+	messageConf.Category = domain.NotificationCategoryOrders
+	messageConf.User = user
+
+	return ru.messageService.SendMessage(ctx, &messageConf)
+}
+
+func (ru *refundUseCase) order(ctx context.Context, orderUUID string) (*domain.Order, error) {
+	order, err := ru.orderRepo.GetByUUID(ctx, orderUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil {
+		return nil, domain.ErrOrderNotFound
+	}
+
+	return order, nil
+}
+
+// refundLines gives the lines of the request, all that is left of the order
+// when it is full, and the amount the lines and the request are refunded by,
+// which the caller takes from the payments when it is full.
+func refundLines(order *domain.Order, refunds []domain.Refund, r *domain.RefundRequest) ([]domain.RefundLine, int64, error) {
+	refunded := map[string]int64{}
+
+	for _, refund := range refunds {
+		if refund.Status == domain.RefundStatusFailed {
+			continue
+		}
+
+		for _, line := range refund.Lines {
+			refunded[line.SKU] += line.Quantity
+		}
+	}
+
+	lines := []domain.RefundLine{}
+
+	if r.Full {
+		for _, item := range order.Items {
+			if left := item.Quantity - refunded[item.SKU]; left > 0 {
+				lines = append(lines, domain.RefundLine{SKU: item.SKU, Quantity: left})
+			}
+		}
+
+		return lines, 0, nil
+	}
+
+	amount := r.Amount
+
+	for _, line := range r.Lines {
+		item := orderItem(order, line.SKU)
+
+		if item == nil {
+			return nil, 0, domain.ErrRefundLineNotFound
+		}
+
+		if line.Quantity > item.Quantity-refunded[line.SKU] {
+			return nil, 0, domain.ErrRefundExceeds
+		}
+
+		refunded[line.SKU] += line.Quantity
+		amount += item.UnitPrice.Amount * line.Quantity
+		lines = append(lines, line)
+	}
+
+	return lines, amount, nil
+}
+
+func orderItem(order *domain.Order, sku string) *domain.OrderItem {
+	for i := range order.Items {
+		if order.Items[i].SKU == sku {
+			return &order.Items[i]
+		}
+	}
+
+	return nil
+}
+
+// refundPayment asks the gateway to give back the refund from the captured
+// payment, holding its amount on the payment first. The refund is finished
+// by the caller with the answer. A failure to ask leaves it pending, unless
+// the gateway refused the operation.
+func refundPayment(ctx context.Context, refundRepo domain.RefundRepository, gateway domain.PaymentGateway, p *domain.PaymentIntent, r *domain.Refund) error {
+	r.PaymentID, r.PaymentUUID, r.Status = p.ID, p.UUID, domain.RefundStatusPending
+
+	if err := refundRepo.Create(ctx, r); err != nil {
+		return err
+	}
+
+	res, err := gateway.Refund(ctx, p.ProviderReference, r.UUID, r.Amount)
+
+	if errors.Is(err, domain.ErrPaymentInvalidState) {
+		r.Status, r.FailureReason = domain.RefundStatusFailed, err.Error()
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	r.ProviderReference = res.ProviderReference
+
+	if res.Status == domain.PaymentStatusFailed {
+		r.Status, r.FailureReason = domain.RefundStatusFailed, res.FailureReason
+		return nil
+	}
+
+	r.Status = domain.RefundStatusSucceeded
+	p.Refunded.Amount += r.Amount.Amount
+
+	return nil
+}
+
+func paymentGateway(gateways map[domain.PaymentMethod]domain.PaymentGateway, provider string) domain.PaymentGateway {
+	for _, gateway := range gateways {
+		if gateway.Name() == provider {
+			return gateway
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,347 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func paidOrder(status domain.OrderStatus) *domain.Order {
+	return &domain.Order{
+		ID:     3,
+		UUID:   "order-1",
+		UserID: 7,
+		Status: status,
+		Items: []domain.OrderItem{
+			{SKU: "P7-M", Quantity: 2, UnitPrice: brl(4000), ReservationUUID: "res-1"},
+		},
+		Total: brl(9500),
+	}
+}
+
+func capturedPayment() domain.PaymentIntent {
+	return domain.PaymentIntent{ID: 12, UUID: "pay-1", Provider: "fake", ProviderReference: "ref-1", Status: domain.PaymentStatusCaptured, Amount: brl(9500), Refunded: brl(0)}
+}
+
+func refundGateways(gateway domain.PaymentGateway) map[domain.PaymentMethod]domain.PaymentGateway {
+	return map[domain.PaymentMethod]domain.PaymentGateway{domain.PaymentMethodCard: gateway}
+}
+
+func TestRefundLineRestocks(t *testing.T) {
+	mockRefundRepo := new(mocks.MockRefundRepository)
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockInventoryRepo := new(mocks.MockInventoryRepository)
+	mockMessageService := new(mocks.MockMessageService)
+	mockGateway := fakeGateway()
+
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{capturedPayment()}, nil)
+	mockRefundRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.Refund{}, nil)
+	mockRefundRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *domain.Refund) bool {
+		return r.PaymentID == 12 && r.Amount == brl(4000) && len(r.Lines) == 1 && r.Reason == "produto com defeito" && r.Actor == "admin@test.com"
+	})).Return(nil)
+	mockGateway.On("Refund", mock.Anything, "ref-1", mock.AnythingOfType("string"), brl(4000)).Return(&domain.PaymentResult{ProviderReference: "ref-1_refund_1", Status: domain.PaymentStatusRefunded}, nil)
+	mockInventoryRepo.On("GetReservation", mock.Anything, "res-1").Return(&domain.Reservation{UUID: "res-1", SKU: "P7-M", Warehouse: "sp"}, nil)
+	mockInventoryRepo.On("Adjust", mock.Anything, &domain.StockMovement{SKU: "P7-M", Warehouse: "sp", OnHandDelta: 1, Reason: domain.MovementReasonRefund, Reference: "order-1"}).Return(nil)
+	mockRefundRepo.On("Finish", mock.Anything, mock.MatchedBy(func(r *domain.Refund) bool {
+		return r.Status == domain.RefundStatusSucceeded && r.ProviderReference == "ref-1_refund_1" && r.Restocked
+	})).Return(nil)
+	mockUserRepo.On("GetByID", mock.Anything, int64(7)).Return(&domain.User{ID: 7, Email: "ana@test.com"}, nil)
+	// the message is held until the refund returned, a refund waiting for it
+	// would never end
+	release := make(chan bool)
+	sent := make(chan bool)
+
+	mockMessageService.On("SendMessage", mock.Anything, mock.MatchedBy(func(mc *domain.MessageConfig) bool {
+		return mc.To == "ana@test.com" && mc.Message == "O pedido order-1 teve BRL 40,00 reembolsados: produto com defeito" && mc.Category == domain.NotificationCategoryOrders
+	})).Run(func(args mock.Arguments) {
+		<-release
+		sent <- true
+	}).Return(nil)
+
+	refunds, err := NewRefundUseCase(mockRefundRepo, mockPaymentRepo, orderRepoWith(paidOrder(domain.OrderStatusPaid)), mockUserRepo, mockInventoryRepo, nil, mockMessageService, refundGateways(mockGateway), true).
+		Refund(context.Background(), "order-1", "admin@test.com", &domain.RefundRequest{Lines: []domain.RefundLine{{SKU: "P7-M", Quantity: 1}}, Reason: "produto com defeito"})
+
+	assert.NoError(t, err)
+	assert.Len(t, refunds, 1)
+	assert.Equal(t, "pay-1", refunds[0].PaymentUUID)
+	// the gateway is asked with the reference of the refund, a retry is the same refund
+	mockGateway.AssertCalled(t, "Refund", mock.Anything, "ref-1", refunds[0].UUID, brl(4000))
+	mockRefundRepo.AssertExpectations(t)
+	mockInventoryRepo.AssertExpectations(t)
+
+	close(release)
+
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("the refund was not notified")
+	}
+
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestRefundLineNotRestockedWhenNotConfigured(t *testing.T) {
+	mockRefundRepo := new(mocks.MockRefundRepository)
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockInventoryRepo := new(mocks.MockInventoryRepository)
+	mockMessageService := new(mocks.MockMessageService)
+	mockGateway := fakeGateway()
+
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{capturedPayment()}, nil)
+	mockRefundRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.Refund{}, nil)
+	mockRefundRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	// the shipping goes with the line
+	mockGateway.On("Refund", mock.Anything, "ref-1", mock.AnythingOfType("string"), brl(5500)).Return(&domain.PaymentResult{ProviderReference: "ref-1_refund_1", Status: domain.PaymentStatusRefunded}, nil)
+	mockRefundRepo.On("Finish", mock.Anything, mock.MatchedBy(func(r *domain.Refund) bool {
+		return r.Status == domain.RefundStatusSucceeded && !r.Restocked
+	})).Return(nil)
+	mockUserRepo.On("GetByID", mock.Anything, int64(7)).Return(nil, errors.New("db down"))
+
+	_, err := NewRefundUseCase(mockRefundRepo, mockPaymentRepo, orderRepoWith(paidOrder(domain.OrderStatusPicking)), mockUserRepo, mockInventoryRepo, nil, mockMessageService, refundGateways(mockGateway), false).
+		Refund(context.Background(), "order-1", "admin@test.com", &domain.RefundRequest{Lines: []domain.RefundLine{{SKU: "P7-M", Quantity: 1}}, Amount: 1500, Reason: "atraso"})
+
+	assert.NoError(t, err)
+	mockRefundRepo.AssertExpectations(t)
+	mockInventoryRepo.AssertNotCalled(t, "Adjust", mock.Anything, mock.Anything)
+	mockMessageService.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+}
+
+func TestRefundFullRefundsOrder(t *testing.T) {
+	mockRefundRepo := new(mocks.MockRefundRepository)
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+	mockInventoryRepo := new(mocks.MockInventoryRepository)
+	mockMessageService := new(mocks.MockMessageService)
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+	mockGateway := fakeGateway()
+
+	payment := capturedPayment()
+	payment.Refunded = brl(4000)
+
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{payment}, nil)
+	mockRefundRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.Refund{
+		{Status: domain.RefundStatusSucceeded, Amount: brl(4000), Lines: []domain.RefundLine{{SKU: "P7-M", Quantity: 1}}},
+		{Status: domain.RefundStatusFailed, Amount: brl(4000), Lines: []domain.RefundLine{{SKU: "P7-M", Quantity: 1}}},
+	}, nil)
+	mockRefundRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *domain.Refund) bool {
+		return r.Amount == brl(5500) && len(r.Lines) == 1 && r.Lines[0] == domain.RefundLine{SKU: "P7-M", Quantity: 1}
+	})).Return(nil)
+	mockGateway.On("Refund", mock.Anything, "ref-1", mock.AnythingOfType("string"), brl(5500)).Return(&domain.PaymentResult{ProviderReference: "ref-1_refund_2", Status: domain.PaymentStatusRefunded}, nil)
+	mockRefundRepo.On("Finish", mock.Anything, mock.MatchedBy(func(r *domain.Refund) bool {
+		return r.Status == domain.RefundStatusSucceeded && !r.Restocked
+	})).Return(nil)
+	mockOrderUseCase.On("Transition", mock.Anything, "order-1", "admin@test.com", &domain.OrderTransitionRequest{Status: domain.OrderStatusRefunded, Note: "desistência"}).Return(&domain.Order{UUID: "order-1", Status: domain.OrderStatusRefunded}, nil)
+
+	refunds, err := NewRefundUseCase(mockRefundRepo, mockPaymentRepo, orderRepoWith(paidOrder(domain.OrderStatusDelivered)), nil, mockInventoryRepo, mockOrderUseCase, mockMessageService, refundGateways(mockGateway), true).
+		Refund(context.Background(), "order-1", "admin@test.com", &domain.RefundRequest{Full: true, Reason: "desistência"})
+
+	assert.NoError(t, err)
+	assert.Len(t, refunds, 1)
+	mockOrderUseCase.AssertExpectations(t)
+	mockInventoryRepo.AssertNotCalled(t, "Adjust", mock.Anything, mock.Anything)
+	mockMessageService.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+}
+
+func TestRefundSplitAcrossPayments(t *testing.T) {
+	mockRefundRepo := new(mocks.MockRefundRepository)
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+	mockGateway := fakeGateway()
+
+	first := capturedPayment()
+	first.Amount = brl(3000)
+	second := capturedPayment()
+	second.ID, second.UUID, second.ProviderReference, second.Amount = 13, "pay-2", "ref-2", brl(6500)
+
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{{Status: domain.PaymentStatusFailed, Amount: brl(9500)}, first, second}, nil)
+	mockRefundRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.Refund{}, nil)
+	mockRefundRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *domain.Refund) bool {
+		return r.PaymentID == 12 && r.Amount == brl(3000) && len(r.Lines) == 1
+	})).Return(nil)
+	mockRefundRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *domain.Refund) bool {
+		return r.PaymentID == 13 && r.Amount == brl(6500) && len(r.Lines) == 0
+	})).Return(nil)
+	mockGateway.On("Refund", mock.Anything, "ref-1", mock.AnythingOfType("string"), brl(3000)).Return(&domain.PaymentResult{Status: domain.PaymentStatusRefunded}, nil)
+	mockGateway.On("Refund", mock.Anything, "ref-2", mock.AnythingOfType("string"), brl(6500)).Return(&domain.PaymentResult{Status: domain.PaymentStatusRefunded}, nil)
+	mockRefundRepo.On("Finish", mock.Anything, mock.Anything).Return(nil)
+	mockOrderUseCase.On("Transition", mock.Anything, "order-1", "admin@test.com", mock.Anything).Return(&domain.Order{UUID: "order-1", Status: domain.OrderStatusRefunded}, nil)
+
+	refunds, err := NewRefundUseCase(mockRefundRepo, mockPaymentRepo, orderRepoWith(paidOrder(domain.OrderStatusReturned)), nil, nil, mockOrderUseCase, nil, refundGateways(mockGateway), false).
+		Refund(context.Background(), "order-1", "admin@test.com", &domain.RefundRequest{Full: true, Reason: "devolução"})
+
+	assert.NoError(t, err)
+	assert.Len(t, refunds, 2)
+	mockRefundRepo.AssertExpectations(t)
+	mockGateway.AssertExpectations(t)
+	mockOrderUseCase.AssertExpectations(t)
+}
+
+func TestRefundFullOrderNotMoved(t *testing.T) {
+	mockRefundRepo := new(mocks.MockRefundRepository)
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+	mockOrderUseCase := new(mocks.MockOrderUseCase)
+	mockGateway := fakeGateway()
+
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{capturedPayment()}, nil)
+	mockRefundRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.Refund{}, nil)
+	mockRefundRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockGateway.On("Refund", mock.Anything, "ref-1", mock.AnythingOfType("string"), brl(9500)).Return(&domain.PaymentResult{Status: domain.PaymentStatusRefunded}, nil)
+	mockRefundRepo.On("Finish", mock.Anything, mock.Anything).Return(nil)
+	mockOrderUseCase.On("Transition", mock.Anything, "order-1", "admin@test.com", mock.Anything).Return(nil, errors.New("db down"))
+
+	refunds, err := NewRefundUseCase(mockRefundRepo, mockPaymentRepo, orderRepoWith(paidOrder(domain.OrderStatusDelivered)), nil, nil, mockOrderUseCase, nil, refundGateways(mockGateway), false).
+		Refund(context.Background(), "order-1", "admin@test.com", &domain.RefundRequest{Full: true, Reason: "desistência"})
+
+	// the money was given back, the refunds are kept with the error
+	assert.ErrorIs(t, err, domain.ErrOrderNotRefunded)
+	assert.Contains(t, err.Error(), "db down")
+	assert.Len(t, refunds, 1)
+	mockRefundRepo.AssertExpectations(t)
+}
+
+func TestRefundRefusedByGateway(t *testing.T) {
+	mockRefundRepo := new(mocks.MockRefundRepository)
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+	mockGateway := fakeGateway()
+
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{capturedPayment()}, nil)
+	mockRefundRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.Refund{}, nil)
+	mockRefundRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockGateway.On("Refund", mock.Anything, "ref-1", mock.AnythingOfType("string"), brl(1500)).Return(&domain.PaymentResult{ProviderReference: "D1", Status: domain.PaymentStatusFailed, FailureReason: "saldo insuficiente"}, nil)
+	mockRefundRepo.On("Finish", mock.Anything, mock.MatchedBy(func(r *domain.Refund) bool {
+		return r.Status == domain.RefundStatusFailed && r.FailureReason == "saldo insuficiente"
+	})).Return(nil)
+
+	_, err := NewRefundUseCase(mockRefundRepo, mockPaymentRepo, orderRepoWith(paidOrder(domain.OrderStatusDelivered)), nil, nil, nil, nil, refundGateways(mockGateway), true).
+		Refund(context.Background(), "order-1", "admin@test.com", &domain.RefundRequest{Amount: 1500, Reason: "frete"})
+
+	assert.ErrorIs(t, err, domain.ErrRefundFailed)
+	assert.Contains(t, err.Error(), "saldo insuficiente")
+	mockRefundRepo.AssertExpectations(t)
+}
+
+func TestRefundLineExceedsOrdered(t *testing.T) {
+	mockRefundRepo := new(mocks.MockRefundRepository)
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{capturedPayment()}, nil)
+	mockRefundRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.Refund{
+		{Status: domain.RefundStatusPending, Amount: brl(4000), Lines: []domain.RefundLine{{SKU: "P7-M", Quantity: 1}}},
+	}, nil)
+
+	_, err := NewRefundUseCase(mockRefundRepo, mockPaymentRepo, orderRepoWith(paidOrder(domain.OrderStatusDelivered)), nil, nil, nil, nil, nil, true).
+		Refund(context.Background(), "order-1", "admin@test.com", &domain.RefundRequest{Lines: []domain.RefundLine{{SKU: "P7-M", Quantity: 2}}, Reason: "defeito"})
+
+	assert.ErrorIs(t, err, domain.ErrRefundExceeds)
+	mockRefundRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestRefundLineNotInOrder(t *testing.T) {
+	mockRefundRepo := new(mocks.MockRefundRepository)
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{capturedPayment()}, nil)
+	mockRefundRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.Refund{}, nil)
+
+	_, err := NewRefundUseCase(mockRefundRepo, mockPaymentRepo, orderRepoWith(paidOrder(domain.OrderStatusDelivered)), nil, nil, nil, nil, nil, true).
+		Refund(context.Background(), "order-1", "admin@test.com", &domain.RefundRequest{Lines: []domain.RefundLine{{SKU: "P9-G", Quantity: 1}}, Reason: "defeito"})
+
+	assert.ErrorIs(t, err, domain.ErrRefundLineNotFound)
+}
+
+func TestRefundAmountExceedsCollected(t *testing.T) {
+	mockRefundRepo := new(mocks.MockRefundRepository)
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+
+	payment := capturedPayment()
+	payment.Refunded = brl(9000)
+
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{payment}, nil)
+	mockRefundRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.Refund{}, nil)
+
+	_, err := NewRefundUseCase(mockRefundRepo, mockPaymentRepo, orderRepoWith(paidOrder(domain.OrderStatusDelivered)), nil, nil, nil, nil, nil, true).
+		Refund(context.Background(), "order-1", "admin@test.com", &domain.RefundRequest{Amount: 1000, Reason: "frete"})
+
+	assert.ErrorIs(t, err, domain.ErrRefundExceeds)
+}
+
+func TestRefundFullOfShippedOrder(t *testing.T) {
+	mockRefundRepo := new(mocks.MockRefundRepository)
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{capturedPayment()}, nil)
+	mockRefundRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.Refund{}, nil)
+
+	_, err := NewRefundUseCase(mockRefundRepo, mockPaymentRepo, orderRepoWith(paidOrder(domain.OrderStatusShipped)), nil, nil, nil, nil, nil, true).
+		Refund(context.Background(), "order-1", "admin@test.com", &domain.RefundRequest{Full: true, Reason: "extravio"})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	mockRefundRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestRefundOrderNotPaid(t *testing.T) {
+	_, err := NewRefundUseCase(nil, nil, orderRepoWith(pendingOrder()), nil, nil, nil, nil, nil, true).
+		Refund(context.Background(), "order-1", "admin@test.com", &domain.RefundRequest{Full: true, Reason: "desistência"})
+
+	assert.ErrorIs(t, err, domain.ErrRefundNotAllowed)
+}
+
+func TestRefundOrderNotFound(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+
+	mockOrderRepo.On("GetByUUID", mock.Anything, "order-9").Return(nil, nil)
+
+	_, err := NewRefundUseCase(nil, nil, mockOrderRepo, nil, nil, nil, nil, nil, true).
+		Refund(context.Background(), "order-9", "admin@test.com", &domain.RefundRequest{Full: true, Reason: "desistência"})
+
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound)
+}
+
+func TestLedger(t *testing.T) {
+	mockRefundRepo := new(mocks.MockRefundRepository)
+	mockPaymentRepo := new(mocks.MockPaymentRepository)
+
+	paidAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	refundedAt := paidAt.Add(48 * time.Hour)
+
+	mockPaymentRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.PaymentIntent{
+		{UUID: "pay-0", Status: domain.PaymentStatusFailed, Amount: brl(9500), CreatedAt: paidAt.Add(-time.Hour)},
+		{UUID: "pay-1", Status: domain.PaymentStatusCaptured, Amount: brl(9500), Refunded: brl(4000), CreatedAt: paidAt},
+	}, nil)
+	mockRefundRepo.On("ListByOrder", mock.Anything, int64(3)).Return([]domain.Refund{
+		{UUID: "refund-2", Status: domain.RefundStatusFailed, Amount: brl(1500), Reason: "frete", CreatedAt: refundedAt.Add(time.Hour)},
+		{UUID: "refund-1", Status: domain.RefundStatusSucceeded, Amount: brl(4000), Reason: "produto com defeito", CreatedAt: refundedAt},
+	}, nil)
+
+	ledger, err := NewRefundUseCase(mockRefundRepo, mockPaymentRepo, orderRepoWith(paidOrder(domain.OrderStatusDelivered)), nil, nil, nil, nil, nil, true).
+		Ledger(context.Background(), "order-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Ledger{
+		OrderUUID: "order-1",
+		Entries: []domain.LedgerEntry{
+			{Kind: domain.LedgerEntryPayment, UUID: "pay-1", Amount: brl(9500), CreatedAt: paidAt},
+			{Kind: domain.LedgerEntryRefund, UUID: "refund-1", Amount: brl(-4000), Reason: "produto com defeito", CreatedAt: refundedAt},
+		},
+		Collected: brl(9500),
+		Refunded:  brl(4000),
+		Net:       brl(5500),
+	}, ledger)
+}
+
+func TestLedgerMineOfAnotherUser(t *testing.T) {
+	order := paidOrder(domain.OrderStatusDelivered)
+	order.UserID = 8
+
+	_, err := NewRefundUseCase(nil, nil, orderRepoWith(order), userRepoWithAna(), nil, nil, nil, nil, true).
+		LedgerMine(context.Background(), "ana@test.com", "order-1")
+
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound)
+}
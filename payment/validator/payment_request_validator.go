@@ -0,0 +1,59 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type paymentRequestValidator struct {
+	methods []domain.PaymentMethod
+}
+
+// NewPaymentRequestValidator takes only the methods given, the ones the
+// payment driver has a gateway for.
+func NewPaymentRequestValidator(methods []domain.PaymentMethod) *paymentRequestValidator {
+	return &paymentRequestValidator{methods: methods}
+}
+
+func (prv *paymentRequestValidator) Validate(ctx context.Context, r *domain.PaymentRequest) (domain.IsValid, domain.Message) {
+	if !prv.accepts(r.Method) {
+		return false, domain.Message("payment's method must be " + prv.names())
+	}
+
+	if r.Method == domain.PaymentMethodCard && r.CardToken == "" {
+		return false, "payment's card token can not be empty"
+	}
+
+	if utf8.RuneCountInString(r.CardToken) > 255 {
+		return false, "payment's card token can not be longer than 255 characters"
+	}
+
+	return true, ""
+}
+
+func (prv *paymentRequestValidator) accepts(method domain.PaymentMethod) bool {
+	for _, m := range prv.methods {
+		if m == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// names lists the methods as "card, pix or boleto".
+func (prv *paymentRequestValidator) names() string {
+	names := make([]string, len(prv.methods))
+	for i, m := range prv.methods {
+		names[i] = string(m)
+	}
+
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
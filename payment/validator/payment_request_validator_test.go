@@ -0,0 +1,51 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var allMethods = []domain.PaymentMethod{domain.PaymentMethodCard, domain.PaymentMethodPix, domain.PaymentMethodBoleto}
+
+func TestValidatePaymentRequestInvalid(t *testing.T) {
+	for _, r := range []domain.PaymentRequest{
+		{Method: ""},
+		{Method: "cash"},
+		{Method: domain.PaymentMethodCard},
+		{Method: domain.PaymentMethodCard, CardToken: strings.Repeat("a", 256)},
+	} {
+		isValid, message := NewPaymentRequestValidator(allMethods).Validate(context.Background(), &r)
+
+		assert.False(t, bool(isValid))
+		assert.NotEmpty(t, message)
+	}
+}
+
+func TestValidatePaymentRequest(t *testing.T) {
+	for _, r := range []domain.PaymentRequest{
+		{Method: domain.PaymentMethodCard, CardToken: "tok_visa"},
+		{Method: domain.PaymentMethodPix},
+		{Method: domain.PaymentMethodBoleto},
+	} {
+		isValid, message := NewPaymentRequestValidator(allMethods).Validate(context.Background(), &r)
+
+		assert.True(t, bool(isValid))
+		assert.Empty(t, message)
+	}
+}
+
+func TestValidatePaymentRequestMethodNotConfigured(t *testing.T) {
+	isValid, message := NewPaymentRequestValidator([]domain.PaymentMethod{domain.PaymentMethodCard, domain.PaymentMethodPix}).Validate(context.Background(), &domain.PaymentRequest{Method: domain.PaymentMethodBoleto})
+
+	assert.False(t, bool(isValid))
+	assert.Equal(t, domain.Message("payment's method must be card or pix"), message)
+
+	isValid, message = NewPaymentRequestValidator(allMethods).Validate(context.Background(), &domain.PaymentRequest{Method: "cash"})
+
+	assert.False(t, bool(isValid))
+	assert.Equal(t, domain.Message("payment's method must be card, pix or boleto"), message)
+}
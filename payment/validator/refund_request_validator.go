@@ -0,0 +1,57 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type refundRequestValidator struct{}
+
+func NewRefundRequestValidator() *refundRequestValidator {
+	return &refundRequestValidator{}
+}
+
+func (rrv *refundRequestValidator) Validate(ctx context.Context, r *domain.RefundRequest) (domain.IsValid, domain.Message) {
+	if strings.TrimSpace(r.Reason) == "" {
+		return false, "refund's reason can not be empty"
+	}
+
+	if utf8.RuneCountInString(r.Reason) > 255 {
+		return false, "refund's reason can not be longer than 255 characters"
+	}
+
+	if r.Full && (len(r.Lines) > 0 || r.Amount != 0) {
+		return false, "a full refund can not have lines or an amount"
+	}
+
+	if !r.Full && len(r.Lines) == 0 && r.Amount == 0 {
+		return false, "refund must be full or have lines or an amount"
+	}
+
+	if r.Amount < 0 {
+		return false, "refund's amount can not be negative"
+	}
+
+	skus := map[string]bool{}
+
+	for _, line := range r.Lines {
+		if line.SKU == "" {
+			return false, "refund line's sku can not be empty"
+		}
+
+		if line.Quantity <= 0 {
+			return false, "refund line's quantity must be greater than zero"
+		}
+
+		if skus[line.SKU] {
+			return false, "refund lines can not repeat a sku"
+		}
+
+		skus[line.SKU] = true
+	}
+
+	return true, ""
+}
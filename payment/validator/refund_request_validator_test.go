@@ -0,0 +1,43 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRefundRequestInvalid(t *testing.T) {
+	for _, r := range []domain.RefundRequest{
+		{Full: true},
+		{Full: true, Reason: " "},
+		{Full: true, Reason: strings.Repeat("a", 256)},
+		{Full: true, Amount: 1500, Reason: "frete"},
+		{Full: true, Lines: []domain.RefundLine{{SKU: "P7-M", Quantity: 1}}, Reason: "defeito"},
+		{Reason: "defeito"},
+		{Amount: -1500, Reason: "frete"},
+		{Lines: []domain.RefundLine{{Quantity: 1}}, Reason: "defeito"},
+		{Lines: []domain.RefundLine{{SKU: "P7-M"}}, Reason: "defeito"},
+		{Lines: []domain.RefundLine{{SKU: "P7-M", Quantity: 1}, {SKU: "P7-M", Quantity: 1}}, Reason: "defeito"},
+	} {
+		isValid, message := NewRefundRequestValidator().Validate(context.Background(), &r)
+
+		assert.False(t, bool(isValid))
+		assert.NotEmpty(t, message)
+	}
+}
+
+func TestValidateRefundRequest(t *testing.T) {
+	for _, r := range []domain.RefundRequest{
+		{Full: true, Reason: "desistência"},
+		{Amount: 1500, Reason: "frete"},
+		{Lines: []domain.RefundLine{{SKU: "P7-M", Quantity: 1}, {SKU: "P7-G", Quantity: 2}}, Amount: 1500, Reason: "defeito"},
+	} {
+		isValid, message := NewRefundRequestValidator().Validate(context.Background(), &r)
+
+		assert.True(t, bool(isValid))
+		assert.Empty(t, message)
+	}
+}
@@ -0,0 +1,105 @@
+package presentation
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+const maxPictureSize = 10 << 20
+
+var pictureContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+type pictureAdminHandler struct {
+	PictureUseCase domain.PictureUseCase
+}
+
+func NewPictureAdminHandler(e *echo.Echo, puc domain.PictureUseCase, ts domain.TokenService) *pictureAdminHandler {
+	handler := &pictureAdminHandler{
+		PictureUseCase: puc,
+	}
+
+	admin := _tokenPresentation.NewAdminMiddleware(ts)
+
+	e.POST("/admin/products/:uuid/pictures", handler.Upload, admin)
+
+	return handler
+}
+
+func (pah *pictureAdminHandler) Upload(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	file, err := c.FormFile("picture")
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, "the picture file must be sent in the picture field of a multipart form")
+	}
+
+	if file.Size > maxPictureSize {
+		return c.JSON(http.StatusRequestEntityTooLarge, "picture can not be larger than 10MB")
+	}
+
+	if !pictureContentTypes[file.Header.Get("Content-Type")] {
+		return c.JSON(http.StatusUnsupportedMediaType, "picture must be a jpeg, png or gif image")
+	}
+
+	f, err := file.Open()
+
+	if err != nil {
+		log.Printf("Error trying to open an uploaded picture: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to read the picture")
+	}
+
+	defer f.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(f, maxPictureSize+1))
+
+	if err != nil {
+		log.Printf("Error trying to read an uploaded picture: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to read the picture")
+	}
+
+	if len(data) > maxPictureSize {
+		return c.JSON(http.StatusRequestEntityTooLarge, "picture can not be larger than 10MB")
+	}
+
+	picture, err := pah.PictureUseCase.Upload(c.Request().Context(), uuid, data)
+
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	if errors.Is(err, domain.ErrUnsupportedImage) {
+		return c.JSON(http.StatusUnsupportedMediaType, "picture must be a jpeg, png or gif image")
+	}
+
+	if errors.Is(err, domain.ErrImageTooLarge) {
+		return c.JSON(http.StatusRequestEntityTooLarge, "picture can not be larger than 10000 pixels of side or 24 megapixels")
+	}
+
+	if errors.Is(err, domain.ErrVersionConflict) {
+		return c.JSON(http.StatusConflict, "the product was changed in the meantime, try again")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to upload a picture: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to upload the picture")
+	}
+
+	return c.JSON(http.StatusCreated, picture)
+}
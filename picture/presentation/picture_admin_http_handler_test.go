@@ -0,0 +1,144 @@
+package presentation
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func multipartPicture(t *testing.T, contentType string, data []byte) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="picture"; filename="picture"`)
+	header.Set("Content-Type", contentType)
+
+	part, err := w.CreatePart(header)
+	assert.NoError(t, err)
+
+	_, err = part.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+
+	return body, w.FormDataContentType()
+}
+
+func TestUploadMissingFile(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/products/:uuid/pictures", strings.NewReader("{}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	handler := NewPictureAdminHandler(echo.New(), nil, nil)
+
+	handler.Upload(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestUploadContentType(t *testing.T) {
+	body, contentType := multipartPicture(t, "image/svg+xml", []byte("<svg></svg>"))
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/products/:uuid/pictures", body)
+	assert.NoError(t, err)
+	req.Header.Add("content-type", contentType)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	handler := NewPictureAdminHandler(echo.New(), nil, nil)
+
+	handler.Upload(c)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+}
+
+func TestUploadTooLarge(t *testing.T) {
+	body, contentType := multipartPicture(t, "image/jpeg", make([]byte, maxPictureSize+1))
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/products/:uuid/pictures", body)
+	assert.NoError(t, err)
+	req.Header.Add("content-type", contentType)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	handler := NewPictureAdminHandler(echo.New(), nil, nil)
+
+	handler.Upload(c)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestUploadUnsupportedContent(t *testing.T) {
+	body, contentType := multipartPicture(t, "image/png", []byte("not a png"))
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/products/:uuid/pictures", body)
+	assert.NoError(t, err)
+	req.Header.Add("content-type", contentType)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	mockPictureUsecase := new(mocks.MockPictureUseCase)
+
+	mockPictureUsecase.On("Upload", mock.Anything, "testuuid", []byte("not a png")).Return(nil, domain.ErrUnsupportedImage)
+
+	handler := NewPictureAdminHandler(echo.New(), mockPictureUsecase, nil)
+
+	handler.Upload(c)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+}
+
+func TestUpload(t *testing.T) {
+	body, contentType := multipartPicture(t, "image/png", []byte("png"))
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/products/:uuid/pictures", body)
+	assert.NoError(t, err)
+	req.Header.Add("content-type", contentType)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	mockPictureUsecase := new(mocks.MockPictureUseCase)
+
+	picture := &domain.Picture{Path: "/pictures/products/testuuid/p.png", Width: 800, Height: 400, Thumbnails: map[string]string{"small": "/pictures/products/testuuid/p-small.jpg"}}
+
+	mockPictureUsecase.On("Upload", mock.Anything, "testuuid", []byte("png")).Return(picture, nil)
+
+	handler := NewPictureAdminHandler(echo.New(), mockPictureUsecase, nil)
+
+	handler.Upload(c)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "{\"path\":\"/pictures/products/testuuid/p.png\",\"width\":800,\"height\":400,\"thumbnails\":{\"small\":\"/pictures/products/testuuid/p-small.jpg\"}}\n", rec.Body.String())
+}
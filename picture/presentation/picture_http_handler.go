@@ -0,0 +1,42 @@
+package presentation
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/labstack/echo/v4"
+)
+
+type pictureHandler struct {
+	PictureUseCase domain.PictureUseCase
+}
+
+func NewPictureHandler(e *echo.Echo, puc domain.PictureUseCase) *pictureHandler {
+	handler := &pictureHandler{
+		PictureUseCase: puc,
+	}
+
+	e.GET("/pictures/*", handler.Get)
+
+	return handler
+}
+
+func (ph *pictureHandler) Get(c echo.Context) error {
+	blob, err := ph.PictureUseCase.Get(c.Request().Context(), c.Param("*"))
+
+	if err != nil {
+		log.Printf("Error trying to get a picture: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the picture")
+	}
+
+	if blob == nil {
+		return c.JSON(http.StatusNotFound, "picture not found")
+	}
+
+	// a key is never reused for other content
+	c.Response().Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+
+	return c.Blob(http.StatusOK, blob.ContentType, blob.Data)
+}
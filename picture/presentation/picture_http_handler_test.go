@@ -0,0 +1,59 @@
+package presentation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetPictureNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/pictures/*", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("*")
+	c.SetParamValues("products/uuid/picture.jpg")
+
+	mockPictureUsecase := new(mocks.MockPictureUseCase)
+
+	mockPictureUsecase.On("Get", mock.Anything, "products/uuid/picture.jpg").Return(nil, nil)
+
+	handler := NewPictureHandler(echo.New(), mockPictureUsecase)
+
+	handler.Get(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestGetPicture(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/pictures/*", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("*")
+	c.SetParamValues("products/uuid/picture.jpg")
+
+	mockPictureUsecase := new(mocks.MockPictureUseCase)
+
+	mockPictureUsecase.On("Get", mock.Anything, "products/uuid/picture.jpg").Return(&domain.Blob{Data: []byte("data"), ContentType: "image/jpeg"}, nil)
+
+	handler := NewPictureHandler(echo.New(), mockPictureUsecase)
+
+	handler.Get(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/jpeg", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "public, max-age=31536000, immutable", rec.Header().Get("Cache-Control"))
+	assert.Equal(t, "data", rec.Body.String())
+}
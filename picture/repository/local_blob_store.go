@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+var keyRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+(\.[a-zA-Z0-9_-]+)*(/[a-zA-Z0-9_-]+(\.[a-zA-Z0-9_-]+)*)*$`)
+
+func validKey(key string) bool {
+	return len(key) <= 500 && keyRegexp.MatchString(key)
+}
+
+type localBlobStore struct {
+	Root string
+}
+
+// NewLocalBlobStore keeps the blobs as files under root, their content type
+// comes from the extension of the key.
+func NewLocalBlobStore(root string) domain.BlobStore {
+	return &localBlobStore{Root: root}
+}
+
+func (lbs *localBlobStore) Put(ctx context.Context, key string, b *domain.Blob) error {
+	if !validKey(key) {
+		return fmt.Errorf("invalid blob key %q", key)
+	}
+
+	name := lbs.path(key)
+
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+
+	// written aside and renamed, so a reader never gets half a file
+	f, err := ioutil.TempFile(filepath.Dir(name), ".upload-*")
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(b.Data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := os.Rename(f.Name(), name); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return nil
+}
+
+func (lbs *localBlobStore) Get(ctx context.Context, key string) (*domain.Blob, error) {
+	if !validKey(key) {
+		return nil, nil
+	}
+
+	data, err := ioutil.ReadFile(lbs.path(key))
+
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return &domain.Blob{Data: data, ContentType: contentType}, nil
+}
+
+func (lbs *localBlobStore) Delete(ctx context.Context, key string) error {
+	if !validKey(key) {
+		return nil
+	}
+
+	err := os.Remove(lbs.path(key))
+
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (lbs *localBlobStore) path(key string) string {
+	return filepath.Join(lbs.Root, filepath.FromSlash(strings.TrimPrefix(path.Clean("/"+key), "/")))
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalPutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalBlobStore(t.TempDir())
+
+	err := store.Put(ctx, "products/uuid/picture.jpg", &domain.Blob{Data: []byte("data"), ContentType: "image/jpeg"})
+
+	assert.NoError(t, err)
+
+	blob, err := store.Get(ctx, "products/uuid/picture.jpg")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Blob{Data: []byte("data"), ContentType: "image/jpeg"}, blob)
+
+	assert.NoError(t, store.Delete(ctx, "products/uuid/picture.jpg"))
+	assert.NoError(t, store.Delete(ctx, "products/uuid/picture.jpg"))
+
+	blob, err = store.Get(ctx, "products/uuid/picture.jpg")
+
+	assert.NoError(t, err)
+	assert.Nil(t, blob)
+}
+
+func TestLocalInvalidKey(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalBlobStore(t.TempDir())
+
+	for _, key := range []string{"", "../picture.jpg", "products/../../picture.jpg", "/products/picture.jpg", "products//picture.jpg"} {
+		assert.Error(t, store.Put(ctx, key, &domain.Blob{Data: []byte("data")}))
+
+		blob, err := store.Get(ctx, key)
+
+		assert.NoError(t, err)
+		assert.Nil(t, blob)
+	}
+}
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type s3BlobStore struct {
+	Client    *http.Client
+	Endpoint  string
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+	now       func() time.Time
+}
+
+// NewS3BlobStore talks to any S3 compatible storage signing the requests with
+// AWS Signature Version 4. The bucket goes in the path of the endpoint, leave
+// it empty when the endpoint already points to the bucket.
+func NewS3BlobStore(endpoint string, bucket string, region string, accessKey string, secretKey string) domain.BlobStore {
+	return &s3BlobStore{
+		Client:    &http.Client{Timeout: 30 * time.Second},
+		Endpoint:  strings.TrimRight(endpoint, "/"),
+		Bucket:    bucket,
+		Region:    region,
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+		now:       time.Now,
+	}
+}
+
+func (sbs *s3BlobStore) Put(ctx context.Context, key string, b *domain.Blob) error {
+	if !validKey(key) {
+		return fmt.Errorf("invalid blob key %q", key)
+	}
+
+	res, err := sbs.do(ctx, http.MethodPut, key, b)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return responseError(http.MethodPut, key, res)
+	}
+
+	return nil
+}
+
+func (sbs *s3BlobStore) Get(ctx context.Context, key string) (*domain.Blob, error) {
+	if !validKey(key) {
+		return nil, nil
+	}
+
+	res, err := sbs.do(ctx, http.MethodGet, key, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseError(http.MethodGet, key, res)
+	}
+
+	data, err := ioutil.ReadAll(res.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Blob{Data: data, ContentType: res.Header.Get("Content-Type")}, nil
+}
+
+func (sbs *s3BlobStore) Delete(ctx context.Context, key string) error {
+	if !validKey(key) {
+		return nil
+	}
+
+	res, err := sbs.do(ctx, http.MethodDelete, key, nil)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNotFound {
+		return responseError(http.MethodDelete, key, res)
+	}
+
+	return nil
+}
+
+func (sbs *s3BlobStore) do(ctx context.Context, method string, key string, b *domain.Blob) (*http.Response, error) {
+	u := sbs.Endpoint + "/"
+
+	if sbs.Bucket != "" {
+		u += uriEncode(sbs.Bucket, false) + "/"
+	}
+
+	u += uriEncode(key, true)
+
+	var body []byte
+
+	if b != nil {
+		body = b.Data
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if b != nil {
+		req.Header.Set("Content-Type", b.ContentType)
+	}
+
+	payloadHash := sha256.Sum256(body)
+
+	signV4(req, hex.EncodeToString(payloadHash[:]), sbs.AccessKey, sbs.SecretKey, sbs.Region, sbs.now())
+
+	return sbs.Client.Do(req)
+}
+
+func responseError(method string, key string, res *http.Response) error {
+	body, _ := ioutil.ReadAll(io.LimitReader(res.Body, 512))
+
+	return fmt.Errorf("%s %s answered %d: %s", method, key, res.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// signV4 signs the request for the s3 service, covering the host and all the
+// headers already set in it.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func signV4(req *http.Request, payloadHash string, accessKey string, secretKey string, region string, t time.Time) {
+	amzDate := t.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+secretKey), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+accessKey+"/"+scope+",SignedHeaders="+signedHeaders+",Signature="+signature)
+}
+
+func canonicalQuery(values url.Values) string {
+	pairs := []string{}
+
+	for name, list := range values {
+		for _, value := range list {
+			pairs = append(pairs, uriEncode(name, false)+"="+uriEncode(value, false))
+		}
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode escapes all but the unreserved characters, as the signature
+// expects.
+func uriEncode(s string, keepSlash bool) string {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' || c == '_' || c == '.' || c == '~' || (keepSlash && c == '/') {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
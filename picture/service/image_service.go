@@ -0,0 +1,164 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"net/http"
+
+	// registers the decoders of the accepted formats
+	_ "image/gif"
+	_ "image/png"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_ "golang.org/x/image/webp"
+)
+
+const maxDimension = 10000
+const maxPixels = 24000000
+const thumbnailQuality = 85
+
+type thumbnailSize struct {
+	name  string
+	width int
+}
+
+// the thumbnails fit in a square of the given width, they are never larger
+// than the original
+var thumbnailSizes = []thumbnailSize{{"small", 160}, {"medium", 480}, {"large", 1080}}
+
+var extensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+	"image/webp": "webp",
+}
+
+type imageService struct{}
+
+func NewImageService() *imageService {
+	return &imageService{}
+}
+
+// Process checks the image by its content, not by the name or the declared
+// type, and generates its JPEG thumbnails. WebP is decoded but the thumbnails
+// stay JPEG: there is no lossy WebP encoder in pure Go, the lossless ones
+// give larger thumbnails than the JPEGs.
+func (is *imageService) Process(ctx context.Context, data []byte) (*domain.ProcessedImage, error) {
+	contentType := http.DetectContentType(data)
+	extension, ok := extensions[contentType]
+
+	if !ok {
+		return nil, domain.ErrUnsupportedImage
+	}
+
+	// the size is checked before decoding, a small file can declare a huge
+	// image that would take all the memory
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+
+	if err != nil {
+		return nil, domain.ErrUnsupportedImage
+	}
+
+	if config.Width > maxDimension || config.Height > maxDimension || config.Width*config.Height > maxPixels {
+		return nil, domain.ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+
+	if err != nil {
+		return nil, domain.ErrUnsupportedImage
+	}
+
+	// transparent pixels become white, JPEG has no alpha
+	src := image.NewRGBA(image.Rect(0, 0, config.Width, config.Height))
+	draw.Draw(src, src.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(src, src.Bounds(), img, img.Bounds().Min, draw.Over)
+
+	res := &domain.ProcessedImage{ContentType: contentType, Extension: extension, Width: config.Width, Height: config.Height}
+
+	for _, size := range thumbnailSizes {
+		width, height := fit(config.Width, config.Height, size.width)
+
+		var buf bytes.Buffer
+
+		if err := jpeg.Encode(&buf, resize(src, width, height), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+			return nil, err
+		}
+
+		res.Thumbnails = append(res.Thumbnails, domain.Thumbnail{Size: size.name, Width: width, Height: height, ContentType: "image/jpeg", Data: buf.Bytes()})
+	}
+
+	return res, nil
+}
+
+// fit scales the dimensions down to fit in a square of side max, keeping the
+// aspect ratio.
+func fit(width int, height int, max int) (int, int) {
+	if width <= max && height <= max {
+		return width, height
+	}
+
+	if width >= height {
+		h := height * max / width
+		if h < 1 {
+			h = 1
+		}
+
+		return max, h
+	}
+
+	w := width * max / height
+	if w < 1 {
+		w = 1
+	}
+
+	return w, max
+}
+
+// resize shrinks the image averaging the source pixels covered by each
+// destination pixel, src must start at the origin.
+func resize(src *image.RGBA, width int, height int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	srcWidth, srcHeight := src.Bounds().Dx(), src.Bounds().Dy()
+
+	for y := 0; y < height; y++ {
+		y0, y1 := y*srcHeight/height, (y+1)*srcHeight/height
+		if y1 == y0 {
+			y1 = y0 + 1
+		}
+
+		for x := 0; x < width; x++ {
+			x0, x1 := x*srcWidth/width, (x+1)*srcWidth/width
+			if x1 == x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, a, n uint64
+
+			for sy := y0; sy < y1; sy++ {
+				i := src.PixOffset(x0, sy)
+
+				for sx := x0; sx < x1; sx++ {
+					r += uint64(src.Pix[i])
+					g += uint64(src.Pix[i+1])
+					b += uint64(src.Pix[i+2])
+					a += uint64(src.Pix[i+3])
+					n++
+					i += 4
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func pngImage(t *testing.T, width int, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if x < width/2 {
+				img.Set(x, y, color.NRGBA{R: 255, A: 255})
+			} else {
+				img.Set(x, y, color.NRGBA{A: 0})
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+
+	return buf.Bytes()
+}
+
+func TestProcess(t *testing.T) {
+	processed, err := NewImageService().Process(context.Background(), pngImage(t, 800, 400))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", processed.ContentType)
+	assert.Equal(t, "png", processed.Extension)
+	assert.Equal(t, 800, processed.Width)
+	assert.Equal(t, 400, processed.Height)
+	assert.Len(t, processed.Thumbnails, 3)
+
+	small := processed.Thumbnails[0]
+
+	assert.Equal(t, "small", small.Size)
+	assert.Equal(t, "image/jpeg", small.ContentType)
+
+	img, err := jpeg.Decode(bytes.NewReader(small.Data))
+
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 160, 80), img.Bounds())
+
+	// the left half stays red and the transparent one becomes white
+	r, g, b, _ := img.At(20, 40).RGBA()
+	assert.True(t, r>>8 > 230 && g>>8 < 30 && b>>8 < 30)
+
+	r, g, b, _ = img.At(140, 40).RGBA()
+	assert.True(t, r>>8 > 230 && g>>8 > 230 && b>>8 > 230)
+
+	large := processed.Thumbnails[2]
+
+	assert.Equal(t, "large", large.Size)
+	assert.Equal(t, 800, large.Width)
+	assert.Equal(t, 400, large.Height)
+}
+
+// webpImage is a lossless WebP of a single color, each of its prefix codes
+// has a single symbol and the pixels take no bits.
+func webpImage(width int, height int, c color.NRGBA) []byte {
+	data := []byte{0x2f}
+
+	var bits uint64
+	var n uint
+
+	put := func(v uint64, size uint) {
+		bits |= v << n
+		n += size
+
+		for n >= 8 {
+			data = append(data, byte(bits))
+			bits >>= 8
+			n -= 8
+		}
+	}
+
+	put(uint64(width-1), 14)
+	put(uint64(height-1), 14)
+	put(1, 1) // alpha used
+	put(0, 3) // version
+	put(0, 1) // no transforms
+	put(0, 1) // no color cache
+	put(0, 1) // no meta prefix codes
+
+	// green, red, blue, alpha and distance, as simple codes of one 8 bit symbol
+	for _, symbol := range []uint8{c.G, c.R, c.B, c.A, 0} {
+		put(1, 1)
+		put(0, 1)
+		put(1, 1)
+		put(uint64(symbol), 8)
+	}
+
+	if n > 0 {
+		data = append(data, byte(bits))
+	}
+
+	if len(data)%2 == 1 {
+		data = append(data, 0)
+	}
+
+	chunk := append([]byte("VP8L\x00\x00\x00\x00"), data...)
+	binary.LittleEndian.PutUint32(chunk[4:], uint32(len(data)))
+
+	riff := append([]byte("RIFF\x00\x00\x00\x00WEBP"), chunk...)
+	binary.LittleEndian.PutUint32(riff[4:], uint32(len(riff)-8))
+
+	return riff
+}
+
+func TestProcessWebP(t *testing.T) {
+	processed, err := NewImageService().Process(context.Background(), webpImage(600, 300, color.NRGBA{B: 255, A: 255}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "image/webp", processed.ContentType)
+	assert.Equal(t, "webp", processed.Extension)
+	assert.Equal(t, 600, processed.Width)
+	assert.Equal(t, 300, processed.Height)
+	assert.Len(t, processed.Thumbnails, 3)
+
+	small := processed.Thumbnails[0]
+
+	assert.Equal(t, "image/jpeg", small.ContentType)
+
+	img, err := jpeg.Decode(bytes.NewReader(small.Data))
+
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 160, 80), img.Bounds())
+
+	r, g, b, _ := img.At(80, 40).RGBA()
+	assert.True(t, r>>8 < 30 && g>>8 < 30 && b>>8 > 230)
+}
+
+func TestProcessPortrait(t *testing.T) {
+	processed, err := NewImageService().Process(context.Background(), pngImage(t, 300, 600))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 80, processed.Thumbnails[0].Width)
+	assert.Equal(t, 160, processed.Thumbnails[0].Height)
+	assert.Equal(t, 240, processed.Thumbnails[1].Width)
+	assert.Equal(t, 480, processed.Thumbnails[1].Height)
+}
+
+func TestProcessUnsupported(t *testing.T) {
+	_, err := NewImageService().Process(context.Background(), []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>"))
+
+	assert.ErrorIs(t, err, domain.ErrUnsupportedImage)
+
+	data := pngImage(t, 10, 10)
+
+	_, err = NewImageService().Process(context.Background(), data[:60])
+
+	assert.ErrorIs(t, err, domain.ErrUnsupportedImage)
+}
+
+func TestProcessTooLarge(t *testing.T) {
+	data := pngImage(t, 1, 1)
+
+	// declares a 20000x20000 image in the header, fixing its checksum
+	binary.BigEndian.PutUint32(data[16:], 20000)
+	binary.BigEndian.PutUint32(data[20:], 20000)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+
+	_, err := NewImageService().Process(context.Background(), data)
+
+	assert.ErrorIs(t, err, domain.ErrImageTooLarge)
+}
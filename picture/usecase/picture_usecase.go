@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/google/uuid"
+)
+
+// picturesPath is where the pictures are served, followed by their key.
+const picturesPath = "/pictures/"
+
+type pictureUseCase struct {
+	blobStore    domain.BlobStore
+	imageService domain.ImageService
+	productRepo  domain.ProductRepository
+}
+
+func NewPictureUseCase(bs domain.BlobStore, is domain.ImageService, pr domain.ProductRepository) domain.PictureUseCase {
+	return &pictureUseCase{blobStore: bs, imageService: is, productRepo: pr}
+}
+
+// Upload stores the original and its thumbnails and adds the original to
+// the pictures of the product. The keys are new for each upload, so what is
+// stored never changes and can be cached forever.
+func (pu *pictureUseCase) Upload(ctx context.Context, productUUID string, data []byte) (*domain.Picture, error) {
+	product, err := pu.productRepo.GetByUUID(ctx, productUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil {
+		return nil, domain.ErrProductNotFound
+	}
+
+	processed, err := pu.imageService.Process(ctx, data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	base := fmt.Sprintf("products/%s/%s", product.UUID, uuid.NewString())
+	key := base + "." + processed.Extension
+
+	picture := &domain.Picture{Path: picturesPath + key, Width: processed.Width, Height: processed.Height, Thumbnails: map[string]string{}}
+	stored := []string{}
+
+	if err := pu.blobStore.Put(ctx, key, &domain.Blob{Data: data, ContentType: processed.ContentType}); err != nil {
+		return nil, err
+	}
+
+	stored = append(stored, key)
+
+	for _, thumbnail := range processed.Thumbnails {
+		thumbnailKey := base + "-" + thumbnail.Size + ".jpg"
+
+		if err := pu.blobStore.Put(ctx, thumbnailKey, &domain.Blob{Data: thumbnail.Data, ContentType: thumbnail.ContentType}); err != nil {
+			pu.remove(ctx, stored)
+			return nil, err
+		}
+
+		stored = append(stored, thumbnailKey)
+		picture.Thumbnails[thumbnail.Size] = picturesPath + thumbnailKey
+	}
+
+	product.Pictures = append(product.Pictures, picture.Path)
+
+	if err := pu.productRepo.Update(ctx, product); err != nil {
+		pu.remove(ctx, stored)
+		return nil, err
+	}
+
+	return picture, nil
+}
+
+// Get only gives the pictures of the products, the blob store keeps other
+// files, like the catalogue imports, that are not public.
+func (pu *pictureUseCase) Get(ctx context.Context, key string) (*domain.Blob, error) {
+	if !strings.HasPrefix(key, "products/") {
+		return nil, nil
+	}
+
+	return pu.blobStore.Get(ctx, key)
+}
+
+// remove is a best effort to not leave behind the files of a failed upload.
+func (pu *pictureUseCase) remove(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := pu.blobStore.Delete(ctx, key); err != nil {
+			log.Printf("Error trying to remove the picture %s: %s", key, err.Error())
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var processed = &domain.ProcessedImage{
+	ContentType: "image/png",
+	Extension:   "png",
+	Width:       800,
+	Height:      400,
+	Thumbnails: []domain.Thumbnail{
+		{Size: "small", Width: 160, Height: 80, ContentType: "image/jpeg", Data: []byte("small")},
+		{Size: "medium", Width: 480, Height: 240, ContentType: "image/jpeg", Data: []byte("medium")},
+	},
+}
+
+func TestUploadProductNotFound(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(nil, nil)
+
+	_, err := NewPictureUseCase(nil, nil, mockProductRepo).Upload(context.Background(), "uuid", []byte("data"))
+
+	assert.ErrorIs(t, err, domain.ErrProductNotFound)
+}
+
+func TestUploadUnsupported(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockImageService := new(mocks.MockImageService)
+	mockBlobStore := new(mocks.MockBlobStore)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7, UUID: "uuid"}, nil)
+	mockImageService.On("Process", mock.Anything, []byte("data")).Return(nil, domain.ErrUnsupportedImage)
+
+	_, err := NewPictureUseCase(mockBlobStore, mockImageService, mockProductRepo).Upload(context.Background(), "uuid", []byte("data"))
+
+	assert.ErrorIs(t, err, domain.ErrUnsupportedImage)
+	mockBlobStore.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpload(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockImageService := new(mocks.MockImageService)
+	mockBlobStore := new(mocks.MockBlobStore)
+
+	product := &domain.Product{ID: 7, UUID: "uuid", Pictures: []string{"camiseta.png"}, Version: 3}
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(product, nil)
+	mockImageService.On("Process", mock.Anything, []byte("data")).Return(processed, nil)
+	mockBlobStore.On("Put", mock.Anything, mock.MatchedBy(func(key string) bool {
+		return regexp.MustCompile(`^products/uuid/[0-9a-f-]{36}\.png$`).MatchString(key)
+	}), &domain.Blob{Data: []byte("data"), ContentType: "image/png"}).Return(nil)
+	mockBlobStore.On("Put", mock.Anything, mock.MatchedBy(func(key string) bool {
+		return regexp.MustCompile(`^products/uuid/[0-9a-f-]{36}-small\.jpg$`).MatchString(key)
+	}), &domain.Blob{Data: []byte("small"), ContentType: "image/jpeg"}).Return(nil)
+	mockBlobStore.On("Put", mock.Anything, mock.MatchedBy(func(key string) bool {
+		return regexp.MustCompile(`^products/uuid/[0-9a-f-]{36}-medium\.jpg$`).MatchString(key)
+	}), &domain.Blob{Data: []byte("medium"), ContentType: "image/jpeg"}).Return(nil)
+	mockProductRepo.On("Update", mock.Anything, product).Return(nil)
+
+	picture, err := NewPictureUseCase(mockBlobStore, mockImageService, mockProductRepo).Upload(context.Background(), "uuid", []byte("data"))
+
+	assert.NoError(t, err)
+	assert.Regexp(t, `^/pictures/products/uuid/[0-9a-f-]{36}\.png$`, picture.Path)
+	assert.Equal(t, 800, picture.Width)
+	assert.Equal(t, picture.Path[:len(picture.Path)-4]+"-small.jpg", picture.Thumbnails["small"])
+	assert.Equal(t, picture.Path[:len(picture.Path)-4]+"-medium.jpg", picture.Thumbnails["medium"])
+	assert.Equal(t, []string{"camiseta.png", picture.Path}, product.Pictures)
+	mockBlobStore.AssertNumberOfCalls(t, "Put", 3)
+}
+
+func TestUploadRemovesFilesOnConflict(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockImageService := new(mocks.MockImageService)
+	mockBlobStore := new(mocks.MockBlobStore)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7, UUID: "uuid"}, nil)
+	mockImageService.On("Process", mock.Anything, []byte("data")).Return(processed, nil)
+	mockBlobStore.On("Put", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockBlobStore.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	mockProductRepo.On("Update", mock.Anything, mock.Anything).Return(domain.ErrVersionConflict)
+
+	_, err := NewPictureUseCase(mockBlobStore, mockImageService, mockProductRepo).Upload(context.Background(), "uuid", []byte("data"))
+
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	mockBlobStore.AssertNumberOfCalls(t, "Delete", 3)
+}
+
+func TestUploadStoreError(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockImageService := new(mocks.MockImageService)
+	mockBlobStore := new(mocks.MockBlobStore)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7, UUID: "uuid"}, nil)
+	mockImageService.On("Process", mock.Anything, []byte("data")).Return(processed, nil)
+	mockBlobStore.On("Put", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("error message"))
+
+	_, err := NewPictureUseCase(mockBlobStore, mockImageService, mockProductRepo).Upload(context.Background(), "uuid", []byte("data"))
+
+	assert.Error(t, err)
+	mockProductRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestGetOnlyProductPictures(t *testing.T) {
+	mockBlobStore := new(mocks.MockBlobStore)
+
+	mockBlobStore.On("Get", mock.Anything, "products/uuid/picture.jpg").Return(&domain.Blob{Data: []byte("data"), ContentType: "image/jpeg"}, nil)
+
+	usecase := NewPictureUseCase(mockBlobStore, nil, nil)
+
+	blob, err := usecase.Get(context.Background(), "products/uuid/picture.jpg")
+
+	assert.NoError(t, err)
+	assert.NotNil(t, blob)
+
+	blob, err = usecase.Get(context.Background(), "imports/uuid.csv")
+
+	assert.NoError(t, err)
+	assert.Nil(t, blob)
+	mockBlobStore.AssertNumberOfCalls(t, "Get", 1)
+}
@@ -0,0 +1,196 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type priceAdminHandler struct {
+	PriceUseCase       domain.PriceUseCase
+	PriceValidator     domain.PriceValidator
+	PriceListValidator domain.PriceListValidator
+}
+
+type customerGroupsRequest struct {
+	Groups []string `json:"groups"`
+}
+
+func NewPriceAdminHandler(e *echo.Echo, puc domain.PriceUseCase, pv domain.PriceValidator, plv domain.PriceListValidator, ts domain.TokenService) *priceAdminHandler {
+	handler := &priceAdminHandler{
+		PriceUseCase:       puc,
+		PriceValidator:     pv,
+		PriceListValidator: plv,
+	}
+
+	admin := _tokenPresentation.NewAdminMiddleware(ts)
+
+	e.GET("/admin/products/:uuid/prices", handler.GetPrices, admin)
+	e.POST("/admin/products/:uuid/prices", handler.AddPrice, admin)
+	e.DELETE("/admin/products/:uuid/prices/:id", handler.DeletePrice, admin)
+	e.POST("/admin/price-lists", handler.CreatePriceList, admin)
+	e.PUT("/admin/users/:uuid/groups", handler.SetCustomerGroups, admin)
+
+	return handler
+}
+
+func (pah *priceAdminHandler) GetPrices(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	prices, err := pah.PriceUseCase.GetPrices(c.Request().Context(), uuid)
+
+	if err != nil {
+		log.Printf("Error trying to get the prices of a product: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the prices of the product")
+	}
+
+	if prices == nil {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"prices": prices})
+}
+
+func (pah *priceAdminHandler) AddPrice(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	price := domain.ProductPrice{MinQuantity: 1}
+
+	if err := c.Bind(&price); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	ctx := c.Request().Context()
+
+	isValid, message := pah.PriceValidator.Validate(ctx, &price)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	err := pah.PriceUseCase.AddPrice(ctx, uuid, &price)
+
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	if errors.Is(err, domain.ErrVariantNotFound) {
+		return c.JSON(http.StatusBadRequest, "the variant is not of this product")
+	}
+
+	if errors.Is(err, domain.ErrPriceListNotFound) {
+		return c.JSON(http.StatusBadRequest, "price list not found")
+	}
+
+	if errors.Is(err, domain.ErrCurrencyMismatch) {
+		return c.JSON(http.StatusBadRequest, "the currency must be the one of the price list")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to add a price: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to add the price")
+	}
+
+	return c.JSON(http.StatusCreated, price)
+}
+
+func (pah *priceAdminHandler) DeletePrice(c echo.Context) error {
+	uuid := c.Param("uuid")
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+
+	if uuid == "" || err != nil {
+		return c.JSON(http.StatusBadRequest, "uuid or id param is not valid")
+	}
+
+	err = pah.PriceUseCase.DeletePrice(c.Request().Context(), uuid, id)
+
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	if errors.Is(err, domain.ErrPriceNotFound) {
+		return c.JSON(http.StatusNotFound, "price not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to delete a price: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to delete the price")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (pah *priceAdminHandler) CreatePriceList(c echo.Context) error {
+	var pl domain.PriceList
+
+	if err := c.Bind(&pl); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	ctx := c.Request().Context()
+
+	isValid, message := pah.PriceListValidator.Validate(ctx, &pl)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	err := pah.PriceUseCase.CreatePriceList(ctx, &pl)
+
+	if errors.Is(err, domain.ErrPriceListCodeTaken) {
+		return c.JSON(http.StatusConflict, "price list code already in use")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to create a price list: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to create the price list")
+	}
+
+	return c.JSON(http.StatusCreated, pl)
+}
+
+func (pah *priceAdminHandler) SetCustomerGroups(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	var req customerGroupsRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	for _, group := range req.Groups {
+		if group == "" || len(group) > 50 {
+			return c.JSON(http.StatusBadRequest, "customer groups must have from 1 to 50 characters")
+		}
+	}
+
+	err := pah.PriceUseCase.SetCustomerGroups(c.Request().Context(), uuid, req.Groups)
+
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, "user not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to set the customer groups of a user: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to set the customer groups")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
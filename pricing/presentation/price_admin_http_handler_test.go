@@ -0,0 +1,213 @@
+package presentation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetPricesNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin/products/:uuid/prices", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	mockPriceUsecase := new(mocks.MockPriceUseCase)
+
+	mockPriceUsecase.On("GetPrices", mock.Anything, "testuuid").Return(nil, nil)
+
+	handler := NewPriceAdminHandler(echo.New(), mockPriceUsecase, nil, nil, nil)
+
+	handler.GetPrices(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAddPriceInvalid(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/products/:uuid/prices", strings.NewReader("{\"kind\":\"promo\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	mockPriceValidator := new(mocks.MockPriceValidator)
+
+	mockPriceValidator.On("Validate", mock.Anything, &domain.ProductPrice{Kind: "promo", MinQuantity: 1}).Return(false, "error message")
+
+	handler := NewPriceAdminHandler(echo.New(), nil, mockPriceValidator, nil, nil)
+
+	handler.AddPrice(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "\"error message\"\n", rec.Body.String())
+}
+
+func TestAddPriceListNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/products/:uuid/prices", strings.NewReader("{\"kind\":\"list\",\"priceList\":\"atacado\",\"amount\":{\"amount\":3990,\"currency\":\"BRL\"}}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	mockPriceUsecase := new(mocks.MockPriceUseCase)
+	mockPriceValidator := new(mocks.MockPriceValidator)
+
+	mockPriceValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockPriceUsecase.On("AddPrice", mock.Anything, "testuuid", mock.Anything).Return(domain.ErrPriceListNotFound)
+
+	handler := NewPriceAdminHandler(echo.New(), mockPriceUsecase, mockPriceValidator, nil, nil)
+
+	handler.AddPrice(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestAddPrice(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/products/:uuid/prices", strings.NewReader("{\"kind\":\"sale\",\"amount\":{\"amount\":3990,\"currency\":\"BRL\"},\"endsAt\":\"2026-11-30T00:00:00Z\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	endsAt := time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC)
+	price := &domain.ProductPrice{Kind: domain.PriceKindSale, Amount: domain.Money{Amount: 3990, Currency: domain.CurrencyBRL}, MinQuantity: 1, EndsAt: &endsAt}
+
+	mockPriceUsecase := new(mocks.MockPriceUseCase)
+	mockPriceValidator := new(mocks.MockPriceValidator)
+
+	mockPriceValidator.On("Validate", mock.Anything, price).Return(true, "")
+	mockPriceUsecase.On("AddPrice", mock.Anything, "testuuid", price).Return(nil)
+
+	handler := NewPriceAdminHandler(echo.New(), mockPriceUsecase, mockPriceValidator, nil, nil)
+
+	handler.AddPrice(c)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	mockPriceUsecase.AssertExpectations(t)
+}
+
+func TestDeletePriceInvalidID(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/admin/products/:uuid/prices/:id", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid", "id")
+	c.SetParamValues("testuuid", "abc")
+
+	handler := NewPriceAdminHandler(echo.New(), nil, nil, nil, nil)
+
+	handler.DeletePrice(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestDeletePriceNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/admin/products/:uuid/prices/:id", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid", "id")
+	c.SetParamValues("testuuid", "5")
+
+	mockPriceUsecase := new(mocks.MockPriceUseCase)
+
+	mockPriceUsecase.On("DeletePrice", mock.Anything, "testuuid", int64(5)).Return(domain.ErrPriceNotFound)
+
+	handler := NewPriceAdminHandler(echo.New(), mockPriceUsecase, nil, nil, nil)
+
+	handler.DeletePrice(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestCreatePriceListCodeTaken(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/price-lists", strings.NewReader("{\"code\":\"atacado\",\"name\":\"Atacado\",\"currency\":\"BRL\",\"customerGroup\":\"lojistas\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	pl := &domain.PriceList{Code: "atacado", Name: "Atacado", Currency: domain.CurrencyBRL, CustomerGroup: "lojistas"}
+
+	mockPriceUsecase := new(mocks.MockPriceUseCase)
+	mockPriceListValidator := new(mocks.MockPriceListValidator)
+
+	mockPriceListValidator.On("Validate", mock.Anything, pl).Return(true, "")
+	mockPriceUsecase.On("CreatePriceList", mock.Anything, pl).Return(domain.ErrPriceListCodeTaken)
+
+	handler := NewPriceAdminHandler(echo.New(), mockPriceUsecase, nil, mockPriceListValidator, nil)
+
+	handler.CreatePriceList(c)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestSetCustomerGroupsInvalid(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/users/:uuid/groups", strings.NewReader("{\"groups\":[\"\"]}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("useruuid")
+
+	handler := NewPriceAdminHandler(echo.New(), nil, nil, nil, nil)
+
+	handler.SetCustomerGroups(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSetCustomerGroups(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/users/:uuid/groups", strings.NewReader("{\"groups\":[\"lojistas\"]}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("useruuid")
+
+	mockPriceUsecase := new(mocks.MockPriceUseCase)
+
+	mockPriceUsecase.On("SetCustomerGroups", mock.Anything, "useruuid", []string{"lojistas"}).Return(nil)
+
+	handler := NewPriceAdminHandler(echo.New(), mockPriceUsecase, nil, nil, nil)
+
+	handler.SetCustomerGroups(c)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
@@ -0,0 +1,81 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type priceHandler struct {
+	PriceUseCase domain.PriceUseCase
+}
+
+func NewPriceHandler(e *echo.Echo, puc domain.PriceUseCase, ts domain.TokenService) *priceHandler {
+	handler := &priceHandler{
+		PriceUseCase: puc,
+	}
+
+	optionalAuth := _tokenPresentation.NewOptionalAuthMiddleware(ts)
+
+	e.GET("/products/:uuid/price", handler.Quote, optionalAuth)
+
+	return handler
+}
+
+func (ph *priceHandler) Quote(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	quantity := int64(1)
+
+	if s := c.QueryParam("quantity"); s != "" {
+		q, err := strconv.ParseInt(s, 10, 64)
+
+		if err != nil || q < 1 || q > 10000 {
+			return c.JSON(http.StatusBadRequest, "quantity param must be between 1 and 10000")
+		}
+
+		quantity = q
+	}
+
+	currency := domain.Currency(c.QueryParam("currency"))
+
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+
+	var login string
+
+	if tokenInfo := _tokenPresentation.TokenInfoFromContext(c); tokenInfo != nil {
+		login = tokenInfo.Info
+	}
+
+	price, err := ph.PriceUseCase.Quote(c.Request().Context(), uuid, c.QueryParam("sku"), quantity, currency, login)
+
+	if errors.Is(err, domain.ErrVariantNotFound) {
+		return c.JSON(http.StatusNotFound, "variant not found")
+	}
+
+	if errors.Is(err, domain.ErrNoPrice) {
+		return c.JSON(http.StatusNotFound, "the product has no price in this currency")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to get the price of a product: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the price of the product")
+	}
+
+	if price == nil {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	return c.JSON(http.StatusOK, price)
+}
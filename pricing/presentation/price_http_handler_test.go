@@ -0,0 +1,99 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestQuoteInvalidQuantity(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/:uuid/price?quantity=0", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	handler := NewPriceHandler(echo.New(), nil, nil)
+
+	handler.Quote(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestQuoteNoPrice(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/:uuid/price?currency=USD", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	mockPriceUsecase := new(mocks.MockPriceUseCase)
+
+	mockPriceUsecase.On("Quote", mock.Anything, "testuuid", "", int64(1), domain.Currency("USD"), "").Return(nil, domain.ErrNoPrice)
+
+	handler := NewPriceHandler(echo.New(), mockPriceUsecase, nil)
+
+	handler.Quote(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestQuoteError(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/:uuid/price", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	mockPriceUsecase := new(mocks.MockPriceUseCase)
+
+	mockPriceUsecase.On("Quote", mock.Anything, "testuuid", "", int64(1), domain.CurrencyBRL, "").Return(nil, errors.New("error message"))
+
+	handler := NewPriceHandler(echo.New(), mockPriceUsecase, nil)
+
+	handler.Quote(c)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestQuote(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/:uuid/price?sku=P7-M&quantity=2", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockPriceUsecase := new(mocks.MockPriceUseCase)
+
+	brl := func(amount int64) domain.Money { return domain.Money{Amount: amount, Currency: domain.CurrencyBRL} }
+
+	mockPriceUsecase.On("Quote", mock.Anything, "testuuid", "P7-M", int64(2), domain.CurrencyBRL, "user@test.com").Return(&domain.EffectivePrice{Unit: brl(3990), List: brl(4990), Total: brl(7980), Quantity: 2, OnSale: true}, nil)
+
+	handler := NewPriceHandler(echo.New(), mockPriceUsecase, nil)
+
+	handler.Quote(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"unit\":{\"amount\":3990,\"currency\":\"BRL\"},\"list\":{\"amount\":4990,\"currency\":\"BRL\"},\"total\":{\"amount\":7980,\"currency\":\"BRL\"},\"quantity\":2,\"onSale\":true}\n", rec.Body.String())
+}
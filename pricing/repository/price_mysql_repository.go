@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type priceMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewPriceMysqlRepository(conn *sql.DB) domain.PriceRepository {
+	return &priceMysqlRepository{Conn: conn}
+}
+
+func (pmr *priceMysqlRepository) GetByProductID(ctx context.Context, productID int64) ([]domain.ProductPrice, error) {
+	query := `SELECT pp.id, pp.product_id, pp.variant_sku, pl.code, pp.kind, pp.amount, pp.currency, pp.min_quantity, pp.starts_at, pp.ends_at FROM product_price pp LEFT JOIN price_list pl ON pl.id = pp.price_list_id WHERE pp.product_id = ? ORDER BY pp.id;`
+
+	rows, err := pmr.Conn.QueryContext(ctx, query, productID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []domain.ProductPrice{}
+
+	for rows.Next() {
+		var p domain.ProductPrice
+		var variantSKU, priceList sql.NullString
+		var startsAt, endsAt sql.NullString
+
+		if err := rows.Scan(&p.ID, &p.ProductID, &variantSKU, &priceList, &p.Kind, &p.Amount.Amount, &p.Amount.Currency, &p.MinQuantity, &startsAt, &endsAt); err != nil {
+			return nil, err
+		}
+
+		p.VariantSKU = variantSKU.String
+		p.PriceList = priceList.String
+
+		if p.StartsAt, err = parseDatetime(startsAt); err != nil {
+			return nil, err
+		}
+
+		if p.EndsAt, err = parseDatetime(endsAt); err != nil {
+			return nil, err
+		}
+
+		res = append(res, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (pmr *priceMysqlRepository) Store(ctx context.Context, p *domain.ProductPrice) error {
+	query := `INSERT INTO product_price (product_id, variant_sku, price_list_id, kind, amount, currency, min_quantity, starts_at, ends_at) VALUES (?, ?, (SELECT id FROM price_list WHERE code = ?), ?, ?, ?, ?, ?, ?);`
+
+	exec, err := pmr.Conn.ExecContext(ctx, query, p.ProductID, nullString(p.VariantSKU), nullString(p.PriceList), p.Kind, p.Amount.Amount, p.Amount.Currency, p.MinQuantity, nullTime(p.StartsAt), nullTime(p.EndsAt))
+
+	if err != nil {
+		return err
+	}
+
+	p.ID, err = exec.LastInsertId()
+
+	return err
+}
+
+func (pmr *priceMysqlRepository) Delete(ctx context.Context, productID int64, id int64) error {
+	exec, err := pmr.Conn.ExecContext(ctx, `DELETE FROM product_price WHERE id = ? AND product_id = ?;`, id, productID)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return domain.ErrPriceNotFound
+	}
+
+	return nil
+}
+
+func (pmr *priceMysqlRepository) GetPriceListByCode(ctx context.Context, code string) (*domain.PriceList, error) {
+	row := pmr.Conn.QueryRowContext(ctx, `SELECT id, code, name, currency, customer_group FROM price_list WHERE code = ?;`, code)
+
+	var res domain.PriceList
+
+	if err := row.Scan(&res.ID, &res.Code, &res.Name, &res.Currency, &res.CustomerGroup); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (pmr *priceMysqlRepository) StorePriceList(ctx context.Context, pl *domain.PriceList) error {
+	exec, err := pmr.Conn.ExecContext(ctx, `INSERT INTO price_list (code, name, currency, customer_group) VALUES (?, ?, ?, ?);`, pl.Code, pl.Name, pl.Currency, pl.CustomerGroup)
+
+	if err != nil {
+		return err
+	}
+
+	pl.ID, err = exec.LastInsertId()
+
+	return err
+}
+
+func (pmr *priceMysqlRepository) GetPriceListCodesByUserID(ctx context.Context, userID int64) ([]string, error) {
+	query := `SELECT pl.code FROM price_list pl JOIN customer_group_member cgm ON cgm.customer_group = pl.customer_group WHERE cgm.user_id = ? ORDER BY pl.code;`
+
+	rows, err := pmr.Conn.QueryContext(ctx, query, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []string{}
+
+	for rows.Next() {
+		var code string
+
+		if err := rows.Scan(&code); err != nil {
+			return nil, err
+		}
+
+		res = append(res, code)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (pmr *priceMysqlRepository) SetCustomerGroups(ctx context.Context, userID int64, groups []string) error {
+	tx, err := pmr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM customer_group_member WHERE user_id = ?;`, userID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, group := range groups {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO customer_group_member (user_id, customer_group) VALUES (?, ?);`, userID, group); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// parseDatetime reads a DATETIME column, which the connection gives as text
+// in UTC.
+func parseDatetime(s sql.NullString) (*time.Time, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+
+	t, err := time.Parse("2006-01-02 15:04:05", s.String)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: *t, Valid: true}
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetByProductID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	startsAt := time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC)
+	endsAt := time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"id", "product_id", "variant_sku", "code", "kind", "amount", "currency", "min_quantity", "starts_at", "ends_at"}).
+		AddRow(1, 7, nil, nil, "list", 4990, "BRL", 1, nil, nil).
+		AddRow(2, 7, "P7-M", "atacado", "sale", 3990, "BRL", 10, "2026-11-27 00:00:00", "2026-11-30 00:00:00")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT pp.id, pp.product_id, pp.variant_sku, pl.code, pp.kind, pp.amount, pp.currency, pp.min_quantity, pp.starts_at, pp.ends_at FROM product_price pp LEFT JOIN price_list pl ON pl.id = pp.price_list_id WHERE pp.product_id = ? ORDER BY pp.id;")).
+		WithArgs(7).
+		WillReturnRows(rows)
+
+	prices, err := NewPriceMysqlRepository(db).GetByProductID(context.Background(), 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.ProductPrice{
+		{ID: 1, ProductID: 7, Kind: domain.PriceKindList, Amount: domain.Money{Amount: 4990, Currency: domain.CurrencyBRL}, MinQuantity: 1},
+		{ID: 2, ProductID: 7, VariantSKU: "P7-M", PriceList: "atacado", Kind: domain.PriceKindSale, Amount: domain.Money{Amount: 3990, Currency: domain.CurrencyBRL}, MinQuantity: 10, StartsAt: &startsAt, EndsAt: &endsAt},
+	}, prices)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetByProductIDError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT pp.id, pp.product_id")).WillReturnError(errors.New("error message"))
+
+	_, err = NewPriceMysqlRepository(db).GetByProductID(context.Background(), 7)
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	endsAt := time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC)
+
+	p := &domain.ProductPrice{ProductID: 7, Kind: domain.PriceKindSale, Amount: domain.Money{Amount: 3990, Currency: domain.CurrencyBRL}, MinQuantity: 1, EndsAt: &endsAt}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_price (product_id, variant_sku, price_list_id, kind, amount, currency, min_quantity, starts_at, ends_at) VALUES (?, ?, (SELECT id FROM price_list WHERE code = ?), ?, ?, ?, ?, ?, ?);")).
+		WithArgs(7, sql.NullString{}, sql.NullString{}, "sale", 3990, "BRL", 1, sql.NullTime{}, sql.NullTime{Time: endsAt, Valid: true}).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+
+	err = NewPriceMysqlRepository(db).Store(context.Background(), p)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), p.ID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDeleteNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product_price WHERE id = ? AND product_id = ?;")).
+		WithArgs(5, 7).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = NewPriceMysqlRepository(db).Delete(context.Background(), 7, 5)
+
+	assert.ErrorIs(t, err, domain.ErrPriceNotFound)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetPriceListByCodeNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "code", "name", "currency", "customer_group"})
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, code, name, currency, customer_group FROM price_list WHERE code = ?;")).WithArgs("atacado").WillReturnRows(rows)
+
+	pl, err := NewPriceMysqlRepository(db).GetPriceListByCode(context.Background(), "atacado")
+
+	assert.NoError(t, err)
+	assert.Nil(t, pl)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStorePriceList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO price_list (code, name, currency, customer_group) VALUES (?, ?, ?, ?);")).
+		WithArgs("atacado", "Atacado", "BRL", "lojistas").
+		WillReturnResult(sqlmock.NewResult(2, 1))
+
+	pl := &domain.PriceList{Code: "atacado", Name: "Atacado", Currency: domain.CurrencyBRL, CustomerGroup: "lojistas"}
+
+	err = NewPriceMysqlRepository(db).StorePriceList(context.Background(), pl)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), pl.ID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetPriceListCodesByUserID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"code"}).AddRow("atacado").AddRow("vip")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT pl.code FROM price_list pl JOIN customer_group_member cgm ON cgm.customer_group = pl.customer_group WHERE cgm.user_id = ? ORDER BY pl.code;")).WithArgs(3).WillReturnRows(rows)
+
+	codes, err := NewPriceMysqlRepository(db).GetPriceListCodesByUserID(context.Background(), 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"atacado", "vip"}, codes)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetCustomerGroups(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM customer_group_member WHERE user_id = ?;")).WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO customer_group_member (user_id, customer_group) VALUES (?, ?);")).WithArgs(3, "lojistas").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = NewPriceMysqlRepository(db).SetCustomerGroups(context.Background(), 3, []string{"lojistas"})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetCustomerGroupsError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM customer_group_member WHERE user_id = ?;")).WithArgs(3).WillReturnError(errors.New("error message"))
+	mock.ExpectRollback()
+
+	err = NewPriceMysqlRepository(db).SetCustomerGroups(context.Background(), 3, []string{"lojistas"})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
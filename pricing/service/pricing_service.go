@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type pricingService struct {
+	priceRepo domain.PriceRepository
+}
+
+func NewPricingService(pr domain.PriceRepository) *pricingService {
+	return &pricingService{priceRepo: pr}
+}
+
+// Resolve gives the customer the lowest of the prices that apply to them. The
+// regular price is the public list price of a single unit, the one of the
+// variant replacing the one of the product, and without one the price of the
+// variant or of the product, which are in the default currency. The sales,
+// the price lists and the tiers apply over it.
+func (ps *pricingService) Resolve(ctx context.Context, r domain.PriceRequest) (*domain.EffectivePrice, error) {
+	quantity := r.Quantity
+	if quantity < 1 {
+		quantity = 1
+	}
+
+	currency := r.Currency
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+
+	lists := map[string]bool{"": true}
+
+	if r.UserID != 0 {
+		codes, err := ps.priceRepo.GetPriceListCodesByUserID(ctx, r.UserID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, code := range codes {
+			lists[code] = true
+		}
+	}
+
+	prices, err := ps.priceRepo.GetByProductID(ctx, r.Product.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var list *domain.Money
+	listRule, listVariantRule := false, false
+
+	if currency == domain.DefaultCurrency {
+		base := r.Product.Price
+		if r.Variant != nil {
+			base = r.Variant.Price
+		}
+
+		list = &domain.Money{Amount: base, Currency: currency}
+	}
+
+	applicable := []*domain.ProductPrice{}
+
+	for i := range prices {
+		p := &prices[i]
+
+		if !applies(p, r, lists, quantity, currency) {
+			continue
+		}
+
+		applicable = append(applicable, p)
+
+		if publicList(p) && (!listRule || (p.VariantSKU != "" && !listVariantRule)) {
+			list = &p.Amount
+			listRule, listVariantRule = true, p.VariantSKU != ""
+		}
+	}
+
+	var best *domain.ProductPrice
+
+	for _, p := range applicable {
+		// the list price of the variant replaces the one of the product
+		if listVariantRule && p.VariantSKU == "" && publicList(p) {
+			continue
+		}
+
+		if best == nil || p.Amount.Amount < best.Amount.Amount {
+			best = p
+		}
+	}
+
+	if list == nil && best == nil {
+		return nil, domain.ErrNoPrice
+	}
+
+	if list == nil {
+		list = &best.Amount
+	}
+
+	res := &domain.EffectivePrice{Unit: *list, List: *list, Quantity: quantity}
+
+	if best != nil && best.Amount.Amount < list.Amount {
+		res.Unit = best.Amount
+		res.PriceList = best.PriceList
+		res.EndsAt = best.EndsAt
+		res.OnSale = true
+	}
+
+	res.Total = domain.Money{Amount: res.Unit.Amount * quantity, Currency: currency}
+
+	return res, nil
+}
+
+func applies(p *domain.ProductPrice, r domain.PriceRequest, lists map[string]bool, quantity int64, currency domain.Currency) bool {
+	if p.Amount.Currency != currency || !lists[p.PriceList] || p.MinQuantity > quantity {
+		return false
+	}
+
+	if p.VariantSKU != "" && (r.Variant == nil || r.Variant.SKU != p.VariantSKU) {
+		return false
+	}
+
+	if p.StartsAt != nil && r.At.Before(*p.StartsAt) {
+		return false
+	}
+
+	if p.EndsAt != nil && !r.At.Before(*p.EndsAt) {
+		return false
+	}
+
+	return true
+}
+
+// publicList is the list price of a single unit for every customer.
+func publicList(p *domain.ProductPrice) bool {
+	return p.Kind == domain.PriceKindList && p.PriceList == "" && p.MinQuantity <= 1
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func brl(amount int64) domain.Money {
+	return domain.Money{Amount: amount, Currency: domain.CurrencyBRL}
+}
+
+func TestResolveFallbackToProductPrice(t *testing.T) {
+	mockPriceRepo := new(mocks.MockPriceRepository)
+
+	mockPriceRepo.On("GetByProductID", mock.Anything, int64(7)).Return([]domain.ProductPrice{}, nil)
+
+	price, err := NewPricingService(mockPriceRepo).Resolve(context.Background(), domain.PriceRequest{Product: &domain.Product{ID: 7, Price: 4990}, Quantity: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.EffectivePrice{Unit: brl(4990), List: brl(4990), Total: brl(9980), Quantity: 2}, price)
+	mockPriceRepo.AssertNotCalled(t, "GetPriceListCodesByUserID", mock.Anything, mock.Anything)
+}
+
+func TestResolveFallbackToVariantPrice(t *testing.T) {
+	mockPriceRepo := new(mocks.MockPriceRepository)
+
+	mockPriceRepo.On("GetByProductID", mock.Anything, int64(7)).Return([]domain.ProductPrice{}, nil)
+
+	price, err := NewPricingService(mockPriceRepo).Resolve(context.Background(), domain.PriceRequest{Product: &domain.Product{ID: 7, Price: 4990}, Variant: &domain.Variant{SKU: "P7-GG", Price: 5490}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, brl(5490), price.Unit)
+	assert.Equal(t, int64(1), price.Quantity)
+}
+
+func TestResolveNoPriceInCurrency(t *testing.T) {
+	mockPriceRepo := new(mocks.MockPriceRepository)
+
+	mockPriceRepo.On("GetByProductID", mock.Anything, int64(7)).Return([]domain.ProductPrice{}, nil)
+
+	_, err := NewPricingService(mockPriceRepo).Resolve(context.Background(), domain.PriceRequest{Product: &domain.Product{ID: 7, Price: 4990}, Currency: "USD"})
+
+	assert.ErrorIs(t, err, domain.ErrNoPrice)
+}
+
+func TestResolveScheduledSale(t *testing.T) {
+	mockPriceRepo := new(mocks.MockPriceRepository)
+
+	startsAt := time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC)
+	endsAt := time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC)
+
+	mockPriceRepo.On("GetByProductID", mock.Anything, int64(7)).Return([]domain.ProductPrice{
+		{ID: 1, ProductID: 7, Kind: domain.PriceKindList, Amount: brl(5990), MinQuantity: 1},
+		{ID: 2, ProductID: 7, Kind: domain.PriceKindSale, Amount: brl(3990), MinQuantity: 1, StartsAt: &startsAt, EndsAt: &endsAt},
+	}, nil)
+
+	service := NewPricingService(mockPriceRepo)
+	product := &domain.Product{ID: 7, Price: 4990}
+
+	before, err := service.Resolve(context.Background(), domain.PriceRequest{Product: product, At: startsAt.Add(-time.Second)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, brl(5990), before.Unit)
+	assert.False(t, before.OnSale)
+
+	during, err := service.Resolve(context.Background(), domain.PriceRequest{Product: product, At: startsAt})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.EffectivePrice{Unit: brl(3990), List: brl(5990), Total: brl(3990), Quantity: 1, OnSale: true, EndsAt: &endsAt}, during)
+
+	after, err := service.Resolve(context.Background(), domain.PriceRequest{Product: product, At: endsAt})
+
+	assert.NoError(t, err)
+	assert.Equal(t, brl(5990), after.Unit)
+}
+
+func TestResolveVariantListPrice(t *testing.T) {
+	mockPriceRepo := new(mocks.MockPriceRepository)
+
+	mockPriceRepo.On("GetByProductID", mock.Anything, int64(7)).Return([]domain.ProductPrice{
+		{ID: 1, ProductID: 7, VariantSKU: "P7-GG", Kind: domain.PriceKindList, Amount: brl(6490), MinQuantity: 1},
+		{ID: 2, ProductID: 7, Kind: domain.PriceKindList, Amount: brl(5990), MinQuantity: 1},
+		{ID: 3, ProductID: 7, Kind: domain.PriceKindList, Amount: brl(5490), MinQuantity: 3},
+	}, nil)
+
+	service := NewPricingService(mockPriceRepo)
+	product := &domain.Product{ID: 7, Price: 4990}
+
+	// the list price of the product is not a lower price of the variant
+	gg, err := service.Resolve(context.Background(), domain.PriceRequest{Product: product, Variant: &domain.Variant{SKU: "P7-GG", Price: 4990}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, brl(6490), gg.List)
+	assert.Equal(t, brl(6490), gg.Unit)
+	assert.False(t, gg.OnSale)
+
+	// the tiers of the product apply over it
+	ggTier, err := service.Resolve(context.Background(), domain.PriceRequest{Product: product, Variant: &domain.Variant{SKU: "P7-GG", Price: 4990}, Quantity: 3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, brl(6490), ggTier.List)
+	assert.Equal(t, brl(5490), ggTier.Unit)
+
+	m, err := service.Resolve(context.Background(), domain.PriceRequest{Product: product, Variant: &domain.Variant{SKU: "P7-M", Price: 4990}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, brl(5990), m.List)
+	assert.Equal(t, brl(5990), m.Unit)
+}
+
+func TestResolveTiersAndPriceLists(t *testing.T) {
+	mockPriceRepo := new(mocks.MockPriceRepository)
+
+	prices := []domain.ProductPrice{
+		{ID: 1, ProductID: 7, Kind: domain.PriceKindSale, Amount: brl(4490), MinQuantity: 10},
+		{ID: 2, ProductID: 7, PriceList: "atacado", Kind: domain.PriceKindList, Amount: brl(3990), MinQuantity: 1},
+		{ID: 3, ProductID: 7, PriceList: "atacado", Kind: domain.PriceKindList, Amount: brl(2990), MinQuantity: 50},
+		{ID: 4, ProductID: 7, Kind: domain.PriceKindList, Amount: domain.Money{Amount: 999, Currency: "USD"}, MinQuantity: 1},
+	}
+
+	mockPriceRepo.On("GetByProductID", mock.Anything, int64(7)).Return(prices, nil)
+	mockPriceRepo.On("GetPriceListCodesByUserID", mock.Anything, int64(3)).Return([]string{"atacado"}, nil)
+	mockPriceRepo.On("GetPriceListCodesByUserID", mock.Anything, int64(4)).Return([]string{}, nil)
+
+	service := NewPricingService(mockPriceRepo)
+	product := &domain.Product{ID: 7, Price: 4990}
+
+	anonymous, err := service.Resolve(context.Background(), domain.PriceRequest{Product: product, Quantity: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, brl(4490), anonymous.Unit)
+	assert.Equal(t, brl(44900), anonymous.Total)
+
+	customer, err := service.Resolve(context.Background(), domain.PriceRequest{Product: product, Quantity: 9, UserID: 4})
+
+	assert.NoError(t, err)
+	assert.Equal(t, brl(4990), customer.Unit)
+
+	wholesaler, err := service.Resolve(context.Background(), domain.PriceRequest{Product: product, Quantity: 50, UserID: 3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, brl(2990), wholesaler.Unit)
+	assert.Equal(t, brl(4990), wholesaler.List)
+	assert.Equal(t, "atacado", wholesaler.PriceList)
+
+	dollars, err := service.Resolve(context.Background(), domain.PriceRequest{Product: product, Currency: "USD"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Money{Amount: 999, Currency: "USD"}, dollars.Unit)
+}
+
+func TestResolveError(t *testing.T) {
+	mockPriceRepo := new(mocks.MockPriceRepository)
+
+	mockPriceRepo.On("GetPriceListCodesByUserID", mock.Anything, int64(3)).Return(nil, errors.New("error message"))
+
+	_, err := NewPricingService(mockPriceRepo).Resolve(context.Background(), domain.PriceRequest{Product: &domain.Product{ID: 7}, UserID: 3})
+
+	assert.Error(t, err)
+}
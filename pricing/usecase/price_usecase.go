@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type priceUseCase struct {
+	pricingService domain.PricingService
+	priceRepo      domain.PriceRepository
+	productRepo    domain.ProductRepository
+	variantRepo    domain.VariantRepository
+	userRepo       domain.UserRepository
+}
+
+func NewPriceUseCase(ps domain.PricingService, pr domain.PriceRepository, prodr domain.ProductRepository, vr domain.VariantRepository, ur domain.UserRepository) domain.PriceUseCase {
+	return &priceUseCase{pricingService: ps, priceRepo: pr, productRepo: prodr, variantRepo: vr, userRepo: ur}
+}
+
+func (pu *priceUseCase) Quote(ctx context.Context, productUUID string, sku string, quantity int64, currency domain.Currency, login string) (*domain.EffectivePrice, error) {
+	product, err := pu.productRepo.GetByUUID(ctx, productUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil || product.Status != domain.ProductStatusPublished {
+		return nil, nil
+	}
+
+	r := domain.PriceRequest{Product: product, Quantity: quantity, Currency: currency, At: time.Now()}
+
+	if sku != "" {
+		if r.Variant, err = pu.variant(ctx, product, sku); err != nil {
+			return nil, err
+		}
+	}
+
+	if login != "" {
+		user, err := pu.userRepo.GetByEmail(ctx, login)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if user != nil {
+			r.UserID = user.ID
+		}
+	}
+
+	return pu.pricingService.Resolve(ctx, r)
+}
+
+func (pu *priceUseCase) GetPrices(ctx context.Context, productUUID string) ([]domain.ProductPrice, error) {
+	product, err := pu.productRepo.GetByUUID(ctx, productUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil {
+		return nil, nil
+	}
+
+	return pu.priceRepo.GetByProductID(ctx, product.ID)
+}
+
+func (pu *priceUseCase) AddPrice(ctx context.Context, productUUID string, p *domain.ProductPrice) error {
+	product, err := pu.productRepo.GetByUUID(ctx, productUUID)
+
+	if err != nil {
+		return err
+	}
+
+	if product == nil {
+		return domain.ErrProductNotFound
+	}
+
+	if p.VariantSKU != "" {
+		if _, err := pu.variant(ctx, product, p.VariantSKU); err != nil {
+			return err
+		}
+	}
+
+	if p.PriceList != "" {
+		list, err := pu.priceRepo.GetPriceListByCode(ctx, p.PriceList)
+
+		if err != nil {
+			return err
+		}
+
+		if list == nil {
+			return domain.ErrPriceListNotFound
+		}
+
+		if list.Currency != p.Amount.Currency {
+			return domain.ErrCurrencyMismatch
+		}
+	}
+
+	p.ProductID = product.ID
+
+	return pu.priceRepo.Store(ctx, p)
+}
+
+func (pu *priceUseCase) DeletePrice(ctx context.Context, productUUID string, id int64) error {
+	product, err := pu.productRepo.GetByUUID(ctx, productUUID)
+
+	if err != nil {
+		return err
+	}
+
+	if product == nil {
+		return domain.ErrProductNotFound
+	}
+
+	return pu.priceRepo.Delete(ctx, product.ID, id)
+}
+
+func (pu *priceUseCase) CreatePriceList(ctx context.Context, pl *domain.PriceList) error {
+	existing, err := pu.priceRepo.GetPriceListByCode(ctx, pl.Code)
+
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		return domain.ErrPriceListCodeTaken
+	}
+
+	return pu.priceRepo.StorePriceList(ctx, pl)
+}
+
+func (pu *priceUseCase) SetCustomerGroups(ctx context.Context, userUUID string, groups []string) error {
+	user, err := pu.userRepo.GetByUUID(ctx, userUUID)
+
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+
+	unique := []string{}
+	seen := map[string]bool{}
+
+	for _, group := range groups {
+		if !seen[group] {
+			seen[group] = true
+			unique = append(unique, group)
+		}
+	}
+
+	return pu.priceRepo.SetCustomerGroups(ctx, user.ID, unique)
+}
+
+func (pu *priceUseCase) variant(ctx context.Context, product *domain.Product, sku string) (*domain.Variant, error) {
+	variant, err := pu.variantRepo.GetBySKU(ctx, sku)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if variant == nil || variant.ProductID != product.ID {
+		return nil, domain.ErrVariantNotFound
+	}
+
+	return variant, nil
+}
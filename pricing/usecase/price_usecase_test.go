@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestQuoteNotPublished(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7, Status: domain.ProductStatusDraft}, nil)
+
+	price, err := NewPriceUseCase(nil, nil, mockProductRepo, nil, nil).Quote(context.Background(), "uuid", "", 1, "", "")
+
+	assert.NoError(t, err)
+	assert.Nil(t, price)
+}
+
+func TestQuoteVariantOfOtherProduct(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7, Status: domain.ProductStatusPublished}, nil)
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P8-M").Return(&domain.Variant{ID: 2, ProductID: 8, SKU: "P8-M"}, nil)
+
+	_, err := NewPriceUseCase(nil, nil, mockProductRepo, mockVariantRepo, nil).Quote(context.Background(), "uuid", "P8-M", 1, "", "")
+
+	assert.ErrorIs(t, err, domain.ErrVariantNotFound)
+}
+
+func TestQuote(t *testing.T) {
+	mockPricingService := new(mocks.MockPricingService)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	product := &domain.Product{ID: 7, Status: domain.ProductStatusPublished}
+	variant := &domain.Variant{ID: 2, ProductID: 7, SKU: "P7-M"}
+	price := &domain.EffectivePrice{Unit: domain.Money{Amount: 3990, Currency: domain.CurrencyBRL}, Quantity: 2}
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(product, nil)
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P7-M").Return(variant, nil)
+	mockUserRepo.On("GetByEmail", mock.Anything, "user@test.com").Return(3, "user uuid", "user@test.com", "", "", "", "", "", "", "", "", "", nil)
+	mockPricingService.On("Resolve", mock.Anything, mock.MatchedBy(func(r domain.PriceRequest) bool {
+		return r.Product == product && r.Variant == variant && r.Quantity == 2 && r.UserID == 3 && r.Currency == domain.CurrencyBRL && !r.At.IsZero()
+	})).Return(price, nil)
+
+	res, err := NewPriceUseCase(mockPricingService, nil, mockProductRepo, mockVariantRepo, mockUserRepo).Quote(context.Background(), "uuid", "P7-M", 2, domain.CurrencyBRL, "user@test.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, price, res)
+}
+
+func TestAddPriceProductNotFound(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(nil, nil)
+
+	err := NewPriceUseCase(nil, nil, mockProductRepo, nil, nil).AddPrice(context.Background(), "uuid", &domain.ProductPrice{})
+
+	assert.ErrorIs(t, err, domain.ErrProductNotFound)
+}
+
+func TestAddPriceListNotFound(t *testing.T) {
+	mockPriceRepo := new(mocks.MockPriceRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7}, nil)
+	mockPriceRepo.On("GetPriceListByCode", mock.Anything, "atacado").Return(nil, nil)
+
+	err := NewPriceUseCase(nil, mockPriceRepo, mockProductRepo, nil, nil).AddPrice(context.Background(), "uuid", &domain.ProductPrice{PriceList: "atacado"})
+
+	assert.ErrorIs(t, err, domain.ErrPriceListNotFound)
+}
+
+func TestAddPriceCurrencyMismatch(t *testing.T) {
+	mockPriceRepo := new(mocks.MockPriceRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7}, nil)
+	mockPriceRepo.On("GetPriceListByCode", mock.Anything, "export").Return(&domain.PriceList{Code: "export", Currency: "USD"}, nil)
+
+	err := NewPriceUseCase(nil, mockPriceRepo, mockProductRepo, nil, nil).AddPrice(context.Background(), "uuid", &domain.ProductPrice{PriceList: "export", Amount: domain.Money{Amount: 3990, Currency: domain.CurrencyBRL}})
+
+	assert.ErrorIs(t, err, domain.ErrCurrencyMismatch)
+}
+
+func TestAddPrice(t *testing.T) {
+	mockPriceRepo := new(mocks.MockPriceRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	p := &domain.ProductPrice{VariantSKU: "P7-M", PriceList: "atacado", Kind: domain.PriceKindList, Amount: domain.Money{Amount: 3990, Currency: domain.CurrencyBRL}, MinQuantity: 1}
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7}, nil)
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P7-M").Return(&domain.Variant{ID: 2, ProductID: 7, SKU: "P7-M"}, nil)
+	mockPriceRepo.On("GetPriceListByCode", mock.Anything, "atacado").Return(&domain.PriceList{Code: "atacado", Currency: domain.CurrencyBRL}, nil)
+	mockPriceRepo.On("Store", mock.Anything, p).Return(nil)
+
+	err := NewPriceUseCase(nil, mockPriceRepo, mockProductRepo, mockVariantRepo, nil).AddPrice(context.Background(), "uuid", p)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), p.ProductID)
+	mockPriceRepo.AssertExpectations(t)
+}
+
+func TestDeletePriceError(t *testing.T) {
+	mockPriceRepo := new(mocks.MockPriceRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7}, nil)
+	mockPriceRepo.On("Delete", mock.Anything, int64(7), int64(5)).Return(errors.New("error message"))
+
+	err := NewPriceUseCase(nil, mockPriceRepo, mockProductRepo, nil, nil).DeletePrice(context.Background(), "uuid", 5)
+
+	assert.Error(t, err)
+}
+
+func TestCreatePriceListCodeTaken(t *testing.T) {
+	mockPriceRepo := new(mocks.MockPriceRepository)
+
+	mockPriceRepo.On("GetPriceListByCode", mock.Anything, "atacado").Return(&domain.PriceList{Code: "atacado"}, nil)
+
+	err := NewPriceUseCase(nil, mockPriceRepo, nil, nil, nil).CreatePriceList(context.Background(), &domain.PriceList{Code: "atacado"})
+
+	assert.ErrorIs(t, err, domain.ErrPriceListCodeTaken)
+	mockPriceRepo.AssertNotCalled(t, "StorePriceList", mock.Anything, mock.Anything)
+}
+
+func TestSetCustomerGroupsUserNotFound(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	mockUserRepo.On("GetByUUID", mock.Anything, "user uuid").Return(nil, nil)
+
+	err := NewPriceUseCase(nil, nil, nil, nil, mockUserRepo).SetCustomerGroups(context.Background(), "user uuid", []string{"lojistas"})
+
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestSetCustomerGroups(t *testing.T) {
+	mockPriceRepo := new(mocks.MockPriceRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	mockUserRepo.On("GetByUUID", mock.Anything, "user uuid").Return(3, "user uuid", "user@test.com", "", "", "", "", "", "", "", "", "", nil)
+	mockPriceRepo.On("SetCustomerGroups", mock.Anything, int64(3), []string{"lojistas", "vip"}).Return(nil)
+
+	err := NewPriceUseCase(nil, mockPriceRepo, nil, nil, mockUserRepo).SetCustomerGroups(context.Background(), "user uuid", []string{"lojistas", "vip", "lojistas"})
+
+	assert.NoError(t, err)
+	mockPriceRepo.AssertExpectations(t)
+}
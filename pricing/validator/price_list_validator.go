@@ -0,0 +1,41 @@
+package validator
+
+import (
+	"context"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+var codeRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+type priceListValidator struct{}
+
+func NewPriceListValidator() *priceListValidator {
+	return &priceListValidator{}
+}
+
+func (plv *priceListValidator) Validate(ctx context.Context, pl *domain.PriceList) (domain.IsValid, domain.Message) {
+	if len(pl.Code) > 50 || !codeRegexp.MatchString(pl.Code) {
+		return false, "price list's code must have up to 50 lowercase letters, numbers and hyphens"
+	}
+
+	if pl.Name == "" {
+		return false, "price list's name can not be empty"
+	}
+
+	if utf8.RuneCountInString(pl.Name) > 150 {
+		return false, "price list's name can not have more than 150 characters"
+	}
+
+	if !currencyRegexp.MatchString(string(pl.Currency)) {
+		return false, "price list's currency must be an ISO 4217 code, like BRL"
+	}
+
+	if len(pl.CustomerGroup) > 50 || !codeRegexp.MatchString(pl.CustomerGroup) {
+		return false, "price list's customer group must have up to 50 lowercase letters, numbers and hyphens"
+	}
+
+	return true, ""
+}
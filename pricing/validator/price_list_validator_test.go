@@ -0,0 +1,35 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePriceListCode(t *testing.T) {
+	isValid, message := NewPriceListValidator().Validate(context.Background(), &domain.PriceList{Code: "Atacado", Name: "Atacado", Currency: domain.CurrencyBRL, CustomerGroup: "lojistas"})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidatePriceListEmpty(t *testing.T) {
+	isValid, message := NewPriceListValidator().Validate(context.Background(), &domain.PriceList{Code: "atacado", Currency: domain.CurrencyBRL, CustomerGroup: "lojistas"})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+
+	isValid, message = NewPriceListValidator().Validate(context.Background(), &domain.PriceList{Code: "atacado", Name: "Atacado", Currency: domain.CurrencyBRL})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidatePriceList(t *testing.T) {
+	isValid, message := NewPriceListValidator().Validate(context.Background(), &domain.PriceList{Code: "atacado", Name: "Atacado", Currency: domain.CurrencyBRL, CustomerGroup: "lojistas"})
+
+	assert.True(t, bool(isValid))
+	assert.Empty(t, message)
+}
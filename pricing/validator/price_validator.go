@@ -0,0 +1,40 @@
+package validator
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+var currencyRegexp = regexp.MustCompile(`^[A-Z]{3}$`)
+
+type priceValidator struct{}
+
+func NewPriceValidator() *priceValidator {
+	return &priceValidator{}
+}
+
+func (pv *priceValidator) Validate(ctx context.Context, p *domain.ProductPrice) (domain.IsValid, domain.Message) {
+	if p.Kind != domain.PriceKindList && p.Kind != domain.PriceKindSale {
+		return false, "price's kind must be list or sale"
+	}
+
+	if p.Amount.Amount < 0 {
+		return false, "price's amount can not be negative"
+	}
+
+	if !currencyRegexp.MatchString(string(p.Amount.Currency)) {
+		return false, "price's currency must be an ISO 4217 code, like BRL"
+	}
+
+	if p.MinQuantity < 1 {
+		return false, "price's minimum quantity must be at least 1"
+	}
+
+	if p.StartsAt != nil && p.EndsAt != nil && !p.StartsAt.Before(*p.EndsAt) {
+		return false, "price's start must be before its end"
+	}
+
+	return true, ""
+}
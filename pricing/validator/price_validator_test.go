@@ -0,0 +1,72 @@
+package validator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func validPrice() *domain.ProductPrice {
+	return &domain.ProductPrice{Kind: domain.PriceKindSale, Amount: domain.Money{Amount: 3990, Currency: domain.CurrencyBRL}, MinQuantity: 1}
+}
+
+func TestValidatePriceKind(t *testing.T) {
+	p := validPrice()
+	p.Kind = "promo"
+
+	isValid, message := NewPriceValidator().Validate(context.Background(), p)
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidatePriceAmount(t *testing.T) {
+	p := validPrice()
+	p.Amount.Amount = -1
+
+	isValid, message := NewPriceValidator().Validate(context.Background(), p)
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+
+	p = validPrice()
+	p.Amount.Currency = "real"
+
+	isValid, message = NewPriceValidator().Validate(context.Background(), p)
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidatePriceMinQuantity(t *testing.T) {
+	p := validPrice()
+	p.MinQuantity = 0
+
+	isValid, message := NewPriceValidator().Validate(context.Background(), p)
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidatePriceSchedule(t *testing.T) {
+	startsAt := time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC)
+	endsAt := time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC)
+
+	p := validPrice()
+	p.StartsAt, p.EndsAt = &startsAt, &endsAt
+
+	isValid, message := NewPriceValidator().Validate(context.Background(), p)
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+
+	p.StartsAt, p.EndsAt = &endsAt, &startsAt
+
+	isValid, message = NewPriceValidator().Validate(context.Background(), p)
+
+	assert.True(t, bool(isValid))
+	assert.Empty(t, message)
+}
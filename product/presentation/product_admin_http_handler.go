@@ -0,0 +1,252 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type productAdminHandler struct {
+	ProductUseCase   domain.ProductUseCase
+	ProductValidator domain.ProductValidator
+}
+
+type productRequest struct {
+	Name            *string               `json:"name"`
+	Detail          *string               `json:"detail"`
+	Price           *int64                `json:"price"`
+	Status          *domain.ProductStatus `json:"status"`
+	Pictures        *[]string             `json:"pictures"`
+	Attributes      *[]domain.Attribute   `json:"attributes"`
+	Version         *int64                `json:"version"`
+	MetaTitle       *string               `json:"metaTitle"`
+	MetaDescription *string               `json:"metaDescription"`
+	CanonicalURL    *string               `json:"canonicalUrl"`
+}
+
+func NewProductAdminHandler(e *echo.Echo, puc domain.ProductUseCase, pv domain.ProductValidator, ts domain.TokenService) *productAdminHandler {
+	handler := &productAdminHandler{
+		ProductUseCase:   puc,
+		ProductValidator: pv,
+	}
+
+	admin := _tokenPresentation.NewAdminMiddleware(ts)
+
+	e.GET("/admin/products/:uuid", handler.Get, admin)
+	e.POST("/admin/products", handler.Create, admin)
+	e.PUT("/admin/products/:uuid", handler.Update, admin)
+	e.PATCH("/admin/products/:uuid", handler.Patch, admin)
+	e.DELETE("/admin/products/:uuid", handler.Delete, admin)
+
+	return handler
+}
+
+func (pah *productAdminHandler) Get(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	product, err := pah.ProductUseCase.AdminGet(c.Request().Context(), uuid)
+
+	if err != nil {
+		log.Printf("Error trying to get a product: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the product")
+	}
+
+	if product == nil {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	return c.JSON(http.StatusOK, product)
+}
+
+func (pah *productAdminHandler) Create(c echo.Context) error {
+	var req productRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	product := domain.Product{Status: domain.ProductStatusDraft}
+
+	req.apply(&product)
+
+	ctx := c.Request().Context()
+
+	isValid, message := pah.ProductValidator.Validate(ctx, &product)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	if err := pah.ProductUseCase.Create(ctx, &product); err != nil {
+		log.Printf("Error trying to create a product: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to create the product")
+	}
+
+	return c.JSON(http.StatusCreated, product)
+}
+
+func (pah *productAdminHandler) Update(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	var req productRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	if req.Version == nil {
+		return c.JSON(http.StatusBadRequest, "version can not be empty")
+	}
+
+	product := domain.Product{UUID: uuid, Pictures: []string{}, Attributes: []domain.Attribute{}}
+
+	req.apply(&product)
+
+	return pah.update(c, &product)
+}
+
+func (pah *productAdminHandler) Patch(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	var req productRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	if req.Version == nil {
+		return c.JSON(http.StatusBadRequest, "version can not be empty")
+	}
+
+	product, err := pah.ProductUseCase.AdminGet(c.Request().Context(), uuid)
+
+	if err != nil {
+		log.Printf("Error trying to get a product: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to update the product")
+	}
+
+	if product == nil {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	req.apply(product)
+
+	return pah.update(c, product)
+}
+
+func (pah *productAdminHandler) Delete(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	version, err := strconv.ParseInt(c.QueryParam("version"), 10, 64)
+
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, "version param is not valid")
+	}
+
+	product, err := pah.ProductUseCase.Delete(c.Request().Context(), uuid, version)
+
+	if errors.Is(err, domain.ErrVersionConflict) {
+		return c.JSON(http.StatusConflict, "product was changed by someone else, reload it and try again")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to delete a product: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to delete the product")
+	}
+
+	if product == nil {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (pah *productAdminHandler) update(c echo.Context, product *domain.Product) error {
+	ctx := c.Request().Context()
+
+	isValid, message := pah.ProductValidator.Validate(ctx, product)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	updated, err := pah.ProductUseCase.Update(ctx, product)
+
+	if errors.Is(err, domain.ErrVersionConflict) {
+		return c.JSON(http.StatusConflict, "product was changed by someone else, reload it and try again")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to update a product: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to update the product")
+	}
+
+	if updated == nil {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	return c.JSON(http.StatusOK, updated)
+}
+
+func (pr *productRequest) apply(p *domain.Product) {
+	if pr.Name != nil {
+		p.Name = *pr.Name
+	}
+
+	if pr.Detail != nil {
+		p.Detail = *pr.Detail
+	}
+
+	if pr.Price != nil {
+		p.Price = *pr.Price
+	}
+
+	if pr.Status != nil {
+		p.Status = *pr.Status
+	}
+
+	if pr.Pictures != nil {
+		p.Pictures = *pr.Pictures
+	}
+
+	if pr.Attributes != nil {
+		p.Attributes = *pr.Attributes
+	}
+
+	if pr.Version != nil {
+		p.Version = *pr.Version
+	}
+
+	if pr.MetaTitle != nil {
+		p.MetaTitle = *pr.MetaTitle
+	}
+
+	if pr.MetaDescription != nil {
+		p.MetaDescription = *pr.MetaDescription
+	}
+
+	if pr.CanonicalURL != nil {
+		p.CanonicalURL = *pr.CanonicalURL
+	}
+}
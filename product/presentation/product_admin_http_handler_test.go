@@ -0,0 +1,254 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAdminGetNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin/products/:uuid", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductUsecase.On("AdminGet", mock.Anything, "testuuid").Return(nil, nil)
+
+	handler := NewProductAdminHandler(echo.New(), mockProductUsecase, nil, nil)
+
+	handler.Get(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestCreateWrongBody(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/products", strings.NewReader("invalidbody"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewProductAdminHandler(echo.New(), nil, nil, nil)
+
+	handler.Create(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestCreateInvalid(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/products", strings.NewReader("{\"name\":\"name\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockProductValidator := new(mocks.MockProductValidator)
+
+	mockProductValidator.On("Validate", mock.Anything, &domain.Product{Name: "name", Status: domain.ProductStatusDraft}).Return(false, "error message")
+
+	handler := NewProductAdminHandler(echo.New(), nil, mockProductValidator, nil)
+
+	handler.Create(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "\"error message\"\n", rec.Body.String())
+}
+
+func TestCreate(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/products", strings.NewReader("{\"name\":\"name\",\"detail\":\"detail\",\"price\":1990,\"pictures\":[\"picture.png\"],\"attributes\":[{\"label\":\"color\",\"values\":[\"black\"]}]}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	product := &domain.Product{Name: "name", Detail: "detail", Price: 1990, Status: domain.ProductStatusDraft, Pictures: []string{"picture.png"}, Attributes: []domain.Attribute{{Label: "color", Values: []string{"black"}}}}
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+	mockProductValidator := new(mocks.MockProductValidator)
+
+	mockProductValidator.On("Validate", mock.Anything, product).Return(true, "")
+	mockProductUsecase.On("Create", mock.Anything, product).Return(nil)
+
+	handler := NewProductAdminHandler(echo.New(), mockProductUsecase, mockProductValidator, nil)
+
+	handler.Create(c)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	mockProductUsecase.AssertExpectations(t)
+}
+
+func TestUpdateWithoutVersion(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/products/:uuid", strings.NewReader("{\"name\":\"name\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	handler := NewProductAdminHandler(echo.New(), nil, nil, nil)
+
+	handler.Update(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestUpdateVersionConflict(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/products/:uuid", strings.NewReader("{\"name\":\"name\",\"detail\":\"detail\",\"status\":\"published\",\"version\":1}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	product := &domain.Product{UUID: "testuuid", Name: "name", Detail: "detail", Status: domain.ProductStatusPublished, Version: 1, Pictures: []string{}, Attributes: []domain.Attribute{}}
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+	mockProductValidator := new(mocks.MockProductValidator)
+
+	mockProductValidator.On("Validate", mock.Anything, product).Return(true, "")
+	mockProductUsecase.On("Update", mock.Anything, product).Return(nil, domain.ErrVersionConflict)
+
+	handler := NewProductAdminHandler(echo.New(), mockProductUsecase, mockProductValidator, nil)
+
+	handler.Update(c)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestUpdateNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/products/:uuid", strings.NewReader("{\"name\":\"name\",\"detail\":\"detail\",\"status\":\"published\",\"version\":1}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+	mockProductValidator := new(mocks.MockProductValidator)
+
+	mockProductValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockProductUsecase.On("Update", mock.Anything, mock.Anything).Return(nil, nil)
+
+	handler := NewProductAdminHandler(echo.New(), mockProductUsecase, mockProductValidator, nil)
+
+	handler.Update(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestPatch(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PATCH, "/admin/products/:uuid", strings.NewReader("{\"status\":\"archived\",\"version\":2}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	existing := &domain.Product{ID: 7, UUID: "testuuid", Name: "name", Detail: "detail", Status: domain.ProductStatusPublished, Version: 2}
+	patched := &domain.Product{ID: 7, UUID: "testuuid", Name: "name", Detail: "detail", Status: domain.ProductStatusArchived, Version: 2}
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+	mockProductValidator := new(mocks.MockProductValidator)
+
+	mockProductUsecase.On("AdminGet", mock.Anything, "testuuid").Return(existing, nil)
+	mockProductValidator.On("Validate", mock.Anything, patched).Return(true, "")
+	mockProductUsecase.On("Update", mock.Anything, patched).Return(&domain.Product{ID: 7, UUID: "testuuid", Name: "name", Detail: "detail", Status: domain.ProductStatusArchived, Version: 3}, nil)
+
+	handler := NewProductAdminHandler(echo.New(), mockProductUsecase, mockProductValidator, nil)
+
+	handler.Patch(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"status\":\"archived\",\"version\":3")
+}
+
+func TestDeleteInvalidVersion(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/admin/products/:uuid", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	handler := NewProductAdminHandler(echo.New(), nil, nil, nil)
+
+	handler.Delete(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestDeleteError(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/admin/products/:uuid?version=1", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductUsecase.On("Delete", mock.Anything, "testuuid", int64(1)).Return(nil, errors.New("error message"))
+
+	handler := NewProductAdminHandler(echo.New(), mockProductUsecase, nil, nil)
+
+	handler.Delete(c)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestDelete(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/admin/products/:uuid?version=1", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductUsecase.On("Delete", mock.Anything, "testuuid", int64(1)).Return(&domain.Product{UUID: "testuuid"}, nil)
+
+	handler := NewProductAdminHandler(echo.New(), mockProductUsecase, nil, nil)
+
+	handler.Delete(c)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
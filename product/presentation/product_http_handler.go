@@ -1,10 +1,18 @@
 package presentation
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
 	"github.com/labstack/echo/v4"
 )
 
@@ -19,22 +27,12 @@ func NewProductHandler(e *echo.Echo, puc domain.ProductUseCase, ts domain.TokenS
 		TokenService:   ts,
 	}
 
-	auth := func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			authHeader := c.Request().Header.Get("Authorization")
-			if authHeader == "" {
-				return c.JSON(http.StatusUnauthorized, "request not authorized")
-			}
-			if isValid, err := handler.TokenService.IsValid(c.Request().Context(), domain.Token(authHeader)); err != nil {
-				return c.JSON(http.StatusInternalServerError, "failed to authorize request")
-			} else if !isValid {
-				return c.JSON(http.StatusUnauthorized, "request not authorized")
-			}
-			return next(c)
-		}
-	}
+	optionalAuth := _tokenPresentation.NewOptionalAuthMiddleware(ts)
 
-	e.GET("/products/:uuid", handler.Get, auth)
+	e.GET("/products", handler.List, optionalAuth)
+	e.GET("/products/search", handler.Search, optionalAuth)
+	e.GET("/products/:uuid", handler.Get, optionalAuth)
+	e.GET("/products/by-slug/:slug", handler.GetBySlug, optionalAuth)
 
 	return handler
 }
@@ -46,16 +44,271 @@ func (ph *productHandler) Get(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
 	}
 
-	product, err := ph.ProductUseCase.Get(c.Request().Context(), uuid)
+	var login string
+
+	if tokenInfo := _tokenPresentation.TokenInfoFromContext(c); tokenInfo != nil {
+		login = tokenInfo.Info
+	}
+
+	head, err := ph.ProductUseCase.Head(c.Request().Context(), uuid, login)
 
 	if err != nil {
 		log.Printf("Error trying to get a product: %s", err.Error())
 		return c.JSON(http.StatusInternalServerError, "failed to get the product")
 	}
 
-	if product == nil {
+	if head == nil {
 		return c.JSON(http.StatusNotFound, "product not found")
 	}
 
-	return c.JSON(http.StatusOK, product)
+	return ph.product(c, head, login)
+}
+
+// GetBySlug redirects the old slugs of a product to its current one.
+func (ph *productHandler) GetBySlug(c echo.Context) error {
+	slug := c.Param("slug")
+
+	if slug == "" {
+		return c.JSON(http.StatusBadRequest, "slug param is not valid")
+	}
+
+	var login string
+
+	if tokenInfo := _tokenPresentation.TokenInfoFromContext(c); tokenInfo != nil {
+		login = tokenInfo.Info
+	}
+
+	head, err := ph.ProductUseCase.HeadBySlug(c.Request().Context(), slug, login)
+
+	if err != nil {
+		log.Printf("Error trying to get a product: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the product")
+	}
+
+	if head == nil {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	if head.Slug != slug {
+		return c.Redirect(http.StatusMovedPermanently, "/products/by-slug/"+url.PathEscape(head.Slug))
+	}
+
+	return ph.product(c, head, login)
+}
+
+// product answers 304 from the head of the product alone, the variants and
+// the translations are only loaded when the client copy is stale.
+func (ph *productHandler) product(c echo.Context, head *domain.Product, login string) error {
+	if notModified(c, productETag(c, head), login) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	if err := ph.ProductUseCase.Complete(c.Request().Context(), head); err != nil {
+		log.Printf("Error trying to get a product: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the product")
+	}
+
+	return c.JSON(http.StatusOK, head)
+}
+
+func (ph *productHandler) List(c echo.Context) error {
+	q, message := ParseProductQuery(c)
+
+	if message != "" {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	var login string
+
+	if tokenInfo := _tokenPresentation.TokenInfoFromContext(c); tokenInfo != nil {
+		login = tokenInfo.Info
+	}
+
+	page, err := ph.ProductUseCase.List(c.Request().Context(), *q, login)
+
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return c.JSON(http.StatusBadRequest, "cursor param is not valid")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to list products: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to list the products")
+	}
+
+	return conditionalJSON(c, page, login)
+}
+
+func (ph *productHandler) Search(c echo.Context) error {
+	q := strings.TrimSpace(c.QueryParam("q"))
+
+	if q == "" {
+		return c.JSON(http.StatusBadRequest, "q param can not be empty")
+	}
+
+	if len(q) > 100 {
+		return c.JSON(http.StatusBadRequest, "q param can not be longer than 100 characters")
+	}
+
+	limit := 20
+
+	if l := c.QueryParam("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+
+		if err != nil || parsed < 1 || parsed > 100 {
+			return c.JSON(http.StatusBadRequest, "limit param must be a number between 1 and 100")
+		}
+
+		limit = parsed
+	}
+
+	var login string
+
+	if tokenInfo := _tokenPresentation.TokenInfoFromContext(c); tokenInfo != nil {
+		login = tokenInfo.Info
+	}
+
+	page, err := ph.ProductUseCase.Search(c.Request().Context(), q, limit, login)
+
+	if err != nil {
+		log.Printf("Error trying to search products: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to search the products")
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+// ParseProductQuery reads the listing query params shared by every route
+// that lists products.
+func ParseProductQuery(c echo.Context) (*domain.ProductQuery, domain.Message) {
+	q := domain.ProductQuery{
+		Cursor:     c.QueryParam("cursor"),
+		Limit:      20,
+		Sort:       domain.ProductSortName,
+		Category:   c.QueryParam("category"),
+		Attributes: map[string][]string{},
+		WithTotal:  c.QueryParam("total") == "true",
+		WithFacets: c.QueryParam("facets") == "true",
+	}
+
+	if limit := c.QueryParam("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+
+		if err != nil || l < 1 || l > 100 {
+			return nil, "limit param must be a number between 1 and 100"
+		}
+
+		q.Limit = l
+	}
+
+	if sort := c.QueryParam("sort"); sort != "" {
+		q.Desc = strings.HasPrefix(sort, "-")
+		q.Sort = domain.ProductSort(strings.TrimPrefix(sort, "-"))
+
+		if q.Sort != domain.ProductSortName && q.Sort != domain.ProductSortRate && q.Sort != domain.ProductSortPrice && q.Sort != domain.ProductSortNewest {
+			return nil, "sort param must be name, rate, price or newest"
+		}
+	}
+
+	for _, attr := range c.QueryParams()["attr"] {
+		parts := strings.SplitN(attr, ":", 2)
+
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, "attr param must obey the format label:value"
+		}
+
+		q.Attributes[parts[0]] = append(q.Attributes[parts[0]], parts[1])
+	}
+
+	if minPrice := c.QueryParam("minPrice"); minPrice != "" {
+		p, err := strconv.ParseInt(minPrice, 10, 64)
+
+		if err != nil || p < 0 {
+			return nil, "minPrice param must be a positive number of cents"
+		}
+
+		q.MinPrice = &p
+	}
+
+	if maxPrice := c.QueryParam("maxPrice"); maxPrice != "" {
+		p, err := strconv.ParseInt(maxPrice, 10, 64)
+
+		if err != nil || p < 0 {
+			return nil, "maxPrice param must be a positive number of cents"
+		}
+
+		q.MaxPrice = &p
+	}
+
+	if q.MinPrice != nil && q.MaxPrice != nil && *q.MinPrice > *q.MaxPrice {
+		return nil, "minPrice param can not be greater than maxPrice"
+	}
+
+	return &q, ""
+}
+
+// productETag is built from the content version of the product and the
+// locales asked. The rate, the pictures, the variants, the stock and the
+// translations all bump the content version, the favorite flag and the price
+// of the logged user, which change without it, are added to it.
+func productETag(c echo.Context, p *domain.Product) string {
+	etag := strconv.FormatInt(p.ContentVersion, 10) + ";" + strings.Join(domain.LocalesFromContext(c.Request().Context()), "+")
+
+	if p.Favorite {
+		etag += ";favorite"
+	}
+
+	if price := p.CustomerPrice; price != nil {
+		etag += ";" + strconv.FormatInt(price.Unit.Amount, 10) + string(price.Unit.Currency) + "/" + strconv.FormatInt(price.List.Amount, 10)
+	}
+
+	return `"` + etag + `"`
+}
+
+// conditionalJSON answers like c.JSON, tagging the body with a strong ETag
+// taken from the body itself, as a page has no version of its own.
+func conditionalJSON(c echo.Context, i interface{}, login string) error {
+	body, err := json.Marshal(i)
+
+	if err != nil {
+		return err
+	}
+
+	body = append(body, '\n')
+
+	sum := sha256.Sum256(body)
+
+	if notModified(c, `"`+hex.EncodeToString(sum[:])+`"`, login) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return c.JSONBlob(http.StatusOK, body)
+}
+
+// notModified sets the caching headers of the response and tells whether
+// the client already holds it. Only the anonymous responses may be kept by
+// shared caches, the others depend on the logged user.
+func notModified(c echo.Context, etag string, login string) bool {
+	if login == "" {
+		c.Response().Header().Set("Cache-Control", "public, max-age=60")
+	} else {
+		c.Response().Header().Set("Cache-Control", "private, no-cache")
+	}
+
+	c.Response().Header().Add("Vary", "Authorization")
+	c.Response().Header().Set("ETag", etag)
+
+	return etagMatch(c.Request().Header.Get("If-None-Match"), etag)
+}
+
+func etagMatch(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+
+		// If-None-Match uses the weak comparison
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
 }
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -44,9 +45,7 @@ func TestGetErrorFind(t *testing.T) {
 	mockProductUsecase := new(mocks.MockProductUsecase)
 	mockTokenService := new(mocks.MockTokenService)
 
-	mockProductUsecase.On("Get", mock.Anything, "testuuid").Return(nil, errors.New("error message"))
-
-	mockTokenService.On("isValid", mock.Anything, "token").Return(true, nil)
+	mockProductUsecase.On("Head", mock.Anything, "testuuid", "").Return(nil, errors.New("error message"))
 
 	handler := NewProductHandler(echo.New(), mockProductUsecase, mockTokenService)
 
@@ -69,14 +68,442 @@ func TestGetSuccess(t *testing.T) {
 	mockProductUsecase := new(mocks.MockProductUsecase)
 	mockTokenService := new(mocks.MockTokenService)
 
-	mockProductUsecase.On("Get", mock.Anything, "testuuid").Return(1, "uuid", 2, "picturepath", "name", "detail", true, "color", "black", nil)
-
-	mockTokenService.On("isValid", mock.Anything, "token").Return(true, nil)
+	mockProductUsecase.On("Head", mock.Anything, "testuuid", "").Return(&domain.Product{ID: 1, UUID: "uuid", Rate: 2, Pictures: []string{"picturepath"}, Name: "name", Detail: "detail", Favorite: true, Attributes: []domain.Attribute{{Label: "color", Values: []string{"black"}}}}, nil)
+	mockProductUsecase.On("Complete", mock.Anything, mock.AnythingOfType("*domain.Product")).Return(nil)
 
 	handler := NewProductHandler(echo.New(), mockProductUsecase, mockTokenService)
 
 	handler.Get(c)
 
 	assert.Equal(t, http.StatusOK, rec.Code)
-	assert.Equal(t, "{\"ID\":1,\"uuid\":\"uuid\",\"rate\":2,\"pictures\":[\"picturepath\"],\"name\":\"name\",\"detail\":\"detail\",\"favorite\":true,\"attributes\":[{\"label\":\"color\",\"values\":[\"black\"]}]}\n", rec.Body.String())
+	assert.Equal(t, "{\"ID\":1,\"uuid\":\"uuid\",\"rate\":2,\"pictures\":[\"picturepath\"],\"name\":\"name\",\"detail\":\"detail\",\"favorite\":true,\"attributes\":[{\"label\":\"color\",\"values\":[\"black\"]}],\"price\":0,\"status\":\"\",\"version\":0}\n", rec.Body.String())
+}
+
+func TestGetNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/:uuid", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductUsecase.On("Head", mock.Anything, "testuuid", "").Return(nil, nil)
+
+	handler := NewProductHandler(echo.New(), mockProductUsecase, nil)
+
+	handler.Get(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockProductUsecase.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything)
+}
+
+func TestGetNotModified(t *testing.T) {
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductUsecase.On("Head", mock.Anything, "testuuid", "").Return(&domain.Product{UUID: "testuuid", Version: 1, ContentVersion: 3}, nil)
+	mockProductUsecase.On("Complete", mock.Anything, mock.AnythingOfType("*domain.Product")).Return(nil)
+
+	handler := NewProductHandler(echo.New(), mockProductUsecase, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/:uuid", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	handler.Get(c)
+
+	etag := rec.Header().Get("ETag")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "public, max-age=60", rec.Header().Get("Cache-Control"))
+	assert.Equal(t, "Authorization", rec.Header().Get("Vary"))
+	assert.Equal(t, `"3;"`, etag)
+
+	for _, ifNoneMatch := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		req, err = http.NewRequest(echo.GET, "/products/:uuid", strings.NewReader(""))
+		assert.NoError(t, err)
+		req.Header.Set("If-None-Match", ifNoneMatch)
+
+		rec = httptest.NewRecorder()
+		c = e.NewContext(req, rec)
+		c.SetParamNames("uuid")
+		c.SetParamValues("testuuid")
+
+		handler.Get(c)
+
+		assert.Equal(t, http.StatusNotModified, rec.Code, ifNoneMatch)
+		assert.Equal(t, etag, rec.Header().Get("ETag"))
+		assert.Equal(t, "", rec.Body.String())
+	}
+
+	// the variants and the translations are not loaded for a 304
+	mockProductUsecase.AssertNumberOfCalls(t, "Complete", 1)
+
+	req, err = http.NewRequest(echo.GET, "/products/:uuid", strings.NewReader(""))
+	assert.NoError(t, err)
+	req.Header.Set("If-None-Match", `"2;"`)
+
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	handler.Get(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestGetETagByLocale(t *testing.T) {
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductUsecase.On("Head", mock.Anything, "testuuid", "").Return(&domain.Product{UUID: "testuuid", Version: 1, ContentVersion: 3}, nil)
+	mockProductUsecase.On("Complete", mock.Anything, mock.AnythingOfType("*domain.Product")).Return(nil)
+
+	handler := NewProductHandler(echo.New(), mockProductUsecase, nil)
+
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/:uuid", strings.NewReader(""))
+	assert.NoError(t, err)
+	req.Header.Set("If-None-Match", `"3;"`)
+	req = req.WithContext(domain.ContextWithLocales(req.Context(), []string{"es-AR", "es"}))
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	handler.Get(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"3;es-AR+es"`, rec.Header().Get("ETag"))
+}
+
+// TestGetETagLeavesOutVersion plays a product whose stock changed since an
+// admin wrote it, the tag follows the content version only.
+func TestGetETagLeavesOutVersion(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/:uuid", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductUsecase.On("Head", mock.Anything, "testuuid", "").Return(&domain.Product{UUID: "testuuid", Version: 3, ContentVersion: 4}, nil)
+	mockProductUsecase.On("Complete", mock.Anything, mock.AnythingOfType("*domain.Product")).Return(nil)
+
+	handler := NewProductHandler(echo.New(), mockProductUsecase, nil)
+
+	handler.Get(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"4;"`, rec.Header().Get("ETag"))
+}
+
+func TestGetWithLogin(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/:uuid", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductUsecase.On("Head", mock.Anything, "testuuid", "user@test.com").Return(&domain.Product{UUID: "testuuid", Favorite: true, ContentVersion: 3}, nil)
+	mockProductUsecase.On("Complete", mock.Anything, mock.AnythingOfType("*domain.Product")).Return(nil)
+
+	handler := NewProductHandler(echo.New(), mockProductUsecase, nil)
+
+	handler.Get(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "private, no-cache", rec.Header().Get("Cache-Control"))
+	mockProductUsecase.AssertExpectations(t)
+}
+
+// TestGetWithCustomerPrice plays a customer of a price list, the price
+// changes the tag as it changes without the content version.
+func TestGetWithCustomerPrice(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/:uuid", strings.NewReader(""))
+	assert.NoError(t, err)
+	req.Header.Set("If-None-Match", `"3;"`)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	price := &domain.EffectivePrice{
+		Unit:      domain.Money{Amount: 3990, Currency: domain.CurrencyBRL},
+		List:      domain.Money{Amount: 4990, Currency: domain.CurrencyBRL},
+		Total:     domain.Money{Amount: 3990, Currency: domain.CurrencyBRL},
+		Quantity:  1,
+		OnSale:    true,
+		PriceList: "atacado",
+	}
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductUsecase.On("Head", mock.Anything, "testuuid", "user@test.com").Return(&domain.Product{UUID: "testuuid", Price: 4990, ContentVersion: 3, CustomerPrice: price}, nil)
+	mockProductUsecase.On("Complete", mock.Anything, mock.AnythingOfType("*domain.Product")).Return(nil)
+
+	handler := NewProductHandler(echo.New(), mockProductUsecase, nil)
+
+	handler.Get(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"3;;3990BRL/4990"`, rec.Header().Get("ETag"))
+	assert.Contains(t, rec.Body.String(), `"customerPrice":{"unit":{"amount":3990,"currency":"BRL"},"list":{"amount":4990,"currency":"BRL"},"total":{"amount":3990,"currency":"BRL"},"quantity":1,"onSale":true,"priceList":"atacado"}`)
+	mockProductUsecase.AssertExpectations(t)
+}
+
+func TestGetBySlugNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/by-slug/:slug", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("slug")
+	c.SetParamValues("camiseta")
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductUsecase.On("HeadBySlug", mock.Anything, "camiseta", "").Return(nil, nil)
+
+	handler := NewProductHandler(echo.New(), mockProductUsecase, nil)
+
+	handler.GetBySlug(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestGetBySlugRedirect(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/by-slug/:slug", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("slug")
+	c.SetParamValues("camisa")
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductUsecase.On("HeadBySlug", mock.Anything, "camisa", "").Return(&domain.Product{UUID: "uuid", Slug: "camiseta-basica"}, nil)
+
+	handler := NewProductHandler(echo.New(), mockProductUsecase, nil)
+
+	handler.GetBySlug(c)
+
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "/products/by-slug/camiseta-basica", rec.Header().Get("Location"))
+}
+
+func TestGetBySlug(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/by-slug/:slug", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("slug")
+	c.SetParamValues("camiseta-basica")
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductUsecase.On("HeadBySlug", mock.Anything, "camiseta-basica", "").Return(&domain.Product{UUID: "uuid", Slug: "camiseta-basica", Name: "Camiseta básica", MetaTitle: "Camiseta básica de algodão", Version: 2, ContentVersion: 2}, nil)
+	mockProductUsecase.On("Complete", mock.Anything, mock.AnythingOfType("*domain.Product")).Return(nil)
+
+	handler := NewProductHandler(echo.New(), mockProductUsecase, nil)
+
+	handler.GetBySlug(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"slug\":\"camiseta-basica\"")
+	assert.Contains(t, rec.Body.String(), "\"metaTitle\":\"Camiseta básica de algodão\"")
+	assert.Equal(t, `"2;"`, rec.Header().Get("ETag"))
+}
+
+func TestListInvalidQuery(t *testing.T) {
+	for _, target := range []string{
+		"/products?limit=0",
+		"/products?limit=abc",
+		"/products?sort=color",
+		"/products?attr=color",
+		"/products?minPrice=-1",
+		"/products?minPrice=200&maxPrice=100",
+	} {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, target, strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		handler := NewProductHandler(echo.New(), nil, nil)
+
+		handler.List(c)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+	}
+}
+
+func TestListInvalidCursor(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products?cursor=invalid", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductUsecase.On("List", mock.Anything, mock.Anything, "").Return(nil, domain.ErrInvalidCursor)
+
+	handler := NewProductHandler(echo.New(), mockProductUsecase, nil)
+
+	handler.List(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestListError(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductUsecase.On("List", mock.Anything, mock.Anything, "").Return(nil, errors.New("error message"))
+
+	handler := NewProductHandler(echo.New(), mockProductUsecase, nil)
+
+	handler.List(c)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestListSuccess(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products?limit=1&sort=-price&category=shirts&attr=color:black&attr=color:white&minPrice=100&maxPrice=500&total=true&facets=true", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	minPrice, maxPrice, total := int64(100), int64(500), int64(2)
+
+	q := domain.ProductQuery{
+		Limit:      1,
+		Sort:       domain.ProductSortPrice,
+		Desc:       true,
+		Category:   "shirts",
+		Attributes: map[string][]string{"color": {"black", "white"}},
+		MinPrice:   &minPrice,
+		MaxPrice:   &maxPrice,
+		WithTotal:  true,
+		WithFacets: true,
+	}
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductUsecase.On("List", mock.Anything, q, "").Return(&domain.ProductPage{Products: []domain.Product{{ID: 1, UUID: "uuid", Pictures: []string{}, Attributes: []domain.Attribute{}, Price: 200, Status: domain.ProductStatusPublished, Version: 1}}, NextCursor: "cursor", Total: &total}, nil)
+
+	handler := NewProductHandler(echo.New(), mockProductUsecase, nil)
+
+	handler.List(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"products\":[{\"ID\":1,\"uuid\":\"uuid\",\"rate\":0,\"pictures\":[],\"name\":\"\",\"detail\":\"\",\"favorite\":false,\"attributes\":[],\"price\":200,\"status\":\"published\",\"version\":1}],\"nextCursor\":\"cursor\",\"total\":2}\n", rec.Body.String())
+}
+
+func TestSearchEmptyQuery(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/search?q=%20", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewProductHandler(echo.New(), nil, nil)
+
+	handler.Search(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSearchInvalidLimit(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/search?q=camiseta&limit=500", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewProductHandler(echo.New(), nil, nil)
+
+	handler.Search(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSearchError(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/search?q=camiseta", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductUsecase.On("Search", mock.Anything, "camiseta", 20, "").Return(nil, errors.New("error message"))
+
+	handler := NewProductHandler(echo.New(), mockProductUsecase, nil)
+
+	handler.Search(c)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestSearchSuccess(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/search?q=c%C3%B3digo&limit=5", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductUsecase.On("Search", mock.Anything, "código", 5, "user@test.com").Return(&domain.ProductPage{Products: []domain.Product{{ID: 1, UUID: "uuid", Pictures: []string{}, Attributes: []domain.Attribute{}, Status: domain.ProductStatusPublished, Version: 1}}}, nil)
+
+	handler := NewProductHandler(echo.New(), mockProductUsecase, nil)
+
+	handler.Search(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"products\":[{\"ID\":1,\"uuid\":\"uuid\",\"rate\":0,\"pictures\":[],\"name\":\"\",\"detail\":\"\",\"favorite\":false,\"attributes\":[],\"price\":0,\"status\":\"published\",\"version\":1}]}\n", rec.Body.String())
 }
@@ -0,0 +1,107 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type variantAdminHandler struct {
+	VariantUseCase   domain.VariantUseCase
+	VariantValidator domain.VariantValidator
+}
+
+type variantRequest struct {
+	Price    int64    `json:"price"`
+	Barcode  string   `json:"barcode"`
+	Pictures []string `json:"pictures"`
+}
+
+func NewVariantAdminHandler(e *echo.Echo, vuc domain.VariantUseCase, vv domain.VariantValidator, ts domain.TokenService) *variantAdminHandler {
+	handler := &variantAdminHandler{
+		VariantUseCase:   vuc,
+		VariantValidator: vv,
+	}
+
+	admin := _tokenPresentation.NewAdminMiddleware(ts)
+
+	e.POST("/admin/products/:uuid/variants", handler.Generate, admin)
+	e.PUT("/admin/products/:uuid/variants/:sku", handler.Update, admin)
+
+	return handler
+}
+
+func (vah *variantAdminHandler) Generate(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	variants, err := vah.VariantUseCase.Generate(c.Request().Context(), uuid)
+
+	if errors.Is(err, domain.ErrTooManyVariants) {
+		return c.JSON(http.StatusBadRequest, "the attributes of the product give too many variants")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to generate the variants: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to generate the variants")
+	}
+
+	if variants == nil {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{"variants": variants})
+}
+
+func (vah *variantAdminHandler) Update(c echo.Context) error {
+	uuid := c.Param("uuid")
+	sku := c.Param("sku")
+
+	if uuid == "" || sku == "" {
+		return c.JSON(http.StatusBadRequest, "uuid or sku param is not valid")
+	}
+
+	var req variantRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	variant := domain.Variant{SKU: sku, Price: req.Price, Barcode: req.Barcode, Pictures: req.Pictures}
+
+	if variant.Pictures == nil {
+		variant.Pictures = []string{}
+	}
+
+	ctx := c.Request().Context()
+
+	isValid, message := vah.VariantValidator.Validate(ctx, &variant)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	updated, err := vah.VariantUseCase.Update(ctx, uuid, &variant)
+
+	if errors.Is(err, domain.ErrBarcodeTaken) {
+		return c.JSON(http.StatusConflict, "barcode already in use")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to update a variant: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to update the variant")
+	}
+
+	if updated == nil {
+		return c.JSON(http.StatusNotFound, "variant not found")
+	}
+
+	return c.JSON(http.StatusOK, updated)
+}
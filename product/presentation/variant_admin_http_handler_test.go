@@ -0,0 +1,177 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGenerateVariantsNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/products/:uuid/variants", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	mockVariantUsecase := new(mocks.MockVariantUseCase)
+
+	mockVariantUsecase.On("Generate", mock.Anything, "testuuid").Return(nil, nil)
+
+	handler := NewVariantAdminHandler(echo.New(), mockVariantUsecase, nil, nil)
+
+	handler.Generate(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestGenerateVariantsTooMany(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/products/:uuid/variants", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	mockVariantUsecase := new(mocks.MockVariantUseCase)
+
+	mockVariantUsecase.On("Generate", mock.Anything, "testuuid").Return(nil, domain.ErrTooManyVariants)
+
+	handler := NewVariantAdminHandler(echo.New(), mockVariantUsecase, nil, nil)
+
+	handler.Generate(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGenerateVariants(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/admin/products/:uuid/variants", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("testuuid")
+
+	mockVariantUsecase := new(mocks.MockVariantUseCase)
+
+	mockVariantUsecase.On("Generate", mock.Anything, "testuuid").Return([]domain.Variant{{ID: 3, SKU: "P7-M", Price: 4990, Barcode: "2000000700014", Options: []domain.VariantOption{{Label: "size", Value: "M"}}, Pictures: []string{}}}, nil)
+
+	handler := NewVariantAdminHandler(echo.New(), mockVariantUsecase, nil, nil)
+
+	handler.Generate(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"variants\":[{\"sku\":\"P7-M\",\"options\":[{\"label\":\"size\",\"value\":\"M\"}],\"price\":4990,\"stock\":0,\"barcode\":\"2000000700014\",\"pictures\":[]}]}\n", rec.Body.String())
+}
+
+func TestUpdateVariantInvalid(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/products/:uuid/variants/:sku", strings.NewReader("{\"price\":-1}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid", "sku")
+	c.SetParamValues("testuuid", "P7-M")
+
+	mockVariantValidator := new(mocks.MockVariantValidator)
+
+	mockVariantValidator.On("Validate", mock.Anything, &domain.Variant{SKU: "P7-M", Price: -1, Pictures: []string{}}).Return(false, "error message")
+
+	handler := NewVariantAdminHandler(echo.New(), nil, mockVariantValidator, nil)
+
+	handler.Update(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "\"error message\"\n", rec.Body.String())
+}
+
+func TestUpdateVariantBarcodeTaken(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/products/:uuid/variants/:sku", strings.NewReader("{\"price\":4990,\"barcode\":\"7891234567895\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid", "sku")
+	c.SetParamValues("testuuid", "P7-M")
+
+	mockVariantUsecase := new(mocks.MockVariantUseCase)
+	mockVariantValidator := new(mocks.MockVariantValidator)
+
+	mockVariantValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockVariantUsecase.On("Update", mock.Anything, "testuuid", mock.Anything).Return(nil, domain.ErrBarcodeTaken)
+
+	handler := NewVariantAdminHandler(echo.New(), mockVariantUsecase, mockVariantValidator, nil)
+
+	handler.Update(c)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestUpdateVariantFailed(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/products/:uuid/variants/:sku", strings.NewReader("{\"price\":4990,\"barcode\":\"7891234567895\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid", "sku")
+	c.SetParamValues("testuuid", "P7-M")
+
+	mockVariantUsecase := new(mocks.MockVariantUseCase)
+	mockVariantValidator := new(mocks.MockVariantValidator)
+
+	mockVariantValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockVariantUsecase.On("Update", mock.Anything, "testuuid", mock.Anything).Return(nil, errors.New("error message"))
+
+	handler := NewVariantAdminHandler(echo.New(), mockVariantUsecase, mockVariantValidator, nil)
+
+	handler.Update(c)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestUpdateVariant(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/products/:uuid/variants/:sku", strings.NewReader("{\"price\":5990,\"barcode\":\"7891234567895\",\"pictures\":[\"m.png\"]}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid", "sku")
+	c.SetParamValues("testuuid", "P7-M")
+
+	variant := &domain.Variant{SKU: "P7-M", Price: 5990, Barcode: "7891234567895", Pictures: []string{"m.png"}}
+
+	mockVariantUsecase := new(mocks.MockVariantUseCase)
+	mockVariantValidator := new(mocks.MockVariantValidator)
+
+	mockVariantValidator.On("Validate", mock.Anything, variant).Return(true, "")
+	mockVariantUsecase.On("Update", mock.Anything, "testuuid", variant).Return(&domain.Variant{ID: 3, ProductID: 7, SKU: "P7-M", Price: 5990, Stock: 2, Barcode: "7891234567895", Options: []domain.VariantOption{{Label: "size", Value: "M"}}, Pictures: []string{"m.png"}}, nil)
+
+	handler := NewVariantAdminHandler(echo.New(), mockVariantUsecase, mockVariantValidator, nil)
+
+	handler.Update(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "\"sku\":\"P7-M\"")
+}
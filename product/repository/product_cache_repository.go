@@ -0,0 +1,342 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+// productsGenerationKey holds the generation of the cached pages, a write
+// drops it and the pages of the generation before are never read again.
+const productsGenerationKey = "products:generation"
+
+// productCacheRepository reads the products through the cache, keyed by
+// their uuid, and the slugs and the pages of products too. The writes made
+// through it drop the cached copies of the products they change, the ones
+// made around it, like the variants, the stock, the reviews and the
+// translations, call Touch. The ttl bounds how long a read racing with a
+// write may keep the copy it read.
+type productCacheRepository struct {
+	ProductRepository domain.ProductRepository
+	Cache             domain.Cache
+	TTL               time.Duration
+}
+
+// cachedProduct keeps the content version, which is never shown.
+type cachedProduct struct {
+	domain.Product
+	ContentVersion int64
+}
+
+func NewProductCacheRepository(pr domain.ProductRepository, c domain.Cache, ttl time.Duration) domain.ProductRepository {
+	return &productCacheRepository{ProductRepository: pr, Cache: c, TTL: ttl}
+}
+
+func (pcr *productCacheRepository) GetByUUID(ctx context.Context, uuid string) (*domain.Product, error) {
+	if p := pcr.cached(ctx, uuid); p != nil {
+		return p, nil
+	}
+
+	p, err := pcr.ProductRepository.GetByUUID(ctx, uuid)
+
+	if err != nil || p == nil {
+		return p, err
+	}
+
+	pcr.keep(ctx, p)
+
+	return p, nil
+}
+
+func (pcr *productCacheRepository) GetUUIDs(ctx context.Context, productIDs []int64) ([]string, error) {
+	return pcr.ProductRepository.GetUUIDs(ctx, productIDs)
+}
+
+func (pcr *productCacheRepository) GetIDsByAttribute(ctx context.Context, label string) ([]int64, error) {
+	return pcr.ProductRepository.GetIDsByAttribute(ctx, label)
+}
+
+// GetBySlug keeps the uuid of the slug, it is only taken while the product
+// still has that slug.
+func (pcr *productCacheRepository) GetBySlug(ctx context.Context, slug string) (*domain.Product, error) {
+	key := "product-slug:" + slug
+
+	cached, err := pcr.Cache.Get(ctx, key)
+
+	if err != nil {
+		log.Printf("Error trying to get a slug from the cache: %s", err.Error())
+	}
+
+	if cached != nil {
+		p, err := pcr.GetByUUID(ctx, string(cached))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if p != nil && p.Slug == slug {
+			return p, nil
+		}
+	}
+
+	p, err := pcr.ProductRepository.GetBySlug(ctx, slug)
+
+	if err != nil || p == nil {
+		return p, err
+	}
+
+	if err := pcr.Cache.Set(ctx, key, []byte(p.UUID), pcr.TTL); err != nil {
+		log.Printf("Error trying to cache a slug: %s", err.Error())
+	}
+
+	pcr.keep(ctx, p)
+
+	return p, nil
+}
+
+func (pcr *productCacheRepository) GetByOldSlug(ctx context.Context, slug string) (*domain.Product, error) {
+	return pcr.ProductRepository.GetByOldSlug(ctx, slug)
+}
+
+func (pcr *productCacheRepository) SlugTaken(ctx context.Context, slug string, productID int64) (bool, error) {
+	return pcr.ProductRepository.SlugTaken(ctx, slug, productID)
+}
+
+// GetByUUIDs gives the products in the order of the uuids, only the ones
+// not cached are read.
+func (pcr *productCacheRepository) GetByUUIDs(ctx context.Context, uuids []string) ([]domain.Product, error) {
+	byUUID := map[string]domain.Product{}
+	missing := []string{}
+
+	for _, uuid := range uuids {
+		if p := pcr.cached(ctx, uuid); p != nil {
+			byUUID[uuid] = *p
+		} else {
+			missing = append(missing, uuid)
+		}
+	}
+
+	if len(missing) > 0 {
+		found, err := pcr.ProductRepository.GetByUUIDs(ctx, missing)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range found {
+			pcr.keep(ctx, &found[i])
+			byUUID[found[i].UUID] = found[i]
+		}
+	}
+
+	res := []domain.Product{}
+
+	for _, uuid := range uuids {
+		if p, ok := byUUID[uuid]; ok {
+			res = append(res, p)
+			delete(byUUID, uuid)
+		}
+	}
+
+	return res, nil
+}
+
+func (pcr *productCacheRepository) List(ctx context.Context, q domain.ProductQuery) (*domain.ProductPage, error) {
+	key := pcr.pageKey(ctx, "list", q)
+
+	if key != "" {
+		var page domain.ProductPage
+
+		if pcr.get(ctx, key, &page) {
+			return &page, nil
+		}
+	}
+
+	page, err := pcr.ProductRepository.List(ctx, q)
+
+	if err != nil || key == "" {
+		return page, err
+	}
+
+	pcr.set(ctx, key, page)
+
+	return page, nil
+}
+
+func (pcr *productCacheRepository) Facets(ctx context.Context, q domain.ProductQuery) (*domain.ProductFacets, error) {
+	key := pcr.pageKey(ctx, "facets", q)
+
+	if key != "" {
+		var facets domain.ProductFacets
+
+		if pcr.get(ctx, key, &facets) {
+			return &facets, nil
+		}
+	}
+
+	facets, err := pcr.ProductRepository.Facets(ctx, q)
+
+	if err != nil || key == "" {
+		return facets, err
+	}
+
+	pcr.set(ctx, key, facets)
+
+	return facets, nil
+}
+
+func (pcr *productCacheRepository) GetFavoriteProductIDs(ctx context.Context, userID int64, productIDs []int64) (map[int64]bool, error) {
+	return pcr.ProductRepository.GetFavoriteProductIDs(ctx, userID, productIDs)
+}
+
+func (pcr *productCacheRepository) Store(ctx context.Context, p *domain.Product) error {
+	if err := pcr.ProductRepository.Store(ctx, p); err != nil {
+		return err
+	}
+
+	pcr.forgetPages(ctx)
+
+	return nil
+}
+
+func (pcr *productCacheRepository) Update(ctx context.Context, p *domain.Product) error {
+	if err := pcr.ProductRepository.Update(ctx, p); err != nil {
+		return err
+	}
+
+	pcr.forget(ctx, p.UUID)
+	pcr.forgetPages(ctx)
+
+	return nil
+}
+
+func (pcr *productCacheRepository) Delete(ctx context.Context, uuid string, version int64) error {
+	if err := pcr.ProductRepository.Delete(ctx, uuid, version); err != nil {
+		return err
+	}
+
+	pcr.forget(ctx, uuid)
+	pcr.forgetPages(ctx)
+
+	return nil
+}
+
+func (pcr *productCacheRepository) Touch(ctx context.Context, productIDs []int64) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	if err := pcr.ProductRepository.Touch(ctx, productIDs); err != nil {
+		return err
+	}
+
+	uuids, err := pcr.ProductRepository.GetUUIDs(ctx, productIDs)
+
+	if err != nil {
+		return err
+	}
+
+	for _, uuid := range uuids {
+		pcr.forget(ctx, uuid)
+	}
+
+	pcr.forgetPages(ctx)
+
+	return nil
+}
+
+func (pcr *productCacheRepository) cached(ctx context.Context, uuid string) *domain.Product {
+	var c cachedProduct
+
+	if !pcr.get(ctx, productCacheKey(uuid), &c) {
+		return nil
+	}
+
+	c.Product.ContentVersion = c.ContentVersion
+
+	return &c.Product
+}
+
+func (pcr *productCacheRepository) keep(ctx context.Context, p *domain.Product) {
+	pcr.set(ctx, productCacheKey(p.UUID), cachedProduct{Product: *p, ContentVersion: p.ContentVersion})
+}
+
+func (pcr *productCacheRepository) forget(ctx context.Context, uuid string) {
+	if err := pcr.Cache.Delete(ctx, productCacheKey(uuid)); err != nil {
+		log.Printf("Error trying to drop a product from the cache: %s", err.Error())
+	}
+}
+
+func (pcr *productCacheRepository) forgetPages(ctx context.Context) {
+	if err := pcr.Cache.Delete(ctx, productsGenerationKey); err != nil {
+		log.Printf("Error trying to drop the product pages from the cache: %s", err.Error())
+	}
+}
+
+// pageKey is empty for the pages not cached: the ones of a user, and the
+// ones of categories and collections, which change without a product write.
+func (pcr *productCacheRepository) pageKey(ctx context.Context, kind string, q domain.ProductQuery) string {
+	if q.FavoriteOf != 0 || q.Wishlist != 0 || q.Category != "" || q.Collection != "" {
+		return ""
+	}
+
+	generation, err := pcr.Cache.Get(ctx, productsGenerationKey)
+
+	if err != nil {
+		log.Printf("Error trying to get the product pages generation from the cache: %s", err.Error())
+		return ""
+	}
+
+	if generation == nil {
+		generation = []byte(strconv.FormatInt(time.Now().UnixNano(), 36))
+
+		if err := pcr.Cache.Set(ctx, productsGenerationKey, generation, pcr.TTL); err != nil {
+			log.Printf("Error trying to cache the product pages generation: %s", err.Error())
+			return ""
+		}
+	}
+
+	query, err := json.Marshal(q)
+
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(query)
+
+	return "products:" + string(generation) + ":" + kind + ":" + hex.EncodeToString(sum[:])
+}
+
+func (pcr *productCacheRepository) get(ctx context.Context, key string, v interface{}) bool {
+	cached, err := pcr.Cache.Get(ctx, key)
+
+	if err != nil {
+		log.Printf("Error trying to get a product from the cache: %s", err.Error())
+		return false
+	}
+
+	return cached != nil && json.Unmarshal(cached, v) == nil
+}
+
+func (pcr *productCacheRepository) set(ctx context.Context, key string, v interface{}) {
+	value, err := json.Marshal(v)
+
+	if err != nil {
+		log.Printf("Error trying to cache a product: %s", err.Error())
+		return
+	}
+
+	if err := pcr.Cache.Set(ctx, key, value, pcr.TTL); err != nil {
+		log.Printf("Error trying to cache a product: %s", err.Error())
+	}
+}
+
+func productCacheKey(uuid string) string {
+	return "product:" + uuid
+}
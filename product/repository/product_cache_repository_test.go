@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCacheGetByUUIDHit(t *testing.T) {
+	mockCache := new(mocks.MockCache)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockCache.On("Get", mock.Anything, "product:uuid").Return([]byte(`{"ID":1,"uuid":"uuid","name":"name","version":3,"ContentVersion":5}`), nil)
+
+	repo := NewProductCacheRepository(mockProductRepo, mockCache, time.Minute)
+
+	p, err := repo.GetByUUID(context.Background(), "uuid")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Product{ID: 1, UUID: "uuid", Name: "name", Version: 3, ContentVersion: 5}, p)
+	mockProductRepo.AssertNotCalled(t, "GetByUUID", mock.Anything, mock.Anything)
+}
+
+func TestCacheGetByUUIDMiss(t *testing.T) {
+	mockCache := new(mocks.MockCache)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	product := &domain.Product{ID: 1, UUID: "uuid", Name: "name", Version: 3, ContentVersion: 5}
+
+	mockCache.On("Get", mock.Anything, "product:uuid").Return(nil, nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(product, nil)
+	mockCache.On("Set", mock.Anything, "product:uuid", mock.MatchedBy(func(value []byte) bool {
+		var c cachedProduct
+		return json.Unmarshal(value, &c) == nil && c.UUID == "uuid" && c.ContentVersion == 5
+	}), time.Minute).Return(nil)
+
+	repo := NewProductCacheRepository(mockProductRepo, mockCache, time.Minute)
+
+	p, err := repo.GetByUUID(context.Background(), "uuid")
+
+	assert.NoError(t, err)
+	assert.Equal(t, product, p)
+	mockCache.AssertExpectations(t)
+}
+
+func TestCacheGetByUUIDNotFound(t *testing.T) {
+	mockCache := new(mocks.MockCache)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockCache.On("Get", mock.Anything, "product:uuid").Return(nil, nil)
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(nil, nil)
+
+	repo := NewProductCacheRepository(mockProductRepo, mockCache, time.Minute)
+
+	p, err := repo.GetByUUID(context.Background(), "uuid")
+
+	assert.NoError(t, err)
+	assert.Nil(t, p)
+	mockCache.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCacheGetByUUIDCacheDown(t *testing.T) {
+	mockCache := new(mocks.MockCache)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	product := &domain.Product{ID: 1, UUID: "uuid", Version: 1}
+
+	mockCache.On("Get", mock.Anything, "product:uuid").Return(nil, errors.New("connection refused"))
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(product, nil)
+	mockCache.On("Set", mock.Anything, "product:uuid", mock.Anything, time.Minute).Return(errors.New("connection refused"))
+
+	repo := NewProductCacheRepository(mockProductRepo, mockCache, time.Minute)
+
+	p, err := repo.GetByUUID(context.Background(), "uuid")
+
+	assert.NoError(t, err)
+	assert.Equal(t, product, p)
+}
+
+func TestCacheGetBySlugHit(t *testing.T) {
+	mockCache := new(mocks.MockCache)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockCache.On("Get", mock.Anything, "product-slug:shirt").Return([]byte("uuid"), nil)
+	mockCache.On("Get", mock.Anything, "product:uuid").Return([]byte(`{"uuid":"uuid","slug":"shirt"}`), nil)
+
+	repo := NewProductCacheRepository(mockProductRepo, mockCache, time.Minute)
+
+	p, err := repo.GetBySlug(context.Background(), "shirt")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "uuid", p.UUID)
+	mockProductRepo.AssertNotCalled(t, "GetBySlug", mock.Anything, mock.Anything)
+}
+
+// TestCacheGetBySlugRenamed plays a product renamed since its slug was
+// cached, the slug is read again.
+func TestCacheGetBySlugRenamed(t *testing.T) {
+	mockCache := new(mocks.MockCache)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockCache.On("Get", mock.Anything, "product-slug:shirt").Return([]byte("uuid"), nil)
+	mockCache.On("Get", mock.Anything, "product:uuid").Return([]byte(`{"uuid":"uuid","slug":"t-shirt"}`), nil)
+	mockProductRepo.On("GetBySlug", mock.Anything, "shirt").Return(nil, nil)
+
+	repo := NewProductCacheRepository(mockProductRepo, mockCache, time.Minute)
+
+	p, err := repo.GetBySlug(context.Background(), "shirt")
+
+	assert.NoError(t, err)
+	assert.Nil(t, p)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestCacheGetByUUIDsReadsOnlyTheMissing(t *testing.T) {
+	mockCache := new(mocks.MockCache)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockCache.On("Get", mock.Anything, "product:a").Return([]byte(`{"uuid":"a"}`), nil)
+	mockCache.On("Get", mock.Anything, "product:b").Return(nil, nil)
+	mockCache.On("Get", mock.Anything, "product:c").Return(nil, nil)
+	mockProductRepo.On("GetByUUIDs", mock.Anything, []string{"b", "c"}).Return([]domain.Product{{UUID: "c"}, {UUID: "b"}}, nil)
+	mockCache.On("Set", mock.Anything, "product:b", mock.Anything, time.Minute).Return(nil)
+	mockCache.On("Set", mock.Anything, "product:c", mock.Anything, time.Minute).Return(nil)
+
+	repo := NewProductCacheRepository(mockProductRepo, mockCache, time.Minute)
+
+	products, err := repo.GetByUUIDs(context.Background(), []string{"b", "a", "c"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Product{{UUID: "b"}, {UUID: "a"}, {UUID: "c"}}, products)
+	mockCache.AssertExpectations(t)
+}
+
+func TestCacheListHit(t *testing.T) {
+	mockCache := new(mocks.MockCache)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockCache.On("Get", mock.Anything, productsGenerationKey).Return([]byte("g1"), nil)
+	mockCache.On("Get", mock.Anything, mock.MatchedBy(func(key string) bool { return len(key) > 15 && key[:15] == "products:g1:lis" })).
+		Return([]byte(`{"products":[{"uuid":"a"}],"nextCursor":"next"}`), nil)
+
+	repo := NewProductCacheRepository(mockProductRepo, mockCache, time.Minute)
+
+	page, err := repo.List(context.Background(), domain.ProductQuery{Limit: 20, Status: domain.ProductStatusPublished})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "next", page.NextCursor)
+	assert.Equal(t, "a", page.Products[0].UUID)
+	mockProductRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
+
+func TestCacheListMiss(t *testing.T) {
+	mockCache := new(mocks.MockCache)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	q := domain.ProductQuery{Limit: 20, Status: domain.ProductStatusPublished}
+	page := &domain.ProductPage{Products: []domain.Product{{UUID: "a"}}}
+
+	mockCache.On("Get", mock.Anything, productsGenerationKey).Return(nil, nil)
+	mockCache.On("Set", mock.Anything, productsGenerationKey, mock.Anything, time.Minute).Return(nil)
+	mockCache.On("Get", mock.Anything, mock.AnythingOfType("string")).Return(nil, nil)
+	mockProductRepo.On("List", mock.Anything, q).Return(page, nil)
+	mockCache.On("Set", mock.Anything, mock.AnythingOfType("string"), mock.Anything, time.Minute).Return(nil)
+
+	repo := NewProductCacheRepository(mockProductRepo, mockCache, time.Minute)
+
+	res, err := repo.List(context.Background(), q)
+
+	assert.NoError(t, err)
+	assert.Equal(t, page, res)
+	mockCache.AssertNumberOfCalls(t, "Set", 2)
+}
+
+// TestCacheListCategoryNotCached plays a category page, which changes when
+// a category gets a product without a product write.
+func TestCacheListCategoryNotCached(t *testing.T) {
+	mockCache := new(mocks.MockCache)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	q := domain.ProductQuery{Limit: 20, Category: "shirts"}
+	page := &domain.ProductPage{Products: []domain.Product{}}
+
+	mockProductRepo.On("List", mock.Anything, q).Return(page, nil)
+
+	repo := NewProductCacheRepository(mockProductRepo, mockCache, time.Minute)
+
+	res, err := repo.List(context.Background(), q)
+
+	assert.NoError(t, err)
+	assert.Equal(t, page, res)
+	mockCache.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	mockCache.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCacheUpdateForgetsTheProduct(t *testing.T) {
+	mockCache := new(mocks.MockCache)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	product := &domain.Product{ID: 1, UUID: "uuid", Version: 3}
+
+	mockProductRepo.On("Update", mock.Anything, product).Return(nil)
+	mockCache.On("Delete", mock.Anything, "product:uuid").Return(nil)
+	mockCache.On("Delete", mock.Anything, productsGenerationKey).Return(nil)
+
+	repo := NewProductCacheRepository(mockProductRepo, mockCache, time.Minute)
+
+	assert.NoError(t, repo.Update(context.Background(), product))
+	mockCache.AssertExpectations(t)
+}
+
+func TestCacheUpdateVersionConflict(t *testing.T) {
+	mockCache := new(mocks.MockCache)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	product := &domain.Product{ID: 1, UUID: "uuid", Version: 3}
+
+	mockProductRepo.On("Update", mock.Anything, product).Return(domain.ErrVersionConflict)
+
+	repo := NewProductCacheRepository(mockProductRepo, mockCache, time.Minute)
+
+	assert.ErrorIs(t, repo.Update(context.Background(), product), domain.ErrVersionConflict)
+	mockCache.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestCacheDeleteForgetsTheProduct(t *testing.T) {
+	mockCache := new(mocks.MockCache)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("Delete", mock.Anything, "uuid", int64(3)).Return(nil)
+	mockCache.On("Delete", mock.Anything, "product:uuid").Return(nil)
+	mockCache.On("Delete", mock.Anything, productsGenerationKey).Return(nil)
+
+	repo := NewProductCacheRepository(mockProductRepo, mockCache, time.Minute)
+
+	assert.NoError(t, repo.Delete(context.Background(), "uuid", 3))
+	mockCache.AssertExpectations(t)
+}
+
+func TestCacheTouch(t *testing.T) {
+	mockCache := new(mocks.MockCache)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("Touch", mock.Anything, []int64{1, 2}).Return(nil)
+	mockProductRepo.On("GetUUIDs", mock.Anything, []int64{1, 2}).Return([]string{"a", "b"}, nil)
+	mockCache.On("Delete", mock.Anything, "product:a").Return(nil)
+	mockCache.On("Delete", mock.Anything, "product:b").Return(nil)
+	mockCache.On("Delete", mock.Anything, productsGenerationKey).Return(nil)
+
+	repo := NewProductCacheRepository(mockProductRepo, mockCache, time.Minute)
+
+	assert.NoError(t, repo.Touch(context.Background(), []int64{1, 2}))
+	mockCache.AssertExpectations(t)
+}
@@ -3,8 +3,14 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/google/uuid"
 )
 
 type productMysqlRepository struct {
@@ -15,14 +21,617 @@ func NewProductMysqlRepository(conn *sql.DB) domain.ProductRepository {
 	return &productMysqlRepository{Conn: conn}
 }
 
-func (pmr *productMysqlRepository) GetByUUID(ctx context.Context, uuid string) (*domain.Product, error) {
-	query := `SELECT id, uuid, name, detail FROM product WHERE uuid = ?;`
+func (pmr *productMysqlRepository) GetByUUID(ctx context.Context, productUUID string) (*domain.Product, error) {
+	query := `SELECT id, uuid, slug, name, detail, rate, price, status, version, content_version, meta_title, meta_description, canonical_url FROM product WHERE uuid = ?;`
 
-	row := pmr.Conn.QueryRowContext(ctx, query, uuid)
+	return pmr.get(ctx, query, productUUID)
+}
+
+// GetUUIDs leaves out the products not found.
+func (pmr *productMysqlRepository) GetUUIDs(ctx context.Context, productIDs []int64) ([]string, error) {
+	res := []string{}
+
+	if len(productIDs) == 0 {
+		return res, nil
+	}
+
+	args := make([]interface{}, len(productIDs))
+	for i, id := range productIDs {
+		args[i] = id
+	}
+
+	rows, err := pmr.Conn.QueryContext(ctx, `SELECT uuid FROM product WHERE id IN (`+placeholders(len(productIDs))+`);`, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var productUUID string
+
+		if err := rows.Scan(&productUUID); err != nil {
+			return nil, err
+		}
+
+		res = append(res, productUUID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetIDsByAttribute gives the products showing the attribute label.
+func (pmr *productMysqlRepository) GetIDsByAttribute(ctx context.Context, label string) ([]int64, error) {
+	rows, err := pmr.Conn.QueryContext(ctx, `SELECT DISTINCT product_id FROM product_attribute WHERE label = ?;`, label)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []int64{}
+
+	for rows.Next() {
+		var productID int64
+
+		if err := rows.Scan(&productID); err != nil {
+			return nil, err
+		}
+
+		res = append(res, productID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (pmr *productMysqlRepository) GetBySlug(ctx context.Context, slug string) (*domain.Product, error) {
+	query := `SELECT id, uuid, slug, name, detail, rate, price, status, version, content_version, meta_title, meta_description, canonical_url FROM product WHERE slug = ?;`
+
+	return pmr.get(ctx, query, slug)
+}
+
+func (pmr *productMysqlRepository) GetByOldSlug(ctx context.Context, slug string) (*domain.Product, error) {
+	query := `SELECT p.id, p.uuid, p.slug, p.name, p.detail, p.rate, p.price, p.status, p.version, p.content_version, p.meta_title, p.meta_description, p.canonical_url FROM product p JOIN product_slug ps ON ps.product_id = p.id WHERE ps.slug = ?;`
+
+	return pmr.get(ctx, query, slug)
+}
+
+// SlugTaken also counts the old slugs, they keep leading to their product.
+func (pmr *productMysqlRepository) SlugTaken(ctx context.Context, slug string, productID int64) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM product WHERE slug = ? AND id <> ?) OR EXISTS(SELECT 1 FROM product_slug WHERE slug = ? AND product_id <> ?);`
+
+	var taken bool
+
+	if err := pmr.Conn.QueryRowContext(ctx, query, slug, productID, slug, productID).Scan(&taken); err != nil {
+		return false, err
+	}
+
+	return taken, nil
+}
+
+func (pmr *productMysqlRepository) GetByUUIDs(ctx context.Context, productUUIDs []string) ([]domain.Product, error) {
+	res := []domain.Product{}
+
+	if len(productUUIDs) == 0 {
+		return res, nil
+	}
+
+	query := `SELECT id, uuid, slug, name, detail, rate, price, status, version, content_version, meta_title, meta_description, canonical_url FROM product WHERE uuid IN (` + placeholders(len(productUUIDs)) + `);`
+
+	args := []interface{}{}
+	for _, u := range productUUIDs {
+		args = append(args, u)
+	}
+
+	rows, err := pmr.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var p domain.Product
+
+		if err := rows.Scan(&p.ID, &p.UUID, &p.Slug, &p.Name, &p.Detail, &p.Rate, &p.Price, &p.Status, &p.Version, &p.ContentVersion, &p.MetaTitle, &p.MetaDescription, &p.CanonicalURL); err != nil {
+			return nil, err
+		}
+
+		res = append(res, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	products := make([]*domain.Product, len(res))
+	for i := range res {
+		products[i] = &res[i]
+	}
+
+	if err := pmr.fillDetails(ctx, products); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+var productSortColumns = map[domain.ProductSort]string{
+	domain.ProductSortName:   "p.name",
+	domain.ProductSortRate:   "p.rate",
+	domain.ProductSortPrice:  "p.price",
+	domain.ProductSortNewest: "p.created_at",
+}
+
+type productCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    int64  `json:"id"`
+}
+
+func (pmr *productMysqlRepository) List(ctx context.Context, q domain.ProductQuery) (*domain.ProductPage, error) {
+	column, ok := productSortColumns[q.Sort]
+
+	if !ok {
+		return nil, fmt.Errorf("product sort %s is not supported", q.Sort)
+	}
+
+	if q.Limit <= 0 {
+		return nil, fmt.Errorf("product limit must be positive, got %d", q.Limit)
+	}
+
+	desc := q.Desc || q.Sort == domain.ProductSortNewest
+
+	sortKey := string(q.Sort)
+	if desc {
+		sortKey = "-" + sortKey
+	}
+
+	where, args := productFilters(q)
+
+	res := &domain.ProductPage{Products: []domain.Product{}}
+
+	if q.WithTotal {
+		countQuery := `SELECT COUNT(*) FROM product p` + whereClause(where) + `;`
+
+		var total int64
+
+		if err := pmr.Conn.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+			return nil, err
+		}
+
+		res.Total = &total
+	}
+
+	operator, direction := ">", "ASC"
+	if desc {
+		operator, direction = "<", "DESC"
+	}
+
+	if q.Cursor != "" {
+		cursor, err := decodeProductCursor(q.Cursor)
+
+		if err != nil || cursor.Sort != sortKey {
+			return nil, domain.ErrInvalidCursor
+		}
+
+		where = append(where, fmt.Sprintf("(%s %s ? OR (%s = ? AND p.id %s ?))", column, operator, column, operator))
+		args = append(args, cursor.Value, cursor.Value, cursor.ID)
+	}
+
+	query := fmt.Sprintf(`SELECT p.id, p.uuid, p.slug, p.name, p.detail, p.rate, p.price, p.status, p.version, p.content_version, p.meta_title, p.meta_description, p.canonical_url, %s FROM product p%s ORDER BY %s %s, p.id %s LIMIT ?;`, column, whereClause(where), column, direction, direction)
+	args = append(args, q.Limit+1)
+
+	rows, err := pmr.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	sortValues := []string{}
+
+	for rows.Next() {
+		var p domain.Product
+		var sortValue string
+
+		if err := rows.Scan(&p.ID, &p.UUID, &p.Slug, &p.Name, &p.Detail, &p.Rate, &p.Price, &p.Status, &p.Version, &p.ContentVersion, &p.MetaTitle, &p.MetaDescription, &p.CanonicalURL, &sortValue); err != nil {
+			return nil, err
+		}
+
+		res.Products = append(res.Products, p)
+		sortValues = append(sortValues, sortValue)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(res.Products) > q.Limit {
+		res.Products = res.Products[:q.Limit]
+		last := res.Products[q.Limit-1]
+		res.NextCursor = encodeProductCursor(productCursor{Sort: sortKey, Value: sortValues[q.Limit-1], ID: last.ID})
+	}
+
+	products := make([]*domain.Product, len(res.Products))
+	for i := range res.Products {
+		products[i] = &res.Products[i]
+	}
+
+	if err := pmr.fillDetails(ctx, products); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// productPriceBuckets are the limits, in cents, of the price facet buckets.
+var productPriceBuckets = []int64{5000, 10000, 20000, 50000}
+
+var productRatingBuckets = []int{4, 3, 2, 1}
+
+// Facets counts the products matching the query filters by attribute value,
+// category, price bucket and rating, ignoring the cursor and the sort.
+func (pmr *productMysqlRepository) Facets(ctx context.Context, q domain.ProductQuery) (*domain.ProductFacets, error) {
+	where, args := productFilters(q)
+
+	res := &domain.ProductFacets{
+		Attributes: []domain.AttributeFacet{},
+		Categories: []domain.FacetValue{},
+		Prices:     []domain.PriceFacet{},
+		Ratings:    make([]domain.RatingFacet, len(productRatingBuckets)),
+	}
+
+	attributesQuery := `SELECT fa.label, fav.value, COUNT(DISTINCT p.id) FROM product p JOIN product_attribute fa ON fa.product_id = p.id JOIN product_attribute_value fav ON fav.attribute_id = fa.id` + whereClause(where) + ` GROUP BY fa.label, fav.value ORDER BY fa.label, fav.value;`
+
+	attributeRows, err := pmr.Conn.QueryContext(ctx, attributesQuery, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer attributeRows.Close()
+
+	for attributeRows.Next() {
+		var label string
+		var value domain.FacetValue
+
+		if err := attributeRows.Scan(&label, &value.Value, &value.Count); err != nil {
+			return nil, err
+		}
+
+		if len(res.Attributes) == 0 || res.Attributes[len(res.Attributes)-1].Label != label {
+			res.Attributes = append(res.Attributes, domain.AttributeFacet{Label: label, Values: []domain.FacetValue{}})
+		}
+
+		last := &res.Attributes[len(res.Attributes)-1]
+		last.Values = append(last.Values, value)
+	}
+
+	if err := attributeRows.Err(); err != nil {
+		return nil, err
+	}
+
+	categoriesQuery := `SELECT fc.slug, fc.name, COUNT(DISTINCT p.id) FROM product p JOIN product_category fpc ON fpc.product_id = p.id JOIN category fc ON fc.id = fpc.category_id` + whereClause(where) + ` GROUP BY fc.id, fc.slug, fc.name ORDER BY fc.name;`
+
+	categoryRows, err := pmr.Conn.QueryContext(ctx, categoriesQuery, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer categoryRows.Close()
+
+	for categoryRows.Next() {
+		var value domain.FacetValue
+
+		if err := categoryRows.Scan(&value.Value, &value.Name, &value.Count); err != nil {
+			return nil, err
+		}
+
+		res.Categories = append(res.Categories, value)
+	}
+
+	if err := categoryRows.Err(); err != nil {
+		return nil, err
+	}
+
+	cases := ""
+	for i, limit := range productPriceBuckets {
+		cases += fmt.Sprintf(" WHEN p.price < %d THEN %d", limit, i)
+	}
+
+	pricesQuery := `SELECT CASE` + cases + fmt.Sprintf(` ELSE %d END AS bucket, COUNT(*) FROM product p`, len(productPriceBuckets)) + whereClause(where) + ` GROUP BY bucket ORDER BY bucket;`
+
+	priceRows, err := pmr.Conn.QueryContext(ctx, pricesQuery, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer priceRows.Close()
+
+	for priceRows.Next() {
+		var bucket int
+		var facet domain.PriceFacet
+
+		if err := priceRows.Scan(&bucket, &facet.Count); err != nil {
+			return nil, err
+		}
+
+		if bucket > 0 {
+			facet.Min = productPriceBuckets[bucket-1]
+		}
+
+		if bucket < len(productPriceBuckets) {
+			max := productPriceBuckets[bucket]
+			facet.Max = &max
+		}
+
+		res.Prices = append(res.Prices, facet)
+	}
+
+	if err := priceRows.Err(); err != nil {
+		return nil, err
+	}
+
+	sums := make([]string, len(productRatingBuckets))
+	counts := make([]interface{}, len(productRatingBuckets))
+
+	for i, min := range productRatingBuckets {
+		sums[i] = fmt.Sprintf("COALESCE(SUM(p.rate >= %d), 0)", min)
+		res.Ratings[i].Min = min
+		counts[i] = &res.Ratings[i].Count
+	}
+
+	ratingsQuery := `SELECT ` + strings.Join(sums, ", ") + ` FROM product p` + whereClause(where) + `;`
+
+	if err := pmr.Conn.QueryRowContext(ctx, ratingsQuery, args...).Scan(counts...); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (pmr *productMysqlRepository) GetFavoriteProductIDs(ctx context.Context, userID int64, productIDs []int64) (map[int64]bool, error) {
+	res := map[int64]bool{}
+
+	if len(productIDs) == 0 {
+		return res, nil
+	}
+
+	query := `SELECT product_id FROM favorite WHERE user_id = ? AND product_id IN (` + placeholders(len(productIDs)) + `);`
+
+	args := []interface{}{userID}
+	for _, id := range productIDs {
+		args = append(args, id)
+	}
+
+	rows, err := pmr.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID int64
+
+		if err := rows.Scan(&productID); err != nil {
+			return nil, err
+		}
+
+		res[productID] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (pmr *productMysqlRepository) Store(ctx context.Context, p *domain.Product) error {
+	query := `INSERT INTO product (uuid, slug, name, detail, price, status, meta_title, meta_description, canonical_url, version, content_version) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 1, 1);`
+
+	tx, err := pmr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	p.UUID = uuid.NewString()
+
+	exec, err := tx.ExecContext(ctx, query, p.UUID, p.Slug, p.Name, p.Detail, p.Price, p.Status, p.MetaTitle, p.MetaDescription, p.CanonicalURL)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if p.ID, err = exec.LastInsertId(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = storeDetails(ctx, tx, p); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	p.Version, p.ContentVersion = 1, 1
+
+	return nil
+}
+
+// Update keeps the slug being replaced in the history, and takes the new
+// one out of it when the product gets an old slug back.
+func (pmr *productMysqlRepository) Update(ctx context.Context, p *domain.Product) error {
+	query := `UPDATE product SET slug = ?, name = ?, detail = ?, price = ?, status = ?, meta_title = ?, meta_description = ?, canonical_url = ?, version = version + 1, content_version = content_version + 1 WHERE id = ? AND version = ?;`
+
+	tx, err := pmr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM product_slug WHERE slug = ? AND product_id = ?;`, p.Slug, p.ID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, `INSERT INTO product_slug (slug, product_id) SELECT slug, id FROM product WHERE id = ? AND version = ? AND slug <> ?;`, p.ID, p.Version, p.Slug); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	exec, err := tx.ExecContext(ctx, query, p.Slug, p.Name, p.Detail, p.Price, p.Status, p.MetaTitle, p.MetaDescription, p.CanonicalURL, p.ID, p.Version)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if affect != 1 {
+		tx.Rollback()
+		return domain.ErrVersionConflict
+	}
+
+	if err = deleteDetails(ctx, tx, p.ID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = storeDetails(ctx, tx, p); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	p.Version++
+	p.ContentVersion++
+
+	return nil
+}
+
+func (pmr *productMysqlRepository) Delete(ctx context.Context, productUUID string, version int64) error {
+	query := `DELETE FROM product WHERE uuid = ? AND version = ?;`
+
+	stmt, err := pmr.Conn.PrepareContext(ctx, query)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := stmt.ExecContext(ctx, productUUID, version)
+
+	if err != nil {
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affect != 1 {
+		return domain.ErrVersionConflict
+	}
+
+	return nil
+}
+
+// Touch bumps the content version of the products only, the version is
+// left to the writes of the admins.
+func (pmr *productMysqlRepository) Touch(ctx context.Context, productIDs []int64) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, len(productIDs))
+	for i, id := range productIDs {
+		args[i] = id
+	}
+
+	_, err := pmr.Conn.ExecContext(ctx, `UPDATE product SET content_version = content_version + 1 WHERE id IN (`+placeholders(len(productIDs))+`);`, args...)
+
+	return err
+}
+
+func deleteDetails(ctx context.Context, tx *sql.Tx, productID int64) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM product_picture WHERE product_id = ?;`, productID); err != nil {
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, `DELETE FROM product_attribute WHERE product_id = ?;`, productID)
+
+	return err
+}
+
+func storeDetails(ctx context.Context, tx *sql.Tx, p *domain.Product) error {
+	for i, picture := range p.Pictures {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO product_picture (product_id, path, position) VALUES (?, ?, ?);`, p.ID, picture, i); err != nil {
+			return err
+		}
+	}
+
+	for i, attribute := range p.Attributes {
+		exec, err := tx.ExecContext(ctx, `INSERT INTO product_attribute (product_id, label, position) VALUES (?, ?, ?);`, p.ID, attribute.Label, i)
+
+		if err != nil {
+			return err
+		}
+
+		attributeID, err := exec.LastInsertId()
+
+		if err != nil {
+			return err
+		}
+
+		for j, value := range attribute.Values {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO product_attribute_value (attribute_id, value, position) VALUES (?, ?, ?);`, attributeID, value, j); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (pmr *productMysqlRepository) get(ctx context.Context, query string, arg interface{}) (*domain.Product, error) {
+	row := pmr.Conn.QueryRowContext(ctx, query, arg)
 
 	var res domain.Product
 
-	if err := row.Scan(&res.ID, &res.UUID, &res.Name, &res.Detail); err != nil {
+	if err := row.Scan(&res.ID, &res.UUID, &res.Slug, &res.Name, &res.Detail, &res.Rate, &res.Price, &res.Status, &res.Version, &res.ContentVersion, &res.MetaTitle, &res.MetaDescription, &res.CanonicalURL); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -30,5 +639,196 @@ func (pmr *productMysqlRepository) GetByUUID(ctx context.Context, uuid string) (
 		return nil, err
 	}
 
+	if err := pmr.fillDetails(ctx, []*domain.Product{&res}); err != nil {
+		return nil, err
+	}
+
 	return &res, nil
 }
+
+// fillDetails loads the pictures and attributes of all the given products
+// with one query each, whatever the number of products.
+func (pmr *productMysqlRepository) fillDetails(ctx context.Context, products []*domain.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	byID := map[int64]*domain.Product{}
+	args := []interface{}{}
+
+	for _, p := range products {
+		p.Pictures = []string{}
+		p.Attributes = []domain.Attribute{}
+		byID[p.ID] = p
+		args = append(args, p.ID)
+	}
+
+	picturesQuery := `SELECT product_id, path FROM product_picture WHERE product_id IN (` + placeholders(len(products)) + `) ORDER BY product_id, position, id;`
+
+	pictureRows, err := pmr.Conn.QueryContext(ctx, picturesQuery, args...)
+
+	if err != nil {
+		return err
+	}
+
+	defer pictureRows.Close()
+
+	for pictureRows.Next() {
+		var productID int64
+		var path string
+
+		if err := pictureRows.Scan(&productID, &path); err != nil {
+			return err
+		}
+
+		if p, ok := byID[productID]; ok {
+			p.Pictures = append(p.Pictures, path)
+		}
+	}
+
+	if err := pictureRows.Err(); err != nil {
+		return err
+	}
+
+	attributesQuery := `SELECT pa.id, pa.product_id, pa.label, pav.value FROM product_attribute pa LEFT JOIN product_attribute_value pav ON pav.attribute_id = pa.id WHERE pa.product_id IN (` + placeholders(len(products)) + `) ORDER BY pa.product_id, pa.position, pa.id, pav.position, pav.id;`
+
+	attributeRows, err := pmr.Conn.QueryContext(ctx, attributesQuery, args...)
+
+	if err != nil {
+		return err
+	}
+
+	defer attributeRows.Close()
+
+	var lastAttributeID int64
+
+	for attributeRows.Next() {
+		var attributeID, productID int64
+		var label string
+		var value sql.NullString
+
+		if err := attributeRows.Scan(&attributeID, &productID, &label, &value); err != nil {
+			return err
+		}
+
+		p, ok := byID[productID]
+
+		if !ok {
+			continue
+		}
+
+		if attributeID != lastAttributeID {
+			p.Attributes = append(p.Attributes, domain.Attribute{Label: label, Values: []string{}})
+			lastAttributeID = attributeID
+		}
+
+		if value.Valid {
+			last := &p.Attributes[len(p.Attributes)-1]
+			last.Values = append(last.Values, value.String)
+		}
+	}
+
+	return attributeRows.Err()
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func productFilters(q domain.ProductQuery) ([]string, []interface{}) {
+	where := []string{}
+	args := []interface{}{}
+
+	if q.Status != "" {
+		where = append(where, "p.status = ?")
+		args = append(args, q.Status)
+	}
+
+	if q.Category != "" {
+		where = append(where, "EXISTS (SELECT 1 FROM product_category pc JOIN category c ON c.id = pc.category_id JOIN category root ON c.path LIKE CONCAT(root.path, '%') WHERE pc.product_id = p.id AND root.slug = ?)")
+		args = append(args, q.Category)
+	}
+
+	if q.Collection != "" {
+		where = append(where, "EXISTS (SELECT 1 FROM collection_product cp JOIN collection col ON col.id = cp.collection_id WHERE cp.product_id = p.id AND col.slug = ?)")
+		args = append(args, q.Collection)
+	}
+
+	if q.FavoriteOf != 0 {
+		where = append(where, "EXISTS (SELECT 1 FROM favorite f WHERE f.product_id = p.id AND f.user_id = ?)")
+		args = append(args, q.FavoriteOf)
+	}
+
+	if q.Wishlist != 0 {
+		where = append(where, "EXISTS (SELECT 1 FROM wishlist_product wp WHERE wp.product_id = p.id AND wp.wishlist_id = ?)")
+		args = append(args, q.Wishlist)
+	}
+
+	if len(q.UUIDs) > 0 {
+		where = append(where, "p.uuid IN ("+placeholders(len(q.UUIDs))+")")
+		for _, uuid := range q.UUIDs {
+			args = append(args, uuid)
+		}
+	}
+
+	labels := make([]string, 0, len(q.Attributes))
+	for label := range q.Attributes {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	for _, label := range labels {
+		values := q.Attributes[label]
+
+		if len(values) == 0 {
+			continue
+		}
+
+		where = append(where, "EXISTS (SELECT 1 FROM product_attribute pa JOIN product_attribute_value pav ON pav.attribute_id = pa.id WHERE pa.product_id = p.id AND pa.label = ? AND pav.value IN ("+placeholders(len(values))+"))")
+		args = append(args, label)
+		for _, v := range values {
+			args = append(args, v)
+		}
+	}
+
+	if q.MinPrice != nil {
+		where = append(where, "p.price >= ?")
+		args = append(args, *q.MinPrice)
+	}
+
+	if q.MaxPrice != nil {
+		where = append(where, "p.price <= ?")
+		args = append(args, *q.MaxPrice)
+	}
+
+	return where, args
+}
+
+func whereClause(where []string) string {
+	if len(where) == 0 {
+		return ""
+	}
+
+	return " WHERE " + strings.Join(where, " AND ")
+}
+
+func encodeProductCursor(c productCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeProductCursor(s string) (*productCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var c productCursor
+
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
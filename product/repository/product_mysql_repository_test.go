@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,9 +18,9 @@ func TestGetByUUIDNotFound(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "uuid", "name", "detail"})
+	rows := sqlmock.NewRows([]string{"id", "uuid", "slug", "name", "detail", "rate", "price", "status", "version", "content_version", "meta_title", "meta_description", "canonical_url"})
 
-	query := regexp.QuoteMeta("SELECT id, uuid, name, detail FROM product WHERE uuid = ?;")
+	query := regexp.QuoteMeta("SELECT id, uuid, slug, name, detail, rate, price, status, version, content_version, meta_title, meta_description, canonical_url FROM product WHERE uuid = ?;")
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
@@ -42,7 +43,7 @@ func TestGetByUUIDError(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	query := regexp.QuoteMeta("SELECT id, uuid, name, detail FROM product WHERE uuid = ?;")
+	query := regexp.QuoteMeta("SELECT id, uuid, slug, name, detail, rate, price, status, version, content_version, meta_title, meta_description, canonical_url FROM product WHERE uuid = ?;")
 
 	mock.ExpectQuery(query).WillReturnError(errors.New("error message"))
 
@@ -57,6 +58,29 @@ func TestGetByUUIDError(t *testing.T) {
 	}
 }
 
+func TestGetByUUIDPicturesError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "uuid", "slug", "name", "detail", "rate", "price", "status", "version", "content_version", "meta_title", "meta_description", "canonical_url"}).AddRow(1, "uuid", "slug", "name", "detail", 4.5, 1990, "published", 3, 3, "", "", "")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, uuid, slug, name, detail, rate, price, status, version, content_version, meta_title, meta_description, canonical_url FROM product WHERE uuid = ?;")).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT product_id, path FROM product_picture WHERE product_id IN (?) ORDER BY product_id, position, id;")).WillReturnError(errors.New("error message"))
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	_, err = productMysqlRepository.GetByUUID(context.Background(), "uuid")
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestGetByUUID(t *testing.T) {
 	db, mock, err := sqlmock.New()
 
@@ -64,11 +88,19 @@ func TestGetByUUID(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "uuid", "name", "detail"}).AddRow(1, "uuid", "name", "detail")
+	rows := sqlmock.NewRows([]string{"id", "uuid", "slug", "name", "detail", "rate", "price", "status", "version", "content_version", "meta_title", "meta_description", "canonical_url"}).AddRow(1, "uuid", "slug", "name", "detail", 4.5, 1990, "published", 3, 8, "", "", "")
 
-	query := regexp.QuoteMeta("SELECT id, uuid, name, detail FROM product WHERE uuid = ?;")
+	pictureRows := sqlmock.NewRows([]string{"product_id", "path"}).AddRow(1, "picture1.png").AddRow(1, "picture2.png")
 
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	attributeRows := sqlmock.NewRows([]string{"id", "product_id", "label", "value"}).
+		AddRow(1, 1, "color", "black").
+		AddRow(1, 1, "color", "white").
+		AddRow(2, 1, "size", "M").
+		AddRow(3, 1, "material", nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, uuid, slug, name, detail, rate, price, status, version, content_version, meta_title, meta_description, canonical_url FROM product WHERE uuid = ?;")).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT product_id, path FROM product_picture WHERE product_id IN (?) ORDER BY product_id, position, id;")).WithArgs(1).WillReturnRows(pictureRows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT pa.id, pa.product_id, pa.label, pav.value FROM product_attribute pa LEFT JOIN product_attribute_value pav ON pav.attribute_id = pa.id WHERE pa.product_id IN (?) ORDER BY pa.product_id, pa.position, pa.id, pav.position, pav.id;")).WithArgs(1).WillReturnRows(attributeRows)
 
 	productMysqlRepository := NewProductMysqlRepository(db)
 
@@ -79,6 +111,679 @@ func TestGetByUUID(t *testing.T) {
 	assert.Equal(t, "uuid", product.UUID)
 	assert.Equal(t, "name", product.Name)
 	assert.Equal(t, "detail", product.Detail)
+	assert.Equal(t, float32(4.5), product.Rate)
+	assert.Equal(t, int64(1990), product.Price)
+	assert.Equal(t, domain.ProductStatusPublished, product.Status)
+	assert.Equal(t, int64(3), product.Version)
+	assert.Equal(t, int64(8), product.ContentVersion)
+	assert.Equal(t, []string{"picture1.png", "picture2.png"}, product.Pictures)
+	assert.Len(t, product.Attributes, 3)
+	assert.Equal(t, "color", product.Attributes[0].Label)
+	assert.Equal(t, []string{"black", "white"}, product.Attributes[0].Values)
+	assert.Equal(t, "size", product.Attributes[1].Label)
+	assert.Equal(t, []string{"M"}, product.Attributes[1].Values)
+	assert.Equal(t, []string{}, product.Attributes[2].Values)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetUUIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT uuid FROM product WHERE id IN (?, ?);")).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"uuid"}).AddRow("uuid1"))
+
+	uuids, err := NewProductMysqlRepository(db).GetUUIDs(context.Background(), []int64{1, 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"uuid1"}, uuids)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetIDsByAttribute(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT product_id FROM product_attribute WHERE label = ?;")).
+		WithArgs("color").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id"}).AddRow(1).AddRow(4))
+
+	productIDs, err := NewProductMysqlRepository(db).GetIDsByAttribute(context.Background(), "color")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 4}, productIDs)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTouch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE product SET content_version = content_version + 1 WHERE id IN (?, ?);")).
+		WithArgs(1, 4).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	pr := NewProductMysqlRepository(db)
+
+	assert.NoError(t, pr.Touch(context.Background(), []int64{1, 4}))
+	assert.NoError(t, pr.Touch(context.Background(), []int64{}))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetBySlug(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "uuid", "slug", "name", "detail", "rate", "price", "status", "version", "content_version", "meta_title", "meta_description", "canonical_url"}).AddRow(1, "uuid", "camiseta-basica", "Camiseta básica", "detail", 4.5, 1990, "published", 3, 3, "title", "description", "")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, uuid, slug, name, detail, rate, price, status, version, content_version, meta_title, meta_description, canonical_url FROM product WHERE slug = ?;")).WithArgs("camiseta-basica").WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT product_id, path FROM product_picture WHERE product_id IN (?) ORDER BY product_id, position, id;")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"product_id", "path"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT pa.id, pa.product_id, pa.label, pav.value FROM product_attribute pa")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "label", "value"}))
+
+	p, err := NewProductMysqlRepository(db).GetBySlug(context.Background(), "camiseta-basica")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "uuid", p.UUID)
+	assert.Equal(t, "camiseta-basica", p.Slug)
+	assert.Equal(t, "title", p.MetaTitle)
+	assert.Equal(t, "description", p.MetaDescription)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetByOldSlugNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT p.id, p.uuid, p.slug, p.name, p.detail, p.rate, p.price, p.status, p.version, p.content_version, p.meta_title, p.meta_description, p.canonical_url FROM product p JOIN product_slug ps ON ps.product_id = p.id WHERE ps.slug = ?;")).
+		WithArgs("camiseta").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "uuid", "slug", "name", "detail", "rate", "price", "status", "version", "content_version", "meta_title", "meta_description", "canonical_url"}))
+
+	p, err := NewProductMysqlRepository(db).GetByOldSlug(context.Background(), "camiseta")
+
+	assert.NoError(t, err)
+	assert.Nil(t, p)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSlugTaken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS(SELECT 1 FROM product WHERE slug = ? AND id <> ?) OR EXISTS(SELECT 1 FROM product_slug WHERE slug = ? AND product_id <> ?);")).
+		WithArgs("camiseta", 7, "camiseta", 7).
+		WillReturnRows(sqlmock.NewRows([]string{"taken"}).AddRow(true))
+
+	taken, err := NewProductMysqlRepository(db).SlugTaken(context.Background(), "camiseta", 7)
+
+	assert.NoError(t, err)
+	assert.True(t, taken)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetByUUIDsEmpty(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	products, err := productMysqlRepository.GetByUUIDs(context.Background(), []string{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, products)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetByUUIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "uuid", "slug", "name", "detail", "rate", "price", "status", "version", "content_version", "meta_title", "meta_description", "canonical_url"}).
+		AddRow(1, "uuid1", "slug1", "name1", "detail1", 4.5, 1990, "published", 1, 1, "", "", "").
+		AddRow(2, "uuid2", "slug2", "name2", "detail2", 3, 990, "draft", 2, 2, "", "", "")
+
+	pictureRows := sqlmock.NewRows([]string{"product_id", "path"}).AddRow(2, "picture.png")
+
+	attributeRows := sqlmock.NewRows([]string{"id", "product_id", "label", "value"}).AddRow(1, 1, "color", "black")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, uuid, slug, name, detail, rate, price, status, version, content_version, meta_title, meta_description, canonical_url FROM product WHERE uuid IN (?, ?);")).WithArgs("uuid1", "uuid2").WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT product_id, path FROM product_picture WHERE product_id IN (?, ?) ORDER BY product_id, position, id;")).WithArgs(1, 2).WillReturnRows(pictureRows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT pa.id, pa.product_id, pa.label, pav.value FROM product_attribute pa LEFT JOIN product_attribute_value pav ON pav.attribute_id = pa.id WHERE pa.product_id IN (?, ?) ORDER BY pa.product_id, pa.position, pa.id, pav.position, pav.id;")).WithArgs(1, 2).WillReturnRows(attributeRows)
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	products, err := productMysqlRepository.GetByUUIDs(context.Background(), []string{"uuid1", "uuid2"})
+
+	assert.NoError(t, err)
+	assert.Len(t, products, 2)
+	assert.Equal(t, "uuid1", products[0].UUID)
+	assert.Equal(t, []string{}, products[0].Pictures)
+	assert.Equal(t, []domain.Attribute{{Label: "color", Values: []string{"black"}}}, products[0].Attributes)
+	assert.Equal(t, []string{"picture.png"}, products[1].Pictures)
+	assert.Equal(t, domain.ProductStatusDraft, products[1].Status)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetFavoriteProductIDsEmpty(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	favorites, err := NewProductMysqlRepository(db).GetFavoriteProductIDs(context.Background(), 1, []int64{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, favorites)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetFavoriteProductIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"product_id"}).AddRow(2)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT product_id FROM favorite WHERE user_id = ? AND product_id IN (?, ?);")).WithArgs(1, 1, 2).WillReturnRows(rows)
+
+	favorites, err := NewProductMysqlRepository(db).GetFavoriteProductIDs(context.Background(), 1, []int64{1, 2})
+
+	assert.NoError(t, err)
+	assert.False(t, favorites[1])
+	assert.True(t, favorites[2])
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestListUnsupportedSort(t *testing.T) {
+	db, _, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	_, err = NewProductMysqlRepository(db).List(context.Background(), domain.ProductQuery{Sort: "color", Limit: 10})
+
+	assert.Error(t, err)
+}
+
+func TestListInvalidCursor(t *testing.T) {
+	db, _, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	_, err = NewProductMysqlRepository(db).List(context.Background(), domain.ProductQuery{Sort: domain.ProductSortName, Limit: 10, Cursor: "not a cursor"})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
+
+func TestListCursorFromOtherSort(t *testing.T) {
+	db, _, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	cursor := encodeProductCursor(productCursor{Sort: "price", Value: "100", ID: 1})
+
+	_, err = NewProductMysqlRepository(db).List(context.Background(), domain.ProductQuery{Sort: domain.ProductSortName, Limit: 10, Cursor: cursor})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
+
+func TestListError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT p.id, p.uuid, p.slug, p.name, p.detail, p.rate, p.price, p.status, p.version, p.content_version, p.meta_title, p.meta_description, p.canonical_url, p.name FROM product p ORDER BY p.name ASC, p.id ASC LIMIT ?;")
+
+	mock.ExpectQuery(query).WillReturnError(errors.New("error message"))
+
+	_, err = NewProductMysqlRepository(db).List(context.Background(), domain.ProductQuery{Sort: domain.ProductSortName, Limit: 10})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestListCollection(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT COUNT(*) FROM product p WHERE p.status = ? AND EXISTS (SELECT 1 FROM collection_product cp JOIN collection col ON col.id = cp.collection_id WHERE cp.product_id = p.id AND col.slug = ?);")
+
+	mock.ExpectQuery(query).WithArgs("published", "summer").WillReturnError(errors.New("error message"))
+
+	_, err = NewProductMysqlRepository(db).List(context.Background(), domain.ProductQuery{Sort: domain.ProductSortName, Limit: 10, Status: domain.ProductStatusPublished, Collection: "summer", WithTotal: true})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestListFavoritesAndWishlist(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT COUNT(*) FROM product p WHERE p.status = ? AND EXISTS (SELECT 1 FROM favorite f WHERE f.product_id = p.id AND f.user_id = ?) AND EXISTS (SELECT 1 FROM wishlist_product wp WHERE wp.product_id = p.id AND wp.wishlist_id = ?);")
+
+	mock.ExpectQuery(query).WithArgs("published", 7, 3).WillReturnError(errors.New("error message"))
+
+	_, err = NewProductMysqlRepository(db).List(context.Background(), domain.ProductQuery{Sort: domain.ProductSortName, Limit: 10, Status: domain.ProductStatusPublished, FavoriteOf: 7, Wishlist: 3, WithTotal: true})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestListUUIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT COUNT(*) FROM product p WHERE p.status = ? AND p.uuid IN (?, ?);")
+
+	mock.ExpectQuery(query).WithArgs("published", "uuid1", "uuid2").WillReturnError(errors.New("error message"))
+
+	_, err = NewProductMysqlRepository(db).List(context.Background(), domain.ProductQuery{Sort: domain.ProductSortName, Limit: 10, Status: domain.ProductStatusPublished, UUIDs: []string{"uuid1", "uuid2"}, WithTotal: true})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestListFirstPage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	minPrice := int64(1000)
+
+	q := domain.ProductQuery{
+		Status:     domain.ProductStatusPublished,
+		Sort:       domain.ProductSortPrice,
+		Desc:       true,
+		Limit:      2,
+		Category:   "shirts",
+		Attributes: map[string][]string{"size": {"M", "L"}, "color": {"black"}},
+		MinPrice:   &minPrice,
+		WithTotal:  true,
+	}
+
+	where := " WHERE p.status = ? AND EXISTS (SELECT 1 FROM product_category pc JOIN category c ON c.id = pc.category_id JOIN category root ON c.path LIKE CONCAT(root.path, '%') WHERE pc.product_id = p.id AND root.slug = ?)" +
+		" AND EXISTS (SELECT 1 FROM product_attribute pa JOIN product_attribute_value pav ON pav.attribute_id = pa.id WHERE pa.product_id = p.id AND pa.label = ? AND pav.value IN (?))" +
+		" AND EXISTS (SELECT 1 FROM product_attribute pa JOIN product_attribute_value pav ON pav.attribute_id = pa.id WHERE pa.product_id = p.id AND pa.label = ? AND pav.value IN (?, ?))" +
+		" AND p.price >= ?"
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM product p"+where+";")).
+		WithArgs("published", "shirts", "color", "black", "size", "M", "L", 1000).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT p.id, p.uuid, p.slug, p.name, p.detail, p.rate, p.price, p.status, p.version, p.content_version, p.meta_title, p.meta_description, p.canonical_url, p.price FROM product p"+where+" ORDER BY p.price DESC, p.id DESC LIMIT ?;")).
+		WithArgs("published", "shirts", "color", "black", "size", "M", "L", 1000, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "uuid", "slug", "name", "detail", "rate", "price", "status", "version", "content_version", "meta_title", "meta_description", "canonical_url", "price"}).
+			AddRow(3, "uuid3", "slug3", "name3", "detail3", 4, 3000, "published", 1, 1, "", "", "", "3000").
+			AddRow(2, "uuid2", "slug2", "name2", "detail2", 5, 2000, "published", 1, 1, "", "", "", "2000").
+			AddRow(1, "uuid1", "slug1", "name1", "detail1", 3, 1000, "published", 1, 1, "", "", "", "1000"))
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT product_id, path FROM product_picture WHERE product_id IN (?, ?) ORDER BY product_id, position, id;")).
+		WithArgs(3, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "path"}).AddRow(2, "picture.png"))
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT pa.id, pa.product_id, pa.label, pav.value FROM product_attribute pa LEFT JOIN product_attribute_value pav ON pav.attribute_id = pa.id WHERE pa.product_id IN (?, ?) ORDER BY pa.product_id, pa.position, pa.id, pav.position, pav.id;")).
+		WithArgs(3, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "label", "value"}))
+
+	page, err := NewProductMysqlRepository(db).List(context.Background(), q)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), *page.Total)
+	assert.Len(t, page.Products, 2)
+	assert.Equal(t, "uuid3", page.Products[0].UUID)
+	assert.Equal(t, []string{"picture.png"}, page.Products[1].Pictures)
+
+	cursor, err := decodeProductCursor(page.NextCursor)
+
+	assert.NoError(t, err)
+	assert.Equal(t, productCursor{Sort: "-price", Value: "2000", ID: 2}, *cursor)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestListNextPage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	cursor := encodeProductCursor(productCursor{Sort: "-newest", Value: "2022-05-01 10:00:00", ID: 7})
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT p.id, p.uuid, p.slug, p.name, p.detail, p.rate, p.price, p.status, p.version, p.content_version, p.meta_title, p.meta_description, p.canonical_url, p.created_at FROM product p WHERE (p.created_at < ? OR (p.created_at = ? AND p.id < ?)) ORDER BY p.created_at DESC, p.id DESC LIMIT ?;")).
+		WithArgs("2022-05-01 10:00:00", "2022-05-01 10:00:00", 7, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "uuid", "slug", "name", "detail", "rate", "price", "status", "version", "content_version", "meta_title", "meta_description", "canonical_url", "created_at"}).
+			AddRow(6, "uuid6", "slug6", "name6", "detail6", 4, 3000, "published", 1, 1, "", "", "", "2022-04-01 10:00:00"))
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT product_id, path FROM product_picture WHERE product_id IN (?) ORDER BY product_id, position, id;")).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "path"}))
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT pa.id, pa.product_id, pa.label, pav.value FROM product_attribute pa LEFT JOIN product_attribute_value pav ON pav.attribute_id = pa.id WHERE pa.product_id IN (?) ORDER BY pa.product_id, pa.position, pa.id, pav.position, pav.id;")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "label", "value"}))
+
+	page, err := NewProductMysqlRepository(db).List(context.Background(), domain.ProductQuery{Sort: domain.ProductSortNewest, Limit: 2, Cursor: cursor})
+
+	assert.NoError(t, err)
+	assert.Nil(t, page.Total)
+	assert.Len(t, page.Products, 1)
+	assert.Empty(t, page.NextCursor)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	p := &domain.Product{Slug: "name", Name: "name", Detail: "detail", Price: 1990, Status: domain.ProductStatusDraft, MetaTitle: "title", Pictures: []string{"picture.png"}, Attributes: []domain.Attribute{{Label: "color", Values: []string{"black", "white"}}}}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product (uuid, slug, name, detail, price, status, meta_title, meta_description, canonical_url, version, content_version) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 1, 1);")).
+		WithArgs(sqlmock.AnyArg(), "name", "name", "detail", 1990, "draft", "title", "", "").
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_picture (product_id, path, position) VALUES (?, ?, ?);")).
+		WithArgs(7, "picture.png", 0).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_attribute (product_id, label, position) VALUES (?, ?, ?);")).
+		WithArgs(7, "color", 0).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_attribute_value (attribute_id, value, position) VALUES (?, ?, ?);")).
+		WithArgs(4, "black", 0).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_attribute_value (attribute_id, value, position) VALUES (?, ?, ?);")).
+		WithArgs(4, "white", 1).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit()
+
+	err = NewProductMysqlRepository(db).Store(context.Background(), p)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), p.ID)
+	assert.NotEmpty(t, p.UUID)
+	assert.Equal(t, int64(1), p.Version)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product (uuid, slug, name, detail, price, status, meta_title, meta_description, canonical_url, version, content_version) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 1, 1);")).
+		WillReturnError(errors.New("error message"))
+	mock.ExpectRollback()
+
+	err = NewProductMysqlRepository(db).Store(context.Background(), &domain.Product{Name: "name"})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdateVersionConflict(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product_slug WHERE slug = ? AND product_id = ?;")).WithArgs("name", 7).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_slug (slug, product_id) SELECT slug, id FROM product WHERE id = ? AND version = ? AND slug <> ?;")).
+		WithArgs(7, 2, "name").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE product SET slug = ?, name = ?, detail = ?, price = ?, status = ?, meta_title = ?, meta_description = ?, canonical_url = ?, version = version + 1, content_version = content_version + 1 WHERE id = ? AND version = ?;")).
+		WithArgs("name", "name", "detail", 1990, "published", "", "", "", 7, 2).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err = NewProductMysqlRepository(db).Update(context.Background(), &domain.Product{ID: 7, Slug: "name", Name: "name", Detail: "detail", Price: 1990, Status: domain.ProductStatusPublished, Version: 2})
+
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	p := &domain.Product{ID: 7, Slug: "new-name", Name: "New name", Detail: "detail", Price: 1990, Status: domain.ProductStatusPublished, Version: 2, MetaDescription: "description", CanonicalURL: "https://shop.test/p/7", Pictures: []string{"picture.png"}}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product_slug WHERE slug = ? AND product_id = ?;")).WithArgs("new-name", 7).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_slug (slug, product_id) SELECT slug, id FROM product WHERE id = ? AND version = ? AND slug <> ?;")).
+		WithArgs(7, 2, "new-name").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE product SET slug = ?, name = ?, detail = ?, price = ?, status = ?, meta_title = ?, meta_description = ?, canonical_url = ?, version = version + 1, content_version = content_version + 1 WHERE id = ? AND version = ?;")).
+		WithArgs("new-name", "New name", "detail", 1990, "published", "", "description", "https://shop.test/p/7", 7, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product_picture WHERE product_id = ?;")).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product_attribute WHERE product_id = ?;")).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_picture (product_id, path, position) VALUES (?, ?, ?);")).
+		WithArgs(7, "picture.png", 0).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = NewProductMysqlRepository(db).Update(context.Background(), p)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), p.Version)
+	assert.Equal(t, int64(1), p.ContentVersion)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDeleteVersionConflict(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM product WHERE uuid = ? AND version = ?;")).ExpectExec().WithArgs("uuid", 2).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = NewProductMysqlRepository(db).Delete(context.Background(), "uuid", 2)
+
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM product WHERE uuid = ? AND version = ?;")).ExpectExec().WithArgs("uuid", 2).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewProductMysqlRepository(db).Delete(context.Background(), "uuid", 2)
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFacetsError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT fa.label, fav.value, COUNT(DISTINCT p.id) FROM product p")).WillReturnError(errors.New("error message"))
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	_, err = productMysqlRepository.Facets(context.Background(), domain.ProductQuery{})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFacets(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	where := " WHERE p.status = ? AND p.price <= ?"
+
+	attributeRows := sqlmock.NewRows([]string{"label", "value", "count"}).
+		AddRow("color", "black", 3).
+		AddRow("color", "white", 1).
+		AddRow("size", "M", 2)
+
+	categoryRows := sqlmock.NewRows([]string{"slug", "name", "count"}).AddRow("shirts", "Shirts", 4)
+
+	priceRows := sqlmock.NewRows([]string{"bucket", "count"}).AddRow(0, 1).AddRow(2, 2).AddRow(4, 1)
+
+	ratingRows := sqlmock.NewRows([]string{"4", "3", "2", "1"}).AddRow(1, 2, 3, 3)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT fa.label, fav.value, COUNT(DISTINCT p.id) FROM product p JOIN product_attribute fa ON fa.product_id = p.id JOIN product_attribute_value fav ON fav.attribute_id = fa.id"+where+" GROUP BY fa.label, fav.value ORDER BY fa.label, fav.value;")).WithArgs("published", 100000).WillReturnRows(attributeRows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT fc.slug, fc.name, COUNT(DISTINCT p.id) FROM product p JOIN product_category fpc ON fpc.product_id = p.id JOIN category fc ON fc.id = fpc.category_id"+where+" GROUP BY fc.id, fc.slug, fc.name ORDER BY fc.name;")).WithArgs("published", 100000).WillReturnRows(categoryRows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT CASE WHEN p.price < 5000 THEN 0 WHEN p.price < 10000 THEN 1 WHEN p.price < 20000 THEN 2 WHEN p.price < 50000 THEN 3 ELSE 4 END AS bucket, COUNT(*) FROM product p"+where+" GROUP BY bucket ORDER BY bucket;")).WithArgs("published", 100000).WillReturnRows(priceRows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(p.rate >= 4), 0), COALESCE(SUM(p.rate >= 3), 0), COALESCE(SUM(p.rate >= 2), 0), COALESCE(SUM(p.rate >= 1), 0) FROM product p"+where+";")).WithArgs("published", 100000).WillReturnRows(ratingRows)
+
+	productMysqlRepository := NewProductMysqlRepository(db)
+
+	maxPrice := int64(100000)
+
+	facets, err := productMysqlRepository.Facets(context.Background(), domain.ProductQuery{Status: domain.ProductStatusPublished, MaxPrice: &maxPrice})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.AttributeFacet{
+		{Label: "color", Values: []domain.FacetValue{{Value: "black", Count: 3}, {Value: "white", Count: 1}}},
+		{Label: "size", Values: []domain.FacetValue{{Value: "M", Count: 2}}},
+	}, facets.Attributes)
+	assert.Equal(t, []domain.FacetValue{{Value: "shirts", Name: "Shirts", Count: 4}}, facets.Categories)
+
+	max0, max2 := int64(5000), int64(20000)
+	assert.Equal(t, []domain.PriceFacet{{Min: 0, Max: &max0, Count: 1}, {Min: 10000, Max: &max2, Count: 2}, {Min: 50000, Count: 1}}, facets.Prices)
+	assert.Equal(t, []domain.RatingFacet{{Min: 4, Count: 1}, {Min: 3, Count: 2}, {Min: 2, Count: 3}, {Min: 1, Count: 3}}, facets.Ratings)
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Error(err)
@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type variantMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewVariantMysqlRepository(conn *sql.DB) domain.VariantRepository {
+	return &variantMysqlRepository{Conn: conn}
+}
+
+func (vmr *variantMysqlRepository) GetByProductID(ctx context.Context, productID int64) ([]domain.Variant, error) {
+	query := `SELECT v.id, v.product_id, v.sku, v.price, COALESCE((SELECT SUM(sl.on_hand - sl.reserved) FROM stock_level sl WHERE sl.sku = v.sku), 0), v.barcode FROM variant v WHERE v.product_id = ? ORDER BY v.position, v.id;`
+
+	return vmr.list(ctx, query, productID)
+}
+
+// GetBySKUs loads the variants of any number of skus with one query, the
+// skus not found are left out.
+func (vmr *variantMysqlRepository) GetBySKUs(ctx context.Context, skus []string) ([]domain.Variant, error) {
+	if len(skus) == 0 {
+		return []domain.Variant{}, nil
+	}
+
+	query := `SELECT v.id, v.product_id, v.sku, v.price, COALESCE((SELECT SUM(sl.on_hand - sl.reserved) FROM stock_level sl WHERE sl.sku = v.sku), 0), v.barcode FROM variant v WHERE v.sku IN (` + placeholders(len(skus)) + `) ORDER BY v.product_id, v.position, v.id;`
+
+	args := make([]interface{}, len(skus))
+	for i, sku := range skus {
+		args[i] = sku
+	}
+
+	return vmr.list(ctx, query, args...)
+}
+
+func (vmr *variantMysqlRepository) list(ctx context.Context, query string, args ...interface{}) ([]domain.Variant, error) {
+	rows, err := vmr.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []domain.Variant{}
+
+	for rows.Next() {
+		var v domain.Variant
+
+		if err := rows.Scan(&v.ID, &v.ProductID, &v.SKU, &v.Price, &v.Stock, &v.Barcode); err != nil {
+			return nil, err
+		}
+
+		res = append(res, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	variants := make([]*domain.Variant, len(res))
+	for i := range res {
+		variants[i] = &res[i]
+	}
+
+	if err := vmr.fillDetails(ctx, variants); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (vmr *variantMysqlRepository) GetBySKU(ctx context.Context, sku string) (*domain.Variant, error) {
+	return vmr.getOne(ctx, `SELECT v.id, v.product_id, v.sku, v.price, COALESCE((SELECT SUM(sl.on_hand - sl.reserved) FROM stock_level sl WHERE sl.sku = v.sku), 0), v.barcode FROM variant v WHERE v.sku = ?;`, sku)
+}
+
+func (vmr *variantMysqlRepository) GetByBarcode(ctx context.Context, barcode string) (*domain.Variant, error) {
+	return vmr.getOne(ctx, `SELECT v.id, v.product_id, v.sku, v.price, COALESCE((SELECT SUM(sl.on_hand - sl.reserved) FROM stock_level sl WHERE sl.sku = v.sku), 0), v.barcode FROM variant v WHERE v.barcode = ?;`, barcode)
+}
+
+func (vmr *variantMysqlRepository) getOne(ctx context.Context, query string, arg string) (*domain.Variant, error) {
+	row := vmr.Conn.QueryRowContext(ctx, query, arg)
+
+	var res domain.Variant
+
+	if err := row.Scan(&res.ID, &res.ProductID, &res.SKU, &res.Price, &res.Stock, &res.Barcode); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if err := vmr.fillDetails(ctx, []*domain.Variant{&res}); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// Replace keeps the variants that already have an id, in the new order, and
+// deletes the ones of the product that are not in the list anymore. The new
+// variants are inserted and get their ids set.
+func (vmr *variantMysqlRepository) Replace(ctx context.Context, productID int64, variants []domain.Variant) error {
+	tx, err := vmr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	deleteQuery := `DELETE FROM variant WHERE product_id = ?;`
+	deleteArgs := []interface{}{productID}
+
+	kept := []interface{}{}
+	for _, v := range variants {
+		if v.ID != 0 {
+			kept = append(kept, v.ID)
+		}
+	}
+
+	if len(kept) > 0 {
+		deleteQuery = `DELETE FROM variant WHERE product_id = ? AND id NOT IN (` + placeholders(len(kept)) + `);`
+		deleteArgs = append(deleteArgs, kept...)
+	}
+
+	if _, err := tx.ExecContext(ctx, deleteQuery, deleteArgs...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for i := range variants {
+		v := &variants[i]
+
+		if v.ID != 0 {
+			if _, err := tx.ExecContext(ctx, `UPDATE variant SET position = ? WHERE id = ?;`, i, v.ID); err != nil {
+				tx.Rollback()
+				return err
+			}
+
+			continue
+		}
+
+		exec, err := tx.ExecContext(ctx, `INSERT INTO variant (product_id, sku, price, barcode, position) VALUES (?, ?, ?, ?, ?);`, productID, v.SKU, v.Price, v.Barcode, i)
+
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if v.ID, err = exec.LastInsertId(); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		v.ProductID = productID
+
+		for j, option := range v.Options {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO variant_option (variant_id, label, value, position) VALUES (?, ?, ?, ?);`, v.ID, option.Label, option.Value, j); err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+
+		if err := storeVariantPictures(ctx, tx, v); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (vmr *variantMysqlRepository) Update(ctx context.Context, v *domain.Variant) error {
+	tx, err := vmr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE variant SET price = ?, barcode = ? WHERE id = ?;`, v.Price, v.Barcode, v.ID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM variant_picture WHERE variant_id = ?;`, v.ID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := storeVariantPictures(ctx, tx, v); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func storeVariantPictures(ctx context.Context, tx *sql.Tx, v *domain.Variant) error {
+	for i, picture := range v.Pictures {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO variant_picture (variant_id, path, position) VALUES (?, ?, ?);`, v.ID, picture, i); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fillDetails loads the options and pictures of all the given variants with
+// one query each.
+func (vmr *variantMysqlRepository) fillDetails(ctx context.Context, variants []*domain.Variant) error {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	byID := map[int64]*domain.Variant{}
+	args := []interface{}{}
+
+	for _, v := range variants {
+		v.Options = []domain.VariantOption{}
+		v.Pictures = []string{}
+		byID[v.ID] = v
+		args = append(args, v.ID)
+	}
+
+	optionsQuery := `SELECT variant_id, label, value FROM variant_option WHERE variant_id IN (` + placeholders(len(variants)) + `) ORDER BY variant_id, position;`
+
+	optionRows, err := vmr.Conn.QueryContext(ctx, optionsQuery, args...)
+
+	if err != nil {
+		return err
+	}
+
+	defer optionRows.Close()
+
+	for optionRows.Next() {
+		var variantID int64
+		var option domain.VariantOption
+
+		if err := optionRows.Scan(&variantID, &option.Label, &option.Value); err != nil {
+			return err
+		}
+
+		if v, ok := byID[variantID]; ok {
+			v.Options = append(v.Options, option)
+		}
+	}
+
+	if err := optionRows.Err(); err != nil {
+		return err
+	}
+
+	picturesQuery := `SELECT variant_id, path FROM variant_picture WHERE variant_id IN (` + placeholders(len(variants)) + `) ORDER BY variant_id, position, id;`
+
+	pictureRows, err := vmr.Conn.QueryContext(ctx, picturesQuery, args...)
+
+	if err != nil {
+		return err
+	}
+
+	defer pictureRows.Close()
+
+	for pictureRows.Next() {
+		var variantID int64
+		var path string
+
+		if err := pictureRows.Scan(&variantID, &path); err != nil {
+			return err
+		}
+
+		if v, ok := byID[variantID]; ok {
+			v.Pictures = append(v.Pictures, path)
+		}
+	}
+
+	return pictureRows.Err()
+}
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariantGetByProductID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "product_id", "sku", "price", "available", "barcode"}).
+		AddRow(1, 7, "P7-PRETO-M", 4990, 3, "2000000700014").
+		AddRow(2, 7, "P7-BRANCO-M", 4990, 0, "2000000700021")
+	optionRows := sqlmock.NewRows([]string{"variant_id", "label", "value"}).
+		AddRow(1, "color", "preto").AddRow(1, "size", "M").
+		AddRow(2, "color", "branco").AddRow(2, "size", "M")
+	pictureRows := sqlmock.NewRows([]string{"variant_id", "path"}).AddRow(2, "branco.png")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT v.id, v.product_id, v.sku, v.price, COALESCE((SELECT SUM(sl.on_hand - sl.reserved) FROM stock_level sl WHERE sl.sku = v.sku), 0), v.barcode FROM variant v WHERE v.product_id = ? ORDER BY v.position, v.id;")).WithArgs(7).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT variant_id, label, value FROM variant_option WHERE variant_id IN (?, ?) ORDER BY variant_id, position;")).WithArgs(1, 2).WillReturnRows(optionRows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT variant_id, path FROM variant_picture WHERE variant_id IN (?, ?) ORDER BY variant_id, position, id;")).WithArgs(1, 2).WillReturnRows(pictureRows)
+
+	variants, err := NewVariantMysqlRepository(db).GetByProductID(context.Background(), 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Variant{
+		{ID: 1, ProductID: 7, SKU: "P7-PRETO-M", Price: 4990, Stock: 3, Barcode: "2000000700014", Options: []domain.VariantOption{{Label: "color", Value: "preto"}, {Label: "size", Value: "M"}}, Pictures: []string{}},
+		{ID: 2, ProductID: 7, SKU: "P7-BRANCO-M", Price: 4990, Stock: 0, Barcode: "2000000700021", Options: []domain.VariantOption{{Label: "color", Value: "branco"}, {Label: "size", Value: "M"}}, Pictures: []string{"branco.png"}},
+	}, variants)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVariantGetBySKUs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "product_id", "sku", "price", "available", "barcode"}).
+		AddRow(1, 7, "P7-PRETO-M", 4990, 3, "2000000700014").
+		AddRow(5, 9, "P9-G", 2990, 1, "2000000900018")
+	optionRows := sqlmock.NewRows([]string{"variant_id", "label", "value"}).AddRow(1, "size", "M").AddRow(5, "size", "G")
+	pictureRows := sqlmock.NewRows([]string{"variant_id", "path"})
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT v.id, v.product_id, v.sku, v.price, COALESCE((SELECT SUM(sl.on_hand - sl.reserved) FROM stock_level sl WHERE sl.sku = v.sku), 0), v.barcode FROM variant v WHERE v.sku IN (?, ?, ?) ORDER BY v.product_id, v.position, v.id;")).
+		WithArgs("P7-PRETO-M", "P9-G", "P9-X").
+		WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT variant_id, label, value FROM variant_option WHERE variant_id IN (?, ?)")).WithArgs(1, 5).WillReturnRows(optionRows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT variant_id, path FROM variant_picture WHERE variant_id IN (?, ?)")).WithArgs(1, 5).WillReturnRows(pictureRows)
+
+	variants, err := NewVariantMysqlRepository(db).GetBySKUs(context.Background(), []string{"P7-PRETO-M", "P9-G", "P9-X"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Variant{
+		{ID: 1, ProductID: 7, SKU: "P7-PRETO-M", Price: 4990, Stock: 3, Barcode: "2000000700014", Options: []domain.VariantOption{{Label: "size", Value: "M"}}, Pictures: []string{}},
+		{ID: 5, ProductID: 9, SKU: "P9-G", Price: 2990, Stock: 1, Barcode: "2000000900018", Options: []domain.VariantOption{{Label: "size", Value: "G"}}, Pictures: []string{}},
+	}, variants)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVariantGetBySKUsEmpty(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	variants, err := NewVariantMysqlRepository(db).GetBySKUs(context.Background(), []string{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, variants)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVariantGetBySKUNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "product_id", "sku", "price", "available", "barcode"})
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT v.id, v.product_id, v.sku, v.price, COALESCE((SELECT SUM(sl.on_hand - sl.reserved) FROM stock_level sl WHERE sl.sku = v.sku), 0), v.barcode FROM variant v WHERE v.sku = ?;")).WithArgs("P7-PRETO-M").WillReturnRows(rows)
+
+	variant, err := NewVariantMysqlRepository(db).GetBySKU(context.Background(), "P7-PRETO-M")
+
+	assert.NoError(t, err)
+	assert.Nil(t, variant)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVariantGetByBarcodeError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT v.id, v.product_id, v.sku, v.price, COALESCE((SELECT SUM(sl.on_hand - sl.reserved) FROM stock_level sl WHERE sl.sku = v.sku), 0), v.barcode FROM variant v WHERE v.barcode = ?;")).WillReturnError(errors.New("error message"))
+
+	_, err = NewVariantMysqlRepository(db).GetByBarcode(context.Background(), "2000000700014")
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVariantReplace(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	variants := []domain.Variant{
+		{ID: 2, ProductID: 7, SKU: "P7-BRANCO"},
+		{SKU: "P7-AZUL", Price: 4990, Barcode: "2000000700039", Options: []domain.VariantOption{{Label: "color", Value: "azul"}}, Pictures: []string{"azul.png"}},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM variant WHERE product_id = ? AND id NOT IN (?);")).WithArgs(7, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE variant SET position = ? WHERE id = ?;")).WithArgs(0, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO variant (product_id, sku, price, barcode, position) VALUES (?, ?, ?, ?, ?);")).
+		WithArgs(7, "P7-AZUL", 4990, "2000000700039", 1).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO variant_option (variant_id, label, value, position) VALUES (?, ?, ?, ?);")).
+		WithArgs(3, "color", "azul", 0).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO variant_picture (variant_id, path, position) VALUES (?, ?, ?);")).
+		WithArgs(3, "azul.png", 0).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = NewVariantMysqlRepository(db).Replace(context.Background(), 7, variants)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), variants[1].ID)
+	assert.Equal(t, int64(7), variants[1].ProductID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVariantReplaceError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM variant WHERE product_id = ?;")).WithArgs(7).WillReturnError(errors.New("error message"))
+	mock.ExpectRollback()
+
+	err = NewVariantMysqlRepository(db).Replace(context.Background(), 7, []domain.Variant{})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVariantUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE variant SET price = ?, barcode = ? WHERE id = ?;")).
+		WithArgs(5990, "7891234567895", 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM variant_picture WHERE variant_id = ?;")).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO variant_picture (variant_id, path, position) VALUES (?, ?, ?);")).
+		WithArgs(2, "branco.png", 0).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = NewVariantMysqlRepository(db).Update(context.Background(), &domain.Variant{ID: 2, Price: 5990, Barcode: "7891234567895", Pictures: []string{"branco.png"}})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
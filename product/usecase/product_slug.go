@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"strings"
+	"unicode"
+)
+
+// the column has 150, the rest is room for the number of a repeated slug
+const maxSlugLength = 140
+
+var transliterations = map[rune]string{
+	'á': "a", 'à': "a", 'â': "a", 'ã': "a", 'ä': "a", 'ª': "a",
+	'é': "e", 'è': "e", 'ê': "e", 'ë': "e",
+	'í': "i", 'ì': "i", 'î': "i", 'ï': "i",
+	'ó': "o", 'ò': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'º': "o",
+	'ú': "u", 'ù': "u", 'û': "u", 'ü': "u",
+	'ç': "c", 'ñ': "n", '&': "e",
+}
+
+// slugify turns a product name in lowercase ascii words split by hyphens,
+// "Camisa Polo Algodão & Linho" becomes "camisa-polo-algodao-e-linho".
+func slugify(name string) string {
+	var b strings.Builder
+
+	hyphen := false
+
+	for _, r := range strings.ToLower(name) {
+		word, ok := transliterations[r]
+
+		if !ok && r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			word, ok = string(r), true
+		}
+
+		if !ok {
+			hyphen = b.Len() > 0
+			continue
+		}
+
+		if hyphen {
+			b.WriteByte('-')
+			hyphen = false
+		}
+
+		b.WriteString(word)
+	}
+
+	slug := b.String()
+
+	if len(slug) > maxSlugLength {
+		slug = strings.TrimRight(slug[:maxSlugLength], "-")
+
+		// avoid ending in a cut word
+		if i := strings.LastIndex(slug, "-"); i > 0 {
+			slug = slug[:i]
+		}
+	}
+
+	if slug == "" {
+		return "product"
+	}
+
+	return slug
+}
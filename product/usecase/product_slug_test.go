@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	for name, slug := range map[string]string{
+		"Camiseta Básica":             "camiseta-basica",
+		"Camisa Polo Algodão & Linho": "camisa-polo-algodao-e-linho",
+		"  AÇÚCAR  Orgânico 1kg ":     "acucar-organico-1kg",
+		"Tênis Nº 42 (Preto/Branco)":  "tenis-no-42-preto-branco",
+		"Coração---Pão":               "coracao-pao",
+		"日本":                          "product",
+		"!!!":                         "product",
+	} {
+		assert.Equal(t, slug, slugify(name), name)
+	}
+}
+
+func TestSlugifyLong(t *testing.T) {
+	slug := slugify(strings.Repeat("palavra ", 30))
+
+	assert.LessOrEqual(t, len(slug), maxSlugLength)
+	assert.True(t, strings.HasSuffix(slug, "-palavra"))
+}
@@ -2,18 +2,517 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
 )
 
 type productUseCase struct {
-	productRepo domain.ProductRepository
+	productRepo     domain.ProductRepository
+	userRepo        domain.UserRepository
+	searchIndex     domain.SearchIndex
+	searchRepo      domain.SearchRepository
+	variantRepo     domain.VariantRepository
+	translationRepo domain.TranslationRepository
+	pricingService  domain.PricingService
 }
 
-func NewProductUseCase(pr domain.ProductRepository) domain.ProductUseCase {
-	return &productUseCase{productRepo: pr}
+func NewProductUseCase(pr domain.ProductRepository, ur domain.UserRepository, si domain.SearchIndex, sr domain.SearchRepository, vr domain.VariantRepository, tr domain.TranslationRepository, ps domain.PricingService) domain.ProductUseCase {
+	return &productUseCase{productRepo: pr, userRepo: ur, searchIndex: si, searchRepo: sr, variantRepo: vr, translationRepo: tr, pricingService: ps}
 }
 
-func (pu *productUseCase) Get(ctx context.Context, uuid string) (*domain.Product, error) {
+// Head gives the published product with what depends on the logged user,
+// its favorite flag and price, without the variants and the translations. It is enough to tell whether the copy
+// held by a client is still fresh.
+func (pu *productUseCase) Head(ctx context.Context, uuid string, login string) (*domain.Product, error) {
+	product, err := pu.productRepo.GetByUUID(ctx, uuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pu.published(ctx, product, login)
+}
+
+// HeadBySlug also finds the products by a slug they had before, the product
+// slug tells the caller which one it was.
+func (pu *productUseCase) HeadBySlug(ctx context.Context, slug string, login string) (*domain.Product, error) {
+	product, err := pu.productRepo.GetBySlug(ctx, slug)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil {
+		if product, err = pu.productRepo.GetByOldSlug(ctx, slug); err != nil {
+			return nil, err
+		}
+	}
+
+	return pu.published(ctx, product, login)
+}
+
+// Complete loads the variants and the translations of a product given by
+// Head.
+func (pu *productUseCase) Complete(ctx context.Context, product *domain.Product) error {
+	variants, err := pu.variantRepo.GetByProductID(ctx, product.ID)
+
+	if err != nil {
+		return err
+	}
+
+	product.Variants = variants
+
+	return pu.translate(ctx, []*domain.Product{product}, nil)
+}
+
+func (pu *productUseCase) published(ctx context.Context, product *domain.Product, login string) (*domain.Product, error) {
+	if product == nil || product.Status != domain.ProductStatusPublished {
+		return nil, nil
+	}
+
+	if err := pu.personalize(ctx, login, []*domain.Product{product}); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+func (pu *productUseCase) List(ctx context.Context, q domain.ProductQuery, login string) (*domain.ProductPage, error) {
+	q.Status = domain.ProductStatusPublished
+
+	page, err := pu.productRepo.List(ctx, q)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if q.WithFacets {
+		facets, err := pu.productRepo.Facets(ctx, q)
+
+		if err != nil {
+			return nil, err
+		}
+
+		page.Facets = facets
+	}
+
+	products := make([]*domain.Product, len(page.Products))
+	for i := range page.Products {
+		products[i] = &page.Products[i]
+	}
+
+	if err := pu.personalize(ctx, login, products); err != nil {
+		return nil, err
+	}
+
+	var facets []domain.AttributeFacet
+
+	if page.Facets != nil {
+		facets = page.Facets.Attributes
+	}
+
+	if err := pu.translate(ctx, products, facets); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+func (pu *productUseCase) Search(ctx context.Context, q string, limit int, login string) (*domain.ProductPage, error) {
+	hits := pu.searchIndex.Search(ctx, q, limit)
+
+	res := &domain.ProductPage{Products: []domain.Product{}}
+
+	if len(hits) == 0 {
+		return res, nil
+	}
+
+	uuids := make([]string, len(hits))
+	for i, hit := range hits {
+		uuids[i] = hit.ProductUUID
+	}
+
+	found, err := pu.productRepo.GetByUUIDs(ctx, uuids)
+
+	if err != nil {
+		return nil, err
+	}
+
+	byUUID := map[string]domain.Product{}
+	for _, p := range found {
+		byUUID[p.UUID] = p
+	}
+
+	for _, hit := range hits {
+		if p, ok := byUUID[hit.ProductUUID]; ok && p.Status == domain.ProductStatusPublished {
+			res.Products = append(res.Products, p)
+		}
+	}
+
+	products := make([]*domain.Product, len(res.Products))
+	for i := range res.Products {
+		products[i] = &res.Products[i]
+	}
+
+	if err := pu.personalize(ctx, login, products); err != nil {
+		return nil, err
+	}
+
+	if err := pu.translate(ctx, products, nil); err != nil {
+		return nil, err
+	}
+
+	// the popular queries only feed the suggestions, failing to count one
+	// must not fail the search
+	if len(res.Products) > 0 {
+		if err := pu.searchRepo.StoreQuery(ctx, q); err != nil {
+			log.Printf("Error trying to store the search query: %s", err.Error())
+		}
+	}
+
+	return res, nil
+}
+
+// IndexAll loads every published product in the search index, it is meant
+// to be called once on startup, the writes keep the index updated after that.
+func (pu *productUseCase) IndexAll(ctx context.Context) error {
+	q := domain.ProductQuery{Limit: 100, Sort: domain.ProductSortName, Status: domain.ProductStatusPublished}
+
+	for {
+		page, err := pu.productRepo.List(ctx, q)
+
+		if err != nil {
+			return err
+		}
+
+		for i := range page.Products {
+			pu.searchIndex.Index(ctx, &page.Products[i])
+		}
+
+		if page.NextCursor == "" {
+			return nil
+		}
+
+		q.Cursor = page.NextCursor
+	}
+}
+
+func (pu *productUseCase) AdminGet(ctx context.Context, uuid string) (*domain.Product, error) {
 	return pu.productRepo.GetByUUID(ctx, uuid)
 }
+
+func (pu *productUseCase) Create(ctx context.Context, p *domain.Product) error {
+	slug, err := pu.availableSlug(ctx, p.Name, 0)
+
+	if err != nil {
+		return err
+	}
+
+	p.Slug = slug
+
+	if err := pu.productRepo.Store(ctx, p); err != nil {
+		return err
+	}
+
+	pu.syncSearchIndex(ctx, p)
+
+	return nil
+}
+
+func (pu *productUseCase) Update(ctx context.Context, p *domain.Product) (*domain.Product, error) {
+	existing, err := pu.productRepo.GetByUUID(ctx, p.UUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if existing == nil {
+		return nil, nil
+	}
+
+	p.ID = existing.ID
+	p.Slug = existing.Slug
+
+	// the old slug is kept by the repository and keeps leading here
+	if p.Name != existing.Name {
+		if p.Slug, err = pu.availableSlug(ctx, p.Name, p.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := pu.productRepo.Update(ctx, p); err != nil {
+		return nil, err
+	}
+
+	pu.syncSearchIndex(ctx, p)
+
+	return p, nil
+}
+
+func (pu *productUseCase) Delete(ctx context.Context, uuid string, version int64) (*domain.Product, error) {
+	existing, err := pu.productRepo.GetByUUID(ctx, uuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if existing == nil {
+		return nil, nil
+	}
+
+	if err := pu.productRepo.Delete(ctx, uuid, version); err != nil {
+		return nil, err
+	}
+
+	pu.searchIndex.Remove(ctx, uuid)
+
+	return existing, nil
+}
+
+// translate gives each field the translation of the most wanted locale of
+// the request that has it, the fields no locale translates keep the original
+// content. The attribute facets get the translated names, their values stay
+// the original ones since they are what the filters take.
+func (pu *productUseCase) translate(ctx context.Context, products []*domain.Product, facets []domain.AttributeFacet) error {
+	locales := domain.LocalesFromContext(ctx)
+
+	if len(locales) == 0 || (len(products) == 0 && len(facets) == 0) {
+		return nil
+	}
+
+	rank := map[string]int{}
+	for i, l := range locales {
+		rank[l] = i
+	}
+
+	if len(products) > 0 {
+		productIDs := make([]int64, len(products))
+		for i, p := range products {
+			productIDs[i] = p.ID
+		}
+
+		translations, err := pu.translationRepo.GetProductTranslations(ctx, productIDs, locales)
+
+		if err != nil {
+			return err
+		}
+
+		names := map[int64]string{}
+		details := map[int64]string{}
+		nameRanks := map[int64]int{}
+		detailRanks := map[int64]int{}
+
+		for _, t := range translations {
+			r := rank[t.Locale]
+
+			if best, ok := nameRanks[t.ProductID]; t.Name != "" && (!ok || r < best) {
+				names[t.ProductID], nameRanks[t.ProductID] = t.Name, r
+			}
+
+			if best, ok := detailRanks[t.ProductID]; t.Detail != "" && (!ok || r < best) {
+				details[t.ProductID], detailRanks[t.ProductID] = t.Detail, r
+			}
+		}
+
+		for _, p := range products {
+			if name, ok := names[p.ID]; ok {
+				p.Name = name
+			}
+
+			if detail, ok := details[p.ID]; ok {
+				p.Detail = detail
+			}
+		}
+	}
+
+	labelSet := map[string]bool{}
+
+	for _, p := range products {
+		for _, a := range p.Attributes {
+			labelSet[a.Label] = true
+		}
+
+		for _, v := range p.Variants {
+			for _, o := range v.Options {
+				labelSet[o.Label] = true
+			}
+		}
+	}
+
+	for _, f := range facets {
+		labelSet[f.Label] = true
+	}
+
+	if len(labelSet) == 0 {
+		return nil
+	}
+
+	labels := make([]string, 0, len(labelSet))
+	for l := range labelSet {
+		labels = append(labels, l)
+	}
+
+	sort.Strings(labels)
+
+	attributeTranslations, err := pu.translationRepo.GetAttributeTranslations(ctx, locales, labels)
+
+	if err != nil {
+		return err
+	}
+
+	// the repository gives them in no particular locale order
+	sort.SliceStable(attributeTranslations, func(i, j int) bool {
+		return rank[attributeTranslations[i].Locale] < rank[attributeTranslations[j].Locale]
+	})
+
+	labelNames := map[string]string{}
+	valueNames := map[string]map[string]string{}
+
+	for _, t := range attributeTranslations {
+		if _, ok := labelNames[t.Label]; !ok {
+			labelNames[t.Label] = t.Translation
+		}
+
+		if valueNames[t.Label] == nil {
+			valueNames[t.Label] = map[string]string{}
+		}
+
+		for value, translation := range t.Values {
+			if _, ok := valueNames[t.Label][value]; !ok {
+				valueNames[t.Label][value] = translation
+			}
+		}
+	}
+
+	translateLabel := func(label string) string {
+		if name, ok := labelNames[label]; ok {
+			return name
+		}
+
+		return label
+	}
+
+	translateValue := func(label string, value string) string {
+		if name, ok := valueNames[label][value]; ok {
+			return name
+		}
+
+		return value
+	}
+
+	for _, p := range products {
+		for i, a := range p.Attributes {
+			values := make([]string, len(a.Values))
+			for j, v := range a.Values {
+				values[j] = translateValue(a.Label, v)
+			}
+
+			p.Attributes[i] = domain.Attribute{Label: translateLabel(a.Label), Values: values}
+		}
+
+		for i := range p.Variants {
+			for j, o := range p.Variants[i].Options {
+				p.Variants[i].Options[j] = domain.VariantOption{Label: translateLabel(o.Label), Value: translateValue(o.Label, o.Value)}
+			}
+		}
+	}
+
+	for i, f := range facets {
+		facets[i].Name = labelNames[f.Label]
+
+		for j, v := range f.Values {
+			facets[i].Values[j].Name = valueNames[f.Label][v.Value]
+		}
+	}
+
+	return nil
+}
+
+// availableSlug numbers the slug of the name when another product has or had
+// it, "camiseta", "camiseta-2", "camiseta-3" and so on.
+func (pu *productUseCase) availableSlug(ctx context.Context, name string, productID int64) (string, error) {
+	base := slugify(name)
+
+	for n := 1; ; n++ {
+		slug := base
+
+		if n > 1 {
+			slug = fmt.Sprintf("%s-%d", base, n)
+		}
+
+		taken, err := pu.productRepo.SlugTaken(ctx, slug, productID)
+
+		if err != nil {
+			return "", err
+		}
+
+		if !taken {
+			return slug, nil
+		}
+	}
+}
+
+// syncSearchIndex keeps only the published products searchable.
+func (pu *productUseCase) syncSearchIndex(ctx context.Context, p *domain.Product) {
+	if p.Status == domain.ProductStatusPublished {
+		pu.searchIndex.Index(ctx, p)
+		return
+	}
+
+	pu.searchIndex.Remove(ctx, p.UUID)
+}
+
+// personalize fills what depends on the logged user: the favorite flags and
+// the prices of their customer groups. A product without a price for them is
+// left without one.
+func (pu *productUseCase) personalize(ctx context.Context, login string, products []*domain.Product) error {
+	if login == "" || len(products) == 0 {
+		return nil
+	}
+
+	user, err := pu.userRepo.GetByEmail(ctx, login)
+
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	productIDs := make([]int64, len(products))
+	for i, p := range products {
+		productIDs[i] = p.ID
+	}
+
+	favorites, err := pu.productRepo.GetFavoriteProductIDs(ctx, user.ID, productIDs)
+
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	for _, p := range products {
+		p.Favorite = favorites[p.ID]
+
+		price, err := pu.pricingService.Resolve(ctx, domain.PriceRequest{Product: p, UserID: user.ID, At: now})
+
+		if errors.Is(err, domain.ErrNoPrice) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		p.CustomerPrice = price
+	}
+
+	return nil
+}
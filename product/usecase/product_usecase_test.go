@@ -5,44 +5,47 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
-func TestGetError(t *testing.T) {
+func TestHeadError(t *testing.T) {
 	mockProductRepo := new(mocks.MockProductRepository)
 
 	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(nil, errors.New("error message"))
 
-	productUseCase := NewProductUseCase(mockProductRepo)
+	productUseCase := NewProductUseCase(mockProductRepo, nil, nil, nil, nil, nil, nil)
 
-	_, err := productUseCase.Get(context.Background(), "uuid")
+	_, err := productUseCase.Head(context.Background(), "uuid", "")
 
 	assert.Error(t, err)
 }
 
-func TestGetNotExists(t *testing.T) {
+func TestHeadNotExists(t *testing.T) {
 	mockProductRepo := new(mocks.MockProductRepository)
 
 	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(nil, nil)
 
-	productUseCase := NewProductUseCase(mockProductRepo)
+	productUseCase := NewProductUseCase(mockProductRepo, nil, nil, nil, nil, nil, nil)
 
-	product, err := productUseCase.Get(context.Background(), "uuid")
+	product, err := productUseCase.Head(context.Background(), "uuid", "")
 
 	assert.Nil(t, product)
 	assert.NoError(t, err)
 }
 
-func TestGet(t *testing.T) {
-	mockProductRepo := new(mocks.MockProductRepository)
+func TestComplete(t *testing.T) {
+	product := &domain.Product{ID: 1, UUID: "uuid", Rate: 2, Pictures: []string{"picturepath"}, Name: "name", Detail: "detail", Favorite: true, Attributes: []domain.Attribute{{Label: "color", Values: []string{"black"}}}, Status: domain.ProductStatusPublished}
+
+	mockVariantRepo := new(mocks.MockVariantRepository)
 
-	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(1, "uuid", 2, "picturepath", "name", "detail", true, "color", "black", nil)
+	mockVariantRepo.On("GetByProductID", mock.Anything, int64(1)).Return([]domain.Variant{{ID: 4, ProductID: 1, SKU: "P1-BLACK", Options: []domain.VariantOption{{Label: "color", Value: "black"}}}}, nil)
 
-	productUseCase := NewProductUseCase(mockProductRepo)
+	productUseCase := NewProductUseCase(nil, nil, nil, nil, mockVariantRepo, nil, nil)
 
-	product, err := productUseCase.Get(context.Background(), "uuid")
+	err := productUseCase.Complete(context.Background(), product)
 
 	assert.Nil(t, err)
 	assert.Equal(t, int64(1), product.ID)
@@ -54,4 +57,490 @@ func TestGet(t *testing.T) {
 	assert.Equal(t, true, product.Favorite)
 	assert.Equal(t, "color", product.Attributes[0].Label)
 	assert.Equal(t, "black", product.Attributes[0].Values[0])
+	assert.Equal(t, "P1-BLACK", product.Variants[0].SKU)
+}
+
+func TestHead(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 1, UUID: "uuid", Status: domain.ProductStatusPublished, Version: 4}, nil)
+
+	product, err := NewProductUseCase(mockProductRepo, nil, nil, nil, mockVariantRepo, nil, nil).Head(context.Background(), "uuid", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), product.Version)
+	assert.Nil(t, product.Variants)
+	mockVariantRepo.AssertNotCalled(t, "GetByProductID", mock.Anything, mock.Anything)
+}
+
+func TestHeadNotPublished(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 1, UUID: "uuid", Status: domain.ProductStatusArchived}, nil)
+
+	product, err := NewProductUseCase(mockProductRepo, nil, nil, nil, nil, nil, nil).Head(context.Background(), "uuid", "")
+
+	assert.NoError(t, err)
+	assert.Nil(t, product)
+}
+
+func TestHeadBySlug(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetBySlug", mock.Anything, "camiseta").Return(&domain.Product{ID: 1, UUID: "uuid", Slug: "camiseta", Status: domain.ProductStatusPublished}, nil)
+
+	product, err := NewProductUseCase(mockProductRepo, nil, nil, nil, nil, nil, nil).HeadBySlug(context.Background(), "camiseta", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "uuid", product.UUID)
+	mockProductRepo.AssertNotCalled(t, "GetByOldSlug", mock.Anything, mock.Anything)
+}
+
+func TestHeadBySlugOld(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetBySlug", mock.Anything, "camisa").Return(nil, nil)
+	mockProductRepo.On("GetByOldSlug", mock.Anything, "camisa").Return(&domain.Product{ID: 1, UUID: "uuid", Slug: "camiseta", Status: domain.ProductStatusPublished}, nil)
+
+	product, err := NewProductUseCase(mockProductRepo, nil, nil, nil, nil, nil, nil).HeadBySlug(context.Background(), "camisa", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "camiseta", product.Slug)
+}
+
+func TestHeadBySlugNotPublished(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetBySlug", mock.Anything, "camiseta").Return(&domain.Product{ID: 1, UUID: "uuid", Slug: "camiseta", Status: domain.ProductStatusDraft}, nil)
+
+	product, err := NewProductUseCase(mockProductRepo, nil, nil, nil, nil, nil, nil).HeadBySlug(context.Background(), "camiseta", "")
+
+	assert.NoError(t, err)
+	assert.Nil(t, product)
+}
+
+func TestHeadBySlugNotExists(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetBySlug", mock.Anything, "camiseta").Return(nil, nil)
+	mockProductRepo.On("GetByOldSlug", mock.Anything, "camiseta").Return(nil, nil)
+
+	product, err := NewProductUseCase(mockProductRepo, nil, nil, nil, nil, nil, nil).HeadBySlug(context.Background(), "camiseta", "")
+
+	assert.NoError(t, err)
+	assert.Nil(t, product)
+}
+
+func TestCompleteVariantsError(t *testing.T) {
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	mockVariantRepo.On("GetByProductID", mock.Anything, int64(1)).Return(nil, errors.New("error message"))
+
+	err := NewProductUseCase(nil, nil, nil, nil, mockVariantRepo, nil, nil).Complete(context.Background(), &domain.Product{ID: 1, UUID: "uuid", Status: domain.ProductStatusPublished})
+
+	assert.Error(t, err)
+}
+
+func TestHeadFavoriteError(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 1, UUID: "uuid", Status: domain.ProductStatusPublished}, nil)
+	mockUserRepo.On("GetByEmail", mock.Anything, "user@test.com").Return(3, "user uuid", "user@test.com", "", "", "", "", "", "", "", "", "", nil)
+	mockProductRepo.On("GetFavoriteProductIDs", mock.Anything, int64(3), []int64{1}).Return(nil, errors.New("error message"))
+
+	_, err := NewProductUseCase(mockProductRepo, mockUserRepo, nil, nil, nil, nil, nil).Head(context.Background(), "uuid", "user@test.com")
+
+	assert.Error(t, err)
+}
+
+func TestHeadFavorite(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockPricingService := new(mocks.MockPricingService)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 1, UUID: "uuid", Status: domain.ProductStatusPublished}, nil)
+	mockUserRepo.On("GetByEmail", mock.Anything, "user@test.com").Return(3, "user uuid", "user@test.com", "", "", "", "", "", "", "", "", "", nil)
+	mockProductRepo.On("GetFavoriteProductIDs", mock.Anything, int64(3), []int64{1}).Return(map[int64]bool{1: true}, nil)
+	mockPricingService.On("Resolve", mock.Anything, mock.Anything).Return(nil, domain.ErrNoPrice)
+
+	product, err := NewProductUseCase(mockProductRepo, mockUserRepo, nil, nil, nil, nil, mockPricingService).Head(context.Background(), "uuid", "user@test.com")
+
+	assert.NoError(t, err)
+	assert.True(t, product.Favorite)
+	assert.Nil(t, product.CustomerPrice)
+}
+
+func TestHeadCustomerPrice(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockPricingService := new(mocks.MockPricingService)
+
+	price := &domain.EffectivePrice{Unit: domain.Money{Amount: 3990, Currency: domain.CurrencyBRL}, List: domain.Money{Amount: 4990, Currency: domain.CurrencyBRL}, Quantity: 1, OnSale: true, PriceList: "atacado"}
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 1, UUID: "uuid", Price: 4990, Status: domain.ProductStatusPublished}, nil)
+	mockUserRepo.On("GetByEmail", mock.Anything, "user@test.com").Return(3, "user uuid", "user@test.com", "", "", "", "", "", "", "", "", "", nil)
+	mockProductRepo.On("GetFavoriteProductIDs", mock.Anything, int64(3), []int64{1}).Return(map[int64]bool{}, nil)
+	mockPricingService.On("Resolve", mock.Anything, mock.MatchedBy(func(r domain.PriceRequest) bool {
+		return r.Product.ID == 1 && r.UserID == 3 && r.Variant == nil && !r.At.IsZero()
+	})).Return(price, nil)
+
+	product, err := NewProductUseCase(mockProductRepo, mockUserRepo, nil, nil, nil, nil, mockPricingService).Head(context.Background(), "uuid", "user@test.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, price, product.CustomerPrice)
+	mockPricingService.AssertExpectations(t)
+}
+
+func TestHeadCustomerPriceError(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockPricingService := new(mocks.MockPricingService)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 1, UUID: "uuid", Status: domain.ProductStatusPublished}, nil)
+	mockUserRepo.On("GetByEmail", mock.Anything, "user@test.com").Return(3, "user uuid", "user@test.com", "", "", "", "", "", "", "", "", "", nil)
+	mockProductRepo.On("GetFavoriteProductIDs", mock.Anything, int64(3), []int64{1}).Return(map[int64]bool{}, nil)
+	mockPricingService.On("Resolve", mock.Anything, mock.Anything).Return(nil, errors.New("error message"))
+
+	_, err := NewProductUseCase(mockProductRepo, mockUserRepo, nil, nil, nil, nil, mockPricingService).Head(context.Background(), "uuid", "user@test.com")
+
+	assert.Error(t, err)
+}
+
+func TestListError(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	q := domain.ProductQuery{Sort: domain.ProductSortName, Limit: 20}
+
+	mockProductRepo.On("List", mock.Anything, domain.ProductQuery{Sort: domain.ProductSortName, Limit: 20, Status: domain.ProductStatusPublished}).Return(nil, errors.New("error message"))
+
+	_, err := NewProductUseCase(mockProductRepo, nil, nil, nil, nil, nil, nil).List(context.Background(), q, "")
+
+	assert.Error(t, err)
+}
+
+func TestListAnonymous(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	q := domain.ProductQuery{Sort: domain.ProductSortName, Limit: 20}
+
+	mockProductRepo.On("List", mock.Anything, domain.ProductQuery{Sort: domain.ProductSortName, Limit: 20, Status: domain.ProductStatusPublished}).Return(&domain.ProductPage{Products: []domain.Product{{ID: 1}, {ID: 2}}, NextCursor: "cursor"}, nil)
+
+	page, err := NewProductUseCase(mockProductRepo, nil, nil, nil, nil, nil, nil).List(context.Background(), q, "")
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Products, 2)
+	assert.Equal(t, "cursor", page.NextCursor)
+	mockProductRepo.AssertNotCalled(t, "GetFavoriteProductIDs", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestListFavorites(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockPricingService := new(mocks.MockPricingService)
+
+	q := domain.ProductQuery{Sort: domain.ProductSortName, Limit: 20}
+
+	mockProductRepo.On("List", mock.Anything, domain.ProductQuery{Sort: domain.ProductSortName, Limit: 20, Status: domain.ProductStatusPublished}).Return(&domain.ProductPage{Products: []domain.Product{{ID: 1}, {ID: 2}}}, nil)
+	mockUserRepo.On("GetByEmail", mock.Anything, "user@test.com").Return(3, "user uuid", "user@test.com", "", "", "", "", "", "", "", "", "", nil)
+	mockProductRepo.On("GetFavoriteProductIDs", mock.Anything, int64(3), []int64{1, 2}).Return(map[int64]bool{2: true}, nil)
+	mockPricingService.On("Resolve", mock.Anything, mock.MatchedBy(func(r domain.PriceRequest) bool { return r.Product.ID == 1 && r.UserID == 3 })).Return(&domain.EffectivePrice{Unit: domain.Money{Amount: 3990, Currency: domain.CurrencyBRL}}, nil)
+	mockPricingService.On("Resolve", mock.Anything, mock.MatchedBy(func(r domain.PriceRequest) bool { return r.Product.ID == 2 })).Return(nil, domain.ErrNoPrice)
+
+	page, err := NewProductUseCase(mockProductRepo, mockUserRepo, nil, nil, nil, nil, mockPricingService).List(context.Background(), q, "user@test.com")
+
+	assert.NoError(t, err)
+	assert.False(t, page.Products[0].Favorite)
+	assert.True(t, page.Products[1].Favorite)
+	assert.Equal(t, int64(3990), page.Products[0].CustomerPrice.Unit.Amount)
+	assert.Nil(t, page.Products[1].CustomerPrice)
+	mockProductRepo.AssertNumberOfCalls(t, "GetFavoriteProductIDs", 1)
+}
+
+func TestAdminGetDraft(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 1, UUID: "uuid", Status: domain.ProductStatusDraft}, nil)
+
+	product, err := NewProductUseCase(mockProductRepo, nil, nil, nil, nil, nil, nil).AdminGet(context.Background(), "uuid")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ProductStatusDraft, product.Status)
+}
+
+func TestCreate(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockSearchIndex := new(mocks.MockSearchIndex)
+
+	p := &domain.Product{UUID: "uuid", Name: "name", Status: domain.ProductStatusDraft}
+
+	mockProductRepo.On("SlugTaken", mock.Anything, "name", int64(0)).Return(true, nil)
+	mockProductRepo.On("SlugTaken", mock.Anything, "name-2", int64(0)).Return(false, nil)
+	mockProductRepo.On("Store", mock.Anything, p).Return(nil)
+	mockSearchIndex.On("Remove", mock.Anything, "uuid").Return()
+
+	err := NewProductUseCase(mockProductRepo, nil, mockSearchIndex, nil, nil, nil, nil).Create(context.Background(), p)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "name-2", p.Slug)
+	mockProductRepo.AssertExpectations(t)
+	mockSearchIndex.AssertExpectations(t)
+}
+
+func TestUpdateNotFound(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(nil, nil)
+
+	product, err := NewProductUseCase(mockProductRepo, nil, nil, nil, nil, nil, nil).Update(context.Background(), &domain.Product{UUID: "uuid"})
+
+	assert.NoError(t, err)
+	assert.Nil(t, product)
+}
+
+func TestUpdateVersionConflict(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	p := &domain.Product{UUID: "uuid", Version: 1}
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7, UUID: "uuid", Version: 2}, nil)
+	mockProductRepo.On("Update", mock.Anything, p).Return(domain.ErrVersionConflict)
+
+	_, err := NewProductUseCase(mockProductRepo, nil, nil, nil, nil, nil, nil).Update(context.Background(), p)
+
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	assert.Equal(t, int64(7), p.ID)
+}
+
+func TestUpdate(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockSearchIndex := new(mocks.MockSearchIndex)
+
+	p := &domain.Product{UUID: "uuid", Name: "new name", Status: domain.ProductStatusPublished, Version: 2}
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7, UUID: "uuid", Slug: "name", Name: "name", Version: 2}, nil)
+	mockProductRepo.On("SlugTaken", mock.Anything, "new-name", int64(7)).Return(false, nil)
+	mockProductRepo.On("Update", mock.Anything, p).Return(nil)
+	mockSearchIndex.On("Index", mock.Anything, p).Return()
+
+	product, err := NewProductUseCase(mockProductRepo, nil, mockSearchIndex, nil, nil, nil, nil).Update(context.Background(), p)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "new name", product.Name)
+	assert.Equal(t, "new-name", product.Slug)
+	mockSearchIndex.AssertExpectations(t)
+}
+
+func TestUpdateKeepsSlug(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockSearchIndex := new(mocks.MockSearchIndex)
+
+	p := &domain.Product{UUID: "uuid", Name: "name", Detail: "new detail", Status: domain.ProductStatusPublished, Version: 2}
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7, UUID: "uuid", Slug: "name-2", Name: "name", Version: 2}, nil)
+	mockProductRepo.On("Update", mock.Anything, p).Return(nil)
+	mockSearchIndex.On("Index", mock.Anything, p).Return()
+
+	product, err := NewProductUseCase(mockProductRepo, nil, mockSearchIndex, nil, nil, nil, nil).Update(context.Background(), p)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "name-2", product.Slug)
+	mockProductRepo.AssertNotCalled(t, "SlugTaken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteNotFound(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(nil, nil)
+
+	product, err := NewProductUseCase(mockProductRepo, nil, nil, nil, nil, nil, nil).Delete(context.Background(), "uuid", 1)
+
+	assert.NoError(t, err)
+	assert.Nil(t, product)
+	mockProductRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDelete(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockSearchIndex := new(mocks.MockSearchIndex)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7, UUID: "uuid", Version: 1}, nil)
+	mockProductRepo.On("Delete", mock.Anything, "uuid", int64(1)).Return(nil)
+	mockSearchIndex.On("Remove", mock.Anything, "uuid").Return()
+
+	product, err := NewProductUseCase(mockProductRepo, nil, mockSearchIndex, nil, nil, nil, nil).Delete(context.Background(), "uuid", 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), product.ID)
+	mockSearchIndex.AssertExpectations(t)
+}
+
+func TestSearchNoHits(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockSearchIndex := new(mocks.MockSearchIndex)
+
+	mockSearchIndex.On("Search", mock.Anything, "camiseta", 20).Return([]domain.SearchHit{})
+
+	page, err := NewProductUseCase(mockProductRepo, nil, mockSearchIndex, nil, nil, nil, nil).Search(context.Background(), "camiseta", 20, "")
+
+	assert.NoError(t, err)
+	assert.Empty(t, page.Products)
+	mockProductRepo.AssertNotCalled(t, "GetByUUIDs", mock.Anything, mock.Anything)
+}
+
+func TestSearchKeepsRelevanceOrder(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockSearchIndex := new(mocks.MockSearchIndex)
+	mockSearchRepo := new(mocks.MockSearchRepository)
+
+	hits := []domain.SearchHit{{ProductUUID: "uuid2", Score: 3}, {ProductUUID: "uuid3", Score: 2}, {ProductUUID: "uuid1", Score: 1}}
+
+	mockSearchIndex.On("Search", mock.Anything, "camiseta", 20).Return(hits)
+	mockProductRepo.On("GetByUUIDs", mock.Anything, []string{"uuid2", "uuid3", "uuid1"}).Return([]domain.Product{
+		{ID: 1, UUID: "uuid1", Status: domain.ProductStatusPublished},
+		{ID: 2, UUID: "uuid2", Status: domain.ProductStatusPublished},
+		{ID: 3, UUID: "uuid3", Status: domain.ProductStatusArchived},
+	}, nil)
+
+	mockSearchRepo.On("StoreQuery", mock.Anything, "camiseta").Return(errors.New("error message"))
+
+	page, err := NewProductUseCase(mockProductRepo, nil, mockSearchIndex, mockSearchRepo, nil, nil, nil).Search(context.Background(), "camiseta", 20, "")
+
+	assert.NoError(t, err)
+	mockSearchRepo.AssertExpectations(t)
+	assert.Len(t, page.Products, 2)
+	assert.Equal(t, "uuid2", page.Products[0].UUID)
+	assert.Equal(t, "uuid1", page.Products[1].UUID)
+}
+
+func TestSearchError(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockSearchIndex := new(mocks.MockSearchIndex)
+
+	mockSearchIndex.On("Search", mock.Anything, "camiseta", 20).Return([]domain.SearchHit{{ProductUUID: "uuid1", Score: 1}})
+	mockProductRepo.On("GetByUUIDs", mock.Anything, []string{"uuid1"}).Return(nil, errors.New("error message"))
+
+	_, err := NewProductUseCase(mockProductRepo, nil, mockSearchIndex, nil, nil, nil, nil).Search(context.Background(), "camiseta", 20, "")
+
+	assert.Error(t, err)
+}
+
+func TestIndexAll(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockSearchIndex := new(mocks.MockSearchIndex)
+
+	q := domain.ProductQuery{Limit: 100, Sort: domain.ProductSortName, Status: domain.ProductStatusPublished}
+	nextQ := q
+	nextQ.Cursor = "cursor"
+
+	mockProductRepo.On("List", mock.Anything, q).Return(&domain.ProductPage{Products: []domain.Product{{UUID: "uuid1"}}, NextCursor: "cursor"}, nil)
+	mockProductRepo.On("List", mock.Anything, nextQ).Return(&domain.ProductPage{Products: []domain.Product{{UUID: "uuid2"}}}, nil)
+	mockSearchIndex.On("Index", mock.Anything, mock.Anything).Return()
+
+	err := NewProductUseCase(mockProductRepo, nil, mockSearchIndex, nil, nil, nil, nil).IndexAll(context.Background())
+
+	assert.NoError(t, err)
+	mockSearchIndex.AssertNumberOfCalls(t, "Index", 2)
+}
+
+func TestListFacets(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	q := domain.ProductQuery{Sort: domain.ProductSortName, Limit: 20, WithFacets: true}
+	published := domain.ProductQuery{Sort: domain.ProductSortName, Limit: 20, WithFacets: true, Status: domain.ProductStatusPublished}
+
+	facets := &domain.ProductFacets{Categories: []domain.FacetValue{{Value: "shirts", Name: "Shirts", Count: 2}}}
+
+	mockProductRepo.On("List", mock.Anything, published).Return(&domain.ProductPage{Products: []domain.Product{{ID: 1}, {ID: 2}}}, nil)
+	mockProductRepo.On("Facets", mock.Anything, published).Return(facets, nil)
+
+	page, err := NewProductUseCase(mockProductRepo, nil, nil, nil, nil, nil, nil).List(context.Background(), q, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, facets, page.Facets)
+}
+
+func TestListFacetsError(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	q := domain.ProductQuery{Sort: domain.ProductSortName, Limit: 20, WithFacets: true}
+	published := domain.ProductQuery{Sort: domain.ProductSortName, Limit: 20, WithFacets: true, Status: domain.ProductStatusPublished}
+
+	mockProductRepo.On("List", mock.Anything, published).Return(&domain.ProductPage{Products: []domain.Product{}}, nil)
+	mockProductRepo.On("Facets", mock.Anything, published).Return(nil, errors.New("error message"))
+
+	_, err := NewProductUseCase(mockProductRepo, nil, nil, nil, nil, nil, nil).List(context.Background(), q, "")
+
+	assert.Error(t, err)
+}
+
+func TestCompleteTranslated(t *testing.T) {
+	mockVariantRepo := new(mocks.MockVariantRepository)
+	mockTranslationRepo := new(mocks.MockTranslationRepository)
+
+	product := &domain.Product{ID: 1, UUID: "uuid", Name: "Camiseta", Detail: "Algodão", Attributes: []domain.Attribute{{Label: "cor", Values: []string{"vermelho", "preto"}}}, Status: domain.ProductStatusPublished}
+
+	mockVariantRepo.On("GetByProductID", mock.Anything, int64(1)).Return([]domain.Variant{{ID: 4, ProductID: 1, Options: []domain.VariantOption{{Label: "cor", Value: "vermelho"}}}}, nil)
+	mockTranslationRepo.On("GetProductTranslations", mock.Anything, []int64{1}, []string{"es-AR", "es"}).Return([]domain.ProductTranslation{
+		{ProductID: 1, Locale: "es", Name: "Camiseta", Detail: "Algodón"},
+		{ProductID: 1, Locale: "es-AR", Name: "Remera"},
+	}, nil)
+	mockTranslationRepo.On("GetAttributeTranslations", mock.Anything, []string{"es-AR", "es"}, []string{"cor"}).Return([]domain.AttributeTranslation{
+		{Locale: "es", Label: "cor", Translation: "color", Values: map[string]string{"vermelho": "rojo", "preto": "negro"}},
+		{Locale: "es-AR", Label: "cor", Translation: "colores", Values: map[string]string{"vermelho": "colorado"}},
+	}, nil)
+
+	ctx := domain.ContextWithLocales(context.Background(), []string{"es-AR", "es"})
+
+	err := NewProductUseCase(nil, nil, nil, nil, mockVariantRepo, mockTranslationRepo, nil).Complete(ctx, product)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Remera", product.Name)
+	assert.Equal(t, "Algodón", product.Detail)
+	assert.Equal(t, []domain.Attribute{{Label: "colores", Values: []string{"colorado", "negro"}}}, product.Attributes)
+	assert.Equal(t, []domain.VariantOption{{Label: "colores", Value: "colorado"}}, product.Variants[0].Options)
+}
+
+func TestListTranslatedFacets(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockTranslationRepo := new(mocks.MockTranslationRepository)
+
+	q := domain.ProductQuery{Sort: domain.ProductSortName, Limit: 20, WithFacets: true}
+	published := domain.ProductQuery{Sort: domain.ProductSortName, Limit: 20, WithFacets: true, Status: domain.ProductStatusPublished}
+
+	facets := &domain.ProductFacets{Attributes: []domain.AttributeFacet{{Label: "tamanho", Values: []domain.FacetValue{{Value: "P", Count: 1}, {Value: "M", Count: 1}}}}}
+
+	mockProductRepo.On("List", mock.Anything, published).Return(&domain.ProductPage{Products: []domain.Product{{ID: 1, Name: "Camiseta"}}}, nil)
+	mockProductRepo.On("Facets", mock.Anything, published).Return(facets, nil)
+	mockTranslationRepo.On("GetProductTranslations", mock.Anything, []int64{1}, []string{"es"}).Return([]domain.ProductTranslation{}, nil)
+	mockTranslationRepo.On("GetAttributeTranslations", mock.Anything, []string{"es"}, []string{"tamanho"}).Return([]domain.AttributeTranslation{
+		{Locale: "es", Label: "tamanho", Translation: "talla", Values: map[string]string{"P": "S"}},
+	}, nil)
+
+	ctx := domain.ContextWithLocales(context.Background(), []string{"es"})
+
+	page, err := NewProductUseCase(mockProductRepo, nil, nil, nil, nil, mockTranslationRepo, nil).List(ctx, q, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Camiseta", page.Products[0].Name)
+	assert.Equal(t, []domain.AttributeFacet{{Label: "tamanho", Name: "talla", Values: []domain.FacetValue{{Value: "P", Name: "S", Count: 1}, {Value: "M", Count: 1}}}}, page.Facets.Attributes)
+}
+
+func TestCompleteTranslationError(t *testing.T) {
+	mockVariantRepo := new(mocks.MockVariantRepository)
+	mockTranslationRepo := new(mocks.MockTranslationRepository)
+
+	mockVariantRepo.On("GetByProductID", mock.Anything, int64(1)).Return([]domain.Variant{}, nil)
+	mockTranslationRepo.On("GetProductTranslations", mock.Anything, []int64{1}, []string{"es"}).Return(nil, errors.New("error message"))
+
+	ctx := domain.ContextWithLocales(context.Background(), []string{"es"})
+
+	err := NewProductUseCase(nil, nil, nil, nil, mockVariantRepo, mockTranslationRepo, nil).Complete(ctx, &domain.Product{ID: 1, UUID: "uuid", Status: domain.ProductStatusPublished})
+
+	assert.Error(t, err)
 }
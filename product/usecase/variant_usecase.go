@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+const maxVariants = 500
+
+type variantUseCase struct {
+	productRepo domain.ProductRepository
+	variantRepo domain.VariantRepository
+}
+
+func NewVariantUseCase(pr domain.ProductRepository, vr domain.VariantRepository) domain.VariantUseCase {
+	return &variantUseCase{productRepo: pr, variantRepo: vr}
+}
+
+// Generate builds one variant for each combination of the attribute values
+// of the product. The variants of combinations that already existed are kept
+// as they are, the new ones start with the product price, no stock and an
+// internal barcode, and the ones of combinations that are gone are removed.
+func (vu *variantUseCase) Generate(ctx context.Context, productUUID string) ([]domain.Variant, error) {
+	product, err := vu.productRepo.GetByUUID(ctx, productUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil {
+		return nil, nil
+	}
+
+	existing, err := vu.variantRepo.GetByProductID(ctx, product.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	combinations, err := variantCombinations(product.Attributes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := map[string]domain.Variant{}
+	skus := map[string]bool{}
+	barcodes := map[string]bool{}
+
+	for _, v := range existing {
+		byKey[optionsKey(v.Options)] = v
+		skus[v.SKU] = true
+		barcodes[v.Barcode] = true
+	}
+
+	variants := make([]domain.Variant, 0, len(combinations))
+	sequence := 0
+
+	for _, options := range combinations {
+		if v, ok := byKey[optionsKey(options)]; ok {
+			variants = append(variants, v)
+			continue
+		}
+
+		v := domain.Variant{ProductID: product.ID, Options: options, Price: product.Price, Pictures: []string{}}
+
+		v.SKU = skuCode(product.ID, options)
+		for i := 2; skus[v.SKU]; i++ {
+			v.SKU = fmt.Sprintf("%s-%d", skuCode(product.ID, options), i)
+		}
+		skus[v.SKU] = true
+
+		for {
+			sequence++
+			v.Barcode = internalBarcode(product.ID, sequence)
+
+			if !barcodes[v.Barcode] {
+				break
+			}
+		}
+		barcodes[v.Barcode] = true
+
+		variants = append(variants, v)
+	}
+
+	if err := vu.variantRepo.Replace(ctx, product.ID, variants); err != nil {
+		return nil, err
+	}
+
+	vu.touch(ctx, product)
+
+	return variants, nil
+}
+
+func (vu *variantUseCase) Update(ctx context.Context, productUUID string, v *domain.Variant) (*domain.Variant, error) {
+	product, err := vu.productRepo.GetByUUID(ctx, productUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil {
+		return nil, nil
+	}
+
+	existing, err := vu.variantRepo.GetBySKU(ctx, v.SKU)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if existing == nil || existing.ProductID != product.ID {
+		return nil, nil
+	}
+
+	if v.Barcode != existing.Barcode {
+		other, err := vu.variantRepo.GetByBarcode(ctx, v.Barcode)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if other != nil {
+			return nil, domain.ErrBarcodeTaken
+		}
+	}
+
+	existing.Price = v.Price
+	existing.Barcode = v.Barcode
+	existing.Pictures = v.Pictures
+
+	if err := vu.variantRepo.Update(ctx, existing); err != nil {
+		return nil, err
+	}
+
+	vu.touch(ctx, product)
+
+	return existing, nil
+}
+
+// touch drops the cached copies of the product, its variants are part of
+// what is shown of it. The variants are already written, so an error is
+// only logged.
+func (vu *variantUseCase) touch(ctx context.Context, product *domain.Product) {
+	if err := vu.productRepo.Touch(ctx, []int64{product.ID}); err != nil {
+		log.Printf("Error trying to touch the product %s: %s", product.UUID, err.Error())
+	}
+}
+
+// variantCombinations is the cartesian product of the attribute values, the
+// attributes without values are left out. A product without values has a
+// single variant without options, so it still has a sku to hold its stock.
+func variantCombinations(attributes []domain.Attribute) ([][]domain.VariantOption, error) {
+	total := 1
+	withValues := []domain.Attribute{}
+
+	for _, attribute := range attributes {
+		if len(attribute.Values) == 0 {
+			continue
+		}
+
+		withValues = append(withValues, attribute)
+		total *= len(attribute.Values)
+
+		if total > maxVariants {
+			return nil, domain.ErrTooManyVariants
+		}
+	}
+
+	combinations := [][]domain.VariantOption{{}}
+
+	for _, attribute := range withValues {
+		next := make([][]domain.VariantOption, 0, len(combinations)*len(attribute.Values))
+
+		for _, combination := range combinations {
+			for _, value := range attribute.Values {
+				options := make([]domain.VariantOption, len(combination), len(combination)+1)
+				copy(options, combination)
+				next = append(next, append(options, domain.VariantOption{Label: attribute.Label, Value: value}))
+			}
+		}
+
+		combinations = next
+	}
+
+	return combinations, nil
+}
+
+// optionsKey identifies a combination whatever the order of its options, so
+// reordering the attributes of a product keeps its variants.
+func optionsKey(options []domain.VariantOption) string {
+	parts := make([]string, len(options))
+	for i, option := range options {
+		parts[i] = option.Label + "=" + option.Value
+	}
+	sort.Strings(parts)
+
+	return strings.Join(parts, "\x00")
+}
+
+var skuAccents = strings.NewReplacer("Á", "A", "À", "A", "Â", "A", "Ã", "A", "Ä", "A", "É", "E", "Ê", "E", "Ë", "E", "Í", "I", "Ï", "I", "Ó", "O", "Ô", "O", "Õ", "O", "Ö", "O", "Ú", "U", "Ü", "U", "Ç", "C", "Ñ", "N")
+
+// skuCode is P<product id> followed by up to 10 letters or digits of each
+// option value, like P12-PRETO-GG.
+func skuCode(productID int64, options []domain.VariantOption) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "P%d", productID)
+
+	for _, option := range options {
+		value := skuAccents.Replace(strings.ToUpper(option.Value))
+		code := []byte{}
+
+		for i := 0; i < len(value) && len(code) < 10; i++ {
+			if (value[i] >= 'A' && value[i] <= 'Z') || (value[i] >= '0' && value[i] <= '9') {
+				code = append(code, value[i])
+			}
+		}
+
+		if len(code) == 0 {
+			code = []byte("X")
+		}
+
+		b.WriteString("-")
+		b.Write(code)
+	}
+
+	return b.String()
+}
+
+// internalBarcode is an EAN-13 in the prefix 2 range, reserved by GS1 for
+// in-store numbers: 2, the product id in 7 digits, the sequence in 4 digits
+// and the check digit.
+func internalBarcode(productID int64, sequence int) string {
+	digits := fmt.Sprintf("2%07d%04d", productID%10000000, sequence%10000)
+
+	sum := 0
+	for i := 0; i < 12; i++ {
+		d := int(digits[i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+
+	return fmt.Sprintf("%s%d", digits, (10-sum%10)%10)
+}
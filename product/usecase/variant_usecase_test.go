@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGenerateProductNotFound(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(nil, nil)
+
+	variants, err := NewVariantUseCase(mockProductRepo, nil).Generate(context.Background(), "uuid")
+
+	assert.NoError(t, err)
+	assert.Nil(t, variants)
+}
+
+func TestGenerateTooManyVariants(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	values := make([]string, 30)
+	for i := range values {
+		values[i] = string(rune('a' + i%26))
+	}
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7, Attributes: []domain.Attribute{{Label: "a", Values: values}, {Label: "b", Values: values}}}, nil)
+	mockVariantRepo.On("GetByProductID", mock.Anything, int64(7)).Return([]domain.Variant{}, nil)
+
+	_, err := NewVariantUseCase(mockProductRepo, mockVariantRepo).Generate(context.Background(), "uuid")
+
+	assert.ErrorIs(t, err, domain.ErrTooManyVariants)
+	mockVariantRepo.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGenerate(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	product := &domain.Product{ID: 7, Price: 4990, Attributes: []domain.Attribute{
+		{Label: "size", Values: []string{"M", "G"}},
+		{Label: "material", Values: []string{}},
+		{Label: "color", Values: []string{"preto", "Azul-claro"}},
+	}}
+
+	// the color and size of the existing one come in another order
+	existing := domain.Variant{ID: 3, ProductID: 7, SKU: "P7-M-PRETO", Price: 3990, Stock: 5, Barcode: "7891234567895", Options: []domain.VariantOption{{Label: "color", Value: "preto"}, {Label: "size", Value: "M"}}, Pictures: []string{}}
+	removed := domain.Variant{ID: 4, ProductID: 7, SKU: "P7-M-AZULCLARO", Barcode: "2000000700014", Options: []domain.VariantOption{{Label: "size", Value: "M"}, {Label: "color", Value: "branco"}}, Pictures: []string{}}
+
+	expected := []domain.Variant{
+		existing,
+		{ProductID: 7, SKU: "P7-M-AZULCLARO-2", Price: 4990, Barcode: "2000000700021", Options: []domain.VariantOption{{Label: "size", Value: "M"}, {Label: "color", Value: "Azul-claro"}}, Pictures: []string{}},
+		{ProductID: 7, SKU: "P7-G-PRETO", Price: 4990, Barcode: "2000000700038", Options: []domain.VariantOption{{Label: "size", Value: "G"}, {Label: "color", Value: "preto"}}, Pictures: []string{}},
+		{ProductID: 7, SKU: "P7-G-AZULCLARO", Price: 4990, Barcode: "2000000700045", Options: []domain.VariantOption{{Label: "size", Value: "G"}, {Label: "color", Value: "Azul-claro"}}, Pictures: []string{}},
+	}
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(product, nil)
+	mockVariantRepo.On("GetByProductID", mock.Anything, int64(7)).Return([]domain.Variant{existing, removed}, nil)
+	mockVariantRepo.On("Replace", mock.Anything, int64(7), expected).Return(nil)
+	mockProductRepo.On("Touch", mock.Anything, []int64{7}).Return(nil)
+
+	variants, err := NewVariantUseCase(mockProductRepo, mockVariantRepo).Generate(context.Background(), "uuid")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, variants)
+	mockVariantRepo.AssertExpectations(t)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestGenerateWithoutAttributes(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7, Price: 4990}, nil)
+	mockVariantRepo.On("GetByProductID", mock.Anything, int64(7)).Return([]domain.Variant{{ID: 3, SKU: "P7-M", Options: []domain.VariantOption{{Label: "size", Value: "M"}}}}, nil)
+	mockVariantRepo.On("Replace", mock.Anything, int64(7), mock.Anything).Return(nil)
+	mockProductRepo.On("Touch", mock.Anything, []int64{7}).Return(nil)
+
+	variants, err := NewVariantUseCase(mockProductRepo, mockVariantRepo).Generate(context.Background(), "uuid")
+
+	assert.NoError(t, err)
+	assert.Len(t, variants, 1)
+	assert.Equal(t, "P7", variants[0].SKU)
+	assert.Empty(t, variants[0].Options)
+	assert.Equal(t, int64(4990), variants[0].Price)
+	mockVariantRepo.AssertExpectations(t)
+}
+
+func TestUpdateVariantOfOtherProduct(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7}, nil)
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P8-M").Return(&domain.Variant{ID: 3, ProductID: 8, SKU: "P8-M"}, nil)
+
+	variant, err := NewVariantUseCase(mockProductRepo, mockVariantRepo).Update(context.Background(), "uuid", &domain.Variant{SKU: "P8-M"})
+
+	assert.NoError(t, err)
+	assert.Nil(t, variant)
+}
+
+func TestUpdateVariantBarcodeTaken(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7}, nil)
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P7-M").Return(&domain.Variant{ID: 3, ProductID: 7, SKU: "P7-M", Barcode: "2000000700014"}, nil)
+	mockVariantRepo.On("GetByBarcode", mock.Anything, "7891234567895").Return(&domain.Variant{ID: 9}, nil)
+
+	_, err := NewVariantUseCase(mockProductRepo, mockVariantRepo).Update(context.Background(), "uuid", &domain.Variant{SKU: "P7-M", Barcode: "7891234567895"})
+
+	assert.ErrorIs(t, err, domain.ErrBarcodeTaken)
+}
+
+func TestUpdateVariantError(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7}, nil)
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P7-M").Return(nil, errors.New("error message"))
+
+	_, err := NewVariantUseCase(mockProductRepo, mockVariantRepo).Update(context.Background(), "uuid", &domain.Variant{SKU: "P7-M"})
+
+	assert.Error(t, err)
+}
+
+func TestUpdateVariant(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockVariantRepo := new(mocks.MockVariantRepository)
+
+	updated := &domain.Variant{ID: 3, ProductID: 7, SKU: "P7-M", Price: 5990, Stock: 2, Barcode: "2000000700014", Options: []domain.VariantOption{{Label: "size", Value: "M"}}, Pictures: []string{"m.png"}}
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7}, nil)
+	mockVariantRepo.On("GetBySKU", mock.Anything, "P7-M").Return(&domain.Variant{ID: 3, ProductID: 7, SKU: "P7-M", Price: 4990, Stock: 2, Barcode: "2000000700014", Options: []domain.VariantOption{{Label: "size", Value: "M"}}, Pictures: []string{}}, nil)
+	mockVariantRepo.On("Update", mock.Anything, updated).Return(nil)
+	mockProductRepo.On("Touch", mock.Anything, []int64{7}).Return(errors.New("error message"))
+
+	variant, err := NewVariantUseCase(mockProductRepo, mockVariantRepo).Update(context.Background(), "uuid", &domain.Variant{SKU: "P7-M", Price: 5990, Stock: 9, Barcode: "2000000700014", Pictures: []string{"m.png"}})
+
+	// the variant is written, a product not touched is only logged
+	assert.NoError(t, err)
+	assert.Equal(t, updated, variant)
+	mockProductRepo.AssertCalled(t, "Touch", mock.Anything, []int64{7})
+	mockVariantRepo.AssertNotCalled(t, "GetByBarcode", mock.Anything, mock.Anything)
+}
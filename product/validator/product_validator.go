@@ -0,0 +1,97 @@
+package validator
+
+import (
+	"context"
+	"net/url"
+	"unicode/utf8"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type productValidator struct{}
+
+func NewProductValidator() *productValidator {
+	return &productValidator{}
+}
+
+func (pv *productValidator) Validate(ctx context.Context, p *domain.Product) (domain.IsValid, domain.Message) {
+	if p.Name == "" {
+		return false, "product's name can not be empty"
+	}
+
+	if p.Detail == "" {
+		return false, "product's detail can not be empty"
+	}
+
+	if utf8.RuneCountInString(p.Name) > 150 {
+		return false, "product's name can not have more than 150 characters"
+	}
+
+	if utf8.RuneCountInString(p.Detail) > 250 {
+		return false, "product's detail can not have more than 250 characters"
+	}
+
+	if utf8.RuneCountInString(p.MetaTitle) > 150 {
+		return false, "product's meta title can not have more than 150 characters"
+	}
+
+	if utf8.RuneCountInString(p.MetaDescription) > 300 {
+		return false, "product's meta description can not have more than 300 characters"
+	}
+
+	if p.CanonicalURL != "" {
+		u, err := url.Parse(p.CanonicalURL)
+
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(p.CanonicalURL) > 250 {
+			return false, "product's canonical url must be an absolute http or https url with up to 250 characters"
+		}
+	}
+
+	if p.Price < 0 {
+		return false, "product's price can not be negative"
+	}
+
+	if p.Status != domain.ProductStatusDraft && p.Status != domain.ProductStatusPublished && p.Status != domain.ProductStatusArchived {
+		return false, "product's status must be draft, published or archived"
+	}
+
+	for _, picture := range p.Pictures {
+		if picture == "" {
+			return false, "product's picture can not be empty"
+		}
+
+		if utf8.RuneCountInString(picture) > 250 {
+			return false, "product's picture can not have more than 250 characters"
+		}
+	}
+
+	labels := map[string]bool{}
+
+	for _, attribute := range p.Attributes {
+		if attribute.Label == "" {
+			return false, "product's attribute label can not be empty"
+		}
+
+		if labels[attribute.Label] {
+			return false, "product's attribute labels must be unique"
+		}
+
+		labels[attribute.Label] = true
+
+		values := map[string]bool{}
+
+		for _, value := range attribute.Values {
+			if value == "" {
+				return false, "product's attribute value can not be empty"
+			}
+
+			if values[value] {
+				return false, "product's attribute values must be unique"
+			}
+
+			values[value] = true
+		}
+	}
+
+	return true, ""
+}
@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateProductDataCanNotBeEmpty(t *testing.T) {
+	isValid, message := NewProductValidator().Validate(context.Background(), &domain.Product{})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+
+	isValid, message = NewProductValidator().Validate(context.Background(), &domain.Product{Name: "name"})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidateProductTooLong(t *testing.T) {
+	isValid, message := NewProductValidator().Validate(context.Background(), &domain.Product{Name: strings.Repeat("a", 151), Detail: "detail", Status: domain.ProductStatusDraft})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+
+	isValid, message = NewProductValidator().Validate(context.Background(), &domain.Product{Name: "name", Detail: strings.Repeat("a", 251), Status: domain.ProductStatusDraft})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidateProductMeta(t *testing.T) {
+	for _, p := range []domain.Product{
+		{MetaTitle: strings.Repeat("a", 151)},
+		{MetaDescription: strings.Repeat("a", 301)},
+		{CanonicalURL: "/products/camiseta"},
+		{CanonicalURL: "ftp://shop.test/camiseta"},
+		{CanonicalURL: "https://shop.test/" + strings.Repeat("a", 250)},
+	} {
+		p.Name, p.Detail, p.Status = "name", "detail", domain.ProductStatusDraft
+
+		isValid, message := NewProductValidator().Validate(context.Background(), &p)
+
+		assert.False(t, bool(isValid))
+		assert.NotEmpty(t, message)
+	}
+
+	isValid, _ := NewProductValidator().Validate(context.Background(), &domain.Product{Name: "name", Detail: "detail", Status: domain.ProductStatusDraft, MetaTitle: "Camiseta", MetaDescription: "Camiseta de algodão", CanonicalURL: "https://shop.test/camiseta"})
+
+	assert.True(t, bool(isValid))
+}
+
+func TestValidateProductNegativePrice(t *testing.T) {
+	isValid, message := NewProductValidator().Validate(context.Background(), &domain.Product{Name: "name", Detail: "detail", Price: -1, Status: domain.ProductStatusDraft})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidateProductInvalidStatus(t *testing.T) {
+	isValid, message := NewProductValidator().Validate(context.Background(), &domain.Product{Name: "name", Detail: "detail", Status: "deleted"})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidateProductEmptyPicture(t *testing.T) {
+	isValid, message := NewProductValidator().Validate(context.Background(), &domain.Product{Name: "name", Detail: "detail", Status: domain.ProductStatusDraft, Pictures: []string{""}})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidateProductAttributes(t *testing.T) {
+	isValid, message := NewProductValidator().Validate(context.Background(), &domain.Product{Name: "name", Detail: "detail", Status: domain.ProductStatusDraft, Attributes: []domain.Attribute{{Label: "", Values: []string{"black"}}}})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+
+	isValid, message = NewProductValidator().Validate(context.Background(), &domain.Product{Name: "name", Detail: "detail", Status: domain.ProductStatusDraft, Attributes: []domain.Attribute{{Label: "color", Values: []string{"black"}}, {Label: "color", Values: []string{"white"}}}})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+
+	isValid, message = NewProductValidator().Validate(context.Background(), &domain.Product{Name: "name", Detail: "detail", Status: domain.ProductStatusDraft, Attributes: []domain.Attribute{{Label: "color", Values: []string{"black", "black"}}}})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+
+	isValid, message = NewProductValidator().Validate(context.Background(), &domain.Product{Name: "name", Detail: "detail", Status: domain.ProductStatusDraft, Attributes: []domain.Attribute{{Label: "color", Values: []string{""}}}})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidateProduct(t *testing.T) {
+	isValid, message := NewProductValidator().Validate(context.Background(), &domain.Product{
+		Name:       "Camiseta básica",
+		Detail:     "Camiseta de algodão",
+		Price:      4990,
+		Status:     domain.ProductStatusPublished,
+		Pictures:   []string{"picture.png"},
+		Attributes: []domain.Attribute{{Label: "color", Values: []string{"black", "white"}}, {Label: "size", Values: []string{"M"}}},
+	})
+
+	assert.True(t, bool(isValid))
+	assert.Empty(t, message)
+}
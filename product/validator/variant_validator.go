@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type variantValidator struct{}
+
+func NewVariantValidator() *variantValidator {
+	return &variantValidator{}
+}
+
+func (vv *variantValidator) Validate(ctx context.Context, v *domain.Variant) (domain.IsValid, domain.Message) {
+	if v.SKU == "" {
+		return false, "variant's sku can not be empty"
+	}
+
+	if v.Price < 0 {
+		return false, "variant's price can not be negative"
+	}
+
+	if !validEAN13(v.Barcode) {
+		return false, "variant's barcode must be a valid EAN-13"
+	}
+
+	for _, picture := range v.Pictures {
+		if picture == "" {
+			return false, "variant's picture can not be empty"
+		}
+
+		if utf8.RuneCountInString(picture) > 250 {
+			return false, "variant's picture can not have more than 250 characters"
+		}
+	}
+
+	return true, ""
+}
+
+func validEAN13(barcode string) bool {
+	if len(barcode) != 13 {
+		return false
+	}
+
+	sum := 0
+
+	for i := 0; i < 13; i++ {
+		if barcode[i] < '0' || barcode[i] > '9' {
+			return false
+		}
+
+		d := int(barcode[i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+
+	return sum%10 == 0
+}
@@ -0,0 +1,39 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateVariantNegative(t *testing.T) {
+	isValid, message := NewVariantValidator().Validate(context.Background(), &domain.Variant{SKU: "P7-M", Price: -1, Barcode: "7891234567895"})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidateVariantBarcode(t *testing.T) {
+	for _, barcode := range []string{"", "789123456789", "7891234567896", "789123456789a"} {
+		isValid, message := NewVariantValidator().Validate(context.Background(), &domain.Variant{SKU: "P7-M", Barcode: barcode})
+
+		assert.False(t, bool(isValid), barcode)
+		assert.NotEmpty(t, message)
+	}
+}
+
+func TestValidateVariantPictureEmpty(t *testing.T) {
+	isValid, message := NewVariantValidator().Validate(context.Background(), &domain.Variant{SKU: "P7-M", Barcode: "7891234567895", Pictures: []string{""}})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidateVariant(t *testing.T) {
+	isValid, message := NewVariantValidator().Validate(context.Background(), &domain.Variant{SKU: "P7-M", Price: 4990, Stock: 3, Barcode: "2000000700014", Pictures: []string{"m.png"}})
+
+	assert.True(t, bool(isValid))
+	assert.Empty(t, message)
+}
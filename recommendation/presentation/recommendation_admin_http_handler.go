@@ -0,0 +1,88 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type recommendationAdminHandler struct {
+	RecommendationUseCase domain.RecommendationUseCase
+}
+
+type pinnedRequest struct {
+	Products []string `json:"products"`
+}
+
+func NewRecommendationAdminHandler(e *echo.Echo, ruc domain.RecommendationUseCase, ts domain.TokenService) *recommendationAdminHandler {
+	handler := &recommendationAdminHandler{
+		RecommendationUseCase: ruc,
+	}
+
+	admin := _tokenPresentation.NewAdminMiddleware(ts)
+
+	e.GET("/admin/products/:uuid/related", handler.GetPinned, admin)
+	e.PUT("/admin/products/:uuid/related", handler.SetPinned, admin)
+
+	return handler
+}
+
+func (rah *recommendationAdminHandler) GetPinned(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	products, err := rah.RecommendationUseCase.GetPinned(c.Request().Context(), uuid)
+
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to get the pinned related products: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the related products")
+	}
+
+	return c.JSON(http.StatusOK, products)
+}
+
+func (rah *recommendationAdminHandler) SetPinned(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	var req pinnedRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	if req.Products == nil {
+		req.Products = []string{}
+	}
+
+	products, err := rah.RecommendationUseCase.SetPinned(c.Request().Context(), uuid, req.Products)
+
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	if errors.Is(err, domain.ErrInvalidRelated) {
+		return c.JSON(http.StatusBadRequest, "related products must be up to 24 other existing products, without repetitions")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to pin the related products: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to pin the related products")
+	}
+
+	return c.JSON(http.StatusOK, products)
+}
@@ -0,0 +1,122 @@
+package presentation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetPinnedNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin/products/:uuid/related", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("uuid")
+
+	mockRecommendationUsecase := new(mocks.MockRecommendationUseCase)
+
+	mockRecommendationUsecase.On("GetPinned", mock.Anything, "uuid").Return(nil, domain.ErrProductNotFound)
+
+	handler := NewRecommendationAdminHandler(echo.New(), mockRecommendationUsecase, nil)
+
+	handler.GetPinned(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestGetPinned(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin/products/:uuid/related", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("uuid")
+
+	mockRecommendationUsecase := new(mocks.MockRecommendationUseCase)
+
+	mockRecommendationUsecase.On("GetPinned", mock.Anything, "uuid").Return([]domain.Product{{UUID: "p1"}}, nil)
+
+	handler := NewRecommendationAdminHandler(echo.New(), mockRecommendationUsecase, nil)
+
+	handler.GetPinned(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestSetPinnedBadRequest(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/products/:uuid/related", strings.NewReader("{\"products\":\"p1\"}"))
+	assert.NoError(t, err)
+
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("uuid")
+
+	handler := NewRecommendationAdminHandler(echo.New(), nil, nil)
+
+	handler.SetPinned(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSetPinnedInvalid(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/products/:uuid/related", strings.NewReader("{\"products\":[\"p1\",\"p1\"]}"))
+	assert.NoError(t, err)
+
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("uuid")
+
+	mockRecommendationUsecase := new(mocks.MockRecommendationUseCase)
+
+	mockRecommendationUsecase.On("SetPinned", mock.Anything, "uuid", []string{"p1", "p1"}).Return(nil, domain.ErrInvalidRelated)
+
+	handler := NewRecommendationAdminHandler(echo.New(), mockRecommendationUsecase, nil)
+
+	handler.SetPinned(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSetPinnedEmpty(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/products/:uuid/related", strings.NewReader("{}"))
+	assert.NoError(t, err)
+
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("uuid")
+
+	mockRecommendationUsecase := new(mocks.MockRecommendationUseCase)
+
+	mockRecommendationUsecase.On("SetPinned", mock.Anything, "uuid", []string{}).Return([]domain.Product{}, nil)
+
+	handler := NewRecommendationAdminHandler(echo.New(), mockRecommendationUsecase, nil)
+
+	handler.SetPinned(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "[]\n", rec.Body.String())
+}
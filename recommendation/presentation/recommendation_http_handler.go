@@ -0,0 +1,65 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type recommendationHandler struct {
+	RecommendationUseCase domain.RecommendationUseCase
+}
+
+func NewRecommendationHandler(e *echo.Echo, ruc domain.RecommendationUseCase, ts domain.TokenService) *recommendationHandler {
+	handler := &recommendationHandler{
+		RecommendationUseCase: ruc,
+	}
+
+	optionalAuth := _tokenPresentation.NewOptionalAuthMiddleware(ts)
+
+	e.GET("/products/:uuid/related", handler.Related, optionalAuth)
+
+	return handler
+}
+
+func (rh *recommendationHandler) Related(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	limit := 8
+
+	if l := c.QueryParam("limit"); l != "" {
+		var err error
+
+		if limit, err = strconv.Atoi(l); err != nil || limit < 1 || limit > 24 {
+			return c.JSON(http.StatusBadRequest, "limit param must be a number between 1 and 24")
+		}
+	}
+
+	var login string
+
+	if tokenInfo := _tokenPresentation.TokenInfoFromContext(c); tokenInfo != nil {
+		login = tokenInfo.Info
+	}
+
+	related, err := rh.RecommendationUseCase.Related(c.Request().Context(), uuid, limit, login)
+
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to get the related products: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the related products")
+	}
+
+	return c.JSON(http.StatusOK, related)
+}
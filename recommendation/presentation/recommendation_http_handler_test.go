@@ -0,0 +1,97 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRelatedInvalidLimit(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/:uuid/related?limit=50", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("uuid")
+
+	handler := NewRecommendationHandler(echo.New(), nil, nil)
+
+	handler.Related(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRelatedNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/:uuid/related", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("uuid")
+
+	mockRecommendationUsecase := new(mocks.MockRecommendationUseCase)
+
+	mockRecommendationUsecase.On("Related", mock.Anything, "uuid", 8, "").Return(nil, domain.ErrProductNotFound)
+
+	handler := NewRecommendationHandler(echo.New(), mockRecommendationUsecase, nil)
+
+	handler.Related(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestRelatedError(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/:uuid/related", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("uuid")
+
+	mockRecommendationUsecase := new(mocks.MockRecommendationUseCase)
+
+	mockRecommendationUsecase.On("Related", mock.Anything, "uuid", 8, "").Return(nil, errors.New("error message"))
+
+	handler := NewRecommendationHandler(echo.New(), mockRecommendationUsecase, nil)
+
+	handler.Related(c)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestRelated(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/:uuid/related?limit=4", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("uuid")
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockRecommendationUsecase := new(mocks.MockRecommendationUseCase)
+
+	mockRecommendationUsecase.On("Related", mock.Anything, "uuid", 4, "user@test.com").Return(&domain.RelatedProducts{BoughtTogether: []domain.Product{{UUID: "b1"}}, Related: []domain.Product{}}, nil)
+
+	handler := NewRecommendationHandler(echo.New(), mockRecommendationUsecase, nil)
+
+	handler.Related(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"uuid":"b1"`)
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+// saleProducts are the products of each order, the sales of the stock
+// ledger carry the order as their reference.
+const saleProducts = `(SELECT DISTINCT m.reference, v.product_id FROM stock_movement m JOIN variant v ON v.sku = m.sku WHERE m.reason = ? AND m.reference <> '' AND m.created_at >= ?)`
+
+type recommendationMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewRecommendationMysqlRepository(conn *sql.DB) domain.RecommendationRepository {
+	return &recommendationMysqlRepository{Conn: conn}
+}
+
+// AddView keeps a single view of a product by user and day.
+func (rmr *recommendationMysqlRepository) AddView(ctx context.Context, userID int64, productID int64) error {
+	_, err := rmr.Conn.ExecContext(ctx, `INSERT IGNORE INTO product_view (user_id, product_id, viewed_on) VALUES (?, ?, ?);`, userID, productID, time.Now().UTC().Format(dateLayout))
+
+	return err
+}
+
+func (rmr *recommendationMysqlRepository) GetAffinities(ctx context.Context, productID int64, kind domain.AffinityKind, limit int) ([]string, error) {
+	query := `SELECT p.uuid FROM product_affinity a JOIN product p ON p.id = a.related_id WHERE a.product_id = ? AND a.kind = ? AND p.status = ? ORDER BY a.score DESC, a.related_id LIMIT ?;`
+
+	return rmr.uuids(ctx, query, productID, kind, domain.ProductStatusPublished, limit)
+}
+
+func (rmr *recommendationMysqlRepository) GetSimilar(ctx context.Context, productID int64, limit int) ([]string, error) {
+	query := `SELECT p.uuid FROM product_attribute pa JOIN product_attribute_value pav ON pav.attribute_id = pa.id ` +
+		`JOIN product_attribute opa ON opa.label = pa.label AND opa.product_id <> pa.product_id ` +
+		`JOIN product_attribute_value opav ON opav.attribute_id = opa.id AND opav.value = pav.value ` +
+		`JOIN product p ON p.id = opa.product_id ` +
+		`WHERE pa.product_id = ? AND p.status = ? GROUP BY p.id, p.uuid ORDER BY COUNT(*) DESC, p.id LIMIT ?;`
+
+	return rmr.uuids(ctx, query, productID, domain.ProductStatusPublished, limit)
+}
+
+func (rmr *recommendationMysqlRepository) GetPinned(ctx context.Context, productID int64) ([]string, error) {
+	query := `SELECT p.uuid FROM product_related r JOIN product p ON p.id = r.related_id WHERE r.product_id = ? ORDER BY r.position;`
+
+	return rmr.uuids(ctx, query, productID)
+}
+
+func (rmr *recommendationMysqlRepository) uuids(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	rows, err := rmr.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []string{}
+
+	for rows.Next() {
+		var uuid string
+
+		if err := rows.Scan(&uuid); err != nil {
+			return nil, err
+		}
+
+		res = append(res, uuid)
+	}
+
+	return res, rows.Err()
+}
+
+func (rmr *recommendationMysqlRepository) SetPinned(ctx context.Context, productID int64, relatedIDs []int64) error {
+	tx, err := rmr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM product_related WHERE product_id = ?;`, productID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for i, relatedID := range relatedIDs {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO product_related (product_id, related_id, position) VALUES (?, ?, ?);`, productID, relatedID, i); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ComputeAffinities scores the products bought in the same order by the
+// number of orders, and the products viewed by the same user in the same day
+// by the number of users, at least two so a single visit is not taken as a
+// trend. The views older than viewsSince are not needed anymore.
+func (rmr *recommendationMysqlRepository) ComputeAffinities(ctx context.Context, salesSince time.Time, viewsSince time.Time) error {
+	tx, err := rmr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM product_affinity;`); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	boughtTogether := `INSERT INTO product_affinity (product_id, related_id, kind, score) ` +
+		`SELECT a.product_id, b.product_id, ?, COUNT(*) FROM ` + saleProducts + ` a JOIN ` + saleProducts + ` b ON b.reference = a.reference AND b.product_id <> a.product_id ` +
+		`GROUP BY a.product_id, b.product_id;`
+
+	if _, err := tx.ExecContext(ctx, boughtTogether, domain.AffinityBoughtTogether, domain.MovementReasonSale, salesSince.UTC(), domain.MovementReasonSale, salesSince.UTC()); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	viewedTogether := `INSERT INTO product_affinity (product_id, related_id, kind, score) ` +
+		`SELECT a.product_id, b.product_id, ?, COUNT(DISTINCT a.user_id) FROM product_view a JOIN product_view b ON b.user_id = a.user_id AND b.viewed_on = a.viewed_on AND b.product_id <> a.product_id ` +
+		`WHERE a.viewed_on >= ? GROUP BY a.product_id, b.product_id HAVING COUNT(DISTINCT a.user_id) >= 2;`
+
+	if _, err := tx.ExecContext(ctx, viewedTogether, domain.AffinityViewedTogether, viewsSince.UTC().Format(dateLayout)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM product_view WHERE viewed_on < ?;`, viewsSince.UTC().Format(dateLayout)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// dateLayout is how the DATE columns are given to the connection.
+const dateLayout = "2006-01-02"
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddView(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO product_view (user_id, product_id, viewed_on) VALUES (?, ?, ?);")).
+		WithArgs(1, 7, time.Now().UTC().Format("2006-01-02")).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewRecommendationMysqlRepository(db).AddView(context.Background(), 1, 7)
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetAffinities(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT p.uuid FROM product_affinity a JOIN product p ON p.id = a.related_id WHERE a.product_id = ? AND a.kind = ? AND p.status = ? ORDER BY a.score DESC, a.related_id LIMIT ?;")
+
+	mock.ExpectQuery(query).WithArgs(7, domain.AffinityBoughtTogether, domain.ProductStatusPublished, 8).WillReturnRows(sqlmock.NewRows([]string{"uuid"}).AddRow("uuid2").AddRow("uuid1"))
+
+	uuids, err := NewRecommendationMysqlRepository(db).GetAffinities(context.Background(), 7, domain.AffinityBoughtTogether, 8)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"uuid2", "uuid1"}, uuids)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetSimilar(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("WHERE pa.product_id = ? AND p.status = ? GROUP BY p.id, p.uuid ORDER BY COUNT(*) DESC, p.id LIMIT ?;")
+
+	mock.ExpectQuery(query).WithArgs(7, domain.ProductStatusPublished, 8).WillReturnRows(sqlmock.NewRows([]string{"uuid"}).AddRow("uuid3"))
+
+	uuids, err := NewRecommendationMysqlRepository(db).GetSimilar(context.Background(), 7, 8)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"uuid3"}, uuids)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetPinnedError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT p.uuid FROM product_related r JOIN product p ON p.id = r.related_id WHERE r.product_id = ? ORDER BY r.position;")).WithArgs(7).WillReturnError(errors.New("error message"))
+
+	_, err = NewRecommendationMysqlRepository(db).GetPinned(context.Background(), 7)
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetPinned(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	insert := regexp.QuoteMeta("INSERT INTO product_related (product_id, related_id, position) VALUES (?, ?, ?);")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product_related WHERE product_id = ?;")).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(insert).WithArgs(7, 9, 0).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insert).WithArgs(7, 8, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = NewRecommendationMysqlRepository(db).SetPinned(context.Background(), 7, []int64{9, 8})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetPinnedError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product_related WHERE product_id = ?;")).WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_related")).WillReturnError(errors.New("error message"))
+	mock.ExpectRollback()
+
+	err = NewRecommendationMysqlRepository(db).SetPinned(context.Background(), 7, []int64{9})
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestComputeAffinities(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	salesSince := time.Date(2025, 1, 2, 3, 0, 0, 0, time.UTC)
+	viewsSince := time.Date(2025, 10, 4, 3, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product_affinity;")).WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_affinity (product_id, related_id, kind, score) SELECT a.product_id, b.product_id, ?, COUNT(*) FROM (SELECT DISTINCT m.reference, v.product_id FROM stock_movement m")).
+		WithArgs(domain.AffinityBoughtTogether, domain.MovementReasonSale, salesSince, domain.MovementReasonSale, salesSince).WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_affinity (product_id, related_id, kind, score) SELECT a.product_id, b.product_id, ?, COUNT(DISTINCT a.user_id) FROM product_view a")).
+		WithArgs(domain.AffinityViewedTogether, "2025-10-04").WillReturnResult(sqlmock.NewResult(0, 6))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product_view WHERE viewed_on < ?;")).WithArgs("2025-10-04").WillReturnResult(sqlmock.NewResult(0, 100))
+	mock.ExpectCommit()
+
+	err = NewRecommendationMysqlRepository(db).ComputeAffinities(context.Background(), salesSince, viewsSince)
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestComputeAffinitiesError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product_affinity;")).WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_affinity")).WillReturnError(errors.New("error message"))
+	mock.ExpectRollback()
+
+	err = NewRecommendationMysqlRepository(db).ComputeAffinities(context.Background(), time.Now(), time.Now())
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
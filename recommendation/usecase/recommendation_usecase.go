@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+const maxPinned = 24
+
+// the windows of history the affinities are computed from
+const salesWindow = 365 * 24 * time.Hour
+const viewsWindow = 90 * 24 * time.Hour
+
+type recommendationUseCase struct {
+	recommendationRepo domain.RecommendationRepository
+	productRepo        domain.ProductRepository
+	userRepo           domain.UserRepository
+	productUseCase     domain.ProductUseCase
+}
+
+func NewRecommendationUseCase(rr domain.RecommendationRepository, pr domain.ProductRepository, ur domain.UserRepository, puc domain.ProductUseCase) domain.RecommendationUseCase {
+	return &recommendationUseCase{recommendationRepo: rr, productRepo: pr, userRepo: ur, productUseCase: puc}
+}
+
+// Related counts a view of the product for the user, the product page asks
+// for its related products, so the product route stays free of writes. A
+// product is not repeated in the two lists.
+func (ru *recommendationUseCase) Related(ctx context.Context, uuid string, limit int, login string) (*domain.RelatedProducts, error) {
+	product, err := ru.productRepo.GetByUUID(ctx, uuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil || product.Status != domain.ProductStatusPublished {
+		return nil, domain.ErrProductNotFound
+	}
+
+	ru.addView(ctx, login, product.ID)
+
+	bought, err := ru.recommendationRepo.GetAffinities(ctx, product.ID, domain.AffinityBoughtTogether, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pinned, err := ru.recommendationRepo.GetPinned(ctx, product.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	viewed, err := ru.recommendationRepo.GetAffinities(ctx, product.ID, domain.AffinityViewedTogether, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := append(pinned, viewed...)
+
+	if len(candidates) < limit {
+		similar, err := ru.recommendationRepo.GetSimilar(ctx, product.ID, limit+len(bought))
+
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, similar...)
+	}
+
+	products, err := ru.published(ctx, append(bought, candidates...), login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{product.UUID: true}
+
+	return &domain.RelatedProducts{
+		BoughtTogether: pick(bought, products, seen, limit),
+		Related:        pick(candidates, products, seen, limit),
+	}, nil
+}
+
+// addView is a best effort, a view not counted is not worth failing the
+// request.
+func (ru *recommendationUseCase) addView(ctx context.Context, login string, productID int64) {
+	if login == "" {
+		return
+	}
+
+	user, err := ru.userRepo.GetByEmail(ctx, login)
+
+	if err == nil && user != nil {
+		err = ru.recommendationRepo.AddView(ctx, user.ID, productID)
+	}
+
+	if err != nil {
+		log.Printf("Error trying to add a product view: %s", err.Error())
+	}
+}
+
+// published gives the published products by uuid, as the customer sees them.
+func (ru *recommendationUseCase) published(ctx context.Context, uuids []string, login string) (map[string]domain.Product, error) {
+	res := map[string]domain.Product{}
+
+	if len(uuids) == 0 {
+		return res, nil
+	}
+
+	page, err := ru.productUseCase.List(ctx, domain.ProductQuery{UUIDs: uuids, Limit: len(uuids), Sort: domain.ProductSortName, Attributes: map[string][]string{}}, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range page.Products {
+		res[p.UUID] = p
+	}
+
+	return res, nil
+}
+
+func pick(uuids []string, products map[string]domain.Product, seen map[string]bool, limit int) []domain.Product {
+	res := []domain.Product{}
+
+	for _, uuid := range uuids {
+		product, ok := products[uuid]
+
+		if !ok || seen[uuid] || len(res) == limit {
+			continue
+		}
+
+		seen[uuid] = true
+		res = append(res, product)
+	}
+
+	return res
+}
+
+func (ru *recommendationUseCase) GetPinned(ctx context.Context, uuid string) ([]domain.Product, error) {
+	product, err := ru.productRepo.GetByUUID(ctx, uuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil {
+		return nil, domain.ErrProductNotFound
+	}
+
+	pinned, err := ru.recommendationRepo.GetPinned(ctx, product.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := ru.productRepo.GetByUUIDs(ctx, pinned)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return inOrder(pinned, products), nil
+}
+
+// SetPinned replaces the pinned products, in the order they are shown, with
+// products of any status, only the published ones are shown.
+func (ru *recommendationUseCase) SetPinned(ctx context.Context, uuid string, related []string) ([]domain.Product, error) {
+	product, err := ru.productRepo.GetByUUID(ctx, uuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil {
+		return nil, domain.ErrProductNotFound
+	}
+
+	unique := map[string]bool{product.UUID: true}
+
+	for _, relatedUUID := range related {
+		if unique[relatedUUID] {
+			return nil, domain.ErrInvalidRelated
+		}
+
+		unique[relatedUUID] = true
+	}
+
+	if len(related) > maxPinned {
+		return nil, domain.ErrInvalidRelated
+	}
+
+	products, err := ru.productRepo.GetByUUIDs(ctx, related)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(products) != len(related) {
+		return nil, domain.ErrInvalidRelated
+	}
+
+	products = inOrder(related, products)
+
+	relatedIDs := make([]int64, len(products))
+	for i, p := range products {
+		relatedIDs[i] = p.ID
+	}
+
+	if err := ru.recommendationRepo.SetPinned(ctx, product.ID, relatedIDs); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func inOrder(uuids []string, products []domain.Product) []domain.Product {
+	byUUID := map[string]domain.Product{}
+	for _, p := range products {
+		byUUID[p.UUID] = p
+	}
+
+	res := []domain.Product{}
+
+	for _, uuid := range uuids {
+		if p, ok := byUUID[uuid]; ok {
+			res = append(res, p)
+		}
+	}
+
+	return res
+}
+
+// ComputeAffinities is the nightly batch, it replaces the affinities of the
+// day before.
+func (ru *recommendationUseCase) ComputeAffinities(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	return ru.recommendationRepo.ComputeAffinities(ctx, now.Add(-salesWindow), now.Add(-viewsWindow))
+}
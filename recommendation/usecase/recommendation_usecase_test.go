@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRelatedProductNotFound(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7, UUID: "uuid", Status: domain.ProductStatusDraft}, nil)
+
+	_, err := NewRecommendationUseCase(nil, mockProductRepo, nil, nil).Related(context.Background(), "uuid", 4, "")
+
+	assert.ErrorIs(t, err, domain.ErrProductNotFound)
+}
+
+func TestRelated(t *testing.T) {
+	mockRecommendationRepo := new(mocks.MockRecommendationRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7, UUID: "uuid", Status: domain.ProductStatusPublished}, nil)
+	mockUserRepo.On("GetByEmail", mock.Anything, "user@test.com").Return(1, "useruuid", "user@test.com", "first", "last", "phone", "city", "state", "neighborhood", "street", "number", "zipcode", nil)
+	mockRecommendationRepo.On("AddView", mock.Anything, int64(1), int64(7)).Return(nil)
+	mockRecommendationRepo.On("GetAffinities", mock.Anything, int64(7), domain.AffinityBoughtTogether, 3).Return([]string{"b1", "b2"}, nil)
+	// the pinned draft is not shown, the viewed b2 is already bought together
+	mockRecommendationRepo.On("GetPinned", mock.Anything, int64(7)).Return([]string{"draft"}, nil)
+	mockRecommendationRepo.On("GetAffinities", mock.Anything, int64(7), domain.AffinityViewedTogether, 3).Return([]string{"b2"}, nil)
+	mockRecommendationRepo.On("GetSimilar", mock.Anything, int64(7), 5).Return([]string{"s1", "s2", "s3"}, nil)
+
+	q := domain.ProductQuery{UUIDs: []string{"b1", "b2", "draft", "b2", "s1", "s2", "s3"}, Limit: 7, Sort: domain.ProductSortName, Attributes: map[string][]string{}}
+	mockProductUsecase.On("List", mock.Anything, q, "user@test.com").Return(&domain.ProductPage{Products: []domain.Product{{UUID: "b1"}, {UUID: "b2"}, {UUID: "s1"}, {UUID: "s2"}, {UUID: "s3"}}}, nil)
+
+	related, err := NewRecommendationUseCase(mockRecommendationRepo, mockProductRepo, mockUserRepo, mockProductUsecase).Related(context.Background(), "uuid", 3, "user@test.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Product{{UUID: "b1"}, {UUID: "b2"}}, related.BoughtTogether)
+	assert.Equal(t, []domain.Product{{UUID: "s1"}, {UUID: "s2"}, {UUID: "s3"}}, related.Related)
+	mockRecommendationRepo.AssertExpectations(t)
+}
+
+func TestRelatedSkipsSimilarWhenFull(t *testing.T) {
+	mockRecommendationRepo := new(mocks.MockRecommendationRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockProductUsecase := new(mocks.MockProductUsecase)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7, UUID: "uuid", Status: domain.ProductStatusPublished}, nil)
+	mockRecommendationRepo.On("GetAffinities", mock.Anything, int64(7), domain.AffinityBoughtTogether, 2).Return([]string{}, nil)
+	mockRecommendationRepo.On("GetPinned", mock.Anything, int64(7)).Return([]string{"p1"}, nil)
+	mockRecommendationRepo.On("GetAffinities", mock.Anything, int64(7), domain.AffinityViewedTogether, 2).Return([]string{"v1", "p1"}, nil)
+	mockProductUsecase.On("List", mock.Anything, mock.Anything, "").Return(&domain.ProductPage{Products: []domain.Product{{UUID: "v1"}, {UUID: "p1"}}}, nil)
+
+	related, err := NewRecommendationUseCase(mockRecommendationRepo, mockProductRepo, nil, mockProductUsecase).Related(context.Background(), "uuid", 2, "")
+
+	assert.NoError(t, err)
+	assert.Empty(t, related.BoughtTogether)
+	assert.Equal(t, []domain.Product{{UUID: "p1"}, {UUID: "v1"}}, related.Related)
+	mockRecommendationRepo.AssertNotCalled(t, "GetSimilar", mock.Anything, mock.Anything, mock.Anything)
+	mockRecommendationRepo.AssertNotCalled(t, "AddView", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRelatedViewError(t *testing.T) {
+	mockRecommendationRepo := new(mocks.MockRecommendationRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7, UUID: "uuid", Status: domain.ProductStatusPublished}, nil)
+	mockUserRepo.On("GetByEmail", mock.Anything, "user@test.com").Return(1, "useruuid", "user@test.com", "first", "last", "phone", "city", "state", "neighborhood", "street", "number", "zipcode", nil)
+	mockRecommendationRepo.On("AddView", mock.Anything, int64(1), int64(7)).Return(errors.New("error message"))
+	mockRecommendationRepo.On("GetAffinities", mock.Anything, int64(7), mock.Anything, 4).Return([]string{}, nil)
+	mockRecommendationRepo.On("GetPinned", mock.Anything, int64(7)).Return([]string{}, nil)
+	mockRecommendationRepo.On("GetSimilar", mock.Anything, int64(7), 4).Return([]string{}, nil)
+
+	related, err := NewRecommendationUseCase(mockRecommendationRepo, mockProductRepo, mockUserRepo, nil).Related(context.Background(), "uuid", 4, "user@test.com")
+
+	assert.NoError(t, err)
+	assert.Empty(t, related.Related)
+}
+
+func TestGetPinned(t *testing.T) {
+	mockRecommendationRepo := new(mocks.MockRecommendationRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7, UUID: "uuid"}, nil)
+	mockRecommendationRepo.On("GetPinned", mock.Anything, int64(7)).Return([]string{"p2", "p1"}, nil)
+	mockProductRepo.On("GetByUUIDs", mock.Anything, []string{"p2", "p1"}).Return([]domain.Product{{UUID: "p1"}, {UUID: "p2"}}, nil)
+
+	products, err := NewRecommendationUseCase(mockRecommendationRepo, mockProductRepo, nil, nil).GetPinned(context.Background(), "uuid")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Product{{UUID: "p2"}, {UUID: "p1"}}, products)
+}
+
+func TestSetPinnedItself(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7, UUID: "uuid"}, nil)
+
+	_, err := NewRecommendationUseCase(nil, mockProductRepo, nil, nil).SetPinned(context.Background(), "uuid", []string{"p1", "uuid"})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidRelated)
+}
+
+func TestSetPinnedUnknownProduct(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7, UUID: "uuid"}, nil)
+	mockProductRepo.On("GetByUUIDs", mock.Anything, []string{"p1", "p2"}).Return([]domain.Product{{ID: 1, UUID: "p1"}}, nil)
+
+	_, err := NewRecommendationUseCase(nil, mockProductRepo, nil, nil).SetPinned(context.Background(), "uuid", []string{"p1", "p2"})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidRelated)
+}
+
+func TestSetPinned(t *testing.T) {
+	mockRecommendationRepo := new(mocks.MockRecommendationRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "uuid").Return(&domain.Product{ID: 7, UUID: "uuid"}, nil)
+	mockProductRepo.On("GetByUUIDs", mock.Anything, []string{"p2", "p1"}).Return([]domain.Product{{ID: 1, UUID: "p1"}, {ID: 2, UUID: "p2"}}, nil)
+	mockRecommendationRepo.On("SetPinned", mock.Anything, int64(7), []int64{2, 1}).Return(nil)
+
+	products, err := NewRecommendationUseCase(mockRecommendationRepo, mockProductRepo, nil, nil).SetPinned(context.Background(), "uuid", []string{"p2", "p1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "p2", products[0].UUID)
+	mockRecommendationRepo.AssertExpectations(t)
+}
+
+func TestComputeAffinities(t *testing.T) {
+	mockRecommendationRepo := new(mocks.MockRecommendationRepository)
+
+	mockRecommendationRepo.On("ComputeAffinities", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	err := NewRecommendationUseCase(mockRecommendationRepo, nil, nil, nil).ComputeAffinities(context.Background())
+
+	assert.NoError(t, err)
+
+	salesSince := mockRecommendationRepo.Calls[0].Arguments.Get(1).(time.Time)
+	viewsSince := mockRecommendationRepo.Calls[0].Arguments.Get(2).(time.Time)
+	assert.True(t, viewsSince.After(salesSince))
+}
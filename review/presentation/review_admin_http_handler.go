@@ -0,0 +1,100 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type reviewAdminHandler struct {
+	ReviewUseCase domain.ReviewUseCase
+}
+
+type statusRequest struct {
+	Status domain.ReviewStatus `json:"status"`
+}
+
+func NewReviewAdminHandler(e *echo.Echo, ruc domain.ReviewUseCase, ts domain.TokenService) *reviewAdminHandler {
+	handler := &reviewAdminHandler{
+		ReviewUseCase: ruc,
+	}
+
+	admin := _tokenPresentation.NewAdminMiddleware(ts)
+
+	e.GET("/admin/reviews", handler.Queue, admin)
+	e.PUT("/admin/reviews/:uuid/status", handler.Moderate, admin)
+
+	return handler
+}
+
+func (rah *reviewAdminHandler) Queue(c echo.Context) error {
+	q, message := parseReviewQuery(c)
+
+	if message != "" {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	if status := c.QueryParam("status"); status != "" {
+		q.Status = domain.ReviewStatus(status)
+
+		if !validStatus(q.Status) {
+			return c.JSON(http.StatusBadRequest, "status param must be pending, approved or rejected")
+		}
+	}
+
+	page, err := rah.ReviewUseCase.Queue(c.Request().Context(), *q)
+
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return c.JSON(http.StatusBadRequest, "cursor param is not valid")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to list the moderation queue: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to list the reviews")
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func (rah *reviewAdminHandler) Moderate(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	var req statusRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	if !validStatus(req.Status) {
+		return c.JSON(http.StatusBadRequest, "review's status must be pending, approved or rejected")
+	}
+
+	review, err := rah.ReviewUseCase.Moderate(c.Request().Context(), uuid, req.Status)
+
+	if errors.Is(err, domain.ErrReviewNotFound) {
+		return c.JSON(http.StatusNotFound, "review not found")
+	}
+
+	if errors.Is(err, domain.ErrVersionConflict) {
+		return c.JSON(http.StatusConflict, "review was moderated by someone else, reload it and try again")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to moderate a review: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to moderate the review")
+	}
+
+	return c.JSON(http.StatusOK, review)
+}
+
+func validStatus(s domain.ReviewStatus) bool {
+	return s == domain.ReviewStatusPending || s == domain.ReviewStatusApproved || s == domain.ReviewStatusRejected
+}
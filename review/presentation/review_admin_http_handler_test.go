@@ -0,0 +1,117 @@
+package presentation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestQueue(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin/reviews?status=rejected&cursor=abc", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockReviewUsecase := new(mocks.MockReviewUseCase)
+
+	mockReviewUsecase.On("Queue", mock.Anything, domain.ReviewQuery{Status: domain.ReviewStatusRejected, Cursor: "abc", Limit: 20}).Return(&domain.ReviewPage{Reviews: []domain.Review{}}, nil)
+
+	handler := NewReviewAdminHandler(echo.New(), mockReviewUsecase, nil)
+
+	handler.Queue(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestQueueInvalidCursor(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin/reviews?cursor=abc", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockReviewUsecase := new(mocks.MockReviewUseCase)
+
+	mockReviewUsecase.On("Queue", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidCursor)
+
+	handler := NewReviewAdminHandler(echo.New(), mockReviewUsecase, nil)
+
+	handler.Queue(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestModerateInvalidStatus(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/reviews/:uuid/status", strings.NewReader("{\"status\":\"deleted\"}"))
+	assert.NoError(t, err)
+
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("r3")
+
+	handler := NewReviewAdminHandler(echo.New(), nil, nil)
+
+	handler.Moderate(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestModerateConflict(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/reviews/:uuid/status", strings.NewReader("{\"status\":\"approved\"}"))
+	assert.NoError(t, err)
+
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("r3")
+
+	mockReviewUsecase := new(mocks.MockReviewUseCase)
+
+	mockReviewUsecase.On("Moderate", mock.Anything, "r3", domain.ReviewStatusApproved).Return(nil, domain.ErrVersionConflict)
+
+	handler := NewReviewAdminHandler(echo.New(), mockReviewUsecase, nil)
+
+	handler.Moderate(c)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestModerate(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/reviews/:uuid/status", strings.NewReader("{\"status\":\"approved\"}"))
+	assert.NoError(t, err)
+
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("r3")
+
+	mockReviewUsecase := new(mocks.MockReviewUseCase)
+
+	mockReviewUsecase.On("Moderate", mock.Anything, "r3", domain.ReviewStatusApproved).Return(&domain.Review{UUID: "r3", Status: domain.ReviewStatusApproved}, nil)
+
+	handler := NewReviewAdminHandler(echo.New(), mockReviewUsecase, nil)
+
+	handler.Moderate(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,179 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type reviewHandler struct {
+	ReviewUseCase   domain.ReviewUseCase
+	ReviewValidator domain.ReviewValidator
+}
+
+type reviewRequest struct {
+	Stars int    `json:"stars"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+func NewReviewHandler(e *echo.Echo, ruc domain.ReviewUseCase, rv domain.ReviewValidator, ts domain.TokenService) *reviewHandler {
+	handler := &reviewHandler{
+		ReviewUseCase:   ruc,
+		ReviewValidator: rv,
+	}
+
+	auth := _tokenPresentation.NewAuthMiddleware(ts)
+
+	e.GET("/products/:uuid/reviews", handler.List)
+	e.POST("/products/:uuid/reviews", handler.Create, auth)
+	e.POST("/reviews/:uuid/helpful", handler.Vote, auth)
+
+	return handler
+}
+
+func (rh *reviewHandler) List(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	q, message := parseReviewQuery(c)
+
+	if message != "" {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	if sort := c.QueryParam("sort"); sort != "" {
+		q.Sort = domain.ReviewSort(sort)
+
+		if q.Sort != domain.ReviewSortHelpful && q.Sort != domain.ReviewSortNewest && q.Sort != domain.ReviewSortHighest && q.Sort != domain.ReviewSortLowest {
+			return c.JSON(http.StatusBadRequest, "sort param must be helpful, newest, highest or lowest")
+		}
+	}
+
+	page, err := rh.ReviewUseCase.List(c.Request().Context(), uuid, *q)
+
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return c.JSON(http.StatusBadRequest, "cursor param is not valid")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to list the reviews of a product: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to list the reviews")
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func (rh *reviewHandler) Create(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	var req reviewRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	ctx := c.Request().Context()
+
+	review := domain.Review{Stars: req.Stars, Title: req.Title, Body: req.Body}
+
+	isValid, message := rh.ReviewValidator.Validate(ctx, &review)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	err := rh.ReviewUseCase.Create(ctx, uuid, tokenInfo.Info, &review)
+
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, "user not found")
+	}
+
+	if errors.Is(err, domain.ErrReviewExists) {
+		return c.JSON(http.StatusConflict, "product already reviewed")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to create a review: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to create the review")
+	}
+
+	return c.JSON(http.StatusCreated, review)
+}
+
+func (rh *reviewHandler) Vote(c echo.Context) error {
+	uuid := c.Param("uuid")
+
+	if uuid == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	tokenInfo := _tokenPresentation.TokenInfoFromContext(c)
+
+	if tokenInfo == nil {
+		return c.JSON(http.StatusUnauthorized, "request not authorized")
+	}
+
+	err := rh.ReviewUseCase.Vote(c.Request().Context(), uuid, tokenInfo.Info)
+
+	if errors.Is(err, domain.ErrReviewNotFound) {
+		return c.JSON(http.StatusNotFound, "review not found")
+	}
+
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return c.JSON(http.StatusNotFound, "user not found")
+	}
+
+	if errors.Is(err, domain.ErrOwnReview) {
+		return c.JSON(http.StatusForbidden, "own reviews can not be voted")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to vote for a review: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to vote for the review")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func parseReviewQuery(c echo.Context) (*domain.ReviewQuery, domain.Message) {
+	q := domain.ReviewQuery{Cursor: c.QueryParam("cursor"), Limit: 20}
+
+	if limit := c.QueryParam("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+
+		if err != nil || l < 1 || l > 100 {
+			return nil, "limit param must be a number between 1 and 100"
+		}
+
+		q.Limit = l
+	}
+
+	return &q, ""
+}
@@ -0,0 +1,211 @@
+package presentation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListReviews(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/:uuid/reviews?sort=newest&limit=5", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("p1")
+
+	mockReviewUsecase := new(mocks.MockReviewUseCase)
+
+	mockReviewUsecase.On("List", mock.Anything, "p1", domain.ReviewQuery{Sort: domain.ReviewSortNewest, Limit: 5}).Return(&domain.ReviewPage{Rating: &domain.RatingSummary{}, Reviews: []domain.Review{}}, nil)
+
+	handler := NewReviewHandler(echo.New(), mockReviewUsecase, nil, nil)
+
+	handler.List(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "histogram")
+}
+
+func TestListReviewsInvalidSort(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/:uuid/reviews?sort=oldest", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("p1")
+
+	handler := NewReviewHandler(echo.New(), nil, nil, nil)
+
+	handler.List(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestListReviewsProductNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/:uuid/reviews", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("p1")
+
+	mockReviewUsecase := new(mocks.MockReviewUseCase)
+
+	mockReviewUsecase.On("List", mock.Anything, "p1", domain.ReviewQuery{Limit: 20}).Return(nil, domain.ErrProductNotFound)
+
+	handler := NewReviewHandler(echo.New(), mockReviewUsecase, nil, nil)
+
+	handler.List(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestCreateReviewUnauthorized(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/products/:uuid/reviews", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("p1")
+
+	handler := NewReviewHandler(echo.New(), nil, nil, nil)
+
+	handler.Create(c)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestCreateReviewInvalid(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/products/:uuid/reviews", strings.NewReader("{\"stars\":9,\"title\":\"great\"}"))
+	assert.NoError(t, err)
+
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("p1")
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockReviewValidator := new(mocks.MockReviewValidator)
+
+	mockReviewValidator.On("Validate", mock.Anything, &domain.Review{Stars: 9, Title: "great"}).Return(false, "review's stars must be between 1 and 5")
+
+	handler := NewReviewHandler(echo.New(), nil, mockReviewValidator, nil)
+
+	handler.Create(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestCreateReviewTwice(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/products/:uuid/reviews", strings.NewReader("{\"stars\":5,\"title\":\"great\"}"))
+	assert.NoError(t, err)
+
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("p1")
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockReviewUsecase := new(mocks.MockReviewUseCase)
+	mockReviewValidator := new(mocks.MockReviewValidator)
+
+	mockReviewValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockReviewUsecase.On("Create", mock.Anything, "p1", "user@test.com", &domain.Review{Stars: 5, Title: "great"}).Return(domain.ErrReviewExists)
+
+	handler := NewReviewHandler(echo.New(), mockReviewUsecase, mockReviewValidator, nil)
+
+	handler.Create(c)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestCreateReview(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/products/:uuid/reviews", strings.NewReader("{\"stars\":5,\"title\":\"great\",\"body\":\"fits well\"}"))
+	assert.NoError(t, err)
+
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("p1")
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockReviewUsecase := new(mocks.MockReviewUseCase)
+	mockReviewValidator := new(mocks.MockReviewValidator)
+
+	mockReviewValidator.On("Validate", mock.Anything, mock.Anything).Return(true, "")
+	mockReviewUsecase.On("Create", mock.Anything, "p1", "user@test.com", &domain.Review{Stars: 5, Title: "great", Body: "fits well"}).Return(nil)
+
+	handler := NewReviewHandler(echo.New(), mockReviewUsecase, mockReviewValidator, nil)
+
+	handler.Create(c)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestVoteOwnReview(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/reviews/:uuid/helpful", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("r3")
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockReviewUsecase := new(mocks.MockReviewUseCase)
+
+	mockReviewUsecase.On("Vote", mock.Anything, "r3", "user@test.com").Return(domain.ErrOwnReview)
+
+	handler := NewReviewHandler(echo.New(), mockReviewUsecase, nil, nil)
+
+	handler.Vote(c)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestVoteReview(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.POST, "/reviews/:uuid/helpful", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("r3")
+	c.Set("tokenInfo", &domain.TokenInfo{Info: "user@test.com"})
+
+	mockReviewUsecase := new(mocks.MockReviewUseCase)
+
+	mockReviewUsecase.On("Vote", mock.Anything, "r3", "user@test.com").Return(nil)
+
+	handler := NewReviewHandler(echo.New(), mockReviewUsecase, nil, nil)
+
+	handler.Vote(c)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
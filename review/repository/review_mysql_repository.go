@@ -0,0 +1,339 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/google/uuid"
+)
+
+const reviewColumns = `r.id, r.uuid, r.product_id, p.uuid, r.user_id, u.first_name, r.stars, r.title, r.body, r.verified_purchase, r.status, r.helpful_count, r.created_at FROM review r JOIN product p ON p.id = r.product_id JOIN users u ON u.id = r.user_id`
+
+type reviewSortColumn struct {
+	column string
+	desc   bool
+}
+
+var reviewSortColumns = map[domain.ReviewSort]reviewSortColumn{
+	domain.ReviewSortHelpful: {"r.helpful_count", true},
+	domain.ReviewSortNewest:  {"r.id", true},
+	domain.ReviewSortHighest: {"r.stars", true},
+	domain.ReviewSortLowest:  {"r.stars", false},
+	domain.ReviewSortOldest:  {"r.id", false},
+}
+
+type reviewCursor struct {
+	Sort  string `json:"s"`
+	Value int64  `json:"v"`
+	ID    int64  `json:"id"`
+}
+
+type reviewMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewReviewMysqlRepository(conn *sql.DB) domain.ReviewRepository {
+	return &reviewMysqlRepository{Conn: conn}
+}
+
+func (rmr *reviewMysqlRepository) List(ctx context.Context, q domain.ReviewQuery) (*domain.ReviewPage, error) {
+	sort, ok := reviewSortColumns[q.Sort]
+
+	if !ok {
+		return nil, fmt.Errorf("review sort %s is not supported", q.Sort)
+	}
+
+	if q.Limit <= 0 {
+		return nil, fmt.Errorf("review limit must be positive, got %d", q.Limit)
+	}
+
+	where := []string{}
+	args := []interface{}{}
+
+	if q.ProductID != 0 {
+		where = append(where, "r.product_id = ?")
+		args = append(args, q.ProductID)
+	}
+
+	if q.Status != "" {
+		where = append(where, "r.status = ?")
+		args = append(args, q.Status)
+	}
+
+	operator, direction := ">", "ASC"
+	if sort.desc {
+		operator, direction = "<", "DESC"
+	}
+
+	if q.Cursor != "" {
+		cursor, err := decodeReviewCursor(q.Cursor)
+
+		if err != nil || cursor.Sort != string(q.Sort) {
+			return nil, domain.ErrInvalidCursor
+		}
+
+		where = append(where, fmt.Sprintf("(%s %s ? OR (%s = ? AND r.id %s ?))", sort.column, operator, sort.column, operator))
+		args = append(args, cursor.Value, cursor.Value, cursor.ID)
+	}
+
+	whereClause := ""
+	if len(where) > 0 {
+		whereClause = " WHERE " + strings.Join(where, " AND ")
+	}
+
+	query := fmt.Sprintf(`SELECT %s%s ORDER BY %s %s, r.id %s LIMIT ?;`, reviewColumns, whereClause, sort.column, direction, direction)
+	args = append(args, q.Limit+1)
+
+	rows, err := rmr.Conn.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := &domain.ReviewPage{Reviews: []domain.Review{}}
+
+	for rows.Next() {
+		r, err := scanReview(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res.Reviews = append(res.Reviews, *r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(res.Reviews) > q.Limit {
+		res.Reviews = res.Reviews[:q.Limit]
+		last := res.Reviews[q.Limit-1]
+		res.NextCursor = encodeReviewCursor(reviewCursor{Sort: string(q.Sort), Value: sortValue(last, sort.column), ID: last.ID})
+	}
+
+	return res, nil
+}
+
+func sortValue(r domain.Review, column string) int64 {
+	switch column {
+	case "r.helpful_count":
+		return r.HelpfulCount
+	case "r.stars":
+		return int64(r.Stars)
+	default:
+		return r.ID
+	}
+}
+
+func (rmr *reviewMysqlRepository) GetByUUID(ctx context.Context, uuid string) (*domain.Review, error) {
+	return rmr.getOne(ctx, `SELECT `+reviewColumns+` WHERE r.uuid = ?;`, uuid)
+}
+
+func (rmr *reviewMysqlRepository) GetByProductAndUser(ctx context.Context, productID int64, userID int64) (*domain.Review, error) {
+	return rmr.getOne(ctx, `SELECT `+reviewColumns+` WHERE r.product_id = ? AND r.user_id = ?;`, productID, userID)
+}
+
+func (rmr *reviewMysqlRepository) getOne(ctx context.Context, query string, args ...interface{}) (*domain.Review, error) {
+	r, err := scanReview(rmr.Conn.QueryRowContext(ctx, query, args...))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return r, err
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReview(row scanner) (*domain.Review, error) {
+	var r domain.Review
+	var createdAt string
+
+	if err := row.Scan(&r.ID, &r.UUID, &r.ProductID, &r.ProductUUID, &r.UserID, &r.Author, &r.Stars, &r.Title, &r.Body, &r.VerifiedPurchase, &r.Status, &r.HelpfulCount, &createdAt); err != nil {
+		return nil, err
+	}
+
+	t, err := time.Parse(datetimeLayout, createdAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.CreatedAt = t
+
+	return &r, nil
+}
+
+func (rmr *reviewMysqlRepository) Store(ctx context.Context, r *domain.Review) error {
+	r.UUID = uuid.NewString()
+	r.CreatedAt = time.Now().UTC().Truncate(time.Second)
+
+	query := `INSERT INTO review (uuid, product_id, user_id, stars, title, body, verified_purchase, status, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+	exec, err := rmr.Conn.ExecContext(ctx, query, r.UUID, r.ProductID, r.UserID, r.Stars, r.Title, r.Body, r.VerifiedPurchase, r.Status, r.CreatedAt)
+
+	if err != nil {
+		return err
+	}
+
+	r.ID, err = exec.LastInsertId()
+
+	return err
+}
+
+// SetStatus moves the review from its current status, failing with a
+// version conflict when someone else moderated it in the meantime, and
+// updates the rating of the product when it enters or leaves the approved
+// status.
+func (rmr *reviewMysqlRepository) SetStatus(ctx context.Context, r *domain.Review, status domain.ReviewStatus) error {
+	if r.Stars < 1 || r.Stars > 5 {
+		return fmt.Errorf("review stars must be between 1 and 5, got %d", r.Stars)
+	}
+
+	tx, err := rmr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := tx.ExecContext(ctx, `UPDATE review SET status = ? WHERE id = ? AND status = ?;`, status, r.ID, r.Status)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if affect != 1 {
+		tx.Rollback()
+		return domain.ErrVersionConflict
+	}
+
+	delta := 0
+
+	if r.Status == domain.ReviewStatusApproved {
+		delta--
+	}
+
+	if status == domain.ReviewStatusApproved {
+		delta++
+	}
+
+	if delta != 0 {
+		query := fmt.Sprintf(`INSERT INTO product_rating (product_id, rating_count, rating_sum, stars_%d) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE rating_count = rating_count + VALUES(rating_count), rating_sum = rating_sum + VALUES(rating_sum), stars_%d = stars_%d + VALUES(stars_%d);`, r.Stars, r.Stars, r.Stars, r.Stars)
+
+		if _, err := tx.ExecContext(ctx, query, r.ProductID, delta, delta*r.Stars, delta); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE product SET rate = (SELECT IF(rating_count = 0, 0, rating_sum / rating_count) FROM product_rating WHERE product_id = ?) WHERE id = ?;`, r.ProductID, r.ProductID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	r.Status = status
+
+	return nil
+}
+
+func (rmr *reviewMysqlRepository) GetRating(ctx context.Context, productID int64) (*domain.RatingSummary, error) {
+	row := rmr.Conn.QueryRowContext(ctx, `SELECT rating_count, rating_sum, stars_1, stars_2, stars_3, stars_4, stars_5 FROM product_rating WHERE product_id = ?;`, productID)
+
+	var res domain.RatingSummary
+	var sum int64
+
+	if err := row.Scan(&res.Count, &sum, &res.Histogram[0], &res.Histogram[1], &res.Histogram[2], &res.Histogram[3], &res.Histogram[4]); err != nil {
+		if err == sql.ErrNoRows {
+			return &res, nil
+		}
+
+		return nil, err
+	}
+
+	if res.Count > 0 {
+		res.Average = math.Round(float64(sum)/float64(res.Count)*100) / 100
+	}
+
+	return &res, nil
+}
+
+// Vote counts one helpful vote of the user for the review, voting again
+// changes nothing.
+func (rmr *reviewMysqlRepository) Vote(ctx context.Context, reviewID int64, userID int64) error {
+	tx, err := rmr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	exec, err := tx.ExecContext(ctx, `INSERT IGNORE INTO review_vote (review_id, user_id) VALUES (?, ?);`, reviewID, userID)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	affect, err := exec.RowsAffected()
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if affect == 1 {
+		if _, err := tx.ExecContext(ctx, `UPDATE review SET helpful_count = helpful_count + 1 WHERE id = ?;`, reviewID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func encodeReviewCursor(c reviewCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeReviewCursor(s string) (*reviewCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var c reviewCursor
+
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// datetimeLayout is how the connection gives the DATETIME columns, in UTC.
+const datetimeLayout = "2006-01-02 15:04:05"
@@ -0,0 +1,324 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var reviewRowColumns = []string{"r.id", "r.uuid", "r.product_id", "p.uuid", "r.user_id", "u.first_name", "r.stars", "r.title", "r.body", "r.verified_purchase", "r.status", "r.helpful_count", "r.created_at"}
+
+const reviewSelect = "SELECT r.id, r.uuid, r.product_id, p.uuid, r.user_id, u.first_name, r.stars, r.title, r.body, r.verified_purchase, r.status, r.helpful_count, r.created_at FROM review r JOIN product p ON p.id = r.product_id JOIN users u ON u.id = r.user_id"
+
+func TestListReviews(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows(reviewRowColumns).
+		AddRow(3, "r3", 1, "p1", 7, "Ana", 5, "great", "", true, "approved", 9, "2026-01-02 10:00:00").
+		AddRow(2, "r2", 1, "p1", 8, "Bia", 4, "good", "", false, "approved", 9, "2026-01-01 10:00:00").
+		AddRow(1, "r1", 1, "p1", 9, "Caio", 2, "bad", "", false, "approved", 1, "2026-01-01 09:00:00")
+
+	mock.ExpectQuery(regexp.QuoteMeta(reviewSelect+" WHERE r.product_id = ? AND r.status = ? ORDER BY r.helpful_count DESC, r.id DESC LIMIT ?;")).
+		WithArgs(1, domain.ReviewStatusApproved, 3).WillReturnRows(rows)
+
+	page, err := NewReviewMysqlRepository(db).List(context.Background(), domain.ReviewQuery{ProductID: 1, Status: domain.ReviewStatusApproved, Sort: domain.ReviewSortHelpful, Limit: 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Reviews, 2)
+	assert.Equal(t, "Ana", page.Reviews[0].Author)
+	assert.Equal(t, time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC), page.Reviews[0].CreatedAt)
+	assert.Equal(t, encodeReviewCursor(reviewCursor{Sort: "helpful", Value: 9, ID: 2}), page.NextCursor)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestListReviewsWithCursor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	cursor := encodeReviewCursor(reviewCursor{Sort: "oldest", Value: 4, ID: 4})
+
+	mock.ExpectQuery(regexp.QuoteMeta(reviewSelect+" WHERE r.status = ? AND (r.id > ? OR (r.id = ? AND r.id > ?)) ORDER BY r.id ASC, r.id ASC LIMIT ?;")).
+		WithArgs(domain.ReviewStatusPending, 4, 4, 4, 11).WillReturnRows(sqlmock.NewRows(reviewRowColumns))
+
+	page, err := NewReviewMysqlRepository(db).List(context.Background(), domain.ReviewQuery{Status: domain.ReviewStatusPending, Sort: domain.ReviewSortOldest, Cursor: cursor, Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Empty(t, page.Reviews)
+	assert.Empty(t, page.NextCursor)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestListReviewsCursorOfAnotherSort(t *testing.T) {
+	db, _, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	cursor := encodeReviewCursor(reviewCursor{Sort: "helpful", Value: 4, ID: 4})
+
+	_, err = NewReviewMysqlRepository(db).List(context.Background(), domain.ReviewQuery{Sort: domain.ReviewSortNewest, Cursor: cursor, Limit: 10})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
+
+func TestGetReviewByUUID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows(reviewRowColumns).AddRow(3, "r3", 1, "p1", 7, "Ana", 5, "great", "body", true, "pending", 0, "2026-01-02 10:00:00")
+
+	mock.ExpectQuery(regexp.QuoteMeta(reviewSelect + " WHERE r.uuid = ?;")).WithArgs("r3").WillReturnRows(rows)
+
+	r, err := NewReviewMysqlRepository(db).GetByUUID(context.Background(), "r3")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Review{ID: 3, UUID: "r3", ProductID: 1, ProductUUID: "p1", UserID: 7, Author: "Ana", Stars: 5, Title: "great", Body: "body", VerifiedPurchase: true, Status: domain.ReviewStatusPending, CreatedAt: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)}, r)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetReviewByProductAndUserNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(reviewSelect+" WHERE r.product_id = ? AND r.user_id = ?;")).WithArgs(1, 7).WillReturnRows(sqlmock.NewRows(reviewRowColumns))
+
+	r, err := NewReviewMysqlRepository(db).GetByProductAndUser(context.Background(), 1, 7)
+
+	assert.NoError(t, err)
+	assert.Nil(t, r)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreReview(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	r := &domain.Review{ProductID: 1, UserID: 7, Stars: 5, Title: "great", Status: domain.ReviewStatusPending}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO review (uuid, product_id, user_id, stars, title, body, verified_purchase, status, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+		WithArgs(sqlmock.AnyArg(), 1, 7, 5, "great", "", false, domain.ReviewStatusPending, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(3, 1))
+
+	err = NewReviewMysqlRepository(db).Store(context.Background(), r)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), r.ID)
+	assert.NotEmpty(t, r.UUID)
+	assert.False(t, r.CreatedAt.IsZero())
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetStatusApproves(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	r := &domain.Review{ID: 3, ProductID: 1, Stars: 4, Status: domain.ReviewStatusPending}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE review SET status = ? WHERE id = ? AND status = ?;")).WithArgs(domain.ReviewStatusApproved, 3, domain.ReviewStatusPending).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_rating (product_id, rating_count, rating_sum, stars_4) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE rating_count = rating_count + VALUES(rating_count), rating_sum = rating_sum + VALUES(rating_sum), stars_4 = stars_4 + VALUES(stars_4);")).
+		WithArgs(1, 1, 4, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE product SET rate = (SELECT IF(rating_count = 0, 0, rating_sum / rating_count) FROM product_rating WHERE product_id = ?) WHERE id = ?;")).WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = NewReviewMysqlRepository(db).SetStatus(context.Background(), r, domain.ReviewStatusApproved)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ReviewStatusApproved, r.Status)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetStatusRejectsApproved(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	r := &domain.Review{ID: 3, ProductID: 1, Stars: 2, Status: domain.ReviewStatusApproved}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE review SET status = ? WHERE id = ? AND status = ?;")).WithArgs(domain.ReviewStatusRejected, 3, domain.ReviewStatusApproved).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_rating (product_id, rating_count, rating_sum, stars_2) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE rating_count = rating_count + VALUES(rating_count), rating_sum = rating_sum + VALUES(rating_sum), stars_2 = stars_2 + VALUES(stars_2);")).
+		WithArgs(1, -1, -2, -1).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE product SET rate = (SELECT IF(rating_count = 0, 0, rating_sum / rating_count) FROM product_rating WHERE product_id = ?) WHERE id = ?;")).WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = NewReviewMysqlRepository(db).SetStatus(context.Background(), r, domain.ReviewStatusRejected)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ReviewStatusRejected, r.Status)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetStatusWithoutRatingChange(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	r := &domain.Review{ID: 3, ProductID: 1, Stars: 2, Status: domain.ReviewStatusPending}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE review SET status = ? WHERE id = ? AND status = ?;")).WithArgs(domain.ReviewStatusRejected, 3, domain.ReviewStatusPending).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = NewReviewMysqlRepository(db).SetStatus(context.Background(), r, domain.ReviewStatusRejected)
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetStatusConflict(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	r := &domain.Review{ID: 3, ProductID: 1, Stars: 2, Status: domain.ReviewStatusPending}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE review SET status = ? WHERE id = ? AND status = ?;")).WithArgs(domain.ReviewStatusApproved, 3, domain.ReviewStatusPending).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err = NewReviewMysqlRepository(db).SetStatus(context.Background(), r, domain.ReviewStatusApproved)
+
+	assert.ErrorIs(t, err, domain.ErrVersionConflict)
+	assert.Equal(t, domain.ReviewStatusPending, r.Status)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetRating(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"rating_count", "rating_sum", "stars_1", "stars_2", "stars_3", "stars_4", "stars_5"}).AddRow(3, 11, 0, 0, 1, 1, 1)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT rating_count, rating_sum, stars_1, stars_2, stars_3, stars_4, stars_5 FROM product_rating WHERE product_id = ?;")).WithArgs(1).WillReturnRows(rows)
+
+	rating, err := NewReviewMysqlRepository(db).GetRating(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.RatingSummary{Average: 3.67, Count: 3, Histogram: [5]int64{0, 0, 1, 1, 1}}, rating)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetRatingWithoutReviews(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT rating_count, rating_sum, stars_1, stars_2, stars_3, stars_4, stars_5 FROM product_rating WHERE product_id = ?;")).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"rating_count", "rating_sum", "stars_1", "stars_2", "stars_3", "stars_4", "stars_5"}))
+
+	rating, err := NewReviewMysqlRepository(db).GetRating(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.RatingSummary{}, rating)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVoteReview(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO review_vote (review_id, user_id) VALUES (?, ?);")).WithArgs(3, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE review SET helpful_count = helpful_count + 1 WHERE id = ?;")).WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = NewReviewMysqlRepository(db).Vote(context.Background(), 3, 7)
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVoteReviewAgain(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO review_vote (review_id, user_id) VALUES (?, ?);")).WithArgs(3, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err = NewReviewMysqlRepository(db).Vote(context.Background(), 3, 7)
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
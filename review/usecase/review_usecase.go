@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type reviewUseCase struct {
+	reviewRepo  domain.ReviewRepository
+	productRepo domain.ProductRepository
+	userRepo    domain.UserRepository
+}
+
+func NewReviewUseCase(rr domain.ReviewRepository, pr domain.ProductRepository, ur domain.UserRepository) domain.ReviewUseCase {
+	return &reviewUseCase{reviewRepo: rr, productRepo: pr, userRepo: ur}
+}
+
+// List gives the approved reviews of a published product together with its
+// rating, the most helpful first unless another sort is asked.
+func (ru *reviewUseCase) List(ctx context.Context, productUUID string, q domain.ReviewQuery) (*domain.ReviewPage, error) {
+	product, err := ru.publishedProduct(ctx, productUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q.ProductID = product.ID
+	q.Status = domain.ReviewStatusApproved
+
+	if q.Sort == "" {
+		q.Sort = domain.ReviewSortHelpful
+	}
+
+	page, err := ru.reviewRepo.List(ctx, q)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if page.Rating, err = ru.reviewRepo.GetRating(ctx, product.ID); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// Create stores the review as pending moderation, each customer reviews a
+// product only once.
+func (ru *reviewUseCase) Create(ctx context.Context, productUUID string, login string, r *domain.Review) error {
+	product, err := ru.publishedProduct(ctx, productUUID)
+
+	if err != nil {
+		return err
+	}
+
+	user, err := ru.user(ctx, login)
+
+	if err != nil {
+		return err
+	}
+
+	existing, err := ru.reviewRepo.GetByProductAndUser(ctx, product.ID, user.ID)
+
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		return domain.ErrReviewExists
+	}
+
+	r.ProductID = product.ID
+	r.ProductUUID = product.UUID
+	r.UserID = user.ID
+	r.Author = user.FirstName
+	r.VerifiedPurchase = false
+	r.Status = domain.ReviewStatusPending
+	r.HelpfulCount = 0
+
+	return ru.reviewRepo.Store(ctx, r)
+}
+
+// Vote marks an approved review as helpful, customers can not vote for their
+// own reviews and voting twice counts once.
+func (ru *reviewUseCase) Vote(ctx context.Context, reviewUUID string, login string) error {
+	review, err := ru.reviewRepo.GetByUUID(ctx, reviewUUID)
+
+	if err != nil {
+		return err
+	}
+
+	if review == nil || review.Status != domain.ReviewStatusApproved {
+		return domain.ErrReviewNotFound
+	}
+
+	user, err := ru.user(ctx, login)
+
+	if err != nil {
+		return err
+	}
+
+	if user.ID == review.UserID {
+		return domain.ErrOwnReview
+	}
+
+	return ru.reviewRepo.Vote(ctx, review.ID, user.ID)
+}
+
+// Queue gives the reviews of all the products waiting for moderation, or in
+// the asked status, the oldest first.
+func (ru *reviewUseCase) Queue(ctx context.Context, q domain.ReviewQuery) (*domain.ReviewPage, error) {
+	q.ProductID = 0
+	q.Sort = domain.ReviewSortOldest
+
+	if q.Status == "" {
+		q.Status = domain.ReviewStatusPending
+	}
+
+	return ru.reviewRepo.List(ctx, q)
+}
+
+func (ru *reviewUseCase) Moderate(ctx context.Context, reviewUUID string, status domain.ReviewStatus) (*domain.Review, error) {
+	review, err := ru.reviewRepo.GetByUUID(ctx, reviewUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if review == nil {
+		return nil, domain.ErrReviewNotFound
+	}
+
+	if review.Status == status {
+		return review, nil
+	}
+
+	if err := ru.reviewRepo.SetStatus(ctx, review, status); err != nil {
+		return nil, err
+	}
+
+	// the rating of the product may have changed
+	if err := ru.productRepo.Touch(ctx, []int64{review.ProductID}); err != nil {
+		log.Printf("Error trying to touch the product of the review %s: %s", review.UUID, err.Error())
+	}
+
+	return review, nil
+}
+
+func (ru *reviewUseCase) publishedProduct(ctx context.Context, uuid string) (*domain.Product, error) {
+	product, err := ru.productRepo.GetByUUID(ctx, uuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil || product.Status != domain.ProductStatusPublished {
+		return nil, domain.ErrProductNotFound
+	}
+
+	return product, nil
+}
+
+func (ru *reviewUseCase) user(ctx context.Context, login string) (*domain.User, error) {
+	user, err := ru.userRepo.GetByEmail(ctx, login)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	return user, nil
+}
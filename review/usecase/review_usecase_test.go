@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var publishedProduct = &domain.Product{ID: 1, UUID: "p1", Status: domain.ProductStatusPublished}
+
+func TestListReviews(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	rating := &domain.RatingSummary{Average: 4.5, Count: 2, Histogram: [5]int64{0, 0, 0, 1, 1}}
+	page := &domain.ReviewPage{Reviews: []domain.Review{{UUID: "r1"}}}
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "p1").Return(publishedProduct, nil)
+	mockReviewRepo.On("List", mock.Anything, domain.ReviewQuery{ProductID: 1, Status: domain.ReviewStatusApproved, Sort: domain.ReviewSortHelpful, Limit: 10}).Return(page, nil)
+	mockReviewRepo.On("GetRating", mock.Anything, int64(1)).Return(rating, nil)
+
+	res, err := NewReviewUseCase(mockReviewRepo, mockProductRepo, nil).List(context.Background(), "p1", domain.ReviewQuery{Status: domain.ReviewStatusPending, Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.ReviewPage{Rating: rating, Reviews: []domain.Review{{UUID: "r1"}}}, res)
+}
+
+func TestListReviewsOfDraftProduct(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "p1").Return(&domain.Product{ID: 1, UUID: "p1", Status: domain.ProductStatusDraft}, nil)
+
+	_, err := NewReviewUseCase(nil, mockProductRepo, nil).List(context.Background(), "p1", domain.ReviewQuery{Limit: 10})
+
+	assert.ErrorIs(t, err, domain.ErrProductNotFound)
+}
+
+func TestCreateReview(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "p1").Return(publishedProduct, nil)
+	mockUserRepo.On("GetByEmail", mock.Anything, "ana@test.com").Return(7, "u7", "ana@test.com", "Ana", "Lima", "", "", "", "", "", "", "", nil)
+	mockReviewRepo.On("GetByProductAndUser", mock.Anything, int64(1), int64(7)).Return(nil, nil)
+	mockReviewRepo.On("Store", mock.Anything, mock.Anything).Return(nil)
+
+	r := &domain.Review{Stars: 5, Title: "great", VerifiedPurchase: true, Status: domain.ReviewStatusApproved, HelpfulCount: 100}
+
+	err := NewReviewUseCase(mockReviewRepo, mockProductRepo, mockUserRepo).Create(context.Background(), "p1", "ana@test.com", r)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Review{ProductID: 1, ProductUUID: "p1", UserID: 7, Author: "Ana", Stars: 5, Title: "great", Status: domain.ReviewStatusPending}, r)
+}
+
+func TestCreateReviewTwice(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "p1").Return(publishedProduct, nil)
+	mockUserRepo.On("GetByEmail", mock.Anything, "ana@test.com").Return(7, "u7", "ana@test.com", "Ana", "Lima", "", "", "", "", "", "", "", nil)
+	mockReviewRepo.On("GetByProductAndUser", mock.Anything, int64(1), int64(7)).Return(&domain.Review{ID: 3}, nil)
+
+	err := NewReviewUseCase(mockReviewRepo, mockProductRepo, mockUserRepo).Create(context.Background(), "p1", "ana@test.com", &domain.Review{Stars: 5})
+
+	assert.ErrorIs(t, err, domain.ErrReviewExists)
+	mockReviewRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+}
+
+func TestVoteReview(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	mockReviewRepo.On("GetByUUID", mock.Anything, "r3").Return(&domain.Review{ID: 3, UserID: 8, Status: domain.ReviewStatusApproved}, nil)
+	mockUserRepo.On("GetByEmail", mock.Anything, "ana@test.com").Return(7, "u7", "ana@test.com", "Ana", "Lima", "", "", "", "", "", "", "", nil)
+	mockReviewRepo.On("Vote", mock.Anything, int64(3), int64(7)).Return(nil)
+
+	err := NewReviewUseCase(mockReviewRepo, nil, mockUserRepo).Vote(context.Background(), "r3", "ana@test.com")
+
+	assert.NoError(t, err)
+	mockReviewRepo.AssertExpectations(t)
+}
+
+func TestVoteOwnReview(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepository)
+	mockUserRepo := new(mocks.MockUserRepository)
+
+	mockReviewRepo.On("GetByUUID", mock.Anything, "r3").Return(&domain.Review{ID: 3, UserID: 7, Status: domain.ReviewStatusApproved}, nil)
+	mockUserRepo.On("GetByEmail", mock.Anything, "ana@test.com").Return(7, "u7", "ana@test.com", "Ana", "Lima", "", "", "", "", "", "", "", nil)
+
+	err := NewReviewUseCase(mockReviewRepo, nil, mockUserRepo).Vote(context.Background(), "r3", "ana@test.com")
+
+	assert.ErrorIs(t, err, domain.ErrOwnReview)
+	mockReviewRepo.AssertNotCalled(t, "Vote", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVotePendingReview(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepository)
+
+	mockReviewRepo.On("GetByUUID", mock.Anything, "r3").Return(&domain.Review{ID: 3, UserID: 8, Status: domain.ReviewStatusPending}, nil)
+
+	err := NewReviewUseCase(mockReviewRepo, nil, nil).Vote(context.Background(), "r3", "ana@test.com")
+
+	assert.ErrorIs(t, err, domain.ErrReviewNotFound)
+}
+
+func TestQueue(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepository)
+
+	page := &domain.ReviewPage{Reviews: []domain.Review{}}
+
+	mockReviewRepo.On("List", mock.Anything, domain.ReviewQuery{Status: domain.ReviewStatusPending, Sort: domain.ReviewSortOldest, Limit: 20}).Return(page, nil)
+
+	res, err := NewReviewUseCase(mockReviewRepo, nil, nil).Queue(context.Background(), domain.ReviewQuery{ProductID: 4, Sort: domain.ReviewSortHelpful, Limit: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, page, res)
+}
+
+func TestModerate(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	review := &domain.Review{ID: 3, ProductID: 4, Stars: 4, Status: domain.ReviewStatusPending}
+
+	mockReviewRepo.On("GetByUUID", mock.Anything, "r3").Return(review, nil)
+	mockReviewRepo.On("SetStatus", mock.Anything, review, domain.ReviewStatusApproved).Return(nil)
+	mockProductRepo.On("Touch", mock.Anything, []int64{4}).Return(nil)
+
+	res, err := NewReviewUseCase(mockReviewRepo, mockProductRepo, nil).Moderate(context.Background(), "r3", domain.ReviewStatusApproved)
+
+	assert.NoError(t, err)
+	assert.Equal(t, review, res)
+	mockReviewRepo.AssertExpectations(t)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestModerateToSameStatus(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepository)
+
+	mockReviewRepo.On("GetByUUID", mock.Anything, "r3").Return(&domain.Review{ID: 3, Stars: 4, Status: domain.ReviewStatusApproved}, nil)
+
+	_, err := NewReviewUseCase(mockReviewRepo, nil, nil).Moderate(context.Background(), "r3", domain.ReviewStatusApproved)
+
+	assert.NoError(t, err)
+	mockReviewRepo.AssertNotCalled(t, "SetStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestModerateNotFound(t *testing.T) {
+	mockReviewRepo := new(mocks.MockReviewRepository)
+
+	mockReviewRepo.On("GetByUUID", mock.Anything, "r3").Return(nil, nil)
+
+	_, err := NewReviewUseCase(mockReviewRepo, nil, nil).Moderate(context.Background(), "r3", domain.ReviewStatusApproved)
+
+	assert.ErrorIs(t, err, domain.ErrReviewNotFound)
+}
@@ -0,0 +1,35 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type reviewValidator struct{}
+
+func NewReviewValidator() *reviewValidator {
+	return &reviewValidator{}
+}
+
+func (rv *reviewValidator) Validate(ctx context.Context, r *domain.Review) (domain.IsValid, domain.Message) {
+	if r.Stars < 1 || r.Stars > 5 {
+		return false, "review's stars must be between 1 and 5"
+	}
+
+	if strings.TrimSpace(r.Title) == "" {
+		return false, "review's title can not be empty"
+	}
+
+	if utf8.RuneCountInString(r.Title) > 150 {
+		return false, "review's title can not have more than 150 characters"
+	}
+
+	if utf8.RuneCountInString(r.Body) > 5000 {
+		return false, "review's body can not have more than 5000 characters"
+	}
+
+	return true, ""
+}
@@ -0,0 +1,42 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateReviewStars(t *testing.T) {
+	for _, stars := range []int{0, 6} {
+		isValid, message := NewReviewValidator().Validate(context.Background(), &domain.Review{Stars: stars, Title: "great"})
+
+		assert.False(t, bool(isValid))
+		assert.NotEmpty(t, message)
+	}
+}
+
+func TestValidateReviewTitle(t *testing.T) {
+	for _, title := range []string{" ", strings.Repeat("a", 151)} {
+		isValid, message := NewReviewValidator().Validate(context.Background(), &domain.Review{Stars: 5, Title: title})
+
+		assert.False(t, bool(isValid))
+		assert.NotEmpty(t, message)
+	}
+}
+
+func TestValidateReviewBody(t *testing.T) {
+	isValid, message := NewReviewValidator().Validate(context.Background(), &domain.Review{Stars: 5, Title: "great", Body: strings.Repeat("é", 5001)})
+
+	assert.False(t, bool(isValid))
+	assert.NotEmpty(t, message)
+}
+
+func TestValidateReview(t *testing.T) {
+	isValid, message := NewReviewValidator().Validate(context.Background(), &domain.Review{Stars: 5, Title: "great", Body: strings.Repeat("é", 5000)})
+
+	assert.True(t, bool(isValid))
+	assert.Empty(t, message)
+}
@@ -0,0 +1,54 @@
+package presentation
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/labstack/echo/v4"
+)
+
+type searchHandler struct {
+	SearchUseCase domain.SearchUseCase
+}
+
+func NewSearchHandler(e *echo.Echo, suc domain.SearchUseCase) *searchHandler {
+	handler := &searchHandler{
+		SearchUseCase: suc,
+	}
+
+	e.GET("/products/suggest", handler.Suggest)
+
+	return handler
+}
+
+func (sh *searchHandler) Suggest(c echo.Context) error {
+	q := strings.TrimSpace(c.QueryParam("q"))
+
+	if len(q) < 2 || len(q) > 100 {
+		return c.JSON(http.StatusBadRequest, "q param must have between 2 and 100 characters")
+	}
+
+	limit := 8
+
+	if l := c.QueryParam("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+
+		if err != nil || parsed < 1 || parsed > 20 {
+			return c.JSON(http.StatusBadRequest, "limit param must be a number between 1 and 20")
+		}
+
+		limit = parsed
+	}
+
+	suggestions, err := sh.SearchUseCase.Suggest(c.Request().Context(), q, limit)
+
+	if err != nil {
+		log.Printf("Error trying to get search suggestions: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the suggestions")
+	}
+
+	return c.JSON(http.StatusOK, map[string][]domain.Suggestion{"suggestions": suggestions})
+}
@@ -0,0 +1,69 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSuggestShortQuery(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/suggest?q=c", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := NewSearchHandler(echo.New(), nil)
+
+	handler.Suggest(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSuggestError(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/suggest?q=cam", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockSearchUsecase := new(mocks.MockSearchUseCase)
+
+	mockSearchUsecase.On("Suggest", mock.Anything, "cam", 8).Return(nil, errors.New("error message"))
+
+	handler := NewSearchHandler(echo.New(), mockSearchUsecase)
+
+	handler.Suggest(c)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestSuggestSuccess(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products/suggest?q=cam&limit=2", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockSearchUsecase := new(mocks.MockSearchUseCase)
+
+	mockSearchUsecase.On("Suggest", mock.Anything, "cam", 2).Return([]domain.Suggestion{{Type: domain.SuggestionTypeCategory, Text: "Camisetas", Key: "camisetas"}}, nil)
+
+	handler := NewSearchHandler(echo.New(), mockSearchUsecase)
+
+	handler.Suggest(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"suggestions\":[{\"type\":\"category\",\"text\":\"Camisetas\",\"key\":\"camisetas\"}]}\n", rec.Body.String())
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type searchMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewSearchMysqlRepository(conn *sql.DB) domain.SearchRepository {
+	return &searchMysqlRepository{Conn: conn}
+}
+
+func (smr *searchMysqlRepository) StoreQuery(ctx context.Context, q string) error {
+	query := `INSERT INTO search_query (query, hits) VALUES (?, 1) ON DUPLICATE KEY UPDATE hits = hits + 1;`
+
+	_, err := smr.Conn.ExecContext(ctx, query, normalizeQuery(q))
+
+	return err
+}
+
+func (smr *searchMysqlRepository) GetQuerySuggestions(ctx context.Context, prefix string, limit int) ([]domain.Suggestion, error) {
+	query := `SELECT query FROM search_query WHERE query LIKE ? ORDER BY hits DESC, query LIMIT ?;`
+
+	rows, err := smr.Conn.QueryContext(ctx, query, likePrefix(normalizeQuery(prefix)), limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []domain.Suggestion{}
+
+	for rows.Next() {
+		var text string
+
+		if err := rows.Scan(&text); err != nil {
+			return nil, err
+		}
+
+		res = append(res, domain.Suggestion{Type: domain.SuggestionTypeQuery, Text: text, Key: text})
+	}
+
+	return res, rows.Err()
+}
+
+func (smr *searchMysqlRepository) GetCategorySuggestions(ctx context.Context, prefix string, limit int) ([]domain.Suggestion, error) {
+	query := `SELECT slug, name FROM category WHERE name LIKE ? OR name LIKE ? ORDER BY name LIMIT ?;`
+
+	prefix = likePrefix(normalizeQuery(prefix))
+
+	rows, err := smr.Conn.QueryContext(ctx, query, prefix, "% "+prefix, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	res := []domain.Suggestion{}
+
+	for rows.Next() {
+		var s domain.Suggestion
+
+		if err := rows.Scan(&s.Key, &s.Text); err != nil {
+			return nil, err
+		}
+
+		s.Type = domain.SuggestionTypeCategory
+		res = append(res, s)
+	}
+
+	return res, rows.Err()
+}
+
+// normalizeQuery makes "Camiseta  Preta" and "camiseta preta" count as the
+// same popular query.
+func normalizeQuery(q string) string {
+	return strings.ToLower(strings.Join(strings.Fields(q), " "))
+}
+
+func likePrefix(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s) + "%"
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreQuery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("INSERT INTO search_query (query, hits) VALUES (?, 1) ON DUPLICATE KEY UPDATE hits = hits + 1;")
+
+	mock.ExpectExec(query).WithArgs("camiseta preta").WillReturnResult(sqlmock.NewResult(1, 1))
+
+	searchMysqlRepository := NewSearchMysqlRepository(db)
+
+	err = searchMysqlRepository.StoreQuery(context.Background(), "  Camiseta   PRETA ")
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetQuerySuggestionsError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := regexp.QuoteMeta("SELECT query FROM search_query WHERE query LIKE ? ORDER BY hits DESC, query LIMIT ?;")
+
+	mock.ExpectQuery(query).WillReturnError(errors.New("error message"))
+
+	searchMysqlRepository := NewSearchMysqlRepository(db)
+
+	_, err = searchMysqlRepository.GetQuerySuggestions(context.Background(), "cam", 3)
+
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetQuerySuggestions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"query"}).AddRow("camiseta").AddRow("camiseta_preta")
+
+	query := regexp.QuoteMeta("SELECT query FROM search_query WHERE query LIKE ? ORDER BY hits DESC, query LIMIT ?;")
+
+	mock.ExpectQuery(query).WithArgs(`cam\_%`, 3).WillReturnRows(rows)
+
+	searchMysqlRepository := NewSearchMysqlRepository(db)
+
+	suggestions, err := searchMysqlRepository.GetQuerySuggestions(context.Background(), "Cam_", 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Suggestion{
+		{Type: domain.SuggestionTypeQuery, Text: "camiseta", Key: "camiseta"},
+		{Type: domain.SuggestionTypeQuery, Text: "camiseta_preta", Key: "camiseta_preta"},
+	}, suggestions)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetCategorySuggestions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"slug", "name"}).AddRow("camisetas", "Camisetas").AddRow("camisas-polo", "Polo Camisas")
+
+	query := regexp.QuoteMeta("SELECT slug, name FROM category WHERE name LIKE ? OR name LIKE ? ORDER BY name LIMIT ?;")
+
+	mock.ExpectQuery(query).WithArgs("cam%", "% cam%", 3).WillReturnRows(rows)
+
+	searchMysqlRepository := NewSearchMysqlRepository(db)
+
+	suggestions, err := searchMysqlRepository.GetCategorySuggestions(context.Background(), "cam", 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Suggestion{
+		{Type: domain.SuggestionTypeCategory, Text: "Camisetas", Key: "camisetas"},
+		{Type: domain.SuggestionTypeCategory, Text: "Polo Camisas", Key: "camisas-polo"},
+	}, suggestions)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
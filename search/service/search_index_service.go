@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+const (
+	nameWeight      = 3.0
+	attributeWeight = 1.5
+	detailWeight    = 1.0
+
+	prefixFactor = 0.7
+	typoFactor   = 0.5
+)
+
+type indexedDocument struct {
+	name   string
+	terms  map[string]float64
+	length float64
+}
+
+type searchIndexService struct {
+	mu          sync.RWMutex
+	documents   map[string]*indexedDocument
+	postings    map[string]map[string]float64
+	sortedTerms []string
+	dirty       bool
+	totalLength float64
+}
+
+func NewSearchIndexService() *searchIndexService {
+	return &searchIndexService{
+		documents: map[string]*indexedDocument{},
+		postings:  map[string]map[string]float64{},
+	}
+}
+
+func (s *searchIndexService) Index(ctx context.Context, p *domain.Product) {
+	doc := &indexedDocument{name: p.Name, terms: map[string]float64{}}
+
+	addTerms := func(text string, weight float64) {
+		for _, term := range analyze(text) {
+			doc.terms[term] += weight
+			doc.length += weight
+		}
+	}
+
+	addTerms(p.Name, nameWeight)
+	addTerms(p.Detail, detailWeight)
+
+	for _, attribute := range p.Attributes {
+		for _, value := range attribute.Values {
+			addTerms(value, attributeWeight)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(p.UUID)
+
+	s.documents[p.UUID] = doc
+	s.totalLength += doc.length
+
+	for term, weight := range doc.terms {
+		if _, ok := s.postings[term]; !ok {
+			s.postings[term] = map[string]float64{}
+			s.dirty = true
+		}
+
+		s.postings[term][p.UUID] = weight
+	}
+}
+
+func (s *searchIndexService) Remove(ctx context.Context, uuid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(uuid)
+}
+
+func (s *searchIndexService) remove(uuid string) {
+	doc, ok := s.documents[uuid]
+
+	if !ok {
+		return
+	}
+
+	for term := range doc.terms {
+		delete(s.postings[term], uuid)
+
+		if len(s.postings[term]) == 0 {
+			delete(s.postings, term)
+			s.dirty = true
+		}
+	}
+
+	s.totalLength -= doc.length
+	delete(s.documents, uuid)
+}
+
+// Search ranks the products with BM25 over the weighted fields. Each query
+// term matches the exact term, and also, with a lower score, the terms it
+// is a prefix of and the terms one or two typos away from it.
+func (s *searchIndexService) Search(ctx context.Context, q string, limit int) []domain.SearchHit {
+	queryTerms := analyze(q)
+
+	if len(queryTerms) == 0 || limit <= 0 {
+		return []domain.SearchHit{}
+	}
+
+	// the sorted terms are refreshed under the same lock the search holds, a
+	// product indexed in between would be missing from them otherwise
+	s.mu.RLock()
+
+	if s.dirty {
+		s.mu.RUnlock()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.refreshSortedTerms()
+	} else {
+		defer s.mu.RUnlock()
+	}
+
+	total := float64(len(s.documents))
+
+	if total == 0 {
+		return []domain.SearchHit{}
+	}
+
+	avgLength := s.totalLength / total
+
+	scores := map[string]float64{}
+	matched := map[string]int{}
+
+	for _, queryTerm := range queryTerms {
+		termScores := map[string]float64{}
+
+		for term, factor := range s.expand(queryTerm) {
+			docs := s.postings[term]
+			df := float64(len(docs))
+			idf := math.Log(1 + (total-df+0.5)/(df+0.5))
+
+			for uuid, tf := range docs {
+				norm := tf * 2.2 / (tf + 1.2*(0.25+0.75*s.documents[uuid].length/avgLength))
+				score := factor * idf * norm
+
+				if score > termScores[uuid] {
+					termScores[uuid] = score
+				}
+			}
+		}
+
+		for uuid, score := range termScores {
+			scores[uuid] += score
+			matched[uuid]++
+		}
+	}
+
+	hits := make([]domain.SearchHit, 0, len(scores))
+
+	for uuid, score := range scores {
+		coordination := float64(matched[uuid]) / float64(len(queryTerms))
+		hits = append(hits, domain.SearchHit{ProductUUID: uuid, Name: s.documents[uuid].name, Score: score * coordination * coordination})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ProductUUID < hits[j].ProductUUID
+	})
+
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits
+}
+
+// expand returns the indexed terms a query term matches with their factor.
+func (s *searchIndexService) expand(queryTerm string) map[string]float64 {
+	res := map[string]float64{}
+
+	if _, ok := s.postings[queryTerm]; ok {
+		res[queryTerm] = 1
+	}
+
+	if len(queryTerm) >= 2 {
+		i := sort.SearchStrings(s.sortedTerms, queryTerm)
+
+		for ; i < len(s.sortedTerms) && strings.HasPrefix(s.sortedTerms[i], queryTerm); i++ {
+			if _, ok := res[s.sortedTerms[i]]; !ok {
+				res[s.sortedTerms[i]] = prefixFactor
+			}
+		}
+	}
+
+	maxTypos := 0
+
+	switch {
+	case len(queryTerm) >= 8:
+		maxTypos = 2
+	case len(queryTerm) >= 4:
+		maxTypos = 1
+	}
+
+	if maxTypos == 0 {
+		return res
+	}
+
+	for _, term := range s.sortedTerms {
+		if _, ok := res[term]; ok {
+			continue
+		}
+
+		if distance := editDistance(queryTerm, term, maxTypos); distance <= maxTypos {
+			res[term] = typoFactor / float64(distance)
+		}
+	}
+
+	return res
+}
+
+// refreshSortedTerms must be called with the write lock held, another search
+// may have refreshed them while it was taken.
+func (s *searchIndexService) refreshSortedTerms() {
+	if !s.dirty {
+		return
+	}
+
+	terms := make([]string, 0, len(s.postings))
+	for term := range s.postings {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+
+	s.sortedTerms = terms
+	s.dirty = false
+}
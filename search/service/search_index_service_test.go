@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestIndex() *searchIndexService {
+	s := NewSearchIndexService()
+
+	s.Index(context.Background(), &domain.Product{UUID: "uuid1", Name: "Camiseta básica", Detail: "Camiseta de algodão com gola redonda", Attributes: []domain.Attribute{{Label: "cor", Values: []string{"preta", "branca"}}}})
+	s.Index(context.Background(), &domain.Product{UUID: "uuid2", Name: "Calça jeans", Detail: "Calça com bolsos e botões", Attributes: []domain.Attribute{{Label: "cor", Values: []string{"azul"}}}})
+	s.Index(context.Background(), &domain.Product{UUID: "uuid3", Name: "Livro de código limpo", Detail: "Um clássico sobre camisetas de programadores"})
+
+	return s
+}
+
+func hitUUIDs(hits []domain.SearchHit) []string {
+	res := []string{}
+	for _, hit := range hits {
+		res = append(res, hit.ProductUUID)
+	}
+	return res
+}
+
+func TestSearchEmptyQuery(t *testing.T) {
+	s := newTestIndex()
+
+	assert.Empty(t, s.Search(context.Background(), "de com", 10))
+	assert.Empty(t, s.Search(context.Background(), "", 10))
+}
+
+func TestSearchNameWeighsMoreThanDetail(t *testing.T) {
+	s := newTestIndex()
+
+	assert.Equal(t, []string{"uuid1", "uuid3"}, hitUUIDs(s.Search(context.Background(), "camisetas", 10)))
+}
+
+func TestSearchAccentFolding(t *testing.T) {
+	s := newTestIndex()
+
+	assert.Equal(t, []string{"uuid3"}, hitUUIDs(s.Search(context.Background(), "codigo", 10)))
+	assert.Equal(t, []string{"uuid2"}, hitUUIDs(s.Search(context.Background(), "BOTÃO", 10)))
+}
+
+func TestSearchAttributeValues(t *testing.T) {
+	s := newTestIndex()
+
+	assert.Equal(t, []string{"uuid2"}, hitUUIDs(s.Search(context.Background(), "azul", 10)))
+}
+
+func TestSearchPrefix(t *testing.T) {
+	s := newTestIndex()
+
+	assert.Equal(t, []string{"uuid2"}, hitUUIDs(s.Search(context.Background(), "jea", 10)))
+}
+
+func TestSearchTypo(t *testing.T) {
+	s := newTestIndex()
+
+	assert.Equal(t, []string{"uuid2"}, hitUUIDs(s.Search(context.Background(), "calsa", 10)))
+	assert.Equal(t, []string{"uuid1", "uuid3"}, hitUUIDs(s.Search(context.Background(), "camizeta", 10)))
+}
+
+func TestSearchExactBeatsTypo(t *testing.T) {
+	s := NewSearchIndexService()
+
+	s.Index(context.Background(), &domain.Product{UUID: "uuid1", Name: "Bolsa"})
+	s.Index(context.Background(), &domain.Product{UUID: "uuid2", Name: "Bolso"})
+
+	assert.Equal(t, []string{"uuid2", "uuid1"}, hitUUIDs(s.Search(context.Background(), "bolso", 10)))
+}
+
+func TestSearchAllTermsRankFirst(t *testing.T) {
+	s := newTestIndex()
+
+	hits := hitUUIDs(s.Search(context.Background(), "camiseta algodão", 10))
+
+	assert.Equal(t, "uuid1", hits[0])
+}
+
+func TestSearchLimit(t *testing.T) {
+	s := newTestIndex()
+
+	assert.Len(t, s.Search(context.Background(), "camiseta", 1), 1)
+}
+
+func TestSearchIncrementalUpdates(t *testing.T) {
+	s := newTestIndex()
+
+	s.Index(context.Background(), &domain.Product{UUID: "uuid2", Name: "Bermuda jeans", Detail: "Bermuda"})
+
+	assert.Empty(t, s.Search(context.Background(), "calça", 10))
+	assert.Equal(t, []string{"uuid2"}, hitUUIDs(s.Search(context.Background(), "bermuda", 10)))
+
+	s.Remove(context.Background(), "uuid2")
+
+	assert.Empty(t, s.Search(context.Background(), "bermuda", 10))
+	assert.Empty(t, s.postings["bermuda"])
+}
+
+func TestSearchHitName(t *testing.T) {
+	s := newTestIndex()
+
+	hits := s.Search(context.Background(), "jeans", 10)
+
+	assert.Equal(t, "Calça jeans", hits[0].Name)
+}
+
+// TestSearchWhileIndexing is meant for go test -race, each search must find
+// the product indexed before it by the same goroutine.
+func TestSearchWhileIndexing(t *testing.T) {
+	s := newTestIndex()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 50; j++ {
+				uuid := fmt.Sprintf("uuid-%d-%d", i, j)
+				term := fmt.Sprintf("modelo%dx%d", i, j)
+
+				s.Index(context.Background(), &domain.Product{UUID: uuid, Name: "Camiseta " + term})
+
+				assert.Contains(t, hitUUIDs(s.Search(context.Background(), term, 10)), uuid)
+
+				if j%2 == 0 {
+					s.Remove(context.Background(), uuid)
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	assert.Len(t, s.Search(context.Background(), "camiseta", 1000), 202)
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+)
+
+var accentFolding = map[rune]rune{
+	'á': 'a', 'à': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a',
+	'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e',
+	'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+	'ó': 'o', 'ò': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o',
+	'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+	'ç': 'c', 'ñ': 'n',
+}
+
+var stopwords = map[string]bool{
+	"a": true, "o": true, "as": true, "os": true, "um": true, "uma": true, "uns": true, "umas": true,
+	"de": true, "da": true, "do": true, "das": true, "dos": true, "e": true, "em": true, "no": true,
+	"na": true, "nos": true, "nas": true, "com": true, "para": true, "pra": true, "por": true,
+	"pelo": true, "pela": true, "ao": true, "aos": true, "ou": true, "que": true, "se": true,
+}
+
+// foldAccents lowercases the text and removes the accents, so "Código"
+// and "codigo" end up as the same term.
+func foldAccents(s string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(s) {
+		if folded, ok := accentFolding[r]; ok {
+			r = folded
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// analyze splits the text in folded, stemmed terms without stopwords.
+func analyze(s string) []string {
+	words := strings.FieldsFunc(foldAccents(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	terms := []string{}
+
+	for _, w := range words {
+		if stopwords[w] {
+			continue
+		}
+
+		terms = append(terms, stem(w))
+	}
+
+	return terms
+}
+
+// stem removes the portuguese plural endings, which is what matters most when
+// matching "camisetas" with "camiseta" or "botões" with "botão".
+func stem(w string) string {
+	if len(w) <= 3 {
+		return w
+	}
+
+	switch {
+	case strings.HasSuffix(w, "oes"), strings.HasSuffix(w, "aes"):
+		return w[:len(w)-3] + "ao"
+	case strings.HasSuffix(w, "ais"):
+		return w[:len(w)-3] + "al"
+	case strings.HasSuffix(w, "eis"):
+		return w[:len(w)-3] + "el"
+	case strings.HasSuffix(w, "ois"):
+		return w[:len(w)-3] + "ol"
+	case strings.HasSuffix(w, "ns"):
+		return w[:len(w)-2] + "m"
+	case strings.HasSuffix(w, "res"), strings.HasSuffix(w, "zes"), strings.HasSuffix(w, "ses"):
+		return w[:len(w)-2]
+	case strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") && !strings.HasSuffix(w, "us") && !strings.HasSuffix(w, "is"):
+		return w[:len(w)-1]
+	}
+
+	return w
+}
+
+// editDistance is the Damerau-Levenshtein distance (with adjacent
+// transpositions), giving up as soon as it goes over max.
+func editDistance(a, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+
+	diff := len(ra) - len(rb)
+	if diff > max || -diff > max {
+		return max + 1
+	}
+
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = minInt(minInt(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = minInt(curr[j], prev2[j-2]+1)
+			}
+
+			rowMin = minInt(rowMin, curr[j])
+		}
+
+		if rowMin > max {
+			return max + 1
+		}
+
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(rb)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFoldAccents(t *testing.T) {
+	assert.Equal(t, "codigo acao pao", foldAccents("Código AÇÃO pão"))
+}
+
+func TestAnalyze(t *testing.T) {
+	assert.Equal(t, []string{"camiseta", "algodao", "botao"}, analyze("Camisetas de Algodão, com botões!"))
+}
+
+func TestStem(t *testing.T) {
+	cases := map[string]string{
+		"camisetas": "camiseta",
+		"botoes":    "botao",
+		"paes":      "pao",
+		"animais":   "animal",
+		"papeis":    "papel",
+		"lencois":   "lencol",
+		"bombons":   "bombom",
+		"cores":     "cor",
+		"luzes":     "luz",
+		"meses":     "mes",
+		"onibus":    "onibus",
+		"tenis":     "tenis",
+		"gas":       "gas",
+	}
+
+	for word, expected := range cases {
+		assert.Equal(t, expected, stem(word), word)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("camiseta", "camiseta", 2))
+	assert.Equal(t, 1, editDistance("camiseta", "camisetq", 2))
+	assert.Equal(t, 1, editDistance("camiseta", "camsieta", 2))
+	assert.Equal(t, 2, editDistance("camiseta", "kamizeta", 2))
+	assert.Equal(t, 3, editDistance("camiseta", "bermuda", 2))
+	assert.Equal(t, 2, editDistance("tenis", "tenisxyz", 1))
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+const maxQuerySuggestions = 3
+const maxCategorySuggestions = 3
+
+type searchUseCase struct {
+	searchIndex domain.SearchIndex
+	searchRepo  domain.SearchRepository
+}
+
+func NewSearchUseCase(si domain.SearchIndex, sr domain.SearchRepository) domain.SearchUseCase {
+	return &searchUseCase{searchIndex: si, searchRepo: sr}
+}
+
+// Suggest returns the popular queries first, then the categories and the
+// product names, up to limit suggestions.
+func (su *searchUseCase) Suggest(ctx context.Context, q string, limit int) ([]domain.Suggestion, error) {
+	queries, err := su.searchRepo.GetQuerySuggestions(ctx, q, maxQuerySuggestions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := su.searchRepo.GetCategorySuggestions(ctx, q, maxCategorySuggestions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := append(queries, categories...)
+
+	for _, hit := range su.searchIndex.Search(ctx, q, limit) {
+		res = append(res, domain.Suggestion{Type: domain.SuggestionTypeProduct, Text: hit.Name, Key: hit.ProductUUID})
+	}
+
+	if len(res) > limit {
+		res = res[:limit]
+	}
+
+	return res, nil
+}
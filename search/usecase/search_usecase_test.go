@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSuggestError(t *testing.T) {
+	mockSearchRepo := new(mocks.MockSearchRepository)
+
+	mockSearchRepo.On("GetQuerySuggestions", mock.Anything, "cam", 3).Return(nil, errors.New("error message"))
+
+	_, err := NewSearchUseCase(nil, mockSearchRepo).Suggest(context.Background(), "cam", 8)
+
+	assert.Error(t, err)
+}
+
+func TestSuggest(t *testing.T) {
+	mockSearchRepo := new(mocks.MockSearchRepository)
+	mockSearchIndex := new(mocks.MockSearchIndex)
+
+	mockSearchRepo.On("GetQuerySuggestions", mock.Anything, "cam", 3).Return([]domain.Suggestion{{Type: domain.SuggestionTypeQuery, Text: "camiseta", Key: "camiseta"}}, nil)
+	mockSearchRepo.On("GetCategorySuggestions", mock.Anything, "cam", 3).Return([]domain.Suggestion{{Type: domain.SuggestionTypeCategory, Text: "Camisetas", Key: "camisetas"}}, nil)
+	mockSearchIndex.On("Search", mock.Anything, "cam", 3).Return([]domain.SearchHit{{ProductUUID: "uuid1", Name: "Camiseta básica"}, {ProductUUID: "uuid2", Name: "Camisa polo"}})
+
+	suggestions, err := NewSearchUseCase(mockSearchIndex, mockSearchRepo).Suggest(context.Background(), "cam", 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Suggestion{
+		{Type: domain.SuggestionTypeQuery, Text: "camiseta", Key: "camiseta"},
+		{Type: domain.SuggestionTypeCategory, Text: "Camisetas", Key: "camisetas"},
+		{Type: domain.SuggestionTypeProduct, Text: "Camiseta básica", Key: "uuid1"},
+	}, suggestions)
+}
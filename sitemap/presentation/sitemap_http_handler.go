@@ -0,0 +1,111 @@
+package presentation
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/labstack/echo/v4"
+)
+
+const sitemapXmlns = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+type sitemapHandler struct {
+	SitemapUseCase domain.SitemapUseCase
+	SiteURL        string
+}
+
+type sitemapLocation struct {
+	Loc string `xml:"loc"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name          `xml:"sitemapindex"`
+	Xmlns    string            `xml:"xmlns,attr"`
+	Sitemaps []sitemapLocation `xml:"sitemap"`
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name          `xml:"urlset"`
+	Xmlns   string            `xml:"xmlns,attr"`
+	URLs    []sitemapLocation `xml:"url"`
+}
+
+// NewSitemapHandler serves the sitemaps with absolute urls of siteURL, the
+// address the store is published at.
+func NewSitemapHandler(e *echo.Echo, suc domain.SitemapUseCase, siteURL string) *sitemapHandler {
+	handler := &sitemapHandler{
+		SitemapUseCase: suc,
+		SiteURL:        strings.TrimSuffix(siteURL, "/"),
+	}
+
+	e.GET("/sitemap.xml", handler.Index)
+	e.GET("/sitemaps/:name", handler.Products)
+
+	return handler
+}
+
+func (sh *sitemapHandler) Index(c echo.Context) error {
+	count, err := sh.SitemapUseCase.Count(c.Request().Context())
+
+	if err != nil {
+		log.Printf("Error trying to count the sitemaps: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the sitemap")
+	}
+
+	index := sitemapIndex{Xmlns: sitemapXmlns}
+
+	for n := 1; n <= count; n++ {
+		index.Sitemaps = append(index.Sitemaps, sitemapLocation{Loc: fmt.Sprintf("%s/sitemaps/products-%d.xml", sh.SiteURL, n)})
+	}
+
+	c.Response().Header().Set("Cache-Control", "public, max-age=3600")
+
+	return c.XML(http.StatusOK, index)
+}
+
+func (sh *sitemapHandler) Products(c echo.Context) error {
+	name := c.Param("name")
+
+	if !strings.HasPrefix(name, "products-") || !strings.HasSuffix(name, ".xml") {
+		return c.JSON(http.StatusNotFound, "sitemap not found")
+	}
+
+	n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, "products-"), ".xml"))
+
+	if err != nil || n < 1 {
+		return c.JSON(http.StatusNotFound, "sitemap not found")
+	}
+
+	products, err := sh.SitemapUseCase.Products(c.Request().Context(), n)
+
+	if err != nil {
+		log.Printf("Error trying to get a sitemap: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the sitemap")
+	}
+
+	// the first sitemap is listed even without products
+	if n > 1 && len(products) == 0 {
+		return c.JSON(http.StatusNotFound, "sitemap not found")
+	}
+
+	urlSet := sitemapURLSet{Xmlns: sitemapXmlns, URLs: []sitemapLocation{}}
+
+	for _, p := range products {
+		loc := p.CanonicalURL
+
+		if loc == "" {
+			loc = sh.SiteURL + "/products/" + p.Slug
+		}
+
+		urlSet.URLs = append(urlSet.URLs, sitemapLocation{Loc: loc})
+	}
+
+	c.Response().Header().Set("Cache-Control", "public, max-age=3600")
+
+	return c.XML(http.StatusOK, urlSet)
+}
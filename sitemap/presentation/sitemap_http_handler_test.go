@@ -0,0 +1,140 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIndexError(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/sitemap.xml", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockSitemapUsecase := new(mocks.MockSitemapUseCase)
+
+	mockSitemapUsecase.On("Count", mock.Anything).Return(0, errors.New("error message"))
+
+	handler := NewSitemapHandler(echo.New(), mockSitemapUsecase, "https://shop.test")
+
+	handler.Index(c)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestIndex(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/sitemap.xml", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockSitemapUsecase := new(mocks.MockSitemapUseCase)
+
+	mockSitemapUsecase.On("Count", mock.Anything).Return(2, nil)
+
+	handler := NewSitemapHandler(echo.New(), mockSitemapUsecase, "https://shop.test/")
+
+	handler.Index(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/xml; charset=UTF-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+`<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><sitemap><loc>https://shop.test/sitemaps/products-1.xml</loc></sitemap><sitemap><loc>https://shop.test/sitemaps/products-2.xml</loc></sitemap></sitemapindex>`, rec.Body.String())
+}
+
+func TestProductsInvalidName(t *testing.T) {
+	for _, name := range []string{"categories-1.xml", "products-1.txt", "products-0.xml", "products-a.xml"} {
+		e := echo.New()
+		req, err := http.NewRequest(echo.GET, "/sitemaps/:name", strings.NewReader(""))
+		assert.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("name")
+		c.SetParamValues(name)
+
+		handler := NewSitemapHandler(echo.New(), nil, "https://shop.test")
+
+		handler.Products(c)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code, name)
+	}
+}
+
+func TestProductsPastTheLast(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/sitemaps/:name", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("name")
+	c.SetParamValues("products-3.xml")
+
+	mockSitemapUsecase := new(mocks.MockSitemapUseCase)
+
+	mockSitemapUsecase.On("Products", mock.Anything, 3).Return(nil, nil)
+
+	handler := NewSitemapHandler(echo.New(), mockSitemapUsecase, "https://shop.test")
+
+	handler.Products(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestProducts(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/sitemaps/:name", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("name")
+	c.SetParamValues("products-1.xml")
+
+	mockSitemapUsecase := new(mocks.MockSitemapUseCase)
+
+	mockSitemapUsecase.On("Products", mock.Anything, 1).Return([]domain.Product{{UUID: "uuid1", Slug: "camiseta"}, {UUID: "uuid2", Slug: "camisa", CanonicalURL: "https://shop.test/camisas/camisa"}}, nil)
+
+	handler := NewSitemapHandler(echo.New(), mockSitemapUsecase, "https://shop.test")
+
+	handler.Products(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "public, max-age=3600", rec.Header().Get("Cache-Control"))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><url><loc>https://shop.test/products/camiseta</loc></url><url><loc>https://shop.test/camisas/camisa</loc></url></urlset>`, rec.Body.String())
+}
+
+func TestProductsEmptyFirst(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/sitemaps/:name", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("name")
+	c.SetParamValues("products-1.xml")
+
+	mockSitemapUsecase := new(mocks.MockSitemapUseCase)
+
+	mockSitemapUsecase.On("Products", mock.Anything, 1).Return(nil, nil)
+
+	handler := NewSitemapHandler(echo.New(), mockSitemapUsecase, "https://shop.test")
+
+	handler.Products(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"></urlset>`, rec.Body.String())
+}
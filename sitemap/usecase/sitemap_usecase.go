@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+// the limit of urls of a sitemap file
+const maxSitemapURLs = 50000
+
+const listPageSize = 1000
+
+type sitemapUseCase struct {
+	productRepo domain.ProductRepository
+}
+
+func NewSitemapUseCase(pr domain.ProductRepository) domain.SitemapUseCase {
+	return &sitemapUseCase{productRepo: pr}
+}
+
+// Count gives at least one sitemap, even an empty one.
+func (su *sitemapUseCase) Count(ctx context.Context) (int, error) {
+	page, err := su.productRepo.List(ctx, domain.ProductQuery{Limit: 1, Sort: domain.ProductSortName, Status: domain.ProductStatusPublished, Attributes: map[string][]string{}, WithTotal: true})
+
+	if err != nil {
+		return 0, err
+	}
+
+	if *page.Total == 0 {
+		return 1, nil
+	}
+
+	return int((*page.Total + maxSitemapURLs - 1) / maxSitemapURLs), nil
+}
+
+// Products pages through the products of the sitemaps before n, the cursor
+// can not jump ahead.
+func (su *sitemapUseCase) Products(ctx context.Context, n int) ([]domain.Product, error) {
+	skip := (n - 1) * maxSitemapURLs
+
+	q := domain.ProductQuery{Limit: listPageSize, Sort: domain.ProductSortName, Status: domain.ProductStatusPublished, Attributes: map[string][]string{}}
+
+	var products []domain.Product
+
+	for {
+		page, err := su.productRepo.List(ctx, q)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range page.Products {
+			if skip > 0 {
+				skip--
+				continue
+			}
+
+			products = append(products, p)
+
+			if len(products) == maxSitemapURLs {
+				return products, nil
+			}
+		}
+
+		if page.NextCursor == "" {
+			return products, nil
+		}
+
+		q.Cursor = page.NextCursor
+	}
+}
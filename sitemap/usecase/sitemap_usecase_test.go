@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func productsPage(from int, size int, nextCursor string) *domain.ProductPage {
+	page := &domain.ProductPage{NextCursor: nextCursor}
+
+	for i := from; i < from+size; i++ {
+		page.Products = append(page.Products, domain.Product{ID: int64(i), UUID: fmt.Sprintf("uuid%d", i)})
+	}
+
+	return page
+}
+
+func TestCount(t *testing.T) {
+	for total, count := range map[int64]int{0: 1, 1: 1, 50000: 1, 50001: 2, 120000: 3} {
+		total := total
+
+		mockProductRepo := new(mocks.MockProductRepository)
+
+		mockProductRepo.On("List", mock.Anything, mock.MatchedBy(func(q domain.ProductQuery) bool {
+			return q.WithTotal && q.Status == domain.ProductStatusPublished
+		})).Return(&domain.ProductPage{Total: &total}, nil)
+
+		n, err := NewSitemapUseCase(mockProductRepo).Count(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, count, n, total)
+	}
+}
+
+func TestCountError(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("List", mock.Anything, mock.Anything).Return(nil, errors.New("error message"))
+
+	_, err := NewSitemapUseCase(mockProductRepo).Count(context.Background())
+
+	assert.Error(t, err)
+}
+
+func TestProductsFirst(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("List", mock.Anything, mock.MatchedBy(func(q domain.ProductQuery) bool {
+		return q.Cursor == "" && q.Status == domain.ProductStatusPublished
+	})).Return(productsPage(0, listPageSize, "next"), nil)
+	mockProductRepo.On("List", mock.Anything, mock.MatchedBy(func(q domain.ProductQuery) bool {
+		return q.Cursor == "next"
+	})).Return(productsPage(listPageSize, 2, ""), nil)
+
+	products, err := NewSitemapUseCase(mockProductRepo).Products(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, products, listPageSize+2)
+	assert.Equal(t, "uuid1001", products[listPageSize+1].UUID)
+}
+
+func TestProductsSkipsPreviousSitemaps(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	pages := maxSitemapURLs / listPageSize
+
+	for i := 0; i < pages; i++ {
+		mockProductRepo.On("List", mock.Anything, mock.Anything).Return(productsPage(i*listPageSize, listPageSize, "next"), nil).Once()
+	}
+
+	mockProductRepo.On("List", mock.Anything, mock.Anything).Return(productsPage(maxSitemapURLs, 3, ""), nil).Once()
+
+	products, err := NewSitemapUseCase(mockProductRepo).Products(context.Background(), 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Product{{ID: 50000, UUID: "uuid50000"}, {ID: 50001, UUID: "uuid50001"}, {ID: 50002, UUID: "uuid50002"}}, products)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestProductsPastTheLast(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("List", mock.Anything, mock.Anything).Return(productsPage(0, 3, ""), nil)
+
+	products, err := NewSitemapUseCase(mockProductRepo).Products(context.Background(), 2)
+
+	assert.NoError(t, err)
+	assert.Empty(t, products)
+}
+
+func TestProductsError(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	mockProductRepo.On("List", mock.Anything, mock.Anything).Return(nil, errors.New("error message"))
+
+	_, err := NewSitemapUseCase(mockProductRepo).Products(context.Background(), 1)
+
+	assert.Error(t, err)
+}
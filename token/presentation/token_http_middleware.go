@@ -0,0 +1,80 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/labstack/echo/v4"
+)
+
+const tokenInfoKey = "tokenInfo"
+
+// NewAuthMiddleware rejects requests without a valid Authorization token and
+// keeps the token info in the echo context for the handlers.
+func NewAuthMiddleware(ts domain.TokenService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			authHeader := c.Request().Header.Get("Authorization")
+			if authHeader == "" {
+				return c.JSON(http.StatusUnauthorized, "request not authorized")
+			}
+
+			info, err := ts.GetInfo(c.Request().Context(), domain.Token(authHeader))
+
+			if err != nil || info == nil {
+				return c.JSON(http.StatusUnauthorized, "request not authorized")
+			}
+
+			c.Set(tokenInfoKey, info)
+
+			return next(c)
+		}
+	}
+}
+
+// NewOptionalAuthMiddleware lets through requests without an Authorization
+// header as anonymous ones. A token that is sent must still be valid, so
+// the client knows it has to log in again.
+func NewOptionalAuthMiddleware(ts domain.TokenService) echo.MiddlewareFunc {
+	auth := NewAuthMiddleware(ts)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		withToken := auth(next)
+
+		return func(c echo.Context) error {
+			if c.Request().Header.Get("Authorization") == "" {
+				return next(c)
+			}
+
+			return withToken(c)
+		}
+	}
+}
+
+// TokenInfoFromContext returns the token info stored by the auth middleware,
+// or nil when the request is not authenticated.
+func TokenInfoFromContext(c echo.Context) *domain.TokenInfo {
+	info, ok := c.Get(tokenInfoKey).(*domain.TokenInfo)
+
+	if !ok {
+		return nil
+	}
+
+	return info
+}
+
+// NewAdminMiddleware works like NewAuthMiddleware but only lets through
+// tokens signed for the admin role.
+func NewAdminMiddleware(ts domain.TokenService) echo.MiddlewareFunc {
+	auth := NewAuthMiddleware(ts)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return auth(func(c echo.Context) error {
+			if info := TokenInfoFromContext(c); info == nil || info.Role != domain.AdminRole {
+				return c.JSON(http.StatusForbidden, "request not allowed")
+			}
+
+			return next(c)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthMiddlewareWithoutToken(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/me", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	next := func(c echo.Context) error {
+		return c.String(http.StatusOK, "")
+	}
+
+	NewAuthMiddleware(nil)(next)(c)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAuthMiddlewareInvalidToken(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/me", strings.NewReader(""))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "token")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockTokenService := new(mocks.MockTokenService)
+
+	mockTokenService.On("GetInfo", mock.Anything, domain.Token("token")).Return(nil, errors.New("error message"))
+
+	next := func(c echo.Context) error {
+		return c.String(http.StatusOK, "")
+	}
+
+	NewAuthMiddleware(mockTokenService)(next)(c)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/me", strings.NewReader(""))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "token")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockTokenService := new(mocks.MockTokenService)
+
+	mockTokenService.On("GetInfo", mock.Anything, domain.Token("token")).Return("user@test.com", "", nil)
+
+	next := func(c echo.Context) error {
+		return c.String(http.StatusOK, TokenInfoFromContext(c).Info)
+	}
+
+	NewAuthMiddleware(mockTokenService)(next)(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "user@test.com", rec.Body.String())
+}
+
+func TestOptionalAuthMiddlewareWithoutToken(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	next := func(c echo.Context) error {
+		assert.Nil(t, TokenInfoFromContext(c))
+		return c.String(http.StatusOK, "")
+	}
+
+	NewOptionalAuthMiddleware(nil)(next)(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestOptionalAuthMiddlewareInvalidToken(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products", strings.NewReader(""))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "token")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockTokenService := new(mocks.MockTokenService)
+
+	mockTokenService.On("GetInfo", mock.Anything, domain.Token("token")).Return(nil, errors.New("error message"))
+
+	next := func(c echo.Context) error {
+		return c.String(http.StatusOK, "")
+	}
+
+	NewOptionalAuthMiddleware(mockTokenService)(next)(c)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestOptionalAuthMiddleware(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products", strings.NewReader(""))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "token")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockTokenService := new(mocks.MockTokenService)
+
+	mockTokenService.On("GetInfo", mock.Anything, domain.Token("token")).Return("user@test.com", "", nil)
+
+	next := func(c echo.Context) error {
+		return c.String(http.StatusOK, TokenInfoFromContext(c).Info)
+	}
+
+	NewOptionalAuthMiddleware(mockTokenService)(next)(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "user@test.com", rec.Body.String())
+}
+
+func TestTokenInfoFromContextEmpty(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/me", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	assert.Nil(t, TokenInfoFromContext(c))
+}
+
+func TestAdminMiddlewareNotAdmin(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin", strings.NewReader(""))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "token")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockTokenService := new(mocks.MockTokenService)
+
+	mockTokenService.On("GetInfo", mock.Anything, domain.Token("token")).Return("user@test.com", "customer", nil)
+
+	next := func(c echo.Context) error {
+		return c.String(http.StatusOK, "")
+	}
+
+	NewAdminMiddleware(mockTokenService)(next)(c)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestAdminMiddlewareWithoutToken(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin", strings.NewReader(""))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	next := func(c echo.Context) error {
+		return c.String(http.StatusOK, "")
+	}
+
+	NewAdminMiddleware(nil)(next)(c)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAdminMiddleware(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin", strings.NewReader(""))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "token")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockTokenService := new(mocks.MockTokenService)
+
+	mockTokenService.On("GetInfo", mock.Anything, domain.Token("token")).Return("admin@test.com", domain.AdminRole, nil)
+
+	next := func(c echo.Context) error {
+		return c.String(http.StatusOK, "")
+	}
+
+	NewAdminMiddleware(mockTokenService)(next)(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
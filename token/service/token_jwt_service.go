@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
@@ -12,6 +13,7 @@ var jwtKey = []byte("my_secret_key")
 
 type Claims struct {
 	Info string
+	Role string
 	jwt.StandardClaims
 }
 
@@ -26,6 +28,7 @@ func (t *tokenService) Sign(ctx context.Context, info domain.TokenInfo, expirati
 
 	claims := &Claims{
 		Info: info.Info,
+		Role: info.Role,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: expirationTime.Unix(),
 		},
@@ -54,3 +57,21 @@ func (t *tokenService) IsValid(ctx context.Context, token domain.Token) (domain.
 
 	return true, nil
 }
+
+func (t *tokenService) GetInfo(ctx context.Context, token domain.Token) (*domain.TokenInfo, error) {
+	claims := &Claims{}
+
+	tkn, err := jwt.ParseWithClaims(string(token), claims, func(t *jwt.Token) (interface{}, error) {
+		return jwtKey, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !tkn.Valid {
+		return nil, fmt.Errorf("token is not valid")
+	}
+
+	return &domain.TokenInfo{Info: claims.Info, Role: claims.Role}, nil
+}
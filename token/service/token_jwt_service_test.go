@@ -36,3 +36,26 @@ func TestIsValid(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, bool(isValid))
 }
+
+func TestGetInfoTokenInvalid(t *testing.T) {
+	ts := NewTokenService()
+
+	token, _ := ts.Sign(context.Background(), domain.TokenInfo{Info: "token info"}, 10)
+
+	info, err := ts.GetInfo(context.Background(), token+"invalid string")
+
+	assert.Error(t, err)
+	assert.Nil(t, info)
+}
+
+func TestGetInfo(t *testing.T) {
+	ts := NewTokenService()
+
+	token, _ := ts.Sign(context.Background(), domain.TokenInfo{Info: "token info", Role: domain.AdminRole}, 10)
+
+	info, err := ts.GetInfo(context.Background(), token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "token info", info.Info)
+	assert.Equal(t, domain.AdminRole, info.Role)
+}
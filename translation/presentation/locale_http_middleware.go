@@ -0,0 +1,151 @@
+package presentation
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/labstack/echo/v4"
+)
+
+type acceptedLanguage struct {
+	tag string
+	q   float64
+}
+
+// NewLocaleMiddleware keeps in the request context the locales of the
+// Accept-Language header, the most wanted first, each followed by its
+// fallbacks and then by itself without the last subtag, "es-AR", "es-419",
+// "es". The content is written in the default locale, so the locales stop
+// at it, or at the first one of its language, since the original content
+// is better than a translation to another language.
+func NewLocaleMiddleware(defaultLocale string, fallbacks map[string]string) echo.MiddlewareFunc {
+	defaultLocale = normalizeLocale(defaultLocale)
+	defaultLanguage := language(defaultLocale)
+
+	normalized := map[string]string{}
+	for from, to := range fallbacks {
+		normalized[normalizeLocale(from)] = normalizeLocale(to)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Response().Header().Add("Vary", "Accept-Language")
+
+			locales := []string{}
+			seen := map[string]bool{}
+
+		accepted:
+			for _, tag := range acceptedLanguages(c.Request().Header.Get("Accept-Language")) {
+				for l := tag; l != "" && !seen[l]; l = fallback(normalized, l) {
+					if l == defaultLocale {
+						break accepted
+					}
+
+					seen[l] = true
+					locales = append(locales, l)
+				}
+
+				if language(tag) == defaultLanguage {
+					break
+				}
+			}
+
+			if len(locales) > 0 {
+				req := c.Request()
+				c.SetRequest(req.WithContext(domain.ContextWithLocales(req.Context(), locales)))
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func fallback(fallbacks map[string]string, locale string) string {
+	if next, ok := fallbacks[locale]; ok {
+		return next
+	}
+
+	if i := strings.LastIndex(locale, "-"); i > 0 {
+		return locale[:i]
+	}
+
+	return ""
+}
+
+// acceptedLanguages gives the tags of the header from the highest quality,
+// the ones with the same quality in the order they were sent. The wildcard
+// and the tags refused with q=0 are left out.
+func acceptedLanguages(header string) []string {
+	accepted := []acceptedLanguage{}
+
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := normalizeLocale(fields[0])
+
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+
+			parsed, err := strconv.ParseFloat(param[2:], 64)
+
+			if err != nil {
+				parsed = 0
+			}
+
+			q = parsed
+		}
+
+		if q <= 0 {
+			continue
+		}
+
+		accepted = append(accepted, acceptedLanguage{tag: tag, q: q})
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return accepted[i].q > accepted[j].q
+	})
+
+	tags := make([]string, len(accepted))
+	for i, a := range accepted {
+		tags[i] = a.tag
+	}
+
+	return tags
+}
+
+// normalizeLocale writes the language in lower case and the country in upper
+// case, as the translations are stored, "pt-br" and "pt_BR" become "pt-BR".
+func normalizeLocale(tag string) string {
+	subtags := strings.Split(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"), "-")
+
+	for i, s := range subtags {
+		switch {
+		case i == 0:
+			subtags[i] = strings.ToLower(s)
+		case len(s) == 2:
+			subtags[i] = strings.ToUpper(s)
+		case len(s) == 4:
+			subtags[i] = strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+		default:
+			subtags[i] = strings.ToLower(s)
+		}
+	}
+
+	return strings.Join(subtags, "-")
+}
+
+func language(locale string) string {
+	return strings.SplitN(locale, "-", 2)[0]
+}
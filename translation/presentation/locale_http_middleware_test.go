@@ -0,0 +1,93 @@
+package presentation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func localesFor(t *testing.T, acceptLanguage string) ([]string, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products", nil)
+	assert.NoError(t, err)
+
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	fallbacks := map[string]string{"es-AR": "es-419", "es-MX": "es-419", "es-419": "es"}
+
+	var locales []string
+
+	middleware := NewLocaleMiddleware("pt-BR", fallbacks)(func(c echo.Context) error {
+		locales = domain.LocalesFromContext(c.Request().Context())
+		return nil
+	})
+
+	assert.NoError(t, middleware(c))
+
+	return locales, rec
+}
+
+func TestLocaleMiddlewareNoHeader(t *testing.T) {
+	locales, rec := localesFor(t, "")
+
+	assert.Empty(t, locales)
+	assert.Equal(t, "Accept-Language", rec.Header().Get("Vary"))
+}
+
+func TestLocaleMiddlewareDefaultLocale(t *testing.T) {
+	locales, _ := localesFor(t, "pt-br, es;q=0.8")
+
+	assert.Empty(t, locales)
+}
+
+func TestLocaleMiddlewareFallbacks(t *testing.T) {
+	locales, _ := localesFor(t, "es-AR")
+
+	assert.Equal(t, []string{"es-AR", "es-419", "es"}, locales)
+}
+
+func TestLocaleMiddlewareQuality(t *testing.T) {
+	locales, _ := localesFor(t, "en;q=0.5, es-MX, fr;q=0, *;q=0.1")
+
+	assert.Equal(t, []string{"es-MX", "es-419", "es", "en"}, locales)
+}
+
+func TestLocaleMiddlewareStopsAtDefaultLanguage(t *testing.T) {
+	locales, _ := localesFor(t, "pt-PT, en;q=0.9")
+
+	assert.Equal(t, []string{"pt-PT", "pt"}, locales)
+}
+
+func TestLocaleMiddlewareStopsAtDefaultLocale(t *testing.T) {
+	locales, _ := localesFor(t, "es-es, pt-BR;q=0.9, en;q=0.8")
+
+	assert.Equal(t, []string{"es-ES", "es"}, locales)
+}
+
+func TestLocaleMiddlewareFallbackCycle(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/products", nil)
+	assert.NoError(t, err)
+	req.Header.Set("Accept-Language", "es-AR")
+
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	var locales []string
+
+	middleware := NewLocaleMiddleware("pt-BR", map[string]string{"es-AR": "es-UY", "es-UY": "es-AR"})(func(c echo.Context) error {
+		locales = domain.LocalesFromContext(c.Request().Context())
+		return nil
+	})
+
+	assert.NoError(t, middleware(c))
+	assert.Equal(t, []string{"es-AR", "es-UY"}, locales)
+}
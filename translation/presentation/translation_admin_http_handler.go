@@ -0,0 +1,181 @@
+package presentation
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	_tokenPresentation "github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/token/presentation"
+	"github.com/labstack/echo/v4"
+)
+
+type translationAdminHandler struct {
+	TranslationUseCase            domain.TranslationUseCase
+	ProductTranslationValidator   domain.ProductTranslationValidator
+	AttributeTranslationValidator domain.AttributeTranslationValidator
+}
+
+type productTranslationRequest struct {
+	Name   string `json:"name"`
+	Detail string `json:"detail"`
+}
+
+type attributeTranslationRequest struct {
+	Label       string            `json:"label"`
+	Translation string            `json:"translation"`
+	Values      map[string]string `json:"values"`
+}
+
+func NewTranslationAdminHandler(e *echo.Echo, tuc domain.TranslationUseCase, ptv domain.ProductTranslationValidator, atv domain.AttributeTranslationValidator, ts domain.TokenService) *translationAdminHandler {
+	handler := &translationAdminHandler{
+		TranslationUseCase:            tuc,
+		ProductTranslationValidator:   ptv,
+		AttributeTranslationValidator: atv,
+	}
+
+	admin := _tokenPresentation.NewAdminMiddleware(ts)
+
+	e.GET("/admin/products/:uuid/translations", handler.GetProductTranslations, admin)
+	e.PUT("/admin/products/:uuid/translations/:locale", handler.SetProductTranslation, admin)
+	e.DELETE("/admin/products/:uuid/translations/:locale", handler.DeleteProductTranslation, admin)
+	e.GET("/admin/attribute-translations/:locale", handler.GetAttributeTranslations, admin)
+	e.PUT("/admin/attribute-translations/:locale", handler.SetAttributeTranslation, admin)
+	e.DELETE("/admin/attribute-translations/:locale", handler.DeleteAttributeTranslation, admin)
+
+	return handler
+}
+
+func (tah *translationAdminHandler) GetProductTranslations(c echo.Context) error {
+	productUUID := c.Param("uuid")
+
+	if productUUID == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	translations, err := tah.TranslationUseCase.GetProductTranslations(c.Request().Context(), productUUID)
+
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to get the product translations: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the product translations")
+	}
+
+	return c.JSON(http.StatusOK, translations)
+}
+
+func (tah *translationAdminHandler) SetProductTranslation(c echo.Context) error {
+	productUUID := c.Param("uuid")
+
+	if productUUID == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	var req productTranslationRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	translation := domain.ProductTranslation{Locale: c.Param("locale"), Name: req.Name, Detail: req.Detail}
+
+	ctx := c.Request().Context()
+
+	isValid, message := tah.ProductTranslationValidator.Validate(ctx, &translation)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	err := tah.TranslationUseCase.SetProductTranslation(ctx, productUUID, &translation)
+
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to set a product translation: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to set the product translation")
+	}
+
+	return c.JSON(http.StatusOK, translation)
+}
+
+func (tah *translationAdminHandler) DeleteProductTranslation(c echo.Context) error {
+	productUUID := c.Param("uuid")
+
+	if productUUID == "" {
+		return c.JSON(http.StatusBadRequest, "uuid param is not valid")
+	}
+
+	err := tah.TranslationUseCase.DeleteProductTranslation(c.Request().Context(), productUUID, c.Param("locale"))
+
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return c.JSON(http.StatusNotFound, "product not found")
+	}
+
+	if err != nil {
+		log.Printf("Error trying to delete a product translation: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to delete the product translation")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (tah *translationAdminHandler) GetAttributeTranslations(c echo.Context) error {
+	translations, err := tah.TranslationUseCase.GetAttributeTranslations(c.Request().Context(), c.Param("locale"))
+
+	if err != nil {
+		log.Printf("Error trying to get the attribute translations: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to get the attribute translations")
+	}
+
+	return c.JSON(http.StatusOK, translations)
+}
+
+func (tah *translationAdminHandler) SetAttributeTranslation(c echo.Context) error {
+	var req attributeTranslationRequest
+
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, "failed to interpret the submitted information")
+	}
+
+	translation := domain.AttributeTranslation{Locale: c.Param("locale"), Label: req.Label, Translation: req.Translation, Values: req.Values}
+
+	if translation.Values == nil {
+		translation.Values = map[string]string{}
+	}
+
+	ctx := c.Request().Context()
+
+	isValid, message := tah.AttributeTranslationValidator.Validate(ctx, &translation)
+
+	if !isValid {
+		return c.JSON(http.StatusBadRequest, message)
+	}
+
+	if err := tah.TranslationUseCase.SetAttributeTranslation(ctx, &translation); err != nil {
+		log.Printf("Error trying to set an attribute translation: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to set the attribute translation")
+	}
+
+	return c.JSON(http.StatusOK, translation)
+}
+
+func (tah *translationAdminHandler) DeleteAttributeTranslation(c echo.Context) error {
+	label := c.QueryParam("label")
+
+	if label == "" {
+		return c.JSON(http.StatusBadRequest, "label query param is not valid")
+	}
+
+	if err := tah.TranslationUseCase.DeleteAttributeTranslation(c.Request().Context(), c.Param("locale"), label); err != nil {
+		log.Printf("Error trying to delete an attribute translation: %s", err.Error())
+		return c.JSON(http.StatusInternalServerError, "failed to delete the attribute translation")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,211 @@
+package presentation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetProductTranslationsNotFound(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin/products/p1/translations", nil)
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("p1")
+
+	mockTranslationUseCase := new(mocks.MockTranslationUseCase)
+
+	mockTranslationUseCase.On("GetProductTranslations", mock.Anything, "p1").Return(nil, domain.ErrProductNotFound)
+
+	handler := NewTranslationAdminHandler(echo.New(), mockTranslationUseCase, nil, nil, nil)
+
+	handler.GetProductTranslations(c)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestGetProductTranslations(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.GET, "/admin/products/p1/translations", nil)
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid")
+	c.SetParamValues("p1")
+
+	mockTranslationUseCase := new(mocks.MockTranslationUseCase)
+
+	mockTranslationUseCase.On("GetProductTranslations", mock.Anything, "p1").Return([]domain.ProductTranslation{{ProductID: 1, Locale: "es", Name: "Camiseta"}}, nil)
+
+	handler := NewTranslationAdminHandler(echo.New(), mockTranslationUseCase, nil, nil, nil)
+
+	handler.GetProductTranslations(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "[{\"locale\":\"es\",\"name\":\"Camiseta\",\"detail\":\"\"}]\n", rec.Body.String())
+}
+
+func TestSetProductTranslationWrongBody(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/products/p1/translations/es", strings.NewReader("invalidbody"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid", "locale")
+	c.SetParamValues("p1", "es")
+
+	handler := NewTranslationAdminHandler(echo.New(), nil, nil, nil, nil)
+
+	handler.SetProductTranslation(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSetProductTranslationInvalid(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/products/p1/translations/spanish", strings.NewReader("{\"name\":\"Camiseta\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid", "locale")
+	c.SetParamValues("p1", "spanish")
+
+	mockValidator := new(mocks.MockProductTranslationValidator)
+
+	mockValidator.On("Validate", mock.Anything, &domain.ProductTranslation{Locale: "spanish", Name: "Camiseta"}).Return(false, "translation's locale is not valid")
+
+	handler := NewTranslationAdminHandler(echo.New(), nil, mockValidator, nil, nil)
+
+	handler.SetProductTranslation(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "\"translation's locale is not valid\"\n", rec.Body.String())
+}
+
+func TestSetProductTranslation(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/products/p1/translations/es", strings.NewReader("{\"name\":\"Camiseta\",\"detail\":\"Algodón\"}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid", "locale")
+	c.SetParamValues("p1", "es")
+
+	mockTranslationUseCase := new(mocks.MockTranslationUseCase)
+	mockValidator := new(mocks.MockProductTranslationValidator)
+
+	translation := &domain.ProductTranslation{Locale: "es", Name: "Camiseta", Detail: "Algodón"}
+
+	mockValidator.On("Validate", mock.Anything, translation).Return(true, "")
+	mockTranslationUseCase.On("SetProductTranslation", mock.Anything, "p1", translation).Return(nil)
+
+	handler := NewTranslationAdminHandler(echo.New(), mockTranslationUseCase, mockValidator, nil, nil)
+
+	handler.SetProductTranslation(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockTranslationUseCase.AssertExpectations(t)
+}
+
+func TestDeleteProductTranslation(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/admin/products/p1/translations/es", nil)
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("uuid", "locale")
+	c.SetParamValues("p1", "es")
+
+	mockTranslationUseCase := new(mocks.MockTranslationUseCase)
+
+	mockTranslationUseCase.On("DeleteProductTranslation", mock.Anything, "p1", "es").Return(nil)
+
+	handler := NewTranslationAdminHandler(echo.New(), mockTranslationUseCase, nil, nil, nil)
+
+	handler.DeleteProductTranslation(c)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestSetAttributeTranslation(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.PUT, "/admin/attribute-translations/es", strings.NewReader("{\"label\":\"cor\",\"translation\":\"color\",\"values\":{\"vermelho\":\"rojo\"}}"))
+	assert.NoError(t, err)
+	req.Header.Add("content-type", "application/json")
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("locale")
+	c.SetParamValues("es")
+
+	mockTranslationUseCase := new(mocks.MockTranslationUseCase)
+	mockValidator := new(mocks.MockAttributeTranslationValidator)
+
+	translation := &domain.AttributeTranslation{Locale: "es", Label: "cor", Translation: "color", Values: map[string]string{"vermelho": "rojo"}}
+
+	mockValidator.On("Validate", mock.Anything, translation).Return(true, "")
+	mockTranslationUseCase.On("SetAttributeTranslation", mock.Anything, translation).Return(nil)
+
+	handler := NewTranslationAdminHandler(echo.New(), mockTranslationUseCase, nil, mockValidator, nil)
+
+	handler.SetAttributeTranslation(c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "{\"locale\":\"es\",\"label\":\"cor\",\"translation\":\"color\",\"values\":{\"vermelho\":\"rojo\"}}\n", rec.Body.String())
+}
+
+func TestDeleteAttributeTranslationNoLabel(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/admin/attribute-translations/es", nil)
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("locale")
+	c.SetParamValues("es")
+
+	handler := NewTranslationAdminHandler(echo.New(), nil, nil, nil, nil)
+
+	handler.DeleteAttributeTranslation(c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestDeleteAttributeTranslation(t *testing.T) {
+	e := echo.New()
+	req, err := http.NewRequest(echo.DELETE, "/admin/attribute-translations/es?label=cor", nil)
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("locale")
+	c.SetParamValues("es")
+
+	mockTranslationUseCase := new(mocks.MockTranslationUseCase)
+
+	mockTranslationUseCase.On("DeleteAttributeTranslation", mock.Anything, "es", "cor").Return(nil)
+
+	handler := NewTranslationAdminHandler(echo.New(), mockTranslationUseCase, nil, nil, nil)
+
+	handler.DeleteAttributeTranslation(c)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
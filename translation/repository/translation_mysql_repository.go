@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type translationMysqlRepository struct {
+	Conn *sql.DB
+}
+
+func NewTranslationMysqlRepository(conn *sql.DB) domain.TranslationRepository {
+	return &translationMysqlRepository{Conn: conn}
+}
+
+func (tmr *translationMysqlRepository) GetProductTranslations(ctx context.Context, productIDs []int64, locales []string) ([]domain.ProductTranslation, error) {
+	res := []domain.ProductTranslation{}
+
+	if len(productIDs) == 0 {
+		return res, nil
+	}
+
+	query := `SELECT product_id, locale, name, detail FROM product_translation WHERE product_id IN (` + placeholders(len(productIDs)) + `)`
+	args := make([]interface{}, 0, len(productIDs)+len(locales))
+
+	for _, id := range productIDs {
+		args = append(args, id)
+	}
+
+	if locales != nil {
+		query += ` AND locale IN (` + placeholders(len(locales)) + `)`
+
+		for _, l := range locales {
+			args = append(args, l)
+		}
+	}
+
+	rows, err := tmr.Conn.QueryContext(ctx, query+` ORDER BY product_id, locale;`, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var t domain.ProductTranslation
+
+		if err := rows.Scan(&t.ProductID, &t.Locale, &t.Name, &t.Detail); err != nil {
+			return nil, err
+		}
+
+		res = append(res, t)
+	}
+
+	return res, rows.Err()
+}
+
+func (tmr *translationMysqlRepository) StoreProductTranslation(ctx context.Context, t *domain.ProductTranslation) error {
+	query := `INSERT INTO product_translation (product_id, locale, name, detail) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE name = VALUES(name), detail = VALUES(detail);`
+
+	_, err := tmr.Conn.ExecContext(ctx, query, t.ProductID, t.Locale, t.Name, t.Detail)
+
+	return err
+}
+
+func (tmr *translationMysqlRepository) DeleteProductTranslation(ctx context.Context, productID int64, locale string) error {
+	_, err := tmr.Conn.ExecContext(ctx, `DELETE FROM product_translation WHERE product_id = ? AND locale = ?;`, productID, locale)
+
+	return err
+}
+
+func (tmr *translationMysqlRepository) GetAttributeTranslations(ctx context.Context, locales []string, labels []string) ([]domain.AttributeTranslation, error) {
+	res := []domain.AttributeTranslation{}
+
+	if (locales != nil && len(locales) == 0) || (labels != nil && len(labels) == 0) {
+		return res, nil
+	}
+
+	where, args := attributeFilter(locales, labels)
+
+	rows, err := tmr.Conn.QueryContext(ctx, `SELECT locale, label, translation FROM attribute_translation`+where+` ORDER BY locale, label;`, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	index := map[string]int{}
+
+	for rows.Next() {
+		t := domain.AttributeTranslation{Values: map[string]string{}}
+
+		if err := rows.Scan(&t.Locale, &t.Label, &t.Translation); err != nil {
+			return nil, err
+		}
+
+		index[t.Locale+"\x00"+t.Label] = len(res)
+		res = append(res, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return res, nil
+	}
+
+	valueRows, err := tmr.Conn.QueryContext(ctx, `SELECT locale, label, value, translation FROM attribute_value_translation`+where+`;`, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer valueRows.Close()
+
+	for valueRows.Next() {
+		var locale, label, value, translation string
+
+		if err := valueRows.Scan(&locale, &label, &value, &translation); err != nil {
+			return nil, err
+		}
+
+		if i, ok := index[locale+"\x00"+label]; ok {
+			res[i].Values[value] = translation
+		}
+	}
+
+	return res, valueRows.Err()
+}
+
+func attributeFilter(locales []string, labels []string) (string, []interface{}) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	if locales != nil {
+		conditions = append(conditions, `locale IN (`+placeholders(len(locales))+`)`)
+
+		for _, l := range locales {
+			args = append(args, l)
+		}
+	}
+
+	if labels != nil {
+		conditions = append(conditions, `label IN (`+placeholders(len(labels))+`)`)
+
+		for _, l := range labels {
+			args = append(args, l)
+		}
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return ` WHERE ` + strings.Join(conditions, ` AND `), args
+}
+
+// StoreAttributeTranslation replaces the value translations of the label
+// with the given ones.
+func (tmr *translationMysqlRepository) StoreAttributeTranslation(ctx context.Context, t *domain.AttributeTranslation) error {
+	tx, err := tmr.Conn.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	labelQuery := `INSERT INTO attribute_translation (locale, label, translation) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE translation = VALUES(translation);`
+
+	if _, err := tx.ExecContext(ctx, labelQuery, t.Locale, t.Label, t.Translation); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM attribute_value_translation WHERE locale = ? AND label = ?;`, t.Locale, t.Label); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if len(t.Values) > 0 {
+		values := make([]string, 0, len(t.Values))
+		for v := range t.Values {
+			values = append(values, v)
+		}
+
+		sort.Strings(values)
+
+		args := make([]interface{}, 0, len(values)*4)
+		for _, v := range values {
+			args = append(args, t.Locale, t.Label, v, t.Values[v])
+		}
+
+		valuesQuery := `INSERT INTO attribute_value_translation (locale, label, value, translation) VALUES ` + strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?), ", len(values)), ", ") + `;`
+
+		if _, err := tx.ExecContext(ctx, valuesQuery, args...); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// DeleteAttributeTranslation also deletes the value translations of the
+// label, through the foreign key.
+func (tmr *translationMysqlRepository) DeleteAttributeTranslation(ctx context.Context, locale string, label string) error {
+	_, err := tmr.Conn.ExecContext(ctx, `DELETE FROM attribute_translation WHERE locale = ? AND label = ?;`, locale, label)
+
+	return err
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProductTranslations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"product_id", "locale", "name", "detail"}).
+		AddRow(1, "es", "Camiseta", "").
+		AddRow(2, "pt-PT", "T-shirt", "Algodão")
+
+	query := "SELECT product_id, locale, name, detail FROM product_translation WHERE product_id IN (?, ?) AND locale IN (?, ?) ORDER BY product_id, locale;"
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1, 2, "pt-PT", "es").WillReturnRows(rows)
+
+	res, err := NewTranslationMysqlRepository(db).GetProductTranslations(context.Background(), []int64{1, 2}, []string{"pt-PT", "es"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.ProductTranslation{
+		{ProductID: 1, Locale: "es", Name: "Camiseta"},
+		{ProductID: 2, Locale: "pt-PT", Name: "T-shirt", Detail: "Algodão"},
+	}, res)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetProductTranslationsAllLocales(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"product_id", "locale", "name", "detail"})
+
+	query := "SELECT product_id, locale, name, detail FROM product_translation WHERE product_id IN (?) ORDER BY product_id, locale;"
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1).WillReturnRows(rows)
+
+	res, err := NewTranslationMysqlRepository(db).GetProductTranslations(context.Background(), []int64{1}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.ProductTranslation{}, res)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreProductTranslation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	query := "INSERT INTO product_translation (product_id, locale, name, detail) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE name = VALUES(name), detail = VALUES(detail);"
+	mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(1, "es", "Camiseta", "Algodón").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewTranslationMysqlRepository(db).StoreProductTranslation(context.Background(), &domain.ProductTranslation{ProductID: 1, Locale: "es", Name: "Camiseta", Detail: "Algodón"})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDeleteProductTranslation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product_translation WHERE product_id = ? AND locale = ?;")).WithArgs(1, "es").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewTranslationMysqlRepository(db).DeleteProductTranslation(context.Background(), 1, "es")
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetAttributeTranslations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	labelRows := sqlmock.NewRows([]string{"locale", "label", "translation"}).
+		AddRow("es", "cor", "color").
+		AddRow("es", "tamanho", "talla")
+
+	valueRows := sqlmock.NewRows([]string{"locale", "label", "value", "translation"}).
+		AddRow("es", "cor", "azul", "azul").
+		AddRow("es", "cor", "vermelho", "rojo")
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT locale, label, translation FROM attribute_translation WHERE locale IN (?) AND label IN (?, ?) ORDER BY locale, label;")).
+		WithArgs("es", "cor", "tamanho").WillReturnRows(labelRows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT locale, label, value, translation FROM attribute_value_translation WHERE locale IN (?) AND label IN (?, ?);")).
+		WithArgs("es", "cor", "tamanho").WillReturnRows(valueRows)
+
+	res, err := NewTranslationMysqlRepository(db).GetAttributeTranslations(context.Background(), []string{"es"}, []string{"cor", "tamanho"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.AttributeTranslation{
+		{Locale: "es", Label: "cor", Translation: "color", Values: map[string]string{"azul": "azul", "vermelho": "rojo"}},
+		{Locale: "es", Label: "tamanho", Translation: "talla", Values: map[string]string{}},
+	}, res)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetAttributeTranslationsNoLabels(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	res, err := NewTranslationMysqlRepository(db).GetAttributeTranslations(context.Background(), []string{"es"}, []string{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.AttributeTranslation{}, res)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreAttributeTranslation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO attribute_translation (locale, label, translation) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE translation = VALUES(translation);")).
+		WithArgs("es", "cor", "color").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM attribute_value_translation WHERE locale = ? AND label = ?;")).
+		WithArgs("es", "cor").WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO attribute_value_translation (locale, label, value, translation) VALUES (?, ?, ?, ?), (?, ?, ?, ?);")).
+		WithArgs("es", "cor", "azul", "azul", "es", "cor", "vermelho", "rojo").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	err = NewTranslationMysqlRepository(db).StoreAttributeTranslation(context.Background(), &domain.AttributeTranslation{
+		Locale: "es", Label: "cor", Translation: "color", Values: map[string]string{"vermelho": "rojo", "azul": "azul"},
+	})
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreAttributeTranslationError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO attribute_translation (locale, label, translation) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE translation = VALUES(translation);")).
+		WithArgs("es", "cor", "color").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM attribute_value_translation WHERE locale = ? AND label = ?;")).
+		WithArgs("es", "cor").WillReturnError(errors.New("connection lost"))
+	mock.ExpectRollback()
+
+	err = NewTranslationMysqlRepository(db).StoreAttributeTranslation(context.Background(), &domain.AttributeTranslation{
+		Locale: "es", Label: "cor", Translation: "color",
+	})
+
+	assert.EqualError(t, err, "connection lost")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDeleteAttributeTranslation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM attribute_translation WHERE locale = ? AND label = ?;")).WithArgs("es", "cor").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewTranslationMysqlRepository(db).DeleteAttributeTranslation(context.Background(), "es", "cor")
+
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type translationUseCase struct {
+	productRepo     domain.ProductRepository
+	translationRepo domain.TranslationRepository
+}
+
+func NewTranslationUseCase(pr domain.ProductRepository, tr domain.TranslationRepository) domain.TranslationUseCase {
+	return &translationUseCase{productRepo: pr, translationRepo: tr}
+}
+
+func (tu *translationUseCase) GetProductTranslations(ctx context.Context, productUUID string) ([]domain.ProductTranslation, error) {
+	product, err := tu.product(ctx, productUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tu.translationRepo.GetProductTranslations(ctx, []int64{product.ID}, nil)
+}
+
+func (tu *translationUseCase) SetProductTranslation(ctx context.Context, productUUID string, t *domain.ProductTranslation) error {
+	product, err := tu.product(ctx, productUUID)
+
+	if err != nil {
+		return err
+	}
+
+	t.ProductID = product.ID
+
+	if err := tu.translationRepo.StoreProductTranslation(ctx, t); err != nil {
+		return err
+	}
+
+	tu.touch(ctx, []int64{product.ID})
+
+	return nil
+}
+
+func (tu *translationUseCase) DeleteProductTranslation(ctx context.Context, productUUID string, locale string) error {
+	product, err := tu.product(ctx, productUUID)
+
+	if err != nil {
+		return err
+	}
+
+	if err := tu.translationRepo.DeleteProductTranslation(ctx, product.ID, locale); err != nil {
+		return err
+	}
+
+	tu.touch(ctx, []int64{product.ID})
+
+	return nil
+}
+
+func (tu *translationUseCase) product(ctx context.Context, productUUID string) (*domain.Product, error) {
+	product, err := tu.productRepo.GetByUUID(ctx, productUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil {
+		return nil, domain.ErrProductNotFound
+	}
+
+	return product, nil
+}
+
+func (tu *translationUseCase) GetAttributeTranslations(ctx context.Context, locale string) ([]domain.AttributeTranslation, error) {
+	return tu.translationRepo.GetAttributeTranslations(ctx, []string{locale}, nil)
+}
+
+func (tu *translationUseCase) SetAttributeTranslation(ctx context.Context, t *domain.AttributeTranslation) error {
+	if err := tu.translationRepo.StoreAttributeTranslation(ctx, t); err != nil {
+		return err
+	}
+
+	tu.touchAttribute(ctx, t.Label)
+
+	return nil
+}
+
+func (tu *translationUseCase) DeleteAttributeTranslation(ctx context.Context, locale string, label string) error {
+	if err := tu.translationRepo.DeleteAttributeTranslation(ctx, locale, label); err != nil {
+		return err
+	}
+
+	tu.touchAttribute(ctx, label)
+
+	return nil
+}
+
+// touchAttribute touches the products that have the attribute, its
+// translation is shown with them.
+func (tu *translationUseCase) touchAttribute(ctx context.Context, label string) {
+	productIDs, err := tu.productRepo.GetIDsByAttribute(ctx, label)
+
+	if err != nil {
+		log.Printf("Error trying to get the products of the attribute %s: %s", label, err.Error())
+		return
+	}
+
+	tu.touch(ctx, productIDs)
+}
+
+// touch drops the cached copies of the products, the translation is already
+// written so an error is only logged.
+func (tu *translationUseCase) touch(ctx context.Context, productIDs []int64) {
+	if err := tu.productRepo.Touch(ctx, productIDs); err != nil {
+		log.Printf("Error trying to touch the products of a translation: %s", err.Error())
+	}
+}
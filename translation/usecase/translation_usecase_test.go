@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetProductTranslations(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockTranslationRepo := new(mocks.MockTranslationRepository)
+
+	translations := []domain.ProductTranslation{{ProductID: 1, Locale: "es", Name: "Camiseta"}}
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "p1").Return(&domain.Product{ID: 1, UUID: "p1"}, nil)
+	mockTranslationRepo.On("GetProductTranslations", mock.Anything, []int64{1}, []string(nil)).Return(translations, nil)
+
+	res, err := NewTranslationUseCase(mockProductRepo, mockTranslationRepo).GetProductTranslations(context.Background(), "p1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, translations, res)
+}
+
+func TestSetProductTranslation(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockTranslationRepo := new(mocks.MockTranslationRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "p1").Return(&domain.Product{ID: 1, UUID: "p1", Status: domain.ProductStatusDraft}, nil)
+	mockTranslationRepo.On("StoreProductTranslation", mock.Anything, &domain.ProductTranslation{ProductID: 1, Locale: "es", Name: "Camiseta"}).Return(nil)
+	mockProductRepo.On("Touch", mock.Anything, []int64{1}).Return(nil)
+
+	err := NewTranslationUseCase(mockProductRepo, mockTranslationRepo).SetProductTranslation(context.Background(), "p1", &domain.ProductTranslation{Locale: "es", Name: "Camiseta"})
+
+	assert.NoError(t, err)
+	mockTranslationRepo.AssertExpectations(t)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestSetProductTranslationProductNotFound(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockTranslationRepo := new(mocks.MockTranslationRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "p1").Return(nil, nil)
+
+	err := NewTranslationUseCase(mockProductRepo, mockTranslationRepo).SetProductTranslation(context.Background(), "p1", &domain.ProductTranslation{Locale: "es", Name: "Camiseta"})
+
+	assert.ErrorIs(t, err, domain.ErrProductNotFound)
+	mockTranslationRepo.AssertNotCalled(t, "StoreProductTranslation", mock.Anything, mock.Anything)
+}
+
+func TestDeleteProductTranslation(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockTranslationRepo := new(mocks.MockTranslationRepository)
+
+	mockProductRepo.On("GetByUUID", mock.Anything, "p1").Return(&domain.Product{ID: 1, UUID: "p1"}, nil)
+	mockTranslationRepo.On("DeleteProductTranslation", mock.Anything, int64(1), "es").Return(nil)
+	mockProductRepo.On("Touch", mock.Anything, []int64{1}).Return(nil)
+
+	err := NewTranslationUseCase(mockProductRepo, mockTranslationRepo).DeleteProductTranslation(context.Background(), "p1", "es")
+
+	assert.NoError(t, err)
+	mockTranslationRepo.AssertExpectations(t)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestGetAttributeTranslations(t *testing.T) {
+	mockTranslationRepo := new(mocks.MockTranslationRepository)
+
+	translations := []domain.AttributeTranslation{{Locale: "es", Label: "cor", Translation: "color", Values: map[string]string{}}}
+
+	mockTranslationRepo.On("GetAttributeTranslations", mock.Anything, []string{"es"}, []string(nil)).Return(translations, nil)
+
+	res, err := NewTranslationUseCase(new(mocks.MockProductRepository), mockTranslationRepo).GetAttributeTranslations(context.Background(), "es")
+
+	assert.NoError(t, err)
+	assert.Equal(t, translations, res)
+}
+
+func TestSetAttributeTranslation(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockTranslationRepo := new(mocks.MockTranslationRepository)
+
+	translation := &domain.AttributeTranslation{Locale: "es", Label: "cor", Translation: "color", Values: map[string]string{}}
+
+	mockTranslationRepo.On("StoreAttributeTranslation", mock.Anything, translation).Return(nil)
+	mockProductRepo.On("GetIDsByAttribute", mock.Anything, "cor").Return([]int64{1, 4}, nil)
+	mockProductRepo.On("Touch", mock.Anything, []int64{1, 4}).Return(nil)
+
+	err := NewTranslationUseCase(mockProductRepo, mockTranslationRepo).SetAttributeTranslation(context.Background(), translation)
+
+	assert.NoError(t, err)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestDeleteAttributeTranslationProductsError(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockTranslationRepo := new(mocks.MockTranslationRepository)
+
+	mockTranslationRepo.On("DeleteAttributeTranslation", mock.Anything, "es", "cor").Return(nil)
+	mockProductRepo.On("GetIDsByAttribute", mock.Anything, "cor").Return(nil, errors.New("error message"))
+
+	err := NewTranslationUseCase(mockProductRepo, mockTranslationRepo).DeleteAttributeTranslation(context.Background(), "es", "cor")
+
+	// the translation is deleted, the products not touched are only logged
+	assert.NoError(t, err)
+	mockProductRepo.AssertNotCalled(t, "Touch", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,41 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+type attributeTranslationValidator struct{}
+
+func NewAttributeTranslationValidator() *attributeTranslationValidator {
+	return &attributeTranslationValidator{}
+}
+
+func (atv *attributeTranslationValidator) Validate(ctx context.Context, t *domain.AttributeTranslation) (domain.IsValid, domain.Message) {
+	if !localeRegexp.MatchString(t.Locale) {
+		return false, "translation's locale is not valid"
+	}
+
+	if strings.TrimSpace(t.Label) == "" || utf8.RuneCountInString(t.Label) > 100 {
+		return false, "translation's label must have between 1 and 100 characters"
+	}
+
+	if strings.TrimSpace(t.Translation) == "" || utf8.RuneCountInString(t.Translation) > 100 {
+		return false, "translation's translation must have between 1 and 100 characters"
+	}
+
+	for value, translation := range t.Values {
+		if strings.TrimSpace(value) == "" || utf8.RuneCountInString(value) > 100 {
+			return false, "translation's values must have between 1 and 100 characters"
+		}
+
+		if strings.TrimSpace(translation) == "" || utf8.RuneCountInString(translation) > 100 {
+			return false, "translation's value translations must have between 1 and 100 characters"
+		}
+	}
+
+	return true, ""
+}
@@ -0,0 +1,35 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAttributeTranslationInvalid(t *testing.T) {
+	for _, tr := range []domain.AttributeTranslation{
+		{Locale: "spanish", Label: "cor", Translation: "color"},
+		{Locale: "es", Label: "", Translation: "color"},
+		{Locale: "es", Label: "cor", Translation: " "},
+		{Locale: "es", Label: "cor", Translation: strings.Repeat("a", 101)},
+		{Locale: "es", Label: "cor", Translation: "color", Values: map[string]string{"": "rojo"}},
+		{Locale: "es", Label: "cor", Translation: "color", Values: map[string]string{"vermelho": ""}},
+	} {
+		isValid, message := NewAttributeTranslationValidator().Validate(context.Background(), &tr)
+
+		assert.False(t, bool(isValid))
+		assert.NotEmpty(t, message)
+	}
+}
+
+func TestValidateAttributeTranslation(t *testing.T) {
+	isValid, message := NewAttributeTranslationValidator().Validate(context.Background(), &domain.AttributeTranslation{
+		Locale: "es", Label: "cor", Translation: "color", Values: map[string]string{"vermelho": "rojo"},
+	})
+
+	assert.True(t, bool(isValid))
+	assert.Empty(t, message)
+}
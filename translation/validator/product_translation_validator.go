@@ -0,0 +1,40 @@
+package validator
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+)
+
+// localeRegexp takes a language with an optional country or UN M.49 region,
+// as in "pt", "pt-BR" and "es-419".
+var localeRegexp = regexp.MustCompile(`^[a-z]{2,3}(-([A-Z]{2}|[0-9]{3}))?$`)
+
+type productTranslationValidator struct{}
+
+func NewProductTranslationValidator() *productTranslationValidator {
+	return &productTranslationValidator{}
+}
+
+func (ptv *productTranslationValidator) Validate(ctx context.Context, t *domain.ProductTranslation) (domain.IsValid, domain.Message) {
+	if !localeRegexp.MatchString(t.Locale) {
+		return false, "translation's locale is not valid"
+	}
+
+	if strings.TrimSpace(t.Name) == "" && strings.TrimSpace(t.Detail) == "" {
+		return false, "translation's name and detail can not both be empty"
+	}
+
+	if utf8.RuneCountInString(t.Name) > 150 {
+		return false, "translation's name can not have more than 150 characters"
+	}
+
+	if utf8.RuneCountInString(t.Detail) > 250 {
+		return false, "translation's detail can not have more than 250 characters"
+	}
+
+	return true, ""
+}
@@ -0,0 +1,39 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/giovanisilqueirasantos/e-commerce-go-clean-arch/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateProductTranslationInvalid(t *testing.T) {
+	for _, tr := range []domain.ProductTranslation{
+		{Locale: "", Name: "Camiseta"},
+		{Locale: "es_AR", Name: "Camiseta"},
+		{Locale: "pt-br", Name: "Camiseta"},
+		{Locale: "es", Name: " ", Detail: ""},
+		{Locale: "es", Name: strings.Repeat("a", 151)},
+		{Locale: "es", Name: "Camiseta", Detail: strings.Repeat("a", 251)},
+	} {
+		isValid, message := NewProductTranslationValidator().Validate(context.Background(), &tr)
+
+		assert.False(t, bool(isValid))
+		assert.NotEmpty(t, message)
+	}
+}
+
+func TestValidateProductTranslation(t *testing.T) {
+	for _, tr := range []domain.ProductTranslation{
+		{Locale: "es", Name: "Camiseta"},
+		{Locale: "pt-PT", Detail: "Algodão"},
+		{Locale: "es-419", Name: "Camiseta", Detail: "Algodón"},
+	} {
+		isValid, message := NewProductTranslationValidator().Validate(context.Background(), &tr)
+
+		assert.True(t, bool(isValid))
+		assert.Empty(t, message)
+	}
+}
@@ -32,3 +32,39 @@ func (r *userMysqlRepository) GetByEmail(ctx context.Context, email string) (*do
 
 	return &res, nil
 }
+
+func (r *userMysqlRepository) GetByUUID(ctx context.Context, uuid string) (*domain.User, error) {
+	query := `SELECT id, uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode FROM users WHERE uuid = ?;`
+
+	row := r.Conn.QueryRowContext(ctx, query, uuid)
+
+	var res domain.User
+
+	if err := row.Scan(&res.ID, &res.UUID, &res.Email, &res.FirstName, &res.LastName, &res.PhoneNumber, &res.Address.City, &res.Address.State, &res.Address.Neighborhood, &res.Address.Street, &res.Address.Number, &res.Address.ZipCode); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *userMysqlRepository) GetByID(ctx context.Context, id int64) (*domain.User, error) {
+	query := `SELECT id, uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode FROM users WHERE id = ?;`
+
+	row := r.Conn.QueryRowContext(ctx, query, id)
+
+	var res domain.User
+
+	if err := row.Scan(&res.ID, &res.UUID, &res.Email, &res.FirstName, &res.LastName, &res.PhoneNumber, &res.Address.City, &res.Address.State, &res.Address.Neighborhood, &res.Address.Street, &res.Address.Number, &res.Address.ZipCode); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
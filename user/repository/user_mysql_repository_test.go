@@ -19,7 +19,7 @@ func TestGetByEmailNotFound(t *testing.T) {
 
 	rows := sqlmock.NewRows([]string{"id", "uuid", "email", "first_name", "last_name", "phone_number", "address_city", "address_state", "address_neighborhood", "address_street", "address_number", "address_zipcode"})
 
-	query := regexp.QuoteMeta("SELECT id, uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode FROM user WHERE email = ?;")
+	query := regexp.QuoteMeta("SELECT id, uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode FROM users WHERE email = ?;")
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
@@ -42,7 +42,7 @@ func TestGetByLoginError(t *testing.T) {
 		t.Fatalf("error when opening a stub database conn %s", err)
 	}
 
-	query := regexp.QuoteMeta("SELECT id, uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode FROM user WHERE email = ?;")
+	query := regexp.QuoteMeta("SELECT id, uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode FROM users WHERE email = ?;")
 
 	mock.ExpectQuery(query).WillReturnError(errors.New("error message"))
 
@@ -66,7 +66,7 @@ func TestGetByLogin(t *testing.T) {
 
 	rows := sqlmock.NewRows([]string{"id", "uuid", "email", "first_name", "last_name", "phone_number", "address_city", "address_state", "address_neighborhood", "address_street", "address_number", "address_zipcode"}).AddRow(1, "uuid", "email", "first_name", "last_name", "phone_number", "address_city", "address_state", "address_neighborhood", "address_street", "address_number", "address_zipcode")
 
-	query := regexp.QuoteMeta("SELECT id, uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode FROM user WHERE email = ?;")
+	query := regexp.QuoteMeta("SELECT id, uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode FROM users WHERE email = ?;")
 
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
@@ -92,3 +92,81 @@ func TestGetByLogin(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestGetByUUIDNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "uuid", "email", "first_name", "last_name", "phone_number", "address_city", "address_state", "address_neighborhood", "address_street", "address_number", "address_zipcode"})
+
+	query := regexp.QuoteMeta("SELECT id, uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode FROM users WHERE uuid = ?;")
+
+	mock.ExpectQuery(query).WillReturnRows(rows)
+
+	userMysqlRepository := NewUserMysqlRepository(db)
+
+	user, err := userMysqlRepository.GetByUUID(context.Background(), "uuid")
+
+	assert.NoError(t, err)
+	assert.Nil(t, user)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetByUUID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "uuid", "email", "first_name", "last_name", "phone_number", "address_city", "address_state", "address_neighborhood", "address_street", "address_number", "address_zipcode"}).AddRow(1, "uuid", "email", "first_name", "last_name", "phone_number", "address_city", "address_state", "address_neighborhood", "address_street", "address_number", "address_zipcode")
+
+	query := regexp.QuoteMeta("SELECT id, uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode FROM users WHERE uuid = ?;")
+
+	mock.ExpectQuery(query).WillReturnRows(rows)
+
+	userMysqlRepository := NewUserMysqlRepository(db)
+
+	user, err := userMysqlRepository.GetByUUID(context.Background(), "uuid")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), user.ID)
+	assert.Equal(t, "uuid", user.UUID)
+	assert.Equal(t, "email", user.Email)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		t.Fatalf("error when opening a stub database conn %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "uuid", "email", "first_name", "last_name", "phone_number", "address_city", "address_state", "address_neighborhood", "address_street", "address_number", "address_zipcode"}).AddRow(1, "uuid", "email", "first_name", "last_name", "phone_number", "address_city", "address_state", "address_neighborhood", "address_street", "address_number", "address_zipcode")
+
+	query := regexp.QuoteMeta("SELECT id, uuid, email, first_name, last_name, phone_number, address_city, address_state, address_neighborhood, address_street, address_number, address_zipcode FROM users WHERE id = ?;")
+
+	mock.ExpectQuery(query).WithArgs(1).WillReturnRows(rows)
+
+	userMysqlRepository := NewUserMysqlRepository(db)
+
+	user, err := userMysqlRepository.GetByID(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), user.ID)
+	assert.Equal(t, "uuid", user.UUID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}